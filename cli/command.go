@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -16,10 +17,28 @@ type CommandOptions struct {
 	ConfigFile string
 	Verbose    bool
 	JSONOutput bool
+	YAMLOutput bool
+	Quiet      bool
+	NoColor    bool
+}
+
+// OutputFormat returns the format selected by --json or --yaml; --json wins
+// when both are given.
+func (o CommandOptions) OutputFormat() OutputFormat {
+	switch {
+	case o.JSONOutput:
+		return FormatJSON
+	case o.YAMLOutput:
+		return FormatYAML
+	default:
+		return FormatText
+	}
 }
 
 // NewStandardCommand creates a new command with standard Grove flags.
-// After adding all subcommands, call Execute(cmd) to run with styled help.
+// After adding all subcommands, call Execute(cmd) to run with styled help
+// and the flag defaults configured under cli.defaults (see
+// DefaultsExtension).
 func NewStandardCommand(use, short string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   use,
@@ -29,7 +48,12 @@ func NewStandardCommand(use, short string) *cobra.Command {
 	// Standard flags for all Grove tools
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
 	cmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	cmd.PersistentFlags().Bool("yaml", false, "Output in YAML format")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Only print results and errors")
+	cmd.PersistentFlags().Var(&noColorFlag{}, "no-color", "Disable colored output")
+	cmd.PersistentFlags().Lookup("no-color").NoOptDefVal = "true"
 	cmd.PersistentFlags().StringP("config", "c", "", "Path to grove.yml config file")
+	cmd.PersistentFlags().Var(&setFlag{}, "set", "Override a config value for this invocation (e.g. --set logging.level=debug); repeatable")
 
 	return cmd
 }
@@ -41,8 +65,13 @@ func Execute(cmd *cobra.Command) error {
 
 	// Silence cobra's default error printing so we can style it
 	cmd.SilenceErrors = true
+	applySetOverrides(os.Args[1:])
+	applyConfigDefaults(cmd, os.Args[1:])
 
-	err := cmd.Execute()
+	start := time.Now()
+	executed, err := cmd.ExecuteC()
+	recordUsage(executed, start, err)
+	logging.Flush()
 	if err != nil {
 		// Find the actual command that was targeted
 		targetCmd, _, _ := cmd.Find(os.Args[1:])
@@ -62,8 +91,13 @@ func ExecuteContext(ctx context.Context, cmd *cobra.Command) error {
 
 	// Silence cobra's default error printing so we can style it
 	cmd.SilenceErrors = true
+	applySetOverrides(os.Args[1:])
+	applyConfigDefaults(cmd, os.Args[1:])
 
-	err := cmd.ExecuteContext(ctx)
+	start := time.Now()
+	executed, err := cmd.ExecuteContextC(ctx)
+	recordUsage(executed, start, err)
+	logging.Flush()
 	if err != nil {
 		// Find the actual command that was targeted
 		targetCmd, _, _ := cmd.Find(os.Args[1:])
@@ -84,13 +118,15 @@ func GetLogger(cmd *cobra.Command) *logrus.Logger {
 	logger := entry.Logger
 
 	verbose, _ := cmd.Flags().GetBool("verbose")
+	quiet, _ := cmd.Flags().GetBool("quiet")
 	if verbose {
 		logger.SetLevel(logrus.DebugLevel)
+	} else if quiet {
+		logger.SetLevel(logrus.ErrorLevel)
 	}
 
-	jsonOutput, _ := cmd.Flags().GetBool("json")
-	if jsonOutput {
-		logger.SetFormatter(&logrus.JSONFormatter{})
+	if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+		logging.SetConsoleJSON(logger)
 	}
 
 	return logger
@@ -101,11 +137,20 @@ func GetOptions(cmd *cobra.Command) CommandOptions {
 	configFile, _ := cmd.Flags().GetString("config")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	jsonOutput, _ := cmd.Flags().GetBool("json")
+	yamlOutput, _ := cmd.Flags().GetBool("yaml")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	noColor := os.Getenv("NO_COLOR") != ""
+	if f := cmd.Flags().Lookup("no-color"); f != nil && f.Changed {
+		noColor = f.Value.String() == "true"
+	}
 
 	return CommandOptions{
 		ConfigFile: configFile,
 		Verbose:    verbose,
 		JSONOutput: jsonOutput,
+		YAMLOutput: yamlOutput,
+		Quiet:      quiet,
+		NoColor:    noColor,
 	}
 }
 
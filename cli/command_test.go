@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/logging"
+)
+
+func TestGetLoggerJSON(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("GROVE_HOME", home)
+	var stderr bytes.Buffer
+	logging.SetGlobalOutput(&stderr)
+	logging.Reset()
+	t.Cleanup(func() {
+		logging.SetGlobalOutput(os.Stderr)
+		logging.Reset()
+	})
+
+	cmd := NewStandardCommand("core", "test")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		GetLogger(cmd).WithField("path", "/tmp/x").Info("Scanned workspace")
+		return nil
+	}
+	cmd.SetArgs([]string{"--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		t.Fatal("no log output on stderr")
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("stderr line is not JSON: %q: %v", line, err)
+		}
+	}
+	var last map[string]interface{}
+	_ = json.Unmarshal([]byte(lines[len(lines)-1]), &last)
+	if last["msg"] != "Scanned workspace" || last["path"] != "/tmp/x" {
+		t.Errorf("last entry = %v", last)
+	}
+}
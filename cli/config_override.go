@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/grovetools/core/config"
+)
+
+// setFlag is the pflag.Value behind --set. Each occurrence is recorded as a
+// config override the moment it is parsed, so the layer is in place before
+// any command code loads configuration.
+type setFlag struct {
+	values []string
+}
+
+func (f *setFlag) String() string {
+	return strings.Join(f.values, ",")
+}
+
+func (f *setFlag) Set(value string) error {
+	if err := config.AddCommandLineOverride(value); err != nil {
+		return err
+	}
+	f.values = append(f.values, value)
+	return nil
+}
+
+func (f *setFlag) Type() string {
+	return "key=value"
+}
+
+// applySetOverrides records the --set assignments in args ahead of flag
+// parsing, so the config that cli.defaults are read from already includes
+// them. Cobra records them again when it parses the flags, which changes
+// nothing; malformed ones are left for it to report.
+func applySetOverrides(args []string) {
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--":
+			return
+		case arg == "--set" && i+1 < len(args):
+			i++
+			_ = config.AddCommandLineOverride(args[i])
+		case strings.HasPrefix(arg, "--set="):
+			_ = config.AddCommandLineOverride(strings.TrimPrefix(arg, "--set="))
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/grovetools/core/config"
+)
+
+// DefaultsExtension is the `cli` extension block of grove.yml. Defaults maps
+// a command path to flag values used when the flag is not given on the
+// command line:
+//
+//	cli:
+//	  defaults:
+//	    logs:
+//	      follow: true
+//	      interactive: true
+//	    core sessions list:
+//	      by-repo: true
+//
+// A path is the command's subcommands separated by spaces, with or without
+// the tool name in front; the form with the tool name wins, so tools sharing
+// a config can be told apart.
+type DefaultsExtension struct {
+	Defaults map[string]map[string]interface{} `yaml:"defaults"`
+}
+
+// applyConfigDefaults finds the command args will run under root and
+// applies the cli.defaults for it from the merged config. Problems are
+// reported on stderr and never stop the command.
+func applyConfigDefaults(root *cobra.Command, args []string) {
+	target, _, err := root.Find(args)
+	if err != nil || target == nil || strings.HasPrefix(target.Name(), "__") {
+		return
+	}
+	cfg, err := config.LoadDefault()
+	if err != nil || cfg == nil {
+		return
+	}
+	var ext DefaultsExtension
+	if err := cfg.UnmarshalExtension("cli", &ext); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return
+	}
+	ApplyFlagDefaults(target, ext.Defaults, os.Stderr)
+}
+
+// ApplyFlagDefaults sets the default of each of cmd's flags named in
+// defaults under cmd's path (see DefaultsExtension), before the command
+// line is parsed: flags given on the command line still win, and help shows
+// the configured default. Lists set every element of a slice flag. Unknown
+// flags and values the flag rejects are reported on warn and skipped.
+func ApplyFlagDefaults(cmd *cobra.Command, defaults map[string]map[string]interface{}, warn io.Writer) {
+	full := cmd.CommandPath()
+	relative := strings.TrimPrefix(strings.TrimPrefix(full, cmd.Root().Name()), " ")
+	for _, path := range []string{relative, full} {
+		flags, ok := defaults[path]
+		if !ok || path == "" {
+			continue
+		}
+		names := make([]string, 0, len(flags))
+		for name := range flags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := setFlagDefault(cmd, name, flags[name]); err != nil {
+				fmt.Fprintf(warn, "warning: cli.defaults.%s: %v\n", path, err)
+			}
+		}
+	}
+}
+
+// setFlagDefault sets the value and displayed default of cmd's flag name,
+// local or inherited, to value.
+func setFlagDefault(cmd *cobra.Command, name string, value interface{}) error {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.InheritedFlags().Lookup(name)
+	}
+	if flag == nil {
+		return fmt.Errorf("unknown flag --%s", name)
+	}
+
+	var values []string
+	if list, ok := value.([]interface{}); ok {
+		for _, v := range list {
+			values = append(values, fmt.Sprint(v))
+		}
+	} else {
+		values = []string{fmt.Sprint(value)}
+	}
+
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		prev := slice.GetSlice()
+		if err := slice.Replace(values); err != nil {
+			_ = slice.Replace(prev)
+			return fmt.Errorf("invalid value for --%s: %w", name, err)
+		}
+	} else {
+		if len(values) != 1 {
+			return fmt.Errorf("--%s takes a single value", name)
+		}
+		// Set may clobber the value before rejecting the input, so restore
+		// the built-in default on error.
+		prev := flag.Value.String()
+		if err := flag.Value.Set(values[0]); err != nil {
+			_ = flag.Value.Set(prev)
+			return fmt.Errorf("invalid value for --%s: %w", name, err)
+		}
+	}
+	flag.DefValue = flag.Value.String()
+	return nil
+}
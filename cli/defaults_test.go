@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/config"
+)
+
+// newDefaultsTestTree builds `core logs` and `core sessions list` with a few
+// flags of different types.
+func newDefaultsTestTree() (root, logs, list *cobra.Command) {
+	root = NewStandardCommand("core", "test")
+	logs = &cobra.Command{Use: "logs", RunE: func(*cobra.Command, []string) error { return nil }}
+	logs.Flags().BoolP("follow", "f", false, "")
+	logs.Flags().Bool("interactive", false, "")
+	logs.Flags().Int("lines", 10, "")
+	logs.Flags().StringSlice("component", nil, "")
+	sessions := &cobra.Command{Use: "sessions"}
+	list = &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+	list.Flags().Bool("by-repo", false, "")
+	sessions.AddCommand(list)
+	root.AddCommand(logs, sessions)
+	return root, logs, list
+}
+
+func TestApplyFlagDefaults(t *testing.T) {
+	root, logs, _ := newDefaultsTestTree()
+	var warn bytes.Buffer
+	ApplyFlagDefaults(logs, map[string]map[string]interface{}{
+		"logs": {
+			"follow":      true,
+			"lines":       50,
+			"component":   []interface{}{"daemon", "flow"},
+			"verbose":     true,
+			"nonexistent": 1,
+		},
+	}, &warn)
+
+	root.SetArgs([]string{"logs", "--lines", "5"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if follow, _ := logs.Flags().GetBool("follow"); !follow {
+		t.Error("expected --follow from the config default")
+	}
+	if lines, _ := logs.Flags().GetInt("lines"); lines != 5 {
+		t.Errorf("expected the command line to win, got --lines %d", lines)
+	}
+	if c, _ := logs.Flags().GetStringSlice("component"); strings.Join(c, ",") != "daemon,flow" {
+		t.Errorf("unexpected --component %v", c)
+	}
+	if v, _ := logs.Flags().GetBool("verbose"); !v {
+		t.Error("expected the inherited --verbose to take its config default")
+	}
+	if logs.Flags().Changed("follow") {
+		t.Error("a config default must not count as set on the command line")
+	}
+	if got := logs.Flags().Lookup("follow").DefValue; got != "true" {
+		t.Errorf("expected help to show the configured default, got %q", got)
+	}
+	if !strings.Contains(warn.String(), "cli.defaults.logs: unknown flag --nonexistent") {
+		t.Errorf("expected a warning for the unknown flag, got %q", warn.String())
+	}
+}
+
+func TestApplyFlagDefaultsCommandPaths(t *testing.T) {
+	_, _, list := newDefaultsTestTree()
+	var warn bytes.Buffer
+	ApplyFlagDefaults(list, map[string]map[string]interface{}{
+		"sessions list":      {"by-repo": false},
+		"core sessions list": {"by-repo": true},
+		"list":               {"by-repo": false},
+		"flow sessions list": {"by-repo": false},
+	}, &warn)
+	if byRepo, _ := list.Flags().GetBool("by-repo"); !byRepo {
+		t.Error("expected the path with the tool name to win")
+	}
+	if warn.Len() != 0 {
+		t.Errorf("unexpected warnings: %q", warn.String())
+	}
+}
+
+func TestApplyFlagDefaultsInvalidValue(t *testing.T) {
+	_, logs, _ := newDefaultsTestTree()
+	var warn bytes.Buffer
+	ApplyFlagDefaults(logs, map[string]map[string]interface{}{
+		"logs": {"lines": "many", "follow": []interface{}{true, false}},
+	}, &warn)
+	if lines, _ := logs.Flags().GetInt("lines"); lines != 10 {
+		t.Errorf("expected the built-in default to stay, got %d", lines)
+	}
+	for _, want := range []string{"invalid value for --lines", "--follow takes a single value"} {
+		if !strings.Contains(warn.String(), want) {
+			t.Errorf("expected warning %q, got %q", want, warn.String())
+		}
+	}
+}
+
+func TestSetOverridesReachConfigDefaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GROVE_HOME", "")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "grove.yml"), []byte("version: \"1.0\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Cleanup(config.ClearCommandLineOverrides)
+
+	root, logs, _ := newDefaultsTestTree()
+	args := []string{"logs", "--set", "cli.defaults.logs.lines=50"}
+	applySetOverrides(args)
+	applyConfigDefaults(root, args)
+
+	if lines, _ := logs.Flags().GetInt("lines"); lines != 50 {
+		t.Errorf("expected --set to feed cli.defaults, got --lines %d", lines)
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat selects how a command renders its result.
+type OutputFormat string
+
+const (
+	// FormatText is human-readable output, the default.
+	FormatText OutputFormat = "text"
+	// FormatJSON is selected by --json.
+	FormatJSON OutputFormat = "json"
+	// FormatYAML is selected by --yaml.
+	FormatYAML OutputFormat = "yaml"
+)
+
+// Printer is what commands write results through instead of fmt.Println, so
+// --json, --yaml and --quiet behave the same everywhere.
+//
+// Result is the command's data: it is encoded in JSON or YAML mode and
+// rendered by the text callback otherwise, and is printed even with --quiet.
+// Printf and Println are for informational messages ("Removed ...",
+// "No sessions."); they are dropped under --quiet and in JSON/YAML mode so
+// structured output stays parseable.
+type Printer interface {
+	Result(v interface{}, text func(w io.Writer) error) error
+	Printf(format string, a ...interface{})
+	Println(a ...interface{})
+	Format() OutputFormat
+	// Structured reports whether the format is JSON or YAML.
+	Structured() bool
+	Quiet() bool
+}
+
+type printer struct {
+	out    io.Writer
+	format OutputFormat
+	quiet  bool
+}
+
+// NewPrinter returns a Printer writing to out.
+func NewPrinter(out io.Writer, format OutputFormat, quiet bool) Printer {
+	if format == "" {
+		format = FormatText
+	}
+	return &printer{out: out, format: format, quiet: quiet}
+}
+
+// GetPrinter returns a Printer for cmd's standard output flags.
+func GetPrinter(cmd *cobra.Command) Printer {
+	opts := GetOptions(cmd)
+	return NewPrinter(cmd.OutOrStdout(), opts.OutputFormat(), opts.Quiet)
+}
+
+func (p *printer) Format() OutputFormat { return p.format }
+func (p *printer) Structured() bool     { return p.format != FormatText }
+func (p *printer) Quiet() bool          { return p.quiet }
+
+func (p *printer) Result(v interface{}, text func(w io.Writer) error) error {
+	switch p.format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output to JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	case FormatYAML:
+		data, err := marshalYAML(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal output to YAML: %w", err)
+		}
+		_, err = p.out.Write(data)
+		return err
+	}
+	if text == nil {
+		_, err := fmt.Fprintln(p.out, v)
+		return err
+	}
+	return text(p.out)
+}
+
+func (p *printer) Printf(format string, a ...interface{}) {
+	if p.quiet || p.Structured() {
+		return
+	}
+	fmt.Fprintf(p.out, format, a...)
+}
+
+func (p *printer) Println(a ...interface{}) {
+	if p.quiet || p.Structured() {
+		return
+	}
+	fmt.Fprintln(p.out, a...)
+}
+
+// marshalYAML encodes v as YAML using its JSON field names, so --yaml and
+// --json describe the same shape. Going through JSON also keeps struct field
+// order, which decoding into a map would lose.
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the flow and quoting styles the JSON source left on
+// node, so the encoder picks ordinary block YAML.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// noColorFlag is the pflag.Value behind --no-color. Like --set it takes
+// effect as soon as it is parsed, before any command code renders styles.
+type noColorFlag struct {
+	set bool
+}
+
+func (f *noColorFlag) String() string {
+	return strconv.FormatBool(f.set)
+}
+
+func (f *noColorFlag) Set(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	f.set = v
+	if v {
+		DisableColor()
+	}
+	return nil
+}
+
+func (f *noColorFlag) Type() string {
+	return "bool"
+}
+
+// DisableColor turns off styled output for this process and, through
+// NO_COLOR, for the tools it runs.
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	_ = os.Setenv("NO_COLOR", "1")
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+type outputSample struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+func TestPrinterFormats(t *testing.T) {
+	v := outputSample{Name: "grove", Version: "1.0", Tags: []string{"a", "true"}}
+	text := func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "%s %s\n", v.Name, v.Version)
+		return err
+	}
+
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{FormatText, "grove 1.0\n"},
+		{FormatJSON, "{\n  \"name\": \"grove\",\n  \"version\": \"1.0\",\n  \"tags\": [\n    \"a\",\n    \"true\"\n  ]\n}\n"},
+		// Field order follows the struct and strings that would read as
+		// other types stay quoted.
+		{FormatYAML, "name: grove\nversion: \"1.0\"\ntags:\n  - a\n  - \"true\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewPrinter(&buf, tt.format, false).Result(v, text); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinterMessagesSuppressed(t *testing.T) {
+	tests := []struct {
+		name   string
+		format OutputFormat
+		quiet  bool
+		want   string
+	}{
+		{"text", FormatText, false, "Removed x\nresult\n"},
+		{"quiet", FormatText, true, "result\n"},
+		{"json", FormatJSON, false, "\"result\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			p := NewPrinter(&buf, tt.format, tt.quiet)
+			p.Printf("Removed %s\n", "x")
+			if err := p.Result("result", nil); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestStandardOutputFlags(t *testing.T) {
+	var got CommandOptions
+	root := NewStandardCommand("root", "")
+	sub := NewStandardCommand("sub", "")
+	sub.RunE = func(cmd *cobra.Command, args []string) error {
+		got = GetOptions(cmd)
+		return nil
+	}
+	root.AddCommand(sub)
+
+	root.SetArgs([]string{"sub", "--yaml", "-q"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got.OutputFormat() != FormatYAML || !got.Quiet {
+		t.Errorf("expected yaml and quiet, got %+v", got)
+	}
+
+	root.SetArgs([]string{"sub", "--json", "--yaml"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got.OutputFormat() != FormatJSON {
+		t.Errorf("expected --json to win over --yaml, got %s", got.OutputFormat())
+	}
+}
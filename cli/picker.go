@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/internal/tty"
+	"github.com/grovetools/core/tui/components/picker"
+)
+
+// ErrNotInteractive is returned by the Pick functions when cmd cannot
+// prompt; callers report the ambiguity as an error instead.
+var ErrNotInteractive = errors.New("not an interactive terminal")
+
+// CanPrompt reports whether cmd may ask the user to choose: stdin and
+// stderr are terminals and neither --quiet, --json nor --yaml is given.
+func CanPrompt(cmd *cobra.Command) bool {
+	if o := GetOptions(cmd); o.Quiet || o.OutputFormat() != FormatText {
+		return false
+	}
+	return tty.IsTerminal(cmd.InOrStdin()) && tty.IsTerminal(cmd.ErrOrStderr())
+}
+
+// PickOne asks the user to choose one of items and returns its index. The
+// picker is drawn on stderr so stdout stays clean for the command's
+// output. It returns picker.ErrCanceled if the user backs out.
+func PickOne(cmd *cobra.Command, title string, items []picker.Item) (int, error) {
+	chosen, err := pick(cmd, picker.New(title, items))
+	if err != nil {
+		return -1, err
+	}
+	return chosen[0], nil
+}
+
+// PickMany asks the user to choose any number of items and returns their
+// indexes in item order.
+func PickMany(cmd *cobra.Command, title string, items []picker.Item) ([]int, error) {
+	return pick(cmd, picker.New(title, items, picker.WithMulti()))
+}
+
+// PickFuzzy is PickOne with a filter line, for lists too long to scan.
+func PickFuzzy(cmd *cobra.Command, title string, items []picker.Item) (int, error) {
+	chosen, err := pick(cmd, picker.New(title, items, picker.WithFuzzy()))
+	if err != nil {
+		return -1, err
+	}
+	return chosen[0], nil
+}
+
+func pick(cmd *cobra.Command, m picker.Model) ([]int, error) {
+	if !CanPrompt(cmd) {
+		return nil, ErrNotInteractive
+	}
+	return picker.Run(m, cmd.InOrStdin(), cmd.ErrOrStderr())
+}
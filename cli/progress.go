@@ -4,8 +4,25 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/progressx"
 )
 
+// NewProgress starts reporting the progress of a long operation run by
+// cmd; see progressx.Start. It is drawn on stderr unless --quiet, --json
+// or --yaml is given, and always recorded as debug entries of the
+// "progress" log component.
+func NewProgress(cmd *cobra.Command, name string, total int) *progressx.Task {
+	opts := progressx.Options{Logger: logging.NewLogger("progress")}
+	if o := GetOptions(cmd); o.Quiet || o.OutputFormat() != FormatText {
+		opts.Mode = progressx.ModeOff
+	}
+	return progressx.Start(name, total, opts)
+}
+
 // ProgressReporter reports concurrent operation progress
 type ProgressReporter struct {
 	mu       sync.Mutex
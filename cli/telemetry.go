@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/grovetools/core/pkg/telemetry"
+)
+
+// recordUsage appends the run of target to the local telemetry log when the
+// user has opted in. Only the names of the flags that were set are kept,
+// never their values or the arguments. Failures are ignored: telemetry must
+// never affect the command.
+func recordUsage(target *cobra.Command, start time.Time, err error) {
+	if target == nil || strings.HasPrefix(target.Name(), "__") || !telemetry.Enabled() {
+		return
+	}
+	var flags []string
+	target.Flags().Visit(func(f *pflag.Flag) {
+		flags = append(flags, f.Name)
+	})
+	sort.Strings(flags)
+
+	exit := 0
+	if err != nil {
+		exit = 1
+	}
+	_ = telemetry.Record(telemetry.Path(), telemetry.Event{
+		Time:       start,
+		Tool:       target.Root().Name(),
+		Command:    target.CommandPath(),
+		Flags:      flags,
+		DurationMs: time.Since(start).Milliseconds(),
+		ExitStatus: exit,
+	})
+}
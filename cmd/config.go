@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/grovetools/core/cli"
 	"github.com/grovetools/core/config"
 )
 
@@ -53,8 +55,9 @@ func redactSecrets(node interface{}) interface{} {
 	}
 }
 
-// marshalRedacted renders a config layer as YAML with secret values masked.
-func marshalRedacted(cfg *config.Config) ([]byte, error) {
+// redactedTree decodes a config layer into a generic tree keyed by its YAML
+// field names, with secret values masked.
+func redactedTree(cfg *config.Config) (interface{}, error) {
 	raw, err := yaml.Marshal(cfg)
 	if err != nil {
 		return nil, err
@@ -63,7 +66,16 @@ func marshalRedacted(cfg *config.Config) ([]byte, error) {
 	if err := yaml.Unmarshal(raw, &tree); err != nil {
 		return nil, err
 	}
-	return yaml.Marshal(redactSecrets(tree))
+	return redactSecrets(tree), nil
+}
+
+// configLayer is the structured shape of one `config-layers` entry. Config
+// is the redacted tree, or nil with Error set when the layer failed to render.
+type configLayer struct {
+	Layer  string      `json:"layer"`
+	Source string      `json:"source,omitempty"`
+	Config interface{} `json:"config"`
+	Error  string      `json:"error,omitempty"`
 }
 
 func NewConfigCmd() *cobra.Command {
@@ -75,6 +87,7 @@ func NewConfigCmd() *cobra.Command {
 2. Ecosystem config (parent grove.yml with workspaces, if in an ecosystem)
 3. Project config (grove.yml)
 4. Override files (grove.override.yml)
+5. Command-line overrides (--set key=value)
 This is useful for debugging configuration issues.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cwd, err := os.Getwd()
@@ -87,35 +100,52 @@ This is useful for debugging configuration issues.`,
 				return fmt.Errorf("failed to load layered config: %w", err)
 			}
 
-			printLayer := func(title, path string, cfg *config.Config) {
+			var layers []configLayer
+			addLayer := func(title, path string, cfg *config.Config) {
 				if cfg == nil {
 					return
 				}
-				fmt.Printf("--- # %s\n", title)
-				if path != "" {
-					fmt.Printf("# Source: %s\n", path)
-				}
-				data, err := marshalRedacted(cfg)
-				if err != nil {
-					fmt.Printf("# Error rendering layer: %v\n", err)
-					return
+				layer := configLayer{Layer: title, Source: path}
+				if tree, err := redactedTree(cfg); err != nil {
+					layer.Error = err.Error()
+				} else {
+					layer.Config = tree
 				}
-				fmt.Println(string(data))
+				layers = append(layers, layer)
 			}
 
-			printLayer("GLOBAL CONFIG", layered.FilePaths[config.SourceGlobal], layered.Global)
+			addLayer("GLOBAL CONFIG", layered.FilePaths[config.SourceGlobal], layered.Global)
 			if layered.GlobalOverride != nil {
-				printLayer("GLOBAL OVERRIDE CONFIG", layered.FilePaths[config.SourceGlobalOverride], layered.GlobalOverride.Config)
+				addLayer("GLOBAL OVERRIDE CONFIG", layered.FilePaths[config.SourceGlobalOverride], layered.GlobalOverride.Config)
 			}
-			printLayer("ECOSYSTEM CONFIG", layered.FilePaths[config.SourceEcosystem], layered.Ecosystem)
-			printLayer("PROJECT NOTEBOOK CONFIG", layered.FilePaths[config.SourceProjectNotebook], layered.ProjectNotebook)
-			printLayer("PROJECT CONFIG", layered.FilePaths[config.SourceProject], layered.Project)
+			addLayer("ECOSYSTEM CONFIG", layered.FilePaths[config.SourceEcosystem], layered.Ecosystem)
+			addLayer("PROJECT NOTEBOOK CONFIG", layered.FilePaths[config.SourceProjectNotebook], layered.ProjectNotebook)
+			addLayer("PROJECT CONFIG", layered.FilePaths[config.SourceProject], layered.Project)
 			for _, override := range layered.Overrides {
-				printLayer("OVERRIDE CONFIG", override.Path, override.Config)
+				addLayer("OVERRIDE CONFIG", override.Path, override.Config)
 			}
-			printLayer("FINAL MERGED CONFIG", "", layered.Final)
+			addLayer("COMMAND-LINE OVERRIDES (--set)", "", layered.CommandLine)
+			addLayer("FINAL MERGED CONFIG", "", layered.Final)
 
-			return nil
+			return cli.GetPrinter(cmd).Result(layers, func(w io.Writer) error {
+				for _, l := range layers {
+					fmt.Fprintf(w, "--- # %s\n", l.Layer)
+					if l.Source != "" {
+						fmt.Fprintf(w, "# Source: %s\n", l.Source)
+					}
+					if l.Error != "" {
+						fmt.Fprintf(w, "# Error rendering layer: %s\n", l.Error)
+						continue
+					}
+					data, err := yaml.Marshal(l.Config)
+					if err != nil {
+						fmt.Fprintf(w, "# Error rendering layer: %v\n", err)
+						continue
+					}
+					fmt.Fprintln(w, string(data))
+				}
+				return nil
+			})
 		},
 	}
 	return cmd
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/schema"
+)
+
+func newConfigDefaultsCmd() *cobra.Command {
+	var key string
+
+	cmd := cli.NewStandardCommand(
+		"defaults",
+		"Print every setting that has a default, as YAML",
+	)
+	cmd.Long = `Print the configuration with every setting that declares a default set to
+it, as a grove.yml starting point. Each setting is preceded by a comment with
+its description and allowed values.
+
+Defaults are read from the schema bundled with this binary and the registered
+extension schemas, so settings with no default (such as groves) are left out.
+Use --key to limit the output to one section (e.g. logging).`
+	cmd.Example = `  core config defaults > ~/.config/grove/grove.yml
+  core config defaults --key tui.logs`
+	cmd.Flags().StringVar(&key, "key", "", "Dotted config key to print defaults under (e.g. logging)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		fields, err := schema.DefaultFields(key)
+		if err != nil {
+			return err
+		}
+		doc, values, err := defaultsDocument(fields)
+		if err != nil {
+			return err
+		}
+		return cli.GetPrinter(cmd).Result(values, func(w io.Writer) error {
+			if len(fields) == 0 {
+				_, err := fmt.Fprintf(w, "# No settings under %q declare a default.\n", key)
+				return err
+			}
+			enc := yaml.NewEncoder(w)
+			enc.SetIndent(2)
+			if err := enc.Encode(doc); err != nil {
+				return err
+			}
+			return enc.Close()
+		})
+	}
+
+	return cmd
+}
+
+// defaultsDocument nests the dotted default fields into a YAML mapping
+// annotated with their descriptions, and into plain values for --json.
+func defaultsDocument(fields []schema.Field) (*yaml.Node, map[string]interface{}, error) {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	values := map[string]interface{}{}
+	for _, f := range fields {
+		var value interface{}
+		if err := json.Unmarshal([]byte(f.Default), &value); err != nil {
+			return nil, nil, fmt.Errorf("invalid default for %s: %w", f.Key, err)
+		}
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(value); err != nil {
+			return nil, nil, fmt.Errorf("invalid default for %s: %w", f.Key, err)
+		}
+
+		parts := strings.Split(f.Key, ".")
+		parent, parentValues := doc, values
+		for _, part := range parts[:len(parts)-1] {
+			parent = yamlChild(parent, part)
+			next, ok := parentValues[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				parentValues[part] = next
+			}
+			parentValues = next
+		}
+		name := parts[len(parts)-1]
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: name, HeadComment: defaultComment(f)},
+			valueNode)
+		parentValues[name] = value
+	}
+	return doc, values, nil
+}
+
+// yamlChild returns the mapping under key in m, adding it when missing.
+func yamlChild(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
+
+// defaultComment is the comment written above a default: its description
+// and allowed values.
+func defaultComment(f schema.Field) string {
+	comment := f.Description
+	if len(f.Enum) > 0 {
+		comment = strings.TrimSpace(comment + " (one of: " + strings.Join(f.Enum, ", ") + ")")
+	}
+	return comment
+}
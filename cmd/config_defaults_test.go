@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/schema"
+)
+
+func TestDefaultsDocument(t *testing.T) {
+	doc, values, err := defaultsDocument([]schema.Field{
+		{Key: "logging.file.format", Default: `"json"`, Description: "File log format", Enum: []string{"text", "json"}},
+		{Key: "logging.level", Default: `"info"`, Description: "Minimum log level"},
+		{Key: "tui.logs.max_entries", Default: "10000"},
+	})
+	if err != nil {
+		t.Fatalf("defaultsDocument: %v", err)
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `logging:
+    file:
+        # File log format (one of: text, json)
+        format: json
+    # Minimum log level
+    level: info
+tui:
+    logs:
+        max_entries: 10000
+`
+	if string(out) != want {
+		t.Errorf("YAML =\n%s\nwant\n%s", out, want)
+	}
+	logs := values["tui"].(map[string]interface{})["logs"].(map[string]interface{})
+	if logs["max_entries"] != float64(10000) {
+		t.Errorf("values = %v", values)
+	}
+}
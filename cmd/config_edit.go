@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+)
+
+// configValue is the structured output of `config get` and `config set`.
+// Layer and File are empty when the value was read from the merged config.
+type configValue struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+	Layer string      `json:"layer,omitempty"`
+	File  string      `json:"file,omitempty"`
+}
+
+func newConfigGetCmd() *cobra.Command {
+	var layer string
+
+	cmd := cli.NewStandardCommand(
+		"get <key>",
+		"Print the value of a configuration key",
+	)
+	cmd.Long = `Print the value of a dotted configuration key (e.g. logging.level) from the
+merged configuration for the current directory, with secret values masked.
+Scalars are printed bare, so the output can be used directly in scripts;
+tables are printed as YAML.
+
+With --layer, read the key as written in that layer's file instead
+(project, ecosystem or global).
+
+Exits non-zero when the key is not set.`
+	cmd.Example = `  core config get logging.level
+  core config get tui --layer global
+  core config get tui.theme --json`
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.Flags().StringVar(&layer, "layer", "", "Read from one layer's file instead of the merged config")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		layered, err := config.LoadLayered(cwd)
+		if err != nil {
+			return fmt.Errorf("failed to load layered config: %w", err)
+		}
+
+		result := configValue{Key: key, Layer: layer}
+		var tree interface{}
+		if layer == "" {
+			if tree, err = redactedTree(layered.Final); err != nil {
+				return fmt.Errorf("failed to render config: %w", err)
+			}
+		} else {
+			if result.File, err = config.LayerFile(layered, cwd, config.ConfigSource(layer)); err != nil {
+				return err
+			}
+			layerTree, err := config.ReadLayerTree(result.File)
+			if os.IsNotExist(err) {
+				return fmt.Errorf("%s is not set: %s does not exist", key, result.File)
+			}
+			if err != nil {
+				return err
+			}
+			tree = redactSecrets(layerTree)
+		}
+
+		value, ok, err := config.LookupKey(tree, key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if result.File != "" {
+				return fmt.Errorf("%s is not set in %s", key, result.File)
+			}
+			return fmt.Errorf("%s is not set", key)
+		}
+		result.Value = value
+
+		return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			return printConfigValue(w, value)
+		})
+	}
+
+	return cmd
+}
+
+// printConfigValue prints a scalar bare and anything else as YAML.
+func printConfigValue(w io.Writer, value interface{}) error {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case nil:
+		_, err := fmt.Fprintln(w)
+		return err
+	default:
+		_, err := fmt.Fprintln(w, value)
+		return err
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	var layer string
+
+	cmd := cli.NewStandardCommand(
+		"set <key> <value>",
+		"Set a configuration key in a config layer file",
+	)
+	cmd.Long = `Set a dotted configuration key in one layer's config file: the project's
+grove.toml / grove.yml (the default), the ecosystem's, or the global one. The
+file is created when the layer has none.
+
+The value is typed like a --set override: after the config schema when it
+describes the key, so a string field keeps "1.0" or "007" as written and a
+boolean, number, array or object field rejects a value it cannot hold. For
+other keys true/false become booleans, numbers become integers or floats,
+JSON arrays and objects are decoded, a double-quoted value is always a
+string, and anything else is a plain string.
+
+The file is edited in place: comments, key order and the formatting of
+other keys are kept. YAML files are edited as a document tree; TOML files
+are edited as text, and a key whose layout the edit does not cover (such as
+a multi-line array) is reported instead of rewritten. JSON5 files are never
+rewritten.
+
+A warning is printed when nothing reads the key.`
+	cmd.Example = `  core config set logging.level debug
+  core config set tui.theme gruvbox --layer global
+  core config set logging.component_filtering.show '["flow","nb"]'`
+	cmd.Args = cobra.ExactArgs(2)
+	cmd.Flags().StringVar(&layer, "layer", string(config.SourceProject), "Layer to write: project, ecosystem or global")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		key, value := args[0], args[1]
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		layered, err := config.LoadLayered(cwd)
+		if err != nil {
+			return fmt.Errorf("failed to load layered config: %w", err)
+		}
+		path, err := config.LayerFile(layered, cwd, config.ConfigSource(layer))
+		if err != nil {
+			return err
+		}
+		if err := config.SetKey(path, key, value); err != nil {
+			return err
+		}
+
+		result := configValue{Key: key, Value: value, Layer: layer, File: path}
+		if tree, err := config.ReadLayerTree(path); err == nil {
+			if v, ok, _ := config.LookupKey(tree, key); ok {
+				result.Value = v
+			}
+		}
+		warnUnreadConfigKey(cmd.ErrOrStderr(), cwd, key, path)
+
+		return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Set %s = %v in %s (%s)\n", key, result.Value, path, layer)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+// warnUnreadConfigKey warns when the audit classifies key, as set in path,
+// as a key nothing reads.
+func warnUnreadConfigKey(w io.Writer, cwd, key, path string) {
+	findings, err := config.Audit(cwd)
+	if err != nil {
+		return
+	}
+	for _, f := range findings {
+		if f.Key != key || f.File != path {
+			continue
+		}
+		switch f.Class {
+		case config.AuditOrphan, config.AuditUnknownNested:
+			fmt.Fprintf(w, "warning: nothing reads %s (%s); check the key name with 'core config schema print'\n", key, f.Class)
+		case config.AuditDeprecated:
+			fmt.Fprintf(w, "warning: %s is deprecated; see 'core config lint'\n", key)
+		}
+		return
+	}
+}
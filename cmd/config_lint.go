@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+)
+
+// configLintResult is the structured output of `config lint`.
+type configLintResult struct {
+	Issues []config.LintIssue `json:"issues"`
+	Fixed  []config.LintIssue `json:"fixed,omitempty"`
+}
+
+func newConfigLintCmd() *cobra.Command {
+	var fix bool
+
+	cmd := cli.NewStandardCommand(
+		"lint",
+		"Check config files for problems schema validation misses",
+	)
+	cmd.Long = `Check every config layer file for the current directory for problems the
+schema does not catch:
+
+  deprecated-key       keys that still work but should be migrated
+  unreachable-grove    groves entries whose path does not exist
+  unused-group         logging groups no component_filtering list uses
+  filter-conflict      component_filtering entries that have no effect
+  duplicate-workspace  workspaces patterns listed more than once
+
+With --fix, issues that can be fixed without changing the effective
+configuration are rewritten in place (comments are kept); the rest are left
+to fix by hand. JSON5 files are never rewritten.
+
+Exits non-zero while issues remain.`
+	cmd.Example = `  core config lint
+  core config lint --fix`
+	cmd.Flags().BoolVar(&fix, "fix", false, "Rewrite the issues that are safely fixable")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		issues, err := config.Lint(cwd)
+		if err != nil {
+			return err
+		}
+
+		result := configLintResult{Issues: issues}
+		var fixErr error
+		if fix {
+			result.Fixed, fixErr = config.FixLint(issues)
+			if len(result.Fixed) > 0 {
+				if result.Issues, err = config.Lint(cwd); err != nil {
+					return err
+				}
+			}
+		}
+		if result.Issues == nil {
+			result.Issues = []config.LintIssue{}
+		}
+
+		printer := cli.GetPrinter(cmd)
+		if err := printer.Result(result, func(w io.Writer) error {
+			return printLintResult(w, result, fix)
+		}); err != nil {
+			return err
+		}
+		if fixErr != nil {
+			return fixErr
+		}
+		if len(result.Issues) > 0 {
+			return fmt.Errorf("%d config issue(s) found", len(result.Issues))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func printLintResult(w io.Writer, result configLintResult, fix bool) error {
+	if len(result.Fixed) > 0 {
+		fmt.Fprintf(w, "Fixed %d issue(s):\n", len(result.Fixed))
+		for _, issue := range result.Fixed {
+			fmt.Fprintf(w, "  %s: %s (%s)\n", issue.File, issue.Message, issue.Rule)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(result.Issues) == 0 {
+		fmt.Fprintln(w, "No config issues found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tKEY\tRULE\tMESSAGE")
+	fixable := 0
+	for _, issue := range result.Issues {
+		rule := issue.Rule
+		if issue.Fixable {
+			rule += " (fixable)"
+			fixable++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", issue.File, issue.Key, rule, issue.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if fixable > 0 && !fix {
+		fmt.Fprintf(w, "\n%d issue(s) can be fixed with --fix.\n", fixable)
+	}
+	return nil
+}
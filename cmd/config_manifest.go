@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+)
+
+// configManifestResult is the structured output of `config manifest`.
+type configManifestResult struct {
+	ConfigFile string                   `json:"config_file"`
+	Status     *config.ManifestStatus   `json:"status"`
+	Workspaces []manifestWorkspaceState `json:"workspaces"`
+}
+
+// manifestWorkspaceState is one manifest entry and the local directories
+// it matches.
+type manifestWorkspaceState struct {
+	Pattern string   `json:"pattern"`
+	Matches []string `json:"matches"`
+}
+
+func newConfigManifestCmd() *cobra.Command {
+	var refresh bool
+
+	cmd := cli.NewStandardCommand(
+		"manifest",
+		"Show the remote workspaces manifest of the current ecosystem",
+	)
+	cmd.Long = `Resolve the workspaces_from manifest of the ecosystem containing the
+current directory and list its workspaces, marking those with no matching
+directory under the ecosystem root (members not cloned yet).
+
+Manifests are cached for workspaces_from.ttl (default 24h) and a pinned
+sha256 is checked on every fetch. Loading a config only reads the cached
+copy; workspace discovery fetches a new one once it expires, and --refresh
+fetches the manifest now.`
+	cmd.Example = `  core config manifest
+  core config manifest --refresh --json`
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Fetch the manifest even if the cached copy is current")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		path, cfg, err := findManifestConfig(cwd)
+		if err != nil {
+			return err
+		}
+		status, err := config.FetchManifest(cmd.Context(), cfg.WorkspacesFrom, refresh)
+		if err != nil {
+			return err
+		}
+
+		result := configManifestResult{ConfigFile: path, Status: status, Workspaces: []manifestWorkspaceState{}}
+		root := filepath.Dir(path)
+		for _, pattern := range status.Manifest.Workspaces {
+			state := manifestWorkspaceState{Pattern: pattern, Matches: []string{}}
+			matches, _ := filepath.Glob(filepath.Join(root, pattern))
+			for _, m := range matches {
+				if info, err := os.Stat(m); err == nil && info.IsDir() {
+					state.Matches = append(state.Matches, m)
+				}
+			}
+			result.Workspaces = append(result.Workspaces, state)
+		}
+
+		return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			return printManifestResult(w, result)
+		})
+	}
+
+	return cmd
+}
+
+// findManifestConfig returns the config file that sets workspaces_from for
+// dir: its own config, or else its ecosystem's.
+func findManifestConfig(dir string) (string, *config.Config, error) {
+	var candidates []string
+	if path, err := config.FindConfigFile(dir); err == nil {
+		candidates = append(candidates, path)
+	}
+	if eco := config.FindEcosystemConfig(dir); eco != "" {
+		candidates = append(candidates, eco)
+	}
+	for _, path := range candidates {
+		cfg, err := config.Load(path)
+		if err != nil {
+			return "", nil, err
+		}
+		if cfg.WorkspacesFrom != nil {
+			return path, cfg, nil
+		}
+	}
+	return "", nil, fmt.Errorf("no workspaces_from set in the config for %s or its ecosystem", dir)
+}
+
+func printManifestResult(w io.Writer, result configManifestResult) error {
+	status := result.Status
+	fmt.Fprintf(w, "Config:   %s\n", result.ConfigFile)
+	fmt.Fprintf(w, "Source:   %s\n", status.Source)
+	pin := "not pinned"
+	if status.Pinned {
+		pin = "pinned"
+	}
+	fmt.Fprintf(w, "SHA-256:  %s (%s)\n", status.SHA256, pin)
+	fmt.Fprintf(w, "Fetched:  %s (%s ago)\n", status.FetchedAt.Format(time.RFC3339), time.Since(status.FetchedAt).Round(time.Second))
+	if status.Stale {
+		fmt.Fprintf(w, "Warning:  refresh failed, using the cached copy: %s\n", status.RefreshError)
+	}
+	fmt.Fprintln(w)
+
+	if len(result.Workspaces) == 0 {
+		fmt.Fprintln(w, "The manifest lists no workspaces.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKSPACE\tLOCAL")
+	missing := 0
+	for _, ws := range result.Workspaces {
+		local := fmt.Sprintf("%d dir(s)", len(ws.Matches))
+		if len(ws.Matches) == 0 {
+			local = "missing"
+			missing++
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", ws.Pattern, local)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if missing > 0 {
+		fmt.Fprintf(w, "\n%d manifest workspace(s) have no local directory.\n", missing)
+	}
+	return nil
+}
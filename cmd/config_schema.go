@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/schema"
+)
+
+// NewConfigGroupCmd creates the `config` command group.
+func NewConfigGroupCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"config",
+		"Inspect and edit Grove configuration",
+	)
+	cmd.Long = `Inspect Grove configuration and the schema it is validated against, and
+read or write individual keys.
+
+See also 'core config-layers' for how the effective configuration is merged.`
+
+	cmd.AddCommand(newConfigShowCmd())
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigDefaultsCmd())
+	cmd.AddCommand(newConfigLintCmd())
+	cmd.AddCommand(newConfigManifestCmd())
+	cmd.AddCommand(newConfigSchemaCmd())
+
+	return cmd
+}
+
+func newConfigSchemaCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"schema",
+		"Inspect the configuration schema bundled with this binary",
+	)
+
+	cmd.AddCommand(newConfigSchemaPrintCmd())
+
+	return cmd
+}
+
+func newConfigSchemaPrintCmd() *cobra.Command {
+	var key, format string
+
+	cmd := cli.NewStandardCommand(
+		"print",
+		"Print the schema for a configuration key",
+	)
+	cmd.Long = `Print the JSON schema for a configuration key (e.g. logging, tui, or a
+nested key such as logging.file), resolved from the schema bundle embedded in
+this binary. References are inlined, so the output is self-contained.
+
+Use --format yaml (or --yaml) for the schema as YAML, or --format markdown
+for a table of the available settings with their types,
+defaults and descriptions.`
+	cmd.Example = `  core config schema print --key logging
+  core config schema print --key logging.file --format markdown`
+	cmd.Flags().StringVar(&key, "key", "", "Dotted config key to print (e.g. logging)")
+	cmd.Flags().StringVar(&format, "format", "json", "Output format: json, yaml or markdown")
+	_ = cmd.MarkFlagRequired("key")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		node, err := schema.Lookup(key)
+		if err != nil {
+			return err
+		}
+
+		if cli.GetOptions(cmd).YAMLOutput && !cmd.Flags().Changed("format") {
+			format = "yaml"
+		}
+
+		switch format {
+		case "yaml":
+			return cli.NewPrinter(cmd.OutOrStdout(), cli.FormatYAML, false).Result(node, nil)
+		case "json":
+			jsonData, err := json.MarshalIndent(node, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal schema to JSON: %w", err)
+			}
+			fmt.Println(string(jsonData))
+		case "markdown", "md":
+			fmt.Print(renderSchemaMarkdown(key, node))
+		default:
+			return fmt.Errorf("unknown format %q (expected json, yaml or markdown)", format)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// renderSchemaMarkdown renders the settings under a schema node as a
+// markdown table headed by the key and its description.
+func renderSchemaMarkdown(key string, node map[string]interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", key)
+	if desc, ok := node["description"].(string); ok && desc != "" {
+		fmt.Fprintf(&b, "%s\n\n", desc)
+	}
+
+	fields := schema.Fields(node)
+	if len(fields) == 0 {
+		fmt.Fprintf(&b, "No settings are declared under %q.\n", key)
+		return b.String()
+	}
+
+	b.WriteString("| Key | Type | Default | Description |\n")
+	b.WriteString("|-----|------|---------|-------------|\n")
+	for _, f := range fields {
+		desc := f.Description
+		if len(f.Enum) > 0 {
+			desc = strings.TrimSpace(desc + " (one of: " + strings.Join(f.Enum, ", ") + ")")
+		}
+		def := ""
+		if f.Default != "" {
+			def = "`" + f.Default + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", f.Key, escapeTableCell(f.Type), def, escapeTableCell(desc))
+	}
+	return b.String()
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/schema"
+	"github.com/grovetools/core/tui/components/jsontree"
+)
+
+func newConfigShowCmd() *cobra.Command {
+	var interactive bool
+
+	cmd := cli.NewStandardCommand(
+		"show",
+		"Show the merged configuration for the current directory",
+	)
+	cmd.Long = `Show the final configuration for the current directory after all layers
+are merged, with secret values masked. See 'core config-layers' for the
+individual layers.
+
+With -i, browse it as a tree validated against the schema bundled with this
+binary: values that violate the schema get an error badge, deprecated keys a
+warning badge, and the cursor's findings are shown in the status bar. Press
+e / E to jump between findings.`
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Browse the config as a tree with schema validation badges")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		layered, err := config.LoadLayered(cwd)
+		if err != nil {
+			return fmt.Errorf("failed to load layered config: %w", err)
+		}
+		tree, err := redactedTree(layered.Final)
+		if err != nil {
+			return fmt.Errorf("failed to render config: %w", err)
+		}
+
+		if interactive {
+			return runConfigTree(tree)
+		}
+		return cli.GetPrinter(cmd).Result(tree, func(w io.Writer) error {
+			data, err := yaml.Marshal(tree)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+// standaloneJSONTree runs a jsontree.Model as its own program, quitting on
+// the tree's BackMsg or ctrl+c.
+type standaloneJSONTree struct {
+	inner jsontree.Model
+}
+
+func (s standaloneJSONTree) Init() tea.Cmd { return s.inner.Init() }
+
+func (s standaloneJSONTree) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case jsontree.BackMsg:
+		return s, tea.Quit
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return s, tea.Quit
+		}
+	}
+	model, cmd := s.inner.Update(msg)
+	if m, ok := model.(jsontree.Model); ok {
+		s.inner = m
+	}
+	return s, cmd
+}
+
+func (s standaloneJSONTree) View() string { return s.inner.View() }
+
+// runConfigTree opens the schema-annotated tree viewer on tree.
+func runConfigTree(tree interface{}) error {
+	// The YAML-decoded tree carries ints; the viewer and the validator
+	// expect JSON value types.
+	encoded, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("failed to render config: %w", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return fmt.Errorf("failed to render config: %w", err)
+	}
+
+	treeOpts := []jsontree.Option{jsontree.WithSchema(schema.Composed())}
+	progOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if c, err := config.LoadDefault(); err == nil && c.TUI != nil {
+		treeOpts = append(treeOpts, jsontree.WithRenderHints(jsontree.RenderHintsFromConfig(c.TUI)))
+		if c.TUI.Mouse {
+			treeOpts = append(treeOpts, jsontree.WithMouse())
+			progOpts = append(progOpts, tea.WithMouseCellMotion())
+		}
+	}
+
+	model := standaloneJSONTree{inner: jsontree.New(data, treeOpts...)}
+	_, err = tea.NewProgram(model, progOpts...).Run()
+	return err
+}
@@ -5,9 +5,12 @@ import (
 
 	"github.com/grovetools/core/cli"
 	"github.com/grovetools/core/cmd"
+	"github.com/grovetools/core/logging"
 )
 
 func main() {
+	logging.EnableLevelSignals()
+
 	rootCmd := cli.NewStandardCommand(
 		"core",
 		"Core libraries and debugging tools for the Grove ecosystem",
@@ -17,13 +20,23 @@ func main() {
 	rootCmd.AddCommand(cmd.NewVersionCmd())
 	rootCmd.AddCommand(cmd.NewWsCmd())
 	rootCmd.AddCommand(cmd.NewWorktreesCmd())
+	rootCmd.AddCommand(cmd.NewEachCmd())
 	rootCmd.AddCommand(cmd.NewConfigCmd())
+	rootCmd.AddCommand(cmd.NewConfigGroupCmd())
+	rootCmd.AddCommand(cmd.NewSchemaCmd())
 	rootCmd.AddCommand(cmd.NewEditorCmd())
 	rootCmd.AddCommand(cmd.NewOpenInWindowCmd())
 	rootCmd.AddCommand(cmd.NewTmuxCmd())
 	rootCmd.AddCommand(cmd.NewLogsCmd())
 	rootCmd.AddCommand(cmd.NewNvimDemoCmd())
 	rootCmd.AddCommand(cmd.NewPathsCmd())
+	rootCmd.AddCommand(cmd.NewRepoCmd())
+	rootCmd.AddCommand(cmd.NewSessionsCmd())
+	rootCmd.AddCommand(cmd.NewNotesCmd())
+	rootCmd.AddCommand(cmd.NewPsCmd())
+	rootCmd.AddCommand(cmd.NewStatsCmd())
+	rootCmd.AddCommand(cmd.NewDaemonCmd())
+	rootCmd.AddCommand(cmd.NewDevCmd())
 
 	if err := cli.Execute(rootCmd); err != nil {
 		os.Exit(1)
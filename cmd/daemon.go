@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/procman"
+)
+
+// NewDaemonCmd creates the `daemon` command.
+func NewDaemonCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"daemon",
+		"Capture and replay grove daemon state",
+	)
+	cmd.Long = `Capture the grove daemon's state into an archive that can be attached to a
+bug report, and replay such an archive in a sandbox.`
+
+	cmd.AddCommand(newDaemonSnapshotCmd())
+	cmd.AddCommand(newDaemonReplayCmd())
+
+	return cmd
+}
+
+func newDaemonSnapshotCmd() *cobra.Command {
+	var (
+		out    string
+		events int
+	)
+
+	cmd := cli.NewStandardCommand(
+		"snapshot",
+		"Dump the daemon's state into an archive for a bug report",
+	)
+	cmd.Long = `Dump the running daemon's state into a gzipped tar archive: the store
+contents (workspaces with their enrichment, sessions, plan and note counts,
+workflow runs and jobs), the collector intervals, recent events (log entries
+with an event field or at warn level and above) and the effective
+configuration for the current directory with secret values masked.
+
+Each section is a JSON file in the archive, so it can be reviewed before it
+is shared. Sections the daemon cannot serve are listed in manifest.json
+instead of failing the snapshot. Replay the archive with 'core daemon replay'.`
+	cmd.Example = `  core daemon snapshot --out grove-snapshot.tgz
+  core daemon snapshot --out bug.tgz --events 5000`
+	cmd.Args = cobra.NoArgs
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Archive to write (default grove-snapshot-<time>.tgz)")
+	cmd.Flags().IntVar(&events, "events", daemon.DefaultSnapshotEvents, "Number of recent log entries to scan for events")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		client := daemon.New()
+		defer client.Close()
+		if !client.IsRunning() {
+			return fmt.Errorf("the grove daemon is not running; nothing to snapshot")
+		}
+
+		opts := daemon.SnapshotOptions{Events: events}
+		if cwd, err := os.Getwd(); err == nil {
+			if layered, err := config.LoadLayered(cwd); err == nil {
+				opts.Config, _ = redactedTree(layered.Final)
+			}
+		}
+		snap := daemon.CaptureSnapshot(cmd.Context(), client, opts)
+
+		if out == "" {
+			out = "grove-snapshot-" + snap.Manifest.CreatedAt.Local().Format("20060102-150405") + ".tgz"
+		}
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot: %w", err)
+		}
+		if err := daemon.WriteSnapshot(f, snap); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+
+		summary := summarizeSnapshot(out, snap)
+		return cli.GetPrinter(cmd).Result(summary, func(w io.Writer) error {
+			fmt.Fprintf(w, "Wrote daemon snapshot to %s\n", out)
+			return printSnapshotSummary(w, summary)
+		})
+	}
+
+	return cmd
+}
+
+func newDaemonReplayCmd() *cobra.Command {
+	var keep bool
+
+	cmd := cli.NewStandardCommand(
+		"replay <archive> [-- command [args...]]",
+		"Replay a daemon snapshot in a sandbox",
+	)
+	cmd.Long = `Load a snapshot written by 'core daemon snapshot'. Without a command, print
+what the archive holds.
+
+With a command, run it in a sandbox: GROVE_HOME points at a temporary
+directory whose global config is the snapshot's configuration, and
+GROVE_DAEMON_SNAPSHOT points at the archive, so every daemon client in the
+command is served the captured state (as one initial state update, with the
+captured events replayed as the log stream) instead of talking to groved.
+The sandbox is removed afterwards unless --keep is set.`
+	cmd.Example = `  core daemon replay bug.tgz
+  core daemon replay bug.tgz -- core logs -i
+  core daemon replay bug.tgz --keep -- nav`
+	cmd.Args = cobra.MinimumNArgs(1)
+	cmd.Flags().BoolVar(&keep, "keep", false, "Keep the sandbox directory after the command exits")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		archive, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		snap, err := daemon.LoadSnapshot(archive)
+		if err != nil {
+			return fmt.Errorf("failed to load snapshot: %w", err)
+		}
+
+		if len(args) == 1 {
+			summary := summarizeSnapshot(archive, snap)
+			return cli.GetPrinter(cmd).Result(summary, func(w io.Writer) error {
+				return printSnapshotSummary(w, summary)
+			})
+		}
+
+		sandbox, err := newReplaySandbox(snap)
+		if err != nil {
+			return err
+		}
+		if keep {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Sandbox: %s\n", sandbox)
+		} else {
+			defer os.RemoveAll(sandbox)
+		}
+
+		child := exec.Command(args[1], args[2:]...)
+		child.Env = append(os.Environ(), "GROVE_HOME="+sandbox, daemon.SnapshotEnv+"="+archive)
+		child.Stdin = os.Stdin
+		child.Stdout = cmd.OutOrStdout()
+		child.Stderr = cmd.ErrOrStderr()
+		return procman.Default().Run(child, procman.Options{Name: "replay"})
+	}
+
+	return cmd
+}
+
+// newReplaySandbox creates a temporary GROVE_HOME whose global config is
+// the snapshot's configuration.
+func newReplaySandbox(snap *daemon.Snapshot) (string, error) {
+	sandbox, err := os.MkdirTemp("", "grove-replay-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create sandbox: %w", err)
+	}
+	if snap.Config == nil {
+		return sandbox, nil
+	}
+	data, err := yaml.Marshal(snap.Config)
+	if err != nil {
+		os.RemoveAll(sandbox)
+		return "", fmt.Errorf("failed to render snapshot config: %w", err)
+	}
+	dir := filepath.Join(sandbox, "config", "grove")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		os.RemoveAll(sandbox)
+		return "", fmt.Errorf("failed to create sandbox: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "grove.yml"), data, 0o644); err != nil {
+		os.RemoveAll(sandbox)
+		return "", fmt.Errorf("failed to write sandbox config: %w", err)
+	}
+	return sandbox, nil
+}
+
+// snapshotSummary is the structured output of `daemon snapshot` and
+// `daemon replay` without a command.
+type snapshotSummary struct {
+	File       string            `json:"file"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Host       string            `json:"host,omitempty"`
+	Version    string            `json:"version,omitempty"`
+	Workspaces int               `json:"workspaces"`
+	Sessions   int               `json:"sessions"`
+	Jobs       int               `json:"jobs"`
+	Events     int               `json:"events"`
+	Config     bool              `json:"config"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+func summarizeSnapshot(file string, snap *daemon.Snapshot) snapshotSummary {
+	return snapshotSummary{
+		File:       file,
+		CreatedAt:  snap.Manifest.CreatedAt,
+		Host:       snap.Manifest.Host,
+		Version:    snap.Manifest.Version,
+		Workspaces: len(snap.Workspaces),
+		Sessions:   len(snap.Sessions),
+		Jobs:       len(snap.Jobs),
+		Events:     len(snap.Events),
+		Config:     snap.Config != nil,
+		Errors:     snap.Manifest.Errors,
+	}
+}
+
+func printSnapshotSummary(out io.Writer, s snapshotSummary) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Captured:\t%s on %s (core %s)\n", s.CreatedAt.Local().Format(time.RFC3339), s.Host, s.Version)
+	fmt.Fprintf(w, "Workspaces:\t%d\n", s.Workspaces)
+	fmt.Fprintf(w, "Sessions:\t%d\n", s.Sessions)
+	fmt.Fprintf(w, "Jobs:\t%d\n", s.Jobs)
+	fmt.Fprintf(w, "Events:\t%d\n", s.Events)
+	fmt.Fprintf(w, "Config:\t%t\n", s.Config)
+	sections := make([]string, 0, len(s.Errors))
+	for section := range s.Errors {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	for _, section := range sections {
+		fmt.Fprintf(w, "Missing %s:\t%s\n", section, s.Errors[section])
+	}
+	return w.Flush()
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/fixtures"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// NewDevCmd creates the `dev` command
+func NewDevCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"dev",
+		"Tools for developing and benchmarking Grove itself",
+	)
+	cmd.AddCommand(newDevFixturesCmd())
+	return cmd
+}
+
+func newDevFixturesCmd() *cobra.Command {
+	def := fixtures.DefaultSpec()
+	var (
+		spec    = def
+		start   string
+		span    time.Duration
+		envOnly bool
+	)
+
+	cmd := cli.NewStandardCommand(
+		"fixtures <dir>",
+		"Generate a synthetic workspace tree and log files",
+	)
+	cmd.Long = `Generate ecosystems, projects and worktrees with a mix of grove.yml,
+grove.yaml and grove.toml configs, plus dated log files for every workspace.
+The same flags and --seed always produce the same tree, so it can be used for
+reproducible tests and benchmarks.
+
+The directory must be empty or not exist. A fixtures.json manifest is written
+at its root, and the command prints the environment variables that point Grove
+at the fixture instead of your real setup:
+
+  eval "$(core dev fixtures /tmp/grove-fixture --env)"
+
+Git metadata is written directly, so repositories and worktrees are found by
+discovery but have no commits.`
+	cmd.Example = `  core dev fixtures /tmp/fx
+  core dev fixtures /tmp/fx --ecosystems 10 --projects 20 --worktrees 3
+  core dev fixtures /tmp/fx --entries 100000 --start 2025-01-01T00:00:00Z --span 168h
+  core dev fixtures /tmp/fx --json`
+	cmd.Args = cobra.ExactArgs(1)
+
+	f := cmd.Flags()
+	f.Uint64Var(&spec.Seed, "seed", def.Seed, "Seed for every random choice")
+	f.IntVar(&spec.Ecosystems, "ecosystems", def.Ecosystems, "Number of ecosystems")
+	f.IntVar(&spec.ProjectsPerEcosystem, "projects", def.ProjectsPerEcosystem, "Sub-projects per ecosystem")
+	f.IntVar(&spec.EcosystemWorktrees, "ecosystem-worktrees", def.EcosystemWorktrees, "Worktrees of each ecosystem root")
+	f.IntVar(&spec.Standalone, "standalone", def.Standalone, "Number of standalone projects")
+	f.IntVar(&spec.WorktreesPerProject, "worktrees", def.WorktreesPerProject, "Worktrees of each project")
+	f.StringSliceVar(&spec.ConfigFormats, "config-formats", def.ConfigFormats, "Config file names projects pick from")
+	f.IntVar(&spec.Logs.EntriesPerWorkspace, "entries", def.Logs.EntriesPerWorkspace, "Log entries per workspace (0 for no logs)")
+	f.StringVar(&start, "start", def.Logs.Start.Format(time.RFC3339), "Earliest log time: an RFC 3339 time or a duration ago (e.g. 72h)")
+	f.DurationVar(&span, "span", def.Logs.End.Sub(def.Logs.Start), "Length of the time range logs are spread over")
+	f.StringVar(&spec.Logs.Format, "log-format", def.Logs.Format, "Log file format: json or text")
+	f.StringSliceVar(&spec.Logs.Components, "components", def.Logs.Components, "Component names log entries are attributed to")
+	f.BoolVar(&envOnly, "env", false, "Only print export statements for the fixture environment")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		switch spec.Logs.Format {
+		case "json", "text":
+		default:
+			return fmt.Errorf("invalid --log-format %q (expected json or text)", spec.Logs.Format)
+		}
+		from, err := parseReplayTime("--start", start)
+		if err != nil {
+			return err
+		}
+		if span <= 0 {
+			return fmt.Errorf("--span must be positive")
+		}
+		spec.Logs.Start = from.UTC()
+		spec.Logs.End = spec.Logs.Start.Add(span)
+
+		m, err := fixtures.Generate(args[0], spec)
+		if err != nil {
+			return err
+		}
+		if envOnly {
+			printFixtureEnv(cmd.OutOrStdout(), m)
+			return nil
+		}
+		return cli.GetPrinter(cmd).Result(m, func(w io.Writer) error {
+			return printFixtureSummary(w, m)
+		})
+	}
+
+	return cmd
+}
+
+func printFixtureEnv(w io.Writer, m *fixtures.Manifest) {
+	keys := make([]string, 0, len(m.Env))
+	for k := range m.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "export %s=%q\n", k, m.Env[k])
+	}
+}
+
+func printFixtureSummary(w io.Writer, m *fixtures.Manifest) error {
+	fmt.Fprintf(w, "Generated fixture at %s (seed %d)\n", m.Root, m.Spec.Seed)
+	for _, kind := range []workspace.WorkspaceKind{
+		workspace.KindEcosystemRoot,
+		workspace.KindEcosystemWorktree,
+		workspace.KindEcosystemSubProject,
+		workspace.KindEcosystemSubProjectWorktree,
+		workspace.KindStandaloneProject,
+		workspace.KindStandaloneProjectWorktree,
+	} {
+		if n := m.Count(kind); n > 0 {
+			fmt.Fprintf(w, "  %-28s %d\n", kind, n)
+		}
+	}
+	fmt.Fprintf(w, "  %-28s %d entries in %d files\n", "logs", m.LogEntries, len(m.LogFiles))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Point Grove at it with:")
+	var b strings.Builder
+	printFixtureEnv(&b, m)
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		fmt.Fprintln(w, "  "+line)
+	}
+	return nil
+}
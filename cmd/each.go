@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/each"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// NewEachCmd creates the `each` command.
+func NewEachCmd() *cobra.Command {
+	var (
+		tags     []string
+		jobs     int
+		all      bool
+		failFast bool
+	)
+
+	cmd := cli.NewStandardCommand(
+		"each",
+		"Run a command in every workspace of the ecosystem",
+	)
+	cmd.Use = "each [flags] -- <command> [args...]"
+	cmd.Long = `Run a command in the directory of each project in the current ecosystem
+(or, with --all, every project discovery finds), a few at a time. Worktrees
+are not included.
+
+--tag limits the run to projects whose grove.yml lists at least one of the
+given tags:
+
+  tags: [backend, go]
+
+Output lines are prefixed with the project name and also written to the
+logs as component grove.each, so a run can be searched later with
+'core logs --component grove.each'. Exits non-zero if the command failed
+in any project.`
+	cmd.Example = `  core each -- git status --short
+  core each --tag backend -- make test
+  core each --all -j 8 --fail-fast -- go vet ./...`
+	cmd.Args = cobra.MinimumNArgs(1)
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only run in projects with one of these tags (repeatable)")
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", 4, "Number of projects to run in at once")
+	cmd.Flags().BoolVar(&all, "all", false, "Run in every discovered project instead of the current ecosystem")
+	cmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop starting the command in more projects after a failure")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash > 0 {
+			return fmt.Errorf("unexpected arguments before --: %v", args[:dash])
+		}
+		projects, err := workspace.NewDiscoveryService(cli.GetLogger(cmd)).GetProjects()
+		if err != nil {
+			return fmt.Errorf("failed to discover workspaces: %w", err)
+		}
+		targets, err := eachTargets(projects, all)
+		if err != nil {
+			return err
+		}
+		var selected []*workspace.WorkspaceNode
+		for _, node := range targets {
+			if each.HasAnyTag(node, tags) {
+				selected = append(selected, node)
+			}
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("no projects matched")
+		}
+
+		printer := cli.GetPrinter(cmd)
+		stdout := cmd.OutOrStdout()
+		if printer.Structured() {
+			// Keep stdout for the result document.
+			stdout = cmd.ErrOrStderr()
+		}
+		results := each.Run(cmd.Context(), selected, args, each.Options{
+			Jobs:     jobs,
+			Stdout:   stdout,
+			Stderr:   cmd.ErrOrStderr(),
+			FailFast: failFast,
+		})
+
+		if err := printer.Result(results, func(w io.Writer) error {
+			return printEachSummary(w, results)
+		}); err != nil {
+			return err
+		}
+		failed := 0
+		for _, r := range results {
+			if r.Failed() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("command failed in %d of %d project(s)", failed, len(results))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// eachTargets returns the projects `core each` runs in: the non-worktree
+// members of the ecosystem containing the current directory, or every
+// non-worktree project with all.
+func eachTargets(projects []*workspace.WorkspaceNode, all bool) ([]*workspace.WorkspaceNode, error) {
+	var targets []*workspace.WorkspaceNode
+	if all {
+		for _, node := range projects {
+			if !node.IsWorktree() && !node.IsEcosystem() {
+				targets = append(targets, node)
+			}
+		}
+		return targets, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	current, err := workspace.GetProjectByPath(cwd)
+	if err != nil || current == nil {
+		return nil, fmt.Errorf("not inside a grove workspace; use --all to run in every project")
+	}
+	ecosystem := current.ParentEcosystemPath
+	if current.IsEcosystem() {
+		ecosystem = current.Path
+	}
+	if ecosystem == "" {
+		return nil, fmt.Errorf("%s is not part of an ecosystem; use --all to run in every project", current.Name)
+	}
+	for _, node := range projects {
+		if node.ParentEcosystemPath == ecosystem && !node.IsWorktree() && !node.IsEcosystem() {
+			targets = append(targets, node)
+		}
+	}
+	return targets, nil
+}
+
+func printEachSummary(w io.Writer, results []each.Result) error {
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			fmt.Fprintf(w, "  - %s: skipped\n", r.Workspace)
+			failed++
+		case r.Error != "":
+			fmt.Fprintf(w, "  ✗ %s: %s\n", r.Workspace, r.Error)
+			failed++
+		case r.ExitCode != 0:
+			fmt.Fprintf(w, "  ✗ %s: exit %d\n", r.Workspace, r.ExitCode)
+			failed++
+		}
+	}
+	fmt.Fprintf(w, "%d of %d project(s) succeeded\n", len(results)-failed, len(results))
+	return nil
+}
@@ -2,21 +2,38 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/git"
+	"github.com/grovetools/core/pkg/daemon"
 	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/pkg/procman"
+	"github.com/grovetools/core/pkg/sessions"
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/grovetools/core/tui/components/picker"
+	"github.com/grovetools/core/util/sanitize"
 )
 
 func NewEditorCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "editor [file]",
 		Short: "Open a file or directory in the dedicated editor window",
-		Long:  `Finds or creates a tmux window (default name "editor", index 1) and opens the specified file or current directory. By default, if the window exists, it is focused. New flags allow customizing the editor command, window name/index, and forcing a reset of the window.`,
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Finds or creates a tmux window (default name "editor", index 1) and opens the specified file or current directory. By default, if the window exists, it is focused. New flags allow customizing the editor command, window name/index, and forcing a reset of the window.
+
+With --workspace, the editor is launched for a workspace resolved by discovery (a name such as "api" or an identifier such as "eco:api"): it starts in the workspace directory with the GROVE_WORKSPACE* variables set, in a window named after the workspace, and [file] is relative to the workspace. Neovim is started as a server on a per-workspace socket, so later invocations attach to the running editor (opening [file] in it) instead of starting another. The editor is recorded as an interactive session and shows up in 'core sessions list'.`,
+		Example: `  core editor README.md
+  core editor --workspace api
+  core editor -w eco:api cmd/main.go`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filePath := ""
 			if len(args) > 0 {
@@ -28,6 +45,7 @@ func NewEditorCmd() *cobra.Command {
 			reset, _ := cmd.Flags().GetBool("reset")
 			windowName, _ := cmd.Flags().GetString("window-name")
 			windowIndex, _ := cmd.Flags().GetInt("window-index")
+			workspaceName, _ := cmd.Flags().GetString("workspace")
 
 			// Determine editor command
 			if editorCmdStr == "" {
@@ -38,20 +56,38 @@ func NewEditorCmd() *cobra.Command {
 			}
 
 			ctx := context.Background()
+
+			if workspaceName != "" {
+				node, err := resolveNamedWorkspace(cmd, workspaceName)
+				if err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("window-name") {
+					windowName = "editor-" + node.Identifier("_")
+				}
+				return launchWorkspaceEditor(ctx, workspaceEditor{
+					node:        node,
+					editor:      editorCmdStr,
+					file:        filePath,
+					windowName:  windowName,
+					windowIndex: windowIndex,
+					reset:       reset,
+				})
+			}
+
 			engine, err := mux.DetectMuxEngine(ctx)
 			if err != nil {
 				// Not in a mux session, just open the editor normally.
 				fullCommand := editorCmdStr
 				if filePath != "" {
-					quotedPath := "'" + strings.ReplaceAll(filePath, "'", `'\''`) + "'"
-					fullCommand += " " + quotedPath
+					fullCommand += " " + sanitize.ForShell(filePath)
 				}
 
 				editorCmd := exec.Command("sh", "-c", fullCommand)
 				editorCmd.Stdin = os.Stdin
 				editorCmd.Stdout = os.Stdout
 				editorCmd.Stderr = os.Stderr
-				return editorCmd.Run()
+				return procman.Default().Run(editorCmd, procman.Options{Name: "editor", StopOrder: procman.OrderEditor})
 			}
 
 			tuiEngine, ok := engine.(mux.MuxTUIEngine)
@@ -71,8 +107,244 @@ func NewEditorCmd() *cobra.Command {
 
 	cmd.Flags().String("cmd", "", "Custom editor command to execute. The file path will be appended if provided. Defaults to $EDITOR or 'nvim'.")
 	cmd.Flags().Bool("reset", false, "If the editor window exists, kill it and start a fresh session.")
-	cmd.Flags().String("window-name", "editor", "Name of the target tmux window.")
+	cmd.Flags().String("window-name", "editor", "Name of the target tmux window. With --workspace, defaults to one window per workspace.")
 	cmd.Flags().Int("window-index", 1, "Index (position) for the editor window.")
+	cmd.Flags().StringP("workspace", "w", "", "Workspace name or identifier to open the editor in")
 
 	return cmd
 }
+
+// resolveNamedWorkspace finds the workspace named by name (a name or a
+// colon identifier), preferring matches in the current directory's
+// ecosystem. When the name is ambiguous, or matches nothing but has close
+// matches, the user picks one on an interactive terminal.
+func resolveNamedWorkspace(cmd *cobra.Command, name string) (*workspace.WorkspaceNode, error) {
+	result, err := workspace.NewDiscoveryService(cli.GetLogger(cmd)).DiscoverAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	provider := workspace.NewProvider(result)
+	cwd, _ := os.Getwd()
+	node, reason := provider.FindByIdentifierWithInfo(name, cwd)
+	if reason == workspace.MatchedByFallback && cli.CanPrompt(cmd) {
+		var same []*workspace.WorkspaceNode
+		for _, n := range provider.All() {
+			if n.Name == name {
+				same = append(same, n)
+			}
+		}
+		return pickWorkspace(cmd, fmt.Sprintf("Several workspaces are named '%s'", name), same, cli.PickOne)
+	}
+	if node != nil {
+		return node, nil
+	}
+
+	matches := provider.Fuzzy(name)
+	if len(matches) > 0 && cli.CanPrompt(cmd) {
+		return pickWorkspace(cmd, fmt.Sprintf("No workspace '%s'; pick a close match", name), matches, cli.PickFuzzy)
+	}
+
+	var suggestions []string
+	seen := make(map[string]bool)
+	for _, node := range matches {
+		if seen[node.Name] {
+			continue
+		}
+		seen[node.Name] = true
+		suggestions = append(suggestions, node.Name)
+		if len(suggestions) == 5 {
+			break
+		}
+	}
+	if len(suggestions) > 0 {
+		return nil, fmt.Errorf("workspace not found: '%s'. Did you mean: %s?", name, strings.Join(suggestions, ", "))
+	}
+	return nil, fmt.Errorf("workspace not found: '%s'", name)
+}
+
+// pickWorkspace asks the user to choose one of nodes, listed by
+// identifier with their paths and descriptions.
+func pickWorkspace(cmd *cobra.Command, title string, nodes []*workspace.WorkspaceNode, pick func(*cobra.Command, string, []picker.Item) (int, error)) (*workspace.WorkspaceNode, error) {
+	items := make([]picker.Item, len(nodes))
+	for i, n := range nodes {
+		detail := n.Path
+		if n.Metadata != nil && n.Metadata.Description != "" {
+			detail += " · " + n.Metadata.Description
+		}
+		items[i] = picker.Item{Label: n.Identifier(":"), Detail: detail}
+	}
+	i, err := pick(cmd, title, items)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[i], nil
+}
+
+// workspaceEditor describes an editor launch into a workspace.
+type workspaceEditor struct {
+	node        *workspace.WorkspaceNode
+	editor      string
+	file        string
+	windowName  string
+	windowIndex int
+	reset       bool
+}
+
+// launchWorkspaceEditor starts (or attaches to) the editor for a workspace,
+// in the mux window when running inside one and in the foreground
+// otherwise, and records it as an interactive session.
+func launchWorkspaceEditor(ctx context.Context, e workspaceEditor) error {
+	socket := ""
+	if isNeovim(e.editor) {
+		socket = sessions.EditorServerSocket(e.node.Identifier(":"))
+	}
+
+	// The command run in the workspace: a new editor (serving on the
+	// workspace socket for Neovim), or a UI attached to the running server
+	// with the file opened in it.
+	shellCmd := e.editor
+	file := e.file
+	switch {
+	case socket != "" && !e.reset && editorServerAlive(ctx, socket):
+		if file != "" {
+			if err := editorServerOpen(ctx, socket, e.node.Path, file); err != nil {
+				return err
+			}
+			file = ""
+		}
+		shellCmd = "nvim --server " + sanitize.ForShell(socket) + " --remote-ui"
+	case socket != "":
+		_ = os.Remove(socket)
+		if err := os.MkdirAll(filepath.Dir(socket), 0o755); err != nil {
+			return fmt.Errorf("failed to create editor socket directory: %w", err)
+		}
+		shellCmd += " --listen " + sanitize.ForShell(socket)
+	}
+	env := e.node.EnvVars()
+	editorName := filepath.Base(strings.Fields(e.editor)[0])
+
+	// Outside a multiplexer the editor runs in the foreground.
+	if mux.ActiveMux() == mux.MuxNone {
+		line := shellCmd
+		if file != "" {
+			line += " " + sanitize.ForShell(file)
+		}
+		return runWorkspaceEditor(line, editorName, e.node, env)
+	}
+
+	engine, err := mux.DetectMuxEngine(ctx)
+	if err != nil {
+		return err
+	}
+	tuiEngine, ok := engine.(mux.MuxTUIEngine)
+	if !ok {
+		return fmt.Errorf("mux engine does not support TUI operations")
+	}
+	windowCmd := "cd " + sanitize.ForShell(e.node.Path) + " && " + strings.Join(shellEnvAssignments(env), " ") + " " + shellCmd
+	if err := tuiEngine.OpenInEditorWindow(ctx, windowCmd, file, e.windowName, e.windowIndex, e.reset); err != nil {
+		return err
+	}
+	if session, err := engine.GetCurrentSession(ctx); err == nil {
+		target := session + ":" + e.windowName
+		if pid, err := engine.GetPanePID(ctx, target); err == nil && pid > 0 {
+			recordEditorSession(editorName, e.node, pid, string(mux.ActiveMux()), target)
+		}
+	}
+	_ = tuiEngine.ClosePopup(ctx)
+	return nil
+}
+
+// runWorkspaceEditor runs the editor command line in the foreground in the
+// workspace, registered as a session until it exits.
+func runWorkspaceEditor(line, editorName string, node *workspace.WorkspaceNode, env map[string]string) error {
+	editorCmd := exec.Command("sh", "-c", line)
+	editorCmd.Dir = node.Path
+	editorCmd.Env = append(os.Environ(), envList(env)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	child, err := procman.Default().Start(editorCmd, procman.Options{Name: "editor", StopOrder: procman.OrderEditor})
+	if err != nil {
+		return err
+	}
+	sessionID := recordEditorSession(editorName, node, child.PID, "", "")
+	err = child.Wait()
+	if registry, rerr := sessions.NewFileSystemRegistry(); rerr == nil && sessionID != "" {
+		_ = registry.Unregister(sessionID)
+	}
+	return err
+}
+
+// recordEditorSession registers a running editor in the session registry
+// and returns its session ID, or "" when it could not be recorded. Dead
+// entries are reaped by session recovery, so failures are not fatal.
+func recordEditorSession(editor string, node *workspace.WorkspaceNode, pid int, muxName, target string) string {
+	registry, err := sessions.NewFileSystemRegistry()
+	if err != nil {
+		return ""
+	}
+	meta := sessions.EditorSessionMetadata(editor, node.Name, node.Path, pid)
+	if _, branch, err := git.GetRepoInfo(node.Path); err == nil {
+		meta.Branch = branch
+	}
+	meta.Scope = daemon.ResolveClientScope()
+	meta.Mux = muxName
+	meta.TmuxTarget = target
+	if err := registry.Register(meta); err != nil {
+		return ""
+	}
+	return meta.SessionID
+}
+
+// isNeovim reports whether an editor command runs Neovim, whose server
+// mode lets later invocations attach to it.
+func isNeovim(editor string) bool {
+	fields := strings.Fields(editor)
+	return len(fields) > 0 && filepath.Base(fields[0]) == "nvim"
+}
+
+// editorServerAlive reports whether a Neovim server answers on socket.
+func editorServerAlive(ctx context.Context, socket string) bool {
+	if _, err := os.Stat(socket); err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	return exec.CommandContext(ctx, "nvim", "--server", socket, "--remote-expr", "1").Run() == nil
+}
+
+// editorServerOpen opens file (relative to dir) in the Neovim server on
+// socket.
+func editorServerOpen(ctx context.Context, socket, dir, file string) error {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if out, err := exec.CommandContext(ctx, "nvim", "--server", socket, "--remote", file).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to open %s in the running editor: %w: %s", file, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// envList renders env as sorted KEY=value entries for exec.Cmd.Env.
+func envList(env map[string]string) []string {
+	list := make([]string, 0, len(env))
+	for k, v := range env {
+		list = append(list, k+"="+v)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// shellEnvAssignments renders env as sorted, quoted KEY='value' prefixes
+// for a shell command line.
+func shellEnvAssignments(env map[string]string) []string {
+	list := make([]string, 0, len(env))
+	for k, v := range env {
+		list = append(list, k+"="+sanitize.ForShell(v))
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsNeovim(t *testing.T) {
+	for editor, want := range map[string]bool{
+		"nvim":                true,
+		"/usr/local/bin/nvim": true,
+		"nvim -u NONE":        true,
+		"vim":                 false,
+		"code --wait":         false,
+		"":                    false,
+	} {
+		if got := isNeovim(editor); got != want {
+			t.Errorf("isNeovim(%q) = %v, want %v", editor, got, want)
+		}
+	}
+}
+
+func TestShellEnvAssignments(t *testing.T) {
+	got := shellEnvAssignments(map[string]string{
+		"GROVE_WORKSPACE_PATH": "/src/it's here",
+		"GROVE_WORKSPACE":      "api",
+	})
+	want := []string{`GROVE_WORKSPACE='api'`, `GROVE_WORKSPACE_PATH='/src/it'\''s here'`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("shellEnvAssignments = %q, want %q", got, want)
+	}
+}
@@ -25,8 +25,9 @@ func NewLogsCmd() *cobra.Command {
 		Use:   "logs",
 		Short: "Aggregate and display logs from Grove workspaces",
 		Long: `Streams logs from one or more workspaces. By default, shows logs from the
-current workspace only at level info and above (use --level debug to
-include debug entries).
+workspace containing the current directory (found by walking up to the
+nearest grove config) at level info and above (use --level debug to include
+debug entries).
 
 Examples:
   # Stream current workspace logs
@@ -56,25 +57,48 @@ Examples:
   # Filter to a single component
   core logs --component groved.server -f
 
-  # Specific workspaces
+  # Errors with the 3 entries around each one, like grep -C
+  core logs --level error -C 3
+
+  # Hide debug and metrics fields (tagged through _verbosity)
+  core logs --verbosity verbose -f
+
+  # Specific workspaces, by name or path
   core logs -w api,worker -f
+  core logs -w ../api -f
+
+  # Any log files, labelled and merged by time
+  core logs --file api=./api.log --file ./worker.log -f
+  core logs --glob 'svc=/var/log/svc/*.log' --tail 100
+
+  # Files alongside the current workspace's logs
+  core logs --scope workspace --file proxy=/tmp/proxy.log -f
 
   # Styled output, last 100 lines
   core logs --format pretty --tail 100
+
+  # Interactive viewer without last session's filters and cursor
+  core logs -i --fresh
 `,
 		RunE: runLogsE,
 	}
 
 	// Scope
 	cmd.Flags().String("scope", "workspace", "Log scope: workspace, ecosystem, all, system, daemon")
-	cmd.Flags().StringSliceP("workspace", "w", []string{}, "Filter to specific workspace names (comma-separated)")
+	cmd.Flags().StringSliceP("workspace", "w", []string{}, "Show these workspaces, by name or path (comma-separated)")
 	cmd.Flags().Bool("system", false, "Include system logs alongside workspace scope")
+	cmd.Flags().StringArray("file", []string{}, "Also read this log file, optionally labelled as label=path (repeatable)")
+	cmd.Flags().StringArray("glob", []string{}, "Also read the log files matching this pattern, optionally labelled as label=pattern (repeatable)")
 
 	// Filtering
-	cmd.Flags().String("level", "", "Minimum log level: debug, info, warn, error (default: info)")
+	cmd.Flags().String("level", "", "Minimum log level: trace, debug, info, warn, error (default: info)")
 	cmd.Flags().StringSlice("component", []string{}, "Show only these components (comma-separated whitelist)")
 	cmd.Flags().Bool("show-all", false, "Ignore all configured hide/show rules")
+	cmd.Flags().String("verbosity", "", "Highest field verbosity to show: basic, verbose, debug, metrics (default: all)")
 	cmd.Flags().Bool("events", false, "Show only lifecycle events (entries with an event field) plus warn/error")
+	cmd.Flags().IntP("context", "C", 0, "Show N filtered-out entries around each match from the same workspace")
+	cmd.Flags().IntP("before-context", "B", 0, "Show N filtered-out entries before each match")
+	cmd.Flags().IntP("after-context", "A", 0, "Show N filtered-out entries after each match")
 
 	// Output
 	cmd.Flags().BoolP("follow", "f", false, "Follow log output")
@@ -85,12 +109,21 @@ Examples:
 
 	// Mode
 	cmd.Flags().BoolP("tui", "i", false, "Launch the interactive TUI")
+	cmd.Flags().Bool("fresh", false, "With --tui, start without restoring the view state saved by the last session")
+
+	cmd.AddCommand(newLogsSetLevelCmd())
+	cmd.AddCommand(newLogsReplayCmd())
+	cmd.AddCommand(newLogsGrepCmd())
+	cmd.AddCommand(newLogsOpenInBrowserCmd())
+	cmd.AddCommand(newLogsConvertCmd())
+	cmd.AddCommand(newLogsSummarizeCmd())
 
 	return cmd
 }
 
 // validLevels maps level name to its severity rank for threshold filtering.
 var validLevels = map[string]int{
+	"trace":   -1,
 	"debug":   0,
 	"info":    1,
 	"warn":    2,
@@ -107,7 +140,7 @@ func resolveMinLevelRank(level string) (int, error) {
 	}
 	rank, ok := validLevels[strings.ToLower(level)]
 	if !ok {
-		return 0, fmt.Errorf("invalid --level %q: must be debug, info, warn, or error", level)
+		return 0, fmt.Errorf("invalid --level %q: must be trace, debug, info, warn, or error", level)
 	}
 	return rank, nil
 }
@@ -153,6 +186,10 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 	eventsOnly, _ := cmd.Flags().GetBool("events")
 	follow, _ := cmd.Flags().GetBool("follow")
 	tuiMode, _ := cmd.Flags().GetBool("tui")
+	verbosityFlag, _ := cmd.Flags().GetString("verbosity")
+	fresh, _ := cmd.Flags().GetBool("fresh")
+	fileArgs, _ := cmd.Flags().GetStringArray("file")
+	globArgs, _ := cmd.Flags().GetStringArray("glob")
 
 	// Validate scope
 	switch scope {
@@ -166,6 +203,23 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	maxVerbosity := logging.VerbosityMetrics
+	if verbosityFlag != "" {
+		if maxVerbosity, err = logging.ParseVerbosity(verbosityFlag); err != nil {
+			return err
+		}
+	}
+	beforeContext, afterContext, err := resolveContextFlags(cmd)
+	if err != nil {
+		return err
+	}
+	files, err := parseLogFileSources(fileArgs, globArgs)
+	if err != nil {
+		return err
+	}
+	// --file and --glob replace the workspace logs unless --scope or -w
+	// asks for them as well.
+	filesOnly := logFilesOnly(files, cmd.Flags().Changed)
 
 	// -w implies ecosystem scope for workspace discovery
 	if len(wsFilter) > 0 && !cmd.Flags().Changed("scope") {
@@ -180,61 +234,27 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 	}
 	stats := &filterStats{}
 
-	var workspaces []*workspace.WorkspaceNode
+	if opts.YAMLOutput {
+		return fmt.Errorf("--yaml is not supported for log streams; use --json for one JSON object per line")
+	}
 
 	if scope == "daemon" {
 		return fmt.Errorf("--scope daemon is not yet supported in CLI mode; use the TUI (core logs -i --scope daemon)")
 	}
 
-	// Determine which workspaces to show
-	if systemOnly {
-		workspaces = []*workspace.WorkspaceNode{}
-	} else if scope == "ecosystem" || scope == "all" || len(wsFilter) > 0 {
-		allWorkspaces, err := workspace.GetProjects(logger)
-		if err != nil {
-			return fmt.Errorf("failed to discover workspaces: %w", err)
-		}
-
-		if len(wsFilter) > 0 {
-			filterMap := make(map[string]bool)
-			for _, w := range wsFilter {
-				filterMap[w] = true
-			}
-			for _, ws := range allWorkspaces {
-				if filterMap[ws.Name] {
-					workspaces = append(workspaces, ws)
-				}
-			}
-		} else {
-			workspaces = allWorkspaces
-		}
-	} else {
-		// Default: current workspace
-		cwd, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-
-		wsName := filepath.Base(cwd)
-		if cfg, err := config.LoadFrom(cwd); err == nil && cfg.Name != "" {
-			wsName = cfg.Name
+	var workspaces []*workspace.WorkspaceNode
+	if !filesOnly {
+		if workspaces, err = resolveLogWorkspaces(logger, scope, wsFilter); err != nil {
+			return err
 		}
-
-		workspaces = []*workspace.WorkspaceNode{
-			{
-				Path: cwd,
-				Name: wsName,
-			},
+		if len(workspaces) == 0 && !systemOnly && len(files) == 0 {
+			logger.Info("No matching workspaces found.")
+			return nil
 		}
 	}
 
-	if len(workspaces) == 0 && !systemOnly {
-		logger.Info("No matching workspaces found.")
-		return nil
-	}
-
 	if tuiMode {
-		return runLogsTUI(workspaces, follow, overrideOpts, scope, includeSystem, level, eventsOnly)
+		return runLogsTUI(workspaces, files, follow, overrideOpts, scope, includeSystem, level, eventsOnly, max(beforeContext, afterContext), verbosityFlag, !fresh, cmd.Flags().Changed)
 	}
 
 	// --- Non-TUI file tailing mode ---
@@ -254,8 +274,12 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 		format = "json"
 	}
 
+	var sources []logSource
+	progress := cli.NewProgress(cmd, "Locating log files", len(workspaces))
 	for _, ws := range workspaces {
+		progress.Describe(ws.Name)
 		logFile, logsDir, err := logutil.FindLogFileForWorkspace(ws)
+		progress.Add(1)
 		if err != nil {
 			if follow && logsDir != "" {
 				logger.WithFields(logrus.Fields{
@@ -263,8 +287,7 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 					"logs_dir":  logsDir,
 				}).Debug("Waiting for log files in directory")
 
-				wg.Add(1)
-				go logutil.TailDirectory(cmd.Context(), ws.Name, ws.Path, logsDir, lineChan, &wg, follow, tail)
+				sources = append(sources, logSource{name: ws.Name, wsPath: ws.Path, dir: logsDir, follow: follow})
 				continue
 			}
 			logger.WithField("workspace", ws.Name).Debugf("Skipping: %v", err)
@@ -276,41 +299,76 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 			"log_file":  logFile,
 		}).Debug("Tailing log file")
 
-		wg.Add(1)
 		if follow {
-			go logutil.TailDirectory(cmd.Context(), ws.Name, ws.Path, logsDir, lineChan, &wg, follow, tail)
+			sources = append(sources, logSource{name: ws.Name, wsPath: ws.Path, dir: logsDir, follow: follow})
 		} else {
-			go logutil.TailFile(cmd.Context(), ws.Name, ws.Path, logFile, lineChan, &wg, follow, tail)
+			sources = append(sources, logSource{name: ws.Name, wsPath: ws.Path, file: logFile})
 		}
 	}
 
+	progress.Done()
+
 	// Also tail system logs when scope includes them
 	systemLogsDir := filepath.Join(paths.StateDir(), "logs")
 	if _, err := os.Stat(systemLogsDir); err == nil {
-		wg.Add(1)
-		if follow || systemOnly {
-			go logutil.TailDirectory(cmd.Context(), "system", "", systemLogsDir, lineChan, &wg, follow || systemOnly, tail)
-		} else {
+		switch {
+		case filesOnly && !includeSystem:
+		case follow || systemOnly:
+			sources = append(sources, logSource{name: "system", dir: systemLogsDir, follow: true})
+		default:
 			if sysLogFile, err := logutil.FindLatestLogFile(systemLogsDir); err == nil {
-				go logutil.TailFile(cmd.Context(), "system", "", sysLogFile, lineChan, &wg, follow, tail)
-			} else {
-				wg.Done()
+				sources = append(sources, logSource{name: "system", file: sysLogFile})
 			}
 		}
-	} else if systemOnly {
+	} else if systemOnly && len(files) == 0 {
 		logger.Info("No system logs found yet.")
 		return nil
 	}
 
-	go func() {
-		wg.Wait()
-		close(lineChan)
-	}()
+	for _, f := range files {
+		logger.WithFields(logrus.Fields{
+			"label":    f.label,
+			"log_file": f.path,
+		}).Debug("Tailing log file")
+		sources = append(sources, logSource{name: f.label, file: f.path, follow: follow})
+	}
+
+	// Without -f, the files are read up front so entries from several of
+	// them come out in the order they were logged.
+	if canMergeLogSources(sources) {
+		if err := readMergedLogSources(sources, tail, lineChan); err != nil {
+			return err
+		}
+	} else {
+		for _, s := range sources {
+			s.start(cmd.Context(), lineChan, &wg, tail)
+		}
+		go func() {
+			wg.Wait()
+			close(lineChan)
+		}()
+	}
 
 	wsNameSet := make(map[string]bool, len(workspaces))
 	for _, w := range workspaces {
 		wsNameSet[w.Name] = true
 	}
+	fileLabels := make(map[string]bool, len(files))
+	for _, f := range files {
+		fileLabels[f.label] = true
+	}
+
+	outputFormat := format
+	if opts.JSONOutput {
+		outputFormat = "json"
+	}
+	surrounding := newLogContext(beforeContext, afterContext)
+	filter := &logEntryFilter{
+		minLevelRank: minLevelRank,
+		eventsOnly:   eventsOnly,
+		logCfg:       &logCfg,
+		overrides:    overrideOpts,
+	}
 
 	for tailedLine := range lineChan {
 		stats.total++
@@ -322,58 +380,41 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		// System log filtering
-		if tailedLine.Workspace == "system" {
-			wsContext, _ := logMap["workspace"].(string)
-			if !systemOnly {
-				if wsContext != "" {
-					if !wsNameSet[wsContext] {
-						continue
-					}
-				} else if !includeSystem && scope != "ecosystem" && scope != "all" {
-					continue
-				}
-			}
-		} else if systemOnly {
+		logMap = logging.FilterVerbosity(logMap, maxVerbosity)
+
+		if !fileLabels[tailedLine.Workspace] && !keepSystemEntry(tailedLine.Workspace, logMap, scope, includeSystem, wsNameSet) {
 			continue
 		}
 
-		// Level filtering
-		if minLevelRank >= 0 {
-			if entryLevel, ok := logMap["level"].(string); ok {
-				entryRank, known := validLevels[strings.ToLower(entryLevel)]
-				if known && entryRank < minLevelRank {
-					continue
-				}
-			}
+		matched, hidden := filter.match(logMap)
+		if hidden != nil {
+			stats.hidden++
+			stats.lastReason = hidden.Reason
+			stats.lastRule = hidden.Rule
 		}
 
-		// Events-only filtering: keep lifecycle events and warn/error
-		if eventsOnly && !passesEventsFilter(logMap) {
+		if surrounding == nil {
+			if matched {
+				stats.shown++
+				fmt.Print(logutil.FormatLogLine(logMap, tailedLine.Workspace, outputFormat, compact))
+			}
 			continue
 		}
 
-		// Component visibility filtering
-		if component, ok := logMap["component"].(string); ok {
-			result := logging.GetComponentVisibility(component, &logCfg, overrideOpts)
-			if !result.Visible {
-				stats.hidden++
-				stats.lastReason = result.Reason
-				stats.lastRule = result.Rule
-				continue
-			}
+		// With -C/-B/-A, filtered-out entries near a match are printed too,
+		// and non-adjacent groups are separated by "--" as in grep. JSON
+		// output omits the separator so it stays one object per line.
+		entries, separator := surrounding.add(tailedLine.Workspace, logMap, matched)
+		if separator && outputFormat != "json" {
+			fmt.Println("--")
 		}
-		stats.shown++
-
-		outputFormat := format
-		if opts.JSONOutput {
-			outputFormat = "json"
+		for _, entry := range entries {
+			stats.shown++
+			fmt.Print(logutil.FormatLogLine(entry, tailedLine.Workspace, outputFormat, compact))
 		}
-
-		fmt.Print(logutil.FormatLogLine(logMap, tailedLine.Workspace, outputFormat, compact))
 	}
 
-	if !follow && stats.hidden > 0 {
+	if !follow && stats.hidden > 0 && !opts.Quiet {
 		reasonStr := strings.ReplaceAll(string(stats.lastReason), "_", " ")
 		ruleStr := strings.Join(stats.lastRule, ", ")
 		if len(stats.lastRule) > 0 {
@@ -385,3 +426,84 @@ func runLogsE(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// resolveLogWorkspaces returns the workspaces whose logs scope covers: none
+// for system scope, the --workspace names and paths when given, the
+// discovered workspaces for ecosystem and all scope, and otherwise the
+// workspace containing the current directory.
+func resolveLogWorkspaces(logger *logrus.Logger, scope string, wsFilter []string) ([]*workspace.WorkspaceNode, error) {
+	if scope == "system" {
+		return []*workspace.WorkspaceNode{}, nil
+	}
+	if len(wsFilter) > 0 {
+		return resolveWorkspaceArgs(logger, wsFilter)
+	}
+	if scope == "ecosystem" || scope == "all" {
+		allWorkspaces, err := workspace.GetProjects(logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+		}
+		return allWorkspaces, nil
+	}
+
+	// Default: the workspace containing the current directory
+	ws, err := currentWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	return []*workspace.WorkspaceNode{ws}, nil
+}
+
+// keepSystemEntry reports whether an entry read from source belongs in the
+// output. System log entries are kept in system scope; otherwise those
+// logged for a workspace are kept when that workspace is shown, and the
+// rest with --system or in ecosystem and all scope. Workspace log entries
+// are dropped in system scope.
+func keepSystemEntry(source string, logMap map[string]interface{}, scope string, includeSystem bool, wsNameSet map[string]bool) bool {
+	systemOnly := scope == "system"
+	if source != "system" {
+		return !systemOnly
+	}
+	if systemOnly {
+		return true
+	}
+	if wsContext, _ := logMap["workspace"].(string); wsContext != "" {
+		return wsNameSet[wsContext]
+	}
+	return includeSystem || scope == "ecosystem" || scope == "all"
+}
+
+// logEntryFilter is the per-entry filter of `core logs` and `core logs
+// grep`: the --level threshold, --events, and component visibility.
+type logEntryFilter struct {
+	minLevelRank int
+	eventsOnly   bool
+	logCfg       *logging.Config
+	overrides    *logging.OverrideOptions
+}
+
+// match reports whether logMap passes the filter. When component
+// visibility rejected it, hidden holds the rule that did.
+func (f *logEntryFilter) match(logMap map[string]interface{}) (matched bool, hidden *logging.VisibilityResult) {
+	if f.minLevelRank >= 0 {
+		if entryLevel, ok := logMap["level"].(string); ok {
+			entryRank, known := validLevels[strings.ToLower(entryLevel)]
+			if known && entryRank < f.minLevelRank {
+				return false, nil
+			}
+		}
+	}
+
+	// Events-only filtering: keep lifecycle events and warn/error
+	if f.eventsOnly && !passesEventsFilter(logMap) {
+		return false, nil
+	}
+
+	if component, ok := logMap["component"].(string); ok {
+		result := logging.GetComponentVisibility(component, f.logCfg, f.overrides)
+		if !result.Visible {
+			return false, &result
+		}
+	}
+	return true, nil
+}
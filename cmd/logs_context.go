@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// logContext implements grep-style -B/-A context for `core logs`: entries
+// rejected by the level, events or component filters are printed when they
+// sit within N entries of a match in the same stream. Streams are keyed by
+// workspace, since lines from several tailers arrive interleaved.
+type logContext struct {
+	before, after int
+	streams       map[string]*streamContext
+}
+
+// streamContext tracks one stream's position and pending context.
+type streamContext struct {
+	// seq numbers entries as they arrive; lastPrinted is the seq of the
+	// most recently printed entry, used to place group separators.
+	seq, lastPrinted int
+	// afterLeft counts trailing context entries still owed to a match.
+	afterLeft int
+	// buffered holds up to before rejected entries preceding the next match.
+	buffered []map[string]interface{}
+}
+
+// newLogContext returns nil when no context was requested.
+func newLogContext(before, after int) *logContext {
+	if before <= 0 && after <= 0 {
+		return nil
+	}
+	return &logContext{
+		before:  max(before, 0),
+		after:   max(after, 0),
+		streams: make(map[string]*streamContext),
+	}
+}
+
+// add records the next entry of stream and returns the entries to print now,
+// in order, plus whether a "--" separator should precede them because they
+// do not directly follow the previously printed entry of that stream.
+func (c *logContext) add(stream string, logMap map[string]interface{}, matched bool) ([]map[string]interface{}, bool) {
+	s := c.streams[stream]
+	if s == nil {
+		s = &streamContext{}
+		c.streams[stream] = s
+	}
+	s.seq++
+
+	var out []map[string]interface{}
+	switch {
+	case matched:
+		out = append(s.buffered, logMap)
+		s.buffered = nil
+		s.afterLeft = c.after
+	case s.afterLeft > 0:
+		s.afterLeft--
+		out = []map[string]interface{}{logMap}
+	default:
+		if c.before > 0 {
+			s.buffered = append(s.buffered, logMap)
+			if len(s.buffered) > c.before {
+				s.buffered = s.buffered[1:]
+			}
+		}
+		return nil, false
+	}
+
+	first := s.seq - len(out) + 1
+	separator := s.lastPrinted > 0 && first != s.lastPrinted+1
+	s.lastPrinted = s.seq
+	return out, separator
+}
+
+// resolveContextFlags reads -C/-B/-A. -C sets both sides; an explicit -B or
+// -A overrides its side.
+func resolveContextFlags(cmd *cobra.Command) (before, after int, err error) {
+	around, _ := cmd.Flags().GetInt("context")
+	before, after = around, around
+	if cmd.Flags().Changed("before-context") {
+		before, _ = cmd.Flags().GetInt("before-context")
+	}
+	if cmd.Flags().Changed("after-context") {
+		after, _ = cmd.Flags().GetInt("after-context")
+	}
+	if before < 0 || after < 0 {
+		return 0, 0, fmt.Errorf("context line counts must not be negative")
+	}
+	return before, after, nil
+}
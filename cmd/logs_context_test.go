@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogContext(t *testing.T) {
+	c := newLogContext(1, 1)
+	entry := func(msg string) map[string]interface{} { return map[string]interface{}{"msg": msg} }
+
+	// A stream of a b [c] d e f [g] h, with matches in brackets. Each step
+	// is the messages printed and whether a separator precedes them.
+	steps := []struct {
+		msg     string
+		matched bool
+		want    []string
+		sep     bool
+	}{
+		{"a", false, nil, false},
+		{"b", false, nil, false},
+		{"c", true, []string{"b", "c"}, false},
+		{"d", false, []string{"d"}, false},
+		{"e", false, nil, false},
+		{"f", false, nil, false},
+		{"g", true, []string{"f", "g"}, true},
+		{"h", false, []string{"h"}, false},
+	}
+	for _, s := range steps {
+		got, sep := c.add("api", entry(s.msg), s.matched)
+		var msgs []string
+		for _, e := range got {
+			msgs = append(msgs, e["msg"].(string))
+		}
+		if !reflect.DeepEqual(msgs, s.want) || sep != s.sep {
+			t.Errorf("after %q: printed %v (sep=%v), want %v (sep=%v)", s.msg, msgs, sep, s.want, s.sep)
+		}
+	}
+
+	// Streams are independent: a match in another workspace does not pull
+	// in this stream's buffered entries.
+	c.add("api", entry("i"), false)
+	if got, _ := c.add("worker", entry("w"), true); len(got) != 1 {
+		t.Errorf("other stream printed %d entries, want only its match", len(got))
+	}
+}
+
+func TestNewLogContextDisabled(t *testing.T) {
+	if newLogContext(0, 0) != nil {
+		t.Error("no context flags should disable context tracking")
+	}
+}
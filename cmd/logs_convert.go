@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/logging"
+)
+
+// logConversion is the structured output of `logs convert --in-place`, one
+// per file.
+type logConversion struct {
+	File   string `json:"file"`
+	Backup string `json:"backup"`
+	logging.TextConversion
+}
+
+// newLogsConvertCmd creates the `logs convert` subcommand.
+func newLogsConvertCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"convert <file...>",
+		"Convert text-format log files to JSON",
+	)
+	cmd.Long = `Parses log files written in the text format (logging.file.format: text) back
+into JSON entries, the format 'core logs', the TUI and the daemon stream read,
+so a project that started with text logging can migrate its history.
+
+Each "<time> [LEVEL] [component] message key=value..." line becomes one entry
+with time, level, component, msg and the trailing fields, numbers and
+booleans typed. Lines that do not start an entry (multi-line messages, stack
+traces) are joined to the entry before them, and lines that are already JSON
+are copied through. Field values were written without quoting, so a value
+containing spaces runs up to the next key=.
+
+Timestamps are read with logging.time_format and logging.timezone from the
+configuration; override them with --time-format and --timezone when the
+files were written under other settings.
+
+The JSON is written to stdout, or with --in-place back to each file, whose
+original is kept next to it with a .bak suffix. Use "-" to read stdin.`
+	cmd.Example = `  core logs convert --from text --to json .grove/logs/workspace-2026-01-02.log > converted.log
+  core logs convert --in-place .grove/logs/*.log
+  core logs convert --timezone utc - < old.log`
+	cmd.Args = cobra.MinimumNArgs(1)
+	cmd.Flags().String("from", "text", "Input format (text)")
+	cmd.Flags().String("to", "json", "Output format (json)")
+	cmd.Flags().Bool("in-place", false, "Rewrite each file, keeping the original as <file>.bak")
+	cmd.Flags().String("time-format", "", "logging.time_format the files were written with (default: from config)")
+	cmd.Flags().String("timezone", "", "logging.timezone the files were written in (default: from config)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		inPlace, _ := cmd.Flags().GetBool("in-place")
+		if from != "text" || to != "json" {
+			return fmt.Errorf("unsupported conversion %s -> %s: only --from text --to json is supported", from, to)
+		}
+
+		logCfg := logging.GetDefaultLoggingConfig()
+		if c, err := config.LoadDefault(); err == nil {
+			_ = c.UnmarshalExtension("logging", &logCfg)
+		}
+		if cmd.Flags().Changed("time-format") {
+			logCfg.TimeFormat, _ = cmd.Flags().GetString("time-format")
+		}
+		if cmd.Flags().Changed("timezone") {
+			logCfg.Timezone, _ = cmd.Flags().GetString("timezone")
+		}
+		loc, err := logging.LoadTimezone(logCfg.Timezone)
+		if err != nil {
+			return err
+		}
+
+		if !inPlace {
+			out := cmd.OutOrStdout()
+			for _, file := range args {
+				if err := convertLogFile(file, out, logCfg.TimeFormat, loc, cmd.ErrOrStderr()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		var results []logConversion
+		for _, file := range args {
+			if file == "-" {
+				return fmt.Errorf("--in-place cannot rewrite stdin")
+			}
+			result, err := convertLogFileInPlace(file, logCfg.TimeFormat, loc)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return cli.GetPrinter(cmd).Result(results, func(out io.Writer) error {
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "FILE\tENTRIES\tJOINED\tJSON\tSKIPPED\tBACKUP")
+			for _, r := range results {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", r.File, r.Entries, r.Continued, r.Passthrough, r.Skipped, r.Backup)
+			}
+			return w.Flush()
+		})
+	}
+
+	return cmd
+}
+
+// convertLogFile writes the JSON conversion of file ("-" for stdin) to out,
+// warning on stderr about lines it had to drop.
+func convertLogFile(file string, out io.Writer, timeFormat string, loc *time.Location, stderr io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	stats, err := logging.ConvertTextLog(in, out, timeFormat, loc)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", file, err)
+	}
+	if stats.Skipped > 0 {
+		fmt.Fprintf(stderr, "warning: %s: skipped %d lines before the first entry\n", file, stats.Skipped)
+	}
+	return nil
+}
+
+// convertLogFileInPlace replaces file with its JSON conversion, keeping the
+// original as file.bak. The conversion is written to a temporary file
+// first, so a failure leaves the original untouched.
+func convertLogFileInPlace(file string, timeFormat string, loc *time.Location) (logConversion, error) {
+	result := logConversion{File: file, Backup: file + ".bak"}
+	in, err := os.Open(file)
+	if err != nil {
+		return result, err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return result, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".convert-*")
+	if err != nil {
+		return result, err
+	}
+	defer os.Remove(tmp.Name())
+	result.TextConversion, err = logging.ConvertTextLog(in, tmp, timeFormat, loc)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to convert %s: %w", file, err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return result, err
+	}
+	if err := os.Rename(file, result.Backup); err != nil {
+		return result, err
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/logging/logutil"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// newLogsGrepCmd creates the `logs grep` subcommand.
+func newLogsGrepCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"grep <pattern>",
+		"Search log entries, optionally within specific fields",
+	)
+	cmd.Long = `Searches the latest log file of each workspace in scope for entries matching
+a regular expression, and prints them oldest first without following.
+
+With --field, only the named fields are searched (repeatable; msg is the
+message). Without it, the message and every field value are searched. Lines
+that are not JSON are searched as plain text when no --field is given.
+
+Entries are read and filtered as 'core logs' does: --scope, -w, --level,
+--component, --events and the configured component visibility rules apply.
+Unlike 'core logs', --level defaults to trace, so every level is searched.
+--since and --until select a window, as RFC 3339 times or durations ago
+(30m, 2h).
+
+Use -o json (or --json) for one JSON object per matching entry.`
+	cmd.Example = `  core logs grep timeout --field msg --component db --since 2h
+  core logs grep -i 'connection (reset|refused)' --scope ecosystem
+  core logs grep -F 'job.failed' --field event -o json
+  core logs grep --invert-match heartbeat --level warn`
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.Flags().StringSlice("field", nil, "Only search these fields (repeatable; default: message and all fields)")
+	cmd.Flags().BoolP("ignore-case", "i", false, "Match case-insensitively")
+	cmd.Flags().BoolP("fixed-strings", "F", false, "Treat the pattern as a literal string, not a regular expression")
+	cmd.Flags().Bool("invert-match", false, "Print entries that do not match")
+	cmd.Flags().String("since", "", "Only entries logged at or after this time (RFC 3339 or a duration ago, e.g. 2h)")
+	cmd.Flags().String("until", "", "Only entries logged at or before this time (RFC 3339 or a duration ago)")
+
+	// Scope and filtering, as for `core logs`
+	cmd.Flags().String("scope", "workspace", "Log scope: workspace, ecosystem, all, system")
+	cmd.Flags().StringSliceP("workspace", "w", []string{}, "Search these workspaces, by name or path (comma-separated)")
+	cmd.Flags().Bool("system", false, "Include system logs alongside workspace scope")
+	cmd.Flags().String("level", "trace", "Minimum log level: trace, debug, info, warn, error")
+	cmd.Flags().StringSlice("component", []string{}, "Show only these components (comma-separated whitelist)")
+	cmd.Flags().Bool("show-all", false, "Ignore all configured hide/show rules")
+	cmd.Flags().Bool("events", false, "Show only lifecycle events (entries with an event field) plus warn/error")
+
+	// Output
+	cmd.Flags().StringP("output", "o", "text", "Output format: text, json, full, rich, pretty, pretty-text")
+	cmd.Flags().Bool("compact", false, "Disable spacing between entries (pretty/full/rich)")
+
+	cmd.RunE = runLogsGrepE
+	return cmd
+}
+
+func runLogsGrepE(cmd *cobra.Command, args []string) error {
+	fields, _ := cmd.Flags().GetStringSlice("field")
+	ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+	fixed, _ := cmd.Flags().GetBool("fixed-strings")
+	invert, _ := cmd.Flags().GetBool("invert-match")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	scope, _ := cmd.Flags().GetString("scope")
+	wsFilter, _ := cmd.Flags().GetStringSlice("workspace")
+	includeSystem, _ := cmd.Flags().GetBool("system")
+	level, _ := cmd.Flags().GetString("level")
+	showOnly, _ := cmd.Flags().GetStringSlice("component")
+	showAll, _ := cmd.Flags().GetBool("show-all")
+	eventsOnly, _ := cmd.Flags().GetBool("events")
+	format, _ := cmd.Flags().GetString("output")
+	compact, _ := cmd.Flags().GetBool("compact")
+
+	matcher, err := newGrepMatcher(args[0], fields, ignoreCase, fixed, invert)
+	if err != nil {
+		return err
+	}
+	since, err := parseReplayTime("--since", sinceFlag)
+	if err != nil {
+		return err
+	}
+	until, err := parseReplayTime("--until", untilFlag)
+	if err != nil {
+		return err
+	}
+	switch scope {
+	case "workspace", "ecosystem", "all", "system":
+	default:
+		return fmt.Errorf("invalid --scope %q: must be workspace, ecosystem, all, or system", scope)
+	}
+	minLevelRank, err := resolveMinLevelRank(level)
+	if err != nil {
+		return err
+	}
+	if cli.GetOptions(cmd).JSONOutput {
+		format = "json"
+	}
+	if len(wsFilter) > 0 && !cmd.Flags().Changed("scope") {
+		scope = "ecosystem"
+	}
+
+	logCfg := logging.GetDefaultLoggingConfig()
+	if cfg, err := config.LoadDefault(); err == nil {
+		_ = cfg.UnmarshalExtension("logging", &logCfg)
+	}
+	filter := &logEntryFilter{
+		minLevelRank: minLevelRank,
+		eventsOnly:   eventsOnly,
+		logCfg:       &logCfg,
+		overrides:    &logging.OverrideOptions{ShowAll: showAll, ShowOnly: showOnly},
+	}
+
+	workspaces, err := resolveLogWorkspaces(cli.GetLogger(cmd), scope, wsFilter)
+	if err != nil {
+		return err
+	}
+	entries, err := loadGrepEntries(workspaces)
+	if err != nil {
+		return err
+	}
+	entries = logutil.FilterReplayWindow(entries, since, until)
+
+	wsNameSet := make(map[string]bool, len(workspaces))
+	for _, w := range workspaces {
+		wsNameSet[w.Name] = true
+	}
+	out := cmd.OutOrStdout()
+	for _, e := range entries {
+		var logMap map[string]interface{}
+		if err := json.Unmarshal([]byte(e.Line), &logMap); err != nil {
+			if matcher.matchLine(e.Line) {
+				fmt.Fprintln(out, e.Line)
+			}
+			continue
+		}
+		if !keepSystemEntry(e.Workspace, logMap, scope, includeSystem, wsNameSet) {
+			continue
+		}
+		if matched, _ := filter.match(logMap); !matched || !matcher.match(logMap) {
+			continue
+		}
+		fmt.Fprint(out, logutil.FormatLogLine(logMap, e.Workspace, format, compact))
+	}
+	return nil
+}
+
+// loadGrepEntries reads the latest log file of each workspace and of the
+// system, merged by time.
+func loadGrepEntries(workspaces []*workspace.WorkspaceNode) ([]logutil.ReplayEntry, error) {
+	var entries []logutil.ReplayEntry
+	for _, ws := range workspaces {
+		path, _, err := logutil.FindLogFileForWorkspace(ws)
+		if err != nil {
+			continue
+		}
+		fileEntries, err := logutil.ReadReplayEntries(ws.Name, ws.Path, path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	systemLogsDir := filepath.Join(paths.StateDir(), "logs")
+	if path, err := logutil.FindLatestLogFile(systemLogsDir); err == nil {
+		fileEntries, err := logutil.ReadReplayEntries("system", "", path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	logutil.SortReplayEntries(entries)
+	return entries, nil
+}
+
+// grepMatcher matches log entries against the pattern of `core logs grep`.
+type grepMatcher struct {
+	re *regexp.Regexp
+	// fields limits matching to these fields; empty means the message and
+	// every field.
+	fields []string
+	invert bool
+}
+
+func newGrepMatcher(pattern string, fields []string, ignoreCase, fixed, invert bool) (*grepMatcher, error) {
+	if fixed {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return &grepMatcher{re: re, fields: fields, invert: invert}, nil
+}
+
+// match reports whether a parsed entry is selected: whether one of the
+// searched fields matches, negated with --invert-match. A missing field
+// does not match.
+func (g *grepMatcher) match(logMap map[string]interface{}) bool {
+	found := false
+	if len(g.fields) == 0 {
+		for _, v := range logMap {
+			if g.re.MatchString(grepFieldText(v)) {
+				found = true
+				break
+			}
+		}
+	} else {
+		for _, f := range g.fields {
+			if v, ok := logMap[f]; ok && g.re.MatchString(grepFieldText(v)) {
+				found = true
+				break
+			}
+		}
+	}
+	return found != g.invert
+}
+
+// matchLine reports whether a line that is not JSON is selected. It is
+// only searched when no fields were named, since it has none.
+func (g *grepMatcher) matchLine(line string) bool {
+	if len(g.fields) > 0 {
+		return false
+	}
+	return g.re.MatchString(line) != g.invert
+}
+
+// grepFieldText renders a field value for matching: strings as they are,
+// objects and arrays as JSON, and other values with %v.
+func grepFieldText(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(val)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
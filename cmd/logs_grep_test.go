@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/grovetools/core/logging"
+)
+
+func TestGrepMatcher(t *testing.T) {
+	entry := map[string]interface{}{
+		"level":     "warn",
+		"msg":       "query timeout",
+		"component": "db",
+		"attempt":   float64(3),
+		"request":   map[string]interface{}{"path": "/api/Timeout"},
+	}
+	tests := []struct {
+		name       string
+		pattern    string
+		fields     []string
+		ignoreCase bool
+		fixed      bool
+		invert     bool
+		want       bool
+	}{
+		{name: "any field", pattern: "timeout", want: true},
+		{name: "scoped to msg", pattern: "timeout", fields: []string{"msg"}, want: true},
+		{name: "scoped to another field", pattern: "timeout", fields: []string{"component"}, want: false},
+		{name: "missing field", pattern: ".", fields: []string{"error"}, want: false},
+		{name: "number field", pattern: "^3$", fields: []string{"attempt"}, want: true},
+		{name: "nested field as json", pattern: `"path":"/api/Timeout"`, fields: []string{"request"}, want: true},
+		{name: "case sensitive", pattern: "TIMEOUT", fields: []string{"msg"}, want: false},
+		{name: "ignore case", pattern: "TIMEOUT", fields: []string{"msg"}, ignoreCase: true, want: true},
+		{name: "regexp", pattern: "query (timeout|error)", want: true},
+		{name: "fixed string", pattern: "query (timeout|error)", fixed: true, want: false},
+		{name: "invert", pattern: "timeout", fields: []string{"msg"}, invert: true, want: false},
+		{name: "invert missing field", pattern: "timeout", fields: []string{"error"}, invert: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newGrepMatcher(tt.pattern, tt.fields, tt.ignoreCase, tt.fixed, tt.invert)
+			if err != nil {
+				t.Fatalf("newGrepMatcher: %v", err)
+			}
+			if got := m.match(entry); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := newGrepMatcher("(", nil, false, false, false); err == nil {
+		t.Error("an invalid regular expression should be rejected")
+	}
+}
+
+func TestGrepMatcherPlainLine(t *testing.T) {
+	m, _ := newGrepMatcher("timeout", nil, false, false, false)
+	if !m.matchLine("panic: timeout waiting") {
+		t.Error("plain lines should be searched when no field is named")
+	}
+	m, _ = newGrepMatcher("timeout", []string{"msg"}, false, false, false)
+	if m.matchLine("panic: timeout waiting") {
+		t.Error("plain lines have no fields to search")
+	}
+}
+
+func TestLogEntryFilter(t *testing.T) {
+	f := &logEntryFilter{
+		minLevelRank: validLevels["info"],
+		logCfg:       &logging.Config{},
+		overrides:    &logging.OverrideOptions{ShowOnly: []string{"db"}},
+	}
+	if ok, _ := f.match(map[string]interface{}{"level": "debug", "component": "db"}); ok {
+		t.Error("entries below the level threshold should not match")
+	}
+	if ok, _ := f.match(map[string]interface{}{"level": "info", "component": "db"}); !ok {
+		t.Error("a whitelisted component at the threshold should match")
+	}
+	ok, hidden := f.match(map[string]interface{}{"level": "error", "component": "api"})
+	if ok || hidden == nil {
+		t.Errorf("a component outside --component should be hidden by visibility: ok=%v hidden=%v", ok, hidden)
+	}
+}
+
+func TestKeepSystemEntry(t *testing.T) {
+	shown := map[string]bool{"api": true}
+	tests := []struct {
+		name          string
+		source        string
+		logMap        map[string]interface{}
+		scope         string
+		includeSystem bool
+		want          bool
+	}{
+		{name: "workspace entry", source: "api", scope: "workspace", want: true},
+		{name: "workspace entry in system scope", source: "api", scope: "system", want: false},
+		{name: "system entry in system scope", source: "system", scope: "system", want: true},
+		{name: "system entry for a shown workspace", source: "system", logMap: map[string]interface{}{"workspace": "api"}, scope: "workspace", want: true},
+		{name: "system entry for another workspace", source: "system", logMap: map[string]interface{}{"workspace": "web"}, scope: "all", want: false},
+		{name: "unscoped system entry", source: "system", scope: "workspace", want: false},
+		{name: "unscoped system entry with --system", source: "system", scope: "workspace", includeSystem: true, want: true},
+		{name: "unscoped system entry in ecosystem scope", source: "system", scope: "ecosystem", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keepSystemEntry(tt.source, tt.logMap, tt.scope, tt.includeSystem, shown); got != tt.want {
+				t.Errorf("keepSystemEntry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/logging/logutil"
+)
+
+// logsReport is the result of `logs open-in-browser`.
+type logsReport struct {
+	Path    string `json:"path"`
+	Entries int    `json:"entries"`
+	Opened  bool   `json:"opened"`
+}
+
+// newLogsOpenInBrowserCmd creates the `logs open-in-browser` subcommand.
+func newLogsOpenInBrowserCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"open-in-browser [file...]",
+		"Render a window of log entries as an HTML report and open it",
+	)
+	cmd.Long = `Renders log entries as a standalone HTML page and opens it in the default
+browser, for sharing findings with people who do not run the TUI. Each entry
+expands to its full JSON payload, and levels are colored with the active
+theme's palette.
+
+With no files, reports on the latest log file of the workspace containing
+the current directory, of each -w workspace (by name or path), or with
+--system the latest system log. --since and --until select the window, as
+RFC 3339 times or durations ago (30m, 2h); --level, --component and
+--events narrow it further.
+
+The report is written to a temporary file unless -o names one; --no-open
+only writes it.`
+	cmd.Example = `  core logs open-in-browser --since 1h --level warn
+  core logs open-in-browser --since 2026-01-02T14:00:00Z --until 2026-01-02T14:30:00Z --component groved.server
+  core logs open-in-browser --system --since 30m -o incident.html --no-open`
+
+	cmd.Flags().String("since", "", "Include entries logged at or after this time (RFC 3339 or a duration ago, e.g. 30m)")
+	cmd.Flags().String("until", "", "Include entries logged at or before this time (RFC 3339 or a duration ago)")
+	cmd.Flags().Bool("system", false, "Report on the latest system log instead of the workspace log")
+	cmd.Flags().StringSliceP("workspace", "w", []string{}, "Report on these workspaces, by name or path (comma-separated)")
+	cmd.Flags().String("level", "", "Minimum log level: trace, debug, info, warn, error (default: info)")
+	cmd.Flags().StringSlice("component", []string{}, "Include only these components (comma-separated)")
+	cmd.Flags().Bool("events", false, "Include only lifecycle events plus warn/error")
+	cmd.Flags().String("title", "", "Report title (default: Grove logs)")
+	cmd.Flags().StringP("output", "o", "", "Write the report to this file instead of a temporary one")
+	cmd.Flags().Bool("no-open", false, "Write the report without opening a browser")
+
+	cmd.RunE = runLogsOpenInBrowserE
+	return cmd
+}
+
+func runLogsOpenInBrowserE(cmd *cobra.Command, args []string) error {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	system, _ := cmd.Flags().GetBool("system")
+	level, _ := cmd.Flags().GetString("level")
+	components, _ := cmd.Flags().GetStringSlice("component")
+	eventsOnly, _ := cmd.Flags().GetBool("events")
+	title, _ := cmd.Flags().GetString("title")
+	output, _ := cmd.Flags().GetString("output")
+	noOpen, _ := cmd.Flags().GetBool("no-open")
+	wsArgs, _ := cmd.Flags().GetStringSlice("workspace")
+
+	opts := logutil.ReportOptions{Title: title}
+	var err error
+	if opts.Since, err = parseReplayTime("--since", sinceFlag); err != nil {
+		return err
+	}
+	if opts.Until, err = parseReplayTime("--until", untilFlag); err != nil {
+		return err
+	}
+	minLevelRank, err := resolveMinLevelRank(level)
+	if err != nil {
+		return err
+	}
+
+	entries, err := loadReplayEntries(cli.GetLogger(cmd), args, system, wsArgs)
+	if err != nil {
+		return err
+	}
+	entries = logutil.FilterReplayWindow(entries, opts.Since, opts.Until)
+	entries = filterReplayLevel(entries, minLevelRank)
+	entries = filterReportEntries(entries, components, eventsOnly)
+	if len(entries) == 0 {
+		return fmt.Errorf("no log entries match")
+	}
+	opts.Filters = reportFilters(level, components, eventsOnly)
+
+	var f *os.File
+	if output != "" {
+		f, err = os.Create(output)
+	} else {
+		f, err = os.CreateTemp("", "grove-logs-*.html")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := logutil.WriteHTMLReport(f, entries, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	path, err := filepath.Abs(f.Name())
+	if err != nil {
+		path = f.Name()
+	}
+
+	report := logsReport{Path: path, Entries: len(entries)}
+	if !noOpen {
+		if err := cli.OpenBrowser("file://" + filepath.ToSlash(path)); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Could not open a browser: %v\n", err)
+		} else {
+			report.Opened = true
+		}
+	}
+	return cli.GetPrinter(cmd).Result(report, func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "Wrote %d entries to %s\n", report.Entries, report.Path)
+		return err
+	})
+}
+
+// filterReportEntries keeps entries from components (all when empty) that
+// pass the --events filter when eventsOnly is set. Lines that are not JSON
+// are kept only when neither filter is set.
+func filterReportEntries(entries []logutil.ReplayEntry, components []string, eventsOnly bool) []logutil.ReplayEntry {
+	if len(components) == 0 && !eventsOnly {
+		return entries
+	}
+	want := make(map[string]bool, len(components))
+	for _, c := range components {
+		want[c] = true
+	}
+	filtered := make([]logutil.ReplayEntry, 0, len(entries))
+	for _, e := range entries {
+		var logMap map[string]interface{}
+		if json.Unmarshal([]byte(e.Line), &logMap) != nil {
+			continue
+		}
+		if component, _ := logMap["component"].(string); len(want) > 0 && !want[component] {
+			continue
+		}
+		if eventsOnly && !passesEventsFilter(logMap) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// reportFilters describes the applied filters for the report header.
+func reportFilters(level string, components []string, eventsOnly bool) []string {
+	if level == "" {
+		level = "info"
+	}
+	filters := []string{"level ≥ " + strings.ToLower(level)}
+	if len(components) > 0 {
+		filters = append(filters, "components: "+strings.Join(components, ", "))
+	}
+	if eventsOnly {
+		filters = append(filters, "lifecycle events and warnings only")
+	}
+	return filters
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/logging/logutil"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// newLogsReplayCmd creates the `logs replay` subcommand.
+func newLogsReplayCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"replay [file...]",
+		"Replay historical log entries at their original pace",
+	)
+	cmd.Long = `Replays log entries with the gaps between them that separated them when they
+were logged, so an incident can be shown as it unfolded. Entries from several
+files are merged by time.
+
+With no files, replays the latest log file of the workspace containing the
+current directory, of each -w workspace (by name or path), or with --system
+the latest system log. --speed 10 replays ten times faster, and
+--max-gap shortens idle stretches. --since and --until select the window to
+replay, as RFC 3339 times or durations ago (30m, 2h).
+
+Entries are printed to stdout in the --format of 'core logs'; with -i they
+are fed into the interactive TUI instead.`
+	cmd.Example = `  core logs replay --since 2026-01-02T14:00:00Z --until 2026-01-02T14:30:00Z
+  core logs replay --speed 20 --max-gap 2s ~/.local/state/grove/logs/grove-2026-01-02.log
+  core logs replay -i --speed 5`
+
+	cmd.Flags().Float64("speed", 1, "Replay speed: 1 is real time, 10 ten times faster")
+	cmd.Flags().Duration("max-gap", 0, "Longest wait between two entries (e.g. 2s; default: no cap)")
+	cmd.Flags().String("since", "", "Replay entries logged at or after this time (RFC 3339 or a duration ago, e.g. 30m)")
+	cmd.Flags().String("until", "", "Replay entries logged at or before this time (RFC 3339 or a duration ago)")
+	cmd.Flags().Bool("system", false, "Replay the latest system log instead of the workspace log")
+	cmd.Flags().StringSliceP("workspace", "w", []string{}, "Replay these workspaces, by name or path (comma-separated)")
+	cmd.Flags().String("level", "", "Minimum log level: trace, debug, info, warn, error (default: info)")
+	cmd.Flags().String("format", "text", "Output format: text, json, full, rich, pretty, pretty-text")
+	cmd.Flags().Bool("compact", false, "Disable spacing between entries (pretty/full/rich)")
+	cmd.Flags().BoolP("tui", "i", false, "Replay into the interactive TUI")
+
+	cmd.RunE = runLogsReplayE
+	return cmd
+}
+
+func runLogsReplayE(cmd *cobra.Command, args []string) error {
+	speed, _ := cmd.Flags().GetFloat64("speed")
+	maxGap, _ := cmd.Flags().GetDuration("max-gap")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	system, _ := cmd.Flags().GetBool("system")
+	level, _ := cmd.Flags().GetString("level")
+	format, _ := cmd.Flags().GetString("format")
+	compact, _ := cmd.Flags().GetBool("compact")
+	tuiMode, _ := cmd.Flags().GetBool("tui")
+	wsArgs, _ := cmd.Flags().GetStringSlice("workspace")
+
+	if speed <= 0 {
+		return fmt.Errorf("invalid --speed %v: must be greater than 0", speed)
+	}
+	opts := logutil.ReplayOptions{Speed: speed, MaxGap: maxGap}
+	var err error
+	if opts.Since, err = parseReplayTime("--since", sinceFlag); err != nil {
+		return err
+	}
+	if opts.Until, err = parseReplayTime("--until", untilFlag); err != nil {
+		return err
+	}
+	minLevelRank, err := resolveMinLevelRank(level)
+	if err != nil {
+		return err
+	}
+	if cli.GetOptions(cmd).JSONOutput {
+		format = "json"
+	}
+
+	entries, err := loadReplayEntries(cli.GetLogger(cmd), args, system, wsArgs)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no log entries to replay")
+	}
+
+	if tuiMode {
+		return runLogsReplayTUI(entries, opts, level)
+	}
+
+	out := cmd.OutOrStdout()
+	return logutil.Replay(cmd.Context(), filterReplayLevel(entries, minLevelRank), opts, func(e logutil.ReplayEntry) error {
+		var logMap map[string]interface{}
+		if err := json.Unmarshal([]byte(e.Line), &logMap); err != nil {
+			_, err := fmt.Fprintln(out, e.Line)
+			return err
+		}
+		_, err := fmt.Fprint(out, logutil.FormatLogLine(logMap, e.Workspace, format, compact))
+		return err
+	})
+}
+
+// parseReplayTime parses a --since/--until value: an RFC 3339 time, or a
+// duration meaning that long before now. Empty means unset.
+func parseReplayTime(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q: expected an RFC 3339 time such as 2026-01-02T14:00:00Z or a duration such as 30m", flag, value)
+}
+
+// loadReplayEntries reads the files to replay, merged by time. Explicit
+// files are labelled with their name; with none, the latest log file of
+// each --workspace (or with system, the system's, or else the workspace
+// containing the current directory) is used.
+func loadReplayEntries(logger *logrus.Logger, files []string, system bool, wsArgs []string) ([]logutil.ReplayEntry, error) {
+	type source struct{ name, wsPath, path string }
+	var sources []source
+	switch {
+	case len(files) > 0:
+		for _, f := range files {
+			sources = append(sources, source{name: strings.TrimSuffix(filepath.Base(f), filepath.Ext(f)), path: f})
+		}
+	case system:
+		path, err := logutil.FindLatestLogFile(logutil.GetSystemLogsDir())
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source{name: "system", path: path})
+	default:
+		var workspaces []*workspace.WorkspaceNode
+		if len(wsArgs) > 0 {
+			var err error
+			if workspaces, err = resolveWorkspaceArgs(logger, wsArgs); err != nil {
+				return nil, err
+			}
+			if len(workspaces) == 0 {
+				return nil, fmt.Errorf("no workspace matches --workspace %s", strings.Join(wsArgs, ","))
+			}
+		} else {
+			ws, err := currentWorkspace()
+			if err != nil {
+				return nil, err
+			}
+			workspaces = []*workspace.WorkspaceNode{ws}
+		}
+		for _, ws := range workspaces {
+			path, _, err := logutil.FindLogFileForWorkspace(ws)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, source{name: ws.Name, wsPath: ws.Path, path: path})
+		}
+	}
+
+	var entries []logutil.ReplayEntry
+	for _, s := range sources {
+		fileEntries, err := logutil.ReadReplayEntries(s.name, s.wsPath, s.path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	logutil.SortReplayEntries(entries)
+	return entries, nil
+}
+
+// filterReplayLevel drops entries below minLevelRank before pacing, so the
+// replay does not wait out the gaps of entries it will not show.
+func filterReplayLevel(entries []logutil.ReplayEntry, minLevelRank int) []logutil.ReplayEntry {
+	filtered := make([]logutil.ReplayEntry, 0, len(entries))
+	for _, e := range entries {
+		if !lineBelowLevel(e.Line, minLevelRank) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// lineBelowLevel reports whether line is a JSON entry logged at a level
+// below minLevelRank. Lines without a known level are never below it.
+func lineBelowLevel(line string, minLevelRank int) bool {
+	var logMap map[string]interface{}
+	if json.Unmarshal([]byte(line), &logMap) != nil {
+		return false
+	}
+	entryLevel, _ := logMap["level"].(string)
+	rank, known := validLevels[strings.ToLower(entryLevel)]
+	return known && rank < minLevelRank
+}
+
+// runLogsReplayTUI feeds the replay into the logs TUI in place of the
+// daemon stream. A level change in the TUI restarts the replay with the
+// new level.
+func runLogsReplayTUI(entries []logutil.ReplayEntry, opts logutil.ReplayOptions, level string) error {
+	cfg := newLogsTUIConfig()
+	cfg.InitialScope = "all"
+	cfg.Follow = true
+	cfg.InitialLevel = level
+	cfg.Stream = func(ctx context.Context, streamOpts models.LogStreamOptions) (<-chan models.LogStreamLine, error) {
+		minLevelRank, err := resolveMinLevelRank(streamOpts.Level)
+		if err != nil {
+			return nil, err
+		}
+		ch := make(chan models.LogStreamLine, 64)
+		go func() {
+			defer close(ch)
+			_ = logutil.Replay(ctx, filterReplayLevel(entries, minLevelRank), opts, func(e logutil.ReplayEntry) error {
+				select {
+				case ch <- models.LogStreamLine{Workspace: e.Workspace, WorkspacePath: e.WorkspacePath, Line: e.Line}:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		}()
+		return ch, nil
+	}
+	return runLogsProgram(cfg, "")
+}
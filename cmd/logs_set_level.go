@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/logging"
+)
+
+// logLevelOverrides is the structured output of `logs set-level`.
+type logLevelOverrides struct {
+	Path   string            `json:"path"`
+	Levels map[string]string `json:"levels"`
+}
+
+// newLogsSetLevelCmd creates the `logs set-level` subcommand.
+func newLogsSetLevelCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"set-level [component] [level]",
+		"Change the log level of running processes",
+	)
+	cmd.Long = `Writes the log level control file (log-levels.json in the Grove state
+directory, or $GROVE_LOG_CONTROL_FILE) that running Grove processes of the
+current user poll, whatever their working directory. Within a couple of
+seconds, every logger for the component switches to the new level for both
+console and file output. Use "*" or "all" as the component to change every
+component, and "reset" as the level to drop an override.
+
+The core CLI also responds to SIGUSR1 (more verbose) and SIGUSR2 (less
+verbose) on unix; groved does not, as it uses SIGUSR1 to drain.
+
+With no arguments, prints the overrides currently in the control file.
+
+Examples:
+  core logs set-level groved.server debug
+  core logs set-level all warn
+  core logs set-level groved.server reset
+  core logs set-level --clear`
+
+	cmd.Args = cobra.RangeArgs(0, 2)
+	cmd.Flags().Bool("clear", false, "Remove all overrides")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		path := logging.ControlFilePath()
+		if path == "" {
+			return fmt.Errorf("could not determine the log level control file path")
+		}
+		clearAll, _ := cmd.Flags().GetBool("clear")
+		printer := cli.GetPrinter(cmd)
+
+		if clearAll {
+			if len(args) > 0 {
+				return fmt.Errorf("--clear takes no arguments")
+			}
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			return printer.Result(logLevelOverrides{Path: path, Levels: map[string]string{}}, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Cleared log level overrides (%s)\n", path)
+				return err
+			})
+		}
+
+		cf, err := logging.ReadControlFile(path)
+		if err != nil {
+			return err
+		}
+
+		switch len(args) {
+		case 0:
+			if len(cf.Levels) == 0 && !printer.Structured() {
+				printer.Printf("No log level overrides (%s)\n", path)
+				return nil
+			}
+			return printer.Result(logLevelOverrides{Path: path, Levels: cf.Levels}, func(w io.Writer) error {
+				components := make([]string, 0, len(cf.Levels))
+				for c := range cf.Levels {
+					components = append(components, c)
+				}
+				sort.Strings(components)
+				for _, c := range components {
+					fmt.Fprintf(w, "%s\t%s\n", c, cf.Levels[c])
+				}
+				return nil
+			})
+		case 1:
+			return fmt.Errorf("expected a component and a level")
+		}
+
+		component, level := args[0], strings.ToLower(args[1])
+		if component == "all" {
+			component = logging.AllComponents
+		}
+		if level == "reset" {
+			delete(cf.Levels, component)
+		} else {
+			if _, err := logrus.ParseLevel(level); err != nil {
+				return fmt.Errorf("invalid level %q: use trace, debug, info, warn, error or reset", args[1])
+			}
+			cf.Levels[component] = level
+		}
+
+		if err := logging.WriteControlFile(path, cf); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		return printer.Result(logLevelOverrides{Path: path, Levels: cf.Levels}, func(w io.Writer) error {
+			var err error
+			if level == "reset" {
+				_, err = fmt.Fprintf(w, "Reset log level for %s (%s)\n", component, path)
+			} else {
+				_, err = fmt.Fprintf(w, "Set log level for %s to %s (%s)\n", component, level, path)
+			}
+			return err
+		})
+	}
+
+	return cmd
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/grovetools/core/pkg/logging/logutil"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// logFileSource is a log file named with --file or matched by --glob. Its
+// entries are shown under label where workspace entries show the
+// workspace name.
+type logFileSource struct {
+	label string
+	path  string
+}
+
+// parseLogFileSources resolves the --file and --glob values. Either may
+// start with "label=", which names every file it selects; unlabelled files
+// are named after their base name without the extension. A file selected
+// twice is read once, under its first label.
+func parseLogFileSources(files, globs []string) ([]logFileSource, error) {
+	var sources []logFileSource
+	seen := make(map[string]bool)
+	add := func(label, path string) error {
+		abs, err := filepath.Abs(paths.ExpandHome(path))
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		if seen[abs] {
+			return nil
+		}
+		seen[abs] = true
+		if label == "" {
+			label = strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs))
+		}
+		sources = append(sources, logFileSource{label: label, path: abs})
+		return nil
+	}
+
+	for _, value := range files {
+		label, path := splitSourceLabel(value)
+		if path == "" {
+			return nil, fmt.Errorf("invalid --file %q: missing path", value)
+		}
+		if info, err := os.Stat(paths.ExpandHome(path)); err != nil {
+			return nil, fmt.Errorf("invalid --file %q: %w", value, err)
+		} else if info.IsDir() {
+			return nil, fmt.Errorf("invalid --file %q: %s is a directory; use --glob '%s/*.log'", value, path, path)
+		}
+		if err := add(label, path); err != nil {
+			return nil, err
+		}
+	}
+	for _, value := range globs {
+		label, pattern := splitSourceLabel(value)
+		matches, err := filepath.Glob(paths.ExpandHome(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --glob %q: %w", value, err)
+		}
+		found := false
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || info.IsDir() {
+				continue
+			}
+			found = true
+			if err := add(label, match); err != nil {
+				return nil, err
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("--glob %q matches no files", value)
+		}
+	}
+	return sources, nil
+}
+
+// splitSourceLabel splits a --file or --glob value into its optional label
+// and its path. Text before the first "=" is a label only when it contains
+// no path separator, so paths that contain "=" still work unlabelled.
+func splitSourceLabel(value string) (label, path string) {
+	before, after, ok := strings.Cut(value, "=")
+	if !ok || before == "" || strings.ContainsAny(before, `/\`) {
+		return "", value
+	}
+	return before, after
+}
+
+// logFilesOnly reports whether --file and --glob replace the workspace
+// logs: they do unless --scope or --workspace asks for workspaces too.
+func logFilesOnly(files []logFileSource, changed func(name string) bool) bool {
+	return len(files) > 0 && !changed("scope") && !changed("workspace")
+}
+
+// logSource is one log read by `core logs` outside the TUI: a file, or a
+// logs directory whose latest file is tailed, following rotation.
+type logSource struct {
+	name   string
+	wsPath string
+	file   string
+	dir    string
+	follow bool
+}
+
+// start tails the source into lineChan.
+func (s logSource) start(ctx context.Context, lineChan chan<- logutil.TailedLine, wg *sync.WaitGroup, tail int) {
+	wg.Add(1)
+	if s.dir != "" {
+		go logutil.TailDirectory(ctx, s.name, s.wsPath, s.dir, lineChan, wg, s.follow, tail)
+		return
+	}
+	go logutil.TailFile(ctx, s.name, s.wsPath, s.file, lineChan, wg, s.follow, tail)
+}
+
+// canMergeLogSources reports whether sources can be read up front and
+// merged by time: there is more than one, and each is a single file read
+// without following.
+func canMergeLogSources(sources []logSource) bool {
+	if len(sources) < 2 {
+		return false
+	}
+	for _, s := range sources {
+		if s.dir != "" || s.follow {
+			return false
+		}
+	}
+	return true
+}
+
+// readMergedLogSources reads the last tail lines of each source (all of
+// them when tail is negative) and sends them to lineChan ordered by time,
+// closing lineChan when done.
+func readMergedLogSources(sources []logSource, tail int, lineChan chan<- logutil.TailedLine) error {
+	var entries []logutil.ReplayEntry
+	for _, s := range sources {
+		fileEntries, err := logutil.ReadTailEntries(s.name, s.wsPath, s.file, tail)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	logutil.SortReplayEntries(entries)
+	go func() {
+		defer close(lineChan)
+		for _, e := range entries {
+			lineChan <- e.TailedLine
+		}
+	}()
+	return nil
+}
+
+// logFilesStream returns a logs TUI stream over files: the last
+// opts.Replay lines of each, merged by time, then their new lines as they
+// are written. When daemonStream is set, the daemon's stream is merged in;
+// the TUI keeps entries in time order whichever source they came from.
+func logFilesStream(files []logFileSource, daemonStream func(context.Context, models.LogStreamOptions) (<-chan models.LogStreamLine, error)) func(context.Context, models.LogStreamOptions) (<-chan models.LogStreamLine, error) {
+	return func(ctx context.Context, opts models.LogStreamOptions) (<-chan models.LogStreamLine, error) {
+		minLevelRank, err := resolveMinLevelRank(opts.Level)
+		if err != nil {
+			return nil, err
+		}
+		var backlog []logutil.ReplayEntry
+		for _, f := range files {
+			fileEntries, err := logutil.ReadTailEntries(f.label, "", f.path, opts.Replay)
+			if err != nil {
+				return nil, err
+			}
+			backlog = append(backlog, fileEntries...)
+		}
+		logutil.SortReplayEntries(backlog)
+
+		var daemonCh <-chan models.LogStreamLine
+		if daemonStream != nil {
+			if daemonCh, err = daemonStream(ctx, opts); err != nil {
+				return nil, err
+			}
+		}
+
+		lineChan := make(chan logutil.TailedLine, 100)
+		var wg sync.WaitGroup
+		for _, f := range files {
+			wg.Add(1)
+			go logutil.TailFile(ctx, f.label, "", f.path, lineChan, &wg, true, 0)
+		}
+		if daemonCh != nil {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for line := range daemonCh {
+					lineChan <- logutil.TailedLine{Workspace: line.Workspace, WorkspacePath: line.WorkspacePath, Line: line.Line}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(lineChan)
+		}()
+
+		ch := make(chan models.LogStreamLine, 64)
+		go func() {
+			defer close(ch)
+			send := func(l logutil.TailedLine) {
+				if ctx.Err() != nil || lineBelowLevel(l.Line, minLevelRank) {
+					return
+				}
+				select {
+				case ch <- models.LogStreamLine{Workspace: l.Workspace, WorkspacePath: l.WorkspacePath, Line: l.Line}:
+				case <-ctx.Done():
+				}
+			}
+			for _, e := range backlog {
+				send(e.TailedLine)
+			}
+			// Keep draining after ctx is done so the tailers, which do not
+			// select on their sends, can see it and exit.
+			for l := range lineChan {
+				send(l)
+			}
+		}()
+		return ch, nil
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/grovetools/core/pkg/logging/logutil"
+)
+
+func TestSplitSourceLabel(t *testing.T) {
+	tests := []struct {
+		value, label, path string
+	}{
+		{"api=./api.log", "api", "./api.log"},
+		{"./api.log", "", "./api.log"},
+		{"/tmp/a=b.log", "", "/tmp/a=b.log"},
+		{"=api.log", "", "=api.log"},
+		{"svc=/var/log/svc/*.log", "svc", "/var/log/svc/*.log"},
+	}
+	for _, tt := range tests {
+		label, path := splitSourceLabel(tt.value)
+		if label != tt.label || path != tt.path {
+			t.Errorf("splitSourceLabel(%q) = %q, %q, want %q, %q", tt.value, label, path, tt.label, tt.path)
+		}
+	}
+}
+
+func TestParseLogFileSources(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"api.log", "worker-1.log", "worker-2.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sources, err := parseLogFileSources(
+		[]string{"gateway=" + filepath.Join(dir, "api.log")},
+		[]string{"workers=" + filepath.Join(dir, "worker-*.log"), filepath.Join(dir, "*.log")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []logFileSource{
+		{label: "gateway", path: filepath.Join(dir, "api.log")},
+		{label: "workers", path: filepath.Join(dir, "worker-1.log")},
+		{label: "workers", path: filepath.Join(dir, "worker-2.log")},
+	}
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("sources = %+v, want %+v", sources, want)
+	}
+
+	sources, err = parseLogFileSources([]string{filepath.Join(dir, "api.log")}, nil)
+	if err != nil || len(sources) != 1 || sources[0].label != "api" {
+		t.Errorf("unlabelled --file = %+v, %v, want label api", sources, err)
+	}
+	if _, err := parseLogFileSources([]string{filepath.Join(dir, "missing.log")}, nil); err == nil {
+		t.Error("expected an error for a missing --file")
+	}
+	if _, err := parseLogFileSources(nil, []string{filepath.Join(dir, "*.txt")}); err == nil {
+		t.Error("expected an error for a --glob matching nothing")
+	}
+}
+
+func TestReadMergedLogSourcesOrdersByTime(t *testing.T) {
+	dir := t.TempDir()
+	api := filepath.Join(dir, "api.log")
+	worker := filepath.Join(dir, "worker.log")
+	if err := os.WriteFile(api, []byte(`{"time":"2026-01-02T10:00:00Z","msg":"a1"}
+{"time":"2026-01-02T10:00:02Z","msg":"a2"}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(worker, []byte(`{"time":"2026-01-02T10:00:01Z","msg":"w1"}
+plain text
+{"time":"2026-01-02T10:00:03Z","msg":"w2"}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := []logSource{{name: "api", file: api}, {name: "worker", file: worker}}
+	if !canMergeLogSources(sources) {
+		t.Fatal("two unfollowed files should be merged")
+	}
+	lineChan := make(chan logutil.TailedLine, 10)
+	if err := readMergedLogSources(sources, -1, lineChan); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for l := range lineChan {
+		got = append(got, l.Workspace+" "+l.Line)
+	}
+	want := []string{
+		`api {"time":"2026-01-02T10:00:00Z","msg":"a1"}`,
+		`worker {"time":"2026-01-02T10:00:01Z","msg":"w1"}`,
+		`worker plain text`,
+		`api {"time":"2026-01-02T10:00:02Z","msg":"a2"}`,
+		`worker {"time":"2026-01-02T10:00:03Z","msg":"w2"}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged lines = %q, want %q", got, want)
+	}
+
+	if canMergeLogSources([]logSource{{name: "api", file: api, follow: true}, {name: "worker", file: worker}}) {
+		t.Error("followed sources should not be merged up front")
+	}
+}
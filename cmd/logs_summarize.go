@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/logging/logutil"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// logsSummaryNote is the result of `logs summarize --inbox`.
+type logsSummaryNote struct {
+	Path    string              `json:"path"`
+	Summary *logutil.LogSummary `json:"summary"`
+}
+
+// newLogsSummarizeCmd creates the `logs summarize` subcommand.
+func newLogsSummarizeCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"summarize",
+		"Summarize a day of logs as markdown",
+	)
+	cmd.Long = `Summarizes the entries logged on one day as a markdown note to drop into a
+daily note: error clusters (errors whose messages differ only in numbers, IDs,
+paths or quoted values), components that did not log in the week before,
+the busiest hours, and notable gaps with no entries.
+
+--date takes today, yesterday or a YYYY-MM-DD date in local time. The dated
+log files of the workspace containing the current directory are read, or of
+the --scope and -w workspaces as for 'core logs'; --system adds the system
+logs.
+
+The summary is printed to stdout unless --inbox writes it into the current
+workspace's notebook inbox as <date>-log-summary.md. Setting
+daemon.daily_log_summary has the daemon write it there after midnight.
+Use --json for the summary's data.`
+	cmd.Example = `  core logs summarize
+  core logs summarize --date yesterday --scope ecosystem --system
+  core logs summarize --date 2026-01-02 --gap 30m --inbox`
+
+	cmd.Flags().String("date", "today", "Day to summarize: today, yesterday or YYYY-MM-DD")
+	cmd.Flags().String("scope", "workspace", "Log scope: workspace, ecosystem, all, system")
+	cmd.Flags().StringSliceP("workspace", "w", []string{}, "Summarize these workspaces, by name or path (comma-separated)")
+	cmd.Flags().Bool("system", false, "Include system logs")
+	cmd.Flags().Duration("gap", logutil.DefaultSummaryGap, "Shortest stretch without entries reported as a gap")
+	cmd.Flags().Int("baseline", logutil.DefaultSummaryBaselineDays, "Days before --date whose components are not new (0 skips new components)")
+	cmd.Flags().Bool("inbox", false, "Write the summary into the current workspace's notebook inbox")
+
+	cmd.RunE = runLogsSummarizeE
+	return cmd
+}
+
+func runLogsSummarizeE(cmd *cobra.Command, args []string) error {
+	dateFlag, _ := cmd.Flags().GetString("date")
+	scope, _ := cmd.Flags().GetString("scope")
+	wsFilter, _ := cmd.Flags().GetStringSlice("workspace")
+	includeSystem, _ := cmd.Flags().GetBool("system")
+	gap, _ := cmd.Flags().GetDuration("gap")
+	baseline, _ := cmd.Flags().GetInt("baseline")
+	inbox, _ := cmd.Flags().GetBool("inbox")
+
+	day, err := parseSummaryDate(dateFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	switch scope {
+	case "workspace", "ecosystem", "all", "system":
+	default:
+		return fmt.Errorf("invalid --scope %q: must be workspace, ecosystem, all, or system", scope)
+	}
+	if gap <= 0 {
+		return fmt.Errorf("invalid --gap %s: must be greater than 0", gap)
+	}
+	if len(wsFilter) > 0 && !cmd.Flags().Changed("scope") {
+		scope = "ecosystem"
+	}
+
+	workspaces, err := resolveLogWorkspaces(cli.GetLogger(cmd), scope, wsFilter)
+	if err != nil {
+		return err
+	}
+	opts := logutil.SummaryOptions{GapThreshold: gap, BaselineDays: baseline}
+	if baseline == 0 {
+		opts.BaselineDays = -1
+	}
+	sources := logutil.SummaryDaySources(workspaces, includeSystem || scope == "system")
+	summary, err := logutil.SummarizeDay(sources, day, opts)
+	if err != nil {
+		return err
+	}
+
+	if !inbox {
+		return cli.GetPrinter(cmd).Result(summary, func(w io.Writer) error {
+			return logutil.WriteSummaryMarkdown(w, summary, time.Local)
+		})
+	}
+
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	node, err := currentWorkspace()
+	if err != nil {
+		return err
+	}
+	path, err := logutil.WriteSummaryNote(workspace.NewNotebookLocator(cfg), node, summary, time.Local)
+	if err != nil {
+		return err
+	}
+	result := logsSummaryNote{Path: path, Summary: summary}
+	return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "Wrote the summary of %s to %s\n", summary.Date, path)
+		return err
+	})
+}
+
+// parseSummaryDate parses a --date value relative to now: today, yesterday
+// or a YYYY-MM-DD date, in local time.
+func parseSummaryDate(value string, now time.Time) (time.Time, error) {
+	switch value {
+	case "", "today":
+		return logutil.DayStart(now), nil
+	case "yesterday":
+		return logutil.DayStart(now).AddDate(0, 0, -1), nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date %q: expected today, yesterday or a date such as 2026-01-02", value)
+	}
+	return day, nil
+}
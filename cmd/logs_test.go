@@ -1,6 +1,11 @@
 package cmd
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/logging/logutil"
+)
 
 func TestResolveMinLevelRank(t *testing.T) {
 	tests := []struct {
@@ -10,6 +15,7 @@ func TestResolveMinLevelRank(t *testing.T) {
 		wantErr bool
 	}{
 		{name: "empty defaults to info", level: "", want: validLevels["info"]},
+		{name: "trace", level: "trace", want: -1},
 		{name: "explicit debug", level: "debug", want: 0},
 		{name: "explicit info", level: "info", want: 1},
 		{name: "warn", level: "warn", want: 2},
@@ -92,3 +98,78 @@ func TestPassesEventsFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterReplayLevel(t *testing.T) {
+	entries := []logutil.ReplayEntry{
+		{TailedLine: logutil.TailedLine{Line: `{"level":"debug","msg":"a"}`}},
+		{TailedLine: logutil.TailedLine{Line: `{"level":"error","msg":"b"}`}},
+		{TailedLine: logutil.TailedLine{Line: `plain text`}},
+		{TailedLine: logutil.TailedLine{Line: `{"level":"info","msg":"c"}`}},
+	}
+	got := filterReplayLevel(entries, validLevels["info"])
+	if len(got) != 3 || got[0].Line != entries[1].Line || got[1].Line != "plain text" {
+		t.Errorf("filterReplayLevel kept %v", got)
+	}
+
+	traced := append(entries, logutil.ReplayEntry{TailedLine: logutil.TailedLine{Line: `{"level":"trace","msg":"d"}`}})
+	if got := filterReplayLevel(traced, validLevels["debug"]); len(got) != 4 {
+		t.Errorf("--level debug should drop trace entries, kept %v", got)
+	}
+	if got := filterReplayLevel(traced, validLevels["trace"]); len(got) != 5 {
+		t.Errorf("--level trace should keep every entry, kept %v", got)
+	}
+}
+
+func TestParseReplayTime(t *testing.T) {
+	if got, err := parseReplayTime("--since", ""); err != nil || !got.IsZero() {
+		t.Errorf("empty value should be unset, got %v, %v", got, err)
+	}
+	want := time.Date(2026, 1, 2, 14, 0, 0, 0, time.UTC)
+	if got, err := parseReplayTime("--since", "2026-01-02T14:00:00Z"); err != nil || !got.Equal(want) {
+		t.Errorf("RFC 3339 value parsed as %v, %v", got, err)
+	}
+	got, err := parseReplayTime("--since", "30m")
+	if err != nil || time.Since(got) < 30*time.Minute || time.Since(got) > 31*time.Minute {
+		t.Errorf("duration should mean that long ago, got %v, %v", got, err)
+	}
+	if _, err := parseReplayTime("--since", "yesterday"); err == nil {
+		t.Error("expected an error for an unparseable value")
+	}
+}
+
+func TestFilterReportEntries(t *testing.T) {
+	entries := []logutil.ReplayEntry{
+		{TailedLine: logutil.TailedLine{Line: `{"level":"info","component":"api","msg":"a"}`}},
+		{TailedLine: logutil.TailedLine{Line: `{"level":"info","component":"api","event":"job.started"}`}},
+		{TailedLine: logutil.TailedLine{Line: `{"level":"error","component":"db","msg":"b"}`}},
+		{TailedLine: logutil.TailedLine{Line: `plain text`}},
+	}
+	if got := filterReportEntries(entries, nil, false); len(got) != 4 {
+		t.Errorf("no filters should keep every entry, kept %d", len(got))
+	}
+	if got := filterReportEntries(entries, []string{"api"}, false); len(got) != 2 {
+		t.Errorf("component filter kept %d entries, want 2", len(got))
+	}
+	if got := filterReportEntries(entries, []string{"api"}, true); len(got) != 1 || got[0].Line != entries[1].Line {
+		t.Errorf("component and events filters kept %v", got)
+	}
+}
+
+func TestParseSummaryDate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := map[string]time.Time{
+		"":           time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		"today":      time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		"yesterday":  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2025-12-24": time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		got, err := parseSummaryDate(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseSummaryDate(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := parseSummaryDate("last week", now); err == nil {
+		t.Error("expected an error for an unknown date")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/grovetools/core/logging"
 	"github.com/grovetools/core/pkg/daemon"
 	"github.com/grovetools/core/pkg/workspace"
+	"github.com/grovetools/core/tui/components/jsontree"
 	"github.com/grovetools/core/tui/embed"
 	"github.com/grovetools/core/tui/logs"
 )
@@ -40,13 +42,15 @@ func (s standaloneLogs) View() string { return s.inner.View() }
 
 // runLogsTUI launches the interactive logs TUI as a standalone
 // bubbletea program. It connects to the daemon's aggregated log
-// stream instead of doing local file tailing.
-func runLogsTUI(workspaces []*workspace.WorkspaceNode, follow bool, overrideOpts *logging.OverrideOptions, scope string, includeSystem bool, level string, eventsOnly bool) error {
-	logCfg := logging.GetDefaultLoggingConfig()
-	if cfg, err := config.LoadDefault(); err == nil {
-		_ = cfg.UnmarshalExtension("logging", &logCfg)
-	}
-
+// stream instead of doing local file tailing. The view state is saved
+// to the workspace on quit and, when restore is set, the last saved
+// state is restored first; flags the user set explicitly (changed
+// reports them) win over it. Workspaces named with -w make up the initial
+// workspace selection; the others the stream carries can be selected in
+// the TUI's workspace picker. Files from --file and --glob are tailed
+// locally and merged into the stream, or replace it when no scope or
+// workspace was asked for; the saved view state is left alone then.
+func runLogsTUI(workspaces []*workspace.WorkspaceNode, files []logFileSource, follow bool, overrideOpts *logging.OverrideOptions, scope string, includeSystem bool, level string, eventsOnly bool, contextLines int, verbosity string, restore bool, changed func(name string) bool) error {
 	var initialPath string
 	if len(workspaces) > 0 && workspaces[0] != nil {
 		initialPath = workspaces[0].Path
@@ -55,28 +59,113 @@ func runLogsTUI(workspaces []*workspace.WorkspaceNode, follow bool, overrideOpts
 	cwd, _ := os.Getwd()
 	daemonClient := daemon.NewWithAutoStart(cwd)
 
-	cfg := logs.Config{
-		DaemonClient:         daemonClient,
-		InitialScope:         scope,
-		IncludeSystem:        includeSystem,
-		LogConfig:            &logCfg,
-		OverrideOpts:         overrideOpts,
-		Follow:               follow,
-		InitialWorkspacePath: initialPath,
-		Replay:               500,
-		InitialLevel:         level,
-		EventsOnly:           eventsOnly,
+	cfg := newLogsTUIConfig()
+	cfg.DaemonClient = daemonClient
+	cfg.InitialScope = scope
+	cfg.IncludeSystem = includeSystem
+	cfg.OverrideOpts = overrideOpts
+	cfg.Follow = follow
+	cfg.InitialWorkspacePath = initialPath
+	cfg.Replay = 500
+	cfg.InitialLevel = level
+	cfg.EventsOnly = eventsOnly
+	cfg.ContextLines = contextLines
+	cfg.Verbosity = verbosity
+	if changed("workspace") {
+		for _, ws := range workspaces {
+			cfg.Workspaces = append(cfg.Workspaces, ws.Path)
+		}
+		for _, f := range files {
+			cfg.Workspaces = append(cfg.Workspaces, f.label)
+		}
+	}
+	if logFilesOnly(files, changed) {
+		cfg.InitialScope = "all"
+		cfg.Stream = logFilesStream(files, nil)
+		return runLogsProgram(cfg, "")
+	}
+	if len(files) > 0 {
+		cfg.Stream = logFilesStream(files, daemonClient.StreamLogs)
+	}
+
+	statePath := logs.ViewStatePath(initialPath)
+	if restore {
+		if st, ok := logs.LoadViewState(statePath); ok {
+			if changed("scope") || changed("workspace") {
+				st.Scope = scope
+			}
+			if changed("workspace") {
+				st.HiddenWorkspaces = nil
+			}
+			if changed("level") {
+				st.Level = level
+			}
+			if changed("system") {
+				st.IncludeSystem = includeSystem
+			}
+			if changed("events") {
+				st.EventsOnly = eventsOnly
+			}
+			if changed("context") || changed("before-context") || changed("after-context") {
+				st.ContextLines = contextLines
+			}
+			if changed("follow") {
+				st.Follow = follow
+			}
+			cfg.ViewState = st
+		}
 	}
 
+	return runLogsProgram(cfg, statePath)
+}
+
+// newLogsTUIConfig returns a logs TUI config carrying the logging and
+// tui.logs settings from the user's configuration.
+func newLogsTUIConfig() logs.Config {
+	logCfg := logging.GetDefaultLoggingConfig()
+	cfg := logs.Config{LogConfig: &logCfg}
+	if c, err := config.LoadDefault(); err == nil {
+		_ = c.UnmarshalExtension("logging", &logCfg)
+		if c.TUI != nil {
+			cfg.Mouse = c.TUI.Mouse
+			hints := jsontree.RenderHintsFromConfig(c.TUI)
+			cfg.RenderHints = &hints
+		}
+		if c.TUI != nil && c.TUI.Logs != nil {
+			cfg.CopyFormat = c.TUI.Logs.CopyFormat
+			cfg.PinnedErrors = c.TUI.Logs.PinnedErrors
+			cfg.MaxEntries = c.TUI.Logs.MaxEntries
+			cfg.Timestamps = c.TUI.Logs.Timestamps
+			cfg.FollowMode = c.TUI.Logs.FollowMode
+			if d, err := time.ParseDuration(c.TUI.Logs.FollowResumeAfter); err == nil {
+				cfg.FollowResumeAfter = d
+			}
+		}
+	}
+	return cfg
+}
+
+// runLogsProgram runs the logs TUI for cfg until the user quits, then
+// saves its view state to statePath unless statePath is empty.
+func runLogsProgram(cfg logs.Config, statePath string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	inner := logs.New(ctx, cfg)
 	defer inner.Close()
 
-	p := tea.NewProgram(standaloneLogs{inner: inner}, tea.WithAltScreen())
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(standaloneLogs{inner: inner}, opts...)
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running TUI: %w", err)
 	}
+	if statePath != "" {
+		if err := logs.SaveViewState(statePath, inner.ViewState()); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+	}
 	return nil
 }
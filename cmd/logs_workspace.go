@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// currentWorkspace returns the workspace containing the working directory,
+// so the logs commands work from any subdirectory of a project.
+func currentWorkspace() (*workspace.WorkspaceNode, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return workspaceForDir(cwd), nil
+}
+
+// workspaceForDir returns the workspace containing dir, found by walking up
+// to the nearest grove config or repository root the way the logger does
+// when it picks a workspace's log directory. Outside any workspace it is
+// dir itself, named after its config or base name.
+func workspaceForDir(dir string) *workspace.WorkspaceNode {
+	if node, err := workspace.GetProjectByPath(dir); err == nil && node != nil {
+		return node
+	}
+	ws := &workspace.WorkspaceNode{Path: dir, Name: filepath.Base(dir)}
+	if cfg, err := config.LoadFrom(dir); err == nil && cfg.Name != "" {
+		ws.Name = cfg.Name
+	}
+	return ws
+}
+
+// isWorkspacePath reports whether a --workspace value is a path rather than
+// a workspace name.
+func isWorkspacePath(value string) bool {
+	return strings.ContainsRune(value, filepath.Separator) || strings.ContainsRune(value, '/') ||
+		value == "." || value == ".." || strings.HasPrefix(value, "~")
+}
+
+// resolveWorkspaceArgs resolves --workspace values. A path selects the
+// workspace containing it; a name selects every discovered workspace with
+// that name. Discovery only runs when a name is given.
+func resolveWorkspaceArgs(logger *logrus.Logger, values []string) ([]*workspace.WorkspaceNode, error) {
+	var workspaces []*workspace.WorkspaceNode
+	seen := make(map[string]bool)
+	add := func(ws *workspace.WorkspaceNode) {
+		if !seen[ws.Path] {
+			seen[ws.Path] = true
+			workspaces = append(workspaces, ws)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, v := range values {
+		if !isWorkspacePath(v) {
+			names[v] = true
+			continue
+		}
+		abs, err := filepath.Abs(paths.ExpandHome(v))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", v, err)
+		}
+		node, err := workspace.GetProjectByPath(abs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --workspace %q: %w", v, err)
+		}
+		add(node)
+	}
+	if len(names) == 0 {
+		return workspaces, nil
+	}
+
+	allWorkspaces, err := workspace.GetProjects(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	for _, ws := range allWorkspaces {
+		if names[ws.Name] {
+			add(ws)
+		}
+	}
+	return workspaces, nil
+}
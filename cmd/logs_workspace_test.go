@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsWorkspacePath(t *testing.T) {
+	for value, want := range map[string]bool{
+		"api":          false,
+		"grove:api":    false,
+		".":            true,
+		"..":           true,
+		"./api":        true,
+		"../api":       true,
+		"~/code/api":   true,
+		"/srv/api":     true,
+		"code/api":     true,
+		"api-worktree": false,
+	} {
+		if got := isWorkspacePath(value); got != want {
+			t.Errorf("isWorkspacePath(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestWorkspaceForDirWalksUpToProjectRoot(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(root, "proj")
+	sub := filepath.Join(project, "internal", "pkg")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "grove.yml"), []byte("name: proj\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ws := workspaceForDir(sub)
+	if ws.Path != project || ws.Name != "proj" {
+		t.Errorf("workspaceForDir(subdir) = %s at %s, want proj at %s", ws.Name, ws.Path, project)
+	}
+
+	nodes, err := resolveWorkspaceArgs(nil, []string{sub, project})
+	if err != nil {
+		t.Fatalf("resolveWorkspaceArgs: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Path != project {
+		t.Errorf("paths inside one project should resolve to it once, got %+v", nodes)
+	}
+}
+
+func TestWorkspaceForDirOutsideWorkspace(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := workspaceForDir(dir)
+	if ws.Path != dir || ws.Name != filepath.Base(dir) {
+		t.Errorf("outside a workspace, want the directory itself, got %s at %s", ws.Name, ws.Path)
+	}
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// NewNotesCmd creates the `notes` command
+func NewNotesCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"notes",
+		"Work with notebook notes, plans and chats",
+	)
+	cmd.Long = `Work with the notes, plans and chats kept in Grove notebooks across all
+discovered workspaces.`
+
+	cmd.AddCommand(newNotesSearchCmd())
+	cmd.AddCommand(newNotesUnlockCmd())
+	cmd.AddCommand(newNotesLockCmd())
+
+	return cmd
+}
+
+func newNotesSearchCmd() *cobra.Command {
+	var kind string
+	var limit int
+
+	cmd := cli.NewStandardCommand(
+		"search <query>",
+		"Full-text search across every workspace's notebook",
+	)
+	cmd.Long = `Search the titles, frontmatter and bodies of notes, plans and chats in every
+discovered workspace. All query terms must match; they match by prefix and
+ignore simple plural and tense suffixes, so "retries" also finds "retry".
+Title matches rank highest, then frontmatter, then body.
+
+The search index is cached under the Grove cache directory and only files
+changed since the last search are re-read.`
+	cmd.Example = `  core notes search retries backoff
+  core notes search --kind plan "http client"`
+	cmd.Args = cobra.MinimumNArgs(1)
+	cmd.Flags().StringVar(&kind, "kind", "", "Only return one kind: note, plan or chat")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of results (0 for all)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		switch kind {
+		case "", workspace.NotebookKindNote, workspace.NotebookKindPlan, workspace.NotebookKindChat:
+		default:
+			return fmt.Errorf("invalid --kind %q (expected note, plan or chat)", kind)
+		}
+
+		logger := cli.GetLogger(cmd)
+		// Without a config the locator falls back to the default notebook.
+		var cfg *config.Config
+		if c, err := config.LoadDefault(); err == nil {
+			cfg = c
+		}
+		result, err := workspace.NewDiscoveryService(logger).DiscoverAll()
+		if err != nil {
+			return fmt.Errorf("failed to discover workspaces: %w", err)
+		}
+
+		results, err := workspace.NewNotebookLocator(cfg).Search(strings.Join(args, " "), workspace.NewProvider(result))
+		if err != nil {
+			return fmt.Errorf("failed to search notebooks: %w", err)
+		}
+		if kind != "" {
+			filtered := results[:0]
+			for _, r := range results {
+				if r.Kind == kind {
+					filtered = append(filtered, r)
+				}
+			}
+			results = filtered
+		}
+		if limit > 0 && len(results) > limit {
+			results = results[:limit]
+		}
+
+		printer := cli.GetPrinter(cmd)
+		if len(results) == 0 && !printer.Structured() {
+			printer.Println("No matches.")
+			return nil
+		}
+		return printer.Result(results, func(w io.Writer) error {
+			for _, r := range results {
+				fmt.Fprintf(w, "%s [%s] %s\n  %s\n", r.Title, r.Kind, r.Workspace, r.Path)
+				if r.Snippet != "" {
+					fmt.Fprintf(w, "  │ %s\n", r.Snippet)
+				}
+			}
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+func newNotesUnlockCmd() *cobra.Command {
+	var ttl time.Duration
+
+	cmd := cli.NewStandardCommand(
+		"unlock [notebook]",
+		"Unlock an encrypted notebook for this session",
+	)
+	cmd.Long = `Resolve an encrypted notebook's key and keep it in XDG_RUNTIME_DIR so
+notebook reads decrypt transparently until it expires or "core notes lock"
+is run. Keys are never written to disk, so unlock fails where
+XDG_RUNTIME_DIR is unset (macOS); use encryption.key_command there. The
+notebook defaults to notebooks.rules.default.
+
+The key comes from GROVE_NOTEBOOK_KEY, then the notebook's
+encryption.key_command, then encryption.key_file. When none is set and
+stdin is a terminal, a passphrase is prompted for.`
+	cmd.Example = `  core notes unlock
+  core notes unlock work --ttl 1h`
+	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.Flags().DurationVar(&ttl, "ttl", workspace.DefaultNotebookUnlockTTL, "How long the notebook stays unlocked")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		name, err := notebookArg(cfg, args)
+		if err != nil {
+			return err
+		}
+		locator := workspace.NewNotebookLocator(cfg)
+		if !locator.IsEncrypted(name) {
+			return fmt.Errorf("notebook %q does not have encryption enabled", name)
+		}
+		root, err := locator.NotebookRoot(name)
+		if err != nil {
+			return err
+		}
+
+		key, err := locator.ResolveNotebookKey(name)
+		if errors.Is(err, workspace.ErrNotebookLocked) {
+			key, err = promptNotebookKey(name, root)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve key for notebook %q: %w", name, err)
+		}
+		if err := workspace.CheckNotebookKey(root, key); err != nil {
+			return fmt.Errorf("key does not unlock notebook %q: %w", name, err)
+		}
+		if err := workspace.UnlockNotebook(name, key, ttl); err != nil {
+			return fmt.Errorf("failed to unlock notebook %q: %w", name, err)
+		}
+
+		expires := time.Now().Add(ttl)
+		result := map[string]interface{}{"notebook": name, "expires": expires}
+		return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Unlocked notebook %s until %s\n", name, expires.Format("2006-01-02 15:04"))
+			return err
+		})
+	}
+
+	return cmd
+}
+
+func newNotesLockCmd() *cobra.Command {
+	var encrypt bool
+
+	cmd := cli.NewStandardCommand(
+		"lock [notebook]",
+		"Forget an unlocked notebook key",
+	)
+	cmd.Long = `Remove the key kept by "core notes unlock" so the notebook's files can no
+longer be read without resolving its key again. The notebook defaults to
+notebooks.rules.default.
+
+With --encrypt, plaintext markdown files under the notebook root are
+encrypted in place first, which is how an existing notebook is converted
+after enabling encryption.`
+	cmd.Example = `  core notes lock
+  core notes lock work --encrypt`
+	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "Encrypt plaintext notebook files before locking")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		name, err := notebookArg(cfg, args)
+		if err != nil {
+			return err
+		}
+		locator := workspace.NewNotebookLocator(cfg)
+
+		encrypted := 0
+		if encrypt {
+			if !locator.IsEncrypted(name) {
+				return fmt.Errorf("notebook %q does not have encryption enabled", name)
+			}
+			root, err := locator.NotebookRoot(name)
+			if err != nil {
+				return err
+			}
+			key, err := locator.NotebookKey(name)
+			if err != nil {
+				return fmt.Errorf("failed to resolve key for notebook %q: %w", name, err)
+			}
+			if err := workspace.CheckNotebookKey(root, key); err != nil {
+				return fmt.Errorf("key does not match notebook %q: %w", name, err)
+			}
+			if encrypted, err = workspace.EncryptNotebookFiles(root, key); err != nil {
+				return fmt.Errorf("failed to encrypt notebook %q: %w", name, err)
+			}
+		}
+		if err := workspace.LockNotebook(name); err != nil {
+			return fmt.Errorf("failed to lock notebook %q: %w", name, err)
+		}
+
+		result := map[string]interface{}{"notebook": name, "encrypted": encrypted}
+		return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			if encrypt {
+				fmt.Fprintf(w, "Encrypted %d file(s) in notebook %s\n", encrypted, name)
+			}
+			_, err := fmt.Fprintf(w, "Locked notebook %s\n", name)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+// notebookArg returns the notebook named on the command line, or the
+// default notebook from the config.
+func notebookArg(cfg *config.Config, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if cfg.Notebooks != nil && cfg.Notebooks.Rules != nil && cfg.Notebooks.Rules.Default != "" {
+		return cfg.Notebooks.Rules.Default, nil
+	}
+	return "", fmt.Errorf("no notebook given and notebooks.rules.default is not set")
+}
+
+// promptNotebookKey reads a passphrase from the terminal when the notebook
+// at root has no configured key source.
+func promptNotebookKey(name, root string) (*workspace.NotebookKey, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("%w: set %s, encryption.key_command or encryption.key_file", workspace.ErrNotebookLocked, config.NotebookKeyEnvVar)
+	}
+	fmt.Fprintf(os.Stderr, "Passphrase for notebook %s: ", name)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return nil, workspace.ErrNotebookLocked
+	}
+	salt, err := workspace.NotebookKeySalt(root)
+	if err != nil {
+		return nil, err
+	}
+	return workspace.DeriveNotebookKey(string(passphrase), salt)
+}
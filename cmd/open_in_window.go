@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/pkg/procman"
 )
 
 // NewOpenInWindowCmd creates the `open-in-window` command.
@@ -37,7 +38,7 @@ func NewOpenInWindowCmd() *cobra.Command {
 				execCmd.Stdin = os.Stdin
 				execCmd.Stdout = os.Stdout
 				execCmd.Stderr = os.Stderr
-				return execCmd.Run()
+				return procman.Default().Run(execCmd, procman.Options{StopOrder: procman.OrderEditor})
 			}
 
 			tuiEngine, ok := engine.(mux.MuxTUIEngine)
@@ -3,9 +3,11 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
+	"github.com/grovetools/core/cli"
 	"github.com/grovetools/core/pkg/paths"
 )
 
@@ -24,8 +26,8 @@ func NewPathsCmd() *cobra.Command {
 		Short: "Print the XDG-compliant paths used by Grove",
 		Long: `Print the XDG-compliant paths used by Grove.
 
-This command outputs the paths in JSON format by default, making it easy
-to parse from scripts and other tools.
+This command outputs the paths in JSON format by default (or YAML with
+--yaml), making it easy to parse from scripts and other tools.
 
 The paths follow the XDG Base Directory Specification:
 - config_dir: Configuration files (grove.yml)
@@ -42,12 +44,14 @@ The paths follow the XDG Base Directory Specification:
 				BinDir:    paths.BinDir(),
 			}
 
-			jsonData, err := json.MarshalIndent(output, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal paths to JSON: %w", err)
-			}
-			fmt.Println(string(jsonData))
-			return nil
+			return cli.GetPrinter(cmd).Result(output, func(w io.Writer) error {
+				jsonData, err := json.MarshalIndent(output, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal paths to JSON: %w", err)
+				}
+				_, err = fmt.Fprintln(w, string(jsonData))
+				return err
+			})
 		},
 	}
 
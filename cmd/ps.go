@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/procman"
+)
+
+// NewPsCmd creates the `ps` command.
+func NewPsCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"ps",
+		"List long-running child processes started by grove tools",
+	)
+	cmd.Long = `List the long-running child processes grove tools have started and are
+tracking: editors, helpers and the background daemon. Each is recorded as a
+pidfile under the state directory while it runs; entries whose process has
+exited are pruned.
+
+Children that are not detached are stopped in order (helpers, then editors)
+when their parent receives SIGTERM.`
+	cmd.Args = cobra.NoArgs
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		procs, err := procman.List(procman.Dir())
+		if err != nil {
+			return fmt.Errorf("failed to read process registry: %w", err)
+		}
+
+		printer := cli.GetPrinter(cmd)
+		if len(procs) == 0 && !printer.Structured() {
+			printer.Println("No tracked processes.")
+			return nil
+		}
+		if procs == nil {
+			procs = []procman.Process{}
+		}
+		return printer.Result(procs, func(out io.Writer) error {
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PID\tNAME\tPARENT\tUPTIME\tCOMMAND")
+			for _, p := range procs {
+				parent := fmt.Sprintf("%d", p.ParentPID)
+				if p.Detached {
+					parent += " (detached)"
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", p.PID, p.Name, parent, formatSessionDuration(time.Since(p.StartedAt)), strings.Join(p.Command, " "))
+			}
+			return w.Flush()
+		})
+	}
+
+	return cmd
+}
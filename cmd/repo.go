@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/repo"
+)
+
+// NewRepoCmd creates the `repo` command
+func NewRepoCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"repo",
+		"Manage cloned bare repositories and their worktrees",
+	)
+	cmd.Long = `Manage the bare repositories that Grove clones under its data directory
+(~/.local/share/grove/cx/repos). Each clone keeps its checkouts in a
+.grove-worktrees directory, which workspace discovery lists as worktrees of
+the cloned project.`
+
+	cmd.AddCommand(newRepoCloneCmd())
+	cmd.AddCommand(newRepoListCmd())
+	cmd.AddCommand(newRepoRemoveCmd())
+	cmd.AddCommand(newRepoWorktreeCmd())
+
+	return cmd
+}
+
+func newRepoCloneCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"clone <repo>",
+		"Clone a repository as a managed bare repo",
+	)
+	cmd.Long = `Clone a repository as a bare repo managed by Grove. The argument may be a
+full git URL or an owner/repo shorthand (resolved against GitHub). Cloning an
+already-managed repository fetches its latest refs instead.`
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		manager, err := repo.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to open repo manager: %w", err)
+		}
+
+		repoURL := repo.ResolveRepoURL(args[0])
+		if err := manager.Ensure(cmd.Context(), repoURL); err != nil {
+			return fmt.Errorf("failed to clone %s: %w", repoURL, err)
+		}
+
+		info, err := manager.Find(repoURL)
+		if err != nil {
+			return err
+		}
+		return cli.GetPrinter(cmd).Result(info, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Cloned %s\n  %s\n", repoURL, info.BarePath)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+// repoListEntry is the structured shape of `core repo list`.
+type repoListEntry struct {
+	URL       string   `json:"url"`
+	Shorthand string   `json:"shorthand,omitempty"`
+	BarePath  string   `json:"bare_path"`
+	Worktrees []string `json:"worktrees"`
+}
+
+func newRepoListCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"list",
+		"List managed repositories and their worktrees",
+	)
+	cmd.Aliases = []string{"ls"}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		manager, err := repo.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to open repo manager: %w", err)
+		}
+
+		repos, err := manager.List()
+		if err != nil {
+			return fmt.Errorf("failed to list repositories: %w", err)
+		}
+		sort.Slice(repos, func(i, j int) bool { return repos[i].URL < repos[j].URL })
+
+		entries := make([]repoListEntry, 0, len(repos))
+		for _, r := range repos {
+			worktrees, err := repo.Worktrees(r.BarePath)
+			if err != nil {
+				return fmt.Errorf("failed to read worktrees for %s: %w", r.URL, err)
+			}
+			if worktrees == nil {
+				worktrees = []string{}
+			}
+			entries = append(entries, repoListEntry{
+				URL:       r.URL,
+				Shorthand: r.Shorthand,
+				BarePath:  r.BarePath,
+				Worktrees: worktrees,
+			})
+		}
+
+		printer := cli.GetPrinter(cmd)
+		if len(entries) == 0 && !printer.Structured() {
+			printer.Println("No managed repositories.")
+			return nil
+		}
+		return printer.Result(entries, func(w io.Writer) error {
+			for _, e := range entries {
+				name := e.Shorthand
+				if name == "" {
+					name = e.URL
+				}
+				fmt.Fprintf(w, "%s\n  %s\n", name, e.BarePath)
+				for _, wt := range e.Worktrees {
+					fmt.Fprintf(w, "  └─ %s\n", filepath.Base(wt))
+				}
+			}
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+func newRepoRemoveCmd() *cobra.Command {
+	var force bool
+
+	cmd := cli.NewStandardCommand(
+		"remove <repo>",
+		"Delete a managed repository and all of its worktrees",
+	)
+	cmd.Long = `Delete a managed bare repository together with every worktree in its
+.grove-worktrees directory. The removal is confirmed first, and refused when a
+worktree has uncommitted changes. --force skips both the confirmation and the
+check, discarding those changes.`
+	cmd.Aliases = []string{"rm"}
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Remove without asking, even with uncommitted changes in worktrees")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		manager, err := repo.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to open repo manager: %w", err)
+		}
+
+		info, err := manager.Find(args[0])
+		if err != nil {
+			return err
+		}
+		if !force {
+			worktrees, _ := repo.Worktrees(info.BarePath)
+			if !confirmRepoRemove(bufio.NewReader(cmd.InOrStdin()), cmd.ErrOrStderr(), info.URL, len(worktrees)) {
+				return fmt.Errorf("removal of %s cancelled", info.URL)
+			}
+		}
+		if err := manager.Remove(cmd.Context(), info.URL, force); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", info.URL, err)
+		}
+		return cli.GetPrinter(cmd).Result(info, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Removed %s\n", info.URL)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+// confirmRepoRemove asks whether to delete repoURL and its worktrees.
+func confirmRepoRemove(in *bufio.Reader, out io.Writer, repoURL string, worktrees int) bool {
+	fmt.Fprintf(out, "Remove %s and its %d worktree(s)? [y/N] ", repoURL, worktrees)
+	answer, err := in.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(out)
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// repoWorktree is the structured output of `core repo worktree add/rm`.
+type repoWorktree struct {
+	Repo string `json:"repo"`
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+func newRepoWorktreeCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"worktree",
+		"Manage worktrees of a cloned repository",
+	)
+	cmd.AddCommand(newRepoWorktreeAddCmd())
+	cmd.AddCommand(newRepoWorktreeRmCmd())
+	return cmd
+}
+
+func newRepoWorktreeAddCmd() *cobra.Command {
+	var ref string
+
+	cmd := cli.NewStandardCommand(
+		"add <repo> <name>",
+		"Create a worktree in the repository's .grove-worktrees directory",
+	)
+	cmd.Long = `Create a worktree named <name> for a managed repository. The repository is
+cloned first if needed. --ref selects the branch, tag or commit to check out
+(defaults to the remote's default branch).`
+	cmd.Args = cobra.ExactArgs(2)
+	cmd.Flags().StringVar(&ref, "ref", "", "Branch, tag or commit to check out")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		manager, err := repo.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to open repo manager: %w", err)
+		}
+
+		repoURL := repo.ResolveRepoURL(args[0])
+		if info, err := manager.Find(args[0]); err == nil {
+			repoURL = info.URL
+		}
+
+		path, err := manager.AddWorktree(cmd.Context(), repoURL, args[1], ref)
+		if err != nil {
+			return fmt.Errorf("failed to add worktree: %w", err)
+		}
+		return cli.GetPrinter(cmd).Result(repoWorktree{Repo: repoURL, Name: args[1], Path: path}, func(w io.Writer) error {
+			_, err := fmt.Fprintln(w, path)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+func newRepoWorktreeRmCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"rm <repo> <name>",
+		"Remove a worktree from a cloned repository",
+	)
+	cmd.Long = `Remove a worktree from a managed repository. Like 'git worktree remove', it
+refuses a worktree with uncommitted changes; commit, stash or discard them
+first.`
+	cmd.Aliases = []string{"remove"}
+	cmd.Args = cobra.ExactArgs(2)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		manager, err := repo.NewManager()
+		if err != nil {
+			return fmt.Errorf("failed to open repo manager: %w", err)
+		}
+
+		info, err := manager.Find(args[0])
+		if err != nil {
+			return err
+		}
+		if err := manager.RemoveWorktree(cmd.Context(), info.URL, args[1]); err != nil {
+			return fmt.Errorf("failed to remove worktree: %w", err)
+		}
+		return cli.GetPrinter(cmd).Result(repoWorktree{Repo: info.URL, Name: args[1]}, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Removed worktree %s\n", args[1])
+			return err
+		})
+	}
+
+	return cmd
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/schema"
+)
+
+// NewSchemaCmd creates the `schema` command group.
+func NewSchemaCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"schema",
+		"Print the Grove configuration schemas bundled with this binary",
+	)
+	cmd.Long = `Print the Grove configuration schemas compiled into this binary, with the
+extension schemas registered by installed tools added, and manage those
+registrations.
+
+See 'core config schema print' for the schema of a single configuration key.`
+
+	cmd.AddCommand(newSchemaPrintCmd())
+	cmd.AddCommand(newSchemaRegisterCmd())
+	cmd.AddCommand(newSchemaUnregisterCmd())
+	cmd.AddCommand(newSchemaExtensionsCmd())
+
+	return cmd
+}
+
+func newSchemaPrintCmd() *cobra.Command {
+	var resolvable bool
+	var output string
+
+	cmd := cli.NewStandardCommand(
+		"print",
+		"Print the full configuration schema",
+	)
+	cmd.Long = `Print the full Grove configuration JSON schema.
+
+By default this is the bundled schema, with extension schemas inlined: the
+one Grove validates against, usable offline. With --resolvable it is the
+schema that references extension schemas by URL, for editors and language
+servers that fetch them on their own.
+
+Both include the extensions registered in the extensions.d config
+directory (see 'core schema extensions').
+
+Use --output to write the schema to a file instead of stdout.`
+	cmd.Example = `  core schema print > grove.schema.json
+  core schema print --resolvable --output .grove/grove.schema.json`
+	cmd.Flags().BoolVar(&resolvable, "resolvable", false, "Print the schema with extension $refs left unresolved")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the schema to this file instead of stdout")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		data := schema.Composed()
+		if resolvable {
+			data = schema.ComposedResolvable()
+		}
+
+		if output != "" {
+			if err := os.WriteFile(output, data, 0o644); err != nil { //nolint:gosec // schema file is not sensitive
+				return fmt.Errorf("failed to write schema: %w", err)
+			}
+			return nil
+		}
+		out := cmd.OutOrStdout()
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			_, err := fmt.Fprintln(out)
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func newSchemaRegisterCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"register <registration.json>",
+		"Register an installed tool's extension schemas",
+	)
+	cmd.Long = `Register the grove.yml extension keys of an installed tool, so config
+validation, 'core config schema print' and 'core schema print' use the
+schemas of the installed version.
+
+The registration is copied to the extensions.d config directory as
+<tool>.json, replacing an earlier registration of the same tool. Installers
+may also write that file directly. Its shape is:
+
+  {
+    "tool": "flow",
+    "version": "1.4.0",
+    "extensions": [
+      {"name": "flow", "schema_path": "flow.schema.json", "url": "https://..."}
+    ]
+  }
+
+Each extension gives its schema inline as "schema" or as "schema_path", a
+file relative to the registration. Files stay referenced, so reinstalling the
+tool updates the schema without registering again. "url" is optional; when
+set, the resolvable schema refers editors to it.`
+	cmd.Example = `  core schema register ./dist/flow.extension.json`
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", args[0], err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read registration: %w", err)
+		}
+		reg, err := schema.ParseRegistration(data, path)
+		if err != nil {
+			return err
+		}
+		written, err := schema.Register(schema.ExtensionsDir(), reg)
+		if err != nil {
+			return err
+		}
+		reg.Path = written
+		return cli.GetPrinter(cmd).Result(reg, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Registered %d extension(s) of %s at %s\n", len(reg.Extensions), reg.Tool, written)
+			return err
+		})
+	}
+
+	return cmd
+}
+
+func newSchemaUnregisterCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"unregister <tool>",
+		"Remove a tool's extension schema registration",
+	)
+	cmd.Args = cobra.ExactArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := schema.Unregister(schema.ExtensionsDir(), args[0]); err != nil {
+			return err
+		}
+		return cli.GetPrinter(cmd).Result(map[string]string{"tool": args[0]}, func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "Unregistered %s\n", args[0])
+			return err
+		})
+	}
+
+	return cmd
+}
+
+// registeredExtension is one row of `core schema extensions`.
+type registeredExtension struct {
+	Name    string `json:"name"`
+	Tool    string `json:"tool"`
+	Version string `json:"version,omitempty"`
+	Source  string `json:"source"`
+	URL     string `json:"url,omitempty"`
+}
+
+func newSchemaExtensionsCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"extensions",
+		"List the extension schemas registered by installed tools",
+	)
+	cmd.Long = `List the extension keys registered in the extensions.d config directory and
+the schema each one uses. Registrations that fail to load are reported and
+left out of validation.`
+	cmd.Args = cobra.NoArgs
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		dir := schema.ExtensionsDir()
+		regs, loadErr := schema.LoadRegistrations(dir)
+
+		rows := []registeredExtension{}
+		for _, reg := range regs {
+			for _, ext := range reg.Extensions {
+				source := ext.SchemaPath
+				if source == "" {
+					source = reg.Path
+				}
+				rows = append(rows, registeredExtension{
+					Name:    ext.Name,
+					Tool:    reg.Tool,
+					Version: reg.Version,
+					Source:  source,
+					URL:     ext.URL,
+				})
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+
+		err := cli.GetPrinter(cmd).Result(rows, func(w io.Writer) error {
+			if len(rows) == 0 {
+				fmt.Fprintf(w, "No extension schemas registered in %s.\n", dir)
+				return nil
+			}
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "KEY\tTOOL\tVERSION\tSCHEMA")
+			for _, r := range rows {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, r.Tool, r.Version, r.Source)
+			}
+			return tw.Flush()
+		})
+		if err != nil {
+			return err
+		}
+		if loadErr != nil {
+			return fmt.Errorf("some registrations were skipped:\n%w", loadErr)
+		}
+		return nil
+	}
+
+	return cmd
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/sessions"
+)
+
+// NewSessionsCmd creates the `sessions` command
+func NewSessionsCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"sessions",
+		"Inspect agent sessions",
+	)
+	cmd.Long = `Inspect agent sessions tracked by the grove daemon (or, when the daemon is
+not running, recovered from the on-disk session registry).`
+
+	cmd.AddCommand(newSessionsListCmd())
+	cmd.AddCommand(newSessionsShowCmd())
+	cmd.AddCommand(newSessionsGCCmd())
+
+	return cmd
+}
+
+// repoUsage is the structured shape of one `sessions list --by-repo` row.
+type repoUsage struct {
+	Repo     string `json:"repo"`
+	Sessions int    `json:"sessions"`
+	models.SessionUsage
+}
+
+func newSessionsListCmd() *cobra.Command {
+	var byRepo bool
+
+	cmd := cli.NewStandardCommand(
+		"list",
+		"List sessions with duration, token and cost totals",
+	)
+	cmd.Long = `List agent sessions with their total duration, token usage and cost, as
+aggregated by the session collector from the agent provider's usage files
+(Claude transcripts, OpenCode message stats). Claude costs are the daemon's
+live cost for the session, so they are blank without a running daemon. Live
+sessions whose transcript and status have not changed for
+daemon.collectors.session.idle_threshold (default 10m) are shown as idle.
+
+Use --by-repo to aggregate the totals per repository.`
+	cmd.Aliases = []string{"ls"}
+	cmd.Flags().BoolVar(&byRepo, "by-repo", false, "Aggregate duration and cost per repository")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		client := daemon.New()
+		defer client.Close()
+
+		list, err := client.GetSessions(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+
+		printer := cli.GetPrinter(cmd)
+
+		if byRepo {
+			totals := sessions.AggregateByRepo(list)
+			counts := make(map[string]int)
+			for _, s := range list {
+				counts[s.Repo]++
+			}
+			rows := make([]repoUsage, 0, len(totals))
+			for repo, u := range totals {
+				rows = append(rows, repoUsage{Repo: repo, Sessions: counts[repo], SessionUsage: u})
+			}
+			sort.Slice(rows, func(i, j int) bool { return rows[i].CostUSD > rows[j].CostUSD })
+
+			return printer.Result(rows, func(out io.Writer) error {
+				w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "REPO\tSESSIONS\tDURATION\tTOKENS\tCOST")
+				for _, r := range rows {
+					repo := r.Repo
+					if repo == "" {
+						repo = "(none)"
+					}
+					fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\n", repo, r.Sessions, formatSessionDuration(r.Duration), r.TotalTokens(), formatCost(r.SessionUsage))
+				}
+				return w.Flush()
+			})
+		}
+
+		if len(list) == 0 && !printer.Structured() {
+			printer.Println("No sessions.")
+			return nil
+		}
+		return printer.Result(list, func(out io.Writer) error {
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tREPO\tSTATUS\tDURATION\tTOKENS\tCOST")
+			for _, s := range list {
+				var u models.SessionUsage
+				if s.Usage != nil {
+					u = *s.Usage
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", s.ID, s.Repo, sessionStatus(s, time.Now()), formatSessionDuration(u.Duration), u.TotalTokens(), formatCost(u))
+			}
+			return w.Flush()
+		})
+	}
+
+	return cmd
+}
+
+func newSessionsShowCmd() *cobra.Command {
+	var timeline bool
+
+	cmd := cli.NewStandardCommand(
+		"show <id>",
+		"Show a session's details and activity timeline",
+	)
+	cmd.Long = `Show an agent session: where it runs, its status, duration, token usage and
+cost. The session is matched by ID or by the agent's own session ID.
+
+With --timeline, also list what happened in the session in order: messages,
+tool calls and file edits, read from the agent provider's transcript (the
+Claude transcript reported by hooks, or OpenCode's message files). Providers
+that keep no readable transcript have no timeline.`
+	cmd.Example = `  core sessions show 3f2a9c1e
+  core sessions show 3f2a9c1e --timeline
+  core sessions show 3f2a9c1e --timeline --json`
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.Flags().BoolVar(&timeline, "timeline", false, "List the session's messages, tool calls and file edits in order")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		client := daemon.New()
+		defer client.Close()
+
+		list, err := client.GetSessions(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+		s := findSession(list, args[0])
+		if s == nil {
+			return fmt.Errorf("session not found: %s", args[0])
+		}
+		var usage models.SessionUsage
+		if s.Usage != nil {
+			usage = *s.Usage
+		}
+		if timeline {
+			s.Activities, err = sessions.CollectActivities(s)
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+			}
+		}
+
+		return cli.GetPrinter(cmd).Result(s, func(out io.Writer) error {
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ID:\t%s\n", s.ID)
+			if s.ClaudeSessionID != "" {
+				fmt.Fprintf(w, "Agent session:\t%s\n", s.ClaudeSessionID)
+			}
+			if s.Provider != "" {
+				fmt.Fprintf(w, "Provider:\t%s\n", s.Provider)
+			}
+			fmt.Fprintf(w, "Status:\t%s\n", sessionStatus(s, time.Now()))
+			if s.Repo != "" {
+				fmt.Fprintf(w, "Repo:\t%s (%s)\n", s.Repo, s.Branch)
+			}
+			if s.WorkingDirectory != "" {
+				fmt.Fprintf(w, "Directory:\t%s\n", s.WorkingDirectory)
+			}
+			if s.JobTitle != "" {
+				fmt.Fprintf(w, "Job:\t%s\n", s.JobTitle)
+			}
+			if !s.StartedAt.IsZero() {
+				fmt.Fprintf(w, "Started:\t%s\n", s.StartedAt.Local().Format(time.RFC3339))
+			}
+			if !s.LastActivity.IsZero() {
+				fmt.Fprintf(w, "Last activity:\t%s\n", s.LastActivity.Local().Format(time.RFC3339))
+			}
+			fmt.Fprintf(w, "Duration:\t%s\n", formatSessionDuration(usage.Duration))
+			fmt.Fprintf(w, "Tokens:\t%d\n", usage.TotalTokens())
+			fmt.Fprintf(w, "Cost:\t%s\n", formatCost(usage))
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if !timeline {
+				return nil
+			}
+			fmt.Fprintln(out)
+			if len(s.Activities) == 0 {
+				fmt.Fprintln(out, "No activity recorded.")
+				return nil
+			}
+			return printTimeline(out, s.Activities)
+		})
+	}
+
+	return cmd
+}
+
+// findSession returns the session whose ID or agent session ID is id.
+func findSession(list []*models.Session, id string) *models.Session {
+	for _, s := range list {
+		if s != nil && (s.ID == id || (s.ClaudeSessionID != "" && s.ClaudeSessionID == id)) {
+			return s
+		}
+	}
+	return nil
+}
+
+// printTimeline renders a session's activities, one per line.
+func printTimeline(out io.Writer, activities []models.SessionActivity) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tKIND\tWHAT\tDETAIL")
+	for _, a := range activities {
+		what, detail := a.Tool, a.Summary
+		switch a.Kind {
+		case models.ActivityMessage:
+			what = a.Role
+		case models.ActivityFileEdit:
+			if a.File != "" {
+				detail = a.File
+			}
+		default:
+			if detail == "" {
+				detail = a.File
+			}
+		}
+		ts := "-"
+		if !a.Time.IsZero() {
+			ts = a.Time.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ts, a.Kind, what, detail)
+	}
+	return w.Flush()
+}
+
+func newSessionsGCCmd() *cobra.Command {
+	var (
+		olderThan time.Duration
+		dryRun    bool
+	)
+
+	cmd := cli.NewStandardCommand(
+		"gc",
+		"Remove stale session artifacts",
+	)
+	cmd.Long = `Remove session artifacts left behind by agents that are no longer running:
+hook session directories whose PID has exited, lock files with no owner, and
+empty job directories under the hooks state directory.
+
+Only artifacts untouched for --older-than are removed. The daemon runs the
+same cleanup on a schedule when daemon.session_gc_interval is set.`
+	cmd.Flags().DurationVar(&olderThan, "older-than", sessions.DefaultCleanupAge, "Only remove artifacts untouched for this long")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List what would be removed without removing it")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		var (
+			report *sessions.CleanupReport
+			err    error
+		)
+		if dryRun {
+			var stale []sessions.StaleArtifact
+			stale, err = sessions.StaleArtifacts(olderThan)
+			report = &sessions.CleanupReport{Removed: stale}
+		} else {
+			report, err = sessions.CleanupStale(olderThan)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to clean up sessions: %w", err)
+		}
+
+		printer := cli.GetPrinter(cmd)
+		if len(report.Removed) == 0 && len(report.Failed) == 0 && !printer.Structured() {
+			printer.Println("Nothing to clean up.")
+			return nil
+		}
+		return printer.Result(report, func(out io.Writer) error {
+			verb := "Removed"
+			if dryRun {
+				verb = "Would remove"
+			}
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			for _, a := range report.Removed {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", a.Kind, a.Path, a.Reason)
+			}
+			for _, a := range report.Failed {
+				fmt.Fprintf(w, "failed\t%s\t%s\n", a.Path, a.Reason)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s %d entries\n", verb, len(report.Removed))
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+// sessionStatus renders s's status, with how long it has been inactive
+// when it is marked idle, e.g. "running (idle 25m0s)".
+func sessionStatus(s *models.Session, now time.Time) string {
+	if !s.Idle {
+		return s.Status
+	}
+	return fmt.Sprintf("%s (idle %s)", s.Status, formatSessionDuration(now.Sub(s.LastActivity)))
+}
+
+// formatSessionDuration renders a duration rounded to the second.
+func formatSessionDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+// formatCost renders a USD cost.
+func formatCost(u models.SessionUsage) string {
+	if u.CostUSD == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", u.CostUSD)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/telemetry"
+)
+
+// NewStatsCmd creates the `stats` command.
+func NewStatsCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"stats",
+		"Show local usage statistics",
+	)
+	cmd.AddCommand(newStatsUsageCmd())
+	return cmd
+}
+
+func newStatsUsageCmd() *cobra.Command {
+	var (
+		since time.Duration
+		tool  string
+		flags bool
+	)
+
+	cmd := cli.NewStandardCommand(
+		"usage",
+		"Summarize the local command usage log",
+	)
+	cmd.Long = `Summarize the opt-in command usage log: how often each command ran, how
+often it failed, how long it took and when it was last used.
+
+Usage is recorded only when telemetry.enabled is true in the global config
+(or GROVE_TELEMETRY=1 is set). Each grove CLI run then appends its command
+name, the names of the flags that were set, its duration and exit status to
+telemetry.jsonl in the state directory. Flag values and arguments are never
+recorded, and nothing leaves the machine.`
+	cmd.Args = cobra.NoArgs
+	cmd.Flags().DurationVar(&since, "since", 0, "Only count runs within this long (e.g. 720h)")
+	cmd.Flags().StringVar(&tool, "tool", "", "Only count runs of this tool (e.g. core, flow)")
+	cmd.Flags().BoolVar(&flags, "flags", false, "Show how often each flag was set")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		events, err := telemetry.Read(telemetry.Path())
+		if err != nil {
+			return err
+		}
+		var cutoff time.Time
+		if since > 0 {
+			cutoff = time.Now().Add(-since)
+		}
+		kept := events[:0]
+		for _, e := range events {
+			if (tool == "" || e.Tool == tool) && !e.Time.Before(cutoff) {
+				kept = append(kept, e)
+			}
+		}
+		usage := telemetry.Summarize(kept)
+
+		printer := cli.GetPrinter(cmd)
+		if len(usage) == 0 && !printer.Structured() {
+			if !telemetry.Enabled() {
+				printer.Println("No usage recorded. Set telemetry.enabled: true in the global config to start recording.")
+			} else {
+				printer.Println("No usage recorded.")
+			}
+			return nil
+		}
+		return printer.Result(usage, func(out io.Writer) error {
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			header := "COMMAND\tRUNS\tFAILED\tAVG\tMAX\tLAST USED"
+			if flags {
+				header += "\tFLAGS"
+			}
+			fmt.Fprintln(w, header)
+			for _, u := range usage {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s", u.Command, u.Runs, u.Failures,
+					formatMillis(u.AvgMs), formatMillis(u.MaxMs), u.LastUsed.Local().Format("2006-01-02 15:04"))
+				if flags {
+					fmt.Fprintf(w, "\t%s", formatFlagCounts(u.FlagCounts))
+				}
+				fmt.Fprintln(w)
+			}
+			return w.Flush()
+		})
+	}
+
+	return cmd
+}
+
+// formatMillis renders a duration in milliseconds for the usage table.
+func formatMillis(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d < time.Second {
+		return d.String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// formatFlagCounts renders flag counts as "--name×n", most used first.
+func formatFlagCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("--%s×%d", name, counts[name])
+	}
+	return strings.Join(parts, " ")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/core/pkg/mux"
+	"github.com/grovetools/core/pkg/procman"
 )
 
 func NewTmuxEditorCmd() *cobra.Command {
@@ -56,7 +57,7 @@ func NewTmuxEditorCmd() *cobra.Command {
 				editorCmd.Stdin = os.Stdin
 				editorCmd.Stdout = os.Stdout
 				editorCmd.Stderr = os.Stderr
-				return editorCmd.Run()
+				return procman.Default().Run(editorCmd, procman.Options{Name: "editor", StopOrder: procman.OrderEditor})
 			}
 
 			tuiEngine, ok := engine.(mux.MuxTUIEngine)
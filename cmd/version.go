@@ -1,37 +1,21 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/spf13/cobra"
 
+	"github.com/grovetools/core/cli"
 	"github.com/grovetools/core/version"
 )
 
 func NewVersionCmd() *cobra.Command {
-	var jsonOutput bool
-
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the version information for this binary",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			info := version.GetInfo()
-
-			if jsonOutput {
-				jsonData, err := json.MarshalIndent(info, "", "  ")
-				if err != nil {
-					return fmt.Errorf("failed to marshal version info to JSON: %w", err)
-				}
-				fmt.Println(string(jsonData))
-			} else {
-				fmt.Println(info.String())
-			}
-			return nil
+			return cli.GetPrinter(cmd).Result(info, nil)
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output version information in JSON format")
-
 	return cmd
 }
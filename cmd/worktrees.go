@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -29,6 +30,19 @@ func NewWorktreesCmd() *cobra.Command {
 	return cmd
 }
 
+// worktreeListEntry is the structured shape of one `worktrees list` workspace.
+type worktreeListEntry struct {
+	Workspace string             `json:"workspace"`
+	Worktrees []worktreeListItem `json:"worktrees"`
+}
+
+type worktreeListItem struct {
+	Path   string          `json:"path"`
+	Branch string          `json:"branch,omitempty"`
+	Commit string          `json:"commit,omitempty"`
+	Status *git.StatusInfo `json:"status,omitempty"`
+}
+
 func newWorktreesListCmd() *cobra.Command {
 	cmd := cli.NewStandardCommand(
 		"list",
@@ -68,8 +82,9 @@ Only shows workspaces that have additional worktrees beyond the main one.`
 			}
 		}
 
-		if len(results) == 0 {
-			fmt.Println("No workspaces have additional worktrees.")
+		printer := cli.GetPrinter(cmd)
+		if len(results) == 0 && !printer.Structured() {
+			printer.Println("No workspaces have additional worktrees.")
 			return nil
 		}
 
@@ -78,31 +93,46 @@ Only shows workspaces that have additional worktrees beyond the main one.`
 			return results[i].name < results[j].name
 		})
 
-		// Display results. Styles are derived from the active theme at
-		// render time rather than captured in package vars.
-		t := theme.DefaultTheme
-		headerStyle := t.Highlight.MarginTop(1)
-		boxStyle := lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(t.Colors.Border).
-			Padding(0, 1).
-			MarginLeft(2)
+		entries := make([]worktreeListEntry, 0, len(results))
 		for _, result := range results {
-			header := headerStyle.Render(result.name)
-			fmt.Println(header)
-
-			var lines []string
+			entry := worktreeListEntry{Workspace: result.name}
 			for _, wt := range result.worktrees {
-				line := formatWorktreeLine(wt)
-				lines = append(lines, line)
+				entry.Worktrees = append(entry.Worktrees, worktreeListItem{
+					Path:   wt.Path,
+					Branch: wt.Branch,
+					Commit: wt.Commit,
+					Status: wt.Status,
+				})
 			}
-
-			content := strings.Join(lines, "\n")
-			boxed := boxStyle.Render(content)
-			fmt.Println(boxed)
+			entries = append(entries, entry)
 		}
 
-		return nil
+		return printer.Result(entries, func(w io.Writer) error {
+			// Styles are derived from the active theme at render time
+			// rather than captured in package vars.
+			t := theme.DefaultTheme
+			headerStyle := t.Highlight.MarginTop(1)
+			boxStyle := lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder()).
+				BorderForeground(t.Colors.Border).
+				Padding(0, 1).
+				MarginLeft(2)
+			for _, result := range results {
+				header := headerStyle.Render(result.name)
+				fmt.Fprintln(w, header)
+
+				var lines []string
+				for _, wt := range result.worktrees {
+					line := formatWorktreeLine(wt)
+					lines = append(lines, line)
+				}
+
+				content := strings.Join(lines, "\n")
+				boxed := boxStyle.Render(content)
+				fmt.Fprintln(w, boxed)
+			}
+			return nil
+		})
 	}
 
 	return cmd
@@ -1,14 +1,15 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/models"
 	"github.com/grovetools/core/pkg/workspace"
 	"github.com/grovetools/core/tui/wsnav"
 )
@@ -21,28 +22,46 @@ func NewWsCmd() *cobra.Command {
 	)
 	cmd.Long = `This command launches an interactive TUI to navigate and explore all workspaces
 discovered by Grove based on your configuration. It provides a hierarchical view
-of ecosystems, projects, and worktrees.`
+of ecosystems, projects, and worktrees. Bare repositories and submodule
+checkouts found under your groves are listed alongside them.
 
-	cmd.Flags().Bool("json", false, "Output discovered workspaces in JSON format")
+With --submodules, each project's .gitmodules is read as well, so submodules
+that discovery would not otherwise reach (including uninitialized ones) appear
+in the inventory.
+
+Groves whose path is ssh://[user@]host[:port]/path are discovered on that
+host by running their command (default: core ws --json --local) over ssh;
+their workspaces are listed after the local ones with the host set. --local
+skips them.
+
+With --json or --yaml, the discovered workspaces are printed instead of
+launching the TUI.`
+
+	cmd.Flags().Bool("submodules", false, "Also list each project's submodules from .gitmodules")
+	cmd.Flags().Bool("local", false, "Skip groves on other hosts (ssh:// paths)")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		logger := cli.GetLogger(cmd)
 
 		// Discover all workspaces using the centralized function
-		projects, err := workspace.GetProjects(logger)
+		discovery := workspace.NewDiscoveryService(logger)
+		if withSubmodules, _ := cmd.Flags().GetBool("submodules"); withSubmodules {
+			discovery = discovery.WithSubmodules()
+		}
+		if localOnly, _ := cmd.Flags().GetBool("local"); !localOnly {
+			discovery = discovery.WithRemote()
+		}
+		progress := cli.NewProgress(cmd, "Discovering workspaces", 0)
+		projects, err := discovery.WithProgress(progress).GetProjects()
 		if err != nil {
+			progress.Fail(err)
 			return fmt.Errorf("failed to discover workspaces: %w", err)
 		}
+		progress.Done()
 
-		// Handle JSON output
-		jsonOutput, _ := cmd.Flags().GetBool("json")
-		if jsonOutput {
-			jsonData, err := json.MarshalIndent(projects, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal projects to JSON: %w", err)
-			}
-			fmt.Println(string(jsonData))
-			return nil
+		printer := cli.GetPrinter(cmd)
+		if printer.Structured() {
+			return printer.Result(models.NewWorkspaces(projects), nil)
 		}
 
 		// Launch the TUI with 30 second refresh interval
@@ -55,7 +74,7 @@ of ecosystems, projects, and worktrees.`
 
 		// If a project was selected, print its path to stdout.
 		if m, ok := finalModel.(*wsnav.Model); ok && m.SelectedProject != nil {
-			fmt.Println(m.SelectedProject.Path)
+			return printer.Result(m.SelectedProject.Path, nil)
 		}
 
 		return nil
@@ -63,6 +82,12 @@ of ecosystems, projects, and worktrees.`
 
 	// Add subcommand for getting current workspace
 	cmd.AddCommand(newWsCwdCmd())
+	cmd.AddCommand(newWsWatchCmd())
+	cmd.AddCommand(newWsInitCmd())
+	cmd.AddCommand(newWsGraphCmd())
+	cmd.AddCommand(newWsHistoryCmd())
+	cmd.AddCommand(newWsPruneCmd())
+	cmd.AddCommand(newWsEnvCmd())
 
 	return cmd
 }
@@ -76,8 +101,6 @@ func newWsCwdCmd() *cobra.Command {
 	cmd.Long = `Get the workspace information for the current working directory.
 This command uses GetProjectByPath to find the workspace containing the current directory.`
 
-	cmd.Flags().Bool("json", false, "Output workspace in JSON format")
-
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		// Get current working directory
 		cwd, err := os.Getwd()
@@ -91,32 +114,21 @@ This command uses GetProjectByPath to find the workspace containing the current
 			return fmt.Errorf("failed to get workspace: %w", err)
 		}
 
-		// Handle JSON output
-		jsonOutput, _ := cmd.Flags().GetBool("json")
-		if jsonOutput {
-			jsonData, err := json.MarshalIndent(node, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal workspace to JSON: %w", err)
+		return cli.GetPrinter(cmd).Result(models.NewWorkspace(node), func(w io.Writer) error {
+			fmt.Fprintf(w, "Name: %s\n", node.Name)
+			fmt.Fprintf(w, "Path: %s\n", node.Path)
+			fmt.Fprintf(w, "Kind: %s\n", node.Kind)
+			if node.ParentProjectPath != "" {
+				fmt.Fprintf(w, "Parent Project: %s\n", node.ParentProjectPath)
+			}
+			if node.ParentEcosystemPath != "" {
+				fmt.Fprintf(w, "Parent Ecosystem: %s\n", node.ParentEcosystemPath)
+			}
+			if node.RootEcosystemPath != "" {
+				fmt.Fprintf(w, "Root Ecosystem: %s\n", node.RootEcosystemPath)
 			}
-			fmt.Println(string(jsonData))
 			return nil
-		}
-
-		// Pretty print output
-		fmt.Printf("Name: %s\n", node.Name)
-		fmt.Printf("Path: %s\n", node.Path)
-		fmt.Printf("Kind: %s\n", node.Kind)
-		if node.ParentProjectPath != "" {
-			fmt.Printf("Parent Project: %s\n", node.ParentProjectPath)
-		}
-		if node.ParentEcosystemPath != "" {
-			fmt.Printf("Parent Ecosystem: %s\n", node.ParentEcosystemPath)
-		}
-		if node.RootEcosystemPath != "" {
-			fmt.Printf("Root Ecosystem: %s\n", node.RootEcosystemPath)
-		}
-
-		return nil
+		})
 	}
 
 	return cmd
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/logging/logutil"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// newWsEnvCmd creates the `ws env` subcommand.
+func newWsEnvCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"env [name]",
+		"Print export statements for a workspace's Grove context",
+	)
+	cmd.Long = `Print sh export statements describing a workspace, for shells and tools to
+source so they agree on the Grove context they run in:
+
+  GROVE_WORKSPACE, GROVE_WORKSPACE_PATH, GROVE_WORKSPACE_ID,
+  GROVE_WORKSPACE_KIND, GROVE_WORKSPACE_ECOSYSTEM
+                            the workspace, as set by 'core editor --workspace'
+  GROVE_PROJECT             the project (a worktree's parent repository)
+  GROVE_ECOSYSTEM           the ecosystem's name, when it belongs to one
+  GROVE_NOTEBOOK_PLANS_DIR, GROVE_NOTEBOOK_CHATS_DIR, GROVE_NOTEBOOK_NOTES_DIR
+                            its notebook directories
+  GROVE_LOG_DIR             the directory its log files are written to
+
+The workspace is the one containing the current directory, or [name] (a
+name such as "api" or an identifier such as "eco:api") resolved by
+discovery. Directories are resolved from the workspace's configuration and
+need not exist yet.`
+	cmd.Example = `  eval "$(core ws env)"
+  eval "$(core ws env eco:api)"
+  core ws env api --json`
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		node, err := wsEnvTarget(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		env, err := workspaceContextEnv(node)
+		if err != nil {
+			return err
+		}
+		return cli.GetPrinter(cmd).Result(env, func(w io.Writer) error {
+			for _, assignment := range shellEnvAssignments(env) {
+				if _, err := fmt.Fprintln(w, "export "+assignment); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+// wsEnvTarget resolves the workspace named in args, or the one containing
+// the current directory.
+func wsEnvTarget(cmd *cobra.Command, args []string) (*workspace.WorkspaceNode, error) {
+	if len(args) == 1 {
+		return resolveNamedWorkspace(cmd, args[0])
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	node, err := workspace.GetProjectByPath(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return node, nil
+}
+
+// workspaceContextEnv resolves node's context variables with its own
+// configuration, adding the log directory core/logging writes it to. A
+// configuration that fails to load is an error rather than silently
+// resolving notebook directories from defaults.
+func workspaceContextEnv(node *workspace.WorkspaceNode) (map[string]string, error) {
+	cfg, err := config.LoadFrom(node.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration for %s: %w", node.Name, err)
+	}
+	env, err := node.ContextEnvVars(workspace.NewNotebookLocator(cfg))
+	if err != nil {
+		return nil, err
+	}
+	// The error only reports that no log file has been written yet.
+	if _, logsDir, _ := logutil.FindLogFileForWorkspace(node); logsDir != "" {
+		env[workspace.EnvLogDir] = logsDir
+	}
+	return env, nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// newWsGraphCmd creates the `ws graph` subcommand.
+func newWsGraphCmd() *cobra.Command {
+	var format string
+
+	cmd := cli.NewStandardCommand(
+		"graph",
+		"Export the workspace relationship graph",
+	)
+	cmd.Long = `Print the graph of discovered workspaces: ecosystems, the projects in them,
+their worktrees, and repositories cloned with 'core repo'. Edges point from
+an ecosystem to what it contains and from a repository to its worktrees.
+
+Formats:
+  dot      Graphviz (render with: dot -Tsvg)
+  mermaid  Mermaid flowchart, for Markdown docs and dashboards
+  json     nodes and edges keyed by path`
+	cmd.Example = `  core ws graph | dot -Tsvg > workspaces.svg
+  core ws graph --format mermaid
+  core ws graph --format json`
+	cmd.Flags().StringVar(&format, "format", "dot", "Output format: dot, mermaid or json")
+	cmd.Flags().Bool("submodules", false, "Also include each project's submodules from .gitmodules")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if format != "dot" && format != "mermaid" && format != "json" {
+			return fmt.Errorf("unknown format %q (want dot, mermaid or json)", format)
+		}
+
+		discovery := workspace.NewDiscoveryService(cli.GetLogger(cmd))
+		if withSubmodules, _ := cmd.Flags().GetBool("submodules"); withSubmodules {
+			discovery = discovery.WithSubmodules()
+		}
+		projects, err := discovery.GetProjects()
+		if err != nil {
+			return fmt.Errorf("failed to discover workspaces: %w", err)
+		}
+		graph := models.NewWorkspaceGraph(projects)
+
+		printer := cli.GetPrinter(cmd)
+		if printer.Structured() {
+			return printer.Result(graph, nil)
+		}
+		switch format {
+		case "json":
+			return cli.NewPrinter(cmd.OutOrStdout(), cli.FormatJSON, false).Result(graph, nil)
+		case "mermaid":
+			return graph.WriteMermaid(cmd.OutOrStdout())
+		default:
+			return graph.WriteDOT(cmd.OutOrStdout())
+		}
+	}
+
+	return cmd
+}
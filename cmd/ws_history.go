@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/grovetools/core/pkg/wshistory"
+)
+
+// newWsHistoryCmd creates the `ws history` subcommand.
+func newWsHistoryCmd() *cobra.Command {
+	var (
+		since  string
+		name   string
+		types  []string
+		noScan bool
+	)
+
+	cmd := cli.NewStandardCommand(
+		"history [path]",
+		"Show when workspaces were added, created, removed or moved",
+	)
+	cmd.Long = `Print the workspace lifecycle journal: projects added and removed, worktrees
+created and removed, and ecosystems moved, oldest first.
+
+The daemon records the workspace set after each rescan and this command
+records it before reading, so each event's time is when the change was first
+observed. Events are kept in the state directory (workspaces/history.jsonl).
+
+With a path, only events for that workspace, the workspaces under it, or the
+worktrees of the repository at it are shown.
+
+Event types for --type: project_added, project_removed, worktree_created,
+worktree_removed, ecosystem_added, ecosystem_removed, ecosystem_moved.`
+	cmd.Example = `  core ws history
+  core ws history ~/code/grove --since 168h
+  core ws history --type worktree_removed --name feature
+  core ws history --json`
+	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.Flags().StringVar(&since, "since", "", "Only events after this RFC 3339 time or this long ago (e.g. 24h)")
+	cmd.Flags().StringVar(&name, "name", "", "Only workspaces whose name contains this text")
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Only these event types (repeatable)")
+	cmd.Flags().BoolVar(&noScan, "no-scan", false, "Read the journal without recording the current workspace set first")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		filter := wshistory.Filter{Name: name}
+		var err error
+		if filter.Since, err = parseReplayTime("--since", since); err != nil {
+			return err
+		}
+		for _, t := range types {
+			if !slices.Contains(wshistory.EventTypes, wshistory.EventType(t)) {
+				return fmt.Errorf("unknown event type %q", t)
+			}
+			filter.Types = append(filter.Types, wshistory.EventType(t))
+		}
+		if len(args) == 1 {
+			if filter.Path, err = filepath.Abs(args[0]); err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", args[0], err)
+			}
+		}
+
+		journal := wshistory.Open("")
+		if !noScan {
+			projects, err := workspace.NewDiscoveryService(cli.GetLogger(cmd)).GetProjects()
+			if err != nil {
+				return fmt.Errorf("failed to discover workspaces: %w", err)
+			}
+			if _, err := journal.Record(projects, "cli"); err != nil {
+				return err
+			}
+		}
+		events, err := journal.Read(filter)
+		if err != nil {
+			return err
+		}
+		if events == nil {
+			events = []wshistory.Event{}
+		}
+
+		return cli.GetPrinter(cmd).Result(events, func(w io.Writer) error {
+			return printWsHistory(w, events)
+		})
+	}
+
+	return cmd
+}
+
+func printWsHistory(w io.Writer, events []wshistory.Event) error {
+	if len(events) == 0 {
+		fmt.Fprintln(w, "No workspace changes recorded yet.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tEVENT\tNAME\tPATH")
+	for _, ev := range events {
+		path := ev.Path
+		if ev.PreviousPath != "" {
+			path = ev.PreviousPath + " -> " + ev.Path
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ev.Time.Local().Format("2006-01-02 15:04:05"), ev.Type, ev.Name, path)
+	}
+	return tw.Flush()
+}
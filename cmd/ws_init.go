@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/schema"
+)
+
+// wsInitConfig is the grove.yml written by `core ws init`. Field order is
+// the order keys appear in the file.
+type wsInitConfig struct {
+	Name       string         `yaml:"name"`
+	Version    string         `yaml:"version"`
+	Workspaces []string       `yaml:"workspaces,omitempty"`
+	Logging    *wsInitLogging `yaml:"logging,omitempty"`
+	TUI        *wsInitTUI     `yaml:"tui,omitempty"`
+}
+
+type wsInitLogging struct {
+	Level string             `yaml:"level"`
+	File  *wsInitLoggingFile `yaml:"file,omitempty"`
+}
+
+type wsInitLoggingFile struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+type wsInitTUI struct {
+	Theme string `yaml:"theme,omitempty"`
+	Icons string `yaml:"icons,omitempty"`
+}
+
+// wsInitResult is the structured output of `core ws init`.
+type wsInitResult struct {
+	Path       string `json:"path" yaml:"path"`
+	Kind       string `json:"kind" yaml:"kind"`
+	Registered string `json:"registered,omitempty" yaml:"registered,omitempty"`
+}
+
+// newWsInitCmd creates the `ws init` subcommand.
+func newWsInitCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"init [dir]",
+		"Scaffold a grove.yml for a project or ecosystem",
+	)
+	cmd.Long = `Write a grove.yml in dir (default: the current directory) for a project or,
+with --kind ecosystem, an ecosystem whose members match --workspaces.
+
+The file includes a logging block and, when --theme or --icons is given, a
+tui block. It is validated against the schema bundled with this binary
+before it is written.
+
+A new project inside an ecosystem is added to the nearest ecosystem's
+workspaces list, unless an existing pattern already covers it or
+--no-register is given.
+
+With -i, each setting is prompted for, with the flag values as defaults.`
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	cmd.Flags().String("kind", "project", "What to scaffold: project or ecosystem")
+	cmd.Flags().String("name", "", "Workspace name (default: the directory name)")
+	cmd.Flags().StringSlice("workspaces", []string{"*"}, "Member glob patterns for an ecosystem (comma-separated)")
+	cmd.Flags().String("log-level", "info", "logging.level: trace, debug, info, warn, error")
+	cmd.Flags().Bool("log-file", false, "Enable the logging file sink")
+	cmd.Flags().String("theme", "", "tui.theme")
+	cmd.Flags().String("icons", "", "tui.icons: nerd or ascii")
+	cmd.Flags().Bool("no-register", false, "Do not add the workspace to the enclosing ecosystem")
+	cmd.Flags().Bool("force", false, "Overwrite an existing grove config in dir")
+	cmd.Flags().BoolP("interactive", "i", false, "Prompt for each setting")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve directory: %w", err)
+		}
+
+		kind, _ := cmd.Flags().GetString("kind")
+		name, _ := cmd.Flags().GetString("name")
+		patterns, _ := cmd.Flags().GetStringSlice("workspaces")
+		logLevel, _ := cmd.Flags().GetString("log-level")
+		logFile, _ := cmd.Flags().GetBool("log-file")
+		theme, _ := cmd.Flags().GetString("theme")
+		icons, _ := cmd.Flags().GetString("icons")
+		noRegister, _ := cmd.Flags().GetBool("no-register")
+		force, _ := cmd.Flags().GetBool("force")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		if name == "" {
+			name = filepath.Base(dir)
+		}
+
+		if interactive {
+			p := &initPrompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+			kind = p.ask("Kind (project/ecosystem)", kind)
+			name = p.ask("Name", name)
+			if kind == "ecosystem" {
+				patterns = splitList(p.ask("Workspace patterns", strings.Join(patterns, ",")))
+			}
+			logLevel = p.ask("Log level (debug/info/warn/error)", logLevel)
+			logFile = p.askBool("Enable log file", logFile)
+			theme = p.ask("TUI theme (empty to skip)", theme)
+			icons = p.ask("TUI icons (nerd/ascii, empty to skip)", icons)
+			if p.err != nil {
+				return fmt.Errorf("failed to read answers: %w", p.err)
+			}
+		}
+
+		if kind != "project" && kind != "ecosystem" {
+			return fmt.Errorf("invalid --kind %q: must be project or ecosystem", kind)
+		}
+
+		target := filepath.Join(dir, "grove.yml")
+		if existing := existingGroveConfig(dir); existing != "" {
+			switch {
+			case !force:
+				return fmt.Errorf("%s already exists; use --force to overwrite", existing)
+			case existing != target:
+				return fmt.Errorf("%s already exists and --force only replaces grove.yml; remove it first", existing)
+			}
+		}
+
+		cfg := wsInitConfig{
+			Name:    name,
+			Version: "1.0",
+			Logging: &wsInitLogging{Level: logLevel},
+		}
+		if kind == "ecosystem" {
+			if len(patterns) == 0 {
+				return fmt.Errorf("an ecosystem needs at least one --workspaces pattern")
+			}
+			cfg.Workspaces = patterns
+		}
+		if logFile {
+			cfg.Logging.File = &wsInitLoggingFile{Enabled: true}
+		}
+		if theme != "" || icons != "" {
+			cfg.TUI = &wsInitTUI{Theme: theme, Icons: icons}
+		}
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(cfg); err != nil {
+			return fmt.Errorf("failed to render config: %w", err)
+		}
+		data := buf.Bytes()
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to render config: %w", err)
+		}
+		validator, err := schema.NewValidator()
+		if err != nil {
+			return err
+		}
+		if err := validator.Validate(doc); err != nil {
+			return fmt.Errorf("generated config is invalid, nothing written: %w", err)
+		}
+
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil { //nolint:gosec // config file is not sensitive
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+
+		result := wsInitResult{Path: target, Kind: kind}
+		if !noRegister {
+			if eco := config.FindEcosystemConfig(filepath.Dir(dir)); eco != "" {
+				added, err := config.RegisterWorkspace(eco, dir)
+				if err != nil {
+					return fmt.Errorf("wrote %s but failed to register it: %w", target, err)
+				}
+				if added {
+					result.Registered = eco
+				}
+			}
+		}
+
+		return cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			fmt.Fprintf(w, "Created %s (%s)\n", target, kind)
+			if result.Registered != "" {
+				fmt.Fprintf(w, "Added to the workspaces of %s\n", result.Registered)
+			}
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+// existingGroveConfig returns the grove config file in dir itself, if any.
+func existingGroveConfig(dir string) string {
+	for _, name := range []string{"grove.toml", "grove.yml", "grove.yaml", "grove.json5", ".grove.toml", ".grove.yml", ".grove.yaml", ".grove.json5"} {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// initPrompter asks line-based questions for `ws init -i`. The first read
+// error is kept and later questions return their defaults.
+type initPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	err error
+}
+
+func (p *initPrompter) ask(label, def string) string {
+	if p.err != nil {
+		return def
+	}
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err != io.EOF {
+			p.err = err
+		}
+		return def
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return def
+}
+
+func (p *initPrompter) askBool(label string, def bool) bool {
+	d := "n"
+	if def {
+		d = "y"
+	}
+	switch strings.ToLower(p.ask(label+" (y/n)", d)) {
+	case "y", "yes", "true":
+		return true
+	case "n", "no", "false":
+		return false
+	}
+	return def
+}
+
+// splitList splits a comma-separated answer, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
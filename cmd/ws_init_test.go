@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runWsInit(t *testing.T, stdin string, args ...string) error {
+	t.Helper()
+	cmd := newWsInitCmd()
+	cmd.SetArgs(args)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return cmd.Execute()
+}
+
+func TestWsInitRegistersWithEcosystem(t *testing.T) {
+	root := t.TempDir()
+	eco := filepath.Join(root, "grove.yml")
+	if err := os.WriteFile(eco, []byte("name: eco\nworkspaces:\n  - libs/*\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runWsInit(t, "", filepath.Join(root, "api"), "--log-level", "debug", "--icons", "ascii"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "api", "grove.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "name: api\nversion: \"1.0\"\nlogging:\n  level: debug\ntui:\n  icons: ascii\n"
+	if string(data) != want {
+		t.Errorf("grove.yml =\n%s\nwant\n%s", data, want)
+	}
+	ecoData, _ := os.ReadFile(eco)
+	if !strings.Contains(string(ecoData), "- api") {
+		t.Errorf("api not registered:\n%s", ecoData)
+	}
+
+	if err := runWsInit(t, "", filepath.Join(root, "api")); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an existing-config error, got %v", err)
+	}
+}
+
+func TestWsInitRejectsInvalidConfig(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "proj")
+	err := runWsInit(t, "", dir, "--log-level", "loud", "--no-register")
+	if err == nil || !strings.Contains(err.Error(), "nothing written") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "grove.yml")); !os.IsNotExist(err) {
+		t.Error("invalid config should not be written")
+	}
+}
+
+func TestWsInitInteractive(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "eco")
+	answers := "ecosystem\nplatform\napps/*, libs/*\n\ny\n\n\n"
+	if err := runWsInit(t, answers, dir, "-i", "--no-register"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "grove.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "name: platform\nversion: \"1.0\"\nworkspaces:\n  - apps/*\n  - libs/*\nlogging:\n  level: info\n  file:\n    enabled: true\n"
+	if string(data) != want {
+		t.Errorf("grove.yml =\n%s\nwant\n%s", data, want)
+	}
+}
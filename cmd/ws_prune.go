@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/prune"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// newWsPruneCmd creates the `ws prune` subcommand.
+func newWsPruneCmd() *cobra.Command {
+	var (
+		dryRun bool
+		force  bool
+		all    bool
+	)
+
+	cmd := cli.NewStandardCommand(
+		"prune [path]",
+		"Remove dead worktrees and orphaned worktree directories",
+	)
+	cmd.Long = `Find and remove dead worktrees of a repository by cross-referencing the
+directories under its worktree bases (.grove-worktrees and the XDG worktrees
+directory) with 'git worktree list':
+
+  missing_dir      git still registers the worktree but its directory is gone
+  missing_git      the directory has no .git reference, nor does any repo in it
+  stale_gitdir     the directory's .git file points at git metadata that is gone
+  branch_deleted   the worktree's branch (or, when detached, the branch it is
+                   named after) no longer exists
+
+Each worktree is confirmed before it is removed unless --force is given.
+Registered worktrees are removed with 'git worktree remove', which refuses to
+discard uncommitted changes; other directories are deleted. Git's worktree
+registrations are pruned afterwards.
+
+The repository is the one containing path (default: the current directory),
+or every discovered repository with --all.`
+	cmd.Example = `  core ws prune --dry-run
+  core ws prune ~/code/api
+  core ws prune --all --force
+  core ws prune --all --dry-run --json`
+	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report dead worktrees without removing them")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Remove without asking for confirmation")
+	cmd.Flags().BoolVar(&all, "all", false, "Prune the worktrees of every discovered repository")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		logger := cli.GetLogger(cmd)
+		git := prune.ExecRunner{}
+
+		roots, err := pruneRepoRoots(logger, args, all, git)
+		if err != nil {
+			return err
+		}
+
+		result := prune.WorktreePruneResult{Dead: []prune.DeadWorktree{}, DryRun: dryRun}
+		for _, root := range roots {
+			dead, err := prune.DetectDeadWorktrees(root, nil, git)
+			if err != nil {
+				if all {
+					logger.WithError(err).WithField("path", root).Warn("Failed to check worktrees")
+					continue
+				}
+				return err
+			}
+			result.Dead = append(result.Dead, dead...)
+		}
+
+		if !dryRun {
+			in := bufio.NewReader(cmd.InOrStdin())
+			for _, d := range result.Dead {
+				if !force && !confirmPrune(in, cmd.ErrOrStderr(), d) {
+					continue
+				}
+				if err := prune.RemoveDeadWorktree(d, nil, git); err != nil {
+					result.Failed = append(result.Failed, prune.FailedWorktreeRemoval{Worktree: d, Error: err.Error()})
+					continue
+				}
+				result.Removed = append(result.Removed, d)
+			}
+		}
+
+		if err := cli.GetPrinter(cmd).Result(result, func(w io.Writer) error {
+			return printWsPrune(w, result)
+		}); err != nil {
+			return err
+		}
+		if len(result.Failed) > 0 {
+			return fmt.Errorf("failed to remove %d of %d dead worktrees", len(result.Failed), len(result.Dead))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// pruneRepoRoots returns the main worktree roots to prune: the repository
+// containing the path argument (or the current directory), or with all,
+// every discovered repository.
+func pruneRepoRoots(logger *logrus.Logger, args []string, all bool, git prune.Runner) ([]string, error) {
+	if !all {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+		}
+		root, err := mainRepoRoot(abs, git)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not in a git repository: %w", abs, err)
+		}
+		return []string{root}, nil
+	}
+
+	projects, err := workspace.GetProjects(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	var roots []string
+	seen := make(map[string]bool)
+	for _, p := range projects {
+		if p.IsWorktree() {
+			continue
+		}
+		root, err := mainRepoRoot(p.Path, git)
+		if err != nil || seen[root] {
+			continue
+		}
+		seen[root] = true
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// mainRepoRoot returns the main worktree of the repository containing dir,
+// so pruning from inside a linked worktree checks the whole repository.
+func mainRepoRoot(dir string, git prune.Runner) (string, error) {
+	out, err := git.Run("git", "-C", dir, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return "", err
+	}
+	common := strings.TrimSpace(string(out))
+	if filepath.Base(common) != ".git" {
+		return "", fmt.Errorf("bare repository at %s", common)
+	}
+	root := filepath.Dir(common)
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	return root, nil
+}
+
+// confirmPrune asks whether to remove d, defaulting to no.
+func confirmPrune(in *bufio.Reader, out io.Writer, d prune.DeadWorktree) bool {
+	fmt.Fprintf(out, "Remove %s (%s)? [y/N] ", d.Path, d.Reason)
+	answer, err := in.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(out)
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func printWsPrune(w io.Writer, result prune.WorktreePruneResult) error {
+	if len(result.Dead) == 0 {
+		fmt.Fprintln(w, "No dead worktrees found.")
+		return nil
+	}
+
+	actions := make(map[string]string)
+	for _, d := range result.Removed {
+		actions[d.Path] = "removed"
+	}
+	for _, f := range result.Failed {
+		actions[f.Worktree.Path] = "failed: " + f.Error
+	}
+
+	cwd, _ := os.Getwd()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tREASON\tBRANCH\tACTION")
+	for _, d := range result.Dead {
+		action, ok := actions[d.Path]
+		switch {
+		case ok:
+		case result.DryRun:
+			action = "would remove"
+		default:
+			action = "kept"
+		}
+		path := d.Path
+		if rel, err := filepath.Rel(cwd, d.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", path, d.Reason, d.Branch, action)
+	}
+	return tw.Flush()
+}
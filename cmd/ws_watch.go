@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/core/cli"
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/grovetools/core/tui/wswatch"
+)
+
+// wsWatchEvent is one line of `core ws watch --json` output.
+type wsWatchEvent struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Name  string    `json:"name"`
+	Path  string    `json:"path"`
+	Kind  string    `json:"kind"`
+}
+
+// newWsWatchCmd creates the `ws watch` subcommand.
+func newWsWatchCmd() *cobra.Command {
+	cmd := cli.NewStandardCommand(
+		"watch",
+		"Watch the workspace tree and highlight additions and removals",
+	)
+	cmd.Long = `Re-runs workspace discovery on an interval and renders a live tree.
+Workspaces that appear are marked with + and ones that disappear are struck
+through for a few seconds, which makes it easy to verify scripted worktree
+creation across many repositories.
+
+With --daemon, the tree follows the running daemon's state stream instead of
+polling discovery.
+
+With --json, no TUI is shown: each addition or removal is printed as one JSON
+object per line until interrupted.`
+
+	cmd.Flags().Duration("interval", wswatch.DefaultInterval, "How often to re-run discovery")
+	cmd.Flags().Duration("highlight", wswatch.DefaultHighlight, "How long changes stay highlighted")
+	cmd.Flags().Bool("daemon", false, "Follow the daemon's state stream instead of polling discovery")
+	cmd.Flags().Bool("json", false, "Print changes as JSON lines instead of showing the TUI")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		logger := cli.GetLogger(cmd)
+		interval, _ := cmd.Flags().GetDuration("interval")
+		highlight, _ := cmd.Flags().GetDuration("highlight")
+		useDaemon, _ := cmd.Flags().GetBool("daemon")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		load := func(context.Context) ([]*workspace.WorkspaceNode, error) {
+			return workspace.GetProjects(logger)
+		}
+
+		if jsonOutput || cli.GetOptions(cmd).JSONOutput {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			return watchWorkspacesJSON(ctx, logger, load, interval)
+		}
+
+		cfg := wswatch.Config{Load: load, Interval: interval, Highlight: highlight}
+		if useDaemon {
+			cwd, _ := os.Getwd()
+			client := daemon.New(cwd)
+			defer client.Close()
+			if !client.IsRunning() {
+				return fmt.Errorf("--daemon requires a running daemon")
+			}
+			cfg.Daemon = client
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		p := tea.NewProgram(wswatch.New(ctx, cfg), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			return fmt.Errorf("error running TUI: %w", err)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// watchWorkspacesJSON polls load and prints each change as a JSON line. The
+// first scan only establishes the baseline.
+func watchWorkspacesJSON(ctx context.Context, logger *logrus.Logger, load func(context.Context) ([]*workspace.WorkspaceNode, error), interval time.Duration) error {
+	prev, err := load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			next, err := load(ctx)
+			if err != nil {
+				logger.WithError(err).Warn("Workspace discovery failed")
+				continue
+			}
+			for _, c := range wswatch.Diff(prev, next) {
+				if err := enc.Encode(wsWatchEvent{
+					Event: string(c.Kind),
+					Time:  now,
+					Name:  c.Node.Name,
+					Path:  c.Node.Path,
+					Kind:  string(c.Node.Kind),
+				}); err != nil {
+					return fmt.Errorf("failed to write event: %w", err)
+				}
+			}
+			prev = next
+		}
+	}
+}
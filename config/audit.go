@@ -40,6 +40,8 @@ type AuditFinding struct {
 	Class AuditClass   `json:"class"` // Classification of the key.
 	Layer ConfigSource `json:"layer"` // Layer the file belongs to.
 	File  string       `json:"file"`  // Absolute path of the file that sets the key.
+	// Deprecation carries the migration details of an AuditDeprecated key.
+	Deprecation *Deprecation `json:"deprecation,omitempty"`
 }
 
 // auditFreeFormPaths lists key paths whose subtrees are intentionally
@@ -78,6 +80,17 @@ func AuditLayered(layered *LayeredConfig) ([]AuditFinding, error) {
 	return findings, nil
 }
 
+// LayerPaths returns the paths of the layer files present on a
+// LayeredConfig, in cascade order.
+func (layered *LayeredConfig) LayerPaths() []string {
+	files := auditLayerFiles(layered)
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.path)
+	}
+	return paths
+}
+
 // auditLayerFile pairs a layer file path with the ConfigSource it belongs to.
 type auditLayerFile struct {
 	source ConfigSource
@@ -118,6 +131,19 @@ func auditLayerFiles(layered *LayeredConfig) []auditLayerFile {
 // same second-pass approach unmarshalConfig uses) and classifies every key in
 // the tree.
 func auditFile(path string, source ConfigSource) ([]AuditFinding, error) {
+	raw, err := readRawLayer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &auditWalker{source: source, file: path}
+	w.classifyTopLevel(raw)
+	return w.findings, nil
+}
+
+// readRawLayer parses one layer file into an untyped key tree, expanding env
+// vars first to match the loader.
+func readRawLayer(path string) (map[string]interface{}, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config layer %s: %w", path, err)
@@ -132,14 +158,18 @@ func auditFile(path string, source ConfigSource) ([]AuditFinding, error) {
 			return nil, fmt.Errorf("failed to parse config layer %s: %w", path, err)
 		}
 	} else {
+		if strings.HasSuffix(path, ".json5") {
+			converted, err := json5ToJSON(expanded)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse config layer %s: %w", path, err)
+			}
+			expanded = converted
+		}
 		if err := yaml.Unmarshal(expanded, &raw); err != nil {
 			return nil, fmt.Errorf("failed to parse config layer %s: %w", path, err)
 		}
 	}
-
-	w := &auditWalker{source: source, file: path}
-	w.classifyTopLevel(raw)
-	return w.findings, nil
+	return raw, nil
 }
 
 // auditWalker accumulates findings for a single layer file while walking its
@@ -159,6 +189,12 @@ func (w *auditWalker) emit(key string, class AuditClass) {
 	})
 }
 
+// emitDeprecated records a deprecated key with its migration details.
+func (w *auditWalker) emitDeprecated(key string, d *Deprecation) {
+	w.emit(key, AuditDeprecated)
+	w.findings[len(w.findings)-1].Deprecation = d
+}
+
 // classifyTopLevel dispatches each top-level key: core struct fields walk
 // into the reflection classifier, registry keys report as known-extension
 // (without descending — code owns those shapes), _grove is core metadata, and
@@ -172,8 +208,8 @@ func (w *auditWalker) classifyTopLevel(raw map[string]interface{}) {
 			continue
 		}
 		if sf, ok := fields[key]; ok {
-			if fieldDeprecated(sf) {
-				w.emit(key, AuditDeprecated)
+			if d := fieldDeprecation(sf); d != nil {
+				w.emitDeprecated(key, d)
 				continue
 			}
 			w.walkValue(sf.Type, raw[key], key)
@@ -223,8 +259,8 @@ func (w *auditWalker) walkValue(t reflect.Type, v interface{}, path string) {
 				w.emit(childPath, AuditUnknownNested)
 				continue
 			}
-			if fieldDeprecated(sf) {
-				w.emit(childPath, AuditDeprecated)
+			if d := fieldDeprecation(sf); d != nil {
+				w.emitDeprecated(childPath, d)
 				continue
 			}
 			w.walkValue(sf.Type, m[key], childPath)
@@ -306,14 +342,6 @@ func fieldConfigKey(sf reflect.StructField) string {
 	return ""
 }
 
-// fieldDeprecated reports whether a struct field carries deprecation markers
-// in its schema tags (e.g. search_paths' `deprecated=true` /
-// `x-deprecated=true`).
-func fieldDeprecated(sf reflect.StructField) bool {
-	return strings.Contains(sf.Tag.Get("jsonschema"), "deprecated=true") ||
-		strings.Contains(sf.Tag.Get("jsonschema_extras"), "x-deprecated=true")
-}
-
 // sortedRawKeys returns the map's keys sorted for deterministic output.
 func sortedRawKeys(m map[string]interface{}) []string {
 	keys := make([]string, 0, len(m))
@@ -9,7 +9,7 @@ import (
 // setupAuditEnv isolates the config cascade for audit tests: a fake HOME for
 // the global layer and a scrubbed GROVE_CONFIG_OVERLAY so the host machine's
 // real config can't leak into findings.
-func setupAuditEnv(t *testing.T) (globalConfigDir, projectDir string) {
+func setupAuditEnv(t testing.TB) (globalConfigDir, projectDir string) {
 	t.Helper()
 	tmpDir := t.TempDir()
 
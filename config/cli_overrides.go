@@ -0,0 +1,199 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/grovetools/core/schema"
+)
+
+// Command-line overrides (`--set key=value`) form an in-memory layer merged
+// over every file layer, so a single invocation can tweak behavior without
+// editing grove.toml. They are process-global: the flag is parsed once per
+// invocation and every subsequent Load sees the same values.
+var (
+	cliOverridesMu sync.RWMutex
+	cliOverrides   map[string]interface{}
+)
+
+// AddCommandLineOverride records a dotted key=value assignment (e.g.
+// "logging.level=debug" or "tui.theme=gruvbox") as a highest-precedence
+// config layer. A key the config schema knows is typed after its schema:
+// a string field takes the value verbatim (so "1.0" stays "1.0"), and
+// boolean, integer, number, array and object fields reject values they
+// cannot hold. Keys the schema does not describe are typed the way a TOML
+// reader would see them (see parseOverrideValue).
+func AddCommandLineOverride(assignment string) error {
+	key, raw, ok := strings.Cut(assignment, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid override %q: expected key=value", assignment)
+	}
+	parts := strings.Split(key, ".")
+	for _, p := range parts {
+		if p == "" {
+			return fmt.Errorf("invalid override key %q: empty path segment", key)
+		}
+	}
+	value, err := coerceOverrideValue(key, raw)
+	if err != nil {
+		return fmt.Errorf("invalid override %q: %w", assignment, err)
+	}
+
+	cliOverridesMu.Lock()
+	defer cliOverridesMu.Unlock()
+
+	if cliOverrides == nil {
+		cliOverrides = make(map[string]interface{})
+	}
+	node := cliOverrides
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := node[p].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			node[p] = child
+		}
+		node = child
+	}
+	node[parts[len(parts)-1]] = value
+
+	// Cached configs predate this layer.
+	ResetLoadCache()
+	return nil
+}
+
+// ClearCommandLineOverrides removes every override recorded with
+// AddCommandLineOverride.
+func ClearCommandLineOverrides() {
+	cliOverridesMu.Lock()
+	cliOverrides = nil
+	cliOverridesMu.Unlock()
+	ResetLoadCache()
+}
+
+// coerceOverrideValue types raw after the schema of key, falling back to
+// parseOverrideValue for keys without a single declared type.
+func coerceOverrideValue(key, raw string) (interface{}, error) {
+	node, err := schema.Lookup(key)
+	if err != nil {
+		return parseOverrideValue(raw), nil
+	}
+	s := strings.TrimSpace(raw)
+	switch schemaType(node) {
+	case "string":
+		if strings.HasPrefix(s, `"`) {
+			var v string
+			if err := json.Unmarshal([]byte(s), &v); err == nil {
+				return v, nil
+			}
+		}
+		return raw, nil
+	case "boolean":
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects true or false", key)
+		}
+		return b, nil
+	case "integer":
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects an integer", key)
+		}
+		return i, nil
+	case "number":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a number", key)
+		}
+		return f, nil
+	case "array":
+		if !strings.HasPrefix(s, "[") {
+			// A bare value is a one-element list.
+			return []interface{}{parseOverrideValue(raw)}, nil
+		}
+		var v []interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("%s expects a JSON array: %w", key, err)
+		}
+		return v, nil
+	case "object":
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("%s expects a JSON object: %w", key, err)
+		}
+		return v, nil
+	}
+	return parseOverrideValue(raw), nil
+}
+
+// schemaType returns the single JSON type a schema node declares, ignoring
+// "null" in a type list, or "" when it declares none or several.
+func schemaType(node map[string]interface{}) string {
+	switch t := node["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		var found string
+		for _, v := range t {
+			if name, _ := v.(string); name != "" && name != "null" {
+				if found != "" {
+					return ""
+				}
+				found = name
+			}
+		}
+		return found
+	}
+	return ""
+}
+
+// parseOverrideValue converts the right-hand side of a --set assignment to
+// a typed value by its form alone.
+func parseOverrideValue(raw string) interface{} {
+	s := strings.TrimSpace(raw)
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{") || strings.HasPrefix(s, `"`) {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v
+		}
+	}
+	return raw
+}
+
+// commandLineOverrideConfig returns the --set layer as a Config, or nil when
+// no overrides were given. The assignments are round-tripped through TOML so
+// they decode exactly like a grove.toml containing the same keys.
+func commandLineOverrideConfig() (*Config, error) {
+	cliOverridesMu.RLock()
+	defer cliOverridesMu.RUnlock()
+
+	if len(cliOverrides) == 0 {
+		return nil, nil
+	}
+	data, err := toml.Marshal(cliOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode --set overrides: %w", err)
+	}
+	cfg, err := unmarshalConfig("--set.toml", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply --set overrides: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseOverrideValue(t *testing.T) {
+	cases := map[string]interface{}{
+		"debug":   "debug",
+		"true":    true,
+		"42":      int64(42),
+		"0.5":     0.5,
+		`"42"`:    "42",
+		`["a"]`:   []interface{}{"a"},
+		"[broken": "[broken",
+	}
+	for raw, want := range cases {
+		got := parseOverrideValue(raw)
+		switch w := want.(type) {
+		case []interface{}:
+			g, ok := got.([]interface{})
+			if !ok || len(g) != len(w) || g[0] != w[0] {
+				t.Errorf("parseOverrideValue(%q) = %#v, want %#v", raw, got, want)
+			}
+		default:
+			if got != want {
+				t.Errorf("parseOverrideValue(%q) = %#v, want %#v", raw, got, want)
+			}
+		}
+	}
+}
+
+func TestCoerceOverrideValueFollowsSchema(t *testing.T) {
+	cases := []struct {
+		key, raw string
+		want     interface{}
+	}{
+		{"version", "1.0", "1.0"},
+		{"name", "42", "42"},
+		{"name", `"quoted"`, "quoted"},
+		{"logging.file.enabled", "false", false},
+		{"workspaces", "api", []interface{}{"api"}},
+		// Keys outside the schema are typed by their form.
+		{"custom.ratio", "0.5", 0.5},
+	}
+	for _, c := range cases {
+		got, err := coerceOverrideValue(c.key, c.raw)
+		if err != nil {
+			t.Errorf("coerceOverrideValue(%q, %q): %v", c.key, c.raw, err)
+			continue
+		}
+		if list, ok := c.want.([]interface{}); ok {
+			g, ok := got.([]interface{})
+			if !ok || len(g) != len(list) || g[0] != list[0] {
+				t.Errorf("coerceOverrideValue(%q, %q) = %#v, want %#v", c.key, c.raw, got, c.want)
+			}
+			continue
+		}
+		if got != c.want {
+			t.Errorf("coerceOverrideValue(%q, %q) = %#v, want %#v", c.key, c.raw, got, c.want)
+		}
+	}
+
+	if _, err := coerceOverrideValue("logging.file.enabled", "sometimes"); err == nil {
+		t.Error("expected an error for a non-boolean value of a boolean key")
+	}
+}
+
+func TestAddCommandLineOverrideRejectsMalformed(t *testing.T) {
+	t.Cleanup(ClearCommandLineOverrides)
+	for _, bad := range []string{"noequals", "=value", "a..b=1"} {
+		if err := AddCommandLineOverride(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+// TestCommandLineOverridesWinOverFiles checks that --set values are merged
+// over the project file, for both core fields and extensions.
+func TestCommandLineOverridesWinOverFiles(t *testing.T) {
+	_, projectDir := setupAuditEnv(t)
+	content := "version = \"1.0\"\n\n[tui]\ntheme = \"kanagawa\"\n\n[logging]\nlevel = \"info\"\nformat = { preset = \"full\" }\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "grove.toml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(ClearCommandLineOverrides)
+	ResetLoadCache()
+	for _, set := range []string{"tui.theme=gruvbox", "logging.level=debug", "version=2.0"} {
+		if err := AddCommandLineOverride(set); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if cfg.Version != "2.0" {
+		t.Errorf("expected version=2.0 kept as a string, got %q", cfg.Version)
+	}
+	if cfg.TUI == nil || cfg.TUI.Theme != "gruvbox" {
+		t.Errorf("expected tui.theme=gruvbox, got %+v", cfg.TUI)
+	}
+	logging, _ := cfg.Extensions["logging"].(map[string]interface{})
+	if logging["level"] != "debug" {
+		t.Errorf("expected logging.level=debug, got %v", logging["level"])
+	}
+	if format, _ := logging["format"].(map[string]interface{}); format["preset"] != "full" {
+		t.Errorf("sibling keys should survive the override, got %v", logging["format"])
+	}
+
+	layered, err := LoadLayered(projectDir)
+	if err != nil {
+		t.Fatalf("LoadLayered: %v", err)
+	}
+	if layered.CommandLine == nil || layered.Final.TUI.Theme != "gruvbox" {
+		t.Errorf("expected command-line layer in LoadLayered, got %+v", layered.CommandLine)
+	}
+}
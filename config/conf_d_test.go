@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfDFragmentsMergeAlphabetically(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	t.Cleanup(ResetLoadCache)
+
+	writeConfig(t, filepath.Join(globalDir, "grove.yml"), "version: \"1.0\"\ntui:\n  theme: kanagawa\n  icons: nerd\n")
+	confD := filepath.Join(globalDir, "conf.d")
+	require.NoError(t, os.MkdirAll(confD, 0o755))
+	writeConfig(t, filepath.Join(confD, "10-theme.yml"), "tui:\n  theme: gruvbox\n")
+	writeConfig(t, filepath.Join(confD, "20-theme.toml"), "[tui]\ntheme = \"nord\"\n")
+	writeConfig(t, filepath.Join(confD, "30-groves.yaml"), "groves:\n  work:\n    path: ~/work\n")
+	writeConfig(t, filepath.Join(confD, ".99-backup.yml"), "tui:\n  theme: ignored\n")
+	writeConfig(t, filepath.Join(confD, "notes.txt"), "not config\n")
+	writeConfig(t, filepath.Join(confD, "40-broken.yml"), "tui: [\n")
+	writeConfig(t, filepath.Join(projectDir, "grove.yml"), "name: app\nversion: \"1.0\"\n")
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	cfg, err := LoadFromWithLogger(projectDir, logger)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.TUI)
+	assert.Equal(t, "nord", cfg.TUI.Theme, "later fragments win")
+	assert.Equal(t, "nerd", cfg.TUI.Icons, "keys a fragment does not set are kept")
+	assert.Contains(t, cfg.Groves, "work")
+
+	layered, err := LoadLayered(projectDir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range layered.GlobalFragments {
+		names = append(names, filepath.Base(f.Path))
+	}
+	assert.Equal(t, []string{"10-theme.yml", "20-theme.toml", "30-groves.yaml"}, names)
+	assert.Equal(t, "nord", layered.Final.TUI.Theme)
+}
+
+func TestConfDFragmentInvalidatesLoadCache(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	t.Cleanup(ResetLoadCache)
+	writeConfig(t, filepath.Join(globalDir, "grove.yml"), "version: \"1.0\"\n")
+
+	first, err := LoadFrom(projectDir)
+	require.NoError(t, err)
+	assert.Nil(t, first.TUI)
+
+	confD := filepath.Join(globalDir, "conf.d")
+	require.NoError(t, os.MkdirAll(confD, 0o755))
+	writeConfig(t, filepath.Join(confD, "theme.yml"), "tui:\n  theme: gruvbox\n")
+	expireLoadCache()
+
+	second, err := LoadFrom(projectDir)
+	require.NoError(t, err)
+	require.NotNil(t, second.TUI)
+	assert.Equal(t, "gruvbox", second.TUI.Theme)
+}
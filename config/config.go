@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/sirupsen/logrus"
@@ -19,29 +18,6 @@ import (
 	"github.com/grovetools/core/pkg/paths"
 )
 
-// loadCacheEntry caches a resolved Config keyed by the absolute startDir passed
-// to LoadFromWithLogger. The TTL is short enough to stay correct for users
-// editing grove.toml interactively, and long enough to absorb thundering-herd
-// callers (60fps TUI renders, 500ms fsnotify batches, long-lived watchers).
-type loadCacheEntry struct {
-	cfg    *Config
-	stored time.Time
-}
-
-const loadCacheTTL = 2 * time.Second
-
-var loadCache sync.Map // map[string]loadCacheEntry, keyed by absolute startDir
-
-// ResetLoadCache clears the LoadFromWithLogger cache. Tests that mutate config
-// files across sub-cases within the TTL window should call this between them;
-// production code has no reason to touch it.
-func ResetLoadCache() {
-	loadCache.Range(func(key, _ any) bool {
-		loadCache.Delete(key)
-		return true
-	})
-}
-
 var envVarRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
 
 var (
@@ -81,6 +57,13 @@ func validateAndWarn(cfg *Config, logger *logrus.Logger, source string) {
 		logger.WithError(err).Debug("config schema validator unavailable; skipping validation")
 		return
 	}
+	// A single layer may carry array merge annotations (`_merge`,
+	// "remove:" elements), which are not config keys.
+	if hasMergeAnnotations(cfg.Extensions) {
+		stripped := *cfg
+		stripped.Extensions = stripMergeAnnotations(cfg.Extensions)
+		cfg = &stripped
+	}
 	if err := validator.Validate(cfg); err != nil {
 		reportSchemaWarning(logger, source, err)
 	}
@@ -135,30 +118,46 @@ func stripGroveMeta(cfg *Config) {
 // coreConfigKeys lists the known top-level keys that are part of the core Config struct.
 // These are excluded from Extensions when loading TOML files.
 var coreConfigKeys = map[string]bool{
-	"name":              true,
-	"version":           true,
-	"workspaces":        true,
-	"build_cmd":         true,
-	"build_after":       true,
-	"notebooks":         true,
-	"tui":               true,
-	"context":           true,
-	"daemon":            true,
-	"environment":       true,
-	"environments":      true,
-	"groves":            true,
-	"search_paths":      true,
-	"explicit_projects": true,
-	"commands":          true,
-	"test_scopes":       true,
-	"worktree":          true,
-	"onboarding":        true,
-	"_grove":            true, // Meta section for config metadata (priority, etc.)
+	"name":               true,
+	"version":            true,
+	"workspaces":         true,
+	"workspaces_from":    true,
+	"build_cmd":          true,
+	"build_after":        true,
+	"tags":               true,
+	"owners":             true,
+	"links":              true,
+	"notebooks":          true,
+	"tui":                true,
+	"context":            true,
+	"daemon":             true,
+	"environment":        true,
+	"environments":       true,
+	"groves":             true,
+	"search_paths":       true,
+	"explicit_projects":  true,
+	"commands":           true,
+	"test_scopes":        true,
+	"worktree":           true,
+	"onboarding":         true,
+	"telemetry":          true,
+	"workspaces_logging": true,
+	"_grove":             true, // Meta section for config metadata (priority, etc.)
 }
 
-// unmarshalConfig parses config data based on file extension (TOML or YAML).
+// unmarshalConfig parses config data based on file extension (TOML, JSON5 or YAML).
 // For TOML files, it also captures extension fields into Extensions to emulate YAML inline behavior.
+// Deprecated keys are reported through the standard logger's fallback; see
+// unmarshalConfigWithLogger.
 func unmarshalConfig(path string, data []byte) (*Config, error) {
+	return unmarshalConfigWithLogger(path, data, logrus.StandardLogger())
+}
+
+// unmarshalConfigWithLogger is unmarshalConfig with the deprecated keys the
+// file sets reported through logger, the loader's own, like its schema
+// warnings (see reportSchemaWarning). A nil logger reports nothing, for
+// callers that only probe a file.
+func unmarshalConfigWithLogger(path string, data []byte, logger *logrus.Logger) (*Config, error) {
 	var cfg Config
 
 	if strings.HasSuffix(path, ".toml") {
@@ -183,11 +182,20 @@ func unmarshalConfig(path string, data []byte) (*Config, error) {
 		// Post-process notebook sync configs (the field is toml:"-")
 		postProcessTOMLNotebookSync(&cfg, data)
 	} else {
+		if strings.HasSuffix(path, ".json5") {
+			converted, err := json5ToJSON(data)
+			if err != nil {
+				return nil, err
+			}
+			data = converted
+		}
 		if err := yaml.Unmarshal(data, &cfg); err != nil {
 			return nil, err
 		}
 	}
 
+	warnDeprecatedKeys(path, data, logger)
+	applyWorkspacesFrom(&cfg, path)
 	return &cfg, nil
 }
 
@@ -280,11 +288,20 @@ func Load(path string) (*Config, error) {
 			WithDetail("path", path)
 	}
 
-	if strings.HasSuffix(path, ".toml") {
-		return LoadFromTOMLBytes(data)
+	var cfg *Config
+	switch {
+	case strings.HasSuffix(path, ".toml"):
+		cfg, err = LoadFromTOMLBytes(data)
+	case strings.HasSuffix(path, ".json5"):
+		cfg, err = LoadFromJSON5Bytes(data)
+	default:
+		cfg, err = LoadFromBytes(data)
 	}
-
-	return LoadFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	applyWorkspacesFrom(cfg, path)
+	return cfg, nil
 }
 
 // LoadDefault finds and loads the configuration with hierarchical merging:
@@ -306,22 +323,20 @@ func LoadFrom(startDir string) (*Config, error) {
 }
 
 // LoadFromWithLogger loads configuration with hierarchical merging and logging
-func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error) {
-	// Short-lived cache keyed by absolute startDir. The full load path stats
-	// and parses ~10 different hierarchical files plus compiles the JSONSchema
-	// validator (~15ms), which shows up as a dominant hot path in long-lived
-	// processes — cx TUI rendering, groved fsnotify handlers, nav ticker
-	// loops, etc. 2s is long enough to absorb bursts from those callers and
-	// short enough that interactive edits to grove.toml feel instant.
-	cacheKey, _ := filepath.Abs(startDir)
-	if cacheKey == "" {
-		cacheKey = startDir
-	}
-	if raw, ok := loadCache.Load(cacheKey); ok {
-		if entry, ok := raw.(loadCacheEntry); ok && time.Since(entry.stored) < loadCacheTTL {
-			return entry.cfg, nil
-		}
-	}
+func LoadFromWithLogger(startDir string, logger *logrus.Logger) (_ *Config, err error) {
+	// Memoized per start directory and validated against the mtimes of the
+	// files and directories involved (see load_cache.go). The full load path
+	// stats and parses ~10 different hierarchical files, shells out to git
+	// and validates against the JSONSchema, which shows up as a dominant hot
+	// path in CLI startup and in long-lived processes — cx TUI rendering,
+	// groved fsnotify handlers, nav ticker loops, etc.
+	cacheKey := loadCacheKey(startDir)
+	if cfg, ok := cachedLoad(cacheKey); ok {
+		return cfg, nil
+	}
+	stop := timer.Start("load")
+	defer func() { stop(err) }()
+	deps := &loadDeps{logger: logger}
 
 	// Find project config file first
 	projectPath, err := FindConfigFile(startDir)
@@ -342,10 +357,10 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		if _, err := os.Stat(globalPath); err == nil {
 			logger.WithField("path", globalPath).Debug("Loading global configuration")
 			// Load global config without validation/defaults (raw load)
-			globalData, err := os.ReadFile(globalPath)
+			globalData, err := deps.read(globalPath)
 			if err == nil {
 				expanded := expandEnvVars(string(globalData))
-				globalConfig, parseErr := unmarshalConfig(globalPath, []byte(expanded))
+				globalConfig, parseErr := deps.unmarshal(globalPath, []byte(expanded))
 				if parseErr == nil {
 					finalConfig = globalConfig
 				} else {
@@ -371,7 +386,7 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 					continue
 				}
 
-				fragmentData, err := os.ReadFile(file)
+				fragmentData, err := deps.read(file)
 				if err != nil {
 					logger.WithError(err).Warnf("Failed to read config fragment %s, skipping", baseName)
 					continue
@@ -394,14 +409,14 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 					"priority": frag.priority,
 				}).Debug("Loading global config fragment")
 
-				fragmentData, err := os.ReadFile(frag.path)
+				fragmentData, err := deps.read(frag.path)
 				if err != nil {
 					logger.WithError(err).Warnf("Failed to read config fragment %s, skipping", baseName)
 					continue
 				}
 
 				expanded := expandEnvVars(string(fragmentData))
-				fragmentConfig, parseErr := unmarshalConfig(frag.path, []byte(expanded))
+				fragmentConfig, parseErr := deps.unmarshal(frag.path, []byte(expanded))
 				if parseErr != nil {
 					logger.WithError(parseErr).Warnf("Failed to parse config fragment %s, skipping", baseName)
 					continue
@@ -425,14 +440,14 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 				baseName := filepath.Base(file)
 				logger.WithField("path", file).Debug("Loading plugin config fragment")
 
-				fragmentData, err := os.ReadFile(file)
+				fragmentData, err := deps.read(file)
 				if err != nil {
 					logger.WithError(err).Warnf("Failed to read plugin config %s, skipping", baseName)
 					continue
 				}
 
 				expanded := expandEnvVars(string(fragmentData))
-				fragmentConfig, parseErr := unmarshalConfig(file, []byte(expanded))
+				fragmentConfig, parseErr := deps.unmarshal(file, []byte(expanded))
 				if parseErr != nil {
 					logger.WithError(parseErr).Warnf("Failed to parse plugin config %s, skipping", baseName)
 					continue
@@ -447,6 +462,34 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 				}
 			}
 		}
+
+		// Then ~/.config/grove/conf.d drop-ins, alphabetically, so tools can
+		// add a self-contained snippet without rewriting grove.yml.
+		for _, file := range globalDropInFiles(globalDir) {
+			baseName := filepath.Base(file)
+			logger.WithField("path", file).Debug("Loading conf.d config fragment")
+
+			fragmentData, err := deps.read(file)
+			if err != nil {
+				logger.WithError(err).Warnf("Failed to read conf.d fragment %s, skipping", baseName)
+				continue
+			}
+
+			expanded := expandEnvVars(string(fragmentData))
+			fragmentConfig, parseErr := deps.unmarshal(file, []byte(expanded))
+			if parseErr != nil {
+				logger.WithError(parseErr).Warnf("Failed to parse conf.d fragment %s, skipping", baseName)
+				continue
+			}
+
+			stripGroveMeta(fragmentConfig)
+
+			if finalConfig == nil {
+				finalConfig = fragmentConfig
+			} else {
+				finalConfig = mergeConfigs(finalConfig, fragmentConfig)
+			}
+		}
 	}
 
 	// Load global override if it exists
@@ -457,13 +500,13 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		for _, overridePath := range overrideFiles {
 			if _, err := os.Stat(overridePath); err == nil {
 				logger.WithField("path", overridePath).Debug("Loading global override configuration")
-				overrideData, err := os.ReadFile(overridePath)
+				overrideData, err := deps.read(overridePath)
 				if err != nil {
 					logger.WithError(err).Warn("Failed to read global override file, skipping")
 					continue
 				}
 				expanded := expandEnvVars(string(overrideData))
-				overrideConfig, parseErr := unmarshalConfig(overridePath, []byte(expanded))
+				overrideConfig, parseErr := deps.unmarshal(overridePath, []byte(expanded))
 				if parseErr != nil {
 					logger.WithError(parseErr).Warn("Failed to parse global override file, skipping")
 					continue
@@ -484,13 +527,13 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		overlayPath = expandPath(overlayPath)
 		if _, err := os.Stat(overlayPath); err == nil {
 			logger.WithField("path", overlayPath).Debug("Loading config overlay from GROVE_CONFIG_OVERLAY")
-			overlayData, err := os.ReadFile(overlayPath)
+			overlayData, err := deps.read(overlayPath)
 			if err != nil {
 				return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "failed to read config overlay").
 					WithDetail("path", overlayPath)
 			}
 			expanded := expandEnvVars(string(overlayData))
-			overlayConfig, parseErr := unmarshalConfig(overlayPath, []byte(expanded))
+			overlayConfig, parseErr := deps.unmarshal(overlayPath, []byte(expanded))
 			if parseErr != nil {
 				return nil, errors.Wrap(parseErr, errors.ErrCodeConfigInvalid, "failed to parse config overlay").
 					WithDetail("path", overlayPath)
@@ -518,14 +561,14 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 	if projectPath != "" {
 		logger.WithField("path", projectPath).Debug("Loading project configuration")
 		// 2. Load and merge project config - also without defaults/validation
-		projectData, err := os.ReadFile(projectPath)
+		projectData, err := deps.read(projectPath)
 		if err != nil {
 			return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "failed to read project config").
 				WithDetail("path", projectPath)
 		}
 
 		expanded := expandEnvVars(string(projectData))
-		projectConfig, parseErr := unmarshalConfig(projectPath, []byte(expanded))
+		projectConfig, parseErr := deps.unmarshal(projectPath, []byte(expanded))
 		if parseErr != nil {
 			return nil, errors.Wrap(parseErr, errors.ErrCodeConfigInvalid, "failed to parse project config").
 				WithDetail("path", projectPath)
@@ -533,16 +576,18 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 
 		// Check if this is a workspace config (has no workspaces field) and look for ecosystem config
 		ecosystemPath := ""
+		var ecosystemConfig *Config
 		if !isGlobalFallback && len(projectConfig.Workspaces) == 0 {
 			// This appears to be a workspace config, look for ecosystem config
 			ecosystemPath = FindEcosystemConfig(filepath.Dir(projectPath))
 			if ecosystemPath != "" {
 				logger.WithField("path", ecosystemPath).Debug("Loading ecosystem configuration")
-				ecosystemData, err := os.ReadFile(ecosystemPath)
+				ecosystemData, err := deps.read(ecosystemPath)
 				if err == nil {
 					expandedEco := expandEnvVars(string(ecosystemData))
-					ecosystemConfig, ecoParseErr := unmarshalConfig(ecosystemPath, []byte(expandedEco))
+					ecoConfig, ecoParseErr := deps.unmarshal(ecosystemPath, []byte(expandedEco))
 					if ecoParseErr == nil {
+						ecosystemConfig = ecoConfig
 						// Merge ecosystem config after global but before project
 						if finalConfig == nil {
 							finalConfig = ecosystemConfig
@@ -581,10 +626,10 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		notebookConfigPath := findNotebookConfigPath(projectRoot, finalConfig)
 		if notebookConfigPath != "" {
 			logger.WithField("path", notebookConfigPath).Debug("Loading project notebook configuration")
-			nbData, err := os.ReadFile(notebookConfigPath)
+			nbData, err := deps.read(notebookConfigPath)
 			if err == nil {
 				expandedNb := expandEnvVars(string(nbData))
-				nbConfig, parseErr := unmarshalConfig(notebookConfigPath, []byte(expandedNb))
+				nbConfig, parseErr := deps.unmarshal(notebookConfigPath, []byte(expandedNb))
 				if parseErr == nil {
 					stripGroveMeta(nbConfig)
 					if finalConfig == nil {
@@ -605,6 +650,13 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 				logger.Debug("Merging project configuration over global/ecosystem/notebook configuration")
 				finalConfig = mergeConfigs(finalConfig, projectConfig)
 			}
+
+			// The ecosystem's workspaces_logging entry for this project wins
+			// over the project's own logging settings.
+			if layer := workspaceLoggingLayer(ecosystemConfig, ecosystemPath, projectConfig, filepath.Dir(projectPath)); layer != nil {
+				logger.WithField("path", ecosystemPath).Debug("Applying ecosystem logging overrides for this workspace")
+				finalConfig = mergeConfigs(finalConfig, layer)
+			}
 		}
 
 		// 3. Load and merge override files if they exist (optional)
@@ -615,7 +667,7 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 			if _, err := os.Stat(overridePath); err == nil {
 				logger.WithField("path", overridePath).Debug("Loading local override configuration")
 
-				overrideData, err := os.ReadFile(overridePath)
+				overrideData, err := deps.read(overridePath)
 				if err != nil {
 					logger.WithError(err).Warn("Failed to read override file, skipping")
 					continue
@@ -623,7 +675,7 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 
 				// Expand environment variables
 				expanded := expandEnvVars(string(overrideData))
-				overrideConfig, parseErr := unmarshalConfig(overridePath, []byte(expanded))
+				overrideConfig, parseErr := deps.unmarshal(overridePath, []byte(expanded))
 				if parseErr != nil {
 					logger.WithError(parseErr).Warn("Failed to parse override file, skipping")
 					continue
@@ -643,10 +695,10 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		notebookConfigPath := findNotebookConfigPath(projectRoot, finalConfig)
 		if notebookConfigPath != "" {
 			logger.WithField("path", notebookConfigPath).Debug("Loading project notebook configuration (no local project config)")
-			nbData, err := os.ReadFile(notebookConfigPath)
+			nbData, err := deps.read(notebookConfigPath)
 			if err == nil {
 				expandedNb := expandEnvVars(string(nbData))
-				nbConfig, parseErr := unmarshalConfig(notebookConfigPath, []byte(expandedNb))
+				nbConfig, parseErr := deps.unmarshal(notebookConfigPath, []byte(expandedNb))
 				if parseErr == nil {
 					stripGroveMeta(nbConfig)
 					finalConfig = mergeConfigs(finalConfig, nbConfig)
@@ -659,11 +711,28 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		}
 	}
 
+	// Command-line overrides (--set key=value) win over every file layer.
+	cliConfig, err := commandLineOverrideConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "invalid --set override")
+	}
+	if cliConfig != nil {
+		logger.Debug("Applying command-line config overrides")
+		if finalConfig == nil {
+			finalConfig = cliConfig
+		} else {
+			finalConfig = mergeConfigs(finalConfig, cliConfig)
+		}
+	}
+
 	// If no configs were found at all, create an empty one to avoid nil pointers
 	if finalConfig == nil {
 		finalConfig = &Config{}
 	}
 
+	// Array merge annotations only steer merging; consumers never see them.
+	finalConfig.Extensions = stripMergeAnnotations(finalConfig.Extensions)
+
 	// Set defaults
 	finalConfig.SetDefaults()
 
@@ -682,10 +751,10 @@ func LoadFromWithLogger(startDir string, logger *logrus.Logger) (*Config, error)
 		}
 	}
 
-	// Populate the short-lived cache for subsequent callers. Callers are
-	// expected to treat the returned *Config as read-only; mutating it would
-	// leak into other callers within the TTL window.
-	loadCache.Store(cacheKey, loadCacheEntry{cfg: finalConfig, stored: time.Now()})
+	// Populate the cache for subsequent callers. Callers are expected to
+	// treat the returned *Config as read-only; mutating it would leak into
+	// every other caller until the involved files change.
+	storeLoad(cacheKey, startDir, finalConfig, deps)
 
 	return finalConfig, nil
 }
@@ -713,6 +782,30 @@ func LoadFromBytes(data []byte) (*Config, error) {
 	return &config, nil
 }
 
+// LoadFromJSON5Bytes parses configuration from a JSON5 byte array. The
+// document is rewritten as JSON and decoded like YAML, so keys and
+// extensions behave exactly as they do in grove.yml.
+func LoadFromJSON5Bytes(data []byte) (*Config, error) {
+	expanded := expandEnvVars(string(data))
+
+	converted, err := json5ToJSON([]byte(expanded))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "failed to parse JSON5 configuration")
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(converted, &config); err != nil {
+		return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "failed to parse JSON5 configuration")
+	}
+
+	// Warn-only schema check. Never fatal — see the note in LoadFromBytes.
+	validateAndWarn(&config, logrus.StandardLogger(), "config JSON5 bytes")
+
+	config.SetDefaults()
+
+	return &config, nil
+}
+
 // LoadFromTOMLBytes parses configuration from TOML byte array
 func LoadFromTOMLBytes(data []byte) (*Config, error) {
 	// Expand environment variables
@@ -756,15 +849,17 @@ func LoadFromTOMLBytes(data []byte) (*Config, error) {
 // 3. XDG config directory (~/.config/grove/grove.toml)
 //
 // Within each directory, TOML is preferred over YAML (read compatibility for
-// grove.yml/grove.yaml is preserved).
+// grove.yml/grove.yaml is preserved), and YAML over JSON5 (grove.json5).
 func FindConfigFile(startDir string) (string, error) {
 	configNames := []string{
 		"grove.toml",
 		"grove.yml",
 		"grove.yaml",
+		"grove.json5",
 		".grove.toml",
 		".grove.yml",
 		".grove.yaml",
+		".grove.json5",
 		"docker-compose.grove.toml",
 		"docker-compose.grove.yml",
 		"docker-compose.grove.yaml",
@@ -820,9 +915,11 @@ func projectOverrideFiles(projectDir string) []string {
 		"grove.override.yml",
 		"grove.override.yaml",
 		"grove.override.toml",
+		"grove.override.json5",
 		".grove.override.yml",
 		".grove.override.yaml",
 		".grove.override.toml",
+		".grove.override.json5",
 		// Legacy names (previously .grove-work.*), still read for compat.
 		".grove-work.yml",
 		".grove-work.yaml",
@@ -842,7 +939,30 @@ func globalOverrideFiles(globalDir string) []string {
 		filepath.Join(globalDir, "grove.override.yml"),
 		filepath.Join(globalDir, "grove.override.yaml"),
 		filepath.Join(globalDir, "grove.override.toml"),
+		filepath.Join(globalDir, "grove.override.json5"),
+	}
+}
+
+// globalDropInFiles returns the conf.d fragments (*.yml, *.yaml, *.toml, *.json5)
+// next to the global config, sorted by file name. Hidden files are skipped
+// so editor backups and half-written temp files are not loaded.
+func globalDropInFiles(globalDir string) []string {
+	entries, err := os.ReadDir(filepath.Join(globalDir, "conf.d"))
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		switch filepath.Ext(name) {
+		case ".yml", ".yaml", ".toml", ".json5":
+			files = append(files, filepath.Join(globalDir, "conf.d", name))
+		}
 	}
+	return files
 }
 
 // expandPath expands ~ to home directory and environment variables in a path
@@ -850,14 +970,8 @@ func expandPath(path string) string {
 	// First expand environment variables
 	path = os.ExpandEnv(path)
 
-	// Then handle ~ expansion
-	if strings.HasPrefix(path, "~/") {
-		if home, err := os.UserHomeDir(); err == nil {
-			path = filepath.Join(home, path[2:])
-		}
-	}
-
-	return path
+	// Then handle ~ (and, on Windows, %VAR%) expansion
+	return paths.ExpandHome(path)
 }
 
 // applyOverlay replaces fields in base with non-zero fields from overlay.
@@ -934,10 +1048,43 @@ func getXDGConfigPath() string {
 		return yamlPath
 	}
 
+	// Then JSON5
+	json5Path := filepath.Join(configDir, "grove.json5")
+	if _, err := os.Stat(json5Path); err == nil {
+		return json5Path
+	}
+
 	// Default to TOML if neither exists (for callers that might create it)
 	return tomlPath
 }
 
+// workspaceLoggingLayer returns the logging overrides that the ecosystem
+// config eco (read from ecosystemPath) sets for the member project in
+// projectDir under workspaces_logging, as a config layer holding only a
+// logging section, or nil when it sets none. The member is matched by its
+// configured name, then its path relative to the ecosystem root, then its
+// directory name. The layer is merged over the project config so an
+// operator can change one member's logging from the ecosystem config.
+func workspaceLoggingLayer(eco *Config, ecosystemPath string, project *Config, projectDir string) *Config {
+	if eco == nil || len(eco.WorkspacesLogging) == 0 {
+		return nil
+	}
+	var candidates []string
+	if project != nil && project.Name != "" {
+		candidates = append(candidates, project.Name)
+	}
+	if rel, err := filepath.Rel(filepath.Dir(ecosystemPath), projectDir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		candidates = append(candidates, filepath.ToSlash(rel))
+	}
+	candidates = append(candidates, filepath.Base(projectDir))
+	for _, name := range candidates {
+		if overrides, ok := eco.WorkspacesLogging[name]; ok && len(overrides) > 0 {
+			return &Config{Extensions: map[string]interface{}{"logging": overrides}}
+		}
+	}
+	return nil
+}
+
 // FindEcosystemConfig searches upward from the given directory for a grove
 // config that has a 'workspaces' field (indicating it's an ecosystem config).
 // TOML is preferred over YAML when both exist with workspaces.
@@ -946,9 +1093,11 @@ func FindEcosystemConfig(startDir string) string {
 		"grove.toml",
 		"grove.yml",
 		"grove.yaml",
+		"grove.json5",
 		".grove.toml",
 		".grove.yml",
 		".grove.yaml",
+		".grove.json5",
 	}
 
 	dir := startDir // Start from the given directory itself
@@ -963,18 +1112,13 @@ func FindEcosystemConfig(startDir string) string {
 				data, err := os.ReadFile(path)
 				if err == nil {
 					expanded := expandEnvVars(string(data))
-					var cfg Config
-					if strings.HasSuffix(name, ".toml") {
-						if err := toml.Unmarshal([]byte(expanded), &cfg); err != nil {
-							continue
-						}
-					} else {
-						if err := yaml.Unmarshal([]byte(expanded), &cfg); err != nil {
-							continue
-						}
+					// A probe: the loaders report this file's warnings.
+					cfg, err := unmarshalConfigWithLogger(name, []byte(expanded), nil)
+					if err != nil {
+						continue
 					}
-					// An ecosystem config is identified by having a non-empty 'workspaces' field.
-					if len(cfg.Workspaces) > 0 {
+					// An ecosystem config lists workspaces or takes them from workspaces_from.
+					if cfg.IsEcosystem() {
 						return path
 					}
 				}
@@ -1080,7 +1224,7 @@ func findNotebookConfigPath(projectRoot string, globalCfg *Config) string {
 		return ""
 	}
 
-	configNames := []string{"grove.toml", "grove.yml", "grove.yaml"}
+	configNames := []string{"grove.toml", "grove.yml", "grove.yaml", "grove.json5"}
 	for _, name := range configNames {
 		configPath := filepath.Join(ctx.notebookRootDir, "workspaces", ctx.workspaceName, name)
 		if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
@@ -1121,6 +1265,12 @@ func resolveNotebookDirWithConfig(projectRoot string, cfg *Config) (string, stri
 func LoadLayered(startDir string) (*LayeredConfig, error) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.WarnLevel) // Suppress debug logs for this loader
+	return LoadLayeredWithLogger(startDir, logger)
+}
+
+// LoadLayeredWithLogger is LoadLayered with schema and deprecation warnings
+// reported through logger.
+func LoadLayeredWithLogger(startDir string, logger *logrus.Logger) (*LayeredConfig, error) {
 
 	layeredConfig := &LayeredConfig{
 		Overrides: make([]OverrideSource, 0),
@@ -1141,7 +1291,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 			globalData, err := os.ReadFile(globalPath)
 			if err == nil {
 				expanded := expandEnvVars(string(globalData))
-				globalConfig, parseErr := unmarshalConfig(globalPath, []byte(expanded))
+				globalConfig, parseErr := unmarshalConfigWithLogger(globalPath, []byte(expanded), logger)
 				if parseErr == nil {
 					layeredConfig.Global = globalConfig
 					layeredConfig.FilePaths[SourceGlobal] = globalPath
@@ -1186,7 +1336,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 				}
 
 				expanded := expandEnvVars(string(fragmentData))
-				fragmentConfig, parseErr := unmarshalConfig(frag.path, []byte(expanded))
+				fragmentConfig, parseErr := unmarshalConfigWithLogger(frag.path, []byte(expanded), logger)
 				if parseErr == nil {
 					stripGroveMeta(fragmentConfig)
 					layeredConfig.GlobalFragments = append(layeredConfig.GlobalFragments, OverrideSource{
@@ -1198,6 +1348,26 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 		}
 	}
 
+	// 2.3. Load conf.d drop-ins (alphabetical), after the modular fragments
+	// so they win over them.
+	if globalPath != "" {
+		for _, file := range globalDropInFiles(filepath.Dir(globalPath)) {
+			fragmentData, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			expanded := expandEnvVars(string(fragmentData))
+			fragmentConfig, parseErr := unmarshalConfigWithLogger(file, []byte(expanded), logger)
+			if parseErr == nil {
+				stripGroveMeta(fragmentConfig)
+				layeredConfig.GlobalFragments = append(layeredConfig.GlobalFragments, OverrideSource{
+					Path:   file,
+					Config: fragmentConfig,
+				})
+			}
+		}
+	}
+
 	// 2.5. Load Global Override layer (optional)
 	if globalPath != "" {
 		globalDir := filepath.Dir(globalPath)
@@ -1207,7 +1377,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 				overrideData, err := os.ReadFile(overridePath)
 				if err == nil {
 					expanded := expandEnvVars(string(overrideData))
-					overrideConfig, parseErr := unmarshalConfig(overridePath, []byte(expanded))
+					overrideConfig, parseErr := unmarshalConfigWithLogger(overridePath, []byte(expanded), logger)
 					if parseErr == nil {
 						layeredConfig.GlobalOverride = &OverrideSource{
 							Path:   overridePath,
@@ -1228,7 +1398,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 			overlayData, err := os.ReadFile(overlayPath)
 			if err == nil {
 				expanded := expandEnvVars(string(overlayData))
-				overlayConfig, parseErr := unmarshalConfig(overlayPath, []byte(expanded))
+				overlayConfig, parseErr := unmarshalConfigWithLogger(overlayPath, []byte(expanded), logger)
 				if parseErr == nil {
 					layeredConfig.EnvOverlay = &OverrideSource{
 						Path:   overlayPath,
@@ -1256,7 +1426,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 			return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "failed to read project config").WithDetail("path", projectPath)
 		}
 		expandedProject := expandEnvVars(string(projectData))
-		projectConfig, parseErr := unmarshalConfig(projectPath, []byte(expandedProject))
+		projectConfig, parseErr := unmarshalConfigWithLogger(projectPath, []byte(expandedProject), logger)
 		if parseErr != nil {
 			return nil, errors.Wrap(parseErr, errors.ErrCodeConfigInvalid, "failed to parse project config").WithDetail("path", projectPath)
 		}
@@ -1270,7 +1440,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 				ecosystemData, err := os.ReadFile(ecosystemPath)
 				if err == nil {
 					expandedEco := expandEnvVars(string(ecosystemData))
-					ecosystemConfig, ecoParseErr := unmarshalConfig(ecosystemPath, []byte(expandedEco))
+					ecosystemConfig, ecoParseErr := unmarshalConfigWithLogger(ecosystemPath, []byte(expandedEco), logger)
 					if ecoParseErr == nil {
 						layeredConfig.Ecosystem = ecosystemConfig
 						layeredConfig.FilePaths[SourceEcosystem] = ecosystemPath
@@ -1322,7 +1492,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 		nbData, err := os.ReadFile(notebookConfigPath)
 		if err == nil {
 			expandedNb := expandEnvVars(string(nbData))
-			nbConfig, parseErr := unmarshalConfig(notebookConfigPath, []byte(expandedNb))
+			nbConfig, parseErr := unmarshalConfigWithLogger(notebookConfigPath, []byte(expandedNb), logger)
 			if parseErr == nil {
 				stripGroveMeta(nbConfig)
 				layeredConfig.ProjectNotebook = nbConfig
@@ -1342,7 +1512,7 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 					continue // Skip unreadable override files
 				}
 				expandedOverride := expandEnvVars(string(overrideData))
-				overrideConfig, parseErr := unmarshalConfig(overridePath, []byte(expandedOverride))
+				overrideConfig, parseErr := unmarshalConfigWithLogger(overridePath, []byte(expandedOverride), logger)
 				if parseErr == nil {
 					layeredConfig.Overrides = append(layeredConfig.Overrides, OverrideSource{
 						Path:   overridePath,
@@ -1390,6 +1560,9 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 	// Merge project config
 	if layeredConfig.Project != nil {
 		finalConfig = mergeConfigs(finalConfig, layeredConfig.Project)
+		if layer := workspaceLoggingLayer(layeredConfig.Ecosystem, layeredConfig.FilePaths[SourceEcosystem], layeredConfig.Project, filepath.Dir(layeredConfig.FilePaths[SourceProject])); layer != nil {
+			finalConfig = mergeConfigs(finalConfig, layer)
+		}
 	}
 
 	// Merge overrides (skip when overlay is active for full isolation)
@@ -1399,6 +1572,19 @@ func LoadLayered(startDir string) (*LayeredConfig, error) {
 		}
 	}
 
+	// Merge command-line overrides (--set key=value) last
+	cliConfig, err := commandLineOverrideConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrCodeConfigInvalid, "invalid --set override")
+	}
+	if cliConfig != nil {
+		layeredConfig.CommandLine = cliConfig
+		finalConfig = mergeConfigs(finalConfig, cliConfig)
+	}
+
+	// Array merge annotations only steer merging; consumers never see them.
+	finalConfig.Extensions = stripMergeAnnotations(finalConfig.Extensions)
+
 	// Set defaults for the final merged config
 	finalConfig.SetDefaults()
 
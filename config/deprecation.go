@@ -0,0 +1,128 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Deprecation describes a deprecated config key. A field is marked
+// deprecated with `deprecated=true` in its jsonschema tag or
+// `x-deprecated=true` in its jsonschema_extras tag; the details come from
+// the x-deprecated-message, x-deprecated-replacement, x-deprecated-version
+// and x-deprecated-removal extras, which the schema carries for editors as
+// well.
+type Deprecation struct {
+	Message     string `json:"message,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+	Since       string `json:"since,omitempty"`
+	Removal     string `json:"removal,omitempty"`
+}
+
+// Hint renders the migration hint for key, e.g. "search_paths is deprecated
+// since v0.5.0 and will be removed in v1.0.0: Use 'groves' for project
+// discovery".
+func (d Deprecation) Hint(key string) string {
+	var b strings.Builder
+	b.WriteString(key + " is deprecated")
+	if d.Since != "" {
+		b.WriteString(" since " + d.Since)
+	}
+	if d.Removal != "" {
+		b.WriteString(" and will be removed in " + d.Removal)
+	}
+	switch {
+	case d.Message != "":
+		b.WriteString(": " + d.Message)
+	case d.Replacement != "":
+		b.WriteString("; use " + d.Replacement)
+	}
+	return b.String()
+}
+
+// fieldDeprecation returns the deprecation of a struct field, or nil when
+// the field is not deprecated.
+func fieldDeprecation(sf reflect.StructField) *Deprecation {
+	extras := sf.Tag.Get("jsonschema_extras")
+	if !strings.Contains(sf.Tag.Get("jsonschema"), "deprecated=true") &&
+		!strings.Contains(extras, "x-deprecated=true") {
+		return nil
+	}
+	d := &Deprecation{}
+	for _, extra := range strings.Split(extras, ",") {
+		name, value, _ := strings.Cut(extra, "=")
+		switch name {
+		case "x-deprecated-message":
+			d.Message = value
+		case "x-deprecated-replacement":
+			d.Replacement = value
+		case "x-deprecated-version":
+			d.Since = value
+		case "x-deprecated-removal":
+			d.Removal = value
+		}
+	}
+	return d
+}
+
+// DeprecatedKeyError is the warning reported when a loaded config file sets
+// a deprecated key. It travels through the schema warning channel (see
+// SetSchemaWarningSink), so it is reported once per key and file per
+// process; sinks can tell it apart with errors.As.
+type DeprecatedKeyError struct {
+	Key  string
+	File string
+	Deprecation
+}
+
+func (e *DeprecatedKeyError) Error() string {
+	return e.Hint(e.Key)
+}
+
+// warnDeprecatedKeys reports every deprecated key set in one config file,
+// falling back to logger like the other schema warnings. A nil logger
+// reports nothing.
+func warnDeprecatedKeys(path string, data []byte, logger *logrus.Logger) {
+	if logger == nil {
+		return
+	}
+	raw := make(map[string]interface{})
+	var err error
+	if strings.HasSuffix(path, ".toml") {
+		err = toml.Unmarshal(data, &raw)
+	} else {
+		// JSON5 has been converted to JSON, which YAML reads.
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return
+	}
+	w := &auditWalker{file: path}
+	w.classifyTopLevel(raw)
+	for _, f := range w.findings {
+		if f.Class != AuditDeprecated || f.Deprecation == nil {
+			continue
+		}
+		reportSchemaWarning(logger, path, &DeprecatedKeyError{Key: f.Key, File: path, Deprecation: *f.Deprecation})
+	}
+}
+
+// DeprecationWarningMessage is the log message of a DeprecatedKeyError.
+const DeprecationWarningMessage = "configuration uses a deprecated key"
+
+// Fields returns the structured log fields of the warning: the key, the file
+// setting it, the migration hint and, when known, the replacement key and
+// the version that removes it.
+func (e *DeprecatedKeyError) Fields() logrus.Fields {
+	fields := logrus.Fields{"key": e.Key, "config_file": e.File, "hint": e.Error()}
+	if e.Replacement != "" {
+		fields["replacement"] = e.Replacement
+	}
+	if e.Removal != "" {
+		fields["removal"] = e.Removal
+	}
+	return fields
+}
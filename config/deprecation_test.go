@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldDeprecationFromTags(t *testing.T) {
+	sf, ok := reflect.TypeOf(Config{}).FieldByName("SearchPaths")
+	require.True(t, ok)
+	d := fieldDeprecation(sf)
+	require.NotNil(t, d)
+	assert.Equal(t, Deprecation{
+		Message:     "Use 'groves' for project discovery",
+		Replacement: "groves",
+		Since:       "v0.5.0",
+		Removal:     "v1.0.0",
+	}, *d)
+	assert.Equal(t, "search_paths is deprecated since v0.5.0 and will be removed in v1.0.0: Use 'groves' for project discovery", d.Hint("search_paths"))
+
+	sf, _ = reflect.TypeOf(Config{}).FieldByName("Groves")
+	assert.Nil(t, fieldDeprecation(sf))
+}
+
+func TestDeprecationHintFallsBackToReplacement(t *testing.T) {
+	assert.Equal(t, "old is deprecated; use new", Deprecation{Replacement: "new"}.Hint("old"))
+	assert.Equal(t, "old is deprecated", Deprecation{}.Hint("old"))
+}
+
+func TestLoadWarnsOncePerDeprecatedKey(t *testing.T) {
+	resetSchemaWarningsForTest()
+	t.Cleanup(resetSchemaWarningsForTest)
+	var (
+		mu       sync.Mutex
+		warnings []*DeprecatedKeyError
+	)
+	SetSchemaWarningSink(func(source string, err error) {
+		var d *DeprecatedKeyError
+		if errors.As(err, &d) {
+			mu.Lock()
+			warnings = append(warnings, d)
+			mu.Unlock()
+		}
+	})
+
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	globalFile := filepath.Join(globalDir, "grove.toml")
+	writeConfig(t, globalFile, "version = \"1.0\"\n\n[search_paths.work]\npath = \"/tmp\"\nenabled = true\n")
+	writeConfig(t, filepath.Join(projectDir, "grove.toml"), "name = \"proj\"\n")
+
+	_, err := LoadFrom(projectDir)
+	require.NoError(t, err)
+	ResetLoadCache()
+	_, err = LoadLayered(projectDir)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, warnings, 1, "a deprecated key must warn once per process")
+	assert.Equal(t, "search_paths", warnings[0].Key)
+	assert.Equal(t, globalFile, warnings[0].File)
+	fields := warnings[0].Fields()
+	assert.Equal(t, "groves", fields["replacement"])
+	assert.Equal(t, "v1.0.0", fields["removal"])
+}
@@ -0,0 +1,371 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// EditableLayers lists the layers `core config set --layer` can write to.
+var EditableLayers = []ConfigSource{SourceProject, SourceEcosystem, SourceGlobal}
+
+// LayerFile returns the config file backing an editable layer for startDir.
+// It is the file LoadLayered read for that layer, or, for a project or
+// global layer without one, the grove.toml the layer would be read from
+// (the project root or the global config directory). An ecosystem layer
+// only exists inside an ecosystem.
+func LayerFile(layered *LayeredConfig, startDir string, layer ConfigSource) (string, error) {
+	if path := layered.FilePaths[layer]; path != "" {
+		return path, nil
+	}
+	switch layer {
+	case SourceProject:
+		root := startDir
+		if gitRoot, err := getGitRoot(startDir); err == nil && gitRoot != "" {
+			root = gitRoot
+		}
+		return filepath.Join(root, "grove.toml"), nil
+	case SourceGlobal:
+		dir := paths.ConfigDir()
+		if dir == "" {
+			return "", fmt.Errorf("cannot determine the global config directory")
+		}
+		return filepath.Join(dir, "grove.toml"), nil
+	case SourceEcosystem:
+		return "", fmt.Errorf("%s is not inside an ecosystem", startDir)
+	}
+	return "", fmt.Errorf("layer %q is not editable (use one of %s)", layer, editableLayerNames())
+}
+
+func editableLayerNames() string {
+	names := make([]string, len(EditableLayers))
+	for i, l := range EditableLayers {
+		names[i] = string(l)
+	}
+	return strings.Join(names, ", ")
+}
+
+// splitKey splits a dotted key into its path segments.
+func splitKey(key string) ([]string, error) {
+	parts := strings.Split(strings.TrimSpace(key), ".")
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("invalid key %q: empty path segment", key)
+		}
+	}
+	return parts, nil
+}
+
+// LookupKey returns the value at a dotted key in a decoded config tree
+// (nested maps as produced by yaml or toml unmarshalling into interface{}).
+func LookupKey(tree interface{}, key string) (interface{}, bool, error) {
+	parts, err := splitKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	node := tree
+	for _, p := range parts {
+		switch m := node.(type) {
+		case map[string]interface{}:
+			v, ok := m[p]
+			if !ok {
+				return nil, false, nil
+			}
+			node = v
+		default:
+			return nil, false, nil
+		}
+	}
+	return node, true, nil
+}
+
+// ReadLayerTree decodes a single config file into a generic tree, for
+// reading keys as written in that file rather than as merged.
+func ReadLayerTree(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tree := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	case ".json5":
+		var raw []byte
+		if raw, err = json5ToJSON(data); err == nil {
+			err = yaml.Unmarshal(raw, &tree)
+		}
+	default:
+		err = yaml.Unmarshal(data, &tree)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return tree, nil
+}
+
+// SetKey sets a dotted key in a config file to value, typed the way a
+// --set override is: after the config schema when it describes key (so
+// "1.0" stays a string in a string field), by its form otherwise.
+// Comments, key order and the formatting of untouched keys survive: YAML
+// files are edited as a yaml.v3 node tree and a replaced value keeps its
+// comments; TOML files are edited as text, like `config lint --fix` does.
+// Missing tables and mappings along the path are created, and a missing
+// file is created. JSON5 files are never rewritten.
+func SetKey(path, key, value string) error {
+	parts, err := splitKey(key)
+	if err != nil {
+		return err
+	}
+	typed, err := coerceOverrideValue(key, value)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var updated []byte
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yml", ".yaml":
+		updated, err = setYAMLKey(data, parts, typed)
+	case ".toml":
+		updated, err = setTOMLKey(data, parts, typed)
+	default:
+		return fmt.Errorf("%s: %s config files cannot be edited in place", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, updated, mode); err != nil {
+		return err
+	}
+	ResetLoadCache()
+	return nil
+}
+
+// setYAMLKey sets the key at parts in the YAML document data.
+func setYAMLKey(data []byte, parts []string, value interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind == yaml.ScalarNode && root.Tag == "!!null" {
+		*root = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: root.HeadComment}
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	if err := setNodeKey(root, parts, &valueNode); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent(data))
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setNodeKey walks (and creates) the mapping nodes along parts and sets the
+// final key to value.
+func setNodeKey(node *yaml.Node, parts []string, value *yaml.Node) error {
+	for i, p := range parts {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a mapping", strings.Join(parts, "."), strings.Join(parts[:i], "."))
+		}
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == p {
+				child = node.Content[j+1]
+				break
+			}
+		}
+		last := i == len(parts)-1
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if last {
+				child = value
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: p}, child)
+			node = child
+			continue
+		}
+		if last {
+			value.HeadComment = child.HeadComment
+			value.LineComment = child.LineComment
+			value.FootComment = child.FootComment
+			if child.Style&yaml.FlowStyle != 0 {
+				value.Style |= yaml.FlowStyle
+			}
+			*child = *value
+		}
+		node = child
+	}
+	return nil
+}
+
+// yamlIndent guesses the indentation width of a YAML document from its
+// first indented line, defaulting to two spaces.
+func yamlIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n >= 2 && n <= 8 {
+			return n
+		}
+	}
+	return 2
+}
+
+// setTOMLKey sets the key at parts in the TOML document data as a text
+// edit: an existing single-line `key = value` directly under the key's
+// [table] header (or at the top level) has its value replaced, keeping any
+// trailing comment; otherwise the assignment is appended to the table,
+// adding the [table] header when there is none. The result is parsed back
+// so an edit the common layouts do not cover fails instead of corrupting
+// the file.
+func setTOMLKey(data []byte, parts []string, value interface{}) ([]byte, error) {
+	key := strings.Join(parts, ".")
+	if _, ok := value.(map[string]interface{}); ok {
+		return nil, fmt.Errorf("cannot set %s to a table; set its keys one at a time", key)
+	}
+	literal, err := tomlLiteral(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	table := strings.Join(parts[:len(parts)-1], ".")
+	name := parts[len(parts)-1]
+	out := append([]byte(nil), data...)
+	if start, end, ok := tomlSection(out, table); ok {
+		lineRe := regexp.MustCompile(`(?m)^([ \t]*` + regexp.QuoteMeta(name) + `[ \t]*=[ \t]*)(.*)$`)
+		if loc := lineRe.FindSubmatchIndex(out[start:end]); loc != nil {
+			line := string(out[start+loc[0] : start+loc[1]])
+			var probe map[string]interface{}
+			if err := toml.Unmarshal([]byte(strings.TrimSpace(line)), &probe); err != nil {
+				return nil, fmt.Errorf("%s spans several lines; set it by hand", key)
+			}
+			comment := tomlTrailingComment(string(out[start+loc[4] : start+loc[5]]))
+			replaced := string(out[start+loc[2]:start+loc[3]]) + literal + comment
+			out = append(out[:start+loc[0]], append([]byte(replaced), out[start+loc[1]:]...)...)
+		} else {
+			// Append after the table's last non-blank line.
+			at := end
+			for at > start && strings.ContainsRune(" \t\r\n", rune(out[at-1])) {
+				at--
+			}
+			line := name + " = " + literal
+			var insert string
+			switch {
+			case at == 0 && len(out) > 0:
+				insert = line + "\n\n"
+			case at == 0:
+				insert = line + "\n"
+			case at == len(out):
+				insert = "\n" + line + "\n"
+			default:
+				insert = "\n" + line
+			}
+			out = append(out[:at:at], append([]byte(insert), out[at:]...)...)
+		}
+	} else {
+		out = bytes.TrimRight(out, "\r\n\t ")
+		if len(out) > 0 {
+			out = append(out, "\n\n"...)
+		}
+		out = append(out, fmt.Sprintf("[%s]\n%s = %s\n", table, name, literal)...)
+	}
+
+	var check map[string]interface{}
+	if err := toml.Unmarshal(out, &check); err != nil {
+		return nil, fmt.Errorf("cannot set %s in this layout (%v); set it by hand", key, err)
+	}
+	if got, ok, _ := LookupKey(check, key); !ok || fmt.Sprint(got) != fmt.Sprint(tomlRoundTrip(value)) {
+		return nil, fmt.Errorf("cannot set %s in this layout; set it by hand", key)
+	}
+	return out, nil
+}
+
+// tomlLiteral encodes value as a TOML value. Strings are written as basic
+// (double-quoted) strings, the form grove.toml files use.
+func tomlLiteral(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return `"` + tomlStringEscaper.Replace(str) + `"`, nil
+	}
+	encoded, err := toml.Marshal(map[string]interface{}{"v": value})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(encoded)), "v =")), nil
+}
+
+var tomlStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// tomlTrailingComment returns the " # comment" suffix of a TOML value, or "".
+func tomlTrailingComment(rest string) string {
+	inString := byte(0)
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case inString != 0:
+			if c == '\\' && inString == '"' {
+				i++
+			} else if c == inString {
+				inString = 0
+			}
+		case c == '"' || c == '\'':
+			inString = c
+		case c == '#':
+			j := i
+			for j > 0 && (rest[j-1] == ' ' || rest[j-1] == '\t') {
+				j--
+			}
+			return rest[j:]
+		}
+	}
+	return ""
+}
+
+// tomlRoundTrip returns value as a TOML decoder sees it after encoding.
+func tomlRoundTrip(value interface{}) interface{} {
+	data, err := toml.Marshal(map[string]interface{}{"v": value})
+	if err != nil {
+		return value
+	}
+	var m map[string]interface{}
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return value
+	}
+	return m["v"]
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetKeyPreservesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.yml")
+	original := `# Project config
+name: demo # the project name
+
+logging:
+    # verbosity for this repo
+    level: info # was debug
+    file:
+        enabled: true
+`
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetKey(path, "logging.level", "warn"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetKey(path, "tui.theme", "gruvbox"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetKey(path, "logging.file.enabled", "false"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		"# Project config",
+		"name: demo # the project name",
+		"    # verbosity for this repo\n    level: warn # was debug",
+		"        enabled: false",
+		"tui:\n    theme: gruvbox",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("edited file missing %q:\n%s", want, got)
+		}
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	tree, err := ReadLayerTree(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, _ := LookupKey(tree, "logging.file.enabled"); !ok || v != false {
+		t.Errorf("logging.file.enabled = %#v, %v", v, ok)
+	}
+}
+
+func TestSetKeyCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "grove.yml")
+	if err := SetKey(path, "tui.theme", "gruvbox"); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := ReadLayerTree(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, _ := LookupKey(tree, "tui.theme"); !ok || v != "gruvbox" {
+		t.Errorf("tui.theme = %#v, %v", v, ok)
+	}
+}
+
+func TestSetKeyFollowsSchemaTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.yml")
+	for _, set := range [][2]string{{"version", "1.0"}, {"name", "007"}} {
+		if err := SetKey(path, set[0], set[1]); err != nil {
+			t.Fatalf("SetKey(%s): %v", set[0], err)
+		}
+	}
+	tree, err := ReadLayerTree(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _, _ := LookupKey(tree, "version"); v != "1.0" {
+		t.Errorf("version = %#v, want the string \"1.0\"", v)
+	}
+	if v, _, _ := LookupKey(tree, "name"); v != "007" {
+		t.Errorf("name = %#v, want the string \"007\"", v)
+	}
+
+	if err := SetKey(path, "logging.file.enabled", "sometimes"); err == nil {
+		t.Error("expected a non-boolean value of a boolean key to be rejected")
+	}
+}
+
+func TestSetKeyErrors(t *testing.T) {
+	dir := t.TempDir()
+	yml := filepath.Join(dir, "grove.yml")
+	if err := os.WriteFile(yml, []byte("name: demo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetKey(yml, "name.first", "x"); err == nil || !strings.Contains(err.Error(), "not a mapping") {
+		t.Errorf("setting below a scalar: err = %v", err)
+	}
+	if err := SetKey(yml, "logging..level", "x"); err == nil {
+		t.Error("expected an error for an empty path segment")
+	}
+	if err := SetKey(filepath.Join(dir, "grove.json5"), "tui.theme", "x"); err == nil {
+		t.Error("expected JSON5 files to be rejected")
+	}
+
+	toml := filepath.Join(dir, "grove.toml")
+	if err := os.WriteFile(toml, []byte("[logging]\ncomponents = [\n  \"a\",\n]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetKey(toml, "logging.components", `["b"]`); err == nil || !strings.Contains(err.Error(), "several lines") {
+		t.Errorf("replacing a multi-line array: err = %v", err)
+	}
+	if err := SetKey(toml, "logging", `{"level": "debug"}`); err == nil {
+		t.Error("expected setting a table to be rejected")
+	}
+}
+
+func TestSetKeyTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.toml")
+	original := `# Project config
+name = "demo" # the project name
+
+[logging]
+# verbosity for this repo
+level = "info" # was debug
+
+[tui]
+theme = "kanagawa"
+`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, set := range [][2]string{
+		{"logging.level", "warn"},
+		{"logging.report_caller", "true"},
+		{"tui.theme", "gruvbox"},
+		{"version", "1.0"},
+		{"notes.root_dir", "~/notes"},
+	} {
+		if err := SetKey(path, set[0], set[1]); err != nil {
+			t.Fatalf("SetKey(%s): %v", set[0], err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Project config
+name = "demo" # the project name
+version = "1.0"
+
+[logging]
+# verbosity for this repo
+level = "warn" # was debug
+report_caller = true
+
+[tui]
+theme = "gruvbox"
+
+[notes]
+root_dir = "~/notes"
+`
+	if got := string(data); got != want {
+		t.Errorf("edited file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLayerFile(t *testing.T) {
+	dir := t.TempDir()
+	layered := &LayeredConfig{FilePaths: map[ConfigSource]string{
+		SourceGlobal: "/home/u/.config/grove/grove.yml",
+	}}
+
+	if got, err := LayerFile(layered, dir, SourceGlobal); err != nil || got != "/home/u/.config/grove/grove.yml" {
+		t.Errorf("global layer = %q, %v", got, err)
+	}
+	if got, err := LayerFile(layered, dir, SourceProject); err != nil || got != filepath.Join(dir, "grove.toml") {
+		t.Errorf("project layer without a file = %q, %v", got, err)
+	}
+	if _, err := LayerFile(layered, dir, SourceEcosystem); err == nil {
+		t.Error("expected an error for an ecosystem layer outside an ecosystem")
+	}
+	if _, err := LayerFile(layered, dir, SourceOverride); err == nil {
+		t.Error("expected an error for a non-editable layer")
+	}
+}
+
+func TestLookupKey(t *testing.T) {
+	tree := map[string]interface{}{
+		"logging": map[string]interface{}{"level": "debug"},
+		"name":    "demo",
+	}
+	if v, ok, err := LookupKey(tree, "logging.level"); err != nil || !ok || v != "debug" {
+		t.Errorf("logging.level = %#v, %v, %v", v, ok, err)
+	}
+	if _, ok, _ := LookupKey(tree, "logging.missing"); ok {
+		t.Error("missing key reported as set")
+	}
+	if _, ok, _ := LookupKey(tree, "name.first"); ok {
+		t.Error("key below a scalar reported as set")
+	}
+}
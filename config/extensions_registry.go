@@ -24,7 +24,7 @@ type ExtensionInfo struct {
 // into one binary can add their own entries at init via RegisterExtension.
 var knownExtensions = map[string]ExtensionInfo{
 	"flow":          {Key: "flow", Repo: "flow", Description: "Flow orchestration (plans, jobs, models)"},
-	"hooks":         {Key: "hooks", Repo: "hooks", Description: "Lifecycle hooks (on_stop, async hooks, plan preservation)"},
+	"hooks":         {Key: "hooks", Repo: "hooks", Description: "Lifecycle hooks (on_stop, async hooks, plan preservation) and event hooks (on_session_start, on_error_log, ...)"},
 	"notifications": {Key: "notifications", Repo: "notify", Description: "Desktop/remote notification routing"},
 	"skills":        {Key: "skills", Repo: "skills", Description: "Skill library configuration"},
 	"playbooks":     {Key: "playbooks", Repo: "skills", Description: "Playbook definitions"},
@@ -33,6 +33,7 @@ var knownExtensions = map[string]ExtensionInfo{
 	"claude":        {Key: "claude", Repo: "grove-anthropic", Description: "Claude Code settings profile (also read by core/pkg/claudenotebook)"},
 	"logging":       {Key: "logging", Repo: "core", Description: "Structured logging (levels, sinks)"},
 	"keys":          {Key: "keys", Repo: "core", Description: "Global keybinding registry (core/pkg/keybind, grove keys)"},
+	"cli":           {Key: "cli", Repo: "core", Description: "Per-command default flags (core/cli, cli.defaults)"},
 	"nav":           {Key: "nav", Repo: "nav", Description: "Session/window navigation groups"},
 	"llm":           {Key: "llm", Repo: "grove", Description: "LLM provider/model selection for CLI helpers"},
 	"context":       {Key: "context", Repo: "cx", Description: "cx context tool settings (also a core Config field; core takes precedence)"},
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// json5ToJSON rewrites a JSON5 document as strict JSON so it can go through
+// the YAML loader (JSON is a subset of YAML). It accepts the JSON5
+// additions: // and /* */ comments, trailing commas, unquoted identifier
+// keys, single-quoted strings, line continuations and \x escapes in
+// strings, hexadecimal numbers, leading or trailing decimal points and an
+// explicit plus sign. Infinity and NaN are rejected since no config value
+// can hold them. Newlines are kept so parse errors point at the right line.
+func json5ToJSON(data []byte) ([]byte, error) {
+	c := &json5Converter{src: data}
+	if err := c.convert(); err != nil {
+		return nil, err
+	}
+	if !json.Valid(c.out.Bytes()) {
+		var v interface{}
+		err := json.Unmarshal(c.out.Bytes(), &v)
+		return nil, fmt.Errorf("invalid JSON5: %w", err)
+	}
+	return c.out.Bytes(), nil
+}
+
+type json5Converter struct {
+	src []byte
+	pos int
+	out bytes.Buffer
+}
+
+func (c *json5Converter) errorf(format string, args ...interface{}) error {
+	line := 1 + bytes.Count(c.src[:c.pos], []byte("\n"))
+	col := c.pos - bytes.LastIndexByte(c.src[:c.pos], '\n')
+	return fmt.Errorf("invalid JSON5 at line %d, column %d: %s", line, col, fmt.Sprintf(format, args...))
+}
+
+func (c *json5Converter) convert() error {
+	for c.pos < len(c.src) {
+		ch := c.src[c.pos]
+		switch {
+		case c.skipSpaceOrComment(true):
+		case ch == '"' || ch == '\'':
+			if err := c.string(ch); err != nil {
+				return err
+			}
+		case ch == ',':
+			c.pos++
+			if next := c.peek(); next != '}' && next != ']' {
+				c.out.WriteByte(',')
+			}
+		case ch == '{' || ch == '}' || ch == '[' || ch == ']' || ch == ':':
+			c.out.WriteByte(ch)
+			c.pos++
+		case ch == '+' || ch == '-' || ch == '.' || (ch >= '0' && ch <= '9'):
+			if err := c.number(); err != nil {
+				return err
+			}
+		default:
+			if err := c.identifier(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipSpaceOrComment consumes one run of whitespace or one comment at pos,
+// echoing newlines when emit is set. It reports whether anything was read.
+func (c *json5Converter) skipSpaceOrComment(emit bool) bool {
+	start := c.pos
+	for c.pos < len(c.src) {
+		r, size := utf8.DecodeRune(c.src[c.pos:])
+		switch {
+		case r == '\n':
+			if emit {
+				c.out.WriteByte('\n')
+			}
+			c.pos += size
+		case unicode.IsSpace(r) || r == '\uFEFF':
+			if emit {
+				c.out.WriteByte(' ')
+			}
+			c.pos += size
+		case bytes.HasPrefix(c.src[c.pos:], []byte("//")):
+			end := bytes.IndexByte(c.src[c.pos:], '\n')
+			if end < 0 {
+				end = len(c.src) - c.pos
+			}
+			c.pos += end
+		case bytes.HasPrefix(c.src[c.pos:], []byte("/*")):
+			end := bytes.Index(c.src[c.pos+2:], []byte("*/"))
+			if end < 0 {
+				c.pos = len(c.src)
+				break
+			}
+			if emit {
+				c.out.Write(bytes.Repeat([]byte("\n"), bytes.Count(c.src[c.pos:c.pos+2+end], []byte("\n"))))
+			}
+			c.pos += end + 4
+		default:
+			return c.pos > start
+		}
+	}
+	return c.pos > start
+}
+
+// peek returns the next significant byte without consuming anything.
+func (c *json5Converter) peek() byte {
+	save, saveOut := c.pos, c.out.Len()
+	for c.skipSpaceOrComment(false) {
+	}
+	var next byte
+	if c.pos < len(c.src) {
+		next = c.src[c.pos]
+	}
+	c.pos = save
+	c.out.Truncate(saveOut)
+	return next
+}
+
+func (c *json5Converter) string(quote byte) error {
+	c.pos++
+	var sb strings.Builder
+	for {
+		if c.pos >= len(c.src) {
+			return c.errorf("unterminated string")
+		}
+		r, size := utf8.DecodeRune(c.src[c.pos:])
+		c.pos += size
+		switch {
+		case r == rune(quote):
+			encoded, _ := json.Marshal(sb.String())
+			c.out.Write(encoded)
+			return nil
+		case r == '\n':
+			return c.errorf("newline in string (end the line with \\ to continue it)")
+		case r != '\\':
+			sb.WriteRune(r)
+			continue
+		}
+
+		if c.pos >= len(c.src) {
+			return c.errorf("unterminated string")
+		}
+		esc := c.src[c.pos]
+		c.pos++
+		switch esc {
+		case '\n':
+			// Line continuation.
+		case '\r':
+			if c.pos < len(c.src) && c.src[c.pos] == '\n' {
+				c.pos++
+			}
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'v':
+			sb.WriteByte('\v')
+		case '0':
+			sb.WriteByte(0)
+		case 'x', 'u':
+			n := 2
+			if esc == 'u' {
+				n = 4
+			}
+			if c.pos+n > len(c.src) {
+				return c.errorf("short \\%c escape", esc)
+			}
+			v, err := strconv.ParseUint(string(c.src[c.pos:c.pos+n]), 16, 32)
+			if err != nil {
+				return c.errorf("bad \\%c escape", esc)
+			}
+			c.pos += n
+			r := rune(v)
+			// Join a UTF-16 surrogate pair written as two \u escapes.
+			if utf16Surrogate(r) && bytes.HasPrefix(c.src[c.pos:], []byte("\\u")) && c.pos+6 <= len(c.src) {
+				if lo, err := strconv.ParseUint(string(c.src[c.pos+2:c.pos+6]), 16, 32); err == nil {
+					r = (r-0xd800)<<10 + (rune(lo) - 0xdc00) + 0x10000
+					c.pos += 6
+				}
+			}
+			sb.WriteRune(r)
+		default:
+			// Any other escaped character stands for itself.
+			r, size := utf8.DecodeRune(c.src[c.pos-1:])
+			c.pos += size - 1
+			sb.WriteRune(r)
+		}
+	}
+}
+
+func utf16Surrogate(r rune) bool {
+	return r >= 0xd800 && r < 0xdc00
+}
+
+func (c *json5Converter) number() error {
+	start := c.pos
+	end := c.pos
+	for end < len(c.src) && (isIdentByte(c.src[end]) || strings.IndexByte("+-.", c.src[end]) >= 0) {
+		end++
+	}
+	lit := string(c.src[start:end])
+	c.pos = end
+
+	sign := ""
+	switch {
+	case strings.HasPrefix(lit, "-"):
+		sign, lit = "-", lit[1:]
+	case strings.HasPrefix(lit, "+"):
+		lit = lit[1:]
+	}
+	if lit == "Infinity" || lit == "NaN" {
+		c.pos = start
+		return c.errorf("%s%s is not supported in config files", sign, lit)
+	}
+	if strings.HasPrefix(lit, "0x") || strings.HasPrefix(lit, "0X") {
+		v, err := strconv.ParseUint(lit[2:], 16, 64)
+		if err != nil {
+			c.pos = start
+			return c.errorf("bad hexadecimal number %q", lit)
+		}
+		c.out.WriteString(sign + strconv.FormatUint(v, 10))
+		return nil
+	}
+	if strings.HasPrefix(lit, ".") {
+		lit = "0" + lit
+	}
+	if mant, exp, ok := strings.Cut(strings.ToLower(lit), "e"); ok && strings.HasSuffix(mant, ".") {
+		lit = mant + "0e" + exp
+	} else if strings.HasSuffix(lit, ".") {
+		lit += "0"
+	}
+	if _, err := strconv.ParseFloat(lit, 64); err != nil {
+		c.pos = start
+		return c.errorf("bad number %q", string(c.src[start:end]))
+	}
+	c.out.WriteString(sign + lit)
+	return nil
+}
+
+func (c *json5Converter) identifier() error {
+	start := c.pos
+	for c.pos < len(c.src) {
+		r, size := utf8.DecodeRune(c.src[c.pos:])
+		if !(r == '$' || r == '_' || unicode.IsLetter(r) || (c.pos > start && unicode.IsDigit(r))) {
+			break
+		}
+		c.pos += size
+	}
+	if c.pos == start {
+		r, _ := utf8.DecodeRune(c.src[c.pos:])
+		return c.errorf("unexpected %q", r)
+	}
+	ident := string(c.src[start:c.pos])
+	if c.peek() == ':' {
+		encoded, _ := json.Marshal(ident)
+		c.out.Write(encoded)
+		return nil
+	}
+	switch ident {
+	case "true", "false", "null":
+		c.out.WriteString(ident)
+		return nil
+	case "Infinity", "NaN":
+		c.pos = start
+		return c.errorf("%s is not supported in config files", ident)
+	}
+	c.pos = start
+	return c.errorf("unquoted value %q (strings must be quoted)", ident)
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
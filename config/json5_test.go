@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON5ToJSON(t *testing.T) {
+	cases := map[string]string{
+		`{a: 1, b: [1, 2,], }`:                   `{"a": 1, "b": [1, 2] }`,
+		"// lead\n{/* c */ 'k': 'it\\'s \"x\"'}": "\n{ \"k\": \"it's \\\"x\\\"\"}",
+		`{n: +.5, m: 5., h: 0x1F, e: -1.e3}`:     `{"n": 0.5, "m": 5.0, "h": 31, "e": -1.0e3}`,
+		"{s: 'a\\\nb', x: '\\x41'}":              `{"s": "ab", "x": "A"}`,
+		`{true: true, null: null, $id_2: false}`: `{"true": true, "null": null, "$id_2": false}`,
+		`["http://x//y", '/* no */']`:            `["http://x//y", "/* no */"]`,
+	}
+	for in, want := range cases {
+		got, err := json5ToJSON([]byte(in))
+		if assert.NoError(t, err, in) {
+			assert.Equal(t, want, string(got), in)
+		}
+	}
+
+	for _, bad := range []string{`{a: Infinity}`, `{a: NaN}`, `{a: bare}`, `{a: 'open`, "{a: 'x\ny'}", `{a: 1`} {
+		_, err := json5ToJSON([]byte(bad))
+		assert.Error(t, err, bad)
+	}
+
+	_, err := json5ToJSON([]byte("{\n  a: 1,\n  b: oops,\n}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestLoadJSON5Config(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	t.Cleanup(ResetLoadCache)
+
+	writeConfig(t, filepath.Join(globalDir, "grove.yml"), "version: \"1.0\"\ntui:\n  theme: kanagawa\n")
+	path := filepath.Join(projectDir, "grove.json5")
+	writeConfig(t, path, `{
+  // Project settings
+  name: 'app',
+  version: "1.0",
+  workspaces: ['services/*',],
+  tui: {icons: 'ascii'},
+  flow: {plans_dir: './plans'}, // extension key
+}
+`)
+
+	found, err := FindConfigFile(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, path, found)
+	assert.Equal(t, path, FindEcosystemConfig(projectDir))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, []string{"services/*"}, cfg.Workspaces)
+	assert.Contains(t, cfg.Extensions, "flow")
+
+	merged, err := LoadFrom(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, "app", merged.Name)
+	assert.Equal(t, "kanagawa", merged.TUI.Theme)
+	assert.Equal(t, "ascii", merged.TUI.Icons)
+
+	// A JSON5 ecosystem is not rewritten; an uncovered member is reported.
+	member := filepath.Join(projectDir, "tools", "cli")
+	require.NoError(t, os.MkdirAll(member, 0o755))
+	added, err := RegisterWorkspace(path, member)
+	assert.False(t, added)
+	assert.ErrorContains(t, err, `add "tools/cli"`)
+	added, err = RegisterWorkspace(path, filepath.Join(projectDir, "services", "api"))
+	assert.NoError(t, err)
+	assert.False(t, added)
+}
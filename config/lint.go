@@ -0,0 +1,631 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lint rules. Each finding names the rule that produced it.
+const (
+	// LintDeprecatedKey: a key carrying deprecation tags (see AuditDeprecated).
+	LintDeprecatedKey = "deprecated-key"
+	// LintUnreachableGrove: a groves entry whose path does not exist.
+	LintUnreachableGrove = "unreachable-grove"
+	// LintUnusedGroup: a logging group no component_filtering list refers to.
+	LintUnusedGroup = "unused-group"
+	// LintFilterConflict: component_filtering lists that contradict or
+	// shadow each other.
+	LintFilterConflict = "filter-conflict"
+	// LintDuplicateWorkspace: a workspaces pattern listed more than once.
+	LintDuplicateWorkspace = "duplicate-workspace"
+)
+
+// LintIssue is one problem found by Lint in one config layer file.
+type LintIssue struct {
+	Rule    string       `json:"rule"`
+	Key     string       `json:"key"` // Dot-joined key path the issue is about.
+	Message string       `json:"message"`
+	Layer   ConfigSource `json:"layer"`
+	File    string       `json:"file"`
+	// Fixable is set when FixLint can rewrite the file without changing the
+	// effective configuration.
+	Fixable bool `json:"fixable"`
+
+	edit *lintEdit
+}
+
+// lintEdit is the rewrite that fixes a LintIssue.
+type lintEdit struct {
+	path   []string // key path of the array or key to change
+	rename string   // new name for the key at path
+	drop   string   // array value to remove from the array at path
+	dedupe bool     // drop repeated values from the array at path
+}
+
+// Lint loads the layered configuration starting from startDir and checks
+// every layer file for problems schema validation does not catch:
+// deprecated keys, groves paths that do not exist, logging groups nothing
+// refers to, contradictory component_filtering lists and repeated
+// workspaces patterns. Issues are returned in cascade order of their files.
+func Lint(startDir string) ([]LintIssue, error) {
+	layered, err := LoadLayered(startDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load layered config: %w", err)
+	}
+	return LintLayered(layered)
+}
+
+// LintLayered is Lint for an already-loaded LayeredConfig.
+func LintLayered(layered *LayeredConfig) ([]LintIssue, error) {
+	// Groups may be defined in one layer and referenced from another, so
+	// references are taken from the merged config.
+	var merged lintLogging
+	if layered.Final != nil {
+		_ = layered.Final.UnmarshalExtension("logging", &merged)
+	}
+	referenced := make(map[string]bool)
+	for _, name := range merged.filterEntries() {
+		referenced[name] = true
+	}
+	mergedGroves := layered.Final != nil && len(layered.Final.Groves) > 0
+
+	var issues []LintIssue
+	for _, layer := range auditLayerFiles(layered) {
+		raw, err := readRawLayer(layer.path)
+		if err != nil {
+			return nil, err
+		}
+		findings, err := auditFile(layer.path, layer.source)
+		if err != nil {
+			return nil, err
+		}
+
+		l := &layerLinter{source: layer.source, file: layer.path, raw: raw}
+		l.deprecatedKeys(findings, mergedGroves)
+		l.unreachableGroves()
+		l.duplicateWorkspaces()
+		l.loggingFilters(referenced)
+		issues = append(issues, l.issues...)
+	}
+	return issues, nil
+}
+
+// lintLogging is the part of the logging extension the linter reads. The
+// config package cannot import logging, so the shape is repeated here.
+type lintLogging struct {
+	Groups             map[string][]string `yaml:"groups"`
+	ComponentFiltering *struct {
+		Only []string `yaml:"only"`
+		Show []string `yaml:"show"`
+		Hide []string `yaml:"hide"`
+	} `yaml:"component_filtering"`
+}
+
+// filterEntries returns every name in the only/show/hide lists, leaving out
+// "remove:" merge directives.
+func (l lintLogging) filterEntries() []string {
+	if l.ComponentFiltering == nil {
+		return nil
+	}
+	var names []string
+	for _, list := range [][]string{l.ComponentFiltering.Only, l.ComponentFiltering.Show, l.ComponentFiltering.Hide} {
+		for _, name := range list {
+			if !strings.HasPrefix(name, mergeRemovePrefix) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+type layerLinter struct {
+	source ConfigSource
+	file   string
+	raw    map[string]interface{}
+	issues []LintIssue
+}
+
+func (l *layerLinter) add(rule, key, message string, edit *lintEdit) {
+	l.issues = append(l.issues, LintIssue{
+		Rule:    rule,
+		Key:     key,
+		Message: message,
+		Layer:   l.source,
+		File:    l.file,
+		Fixable: edit != nil && !strings.HasSuffix(l.file, ".json5"),
+		edit:    edit,
+	})
+}
+
+// deprecatedKeys reports the keys the audit classifies as deprecated, with
+// the migration hint from their deprecation tags.
+// search_paths is renamed to groves by --fix when that keeps discovery
+// unchanged: the file has no groves of its own, every entry states enabled
+// (which defaults differently in the two forms), and for TOML, which only
+// falls back to search_paths in discovery, no layer sets groves.
+func (l *layerLinter) deprecatedKeys(findings []AuditFinding, mergedGroves bool) {
+	for _, f := range findings {
+		if f.Class != AuditDeprecated {
+			continue
+		}
+		message := "deprecated key"
+		if f.Deprecation != nil {
+			message = f.Deprecation.Hint(f.Key)
+		}
+		if f.Key != "search_paths" {
+			l.add(LintDeprecatedKey, f.Key, message, nil)
+			continue
+		}
+		var edit *lintEdit
+		_, ownGroves := l.raw["groves"]
+		shadowed := mergedGroves && strings.HasSuffix(l.file, ".toml")
+		if !ownGroves && !shadowed && allEntriesSet(l.raw["search_paths"], "enabled") {
+			edit = &lintEdit{path: []string{"search_paths"}, rename: "groves"}
+		}
+		l.add(LintDeprecatedKey, f.Key, message, edit)
+	}
+}
+
+func allEntriesSet(v interface{}, key string) bool {
+	entries, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, entry := range entries {
+		m, ok := entry.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// unreachableGroves reports enabled groves whose path does not exist.
+func (l *layerLinter) unreachableGroves() {
+	groves, _ := l.raw["groves"].(map[string]interface{})
+	for _, name := range sortedRawKeys(groves) {
+		grove, ok := groves[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if enabled, ok := grove["enabled"].(bool); ok && !enabled {
+			continue
+		}
+		path, _ := grove["path"].(string)
+		key := "groves." + name + ".path"
+		if path == "" {
+			l.add(LintUnreachableGrove, key, "grove has no path", nil)
+			continue
+		}
+		if strings.HasPrefix(path, "ssh://") {
+			continue // on another host; discovery reports it when unreachable
+		}
+		info, err := os.Stat(expandPath(path))
+		switch {
+		case err != nil:
+			l.add(LintUnreachableGrove, key, fmt.Sprintf("%s does not exist", path), nil)
+		case !info.IsDir():
+			l.add(LintUnreachableGrove, key, fmt.Sprintf("%s is not a directory", path), nil)
+		}
+	}
+}
+
+// duplicateWorkspaces reports workspaces patterns listed more than once.
+func (l *layerLinter) duplicateWorkspaces() {
+	for _, dup := range duplicates(stringList(l.raw["workspaces"])) {
+		l.add(LintDuplicateWorkspace, "workspaces", fmt.Sprintf("%q is listed more than once", dup),
+			&lintEdit{path: []string{"workspaces"}, dedupe: true})
+	}
+}
+
+// loggingFilters checks the logging groups and component_filtering lists
+// set in this layer.
+func (l *layerLinter) loggingFilters(referenced map[string]bool) {
+	logging, _ := l.raw["logging"].(map[string]interface{})
+	if logging == nil {
+		return
+	}
+
+	groups, _ := logging["groups"].(map[string]interface{})
+	for _, name := range sortedRawKeys(groups) {
+		if !referenced[name] {
+			l.add(LintUnusedGroup, "logging.groups."+name,
+				fmt.Sprintf("group %q is not used by logging.component_filtering", name), nil)
+		}
+	}
+
+	filtering, _ := logging["component_filtering"].(map[string]interface{})
+	if filtering == nil {
+		return
+	}
+	only := stringList(filtering["only"])
+	show := stringList(filtering["show"])
+	hide := stringList(filtering["hide"])
+	if len(only) > 0 && (len(show) > 0 || len(hide) > 0) {
+		l.add(LintFilterConflict, "logging.component_filtering.only",
+			"show and hide are ignored while only is set", nil)
+	}
+	shown := make(map[string]bool)
+	for _, name := range show {
+		shown[name] = true
+	}
+	hidePath := []string{"logging", "component_filtering", "hide"}
+	reported := make(map[string]bool)
+	for _, name := range hide {
+		if shown[name] && !reported[name] && !strings.HasPrefix(name, mergeRemovePrefix) {
+			reported[name] = true
+			// show overrides hide, so the hide entry has no effect.
+			l.add(LintFilterConflict, "logging.component_filtering.hide",
+				fmt.Sprintf("%q is in both show and hide; show wins", name),
+				&lintEdit{path: hidePath, drop: name})
+		}
+	}
+	for _, dup := range duplicates(hide) {
+		if !reported[dup] {
+			l.add(LintFilterConflict, "logging.component_filtering.hide",
+				fmt.Sprintf("%q is listed more than once", dup), &lintEdit{path: hidePath, dedupe: true})
+		}
+	}
+}
+
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var out []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// duplicates returns the values that occur more than once, in order of
+// their second occurrence.
+func duplicates(values []string) []string {
+	seen := make(map[string]int)
+	var dups []string
+	for _, v := range values {
+		seen[v]++
+		if seen[v] == 2 {
+			dups = append(dups, v)
+		}
+	}
+	return dups
+}
+
+// FixLint rewrites the files of the fixable issues and returns the issues it
+// fixed. YAML files are edited as a node tree and TOML files in place, so
+// comments are kept; JSON5 files are never rewritten. A file that cannot be
+// fixed is reported in the error and leaves the others unaffected.
+func FixLint(issues []LintIssue) ([]LintIssue, error) {
+	byFile := make(map[string][]LintIssue)
+	var files []string
+	for _, issue := range issues {
+		if !issue.Fixable || issue.edit == nil {
+			continue
+		}
+		if _, ok := byFile[issue.File]; !ok {
+			files = append(files, issue.File)
+		}
+		byFile[issue.File] = append(byFile[issue.File], issue)
+	}
+	sort.Strings(files)
+
+	var fixed []LintIssue
+	var errs []error
+	for _, file := range files {
+		if err := fixFile(file, byFile[file]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		fixed = append(fixed, byFile[file]...)
+	}
+	if len(fixed) > 0 {
+		ResetLoadCache()
+	}
+	return fixed, errors.Join(errs...)
+}
+
+func fixFile(path string, issues []LintIssue) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	var updated []byte
+	if strings.HasSuffix(path, ".toml") {
+		updated = data
+		for _, issue := range issues {
+			if updated, err = applyTOMLEdit(updated, issue.edit); err != nil {
+				return err
+			}
+		}
+	} else {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse: %w", err)
+		}
+		for _, issue := range issues {
+			if err := applyYAMLEdit(&doc, issue.edit); err != nil {
+				return err
+			}
+		}
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		updated = buf.Bytes()
+	}
+	return os.WriteFile(path, updated, info.Mode().Perm())
+}
+
+// applyYAMLEdit applies e to the document node doc.
+func applyYAMLEdit(doc *yaml.Node, e *lintEdit) error {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return fmt.Errorf("%s not found", strings.Join(e.path, "."))
+	}
+	parent := doc.Content[0]
+	for i, key := range e.path {
+		if parent.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s not found", strings.Join(e.path, "."))
+		}
+		var value *yaml.Node
+		for j := 0; j+1 < len(parent.Content); j += 2 {
+			if parent.Content[j].Value == key {
+				if i == len(e.path)-1 && e.rename != "" {
+					parent.Content[j].Value = e.rename
+					return nil
+				}
+				value = parent.Content[j+1]
+				break
+			}
+		}
+		if value == nil {
+			return fmt.Errorf("%s not found", strings.Join(e.path, "."))
+		}
+		parent = value
+	}
+
+	if parent.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%s is not a list", strings.Join(e.path, "."))
+	}
+	seen := make(map[string]bool)
+	kept := parent.Content[:0]
+	for _, item := range parent.Content {
+		if item.Kind == yaml.ScalarNode && (item.Value == e.drop || (e.dedupe && seen[item.Value])) {
+			continue
+		}
+		seen[item.Value] = true
+		kept = append(kept, item)
+	}
+	parent.Content = kept
+	return nil
+}
+
+var (
+	tomlHeaderRe    = regexp.MustCompile(`(?m)^[ \t]*\[`)
+	tomlTableLineRe = regexp.MustCompile(`^[ \t]*\[([^\[\]]+)\][ \t]*(#.*)?$`)
+)
+
+// applyTOMLEdit applies e to the TOML document data as a text edit. Only
+// the common layouts are handled: the array or key must be set with
+// key = [...] directly under its own [table] header (or at the top level).
+func applyTOMLEdit(data []byte, e *lintEdit) ([]byte, error) {
+	if e.rename != "" {
+		return renameTOMLTopLevelKey(data, e.path[0], e.rename), nil
+	}
+
+	table := strings.Join(e.path[:len(e.path)-1], ".")
+	key := e.path[len(e.path)-1]
+	start, end, ok := tomlSection(data, table)
+	if !ok {
+		return nil, fmt.Errorf("[%s] table not found; fix %s by hand", table, strings.Join(e.path, "."))
+	}
+	keyRe := regexp.MustCompile(`(?m)^[ \t]*` + regexp.QuoteMeta(key) + `[ \t]*=[ \t]*\[`)
+	loc := keyRe.FindIndex(data[start:end])
+	if loc == nil {
+		return nil, fmt.Errorf("%s = [...] not found; fix it by hand", strings.Join(e.path, "."))
+	}
+	items, ok := tomlStringArray(data, start+loc[1]-1)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a plain string array; fix it by hand", strings.Join(e.path, "."))
+	}
+
+	seen := make(map[string]bool)
+	var drop []int
+	for i, item := range items {
+		if item.value == e.drop || (e.dedupe && seen[item.value]) {
+			drop = append(drop, i)
+			continue
+		}
+		seen[item.value] = true
+	}
+	return removeTOMLArrayItems(data, items, drop), nil
+}
+
+// tomlSection returns the byte range of the body of table: the lines after
+// its [table] header up to the next header, or for "" everything before
+// the first header.
+func tomlSection(data []byte, table string) (int, int, bool) {
+	headers := tomlHeaderRe.FindAllIndex(data, -1)
+	if table == "" {
+		if len(headers) == 0 {
+			return 0, len(data), true
+		}
+		return 0, headers[0][0], true
+	}
+	for i, h := range headers {
+		lineEnd := bytes.IndexByte(data[h[0]:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(data) - h[0]
+		}
+		m := tomlTableLineRe.FindSubmatch(bytes.TrimRight(data[h[0]:h[0]+lineEnd], "\r"))
+		if m == nil || strings.ReplaceAll(strings.TrimSpace(string(m[1])), " ", "") != table {
+			continue
+		}
+		end := len(data)
+		if i+1 < len(headers) {
+			end = headers[i+1][0]
+		}
+		return h[0] + lineEnd, end, true
+	}
+	return 0, 0, false
+}
+
+// renameTOMLTopLevelKey renames a top-level key in its [key] / [key.sub]
+// headers and in dotted or plain assignments before the first header.
+func renameTOMLTopLevelKey(data []byte, from, to string) []byte {
+	headerRe := regexp.MustCompile(`(?m)^([ \t]*\[\[?[ \t]*)` + regexp.QuoteMeta(from) + `([ \t]*[.\]])`)
+	out := headerRe.ReplaceAll(data, []byte("${1}"+to+"${2}"))
+
+	_, end, _ := tomlSection(out, "")
+	assignRe := regexp.MustCompile(`(?m)^([ \t]*)` + regexp.QuoteMeta(from) + `([ \t]*[.=])`)
+	top := assignRe.ReplaceAll(out[:end], []byte("${1}"+to+"${2}"))
+	return append(top, out[end:]...)
+}
+
+type tomlArrayItem struct {
+	start, end int
+	value      string
+}
+
+// tomlStringArray parses the array of strings opening at data[open] and
+// returns its items with their byte ranges. ok is false for arrays holding
+// anything but single-line strings.
+func tomlStringArray(data []byte, open int) ([]tomlArrayItem, bool) {
+	var items []tomlArrayItem
+	for i := open + 1; i < len(data); {
+		switch c := data[i]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ',':
+			i++
+		case c == '#':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case c == ']':
+			return items, true
+		case c == '"' || c == '\'':
+			if bytes.HasPrefix(data[i:], []byte{c, c, c}) {
+				return nil, false
+			}
+			j := i + 1
+			for j < len(data) && data[j] != c && data[j] != '\n' {
+				if c == '"' && data[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(data) || data[j] != c {
+				return nil, false
+			}
+			value := string(data[i+1 : j])
+			if c == '"' {
+				unquoted, err := strconv.Unquote(string(data[i : j+1]))
+				if err != nil {
+					return nil, false
+				}
+				value = unquoted
+			}
+			items = append(items, tomlArrayItem{start: i, end: j + 1, value: value})
+			i = j + 1
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// removeTOMLArrayItems cuts the items at the drop indexes out of data. An
+// item alone on its line takes the line with it; otherwise it takes its
+// trailing comma, or for the last item the comma after the previous kept
+// item.
+func removeTOMLArrayItems(data []byte, items []tomlArrayItem, drop []int) []byte {
+	dropped := make(map[int]bool)
+	for _, i := range drop {
+		dropped[i] = true
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, i := range drop {
+		item := items[i]
+		lineStart := bytes.LastIndexByte(data[:item.start], '\n') + 1
+		after := skipTOMLBlank(data, item.end)
+		if after < len(data) && data[after] == ',' {
+			after = skipTOMLBlank(data, after+1)
+		}
+		if after < len(data) && data[after] == '#' {
+			for after < len(data) && data[after] != '\n' {
+				after++
+			}
+		}
+		if len(bytes.TrimSpace(data[lineStart:item.start])) == 0 && (after >= len(data) || data[after] == '\n' || data[after] == '\r') {
+			lineEnd := bytes.IndexByte(data[after:], '\n')
+			if lineEnd < 0 {
+				lineEnd = len(data) - after
+			} else {
+				lineEnd++
+			}
+			spans = append(spans, span{lineStart, after + lineEnd})
+			continue
+		}
+
+		next := skipTOMLBlank(data, item.end)
+		if next < len(data) && data[next] == ',' {
+			spans = append(spans, span{item.start, skipTOMLBlank(data, next+1)})
+			continue
+		}
+		prevEnd := -1
+		for j := i - 1; j >= 0; j-- {
+			if !dropped[j] {
+				prevEnd = items[j].end
+				break
+			}
+		}
+		if prevEnd < 0 {
+			spans = append(spans, span{item.start, item.end})
+		} else {
+			spans = append(spans, span{prevEnd, item.end})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var out []byte
+	pos := 0
+	for _, s := range spans {
+		if s.start > pos {
+			out = append(out, data[pos:s.start]...)
+		}
+		if s.end > pos {
+			pos = s.end
+		}
+	}
+	return append(out, data[pos:]...)
+}
+
+func skipTOMLBlank(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	return i
+}
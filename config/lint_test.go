@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintRules(issues []LintIssue) []string {
+	var rules []string
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule+":"+issue.Key)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+func TestLintTOML(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	existing := t.TempDir()
+	writeConfig(t, filepath.Join(globalDir, "grove.toml"), `version = "1.0"
+
+[groves.work]
+path = "`+existing+`"
+
+[groves.gone]
+path = "`+filepath.Join(existing, "missing")+`"
+
+[groves.off]
+path = "/nonexistent/disabled"
+enabled = false
+
+[logging.groups]
+noisy = ["a", "b"]
+unused = ["c"]
+
+[logging.component_filtering]
+show = ["noisy"]
+hide = [
+  "grove-flow",
+  "noisy", # shown anyway
+  "grove-flow",
+]
+`)
+	writeConfig(t, filepath.Join(projectDir, "grove.toml"), `name = "proj"
+workspaces = ["a/*", "b", "a/*"]
+`)
+
+	issues, err := Lint(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"duplicate-workspace:workspaces",
+		"filter-conflict:logging.component_filtering.hide",
+		"filter-conflict:logging.component_filtering.hide",
+		"unreachable-grove:groves.gone.path",
+		"unused-group:logging.groups.unused",
+	}, lintRules(issues))
+
+	fixed, err := FixLint(issues)
+	require.NoError(t, err)
+	assert.Len(t, fixed, 3)
+
+	global, err := os.ReadFile(filepath.Join(globalDir, "grove.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(global), "hide = [\n  \"grove-flow\",\n]\n")
+	project, err := os.ReadFile(filepath.Join(projectDir, "grove.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(project), `workspaces = ["a/*", "b"]`)
+
+	issues, err = Lint(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"unreachable-grove:groves.gone.path",
+		"unused-group:logging.groups.unused",
+	}, lintRules(issues))
+}
+
+func TestLintFixYAMLSearchPaths(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	existing := t.TempDir()
+	writeConfig(t, filepath.Join(globalDir, "grove.yml"), `version: "1.0"
+# Where my repos live.
+search_paths:
+  work:
+    path: `+existing+`
+    enabled: true
+logging:
+  component_filtering:
+    only: [grove-core]
+    hide: [grove-flow]
+`)
+	writeConfig(t, filepath.Join(projectDir, "grove.yml"), "name: proj\n")
+
+	issues, err := Lint(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"deprecated-key:search_paths",
+		"filter-conflict:logging.component_filtering.only",
+	}, lintRules(issues))
+
+	fixed, err := FixLint(issues)
+	require.NoError(t, err)
+	require.Len(t, fixed, 1)
+	assert.Equal(t, LintDeprecatedKey, fixed[0].Rule)
+
+	data, err := os.ReadFile(filepath.Join(globalDir, "grove.yml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Where my repos live.\ngroves:\n")
+	assert.NotContains(t, string(data), "search_paths")
+
+	cfg, err := LoadFrom(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, existing, cfg.Groves["work"].Path)
+}
+
+func TestLintSearchPathsWithoutEnabledNotFixable(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	ResetLoadCache()
+	writeConfig(t, filepath.Join(globalDir, "grove.toml"), "version = \"1.0\"\n\n[search_paths.work]\npath = \"/tmp\"\n")
+	writeConfig(t, filepath.Join(projectDir, "grove.toml"), "name = \"proj\"\n")
+
+	issues, err := Lint(projectDir)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, LintDeprecatedKey, issues[0].Rule)
+	assert.False(t, issues[0].Fixable)
+}
+
+func TestRemoveTOMLArrayItems(t *testing.T) {
+	cases := []struct {
+		in   string
+		drop []int
+		want string
+	}{
+		{`x = ["a", "b", "b", "b"]`, []int{2, 3}, `x = ["a", "b"]`},
+		{`x = ["a", "b", "c"]`, []int{0}, `x = ["b", "c"]`},
+		{`x = ["a"]`, []int{0}, `x = []`},
+		{"x = [\n  \"a\",\n  \"b\"\n]", []int{1}, "x = [\n  \"a\",\n]"},
+	}
+	for _, tc := range cases {
+		data := []byte(tc.in)
+		items, ok := tomlStringArray(data, 4)
+		require.True(t, ok, tc.in)
+		assert.Equal(t, tc.want, string(removeTOMLArrayItems(data, items, tc.drop)), tc.in)
+	}
+}
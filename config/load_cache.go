@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// LoadFromWithLogger memoizes its result per start directory for the life of
+// the process. An entry stays valid while every file the load read, and
+// every directory that decides which files it reads, is unchanged:
+//
+//   - the start directory and its ancestors, whose mtimes change when a
+//     grove.toml or override file is created, removed or renamed in them;
+//   - the global config directory and its plugins/ and conf.d/
+//     directories, which are globbed for fragments;
+//   - each file read, by mtime and size, and the directory holding it,
+//     including the cached workspaces_from manifests the configs list.
+//
+// Within loadCacheRecheck of the last check an entry is returned without
+// touching the filesystem, which absorbs bursts from 60fps TUI renders and
+// fsnotify batches. After that, revalidating costs a handful of stats
+// instead of a full parse, git invocation and schema validation.
+//
+// Changes the fingerprint cannot see — a notebook config created where none
+// existed, or environment variables referenced via ${VAR} — need
+// ResetLoadCache.
+type loadCacheEntry struct {
+	cfg     *Config
+	stamps  []fileStamp
+	checked time.Time
+}
+
+// loadCacheRecheck is how long an entry is trusted before its fingerprint
+// is re-stat'ed.
+const loadCacheRecheck = 2 * time.Second
+
+var loadCache sync.Map // map[string]*loadCacheEntry, keyed by loadCacheKey
+
+// ResetLoadCache clears the LoadFromWithLogger cache. It is the explicit
+// invalidation hook for changes the file fingerprint cannot observe; tests
+// that rewrite config files within loadCacheRecheck call it between cases.
+func ResetLoadCache() {
+	loadCache.Range(func(key, _ any) bool {
+		loadCache.Delete(key)
+		return true
+	})
+}
+
+// fileStamp records the state of one path at load time.
+type fileStamp struct {
+	path    string
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func stampPath(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{path: path}
+	}
+	return fileStamp{path: path, exists: true, modTime: info.ModTime(), size: info.Size()}
+}
+
+func (s fileStamp) same(o fileStamp) bool {
+	return s.exists == o.exists && s.size == o.size && s.modTime.Equal(o.modTime)
+}
+
+// loadDeps collects the files a load reads. Deprecated keys in the files it
+// parses are reported through logger.
+type loadDeps struct {
+	files  []string
+	logger *logrus.Logger
+}
+
+// read reads path and records it as a dependency of the load.
+func (d *loadDeps) read(path string) ([]byte, error) {
+	d.files = append(d.files, path)
+	return os.ReadFile(path)
+}
+
+// unmarshal parses a config file read by the load. A workspaces_from
+// manifest lists workspaces from its cached copy, so that copy becomes a
+// dependency too: a refresh has to invalidate the entry.
+func (d *loadDeps) unmarshal(path string, data []byte) (*Config, error) {
+	cfg, err := unmarshalConfigWithLogger(path, data, d.logger)
+	if err == nil && cfg.WorkspacesFrom != nil {
+		d.files = append(d.files, manifestCachePath(cfg.WorkspacesFrom))
+	}
+	return cfg, err
+}
+
+// loadCacheKey identifies a load: the absolute start directory plus the
+// inputs outside it that select which files are read.
+func loadCacheKey(startDir string) string {
+	abs, _ := filepath.Abs(startDir)
+	if abs == "" {
+		abs = startDir
+	}
+	return abs + "\x00" + paths.ConfigDir() + "\x00" + os.Getenv("GROVE_CONFIG_OVERLAY")
+}
+
+// fingerprint stamps the paths a load of startDir depends on.
+func (d *loadDeps) fingerprint(startDir string) []fileStamp {
+	seen := make(map[string]bool)
+	var stamps []fileStamp
+	add := func(p string) {
+		if p == "" || seen[p] {
+			return
+		}
+		seen[p] = true
+		stamps = append(stamps, stampPath(p))
+	}
+
+	dir, _ := filepath.Abs(startDir)
+	for dir != "" {
+		add(dir)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	if configDir := paths.ConfigDir(); configDir != "" {
+		add(configDir)
+		add(filepath.Join(configDir, "plugins"))
+		add(filepath.Join(configDir, "conf.d"))
+	}
+	for _, f := range d.files {
+		add(f)
+		add(filepath.Dir(f))
+	}
+	return stamps
+}
+
+// cachedLoad returns the cached config for key if its fingerprint still holds.
+func cachedLoad(key string) (*Config, bool) {
+	raw, ok := loadCache.Load(key)
+	if !ok {
+		return nil, false
+	}
+	entry := raw.(*loadCacheEntry)
+	if time.Since(entry.checked) < loadCacheRecheck {
+		return entry.cfg, true
+	}
+	for _, st := range entry.stamps {
+		if !stampPath(st.path).same(st) {
+			loadCache.CompareAndDelete(key, entry)
+			return nil, false
+		}
+	}
+	// Entries are replaced rather than mutated so concurrent readers never
+	// race on checked.
+	loadCache.CompareAndSwap(key, entry, &loadCacheEntry{cfg: entry.cfg, stamps: entry.stamps, checked: time.Now()})
+	return entry.cfg, true
+}
+
+// storeLoad caches cfg for key along with the fingerprint of its inputs.
+func storeLoad(key, startDir string, cfg *Config, deps *loadDeps) {
+	loadCache.Store(key, &loadCacheEntry{cfg: cfg, stamps: deps.fingerprint(startDir), checked: time.Now()})
+}
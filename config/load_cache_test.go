@@ -0,0 +1,183 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// expireLoadCache makes every cached entry due for revalidation, as if
+// loadCacheRecheck had elapsed.
+func expireLoadCache() {
+	loadCache.Range(func(key, raw any) bool {
+		e := raw.(*loadCacheEntry)
+		loadCache.Store(key, &loadCacheEntry{cfg: e.cfg, stamps: e.stamps, checked: time.Time{}})
+		return true
+	})
+}
+
+func writeConfig(t testing.TB, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadCache_RevalidatesFingerprint(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	writeConfig(t, filepath.Join(globalDir, "grove.toml"), "version = \"1.0\"\n")
+	projectFile := filepath.Join(projectDir, "grove.toml")
+	writeConfig(t, projectFile, "name = \"first\"\n")
+	ResetLoadCache()
+
+	first, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+
+	// Unchanged files: the same config is served after revalidation.
+	expireLoadCache()
+	again, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if again != first {
+		t.Error("unchanged inputs should be served from the cache")
+	}
+
+	// An edited file invalidates the entry.
+	writeConfig(t, projectFile, "name = \"second-edit\"\n")
+	expireLoadCache()
+	edited, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if edited.Name != "second-edit" {
+		t.Errorf("Name = %q after edit, want second-edit", edited.Name)
+	}
+
+	// So does a newly created override file, which the first load never read.
+	writeConfig(t, filepath.Join(projectDir, "grove.override.toml"), "name = \"overridden\"\n")
+	expireLoadCache()
+	overridden, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if overridden.Name != "overridden" {
+		t.Errorf("Name = %q after adding an override, want overridden", overridden.Name)
+	}
+}
+
+func TestLoadCache_RevalidatesManifestCache(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	t.Setenv("GROVE_HOME", t.TempDir())
+	writeConfig(t, filepath.Join(globalDir, "grove.toml"), "version = \"1.0\"\n")
+
+	var body atomic.Value
+	var hits atomic.Int32
+	body.Store(testManifest)
+	srv := manifestServer(t, &body, &hits)
+	writeConfig(t, filepath.Join(projectDir, "grove.yml"), "name: eco\nworkspaces:\n  - tools\nworkspaces_from: "+srv.URL+"/cache.yml\n")
+	ResetLoadCache()
+
+	before, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if got := strings.Join(before.Workspaces, ","); got != "tools" {
+		t.Fatalf("workspaces before refresh = %s", got)
+	}
+
+	// Refreshing writes the cached copy, which the memoized load read (as
+	// missing) and so must pick up.
+	if _, err := RefreshManifest(context.Background(), before.WorkspacesFrom); err != nil {
+		t.Fatal(err)
+	}
+	expireLoadCache()
+	after, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if got := strings.Join(after.Workspaces, ","); got != "tools,api,libs/*" {
+		t.Errorf("workspaces after refresh = %s", got)
+	}
+}
+
+func TestLoadCache_KeyedByConfigDir(t *testing.T) {
+	_, projectDir := setupAuditEnv(t)
+	writeConfig(t, filepath.Join(projectDir, "grove.toml"), "name = \"p\"\n")
+	ResetLoadCache()
+
+	a := loadCacheKey(projectDir)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if loadCacheKey(projectDir) == a {
+		t.Error("changing the global config dir should change the cache key")
+	}
+}
+
+// BenchmarkLoadFrom compares a full load (what every CLI invocation paid
+// per LoadDefault call) with the memoized fast path and a revalidation.
+func BenchmarkLoadFrom(b *testing.B) {
+	globalDir, projectDir := setupAuditEnv(b)
+	writeConfig(b, filepath.Join(globalDir, "grove.toml"), "version = \"1.0\"\n\n[logging]\nlevel = \"info\"\n")
+	writeConfig(b, filepath.Join(projectDir, "grove.toml"), "name = \"bench\"\n")
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ResetLoadCache()
+			if _, err := LoadFrom(projectDir); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		ResetLoadCache()
+		for i := 0; i < b.N; i++ {
+			if _, err := LoadFrom(projectDir); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("revalidated", func(b *testing.B) {
+		ResetLoadCache()
+		for i := 0; i < b.N; i++ {
+			expireLoadCache()
+			if _, err := LoadFrom(projectDir); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestLoadFrom_TimesUncachedLoads(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	writeConfig(t, filepath.Join(globalDir, "grove.toml"), "version = \"1.0\"\n")
+	writeConfig(t, filepath.Join(projectDir, "grove.toml"), "name = \"timed\"\n")
+	ResetLoadCache()
+
+	var ops []string
+	var stopped int
+	SetTimer(func(op string) func(error) {
+		ops = append(ops, op)
+		return func(err error) {
+			if err != nil {
+				t.Errorf("load stopped with error: %v", err)
+			}
+			stopped++
+		}
+	})
+	defer SetTimer(nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := LoadFrom(projectDir); err != nil {
+			t.Fatalf("LoadFrom: %v", err)
+		}
+	}
+	if len(ops) != 1 || ops[0] != "load" || stopped != 1 {
+		t.Errorf("timed ops %v (stopped %d), want one load: cache hits are not timed", ops, stopped)
+	}
+}
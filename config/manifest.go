@@ -0,0 +1,475 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+const (
+	// DefaultManifestPath is the file read from a git workspaces_from
+	// source when no path is given.
+	DefaultManifestPath = "ecosystem.yml"
+	// DefaultManifestTTL is how long a fetched manifest is used before it
+	// is fetched again, when workspaces_from.ttl is unset.
+	DefaultManifestTTL = 24 * time.Hour
+	// maxManifestBytes caps the size of a fetched manifest.
+	maxManifestBytes = 1 << 20
+	// manifestFetchTimeout bounds one fetch, so an unreachable source
+	// delays discovery by at most this much.
+	manifestFetchTimeout = 15 * time.Second
+)
+
+// WorkspacesFromConfig points an ecosystem at a remote manifest listing its
+// member workspaces, so an organization can publish the canonical list once
+// and local ecosystem configs follow it. It is written either as a string,
+// "https://host/ecosystem.yml" or "git+<repository>[#<ref>]", or as an
+// object with the fields below.
+type WorkspacesFromConfig struct {
+	// URL is an http(s) URL of the manifest.
+	URL string `yaml:"url,omitempty" toml:"url,omitempty" json:"url,omitempty"`
+	// Git is a repository holding the manifest, fetched with git.
+	Git string `yaml:"git,omitempty" toml:"git,omitempty" json:"git,omitempty"`
+	// Ref is the branch, tag or commit of Git to read. Default: HEAD.
+	Ref string `yaml:"ref,omitempty" toml:"ref,omitempty" json:"ref,omitempty"`
+	// Path is the manifest's path in Git. Default: ecosystem.yml.
+	Path string `yaml:"path,omitempty" toml:"path,omitempty" json:"path,omitempty"`
+	// SHA256 pins the manifest's content: a fetched manifest with another
+	// checksum is rejected.
+	SHA256 string `yaml:"sha256,omitempty" toml:"sha256,omitempty" json:"sha256,omitempty"`
+	// TTL is how long a fetched manifest is cached (e.g. 1h). Default: 24h.
+	TTL string `yaml:"ttl,omitempty" toml:"ttl,omitempty" json:"ttl,omitempty"`
+}
+
+// UnmarshalText accepts the string form of workspaces_from in every config
+// format; the object form decodes field by field as usual.
+func (w *WorkspacesFromConfig) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	switch {
+	case strings.HasPrefix(s, "git+"):
+		repo, ref, _ := strings.Cut(strings.TrimPrefix(s, "git+"), "#")
+		*w = WorkspacesFromConfig{Git: repo, Ref: ref}
+		if err := w.checkGitArgs(); err != nil {
+			return err
+		}
+	case strings.HasPrefix(s, "https://"), strings.HasPrefix(s, "http://"):
+		*w = WorkspacesFromConfig{URL: s}
+	default:
+		return fmt.Errorf("workspaces_from %q: expected an http(s) URL or git+<repository>[#<ref>]", s)
+	}
+	return nil
+}
+
+// JSONSchema describes both the string and the object form.
+func (WorkspacesFromConfig) JSONSchema() *jsonschema.Schema {
+	props := jsonschema.NewProperties()
+	for _, p := range []struct{ name, desc string }{
+		{"url", "http(s) URL of the manifest"},
+		{"git", "Git repository holding the manifest"},
+		{"ref", "Branch, tag or commit of the repository to read (default: HEAD)"},
+		{"path", "Path of the manifest in the repository (default: ecosystem.yml)"},
+		{"sha256", "Expected SHA-256 of the manifest; a manifest with another checksum is rejected"},
+		{"ttl", "How long a fetched manifest is cached before it is fetched again (default: 24h)"},
+	} {
+		props.Set(p.name, &jsonschema.Schema{Type: "string", Description: p.desc})
+	}
+	return &jsonschema.Schema{
+		Description: "Remote manifest listing member workspaces: an http(s) URL, a git+ repository URL with an optional #ref, or an object",
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{Type: "object", Properties: props, AdditionalProperties: jsonschema.FalseSchema},
+		},
+	}
+}
+
+// Source returns a one-line description of where the manifest comes from.
+func (w *WorkspacesFromConfig) Source() string {
+	if w.URL != "" {
+		return w.URL
+	}
+	ref := w.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return fmt.Sprintf("%s@%s:%s", w.Git, ref, w.manifestPath())
+}
+
+// checkGitArgs rejects a repository or ref that git would parse as an
+// option, such as --upload-pack=<command>.
+func (w *WorkspacesFromConfig) checkGitArgs() error {
+	if strings.HasPrefix(w.Git, "-") {
+		return fmt.Errorf("workspaces_from: git repository %q must not start with '-'", w.Git)
+	}
+	if strings.HasPrefix(w.Ref, "-") {
+		return fmt.Errorf("workspaces_from: git ref %q must not start with '-'", w.Ref)
+	}
+	return nil
+}
+
+func (w *WorkspacesFromConfig) manifestPath() string {
+	if w.Path != "" {
+		return w.Path
+	}
+	return DefaultManifestPath
+}
+
+func (w *WorkspacesFromConfig) ttl() time.Duration {
+	if w.TTL != "" {
+		if d, err := time.ParseDuration(w.TTL); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return DefaultManifestTTL
+}
+
+// EcosystemManifest is the document a workspaces_from source publishes.
+// Workspaces has the meaning of the workspaces key in an ecosystem's
+// grove.yml: directories or glob patterns relative to the ecosystem root.
+type EcosystemManifest struct {
+	Name       string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Workspaces []string `yaml:"workspaces" json:"workspaces"`
+}
+
+// ManifestStatus is a resolved manifest and where it came from.
+type ManifestStatus struct {
+	Source    string            `json:"source"`
+	CachePath string            `json:"cache_path"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	SHA256    string            `json:"sha256"`
+	Pinned    bool              `json:"pinned"`
+	Manifest  EcosystemManifest `json:"manifest"`
+	// Stale is set when the cached copy had expired and fetching a new one
+	// failed with RefreshError; the expired copy is used meanwhile.
+	Stale        bool   `json:"stale,omitempty"`
+	RefreshError string `json:"refresh_error,omitempty"`
+}
+
+// manifestCachePath returns where the manifest of w is cached.
+func manifestCachePath(w *WorkspacesFromConfig) string {
+	sum := sha256.Sum256([]byte(w.Source()))
+	return filepath.Join(paths.CacheDir(), "ecosystem-manifests", hex.EncodeToString(sum[:8])+".yml")
+}
+
+// FetchManifest resolves the manifest w points at. A cached copy younger
+// than the TTL is used as is; otherwise, or when refresh is set, the source
+// is fetched and the cache rewritten. When SHA256 is set, only content with
+// that checksum is accepted, cached or fetched. If a fetch fails and an
+// expired copy is cached, that copy is returned with Stale set.
+func FetchManifest(ctx context.Context, w *WorkspacesFromConfig, refresh bool) (*ManifestStatus, error) {
+	if w == nil || (w.URL == "" && w.Git == "") {
+		return nil, fmt.Errorf("workspaces_from: no url or git repository set")
+	}
+	if err := w.checkGitArgs(); err != nil {
+		return nil, err
+	}
+	status := &ManifestStatus{
+		Source:    w.Source(),
+		CachePath: manifestCachePath(w),
+		Pinned:    w.SHA256 != "",
+	}
+
+	cached, cachedAt, cacheErr := readCachedManifest(status.CachePath, w.SHA256)
+	if cacheErr == nil && !refresh && time.Since(cachedAt) < w.ttl() {
+		if err := status.fill(cached, cachedAt); err != nil {
+			return nil, fmt.Errorf("workspaces_from %s: %w", status.Source, err)
+		}
+		return status, nil
+	}
+
+	data, err := fetchManifestSource(ctx, w)
+	if err == nil {
+		err = checkManifestSum(data, w.SHA256)
+	}
+	if err == nil {
+		if err = status.fill(data, time.Now()); err == nil {
+			if mkErr := os.MkdirAll(filepath.Dir(status.CachePath), 0o755); mkErr == nil {
+				_ = os.WriteFile(status.CachePath, data, 0o644)
+			}
+			return status, nil
+		}
+	}
+	if cacheErr == nil && status.fill(cached, cachedAt) == nil {
+		status.Stale = true
+		status.RefreshError = err.Error()
+		return status, nil
+	}
+	return nil, fmt.Errorf("workspaces_from %s: %w", status.Source, err)
+}
+
+// fill sets the manifest fields of s from data.
+func (s *ManifestStatus) fill(data []byte, fetchedAt time.Time) error {
+	m, err := parseManifest(s.Source, data)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	s.SHA256 = hex.EncodeToString(sum[:])
+	s.FetchedAt = fetchedAt
+	s.Manifest = *m
+	return nil
+}
+
+// readCachedManifest returns the cached manifest and when it was fetched.
+// A copy that does not match pin is treated as absent.
+func readCachedManifest(path, pin string) ([]byte, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if err := checkManifestSum(data, pin); err != nil {
+		return nil, time.Time{}, err
+	}
+	return data, info.ModTime(), nil
+}
+
+func checkManifestSum(data []byte, pin string) error {
+	if pin == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, strings.TrimPrefix(pin, "sha256:")) {
+		return fmt.Errorf("checksum mismatch: got sha256 %s, pinned %s", got, pin)
+	}
+	return nil
+}
+
+// parseManifest decodes the manifest of source, dropping entries that are
+// absolute or leave the ecosystem root.
+func parseManifest(source string, data []byte) (*EcosystemManifest, error) {
+	var m EcosystemManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	kept := m.Workspaces[:0]
+	for _, ws := range m.Workspaces {
+		clean := filepath.Clean(ws)
+		if ws == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			reportSchemaWarning(logrus.StandardLogger(), source, &ManifestWarning{
+				Source: source,
+				Reason: fmt.Sprintf("ignoring workspace %q outside the ecosystem root", ws),
+			})
+			continue
+		}
+		kept = append(kept, ws)
+	}
+	m.Workspaces = kept
+	return &m, nil
+}
+
+func fetchManifestSource(ctx context.Context, w *WorkspacesFromConfig) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, manifestFetchTimeout)
+	defer cancel()
+	if w.URL != "" {
+		return fetchManifestURL(ctx, w.URL)
+	}
+	return fetchManifestGit(ctx, w.Git, w.Ref, w.manifestPath())
+}
+
+func fetchManifestURL(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestBytes {
+		return nil, fmt.Errorf("GET %s: manifest larger than %d bytes", url, maxManifestBytes)
+	}
+	return data, nil
+}
+
+// manifestGitProtocols are the only transports fetchManifestGit lets git
+// use, so a manifest source cannot reach ext:: or other helper transports.
+var manifestGitProtocols = []string{
+	"-c", "protocol.allow=never",
+	"-c", "protocol.https.allow=always",
+	"-c", "protocol.ssh.allow=always",
+	"-c", "protocol.file.allow=always",
+}
+
+// fetchManifestGit reads path at ref of repo through a shallow fetch into
+// a scratch repository, which works for branches, tags and commits alike.
+func fetchManifestGit(ctx context.Context, repo, ref, path string) ([]byte, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	dir, err := os.MkdirTemp("", "grove-manifest-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	git := func(args ...string) ([]byte, error) {
+		var stderr bytes.Buffer
+		gitArgs := append(append([]string{"-C", dir}, manifestGitProtocols...), args...)
+		cmd := exec.CommandContext(ctx, "git", gitArgs...)
+		cmd.Stderr = &stderr
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		return out, nil
+	}
+	if _, err := git("init", "-q", "--bare"); err != nil {
+		return nil, err
+	}
+	if _, err := git("fetch", "-q", "--depth", "1", "--end-of-options", repo, ref); err != nil {
+		return nil, err
+	}
+	data, err := git("show", "FETCH_HEAD:"+filepath.ToSlash(path))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestBytes {
+		return nil, fmt.Errorf("manifest larger than %d bytes", maxManifestBytes)
+	}
+	return data, nil
+}
+
+// RefreshManifest fetches the manifest w points at when its cached copy is
+// missing or older than the TTL, so that configs parsed afterwards list its
+// workspaces; config parsing itself only reads the cache. Workspace
+// discovery calls it for ecosystems that are grove roots in the global
+// config, and `core config manifest --refresh` forces a fetch. After a
+// failed fetch the source is not tried again for manifestRetryAfter, so an
+// unreachable source costs one timeout per interval rather than one per
+// discovery.
+func RefreshManifest(ctx context.Context, w *WorkspacesFromConfig) (*ManifestStatus, error) {
+	key := manifestKey(w)
+	if v, ok := manifestRefreshFailures.Load(key); ok {
+		if failure := v.(manifestFailure); time.Since(failure.at) < manifestRetryAfter {
+			return nil, failure.err
+		}
+	}
+	status, err := FetchManifest(ctx, w, false)
+	switch {
+	case err != nil:
+		manifestRefreshFailures.Store(key, manifestFailure{at: time.Now(), err: err})
+	case status.Stale:
+		manifestRefreshFailures.Store(key, manifestFailure{at: time.Now(), err: fmt.Errorf("workspaces_from %s: %s", status.Source, status.RefreshError)})
+	default:
+		manifestRefreshFailures.Delete(key)
+	}
+	return status, err
+}
+
+// manifestRetryAfter is how long RefreshManifest waits before fetching a
+// source that failed again.
+const manifestRetryAfter = 5 * time.Minute
+
+// manifestRefreshFailures holds the last failed fetch of each source.
+var manifestRefreshFailures sync.Map // manifestKey -> manifestFailure
+
+type manifestFailure struct {
+	at  time.Time
+	err error
+}
+
+func manifestKey(w *WorkspacesFromConfig) string {
+	return w.Source() + "\x00" + w.SHA256
+}
+
+// ManifestWarning reports a workspaces_from manifest that a config load
+// could not use in full. It goes to the schema warning sink, like
+// DeprecatedKeyError, so it is logged once per process.
+type ManifestWarning struct {
+	File   string
+	Source string
+	Reason string
+}
+
+// ManifestWarningMessage is the log message of a ManifestWarning.
+const ManifestWarningMessage = "workspaces_from manifest not fully applied"
+
+func (e *ManifestWarning) Error() string {
+	return fmt.Sprintf("workspaces_from %s: %s", e.Source, e.Reason)
+}
+
+// Fields returns the structured log fields of the warning.
+func (e *ManifestWarning) Fields() logrus.Fields {
+	fields := logrus.Fields{"source": e.Source, "reason": e.Reason}
+	if e.File != "" {
+		fields["config_file"] = e.File
+	}
+	return fields
+}
+
+// applyWorkspacesFrom adds the workspaces of cfg's workspaces_from manifest
+// to its own, keeping local entries first. Only the cached copy is read,
+// expired or not (see RefreshManifest); without one, cfg is left as written.
+func applyWorkspacesFrom(cfg *Config, path string) {
+	if cfg == nil || cfg.WorkspacesFrom == nil {
+		return
+	}
+	w := cfg.WorkspacesFrom
+	data, _, err := readCachedManifest(manifestCachePath(w), w.SHA256)
+	if err != nil {
+		reportSchemaWarning(logrus.StandardLogger(), path, &ManifestWarning{
+			File:   path,
+			Source: w.Source(),
+			Reason: "no cached copy yet; using local workspaces only until discovery or `core config manifest` fetches it",
+		})
+		return
+	}
+	m, err := parseManifest(w.Source(), data)
+	if err != nil {
+		reportSchemaWarning(logrus.StandardLogger(), path, &ManifestWarning{File: path, Source: w.Source(), Reason: err.Error()})
+		return
+	}
+	cfg.Workspaces = mergeWorkspacePatterns(cfg.Workspaces, m.Workspaces)
+}
+
+// IsEcosystem reports whether c is an ecosystem config: one that lists
+// workspaces or takes them from a workspaces_from manifest, which may not
+// be fetched yet.
+func (c *Config) IsEcosystem() bool {
+	return c != nil && (len(c.Workspaces) > 0 || c.WorkspacesFrom != nil)
+}
+
+// mergeWorkspacePatterns returns local followed by the entries of remote
+// it does not already contain.
+func mergeWorkspacePatterns(local, remote []string) []string {
+	seen := make(map[string]bool, len(local))
+	merged := append([]string(nil), local...)
+	for _, ws := range local {
+		seen[filepath.Clean(ws)] = true
+	}
+	for _, ws := range remote {
+		if !seen[filepath.Clean(ws)] {
+			seen[filepath.Clean(ws)] = true
+			merged = append(merged, ws)
+		}
+	}
+	return merged
+}
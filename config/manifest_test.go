@@ -0,0 +1,265 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const testManifest = "workspaces:\n  - api\n  - libs/*\n  - ../outside\n"
+
+func manifestServer(t *testing.T, body *atomic.Value, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		b, _ := body.Load().(string)
+		if b == "" {
+			http.Error(w, "gone", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(b))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWorkspacesFromStringForm(t *testing.T) {
+	var y struct {
+		W *WorkspacesFromConfig `yaml:"w"`
+	}
+	if err := yaml.Unmarshal([]byte("w: git+https://example.com/org/eco.git#v2"), &y); err != nil {
+		t.Fatal(err)
+	}
+	if y.W.Git != "https://example.com/org/eco.git" || y.W.Ref != "v2" || y.W.Source() != "https://example.com/org/eco.git@v2:ecosystem.yml" {
+		t.Errorf("unexpected git form %+v", y.W)
+	}
+
+	var tm struct {
+		W *WorkspacesFromConfig `toml:"w"`
+	}
+	if err := toml.Unmarshal([]byte("w = \"https://example.com/ecosystem.yml\""), &tm); err != nil {
+		t.Fatal(err)
+	}
+	if tm.W.URL != "https://example.com/ecosystem.yml" {
+		t.Errorf("unexpected URL form %+v", tm.W)
+	}
+	if err := toml.Unmarshal([]byte("[w]\nurl = \"https://example.com/e.yml\"\nsha256 = \"abc\""), &tm); err != nil || tm.W.SHA256 != "abc" {
+		t.Errorf("object form: %+v, %v", tm.W, err)
+	}
+
+	if err := yaml.Unmarshal([]byte("w: ./ecosystem.yml"), &y); err == nil {
+		t.Error("expected an error for an unsupported string form")
+	}
+}
+
+func TestFetchManifestCachesAndPins(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	var body atomic.Value
+	var hits atomic.Int32
+	body.Store(testManifest)
+	srv := manifestServer(t, &body, &hits)
+	ctx := context.Background()
+
+	w := &WorkspacesFromConfig{URL: srv.URL + "/ecosystem.yml"}
+	status, err := FetchManifest(ctx, w, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(status.Manifest.Workspaces, ","); got != "api,libs/*" {
+		t.Errorf("workspaces = %s, want the entry outside the root dropped", got)
+	}
+	if _, err := FetchManifest(ctx, w, false); err != nil || hits.Load() != 1 {
+		t.Errorf("expected the cached copy within the TTL, hits=%d err=%v", hits.Load(), err)
+	}
+
+	// A failed refresh falls back to the cached copy.
+	body.Store("")
+	status, err = FetchManifest(ctx, w, true)
+	if err != nil || !status.Stale || status.RefreshError == "" {
+		t.Fatalf("expected a stale copy, got %+v, %v", status, err)
+	}
+
+	// A pin that does not match rejects both the cache and the source.
+	body.Store(testManifest)
+	w.SHA256 = strings.Repeat("0", 64)
+	if _, err := FetchManifest(ctx, w, false); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+	sum := sha256.Sum256([]byte(testManifest))
+	w.SHA256 = hex.EncodeToString(sum[:])
+	if status, err := FetchManifest(ctx, w, false); err != nil || !status.Pinned {
+		t.Errorf("expected the pinned manifest, got %+v, %v", status, err)
+	}
+}
+
+func TestFetchManifestFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GROVE_HOME", t.TempDir())
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(repo, "org"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "org", "eco.yml"), []byte(testManifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"add", "."},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "-m", "manifest"},
+		{"tag", "v1"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	w := &WorkspacesFromConfig{Git: "file://" + repo, Ref: "v1", Path: "org/eco.yml"}
+	status, err := FetchManifest(context.Background(), w, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Manifest.Workspaces) != 2 {
+		t.Errorf("workspaces = %v", status.Manifest.Workspaces)
+	}
+}
+
+func TestApplyWorkspacesFrom(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	resetSchemaWarningsForTest()
+	t.Cleanup(resetSchemaWarningsForTest)
+	var warnings []error
+	SetSchemaWarningSink(func(_ string, err error) { warnings = append(warnings, err) })
+
+	var body atomic.Value
+	var hits atomic.Int32
+	body.Store(testManifest)
+	srv := manifestServer(t, &body, &hits)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "grove.yml")
+	data := "name: eco\nworkspaces:\n  - api\n  - tools\nworkspaces_from:\n  url: " + srv.URL + "/apply.yml\n  ttl: 1h\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loading never fetches: without a cached copy only local workspaces
+	// are listed, with one warning.
+	for i := 0; i < 2; i++ {
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(cfg.Workspaces, ","); got != "api,tools" {
+			t.Errorf("workspaces before refresh = %s", got)
+		}
+	}
+	if hits.Load() != 0 {
+		t.Errorf("config load fetched the manifest %d times", hits.Load())
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one", warnings)
+	}
+
+	cfg, _ := Load(path)
+	for i := 0; i < 2; i++ {
+		if _, err := RefreshManifest(context.Background(), cfg.WorkspacesFrom); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected one fetch within the ttl, got %d", hits.Load())
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cfg.Workspaces, ","); got != "api,tools,libs/*" {
+		t.Errorf("workspaces after refresh = %s", got)
+	}
+	if cfg.WorkspacesFrom.TTL != "1h" {
+		t.Errorf("workspaces_from = %+v", cfg.WorkspacesFrom)
+	}
+	if d := (&WorkspacesFromConfig{TTL: "1h"}).ttl(); d != time.Hour {
+		t.Errorf("ttl = %v", d)
+	}
+}
+
+func TestWorkspacesFromRejectsGitOptions(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	var y struct {
+		W *WorkspacesFromConfig `yaml:"w"`
+	}
+	for _, in := range []string{
+		"w: 'git+--upload-pack=touch /tmp/x#.'",
+		"w: 'git+https://example.com/eco.git#--upload-pack=touch /tmp/x'",
+	} {
+		if err := yaml.Unmarshal([]byte(in), &y); err == nil {
+			t.Errorf("%s: expected an error for an option-like repository or ref", in)
+		}
+	}
+
+	for _, w := range []*WorkspacesFromConfig{
+		{Git: "--upload-pack=touch /tmp/x"},
+		{Git: "https://example.com/eco.git", Ref: "--upload-pack=touch /tmp/x"},
+	} {
+		if _, err := FetchManifest(context.Background(), w, true); err == nil || !strings.Contains(err.Error(), "must not start with '-'") {
+			t.Errorf("FetchManifest(%+v) = %v, want an option rejection", w, err)
+		}
+	}
+}
+
+func TestRefreshManifestBacksOff(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	var body atomic.Value
+	var hits atomic.Int32
+	srv := manifestServer(t, &body, &hits)
+
+	w := &WorkspacesFromConfig{URL: srv.URL + "/down.yml"}
+	for i := 0; i < 2; i++ {
+		if _, err := RefreshManifest(context.Background(), w); err == nil {
+			t.Fatal("expected an error from an unreachable source")
+		}
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected one fetch after a failure, got %d", hits.Load())
+	}
+}
+
+func TestConfigIsEcosystem(t *testing.T) {
+	tests := []struct {
+		cfg  *Config
+		want bool
+	}{
+		{nil, false},
+		{&Config{}, false},
+		{&Config{Workspaces: []string{"api"}}, true},
+		// A manifest that has not been fetched still marks an ecosystem.
+		{&Config{WorkspacesFrom: &WorkspacesFromConfig{URL: "https://example.com/e.yml"}}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.IsEcosystem(); got != tt.want {
+			t.Errorf("IsEcosystem(%+v) = %v, want %v", tt.cfg, got, tt.want)
+		}
+	}
+}
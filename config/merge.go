@@ -73,12 +73,19 @@ func mergeKeybindingSection(base, override KeybindingSectionConfig) KeybindingSe
 // dropping the default `services.clickhouse` block. Without this, deepMergeMaps
 // has no way to express deletion and profiles have to resort to empty-command
 // short-circuit hacks or `$VAR` indirection.
+//
+// Array leaves whole-replace unless src carries a `_merge` annotation or
+// "remove:" elements for them (see merge_strategy.go).
 func deepMergeMaps(dst, src map[string]interface{}) map[string]interface{} {
 	out := make(map[string]interface{})
 	for k, v := range dst {
 		out[k] = v
 	}
+	strategies := arrayMergeStrategies(src)
 	for k, vSrc := range src {
+		if k == mergeStrategyKey {
+			continue
+		}
 		// Delete sentinel: `_delete = true` in src drops the key entirely.
 		if mapSrc, ok := vSrc.(map[string]interface{}); ok {
 			if del, _ := mapSrc["_delete"].(bool); del {
@@ -94,6 +101,10 @@ func deepMergeMaps(dst, src map[string]interface{}) map[string]interface{} {
 				}
 			}
 		}
+		if isRawArray(vSrc) {
+			out[k] = mergeRawArrays(out[k], vSrc, strategies[k], MergeReplace)
+			continue
+		}
 		out[k] = vSrc
 	}
 	return out
@@ -205,12 +216,19 @@ func deepMergeMapsUnionWithInherit(dst, src map[string]interface{}, policy Exten
 // that two array leaves at the same key are UNIONED (order-preserving, deduped)
 // instead of whole-replaced. Nested maps recurse; scalars and other non-array
 // leaves keep highest-wins. The `_delete = true` sentinel is preserved.
+//
+// A `_merge` annotation in src overrides the union for its keys, and
+// "remove:" elements drop inherited entries.
 func deepMergeMapsUnion(dst, src map[string]interface{}) map[string]interface{} {
 	out := make(map[string]interface{})
 	for k, v := range dst {
 		out[k] = v
 	}
+	strategies := arrayMergeStrategies(src)
 	for k, vSrc := range src {
+		if k == mergeStrategyKey {
+			continue
+		}
 		// Delete sentinel: `_delete = true` in src drops the key entirely.
 		if mapSrc, ok := vSrc.(map[string]interface{}); ok {
 			if del, _ := mapSrc["_delete"].(bool); del {
@@ -228,10 +246,14 @@ func deepMergeMapsUnion(dst, src map[string]interface{}) map[string]interface{}
 			}
 			// Both arrays: union.
 			if isRawArray(vDst) && isRawArray(vSrc) {
-				out[k] = unionRawArrays(vDst, vSrc)
+				out[k] = mergeRawArrays(vDst, vSrc, strategies[k], MergeUnique)
 				continue
 			}
 		}
+		if isRawArray(vSrc) {
+			out[k] = mergeRawArrays(nil, vSrc, strategies[k], MergeUnique)
+			continue
+		}
 		// Scalar / non-array leaf / type mismatch: highest-wins.
 		out[k] = vSrc
 	}
@@ -300,7 +322,11 @@ func deepMergeMapsWithProvenance(dst, src map[string]interface{}, sourceLabel, p
 	for k, v := range dst {
 		out[k] = v
 	}
+	strategies := arrayMergeStrategies(src)
 	for k, vSrc := range src {
+		if k == mergeStrategyKey {
+			continue
+		}
 		currentPath := k
 		if prefix != "" {
 			currentPath = prefix + "." + k
@@ -327,7 +353,11 @@ func deepMergeMapsWithProvenance(dst, src map[string]interface{}, sourceLabel, p
 
 		// Scalar, array, or map replacing a non-map (or unset). Prune any
 		// stale provenance under currentPath — we just replaced the subtree.
+		// A merged array is attributed to the layer that last changed it.
 		prunePathAndDescendants(prov, deleted, currentPath)
+		if isRawArray(vSrc) {
+			vSrc = mergeRawArrays(out[k], vSrc, strategies[k], MergeReplace)
+		}
 		out[k] = vSrc
 
 		if mapSrc, ok := vSrc.(map[string]interface{}); ok {
@@ -393,9 +423,21 @@ func mergeConfigs(base, override *Config) *Config {
 	if override.Workspaces != nil {
 		result.Workspaces = override.Workspaces
 	}
+	if override.WorkspacesFrom != nil {
+		result.WorkspacesFrom = override.WorkspacesFrom
+	}
 	if override.BuildAfter != nil {
 		result.BuildAfter = override.BuildAfter
 	}
+	if override.Tags != nil {
+		result.Tags = override.Tags
+	}
+	if override.Owners != nil {
+		result.Owners = override.Owners
+	}
+	if override.Links != nil {
+		result.Links = override.Links
+	}
 	if override.ExplicitProjects != nil {
 		result.ExplicitProjects = override.ExplicitProjects
 	}
@@ -425,6 +467,29 @@ func mergeConfigs(base, override *Config) *Config {
 		}
 	}
 
+	// Merge telemetry opt-in. A later layer can turn it off again.
+	if override.Telemetry != nil {
+		if result.Telemetry == nil {
+			result.Telemetry = &TelemetryConfig{}
+		}
+		if override.Telemetry.Enabled != nil {
+			result.Telemetry.Enabled = override.Telemetry.Enabled
+		}
+	}
+
+	// Merge per-workspace logging overrides, deep-merging each member's
+	// entry so a later layer can adjust a single key.
+	if override.WorkspacesLogging != nil {
+		merged := make(map[string]map[string]interface{}, len(result.WorkspacesLogging)+len(override.WorkspacesLogging))
+		for name, overrides := range result.WorkspacesLogging {
+			merged[name] = overrides
+		}
+		for name, overrides := range override.WorkspacesLogging {
+			merged[name] = deepMergeMaps(merged[name], overrides)
+		}
+		result.WorkspacesLogging = merged
+	}
+
 	// Merge TUI configuration
 	if override.TUI != nil {
 		if result.TUI == nil {
@@ -436,6 +501,9 @@ func mergeConfigs(base, override *Config) *Config {
 		if override.TUI.Theme != "" {
 			result.TUI.Theme = override.TUI.Theme
 		}
+		if override.TUI.ColorVision != "" {
+			result.TUI.ColorVision = override.TUI.ColorVision
+		}
 		if override.TUI.Preset != "" {
 			result.TUI.Preset = override.TUI.Preset
 		}
@@ -451,6 +519,9 @@ func mergeConfigs(base, override *Config) *Config {
 		// Bool fields need explicit clauses or an override layer's value is
 		// silently dropped (a false in an override can never un-set a true
 		// in the base, matching the other or-style bool merges here).
+		if override.TUI.Mouse {
+			result.TUI.Mouse = true
+		}
 		if override.TUI.HideSplashOnStartup {
 			result.TUI.HideSplashOnStartup = true
 		}
@@ -460,6 +531,50 @@ func mergeConfigs(base, override *Config) *Config {
 		if override.TUI.Panels != nil {
 			result.TUI.Panels = override.TUI.Panels
 		}
+		if override.TUI.Logs != nil {
+			if result.TUI.Logs == nil {
+				result.TUI.Logs = &TUILogsConfig{}
+			}
+			if override.TUI.Logs.CopyFormat != "" {
+				result.TUI.Logs.CopyFormat = override.TUI.Logs.CopyFormat
+			}
+			if override.TUI.Logs.PinnedErrors != 0 {
+				result.TUI.Logs.PinnedErrors = override.TUI.Logs.PinnedErrors
+			}
+			if override.TUI.Logs.MaxEntries != 0 {
+				result.TUI.Logs.MaxEntries = override.TUI.Logs.MaxEntries
+			}
+			if override.TUI.Logs.Timestamps != "" {
+				result.TUI.Logs.Timestamps = override.TUI.Logs.Timestamps
+			}
+			if override.TUI.Logs.FollowMode != "" {
+				result.TUI.Logs.FollowMode = override.TUI.Logs.FollowMode
+			}
+			if override.TUI.Logs.FollowResumeAfter != "" {
+				result.TUI.Logs.FollowResumeAfter = override.TUI.Logs.FollowResumeAfter
+			}
+		}
+		if override.TUI.JSONTree != nil && override.TUI.JSONTree.RenderHints != nil {
+			if result.TUI.JSONTree == nil {
+				result.TUI.JSONTree = &TUIJSONTreeConfig{}
+			}
+			if result.TUI.JSONTree.RenderHints == nil {
+				result.TUI.JSONTree.RenderHints = &JSONTreeRenderHints{}
+			}
+			hints, over := result.TUI.JSONTree.RenderHints, override.TUI.JSONTree.RenderHints
+			if over.Enabled != nil {
+				hints.Enabled = over.Enabled
+			}
+			if over.Timestamps != nil {
+				hints.Timestamps = over.Timestamps
+			}
+			if over.Durations != nil {
+				hints.Durations = over.Durations
+			}
+			if over.Bytes != nil {
+				hints.Bytes = over.Bytes
+			}
+		}
 
 		// Merge Focus config
 		if override.TUI.Focus != nil {
@@ -572,6 +687,9 @@ func mergeConfigs(base, override *Config) *Config {
 						if v.RecipesPathTemplate != "" {
 							merged.RecipesPathTemplate = v.RecipesPathTemplate
 						}
+						if v.Encryption != nil {
+							merged.Encryption = v.Encryption
+						}
 						if v.Types != nil {
 							if merged.Types == nil {
 								merged.Types = make(map[string]*NoteTypeConfig)
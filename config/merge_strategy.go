@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Array merge strategies. By default an array set in a later layer replaces
+// the inherited one wholesale. A table can name a different strategy for
+// any of its array keys with a `_merge` annotation, the array counterpart
+// of the `_delete = true` sentinel:
+//
+//	[logging.component_filtering]
+//	hide = ["my-noisy-tool", "remove:grove-flow"]
+//	_merge = { hide = "unique" }
+//
+// Elements prefixed with "remove:" drop a matching inherited element. A list
+// with removals and no annotation appends its remaining elements, since
+// removing from a list that is replaced anyway would be a no-op.
+//
+// Annotations apply to the layer that declares them. They work in every
+// raw-map merge — extension blocks such as logging, and environment config —
+// and are stripped from the loaded Extensions and from resolved
+// environments. The typed core fields (workspaces, build_after, context
+// lists, …) keep whole-replace semantics.
+const (
+	// MergeReplace replaces the inherited array (the default).
+	MergeReplace = "replace"
+	// MergeAppend appends this layer's elements to the inherited array.
+	MergeAppend = "append"
+	// MergeUnique appends only elements not already present, keeping the
+	// first occurrence of each.
+	MergeUnique = "unique"
+)
+
+// mergeStrategyKey is the annotation table naming per-key array strategies.
+const mergeStrategyKey = "_merge"
+
+// mergeRemovePrefix marks an array element that removes an inherited one.
+const mergeRemovePrefix = "remove:"
+
+// arrayMergeStrategies reads the `_merge` annotation table of a source map.
+// Unknown strategy names are ignored, so the key falls back to its default.
+func arrayMergeStrategies(src map[string]interface{}) map[string]string {
+	raw, ok := src[mergeStrategyKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, _ := v.(string)
+		switch s {
+		case MergeReplace, MergeAppend, MergeUnique:
+			out[k] = s
+		}
+	}
+	return out
+}
+
+// rawArrayElems returns the elements of a raw config array leaf.
+func rawArrayElems(v interface{}) []interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		return t
+	case []string:
+		out := make([]interface{}, len(t))
+		for i, s := range t {
+			out[i] = s
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// splitRemovals separates "remove:" elements from the elements to add.
+func splitRemovals(elems []interface{}) (adds []interface{}, removals map[string]bool) {
+	for _, e := range elems {
+		if s, ok := e.(string); ok && strings.HasPrefix(s, mergeRemovePrefix) {
+			if removals == nil {
+				removals = make(map[string]bool)
+			}
+			removals[strings.TrimPrefix(s, mergeRemovePrefix)] = true
+			continue
+		}
+		adds = append(adds, e)
+	}
+	return adds, removals
+}
+
+// mergeRawArrays merges the src array leaf over dst using strategy. An empty
+// strategy means fallback, which is MergeReplace for plain merges and
+// MergeUnique for accumulating extension policies; either way a list
+// carrying removals defaults to MergeAppend. dst may be nil (unset). src is
+// returned untouched when it replaces dst and has no removals, so existing
+// whole-replace behavior (and element types) are unchanged.
+func mergeRawArrays(dst, src interface{}, strategy, fallback string) interface{} {
+	srcElems := rawArrayElems(src)
+	adds, removals := splitRemovals(srcElems)
+	if strategy == "" {
+		strategy = fallback
+		if removals != nil && strategy == MergeReplace {
+			strategy = MergeAppend
+		}
+	}
+	if strategy == MergeReplace {
+		if removals == nil {
+			return src
+		}
+		return nonNil(adds)
+	}
+
+	var kept []interface{}
+	for _, e := range rawArrayElems(dst) {
+		if !removals[fmt.Sprintf("%v", e)] {
+			kept = append(kept, e)
+		}
+	}
+	if strategy == MergeUnique {
+		return unionRawArrays(kept, adds)
+	}
+	return nonNil(append(kept, adds...))
+}
+
+func nonNil(s []interface{}) []interface{} {
+	if s == nil {
+		return []interface{}{}
+	}
+	return s
+}
+
+// stripMergeAnnotations returns a copy of m without `_merge` tables or
+// "remove:" elements. Maps that need no change are shared, not copied.
+func stripMergeAnnotations(m map[string]interface{}) map[string]interface{} {
+	if m == nil || !hasMergeAnnotations(m) {
+		return m
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k == mergeStrategyKey {
+			continue
+		}
+		out[k] = stripMergeValue(v)
+	}
+	return out
+}
+
+func stripMergeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return stripMergeAnnotations(t)
+	case []interface{}, []string:
+		if adds, removals := splitRemovals(rawArrayElems(t)); removals != nil {
+			return nonNil(adds)
+		}
+	}
+	return v
+}
+
+func hasMergeAnnotations(v interface{}) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if _, ok := t[mergeStrategyKey]; ok {
+			return true
+		}
+		for _, val := range t {
+			if hasMergeAnnotations(val) {
+				return true
+			}
+		}
+	case []interface{}, []string:
+		_, removals := splitRemovals(rawArrayElems(t))
+		return removals != nil
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeepMergeMaps_ArrayStrategies(t *testing.T) {
+	base := map[string]interface{}{
+		"hide": []interface{}{"a", "b"},
+	}
+	tests := []struct {
+		name string
+		src  map[string]interface{}
+		want []interface{}
+	}{
+		{
+			name: "default replaces",
+			src:  map[string]interface{}{"hide": []interface{}{"c"}},
+			want: []interface{}{"c"},
+		},
+		{
+			name: "append",
+			src: map[string]interface{}{
+				"hide":           []interface{}{"b", "c"},
+				mergeStrategyKey: map[string]interface{}{"hide": MergeAppend},
+			},
+			want: []interface{}{"a", "b", "b", "c"},
+		},
+		{
+			name: "unique",
+			src: map[string]interface{}{
+				"hide":           []interface{}{"b", "c"},
+				mergeStrategyKey: map[string]interface{}{"hide": MergeUnique},
+			},
+			want: []interface{}{"a", "b", "c"},
+		},
+		{
+			name: "removals without annotation append",
+			src:  map[string]interface{}{"hide": []interface{}{"remove:a", "c"}},
+			want: []interface{}{"b", "c"},
+		},
+		{
+			name: "explicit replace ignores removals",
+			src: map[string]interface{}{
+				"hide":           []interface{}{"remove:a", "c"},
+				mergeStrategyKey: map[string]interface{}{"hide": MergeReplace},
+			},
+			want: []interface{}{"c"},
+		},
+		{
+			name: "unknown strategy falls back to replace",
+			src: map[string]interface{}{
+				"hide":           []interface{}{"c"},
+				mergeStrategyKey: map[string]interface{}{"hide": "merge-please"},
+			},
+			want: []interface{}{"c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := deepMergeMaps(base, tt.src)
+			if !reflect.DeepEqual(got["hide"], tt.want) {
+				t.Errorf("hide = %#v, want %#v", got["hide"], tt.want)
+			}
+			if _, ok := got[mergeStrategyKey]; ok {
+				t.Error("the _merge annotation leaked into the merged map")
+			}
+		})
+	}
+}
+
+// TestDeepMergeMapsUnion_StrategyOverride checks that accumulating extension
+// policies honor annotations: replace opts a key out of the union and
+// removals drop accumulated entries.
+func TestDeepMergeMapsUnion_StrategyOverride(t *testing.T) {
+	dst := map[string]interface{}{
+		"allow": []interface{}{"x", "y"},
+		"deny":  []interface{}{"p"},
+	}
+	src := map[string]interface{}{
+		"allow":          []interface{}{"remove:x", "z"},
+		"deny":           []interface{}{"q"},
+		mergeStrategyKey: map[string]interface{}{"deny": MergeReplace},
+	}
+	got := deepMergeMapsUnion(dst, src)
+	if want := []interface{}{"y", "z"}; !reflect.DeepEqual(got["allow"], want) {
+		t.Errorf("allow = %#v, want %#v", got["allow"], want)
+	}
+	if want := []interface{}{"q"}; !reflect.DeepEqual(got["deny"], want) {
+		t.Errorf("deny = %#v, want %#v", got["deny"], want)
+	}
+}
+
+func TestStripMergeAnnotations(t *testing.T) {
+	shared := map[string]interface{}{"level": "info"}
+	in := map[string]interface{}{
+		"logging": map[string]interface{}{
+			"component_filtering": map[string]interface{}{
+				"hide":           []interface{}{"remove:a", "b"},
+				mergeStrategyKey: map[string]interface{}{"hide": MergeUnique},
+			},
+		},
+		"other": shared,
+	}
+	out := stripMergeAnnotations(in)
+
+	cf := out["logging"].(map[string]interface{})["component_filtering"].(map[string]interface{})
+	if _, ok := cf[mergeStrategyKey]; ok {
+		t.Error("_merge was not stripped")
+	}
+	if want := []interface{}{"b"}; !reflect.DeepEqual(cf["hide"], want) {
+		t.Errorf("hide = %#v, want %#v", cf["hide"], want)
+	}
+	// The input is not mutated, and untouched subtrees are shared.
+	orig := in["logging"].(map[string]interface{})["component_filtering"].(map[string]interface{})
+	if _, ok := orig[mergeStrategyKey]; !ok {
+		t.Error("stripMergeAnnotations mutated its input")
+	}
+	if reflect.ValueOf(out["other"]).Pointer() != reflect.ValueOf(shared).Pointer() {
+		t.Error("unchanged subtree was copied")
+	}
+}
+
+// TestLoadFrom_ProjectExtendsEcosystemHideList is the motivating case: a
+// project adds to the hide list inherited from the global config instead of
+// overwriting it, and un-hides one inherited component.
+func TestLoadFrom_ProjectExtendsEcosystemHideList(t *testing.T) {
+	globalDir, projectDir := setupAuditEnv(t)
+	global := "[logging.component_filtering]\nhide = [\"grove-flow\", \"grove-hooks\"]\n"
+	if err := os.WriteFile(filepath.Join(globalDir, "grove.toml"), []byte(global), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := "name = \"p\"\n\n[logging.component_filtering]\nhide = [\"my-noisy-tool\", \"remove:grove-hooks\"]\n_merge = { hide = \"unique\" }\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "grove.toml"), []byte(project), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ResetLoadCache()
+
+	cfg, err := LoadFrom(projectDir)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	logging, _ := cfg.Extensions["logging"].(map[string]interface{})
+	cf, _ := logging["component_filtering"].(map[string]interface{})
+	if want := []interface{}{"grove-flow", "my-noisy-tool"}; !reflect.DeepEqual(cf["hide"], want) {
+		t.Errorf("hide = %#v, want %#v", cf["hide"], want)
+	}
+	if _, ok := cf[mergeStrategyKey]; ok {
+		t.Error("_merge annotation survived into the loaded config")
+	}
+}
@@ -1267,3 +1267,91 @@ func TestMergeConfigs_TUIVimNavAndFocusThickness(t *testing.T) {
 		t.Errorf("theme = %q, want dark", merged.TUI.Theme)
 	}
 }
+
+// TestMergeConfigs_TUILogsCopyFormat verifies tui.logs.copy_format is taken
+// from the override layer and kept from the base when the override omits it.
+func TestMergeConfigs_TUILogsCopyFormat(t *testing.T) {
+	base := &Config{TUI: &TUIConfig{Logs: &TUILogsConfig{CopyFormat: "jsonl"}}}
+
+	merged := mergeConfigs(base, &Config{TUI: &TUIConfig{Theme: "dark"}})
+	if merged.TUI.Logs == nil || merged.TUI.Logs.CopyFormat != "jsonl" {
+		t.Errorf("copy_format = %+v, want base value jsonl", merged.TUI.Logs)
+	}
+
+	merged = mergeConfigs(base, &Config{TUI: &TUIConfig{Logs: &TUILogsConfig{CopyFormat: "jq", PinnedErrors: 8}}})
+	if merged.TUI.Logs.CopyFormat != "jq" {
+		t.Errorf("copy_format = %q, want jq", merged.TUI.Logs.CopyFormat)
+	}
+	if merged.TUI.Logs.PinnedErrors != 8 {
+		t.Errorf("pinned_errors = %d, want 8", merged.TUI.Logs.PinnedErrors)
+	}
+}
+
+// TestEcosystemWorkspacesLogging checks that an ecosystem's
+// workspaces_logging entry for a member is merged over the member's own
+// logging settings, and leaves other members alone.
+func TestEcosystemWorkspacesLogging(t *testing.T) {
+	setupAuditEnv(t)
+	ecosystemDir := t.TempDir()
+	ecosystemConfig := `
+version: "1.0"
+workspaces:
+  - "*"
+workspaces_logging:
+  api:
+    level: debug
+    file:
+      format: json
+`
+	if err := os.WriteFile(filepath.Join(ecosystemDir, "grove.yml"), []byte(ecosystemConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	members := map[string]string{
+		"api": "name: api\nlogging:\n  level: info\n  file:\n    enabled: true\n",
+		"web": "name: web\nlogging:\n  level: info\n",
+	}
+	for name, content := range members {
+		dir := filepath.Join(ecosystemDir, name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "grove.yml"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ResetLoadCache()
+
+	loggingOf := func(cfg *Config) map[string]interface{} {
+		logging, _ := cfg.Extensions["logging"].(map[string]interface{})
+		return logging
+	}
+
+	api, err := LoadFrom(filepath.Join(ecosystemDir, "api"))
+	if err != nil {
+		t.Fatalf("LoadFrom(api): %v", err)
+	}
+	logging := loggingOf(api)
+	if logging["level"] != "debug" {
+		t.Errorf("api level = %v, want debug", logging["level"])
+	}
+	file, _ := logging["file"].(map[string]interface{})
+	if file["enabled"] != true || file["format"] != "json" {
+		t.Errorf("api file = %v, want enabled from the project and format from the ecosystem", file)
+	}
+
+	web, err := LoadFrom(filepath.Join(ecosystemDir, "web"))
+	if err != nil {
+		t.Fatalf("LoadFrom(web): %v", err)
+	}
+	if level := loggingOf(web)["level"]; level != "info" {
+		t.Errorf("web level = %v, want info", level)
+	}
+
+	layered, err := LoadLayered(filepath.Join(ecosystemDir, "api"))
+	if err != nil {
+		t.Fatalf("LoadLayered(api): %v", err)
+	}
+	if level := loggingOf(layered.Final)["level"]; level != "debug" {
+		t.Errorf("layered api level = %v, want debug", level)
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grovetools/core/internal/shell"
+)
+
+// NotebookKeyEnvVar is the environment variable consulted first when
+// resolving a notebook encryption key (env > key_command > key_file).
+const NotebookKeyEnvVar = "GROVE_NOTEBOOK_KEY"
+
+// ResolveKey returns the notebook key material. Resolution order is:
+//
+//  1. GROVE_NOTEBOOK_KEY environment variable
+//  2. key_command output (trimmed)
+//  3. key_file contents (trimmed)
+//
+// Returns an empty string with no error when no source is configured, so
+// callers can report the notebook as locked.
+func (e *NotebookEncryptionConfig) ResolveKey() (string, error) {
+	if key := os.Getenv(NotebookKeyEnvVar); key != "" {
+		return key, nil
+	}
+
+	if e.KeyCommand != "" {
+		cmd := shell.Command(context.Background(), e.KeyCommand)
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to execute notebook key_command %q: %w", e.KeyCommand, err)
+		}
+		key := strings.TrimSpace(string(output))
+		if key == "" {
+			return "", fmt.Errorf("notebook key_command %q returned empty output", e.KeyCommand)
+		}
+		return key, nil
+	}
+
+	if e.KeyFile != "" {
+		data, err := os.ReadFile(expandPath(e.KeyFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to read notebook key_file: %w", err)
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return "", fmt.Errorf("notebook key_file %s is empty", e.KeyFile)
+		}
+		return key, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceCovered reports whether rel, a slash-separated path relative to
+// the ecosystem root, is already matched by one of its workspaces patterns.
+func WorkspaceCovered(patterns []string, rel string) bool {
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	for _, p := range patterns {
+		p = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(p)), "/")
+		if p == rel {
+			return true
+		}
+		if ok, err := filepath.Match(p, rel); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterWorkspace adds dir to the workspaces list of the ecosystem config
+// at configPath, as a path relative to the ecosystem root. It returns false
+// without touching the file when an existing pattern already covers dir.
+// The file is edited in place so its comments are kept; a JSON5 config is
+// only checked, and an uncovered dir is reported as an error to add by hand.
+func RegisterWorkspace(configPath, dir string) (bool, error) {
+	rel, err := filepath.Rel(filepath.Dir(configPath), dir)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve %s relative to %s: %w", dir, filepath.Dir(configPath), err)
+	}
+	if rel == "." || strings.HasPrefix(rel, "..") {
+		return false, fmt.Errorf("%s is not inside the ecosystem at %s", dir, filepath.Dir(configPath))
+	}
+	rel = filepath.ToSlash(rel)
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		patterns []string
+		updated  []byte
+	)
+	if strings.HasSuffix(configPath, ".json5") {
+		cfg, err := unmarshalConfig(configPath, data)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		if WorkspaceCovered(cfg.Workspaces, rel) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s is JSON5 and is not edited automatically; add %q to its workspaces", configPath, rel)
+	}
+	if strings.HasSuffix(configPath, ".toml") {
+		var cfg struct {
+			Workspaces []string `toml:"workspaces"`
+		}
+		if err := toml.Unmarshal(data, &cfg); err != nil {
+			return false, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		patterns = cfg.Workspaces
+		if WorkspaceCovered(patterns, rel) {
+			return false, nil
+		}
+		updated, err = appendTOMLWorkspace(data, rel)
+	} else {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return false, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		seq, serr := yamlWorkspacesNode(&doc)
+		if serr != nil {
+			return false, fmt.Errorf("%s: %w", configPath, serr)
+		}
+		for _, item := range seq.Content {
+			patterns = append(patterns, item.Value)
+		}
+		if WorkspaceCovered(patterns, rel) {
+			return false, nil
+		}
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: rel})
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err = enc.Encode(&doc); err == nil {
+			err = enc.Close()
+		}
+		updated = buf.Bytes()
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update %s: %w", configPath, err)
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(configPath, updated, info.Mode().Perm()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// yamlWorkspacesNode returns the sequence node of the top-level workspaces
+// key, creating it when absent.
+func yamlWorkspacesNode(doc *yaml.Node) (*yaml.Node, error) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level is not a mapping")
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "workspaces" {
+			continue
+		}
+		seq := root.Content[i+1]
+		if seq.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("workspaces is not a list")
+		}
+		return seq, nil
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	root.Content = append(root.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "workspaces"},
+		seq,
+	)
+	return seq, nil
+}
+
+var (
+	tomlWorkspacesRe = regexp.MustCompile(`(?m)^workspaces\s*=\s*\[`)
+	tomlTableRe      = regexp.MustCompile(`(?m)^\[`)
+)
+
+// appendTOMLWorkspace adds rel to the top-level workspaces array of a TOML
+// document by editing its text, keeping comments that a decode/encode round
+// trip would drop. A document without the key gets one before its first
+// table.
+func appendTOMLWorkspace(data []byte, rel string) ([]byte, error) {
+	entry := strconv.Quote(rel)
+	loc := tomlWorkspacesRe.FindIndex(data)
+	if loc == nil {
+		line := []byte("workspaces = [" + entry + "]\n")
+		if table := tomlTableRe.FindIndex(data); table != nil {
+			return concat(data[:table[0]], line, []byte("\n"), data[table[0]:]), nil
+		}
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			line = append([]byte("\n"), line...)
+		}
+		return concat(data, line), nil
+	}
+
+	// Find the closing bracket, skipping strings and comments.
+	end := -1
+	inString := byte(0)
+	for i := loc[1]; i < len(data) && end == -1; i++ {
+		c := data[i]
+		switch {
+		case inString != 0:
+			if c == '\\' && inString == '"' {
+				i++
+			} else if c == inString {
+				inString = 0
+			}
+		case c == '"' || c == '\'':
+			inString = c
+		case c == '#':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case c == ']':
+			end = i
+		}
+	}
+	if end == -1 {
+		return nil, fmt.Errorf("unterminated workspaces array")
+	}
+
+	body := strings.TrimRight(string(data[loc[1]:end]), " \t\r\n")
+	sep := ", "
+	switch {
+	case strings.TrimSpace(body) == "":
+		sep = ""
+		body = ""
+	case strings.HasSuffix(body, ","):
+		sep = " "
+	}
+	if strings.Contains(string(data[loc[1]:end]), "\n") {
+		// Multi-line array: one entry per line, matching the last entry's
+		// indentation.
+		indent := "  "
+		if nl := strings.LastIndex(body, "\n"); nl != -1 {
+			line := body[nl+1:]
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+		if !strings.HasSuffix(body, ",") && body != "" {
+			body += ","
+		}
+		return concat(data[:loc[1]], []byte(body+"\n"+indent+entry+",\n"), data[end:]), nil
+	}
+	return concat(data[:loc[1]], []byte(body+sep+entry), data[end:]), nil
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
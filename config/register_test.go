@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceCovered(t *testing.T) {
+	patterns := []string{"libs/*", "./tools/cli", "apps/web/"}
+	assert.True(t, WorkspaceCovered(patterns, "libs/auth"))
+	assert.True(t, WorkspaceCovered(patterns, "tools/cli"))
+	assert.True(t, WorkspaceCovered(patterns, "apps/web"))
+	assert.False(t, WorkspaceCovered(patterns, "libs/auth/sub"))
+	assert.False(t, WorkspaceCovered(patterns, "api"))
+}
+
+func TestRegisterWorkspaceYAML(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "grove.yml")
+	require.NoError(t, os.WriteFile(path, []byte("name: eco\n# member projects\nworkspaces:\n  - libs/*\n"), 0o644))
+
+	added, err := RegisterWorkspace(path, filepath.Join(root, "api"))
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = RegisterWorkspace(path, filepath.Join(root, "libs", "auth"))
+	require.NoError(t, err)
+	assert.False(t, added, "libs/* already covers libs/auth")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "name: eco\n# member projects\nworkspaces:\n  - libs/*\n  - api\n", string(data))
+
+	_, err = RegisterWorkspace(path, t.TempDir())
+	assert.Error(t, err, "directories outside the ecosystem are rejected")
+}
+
+func TestRegisterWorkspaceTOML(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			name: "inline",
+			in:   "name = \"eco\"\nworkspaces = [\"libs/*\"] # members\n",
+			want: "name = \"eco\"\nworkspaces = [\"libs/*\", \"api\"] # members\n",
+		},
+		{
+			name: "empty",
+			in:   "workspaces = []\n",
+			want: "workspaces = [\"api\"]\n",
+		},
+		{
+			name: "multi-line",
+			in:   "workspaces = [\n    \"libs/*\", # shared\n    \"tools/]x\"\n]\n",
+			want: "workspaces = [\n    \"libs/*\", # shared\n    \"tools/]x\",\n    \"api\",\n]\n",
+		},
+		{
+			name: "missing",
+			in:   "name = \"eco\"\n\n[tui]\ntheme = \"kanagawa\"\n",
+			want: "name = \"eco\"\n\nworkspaces = [\"api\"]\n\n[tui]\ntheme = \"kanagawa\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			path := filepath.Join(root, "grove.toml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.in), 0o644))
+
+			added, err := RegisterWorkspace(path, filepath.Join(root, "api"))
+			require.NoError(t, err)
+			assert.True(t, added)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+
+			cfg, err := LoadFromTOMLBytes(data)
+			require.NoError(t, err)
+			assert.Contains(t, cfg.Workspaces, "api")
+		})
+	}
+}
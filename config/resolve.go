@@ -34,6 +34,7 @@ func ResolveEnvironment(cfg *Config, profileName string) (*EnvironmentConfig, er
 
 	// If no profile requested, return the default
 	if profileName == "" {
+		resolved.Config = stripMergeAnnotations(resolved.Config)
 		return resolved, nil
 	}
 
@@ -55,6 +56,7 @@ func ResolveEnvironment(cfg *Config, profileName string) (*EnvironmentConfig, er
 	for k, v := range namedEnv.Commands {
 		resolved.Commands[k] = v
 	}
+	resolved.Config = stripMergeAnnotations(resolved.Config)
 
 	return resolved, nil
 }
@@ -66,8 +68,8 @@ func ResolveEnvironment(cfg *Config, profileName string) (*EnvironmentConfig, er
 // Provenance keys are dotted paths:
 //   - "provider", "command" — peer scalars on EnvironmentConfig
 //   - "commands.<name>" — entries in the commands map
-//   - "config.<...>" — entries in the nested Config map (leaves only; arrays
-//     are treated as scalars because deepMergeMaps replaces them wholesale)
+//   - "config.<...>" — entries in the nested Config map (leaves only; an
+//     array is attributed to the last layer that set or merged into it)
 //
 // Values look like `<layer> (<block>)`, e.g. `"project (environments.hybrid-api)"`,
 // so a reader can pinpoint the exact config block that produced a setting.
@@ -124,6 +126,9 @@ func ResolveEnvironmentWithProvenance(layered *LayeredConfig, profileName string
 	for _, o := range layered.Overrides {
 		layers = append(layers, layerEntry{o.Config, fmt.Sprintf("%s (%s)", SourceOverride, filepath.Base(o.Path))})
 	}
+	if layered.CommandLine != nil {
+		layers = append(layers, layerEntry{layered.CommandLine, string(SourceCommandLine)})
+	}
 
 	// Step A: base default env from all layers in order.
 	for _, layer := range layers {
@@ -134,6 +139,7 @@ func ResolveEnvironmentWithProvenance(layered *LayeredConfig, profileName string
 	}
 
 	if profileName == "" {
+		resolved.Config = stripMergeAnnotations(resolved.Config)
 		return resolved, prov, deleted, nil
 	}
 
@@ -153,6 +159,7 @@ func ResolveEnvironmentWithProvenance(layered *LayeredConfig, profileName string
 	if !found {
 		return nil, nil, nil, fmt.Errorf("environment profile %q not found", profileName)
 	}
+	resolved.Config = stripMergeAnnotations(resolved.Config)
 
 	return resolved, prov, deleted, nil
 }
@@ -49,19 +49,29 @@ func GenerateSchemaWithThemeNames(themeNames []string) ([]byte, error) {
 
 	// FileSinkSchemaConfig mirrors logging.FileSinkConfig.
 	type FileSinkSchemaConfig struct {
-		Enabled       bool   `yaml:"enabled,omitempty" jsonschema:"description=Enable file logging,default=true"`
+		Enabled       bool   `yaml:"enabled,omitempty" jsonschema:"description=Enable file logging" default:"true"`
 		Path          string `yaml:"path,omitempty" jsonschema:"description=Full path to the log file"`
-		Format        string `yaml:"format,omitempty" jsonschema:"description=File log format: text or json,default=json,enum=text,enum=json"`
-		Level         string `yaml:"level,omitempty" jsonschema:"description=Minimum log level for the file sink only (defaults to the console level; GROVE_LOG_LEVEL overrides both),enum=debug,enum=info,enum=warn,enum=error"`
-		RetentionDays int    `yaml:"retention_days,omitempty" jsonschema:"description=Days of dated log files to keep before the daemon sweeps them (0 = default of 14),default=14"`
+		Dir           string `yaml:"dir,omitempty" jsonschema:"description=Directory for workspace log files instead of the state directory (relative to the project root; namespaced per project)"`
+		Format        string `yaml:"format,omitempty" jsonschema:"description=File log format: text or json,enum=text,enum=json" default:"json"`
+		Level         string `yaml:"level,omitempty" jsonschema:"description=Minimum log level for the file sink only (defaults to level; GROVE_LOG_LEVEL overrides both),enum=trace,enum=debug,enum=info,enum=warn,enum=error"`
+		RetentionDays int    `yaml:"retention_days,omitempty" jsonschema:"description=Days of dated log files to keep before the daemon sweeps them (0 = default of 14)" default:"14"`
+		Async         bool   `yaml:"async,omitempty" jsonschema:"description=Write file logs from a background goroutine through a bounded queue" default:"false"`
+		QueueSize     int    `yaml:"queue_size,omitempty" jsonschema:"description=Entries buffered by the async file sink (0 = default of 1024)" default:"1024"`
+		Overflow      string `yaml:"overflow,omitempty" jsonschema:"description=Async queue overflow policy: block (wait) or drop (discard new entries),enum=block,enum=drop" default:"block"`
+	}
+
+	// ConsoleSinkSchemaConfig mirrors logging.ConsoleSinkConfig.
+	type ConsoleSinkSchemaConfig struct {
+		Level  string `yaml:"level,omitempty" jsonschema:"description=Minimum log level for the console sink only (defaults to level; GROVE_LOG_LEVEL overrides both),enum=trace,enum=debug,enum=info,enum=warn,enum=error"`
+		Format string `yaml:"format,omitempty" jsonschema:"description=Console log format: text/simple/json (defaults to format.preset),enum=text,enum=simple,enum=json"`
 	}
 
 	// FormatSchemaConfig mirrors logging.FormatConfig.
 	type FormatSchemaConfig struct {
 		Preset             string `yaml:"preset,omitempty" jsonschema:"description=Log format preset: default (rich)/simple/json,enum=default,enum=simple,enum=json"`
-		DisableTimestamp   bool   `yaml:"disable_timestamp,omitempty" jsonschema:"description=Disable timestamp in log output,default=false"`
-		DisableComponent   bool   `yaml:"disable_component,omitempty" jsonschema:"description=Disable component name in log output,default=false"`
-		StructuredToStderr string `yaml:"structured_to_stderr,omitempty" jsonschema:"description=When to send structured logs to stderr,enum=auto,enum=always,enum=never,default=auto"`
+		DisableTimestamp   bool   `yaml:"disable_timestamp,omitempty" jsonschema:"description=Disable timestamp in log output" default:"false"`
+		DisableComponent   bool   `yaml:"disable_component,omitempty" jsonschema:"description=Disable component name in log output" default:"false"`
+		StructuredToStderr string `yaml:"structured_to_stderr,omitempty" jsonschema:"description=When to send structured logs to stderr,enum=auto,enum=always,enum=never" default:"auto"`
 	}
 
 	// ComponentFilteringSchemaConfig mirrors logging.ComponentFilteringConfig.
@@ -71,40 +81,72 @@ func GenerateSchemaWithThemeNames(themeNames []string) ([]byte, error) {
 		Hide []string `yaml:"hide,omitempty" jsonschema:"description=Components/groups to hide from log output"`
 	}
 
+	// EscalationMatchSchemaConfig mirrors logging.EscalationMatch.
+	type EscalationMatchSchemaConfig struct {
+		Component string `yaml:"component,omitempty" jsonschema:"description=Component name or group the entry must come from"`
+		Level     string `yaml:"level,omitempty" jsonschema:"description=Only entries logged at this level,enum=trace,enum=debug,enum=info,enum=warn"`
+		MsgRegex  string `yaml:"msg_regex,omitempty" jsonschema:"description=Regular expression the message must match (searched anywhere in the message)"`
+	}
+
+	// EscalationRuleSchemaConfig mirrors logging.EscalationRule.
+	type EscalationRuleSchemaConfig struct {
+		Match   *EscalationMatchSchemaConfig `yaml:"match,omitempty" jsonschema:"description=Entries the rule applies to (all given conditions must hold)"`
+		RaiseTo string                       `yaml:"raise_to,omitempty" jsonschema:"description=Level matching entries are raised to,enum=info,enum=warn,enum=error"`
+	}
+
 	// LoggingSchemaConfig mirrors logging.Config.
 	type LoggingSchemaConfig struct {
-		Level              string                          `yaml:"level,omitempty" jsonschema:"description=Minimum log level (debug/info/warn/error),default=info,enum=debug,enum=info,enum=warn,enum=error"`
-		SystemLevel        string                          `yaml:"system_level,omitempty" jsonschema:"description=Minimum log level for system/daemon logs (debug/info/warn/error),enum=debug,enum=info,enum=warn,enum=error"`
-		ReportCaller       bool                            `yaml:"report_caller,omitempty" jsonschema:"description=Include file/line/function in output,default=true"`
-		LogStartup         bool                            `yaml:"log_startup,omitempty" jsonschema:"description=Log 'Grove binary started' on first init"`
-		File               *FileSinkSchemaConfig           `yaml:"file,omitempty" jsonschema:"description=File logging sink configuration"`
-		Format             *FormatSchemaConfig             `yaml:"format,omitempty" jsonschema:"description=Log output format settings"`
-		Groups             map[string][]string             `yaml:"groups,omitempty" jsonschema:"description=Named collections of component loggers for filtering"`
-		ComponentFiltering *ComponentFilteringSchemaConfig `yaml:"component_filtering,omitempty" jsonschema:"description=Rules for filtering logs by component"`
-		ShowCurrentProject *bool                           `yaml:"show_current_project,omitempty" jsonschema:"description=Always show logs from current project regardless of filters"`
+		Level                  string                          `yaml:"level,omitempty" jsonschema:"description=Minimum log level (trace/debug/info/warn/error),enum=trace,enum=debug,enum=info,enum=warn,enum=error" default:"info"`
+		SystemLevel            string                          `yaml:"system_level,omitempty" jsonschema:"description=Minimum log level for system/daemon logs (trace/debug/info/warn/error),enum=trace,enum=debug,enum=info,enum=warn,enum=error"`
+		ReportCaller           bool                            `yaml:"report_caller,omitempty" jsonschema:"description=Include file/line/function in output" default:"true"`
+		TimeFormat             string                          `yaml:"time_format,omitempty" jsonschema:"description=Timestamp format: rfc3339/rfc3339nano/unix_ms or a custom Go layout"`
+		Timezone               string                          `yaml:"timezone,omitempty" jsonschema:"description=Timezone for written timestamps: local (default)/utc or an IANA zone name"`
+		LogStartup             bool                            `yaml:"log_startup,omitempty" jsonschema:"description=Log a startup banner (version and commit; config layers; level; host and pid) once per process"`
+		Redact                 []string                        `yaml:"redact,omitempty" jsonschema:"description=Field names or regexes (e.g. password or .*_secret) whose values are masked in console and file output"`
+		RecentEntries          int                             `yaml:"recent_entries,omitempty" jsonschema:"description=Recent log entries kept in memory for status endpoints (0 = default of 500; negative disables)" default:"500"`
+		Escalations            []EscalationRuleSchemaConfig    `yaml:"escalations,omitempty" jsonschema:"description=Rules raising matching entries to a more severe level (e.g. known-bad warnings recorded as errors)"`
+		ValidateEntries        bool                            `yaml:"validate_entries,omitempty" jsonschema:"description=Debug: validate every emitted log entry against the log-entry schema and report violations on stderr" default:"false"`
+		Console                *ConsoleSinkSchemaConfig        `yaml:"console,omitempty" jsonschema:"description=Console (stderr) sink configuration: level and format independent of the file sink"`
+		File                   *FileSinkSchemaConfig           `yaml:"file,omitempty" jsonschema:"description=File logging sink configuration"`
+		Format                 *FormatSchemaConfig             `yaml:"format,omitempty" jsonschema:"description=Log output format settings"`
+		StructuredPrettyFields bool                            `yaml:"structured_pretty_fields,omitempty" jsonschema:"description=Embed rendered pretty_ansi/pretty_text fields in structured log entries" default:"false"`
+		Groups                 map[string][]string             `yaml:"groups,omitempty" jsonschema:"description=Named collections of component loggers for filtering"`
+		ComponentFiltering     *ComponentFilteringSchemaConfig `yaml:"component_filtering,omitempty" jsonschema:"description=Rules for filtering logs by component"`
+		ShowCurrentProject     *bool                           `yaml:"show_current_project,omitempty" jsonschema:"description=Always show logs from current project regardless of filters"`
 	}
 
 	type BaseConfig struct {
-		Name             string                        `yaml:"name,omitempty" jsonschema:"description=Name of the project or ecosystem" jsonschema_extras:"x-layer=ecosystem,x-priority=10"`
-		Version          string                        `yaml:"version,omitempty" jsonschema:"description=Configuration version (e.g. 1.0)" jsonschema_extras:"x-layer=global,x-priority=100"`
-		Workspaces       []string                      `yaml:"workspaces,omitempty" jsonschema:"description=Glob patterns for workspace directories in this ecosystem" jsonschema_extras:"x-layer=ecosystem,x-priority=11"`
-		BuildCmd         string                        `yaml:"build_cmd,omitempty" jsonschema:"description=Custom build command (default: make build)" jsonschema_extras:"x-layer=project,x-priority=20"`
-		BuildAfter       []string                      `yaml:"build_after,omitempty" jsonschema:"description=Projects that must be built before this one" jsonschema_extras:"x-layer=project,x-priority=21"`
-		Notebooks        *NotebooksConfig              `yaml:"notebooks,omitempty" jsonschema:"description=Notebook configuration" jsonschema_extras:"x-layer=global,x-priority=2,x-important=true"`
-		Logging          *LoggingSchemaConfig          `yaml:"logging,omitempty" jsonschema:"description=Logging configuration" jsonschema_extras:"x-layer=global,x-priority=60"`
-		TUI              *TUIConfig                    `yaml:"tui,omitempty" jsonschema:"description=TUI appearance and behavior settings" jsonschema_extras:"x-layer=global,x-priority=50"`
-		Context          *ContextConfig                `yaml:"context,omitempty" jsonschema:"description=Configuration for the cx (context) tool" jsonschema_extras:"x-layer=global,x-priority=80"`
-		Environment      *EnvironmentConfig            `yaml:"environment,omitempty" jsonschema:"description=Default environment provider configuration" jsonschema_extras:"x-layer=project,x-priority=25"`
-		Environments     map[string]*EnvironmentConfig `yaml:"environments,omitempty" jsonschema:"description=Named environment profiles selected via --env flag" jsonschema_extras:"x-layer=project,x-priority=26"`
-		Groves           map[string]GroveSourceConfig  `yaml:"groves,omitempty" jsonschema:"description=Root directories to search for projects and ecosystems" jsonschema_extras:"x-layer=global,x-priority=1,x-important=true"`
-		SearchPaths      map[string]SearchPathConfig   `yaml:"search_paths,omitempty" jsonschema:"description=DEPRECATED: Use groves instead,deprecated=true" jsonschema_extras:"x-layer=global,x-priority=1000,x-deprecated=true,x-deprecated-message=Use 'groves' for project discovery,x-deprecated-replacement=groves,x-deprecated-version=v0.5.0,x-deprecated-removal=v1.0.0"`
-		ExplicitProjects []ExplicitProject             `yaml:"explicit_projects,omitempty" jsonschema:"description=Specific projects to include without discovery" jsonschema_extras:"x-layer=global,x-priority=5"`
-		Commands         map[string]string             `yaml:"commands,omitempty" jsonschema:"description=Command overrides per verb (e.g. build check fmt lint)" jsonschema_extras:"x-layer=project,x-priority=22"`
-		TestScopes       []TestScopeConfig             `yaml:"test_scopes,omitempty" jsonschema:"description=Smart test triggering scopes" jsonschema_extras:"x-layer=project,x-priority=23"`
-		Onboarding       *OnboardingConfig             `yaml:"onboarding,omitempty" jsonschema:"description=First-run onboarding progress (completed marker + resume step)" jsonschema_extras:"x-layer=global,x-priority=90"`
+		Name              string                          `yaml:"name,omitempty" jsonschema:"description=Name of the project or ecosystem" jsonschema_extras:"x-layer=ecosystem,x-priority=10"`
+		Version           string                          `yaml:"version,omitempty" jsonschema:"description=Configuration version (e.g. 1.0)" jsonschema_extras:"x-layer=global,x-priority=100"`
+		Workspaces        []string                        `yaml:"workspaces,omitempty" jsonschema:"description=Glob patterns for workspace directories in this ecosystem" jsonschema_extras:"x-layer=ecosystem,x-priority=11"`
+		WorkspacesFrom    *WorkspacesFromConfig           `yaml:"workspaces_from,omitempty" jsonschema:"description=Remote manifest whose workspaces are added to this ecosystem's (cached; pin with sha256)" jsonschema_extras:"x-layer=ecosystem,x-priority=12"`
+		BuildCmd          string                          `yaml:"build_cmd,omitempty" jsonschema:"description=Custom build command (default: make build)" jsonschema_extras:"x-layer=project,x-priority=20"`
+		BuildAfter        []string                        `yaml:"build_after,omitempty" jsonschema:"description=Projects that must be built before this one" jsonschema_extras:"x-layer=project,x-priority=21"`
+		Tags              []string                        `yaml:"tags,omitempty" jsonschema:"description=Labels for selecting this project in aggregate commands such as core each --tag" jsonschema_extras:"x-layer=project,x-priority=24"`
+		Owners            []string                        `yaml:"owners,omitempty" jsonschema:"description=People or teams responsible for this project" jsonschema_extras:"x-layer=project,x-priority=25"`
+		Links             map[string]string               `yaml:"links,omitempty" jsonschema:"description=Named URLs for this project such as docs or dashboards" jsonschema_extras:"x-layer=project,x-priority=26"`
+		Notebooks         *NotebooksConfig                `yaml:"notebooks,omitempty" jsonschema:"description=Notebook configuration" jsonschema_extras:"x-layer=global,x-priority=2,x-important=true"`
+		Logging           *LoggingSchemaConfig            `yaml:"logging,omitempty" jsonschema:"description=Logging configuration" jsonschema_extras:"x-layer=global,x-priority=60"`
+		TUI               *TUIConfig                      `yaml:"tui,omitempty" jsonschema:"description=TUI appearance and behavior settings" jsonschema_extras:"x-layer=global,x-priority=50"`
+		Context           *ContextConfig                  `yaml:"context,omitempty" jsonschema:"description=Configuration for the cx (context) tool" jsonschema_extras:"x-layer=global,x-priority=80"`
+		Environment       *EnvironmentConfig              `yaml:"environment,omitempty" jsonschema:"description=Default environment provider configuration" jsonschema_extras:"x-layer=project,x-priority=25"`
+		Environments      map[string]*EnvironmentConfig   `yaml:"environments,omitempty" jsonschema:"description=Named environment profiles selected via --env flag" jsonschema_extras:"x-layer=project,x-priority=26"`
+		Groves            map[string]GroveSourceConfig    `yaml:"groves,omitempty" jsonschema:"description=Root directories to search for projects and ecosystems" jsonschema_extras:"x-layer=global,x-priority=1,x-important=true"`
+		SearchPaths       map[string]SearchPathConfig     `yaml:"search_paths,omitempty" jsonschema:"description=DEPRECATED: Use groves instead,deprecated=true" jsonschema_extras:"x-layer=global,x-priority=1000,x-deprecated=true,x-deprecated-message=Use 'groves' for project discovery,x-deprecated-replacement=groves,x-deprecated-version=v0.5.0,x-deprecated-removal=v1.0.0"`
+		ExplicitProjects  []ExplicitProject               `yaml:"explicit_projects,omitempty" jsonschema:"description=Specific projects to include without discovery" jsonschema_extras:"x-layer=global,x-priority=5"`
+		Commands          map[string]string               `yaml:"commands,omitempty" jsonschema:"description=Command overrides per verb (e.g. build check fmt lint)" jsonschema_extras:"x-layer=project,x-priority=22"`
+		TestScopes        []TestScopeConfig               `yaml:"test_scopes,omitempty" jsonschema:"description=Smart test triggering scopes" jsonschema_extras:"x-layer=project,x-priority=23"`
+		Onboarding        *OnboardingConfig               `yaml:"onboarding,omitempty" jsonschema:"description=First-run onboarding progress (completed marker + resume step)" jsonschema_extras:"x-layer=global,x-priority=90"`
+		Telemetry         *TelemetryConfig                `yaml:"telemetry,omitempty" jsonschema:"description=Opt-in local command usage log" jsonschema_extras:"x-layer=global,x-priority=92"`
+		WorkspacesLogging map[string]*LoggingSchemaConfig `yaml:"workspaces_logging,omitempty" jsonschema:"description=Logging overrides for member projects keyed by project name or path relative to the ecosystem root; merged over the member's own logging settings" jsonschema_extras:"x-layer=ecosystem,x-priority=61"`
 	}
 
 	schema := r.Reflect(&BaseConfig{})
+	// Defaults come from the fields' default tags rather than the
+	// jsonschema tags, so each is declared once next to its field.
+	if err := AddSchemaDefaults(schema, &BaseConfig{}); err != nil {
+		return nil, err
+	}
 	schema.Title = "Grove Core Configuration"
 	schema.Description = "Base schema for core grove.yml properties."
 	schema.Version = "http://json-schema.org/draft-07/schema#"
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// DefaultTag is the struct tag declaring a config field's default value,
+// e.g. `default:"info"`. The schema generators copy it into the field's
+// schema (see AddSchemaDefaults), from which `core config defaults` and
+// `core config schema print` report it.
+const DefaultTag = "default"
+
+// AddSchemaDefaults sets the default of every property in s, the schema
+// reflected from v's type with YAML field names, whose struct field carries
+// a default tag. The value is converted to the field's type, so a default
+// that does not parse (an int field tagged "ten") is an error. Properties
+// that already declare a default keep it.
+func AddSchemaDefaults(s *jsonschema.Schema, v interface{}) error {
+	w := defaultsWalker{root: s, seen: map[reflect.Type]bool{}}
+	return w.walk(s, reflect.TypeOf(v))
+}
+
+type defaultsWalker struct {
+	root *jsonschema.Schema
+	// seen holds the struct types whose $defs entry has been visited.
+	seen map[reflect.Type]bool
+}
+
+func (w defaultsWalker) walk(s *jsonschema.Schema, t reflect.Type) error {
+	if s == nil {
+		return nil
+	}
+	t = indirect(t)
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return w.walk(s.Items, t.Elem())
+	case reflect.Map:
+		return w.walk(s.AdditionalProperties, t.Elem())
+	case reflect.Struct:
+		if s.Ref != "" {
+			if w.seen[t] {
+				return nil
+			}
+			w.seen[t] = true
+			s = w.root.Definitions[strings.TrimPrefix(s.Ref, "#/$defs/")]
+			if s == nil {
+				return nil
+			}
+		}
+		return w.walkFields(s, t)
+	}
+	return nil
+}
+
+// walkFields visits the fields of struct type t, whose schema is s.
+// Embedded and inlined structs share their parent's properties.
+func (w defaultsWalker) walkFields(s *jsonschema.Schema, t reflect.Type) error {
+	if s.Properties == nil {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		if f.Anonymous || strings.Contains(opts, "inline") {
+			if ft := indirect(f.Type); ft.Kind() == reflect.Struct {
+				if err := w.walkFields(s, ft); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop, ok := s.Properties.Get(name)
+		if !ok || prop == nil {
+			continue
+		}
+		if raw, ok := f.Tag.Lookup(DefaultTag); ok && prop.Default == nil {
+			value, err := parseDefault(f.Type, raw)
+			if err != nil {
+				return fmt.Errorf("%s.%s: invalid default %q: %w", t.Name(), f.Name, raw, err)
+			}
+			prop.Default = value
+		}
+		if err := w.walk(prop, f.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// parseDefault converts a default tag to the JSON value of a field of
+// type t.
+func parseDefault(t reflect.Type, raw string) (interface{}, error) {
+	switch indirect(t).Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(raw, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(raw, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(raw, 64)
+	case reflect.String:
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("defaults are not supported for %s fields", t)
+	}
+}
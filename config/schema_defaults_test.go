@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/invopop/jsonschema"
+)
+
+func TestAddSchemaDefaults(t *testing.T) {
+	type sink struct {
+		Format  string `yaml:"format,omitempty" default:"json"`
+		Enabled *bool  `yaml:"enabled,omitempty" default:"true"`
+	}
+	type settings struct {
+		Level    string           `yaml:"level,omitempty" default:"info"`
+		Keep     int              `yaml:"keep,omitempty" default:"14"`
+		Explicit string           `yaml:"explicit,omitempty" jsonschema:"default=kept" default:"ignored"`
+		Sink     *sink            `yaml:"sink,omitempty"`
+		Sinks    map[string]*sink `yaml:"sinks,omitempty"`
+	}
+
+	r := &jsonschema.Reflector{ExpandedStruct: true, FieldNameTag: "yaml"}
+	s := r.Reflect(&settings{})
+	if err := AddSchemaDefaults(s, &settings{}); err != nil {
+		t.Fatalf("AddSchemaDefaults: %v", err)
+	}
+
+	prop := func(s *jsonschema.Schema, name string) *jsonschema.Schema {
+		p, ok := s.Properties.Get(name)
+		if !ok {
+			t.Fatalf("no %s property", name)
+		}
+		return p
+	}
+	if got := prop(s, "level").Default; got != "info" {
+		t.Errorf("level default = %#v", got)
+	}
+	if got := prop(s, "keep").Default; got != int64(14) {
+		t.Errorf("keep default = %#v, want the int 14", got)
+	}
+	if got := prop(s, "explicit").Default; got != "kept" {
+		t.Errorf("a default already in the schema should win, got %#v", got)
+	}
+	sinkDef := s.Definitions["sink"]
+	if sinkDef == nil {
+		t.Fatalf("no sink definition in %v", s.Definitions)
+	}
+	if got := prop(sinkDef, "format").Default; got != "json" {
+		t.Errorf("sink.format default = %#v", got)
+	}
+	if got := prop(sinkDef, "enabled").Default; got != true {
+		t.Errorf("sink.enabled default = %#v", got)
+	}
+
+	type bad struct {
+		Keep int `yaml:"keep" default:"ten"`
+	}
+	if err := AddSchemaDefaults(r.Reflect(&bad{}), &bad{}); err == nil {
+		t.Error("expected an error for a default that does not parse")
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"io"
 	"os"
 	"sync"
@@ -42,7 +43,8 @@ var isTerminalFd = func(fd uintptr) bool {
 }
 
 // SetSchemaWarningSink installs the process-wide destination for schema
-// warnings and flushes any warnings buffered before logging was ready.
+// warnings (including *DeprecatedKeyError, one per deprecated key a loaded
+// file sets) and flushes any warnings buffered before logging was ready.
 // core/logging registers a component-logger sink on first logger
 // construction. fn runs inside arbitrary config.Load* callers, so it must
 // not call back into config.Load* or logging.NewLogger.
@@ -85,6 +87,24 @@ func reportSchemaWarning(logger *logrus.Logger, source string, err error) {
 	if writerIsInteractive(logger.Out) && os.Getenv("GROVE_DEBUG") != "1" {
 		return
 	}
+	LogSchemaWarning(logger, source, err)
+}
+
+// LogSchemaWarning logs one warning reported for source: a
+// DeprecatedKeyError or ManifestWarning with its own message and fields,
+// anything else as a schema validation failure. Warning sinks use it so
+// every destination words warnings the same way.
+func LogSchemaWarning(logger logrus.FieldLogger, source string, err error) {
+	var deprecated *DeprecatedKeyError
+	if errors.As(err, &deprecated) {
+		logger.WithFields(deprecated.Fields()).Warn(DeprecationWarningMessage)
+		return
+	}
+	var manifest *ManifestWarning
+	if errors.As(err, &manifest) {
+		logger.WithFields(manifest.Fields()).Warn(ManifestWarningMessage)
+		return
+	}
 	logger.WithError(err).WithField("source", source).
 		Warn("configuration does not fully conform to the schema (continuing; validation is advisory)")
 }
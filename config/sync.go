@@ -41,10 +41,10 @@ type SyncProviderConfig struct {
 type SyncWorkspace struct {
 	Name string `yaml:"name" toml:"name" jsonschema:"description=Workspace name to sync"`
 	// Mode selects the subscription filter: full, plans-only, or search-only.
-	Mode string `yaml:"mode,omitempty" toml:"mode,omitempty" jsonschema:"description=Subscription mode,enum=full,enum=plans-only,enum=search-only,default=full"`
+	Mode string `yaml:"mode,omitempty" toml:"mode,omitempty" jsonschema:"description=Subscription mode,enum=full,enum=plans-only,enum=search-only" default:"full"`
 	// Pull opts this machine into writing pulled changes to the local
 	// notebook tree. Without it, sync is push-only (notebook-read-only).
-	Pull bool `yaml:"pull,omitempty" toml:"pull,omitempty" jsonschema:"description=Allow pulled changes to be written to the local notebook tree,default=false"`
+	Pull bool `yaml:"pull,omitempty" toml:"pull,omitempty" jsonschema:"description=Allow pulled changes to be written to the local notebook tree" default:"false"`
 	// Excludes are additional path-prefix/glob exclusions applied on top of
 	// the protocol's default exclusion manifest.
 	Excludes []string `yaml:"excludes,omitempty" toml:"excludes,omitempty" jsonschema:"description=Additional exclusion globs for this workspace"`
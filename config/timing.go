@@ -0,0 +1,11 @@
+package config
+
+import "github.com/grovetools/core/pkg/timinghook"
+
+var timer timinghook.Hook
+
+// SetTimer installs the process-wide timer for config loading (see
+// timinghook). A nil fn disables timing.
+func SetTimer(fn timinghook.StartFunc) {
+	timer.Set(fn)
+}
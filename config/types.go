@@ -23,14 +23,17 @@ type SearchPathConfig struct {
 
 // GroveSourceConfig defines the configuration for a single grove source.
 type GroveSourceConfig struct {
-	Path         string   `yaml:"path" toml:"path" jsonschema:"description=Absolute path to the grove root directory" jsonschema_extras:"x-priority=1,x-important=true"`
+	Path         string   `yaml:"path" toml:"path" jsonschema:"description=Absolute path to the grove root directory; ssh://[user@]host[:port]/path discovers workspaces on another machine" jsonschema_extras:"x-priority=1,x-important=true"`
 	Enabled      *bool    `yaml:"enabled,omitempty" toml:"enabled,omitempty" jsonschema:"description=Whether this grove is enabled (default: true)" jsonschema_extras:"x-priority=2,x-important=true"`
 	Description  string   `yaml:"description,omitempty" toml:"description,omitempty" jsonschema:"description=Human-readable description of this grove" jsonschema_extras:"x-priority=4,x-important=true"`
 	Notebook     string   `yaml:"notebook,omitempty" toml:"notebook,omitempty" jsonschema:"description=Name of the notebook to use for projects in this grove" jsonschema_extras:"x-priority=3,x-important=true"`
 	Depth        *int     `yaml:"depth,omitempty" toml:"depth,omitempty" jsonschema:"description=How many directory levels deep to scan for projects. Unset keeps current behavior; 1 means immediate children only."`
 	IncludeRepos []string `yaml:"include_repos,omitempty" toml:"include_repos,omitempty" jsonschema:"description=List of directory names or relative paths to explicitly include as projects"`
 	ExcludeRepos []string `yaml:"exclude_repos,omitempty" toml:"exclude_repos,omitempty" jsonschema:"description=List of directory names or relative paths to explicitly exclude"`
+	Include      []string `yaml:"include,omitempty" toml:"include,omitempty" jsonschema:"description=Glob patterns relative to the grove root; when set only matching subtrees are scanned. ** matches any number of directories."`
+	Exclude      []string `yaml:"exclude,omitempty" toml:"exclude,omitempty" jsonschema:"description=Glob patterns relative to the grove root for subtrees to skip during the scan (e.g. **/archive/**). Exclude wins over include."`
 	Memory       *bool    `yaml:"memory,omitempty" toml:"memory,omitempty" jsonschema:"description=Whether to index this grove's notebook content into the memory store for semantic search (default: false)"`
+	Command      string   `yaml:"command,omitempty" toml:"command,omitempty" jsonschema:"description=For ssh:// groves: the command run on the host to print its workspaces as JSON (default: core ws --json --local)"`
 }
 
 // ExplicitProject defines a specific project to include regardless of discovery.
@@ -274,29 +277,36 @@ type TUIConfig struct {
 	// Theme's schema enum is generated from the theme registry (see
 	// config.GenerateSchemaWithThemeNames and tools/schema-generator); do
 	// not hardcode theme names in the tag.
-	Theme       string             `yaml:"theme,omitempty" toml:"theme,omitempty" jsonschema:"description=Color theme for terminal interfaces" jsonschema_extras:"x-layer=global,x-priority=51,x-important=true"`
-	Preset      string             `yaml:"preset,omitempty" toml:"preset,omitempty" jsonschema:"description=Keybinding preset: vim (default), emacs, or arrows,enum=vim,enum=emacs,enum=arrows,default=vim" jsonschema_extras:"x-layer=global,x-priority=50,x-important=true"`
+	Theme string `yaml:"theme,omitempty" toml:"theme,omitempty" jsonschema:"description=Color theme for terminal interfaces" jsonschema_extras:"x-layer=global,x-priority=51,x-important=true"`
+	// ColorVision remaps red/green status colors in every theme for
+	// red-green color vision deficiencies.
+	ColorVision string             `yaml:"color_vision,omitempty" toml:"color_vision,omitempty" jsonschema:"description=Adapt status colors for color vision: normal (default) or deuteranopia or protanopia,enum=normal,enum=deuteranopia,enum=protanopia" default:"normal" jsonschema_extras:"x-layer=global,x-priority=51"`
+	Preset      string             `yaml:"preset,omitempty" toml:"preset,omitempty" jsonschema:"description=Keybinding preset: vim (default), emacs, or arrows,enum=vim,enum=emacs,enum=arrows" default:"vim" jsonschema_extras:"x-layer=global,x-priority=50,x-important=true"`
 	Keybindings *KeybindingsConfig `yaml:"keybindings,omitempty" toml:"keybindings,omitempty" jsonschema:"description=Custom keybinding overrides" jsonschema_extras:"x-layer=global,x-priority=54"`
 	NvimEmbed   *NvimEmbedConfig   `yaml:"nvim_embed,omitempty" toml:"nvim_embed,omitempty" jsonschema:"description=Embedded Neovim configuration" jsonschema_extras:"x-status=alpha,x-layer=global,x-priority=55"`
 
 	// LeaderKey is the bubbletea key string that activates the leader
 	// chord (e.g. "ctrl+b", "f12"). Default: "ctrl+b".
-	LeaderKey string `yaml:"leader_key,omitempty" toml:"leader_key,omitempty" jsonschema:"description=Key chord that activates the leader/workspace switcher (bubbletea key string),default=ctrl+b" jsonschema_extras:"x-layer=global,x-priority=53"`
+	LeaderKey string `yaml:"leader_key,omitempty" toml:"leader_key,omitempty" jsonschema:"description=Key chord that activates the leader/workspace switcher (bubbletea key string)" default:"ctrl+b" jsonschema_extras:"x-layer=global,x-priority=53"`
 
 	// ActionKey is the bubbletea key string that activates the action
 	// chord for grove-specific terminal actions (sidebar, rail, agent,
 	// help, etc.). Default: "ctrl+g".
-	ActionKey string `yaml:"action_key,omitempty" toml:"action_key,omitempty" jsonschema:"description=Key chord that activates grove terminal actions (bubbletea key string),default=ctrl+g" jsonschema_extras:"x-layer=global,x-priority=53"`
+	ActionKey string `yaml:"action_key,omitempty" toml:"action_key,omitempty" jsonschema:"description=Key chord that activates grove terminal actions (bubbletea key string)" default:"ctrl+g" jsonschema_extras:"x-layer=global,x-priority=53"`
+
+	// Mouse enables click and wheel handling in the logs viewer and the
+	// JSON tree viewer. Default: false.
+	Mouse bool `yaml:"mouse,omitempty" toml:"mouse,omitempty" jsonschema:"description=Enable mouse support in the logs and JSON tree viewers: click to select or fold and wheel to scroll" default:"false" jsonschema_extras:"x-layer=global,x-priority=56"`
 
 	// SidebarExpanded controls whether the icon rail starts expanded
 	// (showing labels) or collapsed (icons only). Default: false.
-	SidebarExpanded bool `yaml:"sidebar_expanded,omitempty" toml:"sidebar_expanded,omitempty" jsonschema:"description=Start terminal sidebar expanded (icon + label) instead of icon-only,default=false" jsonschema_extras:"x-layer=global,x-priority=57"`
+	SidebarExpanded bool `yaml:"sidebar_expanded,omitempty" toml:"sidebar_expanded,omitempty" jsonschema:"description=Start terminal sidebar expanded (icon + label) instead of icon-only" default:"false" jsonschema_extras:"x-layer=global,x-priority=57"`
 
 	// HideSplashOnStartup suppresses the welcome splash overlay that
 	// treemux otherwise opens on every start. Toggled from the splash
 	// itself (h) and persisted to the global config layer; `treemux
 	// start --welcome` still forces the splash. Default: false.
-	HideSplashOnStartup bool `yaml:"hide_splash_on_startup,omitempty" toml:"hide_splash_on_startup,omitempty" jsonschema:"description=Hide the treemux welcome splash on startup,default=false" jsonschema_extras:"x-layer=global,x-priority=67"`
+	HideSplashOnStartup bool `yaml:"hide_splash_on_startup,omitempty" toml:"hide_splash_on_startup,omitempty" jsonschema:"description=Hide the treemux welcome splash on startup" default:"false" jsonschema_extras:"x-layer=global,x-priority=67"`
 
 	// Shortcuts maps key chords to deep-link navigation targets.
 	// Each value uses the syntax "navigate:<panel>[.<tab>]", e.g.
@@ -313,7 +323,7 @@ type TUIConfig struct {
 	// between panes unless the active PTY's foreground process is an
 	// editor (nvim, vim, hx) or a TUI (fzf, lazygit, less), in which
 	// case the key is passed through to the PTY. Default: false.
-	VimControlHjklPaneNav bool `yaml:"vim_control_hjkl_pane_nav,omitempty" toml:"vim_control_hjkl_pane_nav,omitempty" jsonschema:"description=Enable Ctrl+hjkl pane navigation (vim-tmux-navigator style),default=false" jsonschema_extras:"x-layer=global,x-priority=59"`
+	VimControlHjklPaneNav bool `yaml:"vim_control_hjkl_pane_nav,omitempty" toml:"vim_control_hjkl_pane_nav,omitempty" jsonschema:"description=Enable Ctrl+hjkl pane navigation (vim-tmux-navigator style)" default:"false" jsonschema_extras:"x-layer=global,x-priority=59"`
 
 	// Plugins defines process-based plugin panels that run standalone
 	// executables in PTY panels with their own rail icons.
@@ -325,12 +335,12 @@ type TUIConfig struct {
 	// DrawerOrientation controls the position of the active sessions drawer.
 	// "right" places it as a vertical sidebar; "bottom" places it as a
 	// horizontal bar. Default: "right".
-	DrawerOrientation string `yaml:"drawer_orientation,omitempty" toml:"drawer_orientation,omitempty" jsonschema:"description=Active sessions drawer position,enum=right,enum=bottom,default=right" jsonschema_extras:"x-layer=global,x-priority=62"`
+	DrawerOrientation string `yaml:"drawer_orientation,omitempty" toml:"drawer_orientation,omitempty" jsonschema:"description=Active sessions drawer position,enum=right,enum=bottom" default:"right" jsonschema_extras:"x-layer=global,x-priority=62"`
 
 	// DrawerExpanded controls whether the active sessions drawer starts
 	// expanded (showing full list) or collapsed (mini icons only).
 	// Default: false (collapsed).
-	DrawerExpanded bool `yaml:"drawer_expanded,omitempty" toml:"drawer_expanded,omitempty" jsonschema:"description=Start active sessions drawer expanded,default=false" jsonschema_extras:"x-layer=global,x-priority=63"`
+	DrawerExpanded bool `yaml:"drawer_expanded,omitempty" toml:"drawer_expanded,omitempty" jsonschema:"description=Start active sessions drawer expanded" default:"false" jsonschema_extras:"x-layer=global,x-priority=63"`
 
 	ExperimentalPages []string `yaml:"experimental_pages,omitempty" toml:"experimental_pages,omitempty" json:"experimental_pages,omitempty" jsonschema:"description=List of experimental pages to enable (env,memory,keymap,logs,inspector)" jsonschema_extras:"x-layer=global,x-priority=64"`
 
@@ -347,7 +357,64 @@ type TUIConfig struct {
 	// "unset" is distinguishable from an explicit 0; nil falls back to the
 	// keymap.WhichKeyDelay default (400ms). 0 shows the popup immediately. This
 	// is the SHOW clock, distinct from the sequence EXPIRE timeout.
-	WhichKeyDelayMs *int `yaml:"whichkey_delay_ms,omitempty" toml:"whichkey_delay_ms,omitempty" json:"whichkey_delay_ms,omitempty" jsonschema:"description=Delay in milliseconds before the which-key chord popup appears (0 = immediate),default=400" jsonschema_extras:"x-layer=global,x-priority=68"`
+	WhichKeyDelayMs *int `yaml:"whichkey_delay_ms,omitempty" toml:"whichkey_delay_ms,omitempty" json:"whichkey_delay_ms,omitempty" jsonschema:"description=Delay in milliseconds before the which-key chord popup appears (0 = immediate)" default:"400" jsonschema_extras:"x-layer=global,x-priority=68"`
+
+	// Logs configures the `core logs` viewer.
+	Logs *TUILogsConfig `yaml:"logs,omitempty" toml:"logs,omitempty" json:"logs,omitempty" jsonschema:"description=Log viewer behavior" jsonschema_extras:"x-layer=global,x-priority=69"`
+
+	// JSONTree configures the JSON tree viewer used by the logs viewer and
+	// `core config show -i`.
+	JSONTree *TUIJSONTreeConfig `yaml:"jsontree,omitempty" toml:"jsontree,omitempty" json:"jsontree,omitempty" jsonschema:"description=JSON tree viewer behavior" jsonschema_extras:"x-layer=global,x-priority=70"`
+}
+
+// TUIJSONTreeConfig configures the JSON tree viewer.
+type TUIJSONTreeConfig struct {
+	RenderHints *JSONTreeRenderHints `yaml:"render_hints,omitempty" toml:"render_hints,omitempty" json:"render_hints,omitempty" jsonschema:"description=Which number fields are shown in readable units\\, chosen by key name"`
+}
+
+// JSONTreeRenderHints selects, by glob patterns on the key name (e.g.
+// "*_at"), the number fields the JSON tree shows as times, durations and
+// sizes. A list replaces the built-in patterns for its kind; an empty
+// list turns that kind off. The "H" key toggles raw values.
+type JSONTreeRenderHints struct {
+	// Enabled is whether readable values are shown when the viewer opens.
+	// Default: true.
+	Enabled *bool `yaml:"enabled,omitempty" toml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"description=Show readable values when the viewer opens (H toggles raw values)" default:"true"`
+	// Timestamps are shown as ISO 8601 times. Unix seconds, milliseconds
+	// and nanoseconds are told apart by magnitude.
+	Timestamps []string `yaml:"timestamps,omitempty" toml:"timestamps,omitempty" json:"timestamps,omitempty" jsonschema:"description=Key patterns of unix timestamps shown as ISO 8601 times (default: *_at\\, ts\\, timestamp\\, time\\, *_time\\, *_ts\\, *_unix\\, unix_ms)"`
+	// Durations are milliseconds, shown like "1m23s".
+	Durations []string `yaml:"durations,omitempty" toml:"durations,omitempty" json:"durations,omitempty" jsonschema:"description=Key patterns of millisecond durations shown humanized (default: *_ms)"`
+	// Bytes are byte counts, shown like "1.5 MiB".
+	Bytes []string `yaml:"bytes,omitempty" toml:"bytes,omitempty" json:"bytes,omitempty" jsonschema:"description=Key patterns of byte counts shown in KiB/MiB/GiB (default: *bytes)"`
+}
+
+// TUILogsConfig configures the interactive log viewer.
+type TUILogsConfig struct {
+	// CopyFormat is what the yank key copies: raw JSONL, a pretty JSON
+	// array, a jq command selecting matching entries, or a grep -F command.
+	// The copy-as key (") picks a format for a single copy. Default: json.
+	CopyFormat string `yaml:"copy_format,omitempty" toml:"copy_format,omitempty" json:"copy_format,omitempty" jsonschema:"description=Default clipboard format for yanked log entries,enum=jsonl,enum=json,enum=jq,enum=grep" default:"json"`
+	// PinnedErrors is how many recent error/fatal entries the pinned error
+	// panel (toggled with "!" in follow mode) keeps. Default: 5.
+	PinnedErrors int `yaml:"pinned_errors,omitempty" toml:"pinned_errors,omitempty" json:"pinned_errors,omitempty" jsonschema:"description=Number of recent error entries shown in the pinned error panel,minimum=1" default:"5"`
+	// MaxEntries caps how many entries the viewer holds in memory; the
+	// oldest are dropped beyond it and can be loaded again with gg or
+	// pgup at the top. Default: 10000.
+	MaxEntries int `yaml:"max_entries,omitempty" toml:"max_entries,omitempty" json:"max_entries,omitempty" jsonschema:"description=Maximum log entries held in memory by the log viewer,minimum=100" default:"10000"`
+	// Timestamps is how the viewer's timestamp column starts: absolute
+	// times or relative ages ("2m ago") refreshed as they pass. The "t" key
+	// toggles it. Default: absolute.
+	Timestamps string `yaml:"timestamps,omitempty" toml:"timestamps,omitempty" json:"timestamps,omitempty" jsonschema:"description=Whether the log viewer starts with absolute timestamps or relative ages,enum=absolute,enum=relative" default:"absolute"`
+	// FollowMode is how follow mode auto-scrolls: "pin" keeps the newest
+	// entry selected, "pause" stops following when the cursor is moved off
+	// the newest entry and counts the entries that arrive meanwhile, and
+	// "resume" also picks following back up after FollowResumeAfter
+	// without input. The "F" key cycles the modes. Default: pin.
+	FollowMode string `yaml:"follow_mode,omitempty" toml:"follow_mode,omitempty" json:"follow_mode,omitempty" jsonschema:"description=How the log viewer's follow mode reacts to manual navigation,enum=pin,enum=pause,enum=resume" default:"pin"`
+	// FollowResumeAfter is the idle time after which the "resume" follow
+	// mode returns to the newest entry, as a duration. Default: 10s.
+	FollowResumeAfter string `yaml:"follow_resume_after,omitempty" toml:"follow_resume_after,omitempty" json:"follow_resume_after,omitempty" jsonschema:"description=Idle time before the resume follow mode returns to the newest entry (e.g. 10s)" default:"10s"`
 }
 
 // AgentPaneConfig controls how treemux hosts agent CLI panes (claude etc.).
@@ -360,20 +427,20 @@ type AgentPaneConfig struct {
 	// xterm-256color). Setting e.g. screen-256color makes renderers like
 	// Ink take their conservative tmux render path (fuller line redraws),
 	// which can avoid the stale-model frame merging at the source.
-	Term string `yaml:"term,omitempty" toml:"term,omitempty" json:"term,omitempty" jsonschema:"description=TERM value for agent pane PTYs (e.g. screen-256color for the conservative tmux render path),default=xterm-256color"`
+	Term string `yaml:"term,omitempty" toml:"term,omitempty" json:"term,omitempty" jsonschema:"description=TERM value for agent pane PTYs (e.g. screen-256color for the conservative tmux render path)" default:"xterm-256color"`
 
 	// RepaintNudge enables automatic PTY winsize jiggles (SIGWINCH →
 	// full repaint) after agent output bursts settle and on pane focus,
 	// healing renderer corruption in the live region. Default: true.
-	RepaintNudge *bool `yaml:"repaint_nudge,omitempty" toml:"repaint_nudge,omitempty" json:"repaint_nudge,omitempty" jsonschema:"description=Automatically SIGWINCH-nudge agent panes after output bursts to heal rendering corruption,default=true"`
+	RepaintNudge *bool `yaml:"repaint_nudge,omitempty" toml:"repaint_nudge,omitempty" json:"repaint_nudge,omitempty" jsonschema:"description=Automatically SIGWINCH-nudge agent panes after output bursts to heal rendering corruption" default:"true"`
 }
 
 // JobDetailConfig configures direct keybinds for the job detail tab wrapper.
 // These only activate when the wrapper's active tab is NOT a PTY.
 type JobDetailConfig struct {
-	Editor string `yaml:"editor,omitempty" toml:"editor,omitempty" json:"editor,omitempty" jsonschema:"description=Key to jump to the editor tab,default=e"`
-	Rules  string `yaml:"rules,omitempty" toml:"rules,omitempty" json:"rules,omitempty" jsonschema:"description=Key to jump to the cx rules tab,default=r"`
-	Logs   string `yaml:"logs,omitempty" toml:"logs,omitempty" json:"logs,omitempty" jsonschema:"description=Key to jump to the logs tab,default=l"`
+	Editor string `yaml:"editor,omitempty" toml:"editor,omitempty" json:"editor,omitempty" jsonschema:"description=Key to jump to the editor tab" default:"e"`
+	Rules  string `yaml:"rules,omitempty" toml:"rules,omitempty" json:"rules,omitempty" jsonschema:"description=Key to jump to the cx rules tab" default:"r"`
+	Logs   string `yaml:"logs,omitempty" toml:"logs,omitempty" json:"logs,omitempty" jsonschema:"description=Key to jump to the logs tab" default:"l"`
 }
 
 // FocusConfig controls how the focused BSP pane is visually distinguished.
@@ -381,17 +448,17 @@ type FocusConfig struct {
 	// Style selects the focus indicator strategy: border (highlight
 	// separator cells adjacent to focused pane), gutter (1-col colored
 	// bar on left edge), or title (1-row colored header).
-	Style string `yaml:"style,omitempty" toml:"style,omitempty" jsonschema:"description=Focus indicator style,enum=border,enum=gutter,enum=title,default=gutter"`
+	Style string `yaml:"style,omitempty" toml:"style,omitempty" jsonschema:"description=Focus indicator style,enum=border,enum=gutter,enum=title" default:"gutter"`
 	// ActiveColor is the color used for the focused pane's indicator.
 	// Named theme colors ("cyan", "accent", …) and hex literals are
 	// accepted; the shipped default is "cyan".
-	ActiveColor string `yaml:"active_color,omitempty" toml:"active_color,omitempty" jsonschema:"description=Color for focused pane indicator,default=cyan"`
+	ActiveColor string `yaml:"active_color,omitempty" toml:"active_color,omitempty" jsonschema:"description=Color for focused pane indicator" default:"cyan"`
 	// InactiveColor is the color used for unfocused pane indicators.
 	// "none" hides the unfocused indicator entirely.
-	InactiveColor string `yaml:"inactive_color,omitempty" toml:"inactive_color,omitempty" jsonschema:"description=Color for unfocused pane indicator,default=none"`
+	InactiveColor string `yaml:"inactive_color,omitempty" toml:"inactive_color,omitempty" jsonschema:"description=Color for unfocused pane indicator" default:"none"`
 	// Thickness controls the width (for gutter) or height (for title) of the
 	// focus indicator in cells. Defaults to 1. For border style this is ignored.
-	Thickness int `yaml:"thickness,omitempty" toml:"thickness,omitempty" jsonschema:"description=Indicator thickness in cells,default=1,minimum=1,maximum=4"`
+	Thickness int `yaml:"thickness,omitempty" toml:"thickness,omitempty" jsonschema:"description=Indicator thickness in cells,minimum=1,maximum=4" default:"1"`
 	// DimInactive dims unfocused panes (requires compositor support).
 	DimInactive bool `yaml:"dim_inactive,omitempty" toml:"dim_inactive,omitempty" jsonschema:"description=Dim unfocused panes (requires compositor support)"`
 }
@@ -405,13 +472,13 @@ type PluginConfig struct {
 	// Icon is the nerd font icon displayed in the rail.
 	Icon string `yaml:"icon,omitempty" toml:"icon,omitempty" jsonschema:"description=Nerd font icon for the rail"`
 	// Position controls where the plugin appears: rail (persistent) or ephemeral (on-demand).
-	Position string `yaml:"position,omitempty" toml:"position,omitempty" jsonschema:"description=Panel position: rail (persistent) or ephemeral (on-demand),enum=rail,enum=ephemeral,default=rail"`
+	Position string `yaml:"position,omitempty" toml:"position,omitempty" jsonschema:"description=Panel position: rail (persistent) or ephemeral (on-demand),enum=rail,enum=ephemeral" default:"rail"`
 	// Cwd is the working directory for the command.
 	Cwd string `yaml:"cwd,omitempty" toml:"cwd,omitempty" jsonschema:"description=Working directory for the command"`
 	// Env are extra environment variables (KEY=VALUE format).
 	Env []string `yaml:"env,omitempty" toml:"env,omitempty" jsonschema:"description=Extra environment variables (KEY=VALUE)"`
 	// Restart controls whether the plugin auto-restarts on exit.
-	Restart bool `yaml:"restart,omitempty" toml:"restart,omitempty" jsonschema:"description=Auto-restart plugin on exit,default=false"`
+	Restart bool `yaml:"restart,omitempty" toml:"restart,omitempty" jsonschema:"description=Auto-restart plugin on exit" default:"false"`
 }
 
 // PanelConfig holds configuration for user-defined ephemeral panel
@@ -488,21 +555,56 @@ type EnvironmentConfig struct {
 
 // DaemonConfig holds configuration for the grove daemon (groved).
 type DaemonConfig struct {
-	GitInterval            string            `yaml:"git_interval,omitempty" toml:"git_interval,omitempty" jsonschema:"description=How often to poll git status (default: 10s)"`
-	SessionInterval        string            `yaml:"session_interval,omitempty" toml:"session_interval,omitempty" jsonschema:"description=How often to poll sessions (default: 2s)"`
-	WorkspaceInterval      string            `yaml:"workspace_interval,omitempty" toml:"workspace_interval,omitempty" jsonschema:"description=How often to refresh workspace discovery (default: 30s)"`
-	PlanInterval           string            `yaml:"plan_interval,omitempty" toml:"plan_interval,omitempty" jsonschema:"description=How often to poll plan stats (default: 30s)"`
-	NoteInterval           string            `yaml:"note_interval,omitempty" toml:"note_interval,omitempty" jsonschema:"description=How often to poll note counts (default: 60s)"`
-	ConfigWatch            *bool             `yaml:"config_watch,omitempty" toml:"config_watch,omitempty" jsonschema:"description=Enable config watching (default: true)"`
-	ConfigDebounceMs       int               `yaml:"config_debounce_ms,omitempty" toml:"config_debounce_ms,omitempty" jsonschema:"description=Debounce window for rapid config changes in milliseconds (default: 100)"`
-	AutoSyncSkills         *bool             `yaml:"auto_sync_skills,omitempty" toml:"auto_sync_skills,omitempty" jsonschema:"description=Enable automatic syncing of skills on file change (default: true)"`
-	AutoSyncClaudeSettings *bool             `yaml:"auto_sync_claude_settings,omitempty" toml:"auto_sync_claude_settings,omitempty" jsonschema:"description=Enable automatic syncing of .claude settings on file change (default: true)"`
-	SkillSyncDebounceMs    int               `yaml:"skill_sync_debounce_ms,omitempty" toml:"skill_sync_debounce_ms,omitempty" jsonschema:"description=Debounce window for skill syncs in milliseconds (default: 1000)"`
-	Hooks                  *DaemonHooks      `yaml:"hooks,omitempty" toml:"hooks,omitempty" jsonschema:"description=Daemon-specific hooks configuration"`
-	Jobs                   *DaemonJobsConfig `yaml:"jobs,omitempty" toml:"jobs,omitempty" jsonschema:"description=Job runner configuration"`
-	Build                  *BuildConfig      `yaml:"build,omitempty" toml:"build,omitempty" jsonschema:"description=Machine-wide build queue configuration"`
-	SSH                    *DaemonSSHConfig  `yaml:"ssh,omitempty" toml:"ssh,omitempty" jsonschema:"description=Embedded SSH server configuration"`
-	PairWithTreemux        *bool             `yaml:"pair_with_treemux,omitempty" toml:"pair_with_treemux,omitempty" jsonschema:"description=Opt-in to kill daemon when the parent treemux exits"`
+	GitInterval            string                  `yaml:"git_interval,omitempty" toml:"git_interval,omitempty" jsonschema:"description=How often to poll git status (default: 10s)"`
+	SessionInterval        string                  `yaml:"session_interval,omitempty" toml:"session_interval,omitempty" jsonschema:"description=How often to poll sessions (default: 2s)"`
+	WorkspaceInterval      string                  `yaml:"workspace_interval,omitempty" toml:"workspace_interval,omitempty" jsonschema:"description=How often to refresh workspace discovery (default: 30s)"`
+	PlanInterval           string                  `yaml:"plan_interval,omitempty" toml:"plan_interval,omitempty" jsonschema:"description=How often to poll plan stats (default: 30s)"`
+	NoteInterval           string                  `yaml:"note_interval,omitempty" toml:"note_interval,omitempty" jsonschema:"description=How often to poll note counts (default: 60s)"`
+	ConfigWatch            *bool                   `yaml:"config_watch,omitempty" toml:"config_watch,omitempty" jsonschema:"description=Enable config watching (default: true)"`
+	ConfigDebounceMs       int                     `yaml:"config_debounce_ms,omitempty" toml:"config_debounce_ms,omitempty" jsonschema:"description=Debounce window for rapid config changes in milliseconds (default: 100)"`
+	AutoSyncSkills         *bool                   `yaml:"auto_sync_skills,omitempty" toml:"auto_sync_skills,omitempty" jsonschema:"description=Enable automatic syncing of skills on file change (default: true)"`
+	AutoSyncClaudeSettings *bool                   `yaml:"auto_sync_claude_settings,omitempty" toml:"auto_sync_claude_settings,omitempty" jsonschema:"description=Enable automatic syncing of .claude settings on file change (default: true)"`
+	SkillSyncDebounceMs    int                     `yaml:"skill_sync_debounce_ms,omitempty" toml:"skill_sync_debounce_ms,omitempty" jsonschema:"description=Debounce window for skill syncs in milliseconds (default: 1000)"`
+	Hooks                  *DaemonHooks            `yaml:"hooks,omitempty" toml:"hooks,omitempty" jsonschema:"description=Daemon-specific hooks configuration"`
+	Jobs                   *DaemonJobsConfig       `yaml:"jobs,omitempty" toml:"jobs,omitempty" jsonschema:"description=Job runner configuration"`
+	Build                  *BuildConfig            `yaml:"build,omitempty" toml:"build,omitempty" jsonschema:"description=Machine-wide build queue configuration"`
+	SSH                    *DaemonSSHConfig        `yaml:"ssh,omitempty" toml:"ssh,omitempty" jsonschema:"description=Embedded SSH server configuration"`
+	PairWithTreemux        *bool                   `yaml:"pair_with_treemux,omitempty" toml:"pair_with_treemux,omitempty" jsonschema:"description=Opt-in to kill daemon when the parent treemux exits"`
+	SessionGCInterval      string                  `yaml:"session_gc_interval,omitempty" toml:"session_gc_interval,omitempty" jsonschema:"description=How often to remove stale session artifacts as core sessions gc does (e.g. 6h; unset disables)"`
+	SessionGCAge           string                  `yaml:"session_gc_age,omitempty" toml:"session_gc_age,omitempty" jsonschema:"description=How long a stale session artifact must be untouched before the scheduled cleanup removes it (default: 24h)"`
+	DailyLogSummary        *bool                   `yaml:"daily_log_summary,omitempty" toml:"daily_log_summary,omitempty" jsonschema:"description=Write a markdown summary of each day's logs as core logs summarize does into the notebook inbox just after midnight: the daemon's workspace inbox\\, or the global notebook's for the global daemon (default: false)"`
+	IdleTimeout            string                  `yaml:"idle_timeout,omitempty" toml:"idle_timeout,omitempty" jsonschema:"description=Exit the daemon after this long with no connected clients; clients start it again on demand (e.g. 10m; 0 disables). Scoped daemons default to 2m; the global daemon only idles out when this is set"`
+	UpdateCoalesceInterval string                  `yaml:"update_coalesce_interval,omitempty" toml:"update_coalesce_interval,omitempty" jsonschema:"description=Window in which bursts of workspace and session updates are merged into one broadcast (default: 250ms; 0 disables)"`
+	UpdateQueueSize        int                     `yaml:"update_queue_size,omitempty" toml:"update_queue_size,omitempty" jsonschema:"description=Updates held per priority (sessions\\, workspaces\\, logs) while the daemon's store falls behind its collectors; the oldest are dropped beyond it (default: 256)"`
+	Collectors             *DaemonCollectorsConfig `yaml:"collectors,omitempty" toml:"collectors,omitempty" jsonschema:"description=Per-collector settings"`
+}
+
+// DaemonCollectorsConfig holds settings for individual daemon collectors.
+type DaemonCollectorsConfig struct {
+	Session *SessionCollectorConfig `yaml:"session,omitempty" toml:"session,omitempty" jsonschema:"description=Session collector settings"`
+}
+
+// SessionCollectorConfig configures the sources the session collector scans.
+type SessionCollectorConfig struct {
+	Providers     *SessionProvidersConfig `yaml:"providers,omitempty" toml:"providers,omitempty" jsonschema:"description=Per-source enable flags and scan intervals"`
+	IdleThreshold string                  `yaml:"idle_threshold,omitempty" toml:"idle_threshold,omitempty" jsonschema:"description=How long a live session may show no transcript or status activity before it is marked idle (default: 10m; 0 disables)"`
+	// ProviderPriority orders the sources whose report of a session wins when
+	// more than one reports the same agent; see daemon.SessionDedupPolicy.
+	ProviderPriority []string `yaml:"provider_priority,omitempty" toml:"provider_priority,omitempty" jsonschema:"description=Sources in the order their report wins when several report the same agent after PID presence and before recency (default: interactive then flow_jobs then opencode; list flow_jobs first to prefer flow jobs),enum=interactive,enum=flow_jobs,enum=opencode"`
+}
+
+// SessionProvidersConfig holds the settings of each session source.
+type SessionProvidersConfig struct {
+	Interactive *SessionProviderConfig `yaml:"interactive,omitempty" toml:"interactive,omitempty" jsonschema:"description=Interactive agent sessions registered by hooks (default interval: daemon.session_interval)"`
+	FlowJobs    *SessionProviderConfig `yaml:"flow_jobs,omitempty" toml:"flow_jobs,omitempty" jsonschema:"description=Flow job sessions from plan directories (default interval: daemon.session_interval)"`
+	OpenCode    *SessionProviderConfig `yaml:"opencode,omitempty" toml:"opencode,omitempty" jsonschema:"description=OpenCode sessions from its storage directory (default interval: 10s)"`
+}
+
+// SessionProviderConfig enables or disables one session source and sets how
+// often it is scanned.
+type SessionProviderConfig struct {
+	Enabled  *bool  `yaml:"enabled,omitempty" toml:"enabled,omitempty" jsonschema:"description=Scan this source (default: true)"`
+	Interval string `yaml:"interval,omitempty" toml:"interval,omitempty" jsonschema:"description=How often to scan this source (e.g. 30s)"`
 }
 
 // DaemonSSHConfig holds configuration for the embedded SSH server.
@@ -552,10 +654,20 @@ type SyncthingConfig struct {
 // ObsidianConfig holds settings for automated Obsidian vault setup.
 type ObsidianConfig struct {
 	VaultName      string `yaml:"vault_name,omitempty" toml:"vault_name,omitempty" jsonschema:"description=Display name for the generated Obsidian vault" jsonschema_extras:"x-layer=global,x-priority=45"`
-	AutoLinkPlugin bool   `yaml:"auto_link_plugin,omitempty" toml:"auto_link_plugin,omitempty" jsonschema:"description=Automatically symlink the nb-integration plugin on setup,default=false" jsonschema_extras:"x-layer=global,x-priority=46"`
+	AutoLinkPlugin bool   `yaml:"auto_link_plugin,omitempty" toml:"auto_link_plugin,omitempty" jsonschema:"description=Automatically symlink the nb-integration plugin on setup" default:"false" jsonschema_extras:"x-layer=global,x-priority=46"`
 	TemplateRepo   string `yaml:"template_repo,omitempty" toml:"template_repo,omitempty" jsonschema:"description=Git repo URL containing .obsidian template (e.g. github.com/user/obsidian-dotfiles)" jsonschema_extras:"x-layer=global,x-priority=47"`
 }
 
+// NotebookEncryptionConfig holds settings for encrypting a notebook's files
+// at rest, for notebooks kept in synced directories. The key itself is never
+// stored in config: it is resolved from the environment, a command (e.g. a
+// secrets manager) or a key file (see ResolveKey).
+type NotebookEncryptionConfig struct {
+	Enabled    bool   `yaml:"enabled,omitempty" toml:"enabled,omitempty" jsonschema:"description=Encrypt files written to this notebook and decrypt them on read" default:"false" jsonschema_extras:"x-layer=global,x-priority=48"`
+	KeyCommand string `yaml:"key_command,omitempty" toml:"key_command,omitempty" jsonschema:"description=Shell command printing the notebook key (e.g. a secrets manager)" jsonschema_extras:"x-layer=global,x-priority=49"`
+	KeyFile    string `yaml:"key_file,omitempty" toml:"key_file,omitempty" jsonschema:"description=File holding the notebook key" jsonschema_extras:"x-layer=global,x-priority=49"`
+}
+
 // Notebook defines the configuration for a single, named notebook system.
 type Notebook struct {
 	RootDir                string                     `yaml:"root_dir" toml:"root_dir" jsonschema:"description=Absolute path to the notebook root (enables Centralized Mode)"`
@@ -575,6 +687,9 @@ type Notebook struct {
 	Sync      *SyncConfig      `yaml:"sync,omitempty" toml:"-" jsonschema:"description=Synchronization configuration for this notebook"`
 	Syncthing *SyncthingConfig `yaml:"syncthing,omitempty" toml:"syncthing,omitempty" jsonschema:"description=Syncthing automated setup configuration"`
 	Obsidian  *ObsidianConfig  `yaml:"obsidian,omitempty" toml:"obsidian,omitempty" jsonschema:"description=Obsidian vault automated setup configuration"`
+	// Encryption, when enabled, keeps the notebook's files encrypted at
+	// rest; see NotebookEncryptionConfig.
+	Encryption *NotebookEncryptionConfig `yaml:"encryption,omitempty" toml:"encryption,omitempty" jsonschema:"description=Encryption at rest for this notebook's files"`
 }
 
 // WorktreeConfig holds settings for git worktrees.
@@ -584,6 +699,13 @@ type WorktreeConfig struct {
 	Layout string `yaml:"layout,omitempty" toml:"layout,omitempty" jsonschema:"description=Worktree layout: xdg (XDG data dir) or legacy (in-repo .grove-worktrees),enum=xdg,enum=legacy"`
 }
 
+// TelemetryConfig controls the opt-in local usage log written by grove CLIs
+// (see pkg/telemetry). Nothing is recorded unless Enabled is true, and
+// nothing is ever sent over the network.
+type TelemetryConfig struct {
+	Enabled *bool `yaml:"enabled,omitempty" toml:"enabled,omitempty" jsonschema:"description=Record command name/flag names/duration and exit status of grove CLI runs to a local telemetry.jsonl in the state dir (never sent anywhere)" default:"false" jsonschema_extras:"x-layer=global,x-priority=92"`
+}
+
 // OnboardingConfig tracks the first-run onboarding flow's persistent state,
 // written to the user-global layer (~/.config/grove/grove.toml). A nil
 // section reads as not-completed (treemux boots into the setup takeover);
@@ -591,7 +713,7 @@ type WorktreeConfig struct {
 type OnboardingConfig struct {
 	// Completed marks the flow finished; treemux stops entering the
 	// takeover on startup (re-runnable via `treemux start --onboard`).
-	Completed bool `yaml:"completed,omitempty" toml:"completed,omitempty" jsonschema:"description=First-run onboarding finished; treemux no longer enters the setup takeover on startup,default=false" jsonschema_extras:"x-layer=global,x-priority=90"`
+	Completed bool `yaml:"completed,omitempty" toml:"completed,omitempty" jsonschema:"description=First-run onboarding finished; treemux no longer enters the setup takeover on startup" default:"false" jsonschema_extras:"x-layer=global,x-priority=90"`
 	// LastStep is the resume marker for a mid-run quit; cleared when the
 	// flow completes.
 	LastStep string `yaml:"last_step,omitempty" toml:"last_step,omitempty" jsonschema:"description=Step ID the onboarding flow last persisted (resume marker; cleared on completion)" jsonschema_extras:"x-layer=global,x-priority=91"`
@@ -611,6 +733,17 @@ type Config struct {
 	Workspaces []string `yaml:"workspaces,omitempty" toml:"workspaces,omitempty" jsonschema:"description=Glob patterns for workspace directories in this ecosystem"`
 	BuildCmd   string   `yaml:"build_cmd,omitempty" toml:"build_cmd,omitempty" jsonschema:"description=Custom build command (default: make build)"`
 	BuildAfter []string `yaml:"build_after,omitempty" toml:"build_after,omitempty" jsonschema:"description=Projects that must be built before this one"`
+	Tags       []string `yaml:"tags,omitempty" toml:"tags,omitempty" jsonschema:"description=Labels for selecting this project in aggregate commands such as core each --tag"`
+
+	// Owners and Links give people context about the project: who looks
+	// after it, and where its docs, dashboards or tracker live. Discovery
+	// carries them, with Tags and Description, into workspace nodes.
+	Owners []string          `yaml:"owners,omitempty" toml:"owners,omitempty" jsonschema:"description=People or teams responsible for this project"`
+	Links  map[string]string `yaml:"links,omitempty" toml:"links,omitempty" jsonschema:"description=Named URLs for this project such as docs or dashboards"`
+
+	// WorkspacesFrom adds the workspaces listed by a remote manifest to
+	// Workspaces when the config is parsed (see manifest.go).
+	WorkspacesFrom *WorkspacesFromConfig `yaml:"workspaces_from,omitempty" toml:"workspaces_from,omitempty"`
 
 	Notebooks *NotebooksConfig `yaml:"notebooks,omitempty" toml:"notebooks,omitempty" jsonschema:"description=Notebook configuration"`
 	TUI       *TUIConfig       `yaml:"tui,omitempty" toml:"tui,omitempty" jsonschema:"description=TUI appearance and behavior settings"`
@@ -631,6 +764,14 @@ type Config struct {
 
 	Onboarding *OnboardingConfig `yaml:"onboarding,omitempty" toml:"onboarding,omitempty" jsonschema:"description=First-run onboarding progress (completed marker + resume step)"`
 
+	Telemetry *TelemetryConfig `yaml:"telemetry,omitempty" toml:"telemetry,omitempty" jsonschema:"description=Opt-in local command usage log"`
+
+	// WorkspacesLogging, set in an ecosystem config, holds logging section
+	// overrides for member projects keyed by project name or path relative
+	// to the ecosystem root. They are merged over the member's own logging
+	// settings when it is loaded.
+	WorkspacesLogging map[string]map[string]interface{} `yaml:"workspaces_logging,omitempty" toml:"workspaces_logging,omitempty" jsonschema:"description=Logging overrides for member projects keyed by project name"`
+
 	// Extensions captures all other top-level keys for extensibility.
 	Extensions map[string]interface{} `yaml:",inline" toml:"-" jsonschema:"-"`
 }
@@ -640,24 +781,30 @@ type Config struct {
 func (c *Config) UnmarshalYAML(node *yaml.Node) error {
 	// Create a temporary struct with all fields to capture the data, including legacy ones.
 	type rawConfig struct {
-		Name             string                        `yaml:"name,omitempty"`
-		Version          string                        `yaml:"version"`
-		Workspaces       []string                      `yaml:"workspaces,omitempty"`
-		BuildCmd         string                        `yaml:"build_cmd,omitempty"`
-		BuildAfter       []string                      `yaml:"build_after,omitempty"`
-		Notebooks        *NotebooksConfig              `yaml:"notebooks,omitempty"`
-		TUI              *TUIConfig                    `yaml:"tui,omitempty"`
-		Context          *ContextConfig                `yaml:"context,omitempty"`
-		Daemon           *DaemonConfig                 `yaml:"daemon,omitempty"`
-		Environment      *EnvironmentConfig            `yaml:"environment,omitempty"`
-		Environments     map[string]*EnvironmentConfig `yaml:"environments,omitempty"`
-		Groves           map[string]GroveSourceConfig  `yaml:"groves,omitempty"`
-		ExplicitProjects []ExplicitProject             `yaml:"explicit_projects,omitempty"`
-		Commands         map[string]string             `yaml:"commands,omitempty"`
-		TestScopes       []TestScopeConfig             `yaml:"test_scopes,omitempty"`
-		Worktree         *WorktreeConfig               `yaml:"worktree,omitempty"`
-		Onboarding       *OnboardingConfig             `yaml:"onboarding,omitempty"`
-		Extensions       map[string]interface{}        `yaml:",inline"`
+		Name              string                            `yaml:"name,omitempty"`
+		Version           string                            `yaml:"version"`
+		Workspaces        []string                          `yaml:"workspaces,omitempty"`
+		WorkspacesFrom    *WorkspacesFromConfig             `yaml:"workspaces_from,omitempty"`
+		BuildCmd          string                            `yaml:"build_cmd,omitempty"`
+		BuildAfter        []string                          `yaml:"build_after,omitempty"`
+		Tags              []string                          `yaml:"tags,omitempty"`
+		Owners            []string                          `yaml:"owners,omitempty"`
+		Links             map[string]string                 `yaml:"links,omitempty"`
+		Notebooks         *NotebooksConfig                  `yaml:"notebooks,omitempty"`
+		TUI               *TUIConfig                        `yaml:"tui,omitempty"`
+		Context           *ContextConfig                    `yaml:"context,omitempty"`
+		Daemon            *DaemonConfig                     `yaml:"daemon,omitempty"`
+		Environment       *EnvironmentConfig                `yaml:"environment,omitempty"`
+		Environments      map[string]*EnvironmentConfig     `yaml:"environments,omitempty"`
+		Groves            map[string]GroveSourceConfig      `yaml:"groves,omitempty"`
+		ExplicitProjects  []ExplicitProject                 `yaml:"explicit_projects,omitempty"`
+		Commands          map[string]string                 `yaml:"commands,omitempty"`
+		TestScopes        []TestScopeConfig                 `yaml:"test_scopes,omitempty"`
+		Worktree          *WorktreeConfig                   `yaml:"worktree,omitempty"`
+		Onboarding        *OnboardingConfig                 `yaml:"onboarding,omitempty"`
+		Telemetry         *TelemetryConfig                  `yaml:"telemetry,omitempty"`
+		WorkspacesLogging map[string]map[string]interface{} `yaml:"workspaces_logging,omitempty"`
+		Extensions        map[string]interface{}            `yaml:",inline"`
 
 		// --- Legacy Fields for Backward Compatibility ---
 		SearchPaths       map[string]SearchPathConfig `yaml:"search_paths,omitempty"`        // Old name for Groves
@@ -676,8 +823,12 @@ func (c *Config) UnmarshalYAML(node *yaml.Node) error {
 	c.Name = raw.Name
 	c.Version = raw.Version
 	c.Workspaces = raw.Workspaces
+	c.WorkspacesFrom = raw.WorkspacesFrom
 	c.BuildCmd = raw.BuildCmd
 	c.BuildAfter = raw.BuildAfter
+	c.Tags = raw.Tags
+	c.Owners = raw.Owners
+	c.Links = raw.Links
 	c.TUI = raw.TUI
 	c.Context = raw.Context
 	c.Daemon = raw.Daemon
@@ -688,6 +839,8 @@ func (c *Config) UnmarshalYAML(node *yaml.Node) error {
 	c.TestScopes = raw.TestScopes
 	c.Worktree = raw.Worktree
 	c.Onboarding = raw.Onboarding
+	c.Telemetry = raw.Telemetry
+	c.WorkspacesLogging = raw.WorkspacesLogging
 	c.Extensions = raw.Extensions
 
 	// Handle backward compatibility for `search_paths` -> `groves`
@@ -800,6 +953,14 @@ func (c *Config) SetDefaults() {
 	}
 }
 
+// Description returns the top-level description of the project. It is
+// kept as an extension key rather than a Config field because other tools
+// already read it from Extensions.
+func (c *Config) Description() string {
+	desc, _ := c.Extensions["description"].(string)
+	return desc
+}
+
 // UnmarshalExtension decodes a specific extension's configuration from the
 // loaded grove.yml into the provided target struct. The target must be a pointer.
 // This provides a type-safe way for extensions to access their
@@ -879,6 +1040,7 @@ const (
 	SourceProjectNotebook ConfigSource = "project-notebook"
 	SourceProject         ConfigSource = "project"
 	SourceOverride        ConfigSource = "override"
+	SourceCommandLine     ConfigSource = "command-line" // --set key=value
 	SourceUnknown         ConfigSource = "unknown"
 )
 
@@ -900,6 +1062,7 @@ type LayeredConfig struct {
 	ProjectNotebook *Config                 // Raw config from the project's notebook directory.
 	Project         *Config                 // Raw config from the local project file.
 	Overrides       []OverrideSource        // Raw configs from override files, in order of application.
+	CommandLine     *Config                 // In-memory config from --set key=value flags.
 	Final           *Config                 // The fully merged and validated config.
 	FilePaths       map[ConfigSource]string // Maps sources to their file paths.
 }
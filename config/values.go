@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValueTypeError reports a config value that cannot be converted to the type
+// an accessor asked for.
+type ValueTypeError struct {
+	Key   string
+	Value interface{}
+	Want  string
+	Err   error
+}
+
+func (e *ValueTypeError) Error() string {
+	msg := fmt.Sprintf("config key %s: cannot use %v (%T) as %s", e.Key, e.Value, e.Value, e.Want)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ValueTypeError) Unwrap() error { return e.Err }
+
+// Value returns the value at a dotted key path such as "flow.model" or
+// "daemon.git_interval", and whether it is set. Extension sections are read
+// as written; core keys are read by their YAML names. A null value counts
+// as unset.
+func Value(cfg *Config, key string) (interface{}, bool, error) {
+	if cfg == nil {
+		return nil, false, nil
+	}
+	parts, err := splitKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var tree interface{}
+	if _, ok := cfg.Extensions[parts[0]]; ok {
+		tree = cfg.Extensions
+	} else {
+		raw, err := yaml.Marshal(cfg)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to render config: %w", err)
+		}
+		if err := yaml.Unmarshal(raw, &tree); err != nil {
+			return nil, false, fmt.Errorf("failed to render config: %w", err)
+		}
+	}
+
+	v, ok, err := LookupKey(tree, key)
+	if err != nil || !ok || v == nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+// GetString returns the value at key as a string, or def when it is unset.
+// Numbers and booleans are formatted; tables and lists are an error.
+func GetString(cfg *Config, key, def string) (string, error) {
+	v, ok, err := Value(cfg, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(x), nil
+	}
+	return def, &ValueTypeError{Key: key, Value: v, Want: "string"}
+}
+
+// GetInt returns the value at key as an int, or def when it is unset.
+// Whole floats and numeric strings are converted.
+func GetInt(cfg *Config, key string, def int) (int, error) {
+	v, ok, err := Value(cfg, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	if n, ok := intValue(v); ok {
+		return n, nil
+	}
+	if s, ok := v.(string); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return def, &ValueTypeError{Key: key, Value: v, Want: "int", Err: err}
+		}
+		return n, nil
+	}
+	return def, &ValueTypeError{Key: key, Value: v, Want: "int"}
+}
+
+// GetBool returns the value at key as a bool, or def when it is unset.
+// Strings accepted by strconv.ParseBool are converted.
+func GetBool(cfg *Config, key string, def bool) (bool, error) {
+	v, ok, err := Value(cfg, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	switch x := v.(type) {
+	case bool:
+		return x, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(x))
+		if err != nil {
+			return def, &ValueTypeError{Key: key, Value: v, Want: "bool", Err: err}
+		}
+		return b, nil
+	}
+	return def, &ValueTypeError{Key: key, Value: v, Want: "bool"}
+}
+
+// GetDuration returns the value at key as a duration, or def when it is
+// unset. Strings are parsed with time.ParseDuration ("30s", "1h30m");
+// whole numbers are taken as seconds.
+func GetDuration(cfg *Config, key string, def time.Duration) (time.Duration, error) {
+	v, ok, err := Value(cfg, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	if s, ok := v.(string); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return def, &ValueTypeError{Key: key, Value: v, Want: "duration", Err: err}
+		}
+		return d, nil
+	}
+	if n, ok := intValue(v); ok {
+		return time.Duration(n) * time.Second, nil
+	}
+	return def, &ValueTypeError{Key: key, Value: v, Want: "duration"}
+}
+
+// intValue converts the integer types yaml and toml decode numbers into,
+// and floats with no fractional part.
+func intValue(v interface{}) (int, bool) {
+	switch x := v.(type) {
+	case int:
+		return x, true
+	case int64:
+		return int(x), true
+	case uint64:
+		if x <= math.MaxInt {
+			return int(x), true
+		}
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) <= math.MaxInt64 {
+			return int(x), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValueAccessors(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+name: demo
+daemon:
+  git_interval: 15s
+flow:
+  model: opus
+  max_jobs: 4
+  retries: "3"
+  ratio: 2.5
+  timeout: 90
+  verbose: "true"
+  unset:
+  agent:
+    poll: 500ms
+    tags: [a, b]
+`), &cfg))
+
+	s, err := GetString(&cfg, "flow.model", "sonnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "opus", s)
+
+	s, err = GetString(&cfg, "flow.max_jobs", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", s, "numbers are formatted as strings")
+
+	s, err = GetString(&cfg, "name", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "demo", s, "core keys are readable by their YAML names")
+
+	s, err = GetString(&cfg, "flow.missing", "fallback")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", s)
+
+	s, err = GetString(&cfg, "flow.unset", "fallback")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", s, "a null value counts as unset")
+
+	n, err := GetInt(&cfg, "flow.max_jobs", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	n, err = GetInt(&cfg, "flow.retries", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n, "numeric strings are converted")
+
+	d, err := GetDuration(&cfg, "daemon.git_interval", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Second, d)
+
+	d, err = GetDuration(&cfg, "flow.agent.poll", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, d)
+
+	d, err = GetDuration(&cfg, "flow.timeout", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d, "whole numbers are seconds")
+
+	b, err := GetBool(&cfg, "flow.verbose", false)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	d, err = GetDuration(nil, "daemon.git_interval", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, d, "a nil config yields the default")
+}
+
+func TestValueAccessorErrors(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+flow:
+  ratio: 2.5
+  model: opus
+  agent:
+    tags: [a, b]
+`), &cfg))
+
+	n, err := GetInt(&cfg, "flow.ratio", 7)
+	assert.Equal(t, 7, n, "the default is returned on error")
+	var typeErr *ValueTypeError
+	require.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "flow.ratio", typeErr.Key)
+	assert.Equal(t, "int", typeErr.Want)
+
+	_, err = GetInt(&cfg, "flow.model", 0)
+	assert.ErrorContains(t, err, "config key flow.model: cannot use opus (string) as int")
+
+	_, err = GetDuration(&cfg, "flow.model", 0)
+	assert.ErrorContains(t, err, "as duration")
+
+	_, err = GetString(&cfg, "flow.agent.tags", "")
+	assert.ErrorContains(t, err, "as string")
+
+	_, err = GetBool(&cfg, "flow.model", false)
+	assert.ErrorContains(t, err, "as bool")
+
+	_, err = GetString(&cfg, "flow..model", "")
+	assert.ErrorContains(t, err, "empty path segment")
+}
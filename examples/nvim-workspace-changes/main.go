@@ -0,0 +1,104 @@
+// Command nvim-workspace-changes is a reference adapter between the grove
+// daemon's workspace change stream and a Neovim plugin. Neovim starts it as
+// an RPC job:
+//
+//	local chan = vim.fn.jobstart({ "nvim-workspace-changes" }, { rpc = true })
+//
+// It fires User autocmds in the editor instead of the plugin re-running
+// `core ws` to refresh a project picker:
+//
+//   - GroveWorkspaceSnapshot on each (re)connect, with data = list of
+//     workspaces, to seed the picker.
+//   - GroveWorkspaceChanged for each change, with data = { type, workspace }
+//     where type is WorkspaceAdded, WorkspaceRemoved or WorkspaceModified.
+//
+// See plugin.lua next to this file for the editor side.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+
+	"github.com/neovim/go-client/nvim"
+
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/models"
+)
+
+const fireAutocmd = `vim.api.nvim_exec_autocmds("User", { pattern = ..., data = select(2, ...) })`
+
+func main() {
+	// stdout carries msgpack-rpc, so diagnostics go to stderr (the job's
+	// on_stderr handler).
+	log.SetOutput(os.Stderr)
+
+	v, err := nvim.New(os.Stdin, os.Stdout, os.Stdout, log.Printf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// Serve returns when Neovim closes the channel.
+		if err := v.Serve(); err != nil {
+			log.Print(err)
+		}
+		cancel()
+	}()
+
+	client := daemon.New()
+	defer client.Close()
+
+	backoff := time.Second
+	for ctx.Err() == nil {
+		if err := forward(ctx, v, client); err != nil {
+			log.Printf("workspace stream: %v (retrying in %s)", err, backoff)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// forward sends a snapshot, then relays change events until the stream
+// ends.
+func forward(ctx context.Context, v *nvim.Nvim, client daemon.Client) error {
+	changes, err := client.StreamWorkspaceChanges(ctx)
+	if err != nil {
+		return err
+	}
+	// Subscribe before taking the snapshot so no change falls between them.
+	nodes, err := client.GetWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+	if err := notify(v, "GroveWorkspaceSnapshot", models.NewWorkspaces(nodes)); err != nil {
+		return err
+	}
+	for change := range changes {
+		if err := notify(v, "GroveWorkspaceChanged", change); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// notify fires a User autocmd with payload as its data. The payload goes
+// through JSON so Lua sees the same keys as `core ws --json`.
+func notify(v *nvim.Nvim, pattern string, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var data interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return err
+	}
+	return v.ExecLua(fireAutocmd, nil, pattern, data)
+}
@@ -0,0 +1,54 @@
+-- Editor side of the nvim-workspace-changes adapter: keeps a table of
+-- workspaces keyed by path, updated from the grove daemon's change stream.
+--
+--   require("grove_workspaces").setup()
+--   require("grove_workspaces").list()  -- for a picker
+
+local M = { workspaces = {} }
+
+function M.setup(opts)
+  opts = opts or {}
+  local group = vim.api.nvim_create_augroup("GroveWorkspaces", { clear = true })
+
+  vim.api.nvim_create_autocmd("User", {
+    group = group,
+    pattern = "GroveWorkspaceSnapshot",
+    callback = function(ev)
+      M.workspaces = {}
+      for _, ws in ipairs(ev.data) do
+        M.workspaces[ws.path] = ws
+      end
+    end,
+  })
+
+  vim.api.nvim_create_autocmd("User", {
+    group = group,
+    pattern = "GroveWorkspaceChanged",
+    callback = function(ev)
+      local ws = ev.data.workspace
+      if ev.data.type == "WorkspaceRemoved" then
+        M.workspaces[ws.path] = nil
+      else
+        M.workspaces[ws.path] = ws
+      end
+    end,
+  })
+
+  M.job = vim.fn.jobstart({ opts.cmd or "nvim-workspace-changes" }, {
+    rpc = true,
+    on_stderr = function(_, lines)
+      local msg = table.concat(lines, "\n")
+      if msg ~= "" then
+        vim.notify(msg, vim.log.levels.DEBUG, { title = "grove workspaces" })
+      end
+    end,
+  })
+end
+
+function M.list()
+  local out = vim.tbl_values(M.workspaces)
+  table.sort(out, function(a, b) return a.path < b.path end)
+  return out
+end
+
+return M
@@ -0,0 +1,13 @@
+//go:build !windows
+
+// Package detach starts background servers (groved, the tuimux server)
+// detached from the terminal that launched them.
+package detach
+
+import "syscall"
+
+// SysProcAttr starts a process in its own session so it survives the
+// launching terminal's exit (no SIGHUP from the terminal's process group).
+func SysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
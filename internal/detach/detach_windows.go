@@ -0,0 +1,17 @@
+//go:build windows
+
+// Package detach starts background servers (groved, the tuimux server)
+// detached from the terminal that launched them.
+package detach
+
+import "syscall"
+
+// detachedProcess is the DETACHED_PROCESS creation flag: the process gets
+// no console, so closing the launching terminal does not terminate it.
+const detachedProcess = 0x00000008
+
+// SysProcAttr starts a process in its own process group, detached from the
+// launching console.
+func SysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}
@@ -0,0 +1,18 @@
+// Package shell runs user-configured command strings through the platform
+// shell.
+package shell
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+)
+
+// Command returns a command that runs command with cmd /C on Windows and
+// sh -c elsewhere.
+func Command(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command) //nolint:gosec // command comes from the user's config
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // command comes from the user's config
+}
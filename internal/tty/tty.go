@@ -0,0 +1,21 @@
+// Package tty reports whether the streams a command works with are
+// terminals.
+package tty
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// IsTerminal reports whether v, typically a command's stdin, stdout or
+// stderr, is an *os.File connected to a terminal (including a Cygwin or
+// MSYS pty).
+func IsTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	fd := f.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
@@ -0,0 +1,226 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultAsyncQueueSize is the number of formatted entries an async file
+	// sink buffers before the overflow policy applies.
+	defaultAsyncQueueSize = 1024
+
+	// OverflowBlock makes a full queue block the logging goroutine until
+	// the writer catches up. No entries are lost.
+	OverflowBlock = "block"
+	// OverflowDrop discards new entries while the queue is full so the
+	// caller never waits on disk I/O. Drops are counted and reported once
+	// on stderr.
+	OverflowDrop = "drop"
+
+	// flushTimeout bounds how long Flush waits for each sink to drain.
+	flushTimeout = 2 * time.Second
+)
+
+// asyncItem is one queued write, or a flush barrier when ack is non-nil.
+type asyncItem struct {
+	line []byte
+	ack  chan struct{}
+}
+
+// asyncWriter moves file writes off the logging goroutine. Entries are
+// queued already formatted and written in order by a single background
+// goroutine; Flush waits until everything queued before it is on disk.
+type asyncWriter struct {
+	out      io.Writer
+	queue    chan asyncItem
+	done     chan struct{}
+	drop     bool
+	dropped  atomic.Int64
+	reported atomic.Bool
+
+	// mu guards closed against sends on a closed queue: senders hold the
+	// read lock, close the write lock.
+	mu     sync.RWMutex
+	closed bool
+}
+
+var (
+	asyncWritersMu sync.Mutex
+	asyncWriters   = make(map[string]*asyncWriter)
+	exitHookOnce   sync.Once
+)
+
+// sharedAsyncWriter returns the async writer for the file sink named key,
+// starting one over the writer open returns the first time key is seen.
+// Every component logger writing to the sink shares it, so a process runs one
+// background goroutine and holds one file handle per sink; its queue size
+// and overflow policy are those of the first logger. Flush drains shared
+// writers and Reset closes them.
+func sharedAsyncWriter(key string, open func() (io.Writer, error), size int, policy string) (*asyncWriter, error) {
+	asyncWritersMu.Lock()
+	defer asyncWritersMu.Unlock()
+	if w, ok := asyncWriters[key]; ok {
+		return w, nil
+	}
+	out, err := open()
+	if err != nil {
+		return nil, err
+	}
+	w := newAsyncWriter(out, size, policy)
+	asyncWriters[key] = w
+
+	// logger.Fatal exits via logrus.Exit, which runs registered handlers;
+	// make sure queued entries (including the fatal one) reach disk.
+	exitHookOnce.Do(func() { logrus.RegisterExitHandler(Flush) })
+	return w, nil
+}
+
+// newAsyncWriter starts a background writer for out. size <= 0 selects
+// defaultAsyncQueueSize; policy is OverflowBlock or OverflowDrop (anything
+// else is treated as OverflowBlock).
+func newAsyncWriter(out io.Writer, size int, policy string) *asyncWriter {
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+	w := &asyncWriter{
+		out:   out,
+		queue: make(chan asyncItem, size),
+		done:  make(chan struct{}),
+		drop:  policy == OverflowDrop,
+	}
+	go w.run()
+	return w
+}
+
+// run writes queued entries until the queue is closed, then closes out.
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for item := range w.queue {
+		if item.ack != nil {
+			close(item.ack)
+			continue
+		}
+		_, _ = w.out.Write(item.line)
+	}
+	if c, ok := w.out.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+// Write implements io.Writer. p is copied, since logrus reuses its buffers.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	line := make([]byte, len(p))
+	copy(line, p)
+	item := asyncItem{line: line}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		// Loggers can outlive a Reset; their entries have nowhere to go.
+		return len(p), nil
+	}
+	if !w.drop {
+		w.queue <- item
+		return len(p), nil
+	}
+	select {
+	case w.queue <- item:
+	default:
+		w.dropped.Add(1)
+		if w.reported.CompareAndSwap(false, true) {
+			fmt.Fprintf(os.Stderr, "grove-log: file log queue full, dropping entries (logging.file.overflow=drop)\n")
+		}
+	}
+	return len(p), nil
+}
+
+// Dropped returns the number of entries discarded by the drop policy.
+func (w *asyncWriter) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// flush blocks until every entry queued before the call has been written,
+// or timeout elapses. It reports whether the queue drained in time.
+func (w *asyncWriter) flush(timeout time.Duration) bool {
+	ack := make(chan struct{})
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	w.mu.RLock()
+	if w.closed {
+		w.mu.RUnlock()
+		return true
+	}
+	select {
+	case w.queue <- asyncItem{ack: ack}:
+	case <-timer.C:
+		w.mu.RUnlock()
+		return false
+	}
+	w.mu.RUnlock()
+	select {
+	case <-ack:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// close stops the writer once everything already queued is written, waiting
+// at most timeout, and closes out. Later writes are discarded. It reports
+// whether the writer stopped in time.
+func (w *asyncWriter) close(timeout time.Duration) bool {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Flush waits for all async file sinks to write their queued entries. Call
+// it before exiting a process that enables logging.file.async; cli.Execute
+// does so automatically, and logger.Fatal flushes via a logrus exit handler.
+// Each sink gets a bounded wait, so Flush never hangs on a stuck disk.
+func Flush() {
+	for _, w := range sharedAsyncWriters(false) {
+		w.flush(flushTimeout)
+	}
+}
+
+// closeAsyncWriters closes every shared async writer, writing what they
+// have queued, and forgets them so new loggers start fresh ones.
+func closeAsyncWriters() {
+	for _, w := range sharedAsyncWriters(true) {
+		w.close(flushTimeout)
+	}
+}
+
+// sharedAsyncWriters returns the registered async writers, unregistering
+// them when remove is set.
+func sharedAsyncWriters(remove bool) []*asyncWriter {
+	asyncWritersMu.Lock()
+	defer asyncWritersMu.Unlock()
+	writers := make([]*asyncWriter, 0, len(asyncWriters))
+	for _, w := range asyncWriters {
+		writers = append(writers, w)
+	}
+	if remove {
+		asyncWriters = make(map[string]*asyncWriter)
+	}
+	return writers
+}
@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter blocks every Write until release is closed.
+type gatedWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	release chan struct{}
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	<-g.release
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gatedWriter) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.String()
+}
+
+func TestAsyncWriterFlushWritesInOrder(t *testing.T) {
+	out := &gatedWriter{release: make(chan struct{})}
+	close(out.release)
+	w := newAsyncWriter(out, 4, OverflowBlock)
+
+	for _, line := range []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !w.flush(flushTimeout) {
+		t.Fatal("flush timed out")
+	}
+	if got := out.String(); got != "a\nb\nc\nd\ne\nf\n" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
+
+func TestAsyncWriterDropPolicyNeverBlocks(t *testing.T) {
+	out := &gatedWriter{release: make(chan struct{})}
+	w := newAsyncWriter(out, 2, OverflowDrop)
+
+	// The background goroutine holds one entry in the gated Write; the
+	// queue holds two more; everything after that is dropped.
+	for i := 0; i < 10; i++ {
+		w.Write([]byte("x\n"))
+	}
+	if w.Dropped() == 0 {
+		t.Error("expected entries to be dropped while the writer is stalled")
+	}
+
+	close(out.release)
+	w.flush(flushTimeout)
+	if got := int64(len(out.String()) / 2); got+w.Dropped() != 10 {
+		t.Errorf("written (%d) + dropped (%d) should account for all 10 entries", got, w.Dropped())
+	}
+}
+
+func TestAsyncWriterCopiesBuffer(t *testing.T) {
+	out := &gatedWriter{release: make(chan struct{})}
+	close(out.release)
+	w := newAsyncWriter(out, 4, OverflowBlock)
+
+	buf := []byte("first\n")
+	w.Write(buf)
+	copy(buf, "XXXXX\n")
+	w.flush(flushTimeout)
+	if got := out.String(); got != "first\n" {
+		t.Errorf("queued entry was mutated by the caller: %q", got)
+	}
+}
+
+// closingWriter records whether it was closed.
+type closingWriter struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closingWriter) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestSharedAsyncWriterPerPath(t *testing.T) {
+	t.Cleanup(closeAsyncWriters)
+	opened := 0
+	out := &closingWriter{}
+	open := func() (io.Writer, error) {
+		opened++
+		return out, nil
+	}
+
+	a, err := sharedAsyncWriter("/logs/a.log", open, 4, OverflowBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := sharedAsyncWriter("/logs/a.log", open, 4, OverflowBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b || opened != 1 {
+		t.Fatalf("loggers of one sink should share a writer (opened %d)", opened)
+	}
+
+	a.Write([]byte("queued\n"))
+	closeAsyncWriters()
+	if got := out.String(); got != "queued\n" {
+		t.Errorf("close should write queued entries, got %q", got)
+	}
+	if !out.closed {
+		t.Error("close should close the underlying writer")
+	}
+	// Loggers still holding the writer after Reset must not panic.
+	a.Write([]byte("late\n"))
+	a.flush(flushTimeout)
+
+	if c, _ := sharedAsyncWriter("/logs/a.log", open, 4, OverflowBlock); c == a || opened != 2 {
+		t.Error("a closed writer should be replaced by a fresh one")
+	}
+}
+
+func TestSharedAsyncWriterAcrossDays(t *testing.T) {
+	t.Cleanup(closeAsyncWriters)
+	dir := t.TempDir()
+	pathFn, key := datedLogPath(dir, "system")
+	cfg := FileSinkConfig{Async: true}
+
+	day1 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	day2 := day1.Add(24 * time.Hour)
+	a, err := openFileSink(pathFn, key, cfg, func() time.Time { return day1 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A logger created after midnight must join the sink's writer, which
+	// rolls to the new day's file itself, rather than start a second one.
+	b, err := openFileSink(pathFn, key, cfg, func() time.Time { return day2 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Fatal("loggers created on different days should share the sink's async writer")
+	}
+	if n := len(sharedAsyncWriters(false)); n != 1 {
+		t.Errorf("expected one async writer for the sink, got %d", n)
+	}
+}
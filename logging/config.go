@@ -50,28 +50,76 @@ type ComponentFilteringConfig struct {
 
 // Config defines the structure for logging configuration in grove.yml.
 type Config struct {
-	// Level is the minimum log level to output (e.g., "debug", "info", "warn", "error").
+	// Level is the minimum log level to output (e.g., "trace", "debug", "info", "warn", "error").
 	// Can be overridden by the GROVE_LOG_LEVEL environment variable.
-	Level string `yaml:"level" toml:"level" jsonschema:"description=Minimum log level (debug/info/warn/error),default=info,enum=debug,enum=info,enum=warn,enum=error" jsonschema_extras:"x-layer=global,x-priority=60"`
+	Level string `yaml:"level" toml:"level" jsonschema:"description=Minimum log level (trace/debug/info/warn/error),enum=trace,enum=debug,enum=info,enum=warn,enum=error" default:"info" jsonschema_extras:"x-layer=global,x-priority=60"`
 
 	// SystemLevel is the minimum log level for system-scoped logging (daemon, global tools).
 	// When set, overrides Level for processes running in ScopeSystem.
 	// Prefer file.level for targeted debug capture in the file sink, or the
 	// GROVE_LOG_LEVEL=debug environment variable for one-shot debugging;
 	// system_level=debug makes the daemon verbose on every sink.
-	SystemLevel string `yaml:"system_level,omitempty" toml:"system_level,omitempty" jsonschema:"description=Minimum log level for system/daemon logs (debug/info/warn/error). Prefer file.level for targeted file capture or GROVE_LOG_LEVEL=debug for one-shot debugging,enum=debug,enum=info,enum=warn,enum=error" jsonschema_extras:"x-layer=global,x-priority=61"`
+	SystemLevel string `yaml:"system_level,omitempty" toml:"system_level,omitempty" jsonschema:"description=Minimum log level for system/daemon logs (trace/debug/info/warn/error). Prefer file.level for targeted file capture or GROVE_LOG_LEVEL=debug for one-shot debugging,enum=trace,enum=debug,enum=info,enum=warn,enum=error" jsonschema_extras:"x-layer=global,x-priority=61"`
 
 	// ReportCaller, if true, includes the file, line, and function name in the log output.
 	// Can be enabled with the GROVE_LOG_CALLER=true environment variable.
-	ReportCaller bool `yaml:"report_caller" toml:"report_caller" jsonschema:"description=Include file/line/function in log output,default=true" jsonschema_extras:"x-layer=global,x-priority=65"`
+	ReportCaller bool `yaml:"report_caller" toml:"report_caller" jsonschema:"description=Include file/line/function in log output" default:"true" jsonschema_extras:"x-layer=global,x-priority=65"`
 
-	// LogStartup, if true, logs "Grove binary started" on first logger initialization.
+	// LogStartup, if true, logs a structured "Grove binary started" banner
+	// (version, commit, config layers, effective level, hostname, pid) once
+	// per process. The banner bypasses the level filters so it always marks
+	// the start of a run.
 	// Defaults to false.
-	LogStartup bool `yaml:"log_startup" toml:"log_startup" jsonschema:"description=Log 'Grove binary started' on first init,default=false" jsonschema_extras:"x-layer=global,x-priority=90"`
+	LogStartup bool `yaml:"log_startup" toml:"log_startup" jsonschema:"description=Log a startup banner (version and commit; config layers; level; host and pid) once per process" default:"false" jsonschema_extras:"x-layer=global,x-priority=90"`
+
+	// Redact lists field names or regular expressions (matched
+	// case-insensitively against the whole key, e.g. "password", "token",
+	// ".*_secret") whose values are masked before entries reach the console
+	// or file sinks. Nested maps, slices and structs are masked too.
+	Redact []string `yaml:"redact,omitempty" toml:"redact,omitempty" jsonschema:"description=Field names or regexes (e.g. password or .*_secret) whose values are masked in console and file output" jsonschema_extras:"x-layer=global,x-priority=69"`
+
+	// Escalations raise the level of entries matching known-bad patterns,
+	// e.g. a "connection refused" warning from the db component recorded
+	// as an error, without changing the code that logs them. Escalated
+	// entries carry an escalated_from field with their original level.
+	Escalations []EscalationRule `yaml:"escalations,omitempty" toml:"escalations,omitempty" jsonschema:"description=Rules raising matching entries to a more severe level (e.g. known-bad warnings recorded as errors)" jsonschema_extras:"x-layer=global,x-priority=70"`
+
+	// ValidateEntries, if true, validates every emitted entry, as the file
+	// sink writes it, against the log-entry JSON schema (required
+	// time/level/msg/component fields and their types) and reports
+	// violations on stderr. It only applies when file.format is json.
+	// Intended for debugging producers whose entries break the `core logs`
+	// and TUI parsers.
+	ValidateEntries bool `yaml:"validate_entries,omitempty" toml:"validate_entries,omitempty" jsonschema:"description=Debug: validate every emitted log entry against the log-entry schema and report violations on stderr" default:"false" jsonschema_extras:"x-layer=global,x-priority=91"`
+
+	// RecentEntries is how many of the most recent entries the process
+	// keeps in memory for RecentEntries, so long-running binaries can serve
+	// their own recent logs without re-reading files. 0 means the default
+	// (500); a negative value disables the buffer.
+	RecentEntries int `yaml:"recent_entries,omitempty" toml:"recent_entries,omitempty" jsonschema:"description=Recent log entries kept in memory for status endpoints (0 = default of 500; negative disables)" default:"500" jsonschema_extras:"x-layer=global,x-priority=95"`
+
+	// TimeFormat sets how timestamps are written by the text and JSON
+	// formatters: "rfc3339", "rfc3339nano", "unix_ms" (a number in JSON
+	// entries), or a custom Go layout such as "2006-01-02 15:04:05.000 MST".
+	// Unset keeps each formatter's default ("2006-01-02 15:04:05" for text,
+	// rfc3339 for JSON). `core logs` and the logs TUI parse every one of
+	// these; custom layouts in JSON files should include a zone offset.
+	TimeFormat string `yaml:"time_format,omitempty" toml:"time_format,omitempty" jsonschema:"description=Timestamp format: rfc3339/rfc3339nano/unix_ms or a custom Go layout (unset keeps each formatter's default)" jsonschema_extras:"x-layer=global,x-priority=66"`
+
+	// Timezone is the zone timestamps are written in: "local" (default),
+	// "utc", or an IANA zone name such as "Europe/Berlin". Viewers convert
+	// to local time for display, so teams spread across zones can store UTC
+	// and still read their own wall clock.
+	Timezone string `yaml:"timezone,omitempty" toml:"timezone,omitempty" jsonschema:"description=Timezone for written timestamps: local (default)/utc or an IANA zone name; viewers display local time" jsonschema_extras:"x-layer=global,x-priority=67"`
 
 	// File configures logging to a file.
 	File FileSinkConfig `yaml:"file" toml:"file" jsonschema:"description=File logging sink configuration" jsonschema_extras:"x-layer=global,x-priority=70"`
 
+	// Console configures the console (stderr) sink independently of the
+	// file sink, e.g. pretty text at info on the console while the file
+	// records JSON at debug.
+	Console ConsoleSinkConfig `yaml:"console,omitempty" toml:"console,omitempty" jsonschema:"description=Console (stderr) sink configuration: level and format independent of the file sink" jsonschema_extras:"x-layer=global,x-priority=74"`
+
 	// Format configures the appearance of the log output.
 	Format FormatConfig `yaml:"format" toml:"format" jsonschema:"description=Log output format settings" jsonschema_extras:"x-layer=global,x-priority=75"`
 
@@ -84,7 +132,7 @@ type Config struct {
 	// strip per entry, and viewers fall back to msg when they are absent.
 	// The GROVE_LOG_PRETTY_FIELDS environment variable (true/false)
 	// overrides this setting.
-	StructuredPrettyFields bool `yaml:"structured_pretty_fields,omitempty" toml:"structured_pretty_fields,omitempty" jsonschema:"description=Embed rendered pretty_ansi/pretty_text fields in structured log entries (adds ~10% log volume; GROVE_LOG_PRETTY_FIELDS overrides),default=false" jsonschema_extras:"x-layer=global,x-priority=79"`
+	StructuredPrettyFields bool `yaml:"structured_pretty_fields,omitempty" toml:"structured_pretty_fields,omitempty" jsonschema:"description=Embed rendered pretty_ansi/pretty_text fields in structured log entries (adds ~10% log volume; GROVE_LOG_PRETTY_FIELDS overrides)" default:"false" jsonschema_extras:"x-layer=global,x-priority=79"`
 
 	// Groups defines named collections of component loggers for easy filtering.
 	// Example:
@@ -103,19 +151,49 @@ type Config struct {
 
 // FileSinkConfig configures the file logging sink.
 type FileSinkConfig struct {
-	Enabled bool `yaml:"enabled" toml:"enabled" jsonschema:"description=Enable file logging,default=true" jsonschema_extras:"x-layer=global,x-priority=70"`
+	Enabled bool `yaml:"enabled" toml:"enabled" jsonschema:"description=Enable file logging" default:"true" jsonschema_extras:"x-layer=global,x-priority=70"`
 	// Path is the full path to the log file.
 	Path   string `yaml:"path" toml:"path" jsonschema:"description=Full path to the log file" jsonschema_extras:"x-layer=global,x-priority=71"`
-	Format string `yaml:"format,omitempty" toml:"format,omitempty" jsonschema:"description=File log format: text or json,default=json,enum=text,enum=json" jsonschema_extras:"x-layer=global,x-priority=72"`
+	Format string `yaml:"format,omitempty" toml:"format,omitempty" jsonschema:"description=File log format: text or json,enum=text,enum=json" default:"json" jsonschema_extras:"x-layer=global,x-priority=72"`
+	// Dir moves a workspace's dated log files out of the state directory,
+	// e.g. onto a shared tmpfs. Relative paths resolve against the project
+	// root. Files are namespaced per project as
+	// <dir>/<workspace identifier>/workspace-YYYY-MM-DD.log, so several
+	// projects can share one directory. Path takes precedence; system-scope
+	// logs always stay in the state directory.
+	Dir string `yaml:"dir,omitempty" toml:"dir,omitempty" jsonschema:"description=Directory for workspace log files instead of the state directory (relative to the project root; namespaced per project)" jsonschema_extras:"x-layer=project,x-priority=71"`
 	// Level is the minimum log level for the file sink only. When unset, the
-	// file sink follows the console level. Useful for capturing debug detail
+	// file sink follows level (or system_level in system scope), not
+	// console.level. Useful for capturing debug detail
 	// in the audit trail without making the console verbose.
 	// GROVE_LOG_LEVEL overrides both the console and file levels.
-	Level string `yaml:"level,omitempty" toml:"level,omitempty" jsonschema:"description=Minimum log level for the file sink only (defaults to the console level; GROVE_LOG_LEVEL overrides both),enum=debug,enum=info,enum=warn,enum=error" jsonschema_extras:"x-layer=global,x-priority=73"`
+	Level string `yaml:"level,omitempty" toml:"level,omitempty" jsonschema:"description=Minimum log level for the file sink only (defaults to level; GROVE_LOG_LEVEL overrides both),enum=trace,enum=debug,enum=info,enum=warn,enum=error" jsonschema_extras:"x-layer=global,x-priority=73"`
 	// RetentionDays is how many days of dated log files to keep. Old files
 	// are swept by the grove daemon; files for the current day are never
 	// removed. 0 means use the default (14).
-	RetentionDays int `yaml:"retention_days,omitempty" toml:"retention_days,omitempty" jsonschema:"description=Days of dated log files to keep before the daemon sweeps them (0 = default of 14),default=14" jsonschema_extras:"x-layer=global,x-priority=74"`
+	RetentionDays int `yaml:"retention_days,omitempty" toml:"retention_days,omitempty" jsonschema:"description=Days of dated log files to keep before the daemon sweeps them (0 = default of 14)" default:"14" jsonschema_extras:"x-layer=global,x-priority=74"`
+	// Async moves file writes onto a background goroutine behind a bounded
+	// queue so logging from hot paths (TUI renders, daemon handlers) never
+	// waits on disk I/O. Queued entries are written on logging.Flush(),
+	// which cli.Execute calls before returning.
+	Async bool `yaml:"async,omitempty" toml:"async,omitempty" jsonschema:"description=Write file logs from a background goroutine through a bounded queue" default:"false" jsonschema_extras:"x-layer=global,x-priority=92"`
+	// QueueSize is the number of entries the async queue holds. 0 means
+	// the default (1024).
+	QueueSize int `yaml:"queue_size,omitempty" toml:"queue_size,omitempty" jsonschema:"description=Entries buffered by the async file sink (0 = default of 1024)" default:"1024" jsonschema_extras:"x-layer=global,x-priority=93"`
+	// Overflow selects what happens when the async queue is full: "block"
+	// waits for the writer (no loss), "drop" discards the entry.
+	Overflow string `yaml:"overflow,omitempty" toml:"overflow,omitempty" jsonschema:"description=Async queue overflow policy: block (wait) or drop (discard new entries),enum=block,enum=drop" default:"block" jsonschema_extras:"x-layer=global,x-priority=94"`
+}
+
+// ConsoleSinkConfig configures the console sink.
+type ConsoleSinkConfig struct {
+	// Level is the minimum log level for the console sink only. When unset,
+	// the console follows level (or system_level in system scope). The file
+	// sink is unaffected. GROVE_LOG_LEVEL overrides both sinks.
+	Level string `yaml:"level,omitempty" toml:"level,omitempty" jsonschema:"description=Minimum log level for the console sink only (defaults to level; GROVE_LOG_LEVEL overrides both),enum=trace,enum=debug,enum=info,enum=warn,enum=error" jsonschema_extras:"x-layer=global,x-priority=74"`
+	// Format is the console output format: "text" (rich), "simple" or
+	// "json". When unset, format.preset applies.
+	Format string `yaml:"format,omitempty" toml:"format,omitempty" jsonschema:"description=Console log format: text/simple/json (defaults to format.preset),enum=text,enum=simple,enum=json" jsonschema_extras:"x-layer=global,x-priority=74"`
 }
 
 // FormatConfig controls the log output format.
@@ -123,12 +201,12 @@ type FormatConfig struct {
 	// Preset can be "default" (rich text), "simple" (minimal text), or "json".
 	Preset string `yaml:"preset" toml:"preset" jsonschema:"description=Log format preset: default (rich)/simple/json,enum=default,enum=simple,enum=json" jsonschema_extras:"x-layer=global,x-priority=75"`
 	// DisableTimestamp disables the timestamp from the "default" and "simple" formats.
-	DisableTimestamp bool `yaml:"disable_timestamp" toml:"disable_timestamp" jsonschema:"description=Disable timestamp in log output,default=false" jsonschema_extras:"x-layer=global,x-priority=76"`
+	DisableTimestamp bool `yaml:"disable_timestamp" toml:"disable_timestamp" jsonschema:"description=Disable timestamp in log output" default:"false" jsonschema_extras:"x-layer=global,x-priority=76"`
 	// DisableComponent disables the component name from the "default" and "simple" formats.
-	DisableComponent bool `yaml:"disable_component" toml:"disable_component" jsonschema:"description=Disable component name in log output,default=false" jsonschema_extras:"x-layer=global,x-priority=77"`
+	DisableComponent bool `yaml:"disable_component" toml:"disable_component" jsonschema:"description=Disable component name in log output" default:"false" jsonschema_extras:"x-layer=global,x-priority=77"`
 	// StructuredToStderr controls when structured logs are sent to stderr.
 	// Can be "auto" (default), "always", or "never".
-	StructuredToStderr string `yaml:"structured_to_stderr" toml:"structured_to_stderr" jsonschema:"description=When to send structured logs to stderr,enum=auto,enum=always,enum=never,default=auto" jsonschema_extras:"x-layer=global,x-priority=78"`
+	StructuredToStderr string `yaml:"structured_to_stderr" toml:"structured_to_stderr" jsonschema:"description=When to send structured logs to stderr,enum=auto,enum=always,enum=never" default:"auto" jsonschema_extras:"x-layer=global,x-priority=78"`
 }
 
 // GetDefaultLoggingConfig returns a Config with sensible defaults that enable
@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EscalatedFromField is the field recording the level an escalated entry
+// was logged at, so viewers and readers of the file can tell an escalation
+// from an entry that was an error all along.
+const EscalatedFromField = "escalated_from"
+
+// EscalationRule raises the level of entries matching Match to RaiseTo.
+// Rules only ever raise: an entry already at or above RaiseTo is left
+// alone.
+type EscalationRule struct {
+	Match EscalationMatch `yaml:"match" toml:"match" jsonschema:"description=Entries the rule applies to (all given conditions must hold)"`
+	// RaiseTo is the level matching entries are recorded and displayed at.
+	RaiseTo string `yaml:"raise_to" toml:"raise_to" jsonschema:"description=Level matching entries are raised to,enum=info,enum=warn,enum=error"`
+}
+
+// EscalationMatch selects entries for an EscalationRule. Empty conditions
+// match everything; at least one must be set.
+type EscalationMatch struct {
+	// Component is a component name or a group from logging.groups.
+	Component string `yaml:"component,omitempty" toml:"component,omitempty" jsonschema:"description=Component name or group the entry must come from"`
+	// Level restricts the rule to entries logged at exactly this level.
+	Level string `yaml:"level,omitempty" toml:"level,omitempty" jsonschema:"description=Only entries logged at this level,enum=trace,enum=debug,enum=info,enum=warn"`
+	// MsgRegex is a regular expression the message must contain a match
+	// of. A pattern that does not compile is matched literally.
+	MsgRegex string `yaml:"msg_regex,omitempty" toml:"msg_regex,omitempty" jsonschema:"description=Regular expression the message must match (searched anywhere in the message)"`
+}
+
+// Escalator applies compiled escalation rules.
+type Escalator struct {
+	rules []compiledEscalation
+}
+
+type compiledEscalation struct {
+	components map[string]bool
+	level      logrus.Level
+	anyLevel   bool
+	msg        *regexp.Regexp
+	raiseTo    logrus.Level
+}
+
+// NewEscalator compiles rules, resolving component groups against groups
+// and DefaultGroups. Invalid rules are skipped and returned as errors. It
+// returns a nil Escalator when no rule is usable; a nil Escalator never
+// escalates.
+func NewEscalator(rules []EscalationRule, groups map[string][]string) (*Escalator, []error) {
+	var compiled []compiledEscalation
+	var errs []error
+	for i, rule := range rules {
+		raiseTo, err := logrus.ParseLevel(rule.RaiseTo)
+		if err != nil || raiseTo < logrus.ErrorLevel || raiseTo > logrus.InfoLevel {
+			errs = append(errs, fmt.Errorf("escalations[%d]: raise_to must be info, warn or error, got %q", i, rule.RaiseTo))
+			continue
+		}
+		m := rule.Match
+		if m.Component == "" && m.Level == "" && m.MsgRegex == "" {
+			errs = append(errs, fmt.Errorf("escalations[%d]: match needs a component, level or msg_regex", i))
+			continue
+		}
+		c := compiledEscalation{raiseTo: raiseTo, anyLevel: true}
+		if m.Component != "" {
+			c.components = resolveFilterSet([]string{m.Component}, groups)
+		}
+		if m.Level != "" {
+			level, err := logrus.ParseLevel(m.Level)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("escalations[%d]: %w", i, err))
+				continue
+			}
+			c.level, c.anyLevel = level, false
+		}
+		if m.MsgRegex != "" {
+			re, err := regexp.Compile(m.MsgRegex)
+			if err != nil {
+				re = regexp.MustCompile(regexp.QuoteMeta(m.MsgRegex))
+			}
+			c.msg = re
+		}
+		compiled = append(compiled, c)
+	}
+	if len(compiled) == 0 {
+		return nil, errs
+	}
+	return &Escalator{rules: compiled}, errs
+}
+
+// Escalate returns the level an entry from component logged at level with
+// message msg should be recorded at, and whether a rule raised it. When
+// several rules match, the most severe wins.
+func (e *Escalator) Escalate(component string, level logrus.Level, msg string) (logrus.Level, bool) {
+	if e == nil {
+		return level, false
+	}
+	raised := level
+	for _, r := range e.rules {
+		if r.raiseTo >= raised {
+			continue
+		}
+		if r.components != nil && !r.components[component] {
+			continue
+		}
+		if !r.anyLevel && r.level != level {
+			continue
+		}
+		if r.msg != nil && !r.msg.MatchString(msg) {
+			continue
+		}
+		raised = r.raiseTo
+	}
+	return raised, raised != level
+}
+
+// escalationHook raises matching entries before any sink sees them. NewLogger
+// registers it ahead of the file, recent-entries and console hooks; logrus
+// fires hooks in registration order and later hooks read entry.Level, so
+// every sink records the raised level.
+type escalationHook struct {
+	escalator *Escalator
+}
+
+// Levels implements logrus.Hook.
+func (h *escalationHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *escalationHook) Fire(entry *logrus.Entry) error {
+	component, _ := entry.Data["component"].(string)
+	if level, ok := h.escalator.Escalate(component, entry.Level, entry.Message); ok {
+		entry.Data[EscalatedFromField] = entry.Level.String()
+		entry.Level = level
+	}
+	return nil
+}
+
+// resolvedEscalator is the escalator built by the most recent NewLogger
+// call. Unified loggers consult it before choosing the pretty style, so an
+// escalated warning also looks like an error on the console.
+var (
+	resolvedEscalatorMu sync.RWMutex
+	resolvedEscalator   *Escalator
+)
+
+func setResolvedEscalator(e *Escalator) {
+	resolvedEscalatorMu.Lock()
+	resolvedEscalator = e
+	resolvedEscalatorMu.Unlock()
+}
+
+func currentEscalator() *Escalator {
+	resolvedEscalatorMu.RLock()
+	defer resolvedEscalatorMu.RUnlock()
+	return resolvedEscalator
+}
+
+// reportEscalationErrors writes invalid-rule errors to stderr once per
+// process; the logger is not usable yet when they are found.
+var reportEscalationOnce sync.Once
+
+func reportEscalationErrors(errs []error) {
+	if len(errs) == 0 {
+		return
+	}
+	reportEscalationOnce.Do(func() {
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "grove-log: ignoring logging.%v\n", err)
+		}
+	})
+}
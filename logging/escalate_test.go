@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestEscalatorRules(t *testing.T) {
+	e, errs := NewEscalator([]EscalationRule{
+		{Match: EscalationMatch{Component: "db", MsgRegex: "connection refused"}, RaiseTo: "error"},
+		{Match: EscalationMatch{Component: "ai", Level: "debug"}, RaiseTo: "warn"},
+		{Match: EscalationMatch{MsgRegex: "retry("}, RaiseTo: "warn"},
+		{Match: EscalationMatch{Component: "db"}, RaiseTo: "fatal"},
+		{Match: EscalationMatch{}, RaiseTo: "error"},
+	}, nil)
+	if len(errs) != 2 {
+		t.Errorf("expected the fatal and empty-match rules to be rejected, got %v", errs)
+	}
+
+	cases := []struct {
+		component string
+		level     logrus.Level
+		msg       string
+		want      logrus.Level
+		raised    bool
+	}{
+		{"db", logrus.WarnLevel, "dial tcp: connection refused", logrus.ErrorLevel, true},
+		{"db", logrus.WarnLevel, "slow query", logrus.WarnLevel, false},
+		{"api", logrus.WarnLevel, "connection refused", logrus.WarnLevel, false},
+		// Groups resolve through DefaultGroups.
+		{"grove-gemini", logrus.DebugLevel, "quota", logrus.WarnLevel, true},
+		{"grove-gemini", logrus.InfoLevel, "quota", logrus.InfoLevel, false},
+		// An invalid regex is matched literally.
+		{"api", logrus.InfoLevel, "retry(3)", logrus.WarnLevel, true},
+		// Rules never lower a level.
+		{"db", logrus.ErrorLevel, "connection refused", logrus.ErrorLevel, false},
+	}
+	for _, c := range cases {
+		got, raised := e.Escalate(c.component, c.level, c.msg)
+		if got != c.want || raised != c.raised {
+			t.Errorf("Escalate(%s, %s, %q) = %s, %v; want %s, %v", c.component, c.level, c.msg, got, raised, c.want, c.raised)
+		}
+	}
+
+	var none *Escalator
+	if _, raised := none.Escalate("db", logrus.WarnLevel, "x"); raised {
+		t.Error("a nil Escalator should never escalate")
+	}
+}
+
+func TestEscalationHookRaisesForLaterSinks(t *testing.T) {
+	e, _ := NewEscalator([]EscalationRule{
+		{Match: EscalationMatch{Component: "db", MsgRegex: "refused"}, RaiseTo: "error"},
+	}, nil)
+	ring := newRecentRing(10)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(&escalationHook{escalator: e})
+	logger.AddHook(&recentHook{ring: ring})
+
+	entry := logger.WithField("component", "db")
+	entry.Warn("connection refused")
+	entry.Warn("slow query")
+
+	got := ring.last(0)
+	if len(got) != 2 {
+		t.Fatalf("got %d entries", len(got))
+	}
+	if got[0].Level != "error" || got[0].Fields[EscalatedFromField] != "warning" {
+		t.Errorf("escalated entry = %+v", got[0])
+	}
+	if got[1].Level != "warning" || got[1].Fields[EscalatedFromField] != nil {
+		t.Errorf("unmatched entry = %+v", got[1])
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -13,6 +14,10 @@ import (
 // TextFormatter is a custom logrus formatter.
 type TextFormatter struct {
 	Config FormatConfig
+	// TimeFormat and Location apply logging.time_format and
+	// logging.timezone; empty/nil keep "2006-01-02 15:04:05" in local time.
+	TimeFormat string
+	Location   *time.Location
 }
 
 // Format renders a single log entry.
@@ -20,7 +25,7 @@ func (f *TextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	var b strings.Builder
 
 	if !f.Config.DisableTimestamp {
-		b.WriteString(entry.Time.Format("2006-01-02 15:04:05"))
+		b.WriteString(FormatTime(entry.Time, f.TimeFormat, f.Location, textTimeLayout))
 		b.WriteString(" ")
 	}
 
@@ -51,7 +56,7 @@ func (f *TextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 
 	// Append remaining fields
 	for key, value := range entry.Data {
-		if key != "component" {
+		if key != "component" && key != VerbosityField {
 			b.WriteString(fmt.Sprintf(" %s=%v", key, value))
 		}
 	}
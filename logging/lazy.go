@@ -0,0 +1,32 @@
+package logging
+
+import "github.com/sirupsen/logrus"
+
+// Fields is the set of structured fields attached to a log entry.
+type Fields = logrus.Fields
+
+// TraceFn logs msg at trace level with the fields built by fn. fn is only
+// called when the logger admits trace entries, so expensive payloads
+// (request dumps, full state snapshots) cost nothing when tracing is off:
+//
+//	logging.TraceFn(log, "Resolved config", func() logging.Fields {
+//		return logging.Fields{"config": cfg.Dump()}
+//	})
+func TraceFn(log *logrus.Entry, msg string, fn func() Fields) {
+	logFn(log, logrus.TraceLevel, msg, fn)
+}
+
+// DebugFn is TraceFn at debug level.
+func DebugFn(log *logrus.Entry, msg string, fn func() Fields) {
+	logFn(log, logrus.DebugLevel, msg, fn)
+}
+
+func logFn(log *logrus.Entry, level logrus.Level, msg string, fn func() Fields) {
+	if log == nil || !log.Logger.IsLevelEnabled(level) {
+		return
+	}
+	if fn != nil {
+		log = log.WithFields(fn())
+	}
+	log.Log(level, msg)
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestTraceFnBuildsFieldsOnlyWhenEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	hook := &captureHook{}
+	logger.AddHook(hook)
+	log := logrus.NewEntry(logger)
+
+	built := 0
+	fields := func() Fields {
+		built++
+		return Fields{"dump": "expensive"}
+	}
+
+	logger.SetLevel(logrus.DebugLevel)
+	TraceFn(log, "skipped", fields)
+	if built != 0 || len(hook.entries) != 0 {
+		t.Fatalf("trace disabled: built %d times, %d entries", built, len(hook.entries))
+	}
+	DebugFn(log, "debug", fields)
+	if built != 1 || len(hook.entries) != 1 {
+		t.Fatalf("debug enabled: built %d times, %d entries", built, len(hook.entries))
+	}
+
+	logger.SetLevel(logrus.TraceLevel)
+	TraceFn(log, "traced", fields)
+	if built != 2 || len(hook.entries) != 2 {
+		t.Fatalf("trace enabled: built %d times, %d entries", built, len(hook.entries))
+	}
+	e := hook.entries[1]
+	if e.Level != logrus.TraceLevel || e.Message != "traced" || e.Data["dump"] != "expensive" {
+		t.Errorf("unexpected trace entry: level=%v msg=%q data=%v", e.Level, e.Message, e.Data)
+	}
+
+	TraceFn(log, "no fields", nil)
+	if len(hook.entries) != 3 {
+		t.Errorf("a nil field builder should still log, got %d entries", len(hook.entries))
+	}
+}
@@ -1,6 +1,8 @@
 package logging
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -57,10 +59,24 @@ func TestResolveLevels(t *testing.T) {
 			wantConsole: logrus.DebugLevel,
 			wantFile:    logrus.WarnLevel,
 		},
+		{
+			name:        "console level does not change the file level",
+			cfg:         Config{Level: "debug", Console: ConsoleSinkConfig{Level: "warn"}},
+			scope:       ScopeWorkspace,
+			wantConsole: logrus.WarnLevel,
+			wantFile:    logrus.DebugLevel,
+		},
+		{
+			name:        "independent console and file levels",
+			cfg:         Config{Level: "info", Console: ConsoleSinkConfig{Level: "error"}, File: FileSinkConfig{Level: "trace"}},
+			scope:       ScopeSystem,
+			wantConsole: logrus.ErrorLevel,
+			wantFile:    logrus.TraceLevel,
+		},
 		{
 			name:        "GROVE_LOG_LEVEL overrides both sinks",
 			env:         "error",
-			cfg:         Config{Level: "debug", SystemLevel: "debug", File: FileSinkConfig{Level: "debug"}},
+			cfg:         Config{Level: "debug", SystemLevel: "debug", Console: ConsoleSinkConfig{Level: "debug"}, File: FileSinkConfig{Level: "debug"}},
 			scope:       ScopeSystem,
 			wantConsole: logrus.ErrorLevel,
 			wantFile:    logrus.ErrorLevel,
@@ -100,34 +116,52 @@ func TestMostVerbose(t *testing.T) {
 	}
 }
 
-func TestLevelFilteringFormatter(t *testing.T) {
-	inner := &TextFormatter{Config: FormatConfig{DisableTimestamp: true}}
-	f := &levelFilteringFormatter{maxLevel: logrus.InfoLevel, inner: inner}
+func TestConsoleHookFiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	hook := &ConsoleHook{
+		Writer:    &buf,
+		Formatter: &TextFormatter{Config: FormatConfig{DisableTimestamp: true}},
+		maxLevel:  logrus.InfoLevel,
+	}
 
-	debugEntry := &logrus.Entry{Level: logrus.DebugLevel, Message: "verbose detail"}
-	out, err := f.Format(debugEntry)
-	if err != nil {
+	if err := hook.Fire(&logrus.Entry{Level: logrus.DebugLevel, Message: "verbose detail"}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if out != nil {
-		t.Errorf("expected nil output for entry more verbose than maxLevel, got %q", out)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for entry more verbose than maxLevel, got %q", buf.String())
 	}
 
-	infoEntry := &logrus.Entry{Level: logrus.InfoLevel, Message: "visible message"}
-	out, err = f.Format(infoEntry)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	for _, level := range []logrus.Level{logrus.InfoLevel, logrus.WarnLevel} {
+		buf.Reset()
+		if err := hook.Fire(&logrus.Entry{Level: level, Message: "visible message"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "visible message") {
+			t.Errorf("expected output for %s entry at or above maxLevel, got %q", level, buf.String())
+		}
 	}
-	if len(out) == 0 {
-		t.Error("expected formatted output for entry at maxLevel")
+}
+
+func TestNewConsoleFormatter(t *testing.T) {
+	entry := &logrus.Entry{Level: logrus.InfoLevel, Message: "hello", Data: logrus.Fields{"component": "api"}}
+	format := func(cfg Config) string {
+		out, err := newConsoleFormatter(&cfg, resolveTimeSettings(&cfg), nil).Format(entry)
+		if err != nil {
+			t.Fatalf("Format: %v", err)
+		}
+		return string(out)
 	}
 
-	warnEntry := &logrus.Entry{Level: logrus.WarnLevel, Message: "warning message"}
-	out, err = f.Format(warnEntry)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	if out := format(Config{File: FileSinkConfig{Format: "json"}}); strings.HasPrefix(out, "{") {
+		t.Errorf("a JSON file sink should leave the console as text, got %q", out)
+	}
+	if out := format(Config{Console: ConsoleSinkConfig{Format: "json"}}); !strings.HasPrefix(out, "{") {
+		t.Errorf("console.format=json should write JSON, got %q", out)
+	}
+	if out := format(Config{Console: ConsoleSinkConfig{Format: "text"}, Format: FormatConfig{Preset: "json"}}); strings.HasPrefix(out, "{") {
+		t.Errorf("console.format should take precedence over format.preset, got %q", out)
 	}
-	if len(out) == 0 {
-		t.Error("expected formatted output for entry less verbose than maxLevel")
+	if out := format(Config{Format: FormatConfig{Preset: "json"}}); !strings.HasPrefix(out, "{") {
+		t.Errorf("format.preset should apply when console.format is unset, got %q", out)
 	}
 }
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"cmp"
 	"flag"
 	"fmt"
 	"io"
@@ -17,8 +18,8 @@ import (
 
 	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/timinghook"
 	"github.com/grovetools/core/pkg/workspace"
-	"github.com/grovetools/core/version"
 )
 
 // LogScope defines the execution scope for log routing.
@@ -228,14 +229,19 @@ func GetComponentVisibility(component string, cfg *Config, overrides *OverrideOp
 
 // VersionFields represents the fields logged when a Grove binary starts
 type VersionFields struct {
-	Branch    string `json:"branch" verbosity:"3"`
-	Commit    string `json:"commit" verbosity:"3"`
-	Binary    string `json:"binary" verbosity:"3"`
-	Version   string `json:"version" verbosity:"0"`
-	Platform  string `json:"platform" verbosity:"3"`
-	GoVersion string `json:"goVersion" verbosity:"3"`
-	BuildDate string `json:"buildDate" verbosity:"1"`
-	Compiler  string `json:"compiler" verbosity:"3"`
+	Branch         string   `json:"branch" verbosity:"3"`
+	Commit         string   `json:"commit" verbosity:"3"`
+	Binary         string   `json:"binary" verbosity:"3"`
+	Version        string   `json:"version" verbosity:"0"`
+	Platform       string   `json:"platform" verbosity:"3"`
+	GoVersion      string   `json:"goVersion" verbosity:"3"`
+	BuildDate      string   `json:"buildDate" verbosity:"1"`
+	Compiler       string   `json:"compiler" verbosity:"3"`
+	Hostname       string   `json:"hostname" verbosity:"1"`
+	PID            int      `json:"pid" verbosity:"1"`
+	EffectiveLevel string   `json:"effectiveLevel" verbosity:"1"`
+	FileLevel      string   `json:"fileLevel" verbosity:"2"`
+	ConfigLayers   []string `json:"configLayers" verbosity:"2"`
 }
 
 // StructToLogrusFields converts a struct with verbosity tags to logrus.Fields
@@ -272,7 +278,7 @@ func StructToLogrusFields(v interface{}) logrus.Fields {
 	}
 
 	// Add verbosity metadata
-	fields["_verbosity"] = verbosityMap
+	fields[VerbosityField] = verbosityMap
 
 	return fields
 }
@@ -346,9 +352,9 @@ func parseLevelOrInfo(s string) logrus.Level {
 
 // resolveLevels resolves the per-sink log levels from config and scope.
 //
-// consoleLevel follows the chain: GROVE_LOG_LEVEL env > system_level (for
-// ScopeSystem) > level > "info". fileLevel is file.level when set, otherwise
-// consoleLevel. GROVE_LOG_LEVEL overrides both sinks.
+// Both sinks start from the base level: system_level (for ScopeSystem) >
+// level > "info". consoleLevel is console.level and fileLevel is file.level
+// when set, otherwise the base level. GROVE_LOG_LEVEL overrides both sinks.
 func resolveLevels(logCfg *Config, scope LogScope) (consoleLevel, fileLevel logrus.Level) {
 	if env := os.Getenv("GROVE_LOG_LEVEL"); env != "" {
 		level := parseLevelOrInfo(env)
@@ -361,9 +367,13 @@ func resolveLevels(logCfg *Config, scope LogScope) (consoleLevel, fileLevel logr
 	} else if logCfg.Level != "" {
 		levelStr = logCfg.Level
 	}
-	consoleLevel = parseLevelOrInfo(levelStr)
+	baseLevel := parseLevelOrInfo(levelStr)
 
-	fileLevel = consoleLevel
+	consoleLevel = baseLevel
+	if logCfg.Console.Level != "" {
+		consoleLevel = parseLevelOrInfo(logCfg.Console.Level)
+	}
+	fileLevel = baseLevel
 	if logCfg.File.Level != "" {
 		fileLevel = parseLevelOrInfo(logCfg.File.Level)
 	}
@@ -408,8 +418,7 @@ func NewLogger(component string) *logrus.Entry {
 	scopeMu.RUnlock()
 
 	// Configure Level. The logrus level must admit the most verbose sink;
-	// the console output is filtered back down to consoleLevel via
-	// levelFilteringFormatter, and the file sink via FileHook.LogLevels.
+	// each sink's hook trims entries back down to its own level.
 	consoleLevel, fileLevel := resolveLevels(&logCfg, currentScope)
 	logger.SetLevel(mostVerbose(consoleLevel, fileLevel))
 	setResolvedConsoleLevel(consoleLevel)
@@ -420,17 +429,21 @@ func NewLogger(component string) *logrus.Entry {
 		logger.SetReportCaller(true)
 	}
 
-	// Configure Formatter
-	switch logCfg.Format.Preset {
-	case "json":
-		logger.SetFormatter(&logrus.JSONFormatter{})
-	case "simple":
-		logger.SetFormatter(&TextFormatter{Config: FormatConfig{
-			DisableTimestamp: true,
-			DisableComponent: true,
-		}})
-	default:
-		logger.SetFormatter(&TextFormatter{Config: logCfg.Format})
+	// Configure Formatters. Mask configured secret fields before any sink
+	// formats the entry.
+	timeCfg := resolveTimeSettings(&logCfg)
+	setResolvedTimeFormat(timeCfg.format)
+	redactor := NewRedactor(logCfg.Redact)
+	consoleFormatter := newConsoleFormatter(&logCfg, timeCfg, redactor)
+	jsonConsoleFormatter := consoleFormatterFor("json", &logCfg, timeCfg, redactor)
+	fileFormatter := newFileFormatter(&logCfg, timeCfg, redactor)
+
+	// Escalate matching entries first so every sink records the raised level.
+	escalator, escalationErrs := NewEscalator(logCfg.Escalations, logCfg.Groups)
+	reportEscalationErrors(escalationErrs)
+	setResolvedEscalator(escalator)
+	if escalator != nil {
+		logger.AddHook(&escalationHook{escalator: escalator})
 	}
 
 	// Configure File Sink.
@@ -448,20 +461,18 @@ func NewLogger(component string) *logrus.Entry {
 		// pathFn derives the log file path for a point in time so the
 		// dateRotatingWriter can reopen date-patterned paths when the day
 		// changes. Fixed paths (env override, explicit config) never roll.
+		// sinkKey names the sink independent of the date.
 		var pathFn func(time.Time) string
+		var sinkKey string
 
 		if envPath := os.Getenv("GROVE_LOG_FILE"); envPath != "" {
-			p := expandPath(envPath)
-			pathFn = func(time.Time) string { return p }
+			pathFn, sinkKey = fixedLogPath(expandPath(envPath))
 		} else if currentScope == ScopeSystem {
 			// System scope: write to central XDG state directory
-			pathFn = func(now time.Time) string {
-				return filepath.Join(paths.StateDir(), "logs", fmt.Sprintf("system-%s.log", now.Format("2006-01-02")))
-			}
+			pathFn, sinkKey = datedLogPath(filepath.Join(paths.StateDir(), "logs"), "system")
 		} else if logCfg.File.Path != "" {
 			// Use explicitly configured path
-			p := expandPath(logCfg.File.Path)
-			pathFn = func(time.Time) string { return p }
+			pathFn, sinkKey = fixedLogPath(expandPath(logCfg.File.Path))
 		} else {
 			// Default to XDG state directory organized by workspace identifier
 			cwd, err := os.Getwd()
@@ -474,38 +485,48 @@ func NewLogger(component string) *logrus.Entry {
 			if cwd != "" {
 				node, err := workspace.GetProjectByPath(cwd)
 				if err == nil && node != nil {
-					identifier := node.Identifier("/")
-					pathFn = func(now time.Time) string {
-						return filepath.Join(paths.StateDir(), "logs", "workspaces", identifier, fmt.Sprintf("workspace-%s.log", now.Format("2006-01-02")))
-					}
+					pathFn, sinkKey = datedLogPath(WorkspaceLogsDir(&logCfg, node), "workspace")
 				} else {
-					pathFn = func(now time.Time) string {
-						return filepath.Join(paths.StateDir(), "logs", fmt.Sprintf("system-%s.log", now.Format("2006-01-02")))
-					}
+					pathFn, sinkKey = datedLogPath(filepath.Join(paths.StateDir(), "logs"), "system")
 				}
 			}
 		}
 
 		if pathFn != nil {
-			writer, err := newDateRotatingWriter(pathFn, nil)
+			sink, err := openFileSink(pathFn, sinkKey, logCfg.File, nil)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "grove-log: failed to open log file: %v\n", err)
 			} else {
-				var fileFormatter logrus.Formatter
-				if logCfg.File.Format == "json" {
-					fileFormatter = &logrus.JSONFormatter{}
-				} else {
-					fileFormatter = &TextFormatter{Config: FormatConfig{DisableTimestamp: false}}
-				}
+				// Registered for every level and trimmed to fileLevel in
+				// Fire, so a runtime override can make the file sink more
+				// verbose than configured.
 				logger.AddHook(&FileHook{
-					Writer:    writer,
-					LogLevels: logrus.AllLevels[:fileLevel+1],
+					Writer:    sink,
+					LogLevels: logrus.AllLevels,
 					Formatter: fileFormatter,
+					component: component,
+					maxLevel:  fileLevel,
+					limited:   true,
 				})
 			}
 		}
 	}
 
+	// The schema describes the JSON lines of the file sink; text-format
+	// files have nothing to validate.
+	if logCfg.ValidateEntries && logCfg.File.Format == "json" {
+		logger.AddHook(newEntryValidationHook(fileFormatter, timeCfg.format))
+	}
+
+	// Keep recent entries in memory for RecentEntries.
+	if logCfg.RecentEntries >= 0 {
+		recentEntries.resize(cmp.Or(logCfg.RecentEntries, DefaultRecentEntries))
+		logger.AddHook(&recentHook{ring: recentEntries, redactor: redactor})
+	} else {
+		// Timers with RecordMetrics add to the ring directly.
+		recentEntries.resize(0)
+	}
+
 	// Determine if we should write structured logs to stderr
 	shouldLogToStderr := false
 	suppressDualEmit := false
@@ -540,60 +561,30 @@ func NewLogger(component string) *logrus.Entry {
 	// Check component visibility based on show/hide configuration
 	isVisible := IsComponentVisible(component, &logCfg)
 
-	// Use the global writer instead of os.Stderr to support TUI redirection
+	// The console is a sink like the file: a hook with its own formatter
+	// and level, writing to the global writer instead of os.Stderr to
+	// support TUI redirection. The logger's own output is unused.
 	if shouldLogToStderr && isVisible {
-		logger.SetOutput(GetGlobalOutput())
 		if suppressDualEmit {
-			logger.SetFormatter(&dualEmitSuppressingFormatter{inner: logger.Formatter})
+			consoleFormatter = &dualEmitSuppressingFormatter{inner: consoleFormatter}
+			jsonConsoleFormatter = &dualEmitSuppressingFormatter{inner: jsonConsoleFormatter}
 		}
-		if consoleLevel < logger.GetLevel() {
-			// The logrus level admits entries for a more verbose file sink;
-			// filter them out of the console output here (outermost wrapper).
-			logger.SetFormatter(&levelFilteringFormatter{maxLevel: consoleLevel, inner: logger.Formatter})
-		}
-	} else {
-		logger.SetOutput(io.Discard)
-	}
-
-	// Log version information once on first logger initialization (if enabled)
+		logger.AddHook(&ConsoleHook{
+			Writer:        GetGlobalOutput(),
+			Formatter:     consoleFormatter,
+			component:     component,
+			maxLevel:      consoleLevel,
+			jsonFormatter: jsonConsoleFormatter,
+		})
+	}
+	logger.SetOutput(io.Discard)
+	logger.SetFormatter(discardFormatter{})
+
+	// Log the startup banner once on first logger initialization (if enabled)
 	initOnce.Do(func() {
-		// Only log startup message if explicitly enabled in config
-		if !logCfg.LogStartup {
-			return
-		}
-
-		info := version.GetInfo()
-
-		// Get binary name for more useful logging
-		binaryName := "unknown"
-		if len(os.Args) > 0 {
-			binaryName = filepath.Base(os.Args[0])
+		if logCfg.LogStartup {
+			logStartupBanner(logger, consoleLevel, fileLevel)
 		}
-
-		// Use grove-<binary> as component for clearer identification
-		// Don't prepend grove- if binary name already starts with grove-
-		componentName := binaryName
-		if !strings.HasPrefix(binaryName, "grove-") && binaryName != "grove" {
-			componentName = fmt.Sprintf("grove-%s", binaryName)
-		}
-
-		// Create version fields struct with verbosity tags
-		versionFields := VersionFields{
-			Branch:    info.Branch,
-			Commit:    info.Commit,
-			Binary:    binaryName,
-			Version:   info.Version,
-			Platform:  info.Platform,
-			GoVersion: info.GoVersion,
-			BuildDate: info.BuildDate,
-			Compiler:  info.Compiler,
-		}
-
-		// Convert struct to logrus fields with verbosity metadata
-		fields := StructToLogrusFields(versionFields)
-		fields["component"] = componentName
-
-		logger.WithFields(fields).Info("Grove binary started")
 	})
 
 	// Config-load schema warnings buffer inside the config package until a
@@ -602,6 +593,14 @@ func NewLogger(component string) *logrus.Entry {
 	// including ones fired at package-init time — reach the file sink and
 	// honor the TUI-safe console gating above.
 	schemaWarnSinkOnce.Do(func() { registerSchemaWarningSink(logger) })
+	timingHooksOnce.Do(func() { registerTimingHooks(logger) })
+
+	// Runtime level changes from `core logs set-level`; signals are opt-in
+	// through EnableLevelSignals.
+	registerLevelControl(component, logger)
+	if !IsTestBinary() {
+		startRuntimeLevelControl()
+	}
 
 	entry := logger.WithField("component", component)
 	loggers[component] = entry
@@ -617,11 +616,28 @@ var schemaWarnSinkOnce sync.Once
 func registerSchemaWarningSink(logger *logrus.Logger) {
 	entry := logger.WithField("component", "config")
 	config.SetSchemaWarningSink(func(source string, err error) {
-		entry.WithError(err).WithField("source", source).
-			Warn("configuration does not fully conform to the schema (continuing; validation is advisory)")
+		config.LogSchemaWarning(entry, source, err)
 	})
 }
 
+var timingHooksOnce sync.Once
+
+// registerTimingHooks installs the timinghook timers of config and
+// workspace. Like the schema warning sink, the hooks can fire inside
+// NewLogger, so they log through the captured logger directly.
+func registerTimingHooks(logger *logrus.Logger) {
+	config.SetTimer(componentTimer(logger, "config"))
+	workspace.SetTimer(componentTimer(logger, "workspace"))
+}
+
+// componentTimer times operations through logger as component.
+func componentTimer(logger *logrus.Logger, component string) timinghook.StartFunc {
+	log := logger.WithField("component", component)
+	return func(op string) func(error) {
+		return timeOp(log, op, RecordMetrics())
+	}
+}
+
 // dualEmitSuppressingFormatter wraps a formatter and emits nothing for
 // entries already rendered via the unified pretty path (see dualEmitKey in
 // unified.go). It is installed only on the console output of loggers running
@@ -640,23 +656,14 @@ func (f *dualEmitSuppressingFormatter) Format(entry *logrus.Entry) ([]byte, erro
 	return f.inner.Format(entry)
 }
 
-// levelFilteringFormatter wraps the console formatter and emits nothing for
-// entries more verbose than maxLevel. It is installed when the file sink's
-// level is more verbose than the console level: the logrus logger level must
-// admit the verbose entries so the FileHook can capture them, so the console
-// output filters them here instead. File sinks use their own formatter via
-// FileHook and are not affected.
-type levelFilteringFormatter struct {
-	maxLevel logrus.Level
-	inner    logrus.Formatter
-}
+// discardFormatter formats nothing. NewLogger installs it as the logger's
+// own formatter, whose output is discarded, so entries are only formatted
+// by the sink hooks.
+type discardFormatter struct{}
 
 // Format implements logrus.Formatter.
-func (f *levelFilteringFormatter) Format(entry *logrus.Entry) ([]byte, error) {
-	if entry.Level > f.maxLevel {
-		return nil, nil
-	}
-	return f.inner.Format(entry)
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
 }
 
 // dateRotatingWriter writes to a path derived from the current time and
@@ -676,6 +683,40 @@ type dateRotatingWriter struct {
 
 // newDateRotatingWriter opens the file for the current time. nowFn is
 // injectable for tests; nil means time.Now.
+// fixedLogPath returns the path function of a log file that never rolls,
+// and its path as the sink's key.
+func fixedLogPath(p string) (func(time.Time) string, string) {
+	return func(time.Time) string { return p }, p
+}
+
+// datedLogPath returns the path function of the daily log files
+// <dir>/<prefix>-<date>.log, and a key naming the sink across days.
+func datedLogPath(dir, prefix string) (func(time.Time) string, string) {
+	pathFn := func(now time.Time) string {
+		return filepath.Join(dir, fmt.Sprintf("%s-%s.log", prefix, now.Format("2006-01-02")))
+	}
+	return pathFn, filepath.Join(dir, prefix+"-<date>.log")
+}
+
+// openFileSink opens the date-rotating file sink for pathFn, behind the
+// async writer shared by every logger of the sink named key when cfg.Async
+// is set. The writer rolls to the next day's file itself, so it is shared
+// by key rather than by the day's path. nowFn is the sink's clock (nil for
+// time.Now).
+func openFileSink(pathFn func(time.Time) string, key string, cfg FileSinkConfig, nowFn func() time.Time) (io.Writer, error) {
+	openSink := func() (io.Writer, error) {
+		return newDateRotatingWriter(pathFn, nowFn)
+	}
+	if !cfg.Async {
+		return openSink()
+	}
+	w, err := sharedAsyncWriter(key, openSink, cfg.QueueSize, cfg.Overflow)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
 func newDateRotatingWriter(pathFn func(time.Time) string, nowFn func() time.Time) (*dateRotatingWriter, error) {
 	if nowFn == nil {
 		nowFn = time.Now
@@ -720,6 +761,13 @@ func (w *dateRotatingWriter) Write(p []byte) (int, error) {
 	return w.file.Write(p)
 }
 
+// Close closes the current file. The writer must not be used afterwards.
+func (w *dateRotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
 // FileHook is a logrus hook for writing logs to a file with a specific formatter.
 // It includes a mutex to handle concurrent writes from different tool processes.
 type FileHook struct {
@@ -727,10 +775,26 @@ type FileHook struct {
 	LogLevels []logrus.Level
 	Formatter logrus.Formatter
 	mu        sync.Mutex
+
+	// Set by NewLogger: entries more verbose than maxLevel, or than the
+	// runtime override for component, are dropped.
+	component string
+	maxLevel  logrus.Level
+	limited   bool
 }
 
 // Fire is called by logrus when a log entry is created.
 func (hook *FileHook) Fire(entry *logrus.Entry) error {
+	if hook.limited {
+		maxLevel := hook.maxLevel
+		if l, ok := runtimeLevelFor(hook.component); ok {
+			maxLevel = l
+		}
+		if entry.Level > maxLevel && entry.Data["event"] != StartupEvent {
+			return nil
+		}
+	}
+
 	hook.mu.Lock()
 	defer hook.mu.Unlock()
 
@@ -747,6 +811,115 @@ func (hook *FileHook) Levels() []logrus.Level {
 	return hook.LogLevels
 }
 
+// ConsoleHook is a logrus hook for writing logs to the console with its own
+// formatter. Entries more verbose than its level, or than the runtime
+// override for its component, are dropped, so the console and file sinks
+// filter independently although the logger admits the most verbose one.
+type ConsoleHook struct {
+	Writer    io.Writer
+	Formatter logrus.Formatter
+	mu        sync.Mutex
+
+	component string
+	maxLevel  logrus.Level
+	// jsonFormatter is the formatter SetConsoleJSON switches to.
+	jsonFormatter logrus.Formatter
+}
+
+// SetConsoleJSON switches the console sink of a logger returned by
+// NewLogger to JSON lines, as console.format json does, so that commands run
+// with --json keep stderr machine-readable. A logger without a console sink
+// is left unchanged.
+func SetConsoleJSON(logger *logrus.Logger) {
+	for _, h := range logger.Hooks[logrus.InfoLevel] {
+		hook, ok := h.(*ConsoleHook)
+		if !ok || hook.jsonFormatter == nil {
+			continue
+		}
+		hook.mu.Lock()
+		hook.Formatter = hook.jsonFormatter
+		hook.mu.Unlock()
+	}
+}
+
+// Fire is called by logrus when a log entry is created.
+func (hook *ConsoleHook) Fire(entry *logrus.Entry) error {
+	maxLevel := hook.maxLevel
+	if l, ok := runtimeLevelFor(hook.component); ok {
+		maxLevel = l
+	}
+	if entry.Level > maxLevel {
+		return nil
+	}
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	line, err := hook.Formatter.Format(entry)
+	if err != nil || len(line) == 0 {
+		return err
+	}
+	_, err = hook.Writer.Write(line)
+	return err
+}
+
+// Levels returns the log levels that this hook will fire for.
+func (hook *ConsoleHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// newConsoleFormatter returns the console sink's formatter: console.format
+// when set, otherwise format.preset.
+func newConsoleFormatter(cfg *Config, timeCfg timeSettings, redactor *Redactor) logrus.Formatter {
+	format := cfg.Console.Format
+	if format == "" {
+		format = cfg.Format.Preset
+	}
+	return consoleFormatterFor(format, cfg, timeCfg, redactor)
+}
+
+// consoleFormatterFor returns the console formatter for a console.format
+// value.
+func consoleFormatterFor(format string, cfg *Config, timeCfg timeSettings, redactor *Redactor) logrus.Formatter {
+	var f logrus.Formatter
+	switch format {
+	case "json":
+		f = newJSONFormatter(timeCfg)
+	case "simple":
+		f = &TextFormatter{Config: FormatConfig{
+			DisableTimestamp: true,
+			DisableComponent: true,
+		}}
+	default:
+		f = &TextFormatter{Config: cfg.Format, TimeFormat: timeCfg.format, Location: timeCfg.loc}
+	}
+	if redactor != nil {
+		f = &redactingFormatter{redactor: redactor, inner: f}
+	}
+	return f
+}
+
+// FileFormatter returns the formatter the file sink writes entries with
+// under cfg: JSON or text per file.format, in the configured time format and
+// zone, with the redact rules applied. It lets entries that did not pass
+// through a logger (such as ones ingested by the daemon) be written to the
+// log files exactly as a logger would have.
+func FileFormatter(cfg *Config) logrus.Formatter {
+	return newFileFormatter(cfg, resolveTimeSettings(cfg), NewRedactor(cfg.Redact))
+}
+
+func newFileFormatter(cfg *Config, timeCfg timeSettings, redactor *Redactor) logrus.Formatter {
+	var f logrus.Formatter
+	if cfg.File.Format == "json" {
+		f = newJSONFormatter(timeCfg)
+	} else {
+		f = &TextFormatter{Config: FormatConfig{DisableTimestamp: false}, TimeFormat: timeCfg.format, Location: timeCfg.loc}
+	}
+	if redactor != nil {
+		f = &redactingFormatter{redactor: redactor, inner: f}
+	}
+	return f
+}
+
 // IsTestBinary reports whether this process is a `go test` binary. Compiled
 // test executables are named <pkg>.test (also on the cached-build path,
 // .../bNNN/<pkg>.test); as a fallback the testing package's registered
@@ -765,18 +938,29 @@ func IsTestBinary() bool {
 	return flag.Lookup("test.v") != nil
 }
 
-// expandPath expands tilde in file paths
-func expandPath(path string) string {
-	if len(path) > 0 && path[0] == '~' {
-		home, err := os.UserHomeDir()
-		if err == nil {
-			return filepath.Join(home, path[1:])
+// WorkspaceLogsDir returns the directory holding ws's dated log files:
+// <file.dir>/<identifier> when logging.file.dir is set (relative to ws's
+// root), otherwise the state directory's logs/workspaces/<identifier>.
+// cfg should be the logging config loaded for ws.
+func WorkspaceLogsDir(cfg *Config, ws *workspace.WorkspaceNode) string {
+	identifier := ws.Identifier("/")
+	if cfg != nil && cfg.File.Dir != "" {
+		dir := expandPath(cfg.File.Dir)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(ws.Path, dir)
 		}
+		return filepath.Join(dir, filepath.FromSlash(identifier))
 	}
-	return path
+	return filepath.Join(paths.StateDir(), "logs", "workspaces", identifier)
+}
+
+// expandPath expands tilde in file paths
+func expandPath(path string) string {
+	return paths.ExpandHome(path)
 }
 
-// Reset clears the logger cache and resets the init state.
+// Reset clears the logger cache and resets the init state, closing async
+// file sinks after writing what they have queued.
 // This is primarily useful for testing when you need to reinitialize
 // loggers with different configurations.
 func Reset() {
@@ -784,10 +968,18 @@ func Reset() {
 	defer loggersMu.Unlock()
 	loggers = make(map[string]*logrus.Entry)
 	initOnce = sync.Once{}
+	runtimeMu.Lock()
+	levelControls = make(map[string]*levelControl)
+	fileLevels = nil
+	signalLevel = nil
+	runtimeMu.Unlock()
 	currentProjectOnce = sync.Once{}
 	currentProjectName = ""
 	setResolvedConsoleLevel(logrus.InfoLevel)
 	setResolvedPrettyFields(false)
+	setResolvedEscalator(nil)
+	recentEntries.clear()
+	closeAsyncWriters()
 
 	scopeMu.Lock()
 	activeScope = ScopeWorkspace
@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func TestWorkspaceLogsDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GROVE_HOME", home)
+	t.Setenv("HOME", home)
+	root := filepath.Join(home, "src", "api")
+	ws := &workspace.WorkspaceNode{Name: "api", Path: root, Kind: workspace.KindStandaloneProject}
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{"default", "", filepath.Join(paths.StateDir(), "logs", "workspaces", "api")},
+		{"absolute", "/dev/shm/grove", filepath.Join("/dev/shm/grove", "api")},
+		{"relative", ".grove/logs", filepath.Join(root, ".grove", "logs", "api")},
+		{"home", "~/tmp-logs", filepath.Join(home, "tmp-logs", "api")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{File: FileSinkConfig{Dir: tt.dir}}
+			if got := WorkspaceLogsDir(&cfg, ws); got != tt.want {
+				t.Errorf("WorkspaceLogsDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
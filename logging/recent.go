@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultRecentEntries is the number of entries the in-process ring buffer
+// keeps when logging.recent_entries is unset.
+const DefaultRecentEntries = 500
+
+// RecentEntry is one log entry kept in the in-process ring buffer.
+type RecentEntry struct {
+	Time      time.Time              `json:"time"`
+	Level     string                 `json:"level"`
+	Component string                 `json:"component,omitempty"`
+	Msg       string                 `json:"msg"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// recentRing is a bounded, process-wide buffer of the most recent entries
+// from every logger created by NewLogger. Once full, each new entry
+// overwrites the oldest.
+type recentRing struct {
+	mu      sync.Mutex
+	entries []RecentEntry
+	next    int
+	full    bool
+}
+
+var recentEntries = newRecentRing(DefaultRecentEntries)
+
+func newRecentRing(size int) *recentRing {
+	return &recentRing{entries: make([]RecentEntry, max(size, 0))}
+}
+
+// resize sets the capacity, keeping the newest entries that still fit.
+func (r *recentRing) resize(size int) {
+	size = max(size, 0)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if size == len(r.entries) {
+		return
+	}
+	kept := r.lastLocked(size)
+	r.entries = make([]RecentEntry, size)
+	copy(r.entries, kept)
+	r.next = len(kept) % max(size, 1)
+	r.full = size > 0 && len(kept) == size
+}
+
+func (r *recentRing) add(e RecentEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) == 0 {
+		return
+	}
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *recentRing) last(n int) []RecentEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastLocked(n)
+}
+
+// lastLocked returns up to n of the newest entries, oldest first; n <= 0
+// returns all of them.
+func (r *recentRing) lastLocked(n int) []RecentEntry {
+	count := r.next
+	if r.full {
+		count = len(r.entries)
+	}
+	if n <= 0 || n > count {
+		n = count
+	}
+	out := make([]RecentEntry, 0, n)
+	for i := count - n; i < count; i++ {
+		idx := i
+		if r.full {
+			idx = (r.next + i) % len(r.entries)
+		}
+		out = append(out, r.entries[idx])
+	}
+	return out
+}
+
+func (r *recentRing) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.entries)
+	r.next, r.full = 0, false
+}
+
+// RecentEntries returns up to n of the most recent entries logged in this
+// process, oldest first, or all retained entries when n <= 0. Long-running
+// binaries such as the daemon use it to expose their own recent logs (see
+// RecentEntriesHandler) without re-reading log files. Entries are kept at
+// the most verbose level any sink admits, with logging.redact applied.
+func RecentEntries(n int) []RecentEntry {
+	return recentEntries.last(n)
+}
+
+// RecentEntriesHandler serves RecentEntries as a JSON array. The optional
+// n query parameter limits the number of entries and level drops entries
+// less severe than the given level.
+func RecentEntriesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := 0
+		if v := r.URL.Query().Get("n"); v != "" {
+			parsed, err := strconv.Atoi(v)
+			if err != nil {
+				http.Error(w, "invalid n: "+v, http.StatusBadRequest)
+				return
+			}
+			n = parsed
+		}
+		entries := RecentEntries(0)
+		if v := r.URL.Query().Get("level"); v != "" {
+			minLevel, err := logrus.ParseLevel(v)
+			if err != nil {
+				http.Error(w, "invalid level: "+v, http.StatusBadRequest)
+				return
+			}
+			filtered := entries[:0]
+			for _, e := range entries {
+				if l, err := logrus.ParseLevel(e.Level); err == nil && l <= minLevel {
+					filtered = append(filtered, e)
+				}
+			}
+			entries = filtered
+		}
+		if n > 0 && n < len(entries) {
+			entries = entries[len(entries)-n:]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entries)
+	})
+}
+
+// recentHook copies every entry the logger admits into the ring buffer.
+type recentHook struct {
+	ring     *recentRing
+	redactor *Redactor
+}
+
+// Fire is called by logrus when a log entry is created.
+func (h *recentHook) Fire(entry *logrus.Entry) error {
+	data := entry.Data
+	if h.redactor != nil {
+		data = h.redactor.RedactFields(data)
+	}
+	e := RecentEntry{
+		Time:  entry.Time,
+		Level: entry.Level.String(),
+		Msg:   entry.Message,
+	}
+	for k, v := range data {
+		if k == "component" {
+			e.Component, _ = v.(string)
+			continue
+		}
+		if e.Fields == nil {
+			e.Fields = make(map[string]interface{}, len(data))
+		}
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		e.Fields[k] = v
+	}
+	h.ring.add(e)
+	return nil
+}
+
+// Levels returns the log levels that this hook will fire for.
+func (h *recentHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRecentRingKeepsNewest(t *testing.T) {
+	r := newRecentRing(3)
+	for i := 1; i <= 5; i++ {
+		r.add(RecentEntry{Msg: fmt.Sprint(i)})
+	}
+	msgs := func(entries []RecentEntry) string {
+		s := ""
+		for _, e := range entries {
+			s += e.Msg
+		}
+		return s
+	}
+	if got := msgs(r.last(0)); got != "345" {
+		t.Errorf("last(0) = %s, want 345", got)
+	}
+	if got := msgs(r.last(2)); got != "45" {
+		t.Errorf("last(2) = %s, want 45", got)
+	}
+
+	r.resize(2)
+	if got := msgs(r.last(0)); got != "45" {
+		t.Errorf("after shrinking, last(0) = %s, want 45", got)
+	}
+	r.add(RecentEntry{Msg: "6"})
+	if got := msgs(r.last(0)); got != "56" {
+		t.Errorf("after shrinking and adding, last(0) = %s, want 56", got)
+	}
+	r.resize(4)
+	r.add(RecentEntry{Msg: "7"})
+	if got := msgs(r.last(0)); got != "567" {
+		t.Errorf("after growing, last(0) = %s, want 567", got)
+	}
+
+	r.resize(0)
+	r.add(RecentEntry{Msg: "8"})
+	if got := r.last(0); len(got) != 0 {
+		t.Errorf("a zero-size ring should keep nothing, got %+v", got)
+	}
+}
+
+func TestRecentHook(t *testing.T) {
+	ring := newRecentRing(10)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(&recentHook{ring: ring, redactor: NewRedactor([]string{"token"})})
+
+	logger.WithFields(logrus.Fields{"component": "api", "token": "s3cret", "attempt": 2}).
+		WithError(errors.New("boom")).Warn("retrying")
+
+	got := ring.last(0)
+	if len(got) != 1 {
+		t.Fatalf("got %d entries, want 1", len(got))
+	}
+	e := got[0]
+	if e.Level != "warning" || e.Component != "api" || e.Msg != "retrying" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Fields["token"] == "s3cret" {
+		t.Error("redacted fields should be masked in the ring buffer")
+	}
+	if e.Fields["error"] != "boom" || e.Fields["attempt"] != 2 {
+		t.Errorf("fields = %+v", e.Fields)
+	}
+	if _, ok := e.Fields["component"]; ok {
+		t.Error("component should not be repeated in fields")
+	}
+}
+
+func TestRecentEntriesHandler(t *testing.T) {
+	defer recentEntries.clear()
+	recentEntries.clear()
+	for _, level := range []string{"debug", "info", "warning", "error"} {
+		recentEntries.add(RecentEntry{Level: level, Msg: level})
+	}
+
+	get := func(query string) []RecentEntry {
+		rec := httptest.NewRecorder()
+		RecentEntriesHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/logs/recent"+query, nil))
+		if rec.Code != 200 {
+			t.Fatalf("%s: status %d", query, rec.Code)
+		}
+		var entries []RecentEntry
+		if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return entries
+	}
+	if got := get(""); len(got) != 4 {
+		t.Errorf("all entries: got %d, want 4", len(got))
+	}
+	if got := get("?n=1"); len(got) != 1 || got[0].Msg != "error" {
+		t.Errorf("n=1: got %+v", got)
+	}
+	if got := get("?level=warn"); len(got) != 2 || got[0].Msg != "warning" {
+		t.Errorf("level=warn: got %+v", got)
+	}
+
+	rec := httptest.NewRecorder()
+	RecentEntriesHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/logs/recent?n=x", nil))
+	if rec.Code != 400 {
+		t.Errorf("invalid n: status %d, want 400", rec.Code)
+	}
+}
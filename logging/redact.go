@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RedactedValue replaces the value of any field matched by a redaction rule.
+const RedactedValue = "[REDACTED]"
+
+// Redactor masks the values of log fields whose keys match a set of rules.
+// Each rule is matched case-insensitively against the whole key: plain names
+// ("password") match that key exactly, and regular expressions (".*_secret")
+// match any key they fully cover. Rules that fail to compile as a regular
+// expression are matched literally.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles the given rules. It returns nil when no rules are
+// configured so callers can skip wrapping formatters entirely.
+func NewRedactor(rules []string) *Redactor {
+	var patterns []*regexp.Regexp
+	for _, rule := range rules {
+		if rule == "" {
+			continue
+		}
+		re, err := regexp.Compile("(?i)^(?:" + rule + ")$")
+		if err != nil {
+			re = regexp.MustCompile("(?i)^" + regexp.QuoteMeta(rule) + "$")
+		}
+		patterns = append(patterns, re)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return &Redactor{patterns: patterns}
+}
+
+// Matches reports whether key is covered by one of the redaction rules.
+func (r *Redactor) Matches(key string) bool {
+	for _, re := range r.patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactFields returns a copy of fields with matching keys masked, descending
+// into nested maps, slices and structs. The input is never modified, since
+// logrus shares entry data between hooks and the console formatter.
+func (r *Redactor) RedactFields(fields logrus.Fields) logrus.Fields {
+	out := make(logrus.Fields, len(fields))
+	for k, v := range fields {
+		if r.Matches(k) {
+			out[k] = RedactedValue
+			continue
+		}
+		out[k] = r.redactValue(v)
+	}
+	return out
+}
+
+// redactValue walks v and returns a masked copy when it contains a matching
+// key, or v itself otherwise.
+func (r *Redactor) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil, string, bool, int, int64, float64, error:
+		return v
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, inner := range val {
+			if r.Matches(k) {
+				out[k] = RedactedValue
+				continue
+			}
+			out[k] = r.redactValue(inner)
+		}
+		return out
+	case logrus.Fields:
+		return r.RedactFields(val)
+	case map[string]string:
+		out := make(map[string]string, len(val))
+		for k, inner := range val {
+			if r.Matches(k) {
+				inner = RedactedValue
+			}
+			out[k] = inner
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, inner := range val {
+			out[i] = r.redactValue(inner)
+		}
+		return out
+	}
+
+	// Structs, typed maps and slices: normalize through JSON (the same
+	// representation the JSON sinks emit) and walk the generic form. The
+	// original value is kept when nothing inside it matched.
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return v
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return v
+	}
+	redacted := r.redactValue(generic)
+	if reflect.DeepEqual(redacted, generic) {
+		return v
+	}
+	return redacted
+}
+
+// redactingFormatter masks matching fields before delegating to the inner
+// formatter. It is installed on both the console formatter and the FileHook
+// formatter when logging.redact is configured, so secrets never reach any
+// sink.
+type redactingFormatter struct {
+	redactor *Redactor
+	inner    logrus.Formatter
+}
+
+// Format implements logrus.Formatter.
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	masked := *entry
+	masked.Data = f.redactor.RedactFields(entry.Data)
+	return f.inner.Format(&masked)
+}
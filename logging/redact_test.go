@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedactorMatches(t *testing.T) {
+	r := NewRedactor([]string{"password", "token", ".*_secret", "bad[regex"})
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"PASSWORD", true},
+		{"token", true},
+		{"token_count", false},
+		{"client_secret", true},
+		{"secret", false},
+		{"bad[regex", true},
+		{"user", false},
+	}
+	for _, tt := range tests {
+		if got := r.Matches(tt.key); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestNewRedactorEmpty(t *testing.T) {
+	if r := NewRedactor(nil); r != nil {
+		t.Error("expected nil redactor for no rules")
+	}
+	if r := NewRedactor([]string{""}); r != nil {
+		t.Error("expected nil redactor for empty rules")
+	}
+}
+
+func TestRedactFieldsNested(t *testing.T) {
+	type creds struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	}
+
+	r := NewRedactor([]string{"password", "token", ".*_secret"})
+	headers := map[string]string{"Authorization": "Bearer abc", "token": "xyz"}
+	fields := logrus.Fields{
+		"token": "top-level",
+		"user":  "alice",
+		"request": map[string]interface{}{
+			"url":     "https://example.com",
+			"headers": headers,
+			"auth": map[string]interface{}{
+				"client_secret": "s3cr3t",
+				"client_id":     "id",
+			},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"password": "p1", "name": "a"},
+		},
+		"creds": creds{User: "bob", Password: "hunter2"},
+	}
+
+	out := r.RedactFields(fields)
+
+	if out["token"] != RedactedValue {
+		t.Errorf("top-level token not redacted: %v", out["token"])
+	}
+	if out["user"] != "alice" {
+		t.Errorf("unrelated field changed: %v", out["user"])
+	}
+	req := out["request"].(map[string]interface{})
+	if got := req["headers"].(map[string]string)["token"]; got != RedactedValue {
+		t.Errorf("nested map[string]string token not redacted: %v", got)
+	}
+	auth := req["auth"].(map[string]interface{})
+	if auth["client_secret"] != RedactedValue || auth["client_id"] != "id" {
+		t.Errorf("nested auth not redacted correctly: %v", auth)
+	}
+	item := out["items"].([]interface{})[0].(map[string]interface{})
+	if item["password"] != RedactedValue || item["name"] != "a" {
+		t.Errorf("slice element not redacted correctly: %v", item)
+	}
+	c := out["creds"].(map[string]interface{})
+	if c["password"] != RedactedValue || c["user"] != "bob" {
+		t.Errorf("struct not redacted correctly: %v", c)
+	}
+
+	// The input must be left untouched for other sinks.
+	if fields["token"] != "top-level" || headers["token"] != "xyz" {
+		t.Error("RedactFields modified its input")
+	}
+}
+
+func TestRedactingFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&redactingFormatter{
+		redactor: NewRedactor([]string{"authorization"}),
+		inner:    &logrus.JSONFormatter{},
+	})
+
+	logger.WithFields(logrus.Fields{
+		"headers": map[string]interface{}{"Authorization": "Bearer leaked"},
+	}).Info("request")
+
+	if strings.Contains(buf.String(), "leaked") {
+		t.Fatalf("bearer token leaked into output: %s", buf.String())
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	headers := decoded["headers"].(map[string]interface{})
+	if headers["Authorization"] != RedactedValue {
+		t.Errorf("expected redacted header, got %v", headers["Authorization"])
+	}
+}
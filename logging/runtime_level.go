@@ -0,0 +1,264 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// Runtime level overrides let a running process be made more or less
+// verbose without a restart. Two sources feed them:
+//
+//   - The control file (see ControlFilePath), written by
+//     `core logs set-level`, maps component names to levels; "*" applies to
+//     every component. Running processes poll it every controlPollInterval.
+//   - SIGUSR1 / SIGUSR2 step the process-wide level one notch more / less
+//     verbose (unix only), in processes whose main package calls
+//     EnableLevelSignals.
+//
+// For a component, a control-file entry naming it wins, then the signal
+// level, then the control file's "*" entry. An override replaces both the
+// console and file levels resolved from configuration.
+
+// controlPollInterval is how often running processes check the control file.
+const controlPollInterval = 2 * time.Second
+
+// AllComponents is the control-file key that applies to every component.
+const AllComponents = "*"
+
+// ControlFile is the on-disk shape of the log level control file.
+type ControlFile struct {
+	Levels map[string]string `json:"levels"`
+}
+
+// levelControl is what NewLogger registers so overrides can re-level an
+// existing logger: its configured logrus level, restored on reset.
+type levelControl struct {
+	logger *logrus.Logger
+	base   logrus.Level
+}
+
+var (
+	runtimeMu     sync.RWMutex
+	fileLevels    map[string]logrus.Level
+	signalLevel   *logrus.Level
+	levelControls = make(map[string]*levelControl)
+
+	runtimeControlOnce sync.Once
+	levelSignalsOnce   sync.Once
+)
+
+// runtimeLevelFor returns the override in effect for component, if any.
+func runtimeLevelFor(component string) (logrus.Level, bool) {
+	runtimeMu.RLock()
+	defer runtimeMu.RUnlock()
+	return runtimeLevelLocked(component)
+}
+
+func runtimeLevelLocked(component string) (logrus.Level, bool) {
+	if l, ok := fileLevels[component]; ok {
+		return l, true
+	}
+	if signalLevel != nil {
+		return *signalLevel, true
+	}
+	if l, ok := fileLevels[AllComponents]; ok {
+		return l, true
+	}
+	return 0, false
+}
+
+// registerLevelControl records a new logger and applies any override
+// already in effect for its component.
+func registerLevelControl(component string, logger *logrus.Logger) {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+	lc := &levelControl{logger: logger, base: logger.GetLevel()}
+	levelControls[component] = lc
+	applyLocked(component, lc)
+}
+
+// applyLocked sets lc's logrus level so entries at the override level are
+// created at all; the console filter and file hook then trim each sink.
+func applyLocked(component string, lc *levelControl) {
+	if l, ok := runtimeLevelLocked(component); ok {
+		lc.logger.SetLevel(l)
+	} else {
+		lc.logger.SetLevel(lc.base)
+	}
+}
+
+func applyAllLocked() {
+	for component, lc := range levelControls {
+		applyLocked(component, lc)
+	}
+}
+
+// StepLogLevel moves the process-wide level delta notches towards more
+// verbose (positive) or less verbose (negative), clamped between error and
+// trace, and returns the new level. It is what SIGUSR1/SIGUSR2 call once
+// EnableLevelSignals is in effect.
+func StepLogLevel(delta int) logrus.Level {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+	cur := ConsoleLevel()
+	if signalLevel != nil {
+		cur = *signalLevel
+	} else if l, ok := fileLevels[AllComponents]; ok {
+		cur = l
+	}
+	next := int(cur) + delta
+	next = max(int(logrus.ErrorLevel), min(int(logrus.TraceLevel), next))
+	l := logrus.Level(next)
+	signalLevel = &l
+	applyAllLocked()
+	return l
+}
+
+// ResetRuntimeLevels drops all runtime overrides, restoring configured
+// levels.
+func ResetRuntimeLevels() {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+	fileLevels = nil
+	signalLevel = nil
+	applyAllLocked()
+}
+
+// setFileLevels replaces the control-file overrides.
+func setFileLevels(levels map[string]logrus.Level) {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+	fileLevels = levels
+	applyAllLocked()
+}
+
+// ControlFilePath returns the log level control file:
+// GROVE_LOG_CONTROL_FILE when set, otherwise log-levels.json in the
+// per-user state directory. It does not depend on the working directory,
+// so `core logs set-level` and a daemon started elsewhere agree on it.
+func ControlFilePath() string {
+	if p := os.Getenv("GROVE_LOG_CONTROL_FILE"); p != "" {
+		return expandPath(p)
+	}
+	dir := paths.StateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "log-levels.json")
+}
+
+// ReadControlFile reads and validates the control file at path. A missing
+// file is an empty ControlFile.
+func ReadControlFile(path string) (ControlFile, error) {
+	cf := ControlFile{Levels: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cf, nil
+	}
+	if err != nil {
+		return cf, err
+	}
+	if err := json.Unmarshal(data, &cf); err != nil {
+		return cf, fmt.Errorf("invalid log level control file %s: %w", path, err)
+	}
+	if cf.Levels == nil {
+		cf.Levels = map[string]string{}
+	}
+	return cf, nil
+}
+
+// WriteControlFile writes cf to path atomically, creating its directory as
+// needed.
+func WriteControlFile(path string, cf ControlFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(cf, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil { //nolint:gosec // not sensitive
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// parseControlLevels converts control file entries to logrus levels,
+// skipping (and reporting) invalid ones.
+func parseControlLevels(cf ControlFile) (map[string]logrus.Level, []string) {
+	levels := make(map[string]logrus.Level, len(cf.Levels))
+	var bad []string
+	for component, s := range cf.Levels {
+		l, err := logrus.ParseLevel(s)
+		if err != nil {
+			bad = append(bad, component)
+			continue
+		}
+		levels[component] = l
+	}
+	sort.Strings(bad)
+	return levels, bad
+}
+
+// EnableLevelSignals makes SIGUSR1 raise and SIGUSR2 lower the log level
+// of the calling process (unix only). Installing a process-wide signal
+// handler is up to the main package, so NewLogger never does it; a process
+// that uses SIGUSR1 for something else, like groved's drain on upgrade,
+// must not call it.
+func EnableLevelSignals() {
+	levelSignalsOnce.Do(notifyLevelSignals)
+}
+
+// startRuntimeLevelControl starts polling the control file. NewLogger calls
+// it once per process outside of tests.
+func startRuntimeLevelControl() {
+	runtimeControlOnce.Do(func() {
+		path := ControlFilePath()
+		if path == "" {
+			return
+		}
+		go pollControlFile(path, controlPollInterval)
+	})
+}
+
+// pollControlFile reloads path whenever its modification time changes,
+// including when it is created or removed.
+func pollControlFile(path string, interval time.Duration) {
+	var last time.Time
+	var existed bool
+	for {
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && (!existed || !info.ModTime().Equal(last)):
+			existed, last = true, info.ModTime()
+			loadControlFile(path)
+		case err != nil && existed:
+			existed = false
+			setFileLevels(nil)
+		}
+		time.Sleep(interval)
+	}
+}
+
+func loadControlFile(path string) {
+	cf, err := ReadControlFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "grove-log: %v\n", err)
+		return
+	}
+	levels, bad := parseControlLevels(cf)
+	if len(bad) > 0 {
+		fmt.Fprintf(os.Stderr, "grove-log: ignoring invalid levels in %s for: %v\n", path, bad)
+	}
+	setFileLevels(levels)
+}
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+func TestRuntimeLevelPrecedence(t *testing.T) {
+	defer ResetRuntimeLevels()
+
+	setFileLevels(map[string]logrus.Level{
+		AllComponents: logrus.WarnLevel,
+		"api":         logrus.TraceLevel,
+	})
+	if l, ok := runtimeLevelFor("api"); !ok || l != logrus.TraceLevel {
+		t.Errorf("api: expected trace override, got %v (%v)", l, ok)
+	}
+	if l, ok := runtimeLevelFor("other"); !ok || l != logrus.WarnLevel {
+		t.Errorf("other: expected \"*\" override warn, got %v (%v)", l, ok)
+	}
+
+	// A signal level beats the "*" entry but not a component entry.
+	signalled := StepLogLevel(1)
+	if signalled != logrus.InfoLevel {
+		t.Errorf("expected step from warn to info, got %v", signalled)
+	}
+	if l, _ := runtimeLevelFor("other"); l != logrus.InfoLevel {
+		t.Errorf("other: expected signal level info, got %v", l)
+	}
+	if l, _ := runtimeLevelFor("api"); l != logrus.TraceLevel {
+		t.Errorf("api: expected component entry to win, got %v", l)
+	}
+
+	ResetRuntimeLevels()
+	if _, ok := runtimeLevelFor("api"); ok {
+		t.Error("expected no override after reset")
+	}
+}
+
+func TestStepLogLevelClamps(t *testing.T) {
+	defer ResetRuntimeLevels()
+
+	for i := 0; i < 10; i++ {
+		StepLogLevel(1)
+	}
+	if l := StepLogLevel(1); l != logrus.TraceLevel {
+		t.Errorf("expected clamp at trace, got %v", l)
+	}
+	for i := 0; i < 10; i++ {
+		StepLogLevel(-1)
+	}
+	if l := StepLogLevel(-1); l != logrus.ErrorLevel {
+		t.Errorf("expected clamp at error, got %v", l)
+	}
+}
+
+func TestRuntimeLevelRelevelsLogger(t *testing.T) {
+	defer Reset()
+
+	entry := NewLogger("runtime-level-test")
+	base := entry.Logger.GetLevel()
+
+	setFileLevels(map[string]logrus.Level{"runtime-level-test": logrus.TraceLevel})
+	if got := entry.Logger.GetLevel(); got != logrus.TraceLevel {
+		t.Errorf("expected logger at trace after override, got %v", got)
+	}
+
+	ResetRuntimeLevels()
+	if got := entry.Logger.GetLevel(); got != base {
+		t.Errorf("expected logger restored to %v, got %v", base, got)
+	}
+}
+
+func TestControlFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".grove", "log-levels.json")
+
+	cf, err := ReadControlFile(path)
+	if err != nil {
+		t.Fatalf("missing file should read as empty: %v", err)
+	}
+	if len(cf.Levels) != 0 {
+		t.Errorf("expected no levels, got %v", cf.Levels)
+	}
+
+	cf.Levels["api"] = "debug"
+	cf.Levels[AllComponents] = "bogus"
+	if err := WriteControlFile(path, cf); err != nil {
+		t.Fatalf("WriteControlFile: %v", err)
+	}
+
+	got, err := ReadControlFile(path)
+	if err != nil {
+		t.Fatalf("ReadControlFile: %v", err)
+	}
+	levels, bad := parseControlLevels(got)
+	if levels["api"] != logrus.DebugLevel {
+		t.Errorf("expected api=debug, got %v", levels["api"])
+	}
+	if len(bad) != 1 || bad[0] != AllComponents {
+		t.Errorf("expected \"*\" reported invalid, got %v", bad)
+	}
+}
+
+func TestControlFilePathEnv(t *testing.T) {
+	want := filepath.Join(t.TempDir(), "levels.json")
+	t.Setenv("GROVE_LOG_CONTROL_FILE", want)
+	if got := ControlFilePath(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestControlFilePathIgnoresWorkingDirectory(t *testing.T) {
+	t.Setenv("GROVE_LOG_CONTROL_FILE", "")
+	t.Setenv("GROVE_HOME", t.TempDir())
+	want := filepath.Join(paths.StateDir(), "log-levels.json")
+
+	t.Chdir(t.TempDir())
+	if got := ControlFilePath(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
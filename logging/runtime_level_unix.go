@@ -0,0 +1,24 @@
+//go:build !windows
+
+package logging
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyLevelSignals makes SIGUSR1 raise and SIGUSR2 lower verbosity.
+func notifyLevelSignals() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for sig := range ch {
+			delta := 1
+			if sig == syscall.SIGUSR2 {
+				delta = -1
+			}
+			StepLogLevel(delta)
+		}
+	}()
+}
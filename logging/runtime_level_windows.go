@@ -0,0 +1,7 @@
+//go:build windows
+
+package logging
+
+// notifyLevelSignals is a no-op: Windows has no SIGUSR1/SIGUSR2. Use the
+// control file instead.
+func notifyLevelSignals() {}
@@ -0,0 +1,195 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SlogHandler is a log/slog Handler that writes through the Grove logging
+// pipeline, so packages logging with slog get the component field,
+// component filtering, redaction and the file sinks like any other Grove
+// logger. Attribute groups become dotted field names ("req.id").
+type SlogHandler struct {
+	entry  *logrus.Entry
+	fields logrus.Fields
+	prefix string
+}
+
+// NewSlogHandler returns a slog Handler for component, backed by
+// NewLogger(component):
+//
+//	log := slog.New(logging.NewSlogHandler("my-service"))
+//	log.Info("listening", "addr", addr)
+func NewSlogHandler(component string) *SlogHandler {
+	return newSlogHandler(NewLogger(component))
+}
+
+func newSlogHandler(entry *logrus.Entry) *SlogHandler {
+	return &SlogHandler{entry: entry, fields: logrus.Fields{}}
+}
+
+// Enabled reports whether the underlying logger admits level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.entry.Logger.IsLevelEnabled(slogToLogrusLevel(level))
+}
+
+// Handle writes r as one log entry.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make(logrus.Fields, len(h.fields)+r.NumAttrs())
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(fields, h.prefix, a)
+		return true
+	})
+
+	entry := h.entry.WithFields(fields)
+	if !r.Time.IsZero() {
+		entry = entry.WithTime(r.Time)
+	}
+	if ctx != nil {
+		entry = entry.WithContext(ctx)
+	}
+	entry.Log(slogToLogrusLevel(r.Level), r.Message)
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to every entry.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := h.clone()
+	for _, a := range attrs {
+		addSlogAttr(clone.fields, clone.prefix, a)
+	}
+	return clone
+}
+
+// WithGroup returns a handler that nests later attributes under name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := h.clone()
+	clone.prefix += name + "."
+	return clone
+}
+
+func (h *SlogHandler) clone() *SlogHandler {
+	fields := make(logrus.Fields, len(h.fields))
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	return &SlogHandler{entry: h.entry, fields: fields, prefix: h.prefix}
+}
+
+// addSlogAttr stores a under prefix, flattening groups into dotted keys.
+// Empty attributes are dropped and groups without a key are inlined, as
+// the slog Handler contract asks.
+func addSlogAttr(fields logrus.Fields, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		if len(group) == 0 {
+			return
+		}
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range group {
+			addSlogAttr(fields, prefix, ga)
+		}
+		return
+	}
+	fields[prefix+a.Key] = a.Value.Any()
+}
+
+func slogToLogrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	case level >= slog.LevelDebug:
+		return logrus.DebugLevel
+	default:
+		return logrus.TraceLevel
+	}
+}
+
+// NewZerologWriter returns a writer for zerolog (zerolog.New(w)) that
+// re-emits each JSON event through NewLogger(component). The level,
+// message and time fields map to the entry's own; every other field is
+// kept. Lines that are not JSON are logged at info as they are. Fatal and
+// panic events are logged at error: zerolog itself exits or panics after
+// writing them.
+func NewZerologWriter(component string) io.Writer {
+	return &zerologWriter{entry: NewLogger(component)}
+}
+
+type zerologWriter struct {
+	entry *logrus.Entry
+}
+
+func (w *zerologWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		w.writeEvent(line)
+	}
+	return len(p), nil
+}
+
+func (w *zerologWriter) writeEvent(line []byte) {
+	var event map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&event); err != nil {
+		w.entry.Info(string(line))
+		return
+	}
+
+	level := logrus.InfoLevel
+	if s, ok := event["level"].(string); ok {
+		level = zerologToLogrusLevel(s)
+	}
+	msg, _ := event["message"].(string)
+	entry := w.entry
+	if s, ok := event["time"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			entry = entry.WithTime(t)
+		}
+	}
+	delete(event, "level")
+	delete(event, "message")
+	delete(event, "time")
+	entry.WithFields(logrus.Fields(event)).Log(level, msg)
+}
+
+func zerologToLogrusLevel(level string) logrus.Level {
+	switch strings.ToLower(level) {
+	case "trace":
+		return logrus.TraceLevel
+	case "debug":
+		return logrus.DebugLevel
+	case "warn":
+		return logrus.WarnLevel
+	case "error", "fatal", "panic":
+		return logrus.ErrorLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestEntry(buf *bytes.Buffer) *logrus.Entry {
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	logger.SetLevel(logrus.DebugLevel)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	return logger.WithField("component", "svc")
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("bad line %q: %v", line, err)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(newSlogHandler(newTestEntry(&buf)))
+
+	log.With("service", "api").WithGroup("req").Warn("slow request", "id", 7, slog.Group("db", "ms", 120))
+	log.Debug("debug line")
+	log.Log(context.Background(), slog.LevelDebug-4, "trace line is filtered")
+
+	entries := decodeLines(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %s", len(entries), buf.String())
+	}
+	first := entries[0]
+	for key, want := range map[string]interface{}{
+		"level": "warning", "msg": "slow request", "component": "svc",
+		"service": "api", "req.id": float64(7), "req.db.ms": float64(120),
+	} {
+		if first[key] != want {
+			t.Errorf("%s = %v, want %v", key, first[key], want)
+		}
+	}
+	if entries[1]["level"] != "debug" {
+		t.Errorf("second entry level = %v", entries[1]["level"])
+	}
+}
+
+// TestSlogHandlerConformance runs the standard library's handler checks,
+// reading attributes back out of the flattened dotted fields.
+func TestSlogHandlerConformance(t *testing.T) {
+	var buf bytes.Buffer
+	newHandler := func(t *testing.T) slog.Handler {
+		if strings.Contains(t.Name(), "zero-time") {
+			t.Skip("logrus stamps every entry with the current time")
+		}
+		buf.Reset()
+		return newSlogHandler(newTestEntry(&buf))
+	}
+	result := func(t *testing.T) map[string]any {
+		lines := decodeLines(t, &buf)
+		if len(lines) != 1 {
+			t.Fatalf("got %d entries, want 1", len(lines))
+		}
+		nested := map[string]any{}
+		for k, v := range lines[0] {
+			switch k {
+			case "level":
+				nested[slog.LevelKey] = v
+			case "msg":
+				nested[slog.MessageKey] = v
+			case "time":
+				nested[slog.TimeKey] = v
+			case "component":
+			default:
+				parts := strings.Split(k, ".")
+				cur := nested
+				for _, p := range parts[:len(parts)-1] {
+					next, ok := cur[p].(map[string]any)
+					if !ok {
+						next = map[string]any{}
+						cur[p] = next
+					}
+					cur = next
+				}
+				cur[parts[len(parts)-1]] = v
+			}
+		}
+		return nested
+	}
+	slogtest.Run(t, newHandler, result)
+}
+
+func TestZerologWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &zerologWriter{entry: newTestEntry(&buf)}
+
+	_, _ = w.Write([]byte(`{"level":"error","time":"2024-05-01T10:00:00Z","user":"u1","message":"failed"}` + "\n"))
+	_, _ = w.Write([]byte("plain text line\n"))
+
+	entries := decodeLines(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %s", len(entries), buf.String())
+	}
+	if e := entries[0]; e["level"] != "error" || e["msg"] != "failed" || e["user"] != "u1" || !strings.HasPrefix(e["time"].(string), "2024-05-01T10:00:00") {
+		t.Errorf("zerolog event = %v", e)
+	}
+	if e := entries[1]; e["level"] != "info" || e["msg"] != "plain text line" {
+		t.Errorf("plain line = %v", e)
+	}
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/version"
+)
+
+// StartupEvent is the event field of the banner entry logging.log_startup
+// writes once per process. The logs TUI renders these entries as
+// separators between runs.
+const StartupEvent = "process.started"
+
+// StartupMessage is the message of the startup banner entry.
+const StartupMessage = "Grove binary started"
+
+// IsStartupEntry reports whether a parsed log entry is a startup banner.
+func IsStartupEntry(logMap map[string]interface{}) bool {
+	ev, _ := logMap["event"].(string)
+	return ev == StartupEvent
+}
+
+// startupFields collects the banner's fields: build information, host and
+// pid, the levels in effect and the config layer files of the working
+// directory.
+func startupFields(consoleLevel, fileLevel logrus.Level) VersionFields {
+	info := version.GetInfo()
+	binaryName := "unknown"
+	if len(os.Args) > 0 {
+		binaryName = filepath.Base(os.Args[0])
+	}
+	hostname, _ := os.Hostname()
+
+	fields := VersionFields{
+		Branch:         info.Branch,
+		Commit:         info.Commit,
+		Binary:         binaryName,
+		Version:        info.Version,
+		Platform:       info.Platform,
+		GoVersion:      info.GoVersion,
+		BuildDate:      info.BuildDate,
+		Compiler:       info.Compiler,
+		Hostname:       hostname,
+		PID:            os.Getpid(),
+		EffectiveLevel: consoleLevel.String(),
+		FileLevel:      fileLevel.String(),
+		ConfigLayers:   []string{},
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if layered, err := config.LoadLayered(cwd); err == nil {
+			fields.ConfigLayers = layered.LayerPaths()
+		}
+	}
+	return fields
+}
+
+// logStartupBanner writes the startup banner through logger's sinks. The
+// banner is logged at info even when the logger or the file sink is set to
+// a quieter level, so every run of a process leaves a marker in its log
+// file; the console still trims it to consoleLevel.
+func logStartupBanner(logger *logrus.Logger, consoleLevel, fileLevel logrus.Level) {
+	vf := startupFields(consoleLevel, fileLevel)
+	binaryName := vf.Binary
+	// Use grove-<binary> as component for clearer identification
+	// Don't prepend grove- if binary name already starts with grove-
+	componentName := binaryName
+	if !strings.HasPrefix(binaryName, "grove-") && binaryName != "grove" {
+		componentName = fmt.Sprintf("grove-%s", binaryName)
+	}
+
+	fields := StructToLogrusFields(vf)
+	fields["component"] = componentName
+	fields["event"] = StartupEvent
+
+	banner := logrus.New()
+	banner.Out = logger.Out
+	banner.Hooks = logger.Hooks
+	banner.Formatter = logger.Formatter
+	banner.ReportCaller = logger.ReportCaller
+	banner.SetLevel(mostVerbose(logger.GetLevel(), logrus.InfoLevel))
+	banner.WithFields(fields).Info(StartupMessage)
+}
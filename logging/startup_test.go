@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestStartupBannerReachesQuietFileSink(t *testing.T) {
+	var file bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.WarnLevel)
+	logger.AddHook(&FileHook{
+		Writer:    &file,
+		LogLevels: logrus.AllLevels,
+		Formatter: &logrus.JSONFormatter{},
+		component: "startup-test",
+		maxLevel:  logrus.WarnLevel,
+		limited:   true,
+	})
+
+	logger.Info("ordinary entry")
+	logStartupBanner(logger, logrus.WarnLevel, logrus.WarnLevel)
+
+	lines := bytes.Split(bytes.TrimSpace(file.Bytes()), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("file sink got %d lines, want only the banner:\n%s", len(lines), file.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(lines[0], &entry); err != nil {
+		t.Fatal(err)
+	}
+	if !IsStartupEntry(entry) || entry["msg"] != StartupMessage || entry["level"] != "info" {
+		t.Errorf("banner entry = %v", entry)
+	}
+	if pid, _ := entry["pid"].(float64); int(pid) != os.Getpid() {
+		t.Errorf("pid = %v, want %d", entry["pid"], os.Getpid())
+	}
+	if entry["effectiveLevel"] != "warning" || entry["fileLevel"] != "warning" {
+		t.Errorf("levels = %v / %v", entry["effectiveLevel"], entry["fileLevel"])
+	}
+	if _, ok := entry["configLayers"].([]interface{}); !ok {
+		t.Errorf("configLayers should be a list, got %T", entry["configLayers"])
+	}
+	if logger.GetLevel() != logrus.WarnLevel {
+		t.Error("the banner must not change the logger's level")
+	}
+}
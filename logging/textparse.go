@@ -0,0 +1,203 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sirupsen/logrus"
+)
+
+// textEntryPattern matches the TextFormatter layout:
+//
+//	[time ][LEVEL][ [component]][ [file:line func]] message key=value...
+var textEntryPattern = regexp.MustCompile(`^(?:(.*?) )?\[(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL|PANIC)\](?: \[([^\]\s]+)\])?(?: \[([^\]\s]+:\d+) ([^\]]+)\])?(?: (.*))?$`)
+
+// textFieldPattern matches the " key=" that starts each trailing field.
+var textFieldPattern = regexp.MustCompile(` ([A-Za-z_][A-Za-z0-9_.\-]*)=`)
+
+// ParseTextEntry parses a line written by TextFormatter back into the
+// fields the JSON file formatter writes: time (RFC 3339), level, msg,
+// component, file and func for the caller, and the trailing key=value
+// fields, with numbers and booleans typed. timeFormat and loc are the
+// logging.time_format and logging.timezone the line was written with (loc
+// nil is local time). It reports false for a line that is not the start of
+// an entry, such as the continuation of a multi-line message.
+//
+// Field values were written with %v, so a message containing " key=" text
+// is split there, and a field value containing spaces runs up to the next
+// field.
+func ParseTextEntry(line, timeFormat string, loc *time.Location) (map[string]interface{}, bool) {
+	entry, rest, ok := parseTextHeader(line, timeFormat, loc)
+	if !ok {
+		return nil, false
+	}
+	setTextBody(entry, rest)
+	return entry, true
+}
+
+// parseTextHeader parses the time, level, component and caller of a
+// TextFormatter line, returning the message and fields that follow them.
+func parseTextHeader(line, timeFormat string, loc *time.Location) (map[string]interface{}, string, bool) {
+	m := textEntryPattern.FindStringSubmatch(ansi.Strip(strings.TrimRight(line, "\r\n")))
+	if m == nil {
+		return nil, "", false
+	}
+	entry := make(map[string]interface{})
+	if m[1] != "" {
+		t, ok := parseTextTime(m[1], timeFormat, loc)
+		if !ok {
+			return nil, "", false
+		}
+		entry[logrus.FieldKeyTime] = t.Format(time.RFC3339)
+	}
+	level, err := logrus.ParseLevel(strings.ToLower(m[2]))
+	if err != nil {
+		return nil, "", false
+	}
+	entry[logrus.FieldKeyLevel] = level.String()
+	if m[3] != "" {
+		entry["component"] = m[3]
+	}
+	if m[4] != "" {
+		entry[logrus.FieldKeyFile] = m[4]
+		entry[logrus.FieldKeyFunc] = m[5]
+	}
+	return entry, m[6], true
+}
+
+// setTextBody splits the message from the trailing key=value fields of
+// rest and sets both on entry.
+func setTextBody(entry map[string]interface{}, rest string) {
+	fields := textFieldPattern.FindAllStringSubmatchIndex(rest, -1)
+	msgEnd := len(rest)
+	if len(fields) > 0 {
+		msgEnd = fields[0][0]
+	}
+	entry[logrus.FieldKeyMsg] = rest[:msgEnd]
+	for i, f := range fields {
+		end := len(rest)
+		if i+1 < len(fields) {
+			end = fields[i+1][0]
+		}
+		key := rest[f[2]:f[3]]
+		if _, taken := entry[key]; taken {
+			key = "fields." + key
+		}
+		entry[key] = parseTextValue(rest[f[1]:end])
+	}
+}
+
+// parseTextTime parses a TextFormatter timestamp written with timeFormat in
+// loc.
+func parseTextTime(s, timeFormat string, loc *time.Location) (time.Time, bool) {
+	layout := timeLayout(timeFormat, textTimeLayout)
+	if layout == "" {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.UnixMilli(ms), true
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation(layout, s, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// parseTextValue types a %v-formatted field value: integers, floats and
+// booleans become JSON numbers and booleans, anything else stays a string.
+func parseTextValue(s string) interface{} {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(s); err == nil && (s == "true" || s == "false") {
+		return b
+	}
+	return s
+}
+
+// TextConversion counts what ConvertTextLog did with its input lines.
+type TextConversion struct {
+	// Entries is the number of entries written.
+	Entries int `json:"entries"`
+	// Continued is the number of lines appended to the message of the
+	// entry before them (multi-line messages, stack traces).
+	Continued int `json:"continued"`
+	// Passthrough is the number of lines that were already JSON.
+	Passthrough int `json:"passthrough"`
+	// Skipped is the number of lines before the first entry that could not
+	// be attached to one.
+	Skipped int `json:"skipped"`
+}
+
+// ConvertTextLog rewrites a TextFormatter log from r as JSON lines on w
+// (see ParseTextEntry). Lines that do not start an entry are appended to
+// the previous entry's message, and lines that are already JSON objects are
+// copied through, so partially migrated files convert cleanly.
+func ConvertTextLog(r io.Reader, w io.Writer, timeFormat string, loc *time.Location) (TextConversion, error) {
+	var stats TextConversion
+	// The entry being assembled: a message with a line break carries its
+	// fields after the last line, so the body is split at flush.
+	var pending map[string]interface{}
+	var body string
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	flush := func() error {
+		if pending == nil {
+			return nil
+		}
+		setTextBody(pending, body)
+		stats.Entries++
+		err := enc.Encode(pending)
+		pending = nil
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+			stats.Passthrough++
+			if _, err := io.WriteString(w, trimmed+"\n"); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		if entry, rest, ok := parseTextHeader(line, timeFormat, loc); ok {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+			pending, body = entry, rest
+			continue
+		}
+		if pending == nil {
+			if strings.TrimSpace(line) != "" {
+				stats.Skipped++
+			}
+			continue
+		}
+		body += "\n" + ansi.Strip(line)
+		stats.Continued++
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, err
+	}
+	return stats, flush()
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseTextEntryReadsTextFormatterOutput(t *testing.T) {
+	when := time.Date(2026, 3, 4, 10, 20, 30, 0, time.UTC)
+	entry := &logrus.Entry{
+		Logger:  logrus.New(),
+		Time:    when,
+		Level:   logrus.WarnLevel,
+		Message: "cache miss",
+		Data:    logrus.Fields{"component": "groved.cache", "attempt": 3, "key": "ws/api", "hit": false},
+	}
+	line, err := (&TextFormatter{Location: time.UTC}).Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := ParseTextEntry(string(line), "", time.UTC)
+	if !ok {
+		t.Fatalf("line not recognised: %q", line)
+	}
+	want := map[string]interface{}{
+		"time":      "2026-03-04T10:20:30Z",
+		"level":     "warning",
+		"component": "groved.cache",
+		"msg":       "cache miss",
+		"attempt":   int64(3),
+		"key":       "ws/api",
+		"hit":       false,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %#v, want %#v", k, got[k], v)
+		}
+	}
+}
+
+func TestParseTextEntryCallerAndCustomTime(t *testing.T) {
+	line := "2026-03-04T10:20:30+01:00 [ERROR] [flow] [run.go:42 flow.Run] job failed"
+	got, ok := ParseTextEntry(line, TimeFormatRFC3339, nil)
+	if !ok {
+		t.Fatal("line not recognised")
+	}
+	if got["file"] != "run.go:42" || got["func"] != "flow.Run" || got["msg"] != "job failed" {
+		t.Errorf("unexpected entry: %v", got)
+	}
+	if got["time"] != "2026-03-04T10:20:30+01:00" {
+		t.Errorf("time = %v", got["time"])
+	}
+	if _, ok := ParseTextEntry("    at main.go:12", "", nil); ok {
+		t.Error("continuation line parsed as an entry")
+	}
+}
+
+func TestConvertTextLogJoinsContinuationLines(t *testing.T) {
+	in := strings.Join([]string{
+		"stray preamble",
+		"2026-03-04 10:20:30 [INFO] [api] starting",
+		"2026-03-04 10:20:31 [ERROR] [api] panic: boom",
+		"goroutine 1 [running]: attempt=2",
+		`{"level":"info","msg":"already json"}`,
+	}, "\n")
+	var out bytes.Buffer
+	stats, err := ConvertTextLog(strings.NewReader(in), &out, "", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (TextConversion{Entries: 2, Continued: 1, Passthrough: 1, Skipped: 1}) {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out.String())
+	}
+	var panicEntry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &panicEntry); err != nil {
+		t.Fatal(err)
+	}
+	if panicEntry["msg"] != "panic: boom\ngoroutine 1 [running]:" || panicEntry["attempt"] != float64(2) {
+		t.Errorf("multi-line entry = %v", panicEntry)
+	}
+	if lines[2] != `{"level":"info","msg":"already json"}` {
+		t.Errorf("JSON line not passed through: %s", lines[2])
+	}
+}
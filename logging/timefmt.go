@@ -0,0 +1,206 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Named values for logging.time_format. Any other non-empty value is used as
+// a Go time layout (e.g. "2006-01-02 15:04:05.000 MST").
+const (
+	// TimeFormatRFC3339 is second precision with a zone offset, the JSON
+	// default.
+	TimeFormatRFC3339 = "rfc3339"
+	// TimeFormatRFC3339Nano is RFC3339 with fractional seconds.
+	TimeFormatRFC3339Nano = "rfc3339nano"
+	// TimeFormatUnixMs writes milliseconds since the Unix epoch; JSON entries
+	// carry it as a number.
+	TimeFormatUnixMs = "unix_ms"
+)
+
+// textTimeLayout is the text formatter's timestamp when time_format is unset.
+const textTimeLayout = "2006-01-02 15:04:05"
+
+// timeSettings is the resolved logging.time_format / logging.timezone pair
+// the formatters apply.
+type timeSettings struct {
+	// format is a TimeFormat* name, a custom layout, or "" for each
+	// formatter's default.
+	format string
+	// loc is the zone timestamps are written in; nil keeps the entry's own
+	// (local) zone.
+	loc *time.Location
+}
+
+// resolveTimeSettings reads the time settings from cfg. An unknown timezone
+// is reported on stderr and falls back to local time rather than failing
+// logger construction.
+func resolveTimeSettings(cfg *Config) timeSettings {
+	ts := timeSettings{format: strings.TrimSpace(cfg.TimeFormat)}
+	loc, err := LoadTimezone(cfg.Timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "grove-log: %v; using local time\n", err)
+		return ts
+	}
+	ts.loc = loc
+	return ts
+}
+
+// LoadTimezone resolves a logging.timezone value: "" or "local" is nil (the
+// process's local zone), "utc" is UTC, and anything else is an IANA zone name
+// such as "Europe/Berlin".
+func LoadTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "local":
+		return nil, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid logging timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// timeLayout returns the Go layout for format, or "" for unix_ms. Empty
+// format returns def.
+func timeLayout(format, def string) string {
+	switch strings.ToLower(format) {
+	case "":
+		return def
+	case TimeFormatRFC3339:
+		return time.RFC3339
+	case TimeFormatRFC3339Nano:
+		return time.RFC3339Nano
+	case TimeFormatUnixMs:
+		return ""
+	}
+	return format
+}
+
+// FormatTime renders t with format in loc (nil keeps t's zone). def is the
+// layout used when format is empty.
+func FormatTime(t time.Time, format string, loc *time.Location, def string) string {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	layout := timeLayout(format, def)
+	if layout == "" {
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return t.Format(layout)
+}
+
+// ParseTime reads an entry's "time" field as written under any
+// logging.time_format: RFC3339 strings with or without fractional seconds,
+// unix_ms numbers (or numeric strings), and, when format is a custom layout,
+// strings in that layout. The result keeps the zone it was written in;
+// viewers call Local() to display it.
+func ParseTime(v interface{}, format string) (time.Time, bool) {
+	switch val := v.(type) {
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, val); err == nil {
+			return t, true
+		}
+		if layout := timeLayout(format, ""); layout != "" {
+			if t, err := time.Parse(layout, val); err == nil {
+				return t, true
+			}
+		}
+		if ms, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return time.UnixMilli(ms), true
+		}
+	case float64:
+		if !math.IsNaN(val) && !math.IsInf(val, 0) {
+			return time.UnixMilli(int64(val)), true
+		}
+	case int64:
+		return time.UnixMilli(val), true
+	case int:
+		return time.UnixMilli(int64(val)), true
+	case json.Number:
+		if ms, err := val.Int64(); err == nil {
+			return time.UnixMilli(ms), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// resolvedTimeFormat caches logging.time_format from the most recent
+// NewLogger call so log readers in the same process (`core logs`) can parse
+// custom layouts without re-loading configuration.
+var (
+	resolvedTimeFormat   string
+	resolvedTimeFormatMu sync.RWMutex
+)
+
+func setResolvedTimeFormat(format string) {
+	resolvedTimeFormatMu.Lock()
+	resolvedTimeFormat = format
+	resolvedTimeFormatMu.Unlock()
+}
+
+// ConfiguredTimeFormat returns the logging.time_format resolved by the most
+// recent NewLogger call, for passing to ParseTime.
+func ConfiguredTimeFormat() string {
+	resolvedTimeFormatMu.RLock()
+	defer resolvedTimeFormatMu.RUnlock()
+	return resolvedTimeFormat
+}
+
+// unixTimeKey is where unix_ms JSON output points logrus's own timestamp
+// field. That timestamp is disabled, so the key is never written; moving it
+// only stops logrus from renaming the numeric "time" field this formatter
+// adds to "fields.time".
+const unixTimeKey = "_grove_time"
+
+// timeJSONFormatter is the JSON formatter used by the console "json" preset
+// and the JSON file sink. It applies the time settings logrus's
+// JSONFormatter cannot express on its own: a timezone, and unix_ms as a
+// JSON number.
+type timeJSONFormatter struct {
+	settings timeSettings
+	inner    logrus.JSONFormatter
+}
+
+func newJSONFormatter(ts timeSettings) logrus.Formatter {
+	f := &timeJSONFormatter{settings: ts}
+	layout := timeLayout(ts.format, time.RFC3339)
+	if layout == "" {
+		f.inner.DisableTimestamp = true
+		f.inner.FieldMap = logrus.FieldMap{logrus.FieldKeyTime: unixTimeKey}
+	} else {
+		f.inner.TimestampFormat = layout
+	}
+	return f
+}
+
+// Format implements logrus.Formatter.
+func (f *timeJSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	e := *entry
+	if f.settings.loc != nil {
+		e.Time = e.Time.In(f.settings.loc)
+	}
+	if f.inner.DisableTimestamp {
+		data := make(logrus.Fields, len(e.Data)+1)
+		for k, v := range e.Data {
+			data[k] = v
+		}
+		// Keep logrus's clash handling for a caller-supplied "time" field.
+		if t, ok := data[logrus.FieldKeyTime]; ok {
+			data["fields."+logrus.FieldKeyTime] = t
+		}
+		data[logrus.FieldKeyTime] = e.Time.UnixMilli()
+		e.Data = data
+	}
+	return f.inner.Format(&e)
+}
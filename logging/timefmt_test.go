@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	for _, name := range []string{"", "local", "Local"} {
+		if loc, err := LoadTimezone(name); err != nil || loc != nil {
+			t.Errorf("LoadTimezone(%q) = %v, %v; want nil, nil", name, loc, err)
+		}
+	}
+	if loc, err := LoadTimezone("UTC"); err != nil || loc != time.UTC {
+		t.Errorf("LoadTimezone(UTC) = %v, %v", loc, err)
+	}
+	if _, err := LoadTimezone("Not/AZone"); err == nil {
+		t.Error("LoadTimezone(Not/AZone) succeeded")
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	tokyo := time.FixedZone("JST", 9*3600)
+	tests := []struct {
+		format string
+		loc    *time.Location
+		want   string
+	}{
+		{"", nil, "2026-01-02 03:04:05"},
+		{"", tokyo, "2026-01-02 12:04:05"},
+		{TimeFormatRFC3339, nil, "2026-01-02T03:04:05Z"},
+		{TimeFormatRFC3339Nano, tokyo, "2026-01-02T12:04:05.006+09:00"},
+		{TimeFormatUnixMs, tokyo, "1767323045006"},
+		{"15:04 MST", tokyo, "12:04 JST"},
+	}
+	for _, tt := range tests {
+		if got := FormatTime(ts, tt.format, tt.loc, textTimeLayout); got != tt.want {
+			t.Errorf("FormatTime(%q, %v) = %q, want %q", tt.format, tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		v      interface{}
+		format string
+	}{
+		{"rfc3339", "2026-01-02T03:04:05Z", ""},
+		{"rfc3339 offset", "2026-01-02T12:04:05+09:00", ""},
+		{"rfc3339nano", "2026-01-02T03:04:05.000Z", ""},
+		{"unix_ms number", float64(want.UnixMilli()), TimeFormatUnixMs},
+		{"unix_ms string", "1767323045000", ""},
+		{"custom layout", "02/01/2026 03:04:05 +0000", "02/01/2006 15:04:05 -0700"},
+	}
+	for _, tt := range tests {
+		got, ok := ParseTime(tt.v, tt.format)
+		if !ok || !got.Equal(want) {
+			t.Errorf("%s: ParseTime(%v) = %v, %v; want %v", tt.name, tt.v, got, ok, want)
+		}
+	}
+	if _, ok := ParseTime("yesterday", ""); ok {
+		t.Error("ParseTime(yesterday) succeeded")
+	}
+	if _, ok := ParseTime(nil, ""); ok {
+		t.Error("ParseTime(nil) succeeded")
+	}
+}
+
+func TestJSONFormatterTimeSettings(t *testing.T) {
+	entry := &logrus.Entry{
+		Logger:  logrus.New(),
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   logrus.InfoLevel,
+		Message: "hi",
+		Data:    logrus.Fields{"component": "api"},
+	}
+
+	decode := func(f logrus.Formatter) map[string]interface{} {
+		t.Helper()
+		out, err := f.Format(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateEntry(out); err != nil {
+			t.Errorf("entry %s fails the schema: %v", out, err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(out, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	berlin, err := LoadTimezone("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no zoneinfo: %v", err)
+	}
+	m := decode(newJSONFormatter(timeSettings{loc: berlin}))
+	if m["time"] != "2026-01-02T04:04:05+01:00" {
+		t.Errorf("zoned time = %v", m["time"])
+	}
+
+	m = decode(newJSONFormatter(timeSettings{format: TimeFormatUnixMs}))
+	if m["time"] != float64(1767323045000) {
+		t.Errorf("unix_ms time = %v (%T)", m["time"], m["time"])
+	}
+	for k := range m {
+		if strings.Contains(k, unixTimeKey) {
+			t.Errorf("unexpected key %q in %v", k, m)
+		}
+	}
+}
+
+func TestTextFormatterTimeSettings(t *testing.T) {
+	f := &TextFormatter{TimeFormat: TimeFormatRFC3339, Location: time.UTC}
+	out, err := f.Format(&logrus.Entry{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("X", 3600)),
+		Level:   logrus.InfoLevel,
+		Message: "hi",
+		Data:    logrus.Fields{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(out), "2026-01-02T02:04:05Z [INFO] hi") {
+		t.Errorf("text output = %q", out)
+	}
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TimerMessage is the message of the entries Timer logs. Filter on it, or
+// on the op field, to profile an operation across runs.
+const TimerMessage = "Timed operation"
+
+// TimerOption configures a Timer.
+type TimerOption func(*timerOptions)
+
+type timerOptions struct {
+	recordMetrics bool
+}
+
+// RecordMetrics makes a Timer keep its timing in the in-process ring
+// buffer (see RecentEntries) even when trace logging is off, so long-running
+// processes such as the daemon can report how long their work takes without
+// tracing everything else.
+func RecordMetrics() TimerOption {
+	return func(o *timerOptions) { o.recordMetrics = true }
+}
+
+// Timer starts timing op for component and returns the function that stops
+// it. Stopping logs one trace entry with the standard timing fields: op,
+// duration_ms (at VerbosityMetrics), success and, on failure, error.
+//
+//	stop := logging.Timer("flow", "plan.load")
+//	plan, err := loadPlan(dir)
+//	stop(err)
+//
+// The stop function may be called once; pass nil when op succeeded.
+func Timer(component, op string, opts ...TimerOption) func(err error) {
+	return timeOp(NewLogger(component), op, opts...)
+}
+
+// timeOp is Timer for an existing logger entry. It is used by the timing
+// hooks installed in packages logging imports, which must not call
+// NewLogger (see registerTimingHooks).
+func timeOp(log *logrus.Entry, op string, opts ...TimerOption) func(err error) {
+	var o timerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	start := time.Now()
+	return func(err error) {
+		traced := log.Logger.IsLevelEnabled(logrus.TraceLevel)
+		if !traced && !o.recordMetrics {
+			return
+		}
+		fields := timerFields(op, time.Since(start), err)
+		if traced {
+			log.WithFields(fields).Trace(TimerMessage)
+			return
+		}
+		// Not admitted by the logger, so the ring buffer hook will not see
+		// it: record it directly.
+		component, _ := log.Data["component"].(string)
+		e := RecentEntry{
+			Time:      time.Now(),
+			Level:     logrus.TraceLevel.String(),
+			Component: component,
+			Msg:       TimerMessage,
+			Fields:    make(map[string]interface{}, len(fields)),
+		}
+		for k, v := range fields {
+			if err, ok := v.(error); ok {
+				v = err.Error()
+			}
+			e.Fields[k] = v
+		}
+		recentEntries.add(e)
+	}
+}
+
+// timerFields are the standard fields of a timing entry.
+func timerFields(op string, d time.Duration, err error) logrus.Fields {
+	fields := logrus.Fields{"op": op, "success": err == nil}
+	if err != nil {
+		fields[logrus.ErrorKey] = err
+	}
+	return MergeVerbosity(fields,
+		WithVerbosity(logrus.Fields{"duration_ms": d.Milliseconds()}, VerbosityMetrics))
+}
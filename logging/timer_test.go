@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestTimer(t *testing.T) {
+	defer recentEntries.clear()
+	recentEntries.clear()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	hook := &captureHook{}
+	logger.AddHook(hook)
+	log := logger.WithField("component", "config")
+
+	logger.SetLevel(logrus.DebugLevel)
+	timeOp(log, "load")(nil)
+	if len(hook.entries) != 0 || len(RecentEntries(0)) != 0 {
+		t.Fatal("an untraced timer without RecordMetrics should record nothing")
+	}
+
+	timeOp(log, "load", RecordMetrics())(errors.New("boom"))
+	if len(hook.entries) != 0 {
+		t.Fatal("the timing should not be logged with trace off")
+	}
+	recent := RecentEntries(0)
+	if len(recent) != 1 {
+		t.Fatalf("RecordMetrics kept %d entries, want 1", len(recent))
+	}
+	e := recent[0]
+	if e.Component != "config" || e.Msg != TimerMessage || e.Fields["op"] != "load" ||
+		e.Fields["success"] != false || e.Fields["error"] != "boom" {
+		t.Errorf("unexpected ring entry: %+v", e)
+	}
+	if _, ok := e.Fields["duration_ms"].(int64); !ok {
+		t.Errorf("duration_ms = %#v, want int64", e.Fields["duration_ms"])
+	}
+
+	logger.SetLevel(logrus.TraceLevel)
+	timeOp(log, "discover")(nil)
+	if len(hook.entries) != 1 {
+		t.Fatalf("traced timer logged %d entries, want 1", len(hook.entries))
+	}
+	logged := hook.entries[0]
+	if logged.Level != logrus.TraceLevel || logged.Data["op"] != "discover" || logged.Data["success"] != true {
+		t.Errorf("unexpected timing entry: level=%v data=%v", logged.Level, logged.Data)
+	}
+	if FieldVerbosity(logged.Data)["duration_ms"] != VerbosityMetrics {
+		t.Error("duration_ms should be a metrics field")
+	}
+}
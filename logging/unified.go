@@ -49,6 +49,19 @@ func NewUnifiedLogger(component string) *UnifiedLogger {
 	}
 }
 
+// Trace returns a LogEntry at TRACE level, for detail beyond debug (payload
+// dumps, per-item loop output). Like Debug, it only reaches the pretty
+// output when the console level admits it; pair it with FieldsFn so the
+// payload is not built when tracing is off.
+func (u *UnifiedLogger) Trace(msg string) *LogEntry {
+	return &LogEntry{
+		logger: u,
+		msg:    msg,
+		level:  logrus.TraceLevel,
+		fields: logrus.Fields{},
+	}
+}
+
 // Debug returns a LogEntry at DEBUG level.
 // Debug messages are skipped by the pretty output path unless the resolved
 // console level is debug (e.g. via GROVE_LOG_LEVEL=debug); they still reach
@@ -143,6 +156,7 @@ type LogEntry struct {
 	structOnly bool
 	noIcon     bool
 	err        error
+	lazyFields []func() map[string]interface{}
 }
 
 // Field adds a structured field (chainable).
@@ -160,6 +174,24 @@ func (e *LogEntry) Fields(fields map[string]interface{}) *LogEntry {
 	return e
 }
 
+// FieldsFn adds structured fields built by fn (chainable). fn runs only
+// when the entry is written to a structured sink, so an entry below every
+// sink level never builds its payload:
+//
+//	ulog.Trace("Request payload").
+//	    FieldsFn(func() map[string]interface{} {
+//	        return map[string]interface{}{"body": string(dump)}
+//	    }).
+//	    Emit()
+//
+// The built fields are applied over those set with Field and Fields.
+func (e *LogEntry) FieldsFn(fn func() map[string]interface{}) *LogEntry {
+	if fn != nil {
+		e.lazyFields = append(e.lazyFields, fn)
+	}
+	return e
+}
+
 // Err attaches an error (chainable).
 // The error message is added to structured output as the "error" field.
 func (e *LogEntry) Err(err error) *LogEntry {
@@ -248,14 +280,23 @@ func (e *LogEntry) Log(ctx context.Context) {
 	// Gate on the sink levels before doing ANY formatting work.
 	//
 	// emitPretty: the console/pretty path, gated at the resolved console
-	// level (logrus levels are numerically inverted: Debug=5 > Info=4, so
+	// level or its runtime override (logrus levels are numerically inverted: Debug=5 > Info=4, so
 	// "more verbose" means a larger value).
 	//
 	// emitStructured: the logrus pipeline (console echo + file sinks). The
 	// logrus logger level is the most verbose of all its sinks (see
 	// NewLogger), so IsLevelEnabled is exactly "some structured sink would
 	// accept this entry".
-	emitPretty := !e.structOnly && e.level <= e.logger.prettyLevel
+	if level, ok := currentEscalator().Escalate(e.logger.component, e.level, e.msg); ok {
+		e.fields[EscalatedFromField] = e.level.String()
+		e.level = level
+	}
+
+	prettyLevel := e.logger.prettyLevel
+	if l, ok := runtimeLevelFor(e.logger.component); ok {
+		prettyLevel = l
+	}
+	emitPretty := !e.structOnly && e.level <= prettyLevel
 	emitStructured := !e.prettyOnly && e.logger.structured.Logger.IsLevelEnabled(e.level)
 	if !emitPretty && !emitStructured {
 		return
@@ -279,6 +320,11 @@ func (e *LogEntry) Log(ctx context.Context) {
 
 	// Structured output (to workspace log + core logs)
 	if emitStructured {
+		for _, fn := range e.lazyFields {
+			for k, v := range fn() {
+				e.fields[k] = v
+			}
+		}
 		e.logStructured(prettyOutput, emitPretty, includePrettyFields)
 	}
 }
@@ -320,7 +366,7 @@ func (e *LogEntry) computePrettyOutput() string {
 			output = styles.Warning.Render(output)
 		case logrus.ErrorLevel:
 			output = styles.Error.Render(output)
-		case logrus.DebugLevel:
+		case logrus.DebugLevel, logrus.TraceLevel:
 			// Debug messages may be filtered at a higher level
 			// but we still style them if they make it through
 			output = styles.Key.Render(output) // muted style for debug
@@ -3,6 +3,7 @@ package logging
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -142,6 +143,12 @@ func TestLevelMethods(t *testing.T) {
 		expectedLevel logrus.Level
 		expectedIcon  string
 	}{
+		{
+			name:          "Trace",
+			entry:         ulog.Trace("trace message"),
+			expectedLevel: logrus.TraceLevel,
+			expectedIcon:  "",
+		},
 		{
 			name:          "Debug",
 			entry:         ulog.Debug("debug message"),
@@ -575,3 +582,45 @@ func TestStructuredOnlyBelowConsoleLevelStillReachesSinks(t *testing.T) {
 		t.Errorf("expected 0 pretty renders for StructuredOnly with pretty fields off, got %d", *renders)
 	}
 }
+
+func TestFieldsFnSkippedBelowAllSinks(t *testing.T) {
+	ulog := newIsolatedUnifiedLogger(t, "test-lazy-skipped", "")
+	hook := &captureHook{}
+	ulog.structured.Logger.AddHook(hook)
+
+	built := 0
+	ulog.Trace("payload").FieldsFn(func() map[string]interface{} {
+		built++
+		return map[string]interface{}{"body": "large"}
+	}).Log(WithWriter(context.Background(), io.Discard))
+
+	if built != 0 {
+		t.Errorf("expected lazy fields not to be built below every sink level, built %d times", built)
+	}
+	if len(hook.entries) != 0 {
+		t.Errorf("expected no structured entries, got %d", len(hook.entries))
+	}
+}
+
+func TestFieldsFnAppliedWhenEmitted(t *testing.T) {
+	ulog := newIsolatedUnifiedLogger(t, "test-lazy-applied", "")
+	hook := &captureHook{}
+	ulog.structured.Logger.AddHook(hook)
+
+	ulog.Info("payload").
+		Field("body", "eager").
+		Field("kept", true).
+		FieldsFn(func() map[string]interface{} {
+			return map[string]interface{}{"body": "lazy"}
+		}).
+		StructuredOnly().
+		Emit()
+
+	if len(hook.entries) != 1 {
+		t.Fatalf("expected 1 structured entry, got %d", len(hook.entries))
+	}
+	data := hook.entries[0].Data
+	if data["body"] != "lazy" || data["kept"] != true {
+		t.Errorf("expected lazy fields applied over eager ones, got %v", data)
+	}
+}
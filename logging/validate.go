@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"cmp"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// entrySchemaData is the JSON Schema every structured log entry is expected
+// to satisfy. The CLI `core logs` formatters and the logs TUI parse these
+// fields by name and type, so entries violating it render incorrectly.
+//
+//go:embed entry.schema.json
+var entrySchemaData []byte
+
+var (
+	entrySchemaOnce sync.Once
+	entrySchema     *jsonschema.Schema
+	entrySchemaErr  error
+)
+
+// EntrySchema returns the compiled log-entry schema.
+func EntrySchema() (*jsonschema.Schema, error) {
+	entrySchemaOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		compiler.AssertFormat = true
+		if err := compiler.AddResource("log-entry.json", strings.NewReader(string(entrySchemaData))); err != nil {
+			entrySchemaErr = fmt.Errorf("failed to add log entry schema resource: %w", err)
+			return
+		}
+		entrySchema, entrySchemaErr = compiler.Compile("log-entry.json")
+	})
+	return entrySchema, entrySchemaErr
+}
+
+// ValidateEntry validates one JSON-encoded log line against the log-entry
+// schema. A string "time" must parse under the logging.time_format resolved
+// by the most recent NewLogger call (see ParseTime); the schema itself only
+// requires a non-empty string, since custom layouts have no JSON Schema
+// format.
+func ValidateEntry(line []byte) error {
+	return validateEntry(line, ConfiguredTimeFormat())
+}
+
+func validateEntry(line []byte, timeFormat string) error {
+	schema, err := EntrySchema()
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(line, &doc); err != nil {
+		return fmt.Errorf("log entry is not valid JSON: %w", err)
+	}
+	if err := schema.Validate(doc); err != nil {
+		return err
+	}
+	if fields, ok := doc.(map[string]interface{}); ok {
+		if ts, ok := fields["time"].(string); ok {
+			if _, ok := ParseTime(ts, timeFormat); !ok {
+				return fmt.Errorf("log entry time %q does not match time format %q", ts, cmp.Or(timeFormat, TimeFormatRFC3339))
+			}
+		}
+	}
+	return nil
+}
+
+// entryValidationHook is a debug hook (logging.validate_entries) that renders
+// every entry with the file sink's formatter, so with the configured time
+// format, zone and redaction, and validates the line against the log-entry
+// schema. NewLogger installs it only when file.format is json. Violations
+// are reported to out once per distinct problem rather than through the
+// logger itself, which would recurse. At most maxReportedProblems are
+// reported, followed by one notice that further problems are suppressed,
+// so a long-running process does not remember problems without bound.
+type entryValidationHook struct {
+	formatter  logrus.Formatter
+	timeFormat string
+	out        io.Writer
+
+	mu         sync.Mutex
+	reported   map[string]bool
+	suppressed bool
+}
+
+// maxReportedProblems caps the distinct problems an entryValidationHook
+// reports and remembers.
+const maxReportedProblems = 100
+
+func newEntryValidationHook(formatter logrus.Formatter, timeFormat string) *entryValidationHook {
+	return &entryValidationHook{
+		formatter:  formatter,
+		timeFormat: timeFormat,
+		out:        os.Stderr,
+		reported:   make(map[string]bool),
+	}
+}
+
+// Levels implements logrus.Hook.
+func (h *entryValidationHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook. It never returns an error: validation is
+// advisory and must not interfere with the sinks.
+func (h *entryValidationHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return nil
+	}
+	verr := validateEntry(line, h.timeFormat)
+	if verr == nil {
+		return nil
+	}
+
+	component, _ := entry.Data["component"].(string)
+	problem := strings.ReplaceAll(verr.Error(), "\n", "; ")
+	key := component + "|" + problem
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.reported[key] || h.suppressed {
+		return nil
+	}
+	if len(h.reported) >= maxReportedProblems {
+		h.suppressed = true
+		fmt.Fprintf(h.out, "grove-log: %d distinct invalid log entries reported; further problems are suppressed\n", maxReportedProblems)
+		return nil
+	}
+	h.reported[key] = true
+	fmt.Fprintf(h.out, "grove-log: invalid log entry from component %q (msg %q): %s\n", component, entry.Message, problem)
+	return nil
+}
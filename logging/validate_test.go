@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestValidateEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantErr bool
+	}{
+		{"valid", `{"time":"2026-01-01T12:00:00Z","level":"info","msg":"hi","component":"api"}`, false},
+		{"missing component", `{"time":"2026-01-01T12:00:00Z","level":"info","msg":"hi"}`, true},
+		{"numeric component", `{"time":"2026-01-01T12:00:00Z","level":"info","msg":"hi","component":7}`, true},
+		{"bad time", `{"time":"yesterday","level":"info","msg":"hi","component":"api"}`, true},
+		{"unix_ms time", `{"time":1767268800000,"level":"info","msg":"hi","component":"api"}`, false},
+		{"negative time", `{"time":-1,"level":"info","msg":"hi","component":"api"}`, true},
+		{"unknown level", `{"time":"2026-01-01T12:00:00Z","level":"loud","msg":"hi","component":"api"}`, true},
+		{"bad verbosity", `{"time":"2026-01-01T12:00:00Z","level":"info","msg":"hi","component":"api","_verbosity":{"x":"high"}}`, true},
+		{"not json", `not json`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEntry([]byte(tt.line))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEntryValidationHookReportsOnce(t *testing.T) {
+	var out bytes.Buffer
+	hook := newEntryValidationHook(FileFormatter(&Config{TimeFormat: TimeFormatUnixMs, File: FileSinkConfig{Format: "json"}}), TimeFormatUnixMs)
+	hook.out = &out
+
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+	logger.AddHook(hook)
+
+	logger.WithField("component", "api").Info("fine")
+	if out.Len() != 0 {
+		t.Fatalf("valid entry reported a violation: %s", out.String())
+	}
+
+	logger.Info("no component")
+	logger.Info("no component")
+	if got := strings.Count(out.String(), "grove-log: invalid log entry"); got != 1 {
+		t.Errorf("expected one report for a repeated violation, got %d:\n%s", got, out.String())
+	}
+}
+
+func TestEntryValidationHookCapsReports(t *testing.T) {
+	var out bytes.Buffer
+	hook := newEntryValidationHook(FileFormatter(&Config{File: FileSinkConfig{Format: "json"}}), "")
+	hook.out = &out
+
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+	logger.AddHook(hook)
+
+	// The same violation from each component is a distinct problem.
+	for i := 0; i < maxReportedProblems+20; i++ {
+		logger.WithFields(logrus.Fields{
+			"component":  fmt.Sprintf("c%d", i),
+			"_verbosity": map[string]string{"x": "high"},
+		}).Info("bad verbosity")
+	}
+	if got := strings.Count(out.String(), "grove-log: invalid log entry"); got != maxReportedProblems {
+		t.Errorf("expected %d reports, got %d", maxReportedProblems, got)
+	}
+	if got := strings.Count(out.String(), "further problems are suppressed"); got != 1 {
+		t.Errorf("expected one suppression notice, got %d", got)
+	}
+	if len(hook.reported) != maxReportedProblems {
+		t.Errorf("expected the hook to remember %d problems, got %d", maxReportedProblems, len(hook.reported))
+	}
+}
+
+func TestEntryValidationCustomTimeFormat(t *testing.T) {
+	const layout = "2006-01-02 15:04:05.000 MST"
+	cfg := &Config{TimeFormat: layout, Timezone: "utc", File: FileSinkConfig{Format: "json"}}
+
+	var out bytes.Buffer
+	hook := newEntryValidationHook(FileFormatter(cfg), layout)
+	hook.out = &out
+
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+	logger.AddHook(hook)
+
+	logger.WithField("component", "api").Info("custom layout")
+	if out.Len() != 0 {
+		t.Fatalf("entry written with a custom layout reported a violation: %s", out.String())
+	}
+
+	if err := validateEntry([]byte(`{"time":"2026-10-17 10:00:00.000 UTC","level":"info","msg":"hi","component":"api"}`), layout); err != nil {
+		t.Errorf("custom layout time rejected: %v", err)
+	}
+	if err := validateEntry([]byte(`{"time":"yesterday","level":"info","msg":"hi","component":"api"}`), layout); err == nil {
+		t.Error("time outside the custom layout was accepted")
+	}
+}
@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// VerbosityField is the metadata field mapping each field name of an entry
+// to its Verbosity. Viewers group fields by it and hide fields above their
+// display ceiling; fields it does not mention are Basic.
+const VerbosityField = "_verbosity"
+
+// Verbosity ranks how much detail a log field carries, independent of the
+// entry's level: an info entry can still carry debug-only fields.
+type Verbosity int
+
+const (
+	// VerbosityBasic fields are always shown.
+	VerbosityBasic Verbosity = iota
+	// VerbosityVerbose fields add useful context.
+	VerbosityVerbose
+	// VerbosityDebug fields matter only when debugging.
+	VerbosityDebug
+	// VerbosityMetrics fields are timings, counters and build details.
+	VerbosityMetrics
+)
+
+var verbosityNames = []string{"basic", "verbose", "debug", "metrics"}
+
+// String returns the verbosity's flag name.
+func (v Verbosity) String() string {
+	if v >= 0 && int(v) < len(verbosityNames) {
+		return verbosityNames[v]
+	}
+	return strconv.Itoa(int(v))
+}
+
+// ParseVerbosity parses a verbosity name (basic, verbose, debug, metrics)
+// or its number (0-3).
+func ParseVerbosity(s string) (Verbosity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range verbosityNames {
+		if s == name || s == strconv.Itoa(i) {
+			return Verbosity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid verbosity %q: must be basic, verbose, debug or metrics", s)
+}
+
+// WithVerbosity returns a copy of fields with every field recorded at level
+// in the _verbosity metadata. Levels already recorded in fields for other
+// keys are kept.
+//
+//	log.WithFields(logging.WithVerbosity(logrus.Fields{"elapsed_ms": ms}, logging.VerbosityMetrics)).
+//		Info("Plan loaded")
+//
+// logrus replaces the _verbosity field on each WithFields call, so combine
+// sets at different levels with MergeVerbosity before logging them.
+func WithVerbosity(fields logrus.Fields, level Verbosity) logrus.Fields {
+	out := make(logrus.Fields, len(fields)+1)
+	levels := make(map[string]int, len(fields))
+	for k, v := range fields {
+		if k == VerbosityField {
+			for name, l := range FieldVerbosity(fields) {
+				levels[name] = int(l)
+			}
+			continue
+		}
+		out[k] = v
+		levels[k] = int(level)
+	}
+	out[VerbosityField] = levels
+	return out
+}
+
+// MergeVerbosity combines field sets built with WithVerbosity, merging
+// their _verbosity metadata. Later sets win on conflicting keys.
+func MergeVerbosity(sets ...logrus.Fields) logrus.Fields {
+	out := logrus.Fields{}
+	levels := map[string]int{}
+	for _, fields := range sets {
+		for k, v := range fields {
+			if k != VerbosityField {
+				out[k] = v
+			}
+		}
+		for name, l := range FieldVerbosity(fields) {
+			levels[name] = int(l)
+		}
+	}
+	out[VerbosityField] = levels
+	return out
+}
+
+// FieldVerbosity reads the _verbosity metadata of an entry, whether built
+// in process (map[string]int) or decoded from JSON (numbers as float64).
+func FieldVerbosity(data map[string]interface{}) map[string]Verbosity {
+	out := map[string]Verbosity{}
+	switch m := data[VerbosityField].(type) {
+	case map[string]int:
+		for k, v := range m {
+			out[k] = Verbosity(v)
+		}
+	case map[string]Verbosity:
+		for k, v := range m {
+			out[k] = v
+		}
+	case map[string]interface{}:
+		for k, v := range m {
+			switch n := v.(type) {
+			case float64:
+				out[k] = Verbosity(n)
+			case int:
+				out[k] = Verbosity(n)
+			}
+		}
+	}
+	return out
+}
+
+// FilterVerbosity returns a copy of a decoded entry without the fields
+// above ceiling. The _verbosity metadata is kept, trimmed to the remaining
+// fields.
+func FilterVerbosity(data map[string]interface{}, ceiling Verbosity) map[string]interface{} {
+	levels := FieldVerbosity(data)
+	out := make(map[string]interface{}, len(data))
+	kept := make(map[string]interface{}, len(levels))
+	for k, v := range data {
+		if k == VerbosityField {
+			continue
+		}
+		if l, ok := levels[k]; ok {
+			if l > ceiling {
+				continue
+			}
+			kept[k] = float64(l)
+		}
+		out[k] = v
+	}
+	if _, ok := data[VerbosityField]; ok {
+		out[VerbosityField] = kept
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	tests := map[string]Verbosity{
+		"basic": VerbosityBasic, "Verbose": VerbosityVerbose, "debug": VerbosityDebug,
+		"metrics": VerbosityMetrics, "0": VerbosityBasic, "3": VerbosityMetrics,
+	}
+	for in, want := range tests {
+		if got, err := ParseVerbosity(in); err != nil || got != want {
+			t.Errorf("ParseVerbosity(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "4", "loud"} {
+		if _, err := ParseVerbosity(in); err == nil {
+			t.Errorf("ParseVerbosity(%q) succeeded", in)
+		}
+	}
+	if VerbosityDebug.String() != "debug" {
+		t.Errorf("String() = %q", VerbosityDebug.String())
+	}
+}
+
+func TestWithVerbosityRoundTrip(t *testing.T) {
+	fields := MergeVerbosity(
+		WithVerbosity(logrus.Fields{"plan": "p1"}, VerbosityBasic),
+		WithVerbosity(logrus.Fields{"elapsed_ms": 12}, VerbosityMetrics),
+		WithVerbosity(logrus.Fields{"cache_key": "k"}, VerbosityDebug),
+	)
+
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetOutput(&buf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.WithFields(fields).WithField("component", "plans").Info("Plan loaded")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	levels := FieldVerbosity(entry)
+	want := map[string]Verbosity{"plan": VerbosityBasic, "elapsed_ms": VerbosityMetrics, "cache_key": VerbosityDebug}
+	if len(levels) != len(want) {
+		t.Fatalf("levels = %v, want %v", levels, want)
+	}
+	for k, v := range want {
+		if levels[k] != v {
+			t.Errorf("levels[%s] = %v, want %v", k, levels[k], v)
+		}
+	}
+	if err := ValidateEntry(buf.Bytes()); err != nil {
+		t.Errorf("entry does not match the schema: %v", err)
+	}
+
+	filtered := FilterVerbosity(entry, VerbosityVerbose)
+	if _, ok := filtered["elapsed_ms"]; ok {
+		t.Error("metrics field should be hidden at verbose")
+	}
+	if _, ok := filtered["cache_key"]; ok {
+		t.Error("debug field should be hidden at verbose")
+	}
+	if filtered["plan"] != "p1" || filtered["msg"] != "Plan loaded" {
+		t.Errorf("basic and untagged fields should be kept: %v", filtered)
+	}
+	if got := FieldVerbosity(filtered); len(got) != 1 || got["plan"] != VerbosityBasic {
+		t.Errorf("_verbosity should be trimmed to kept fields, got %v", got)
+	}
+}
+
+func TestTextFormatterOmitsVerbosityMetadata(t *testing.T) {
+	entry := logrus.NewEntry(logrus.New()).WithFields(WithVerbosity(logrus.Fields{"n": 1}, VerbosityVerbose))
+	entry.Message = "hi"
+	out, err := (&TextFormatter{}).Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), VerbosityField) || !strings.Contains(string(out), "n=1") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
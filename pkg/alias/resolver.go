@@ -14,6 +14,9 @@ import (
 	"github.com/grovetools/core/pkg/workspace"
 )
 
+// maxNameSuggestions caps the "Did you mean" list for an unknown workspace.
+const maxNameSuggestions = 5
+
 // aliasLineParts holds the parsed components of a rule line containing an alias.
 type aliasLineParts struct {
 	// The full original line.
@@ -228,11 +231,17 @@ func (r *AliasResolver) ResolveResourceAlias(alias string) (string, error) {
 	// 2. Resolve the workspace name to a WorkspaceNode.
 	wsNode := r.Provider.FindByName(workspaceName)
 	if wsNode == nil {
-		// Attempt fuzzy matching for better error message.
+		// Suggest the closest names for a better error message.
 		var suggestions []string
-		for _, node := range r.Provider.All() {
-			if strings.Contains(node.Name, workspaceName) {
-				suggestions = append(suggestions, node.Name)
+		seen := make(map[string]bool)
+		for _, node := range r.Provider.Fuzzy(workspaceName) {
+			if seen[node.Name] {
+				continue
+			}
+			seen[node.Name] = true
+			suggestions = append(suggestions, node.Name)
+			if len(suggestions) == maxNameSuggestions {
+				break
 			}
 		}
 		if len(suggestions) > 0 {
@@ -478,10 +478,10 @@ type protectedPath struct {
 }
 
 // configFileNames are the grove config filenames protected at each repo/worktree
-// root. grove.toml is the canonical one; the .yml/.yaml variants are included so
+// root. grove.toml is the canonical one; the .yml/.yaml/.json5 variants are included so
 // switching format can't sidestep the lock. Non-existent variants are harmless:
 // a deny rule for a path that doesn't exist yet simply blocks creating it there.
-var configFileNames = []string{"grove.toml", "grove.yml", "grove.yaml"}
+var configFileNames = []string{"grove.toml", "grove.yml", "grove.yaml", "grove.json5"}
 
 // protectedConfigPaths returns the canonicalized set of config paths the
 // self-protection toggle locks: the global grove config dir, the worktree-root
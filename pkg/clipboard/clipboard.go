@@ -0,0 +1,70 @@
+// Package clipboard writes text to the system clipboard using the native
+// utility for the current platform.
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// candidate is one clipboard utility invocation.
+type candidate struct {
+	name string
+	args []string
+}
+
+// candidates returns the clipboard utilities to try for goos, in order of
+// preference. getenv is injected so selection can be tested without the
+// environment of the running session.
+func candidates(goos string, getenv func(string) string) []candidate {
+	switch goos {
+	case "darwin":
+		return []candidate{{name: "pbcopy"}}
+	case "windows":
+		// clip.exe interprets stdin in the console code page and mangles
+		// non-ASCII text; Set-Clipboard reads it as UTF-8.
+		return []candidate{
+			{name: "powershell.exe", args: []string{"-NoProfile", "-NonInteractive", "-Command",
+				"[Console]::InputEncoding=[Text.Encoding]::UTF8; Set-Clipboard -Value ([Console]::In.ReadToEnd())"}},
+			{name: "clip.exe"},
+		}
+	default:
+		var list []candidate
+		if getenv("WAYLAND_DISPLAY") != "" {
+			list = append(list, candidate{name: "wl-copy"})
+		}
+		list = append(list,
+			candidate{name: "xclip", args: []string{"-selection", "clipboard"}},
+			candidate{name: "xsel", args: []string{"--clipboard", "--input"}},
+		)
+		if getenv("WSL_DISTRO_NAME") != "" {
+			list = append(list, candidate{name: "clip.exe"})
+		}
+		return list
+	}
+}
+
+// Write copies content to the system clipboard. It uses pbcopy on macOS,
+// PowerShell (falling back to clip.exe) on Windows, and wl-copy, xclip or
+// xsel on Linux, with clip.exe as a last resort under WSL.
+func Write(content string) error {
+	list := candidates(runtime.GOOS, os.Getenv)
+	names := make([]string, 0, len(list))
+	for _, c := range list {
+		names = append(names, c.name)
+		path, err := exec.LookPath(c.name)
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, c.args...)
+		cmd.Stdin = strings.NewReader(content)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy to clipboard with %s: %w", c.name, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no clipboard utility found (install one of: %s)", strings.Join(names, ", "))
+}
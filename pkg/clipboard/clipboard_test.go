@@ -0,0 +1,29 @@
+package clipboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func names(list []candidate) []string {
+	var out []string
+	for _, c := range list {
+		out = append(out, c.name)
+	}
+	return out
+}
+
+func TestCandidates(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	assert.Equal(t, []string{"pbcopy"}, names(candidates("darwin", env(nil))))
+	assert.Equal(t, []string{"powershell.exe", "clip.exe"}, names(candidates("windows", env(nil))))
+	assert.Equal(t, []string{"xclip", "xsel"}, names(candidates("linux", env(nil))))
+	assert.Equal(t, []string{"wl-copy", "xclip", "xsel"},
+		names(candidates("linux", env(map[string]string{"WAYLAND_DISPLAY": "wayland-0"}))))
+	assert.Equal(t, []string{"xclip", "xsel", "clip.exe"},
+		names(candidates("linux", env(map[string]string{"WSL_DISTRO_NAME": "Ubuntu"}))))
+}
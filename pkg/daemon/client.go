@@ -58,6 +58,10 @@ type RunningConfig struct {
 	PlanInterval      time.Duration `json:"plan_interval"`
 	NoteInterval      time.Duration `json:"note_interval"`
 	StartedAt         time.Time     `json:"started_at"`
+
+	// SessionProviders holds the enable flag and scan interval of each
+	// session collector source, keyed by provider name.
+	SessionProviders map[string]SessionProviderSettings `json:"session_providers,omitempty"`
 }
 
 // Client defines the interface for interacting with the Grove Daemon.
@@ -102,6 +106,12 @@ type Client interface {
 	// For LocalClient, this returns a friendly error (requires daemon).
 	StreamWorkspaceHUD(ctx context.Context, path string) (<-chan models.WorkspaceHUD, error)
 
+	// StreamWorkspaceChanges subscribes to workspace discovery changes:
+	// one event per workspace added, removed or modified, carrying the
+	// node, so editor pickers can update without re-running discovery.
+	// For LocalClient, this returns an error (requires daemon).
+	StreamWorkspaceChanges(ctx context.Context) (<-chan models.WorkspaceChange, error)
+
 	// GetConfig returns the running configuration of the daemon.
 	// For LocalClient, this returns an error since config is only available via daemon.
 	GetConfig(ctx context.Context) (*RunningConfig, error)
@@ -131,6 +141,11 @@ type Client interface {
 	// This is fire-and-forget from the caller's perspective.
 	NotifyNoteEvent(ctx context.Context, event models.NoteEvent) error
 
+	// IngestLogs sends a batch of structured log entries for a workspace to
+	// the daemon's ingestion endpoint (POST /v1/logs), which writes them
+	// into the workspace's log files. LocalClient writes them directly.
+	IngestLogs(ctx context.Context, req models.LogIngestRequest) (*models.LogIngestResponse, error)
+
 	// PublishWorkflowEvent sends a subagent/workflow lifecycle event to the
 	// daemon for aggregation into workflow run state. Fire-and-forget from
 	// the caller's perspective: a 404 from an older daemon that lacks the
@@ -288,6 +303,11 @@ type Client interface {
 	// Returns a channel that receives tailed log lines. Closed when context is cancelled.
 	StreamLogs(ctx context.Context, opts models.LogStreamOptions) (<-chan models.LogStreamLine, error)
 
+	// GetLogHistory fetches a page of the aggregated workspace log history
+	// older than opts.Before, read backwards from the end of each log so
+	// huge histories are never loaded whole.
+	GetLogHistory(ctx context.Context, opts models.LogHistoryOptions) (*models.LogHistoryPage, error)
+
 	// StreamJobLogs subscribes to real-time log output for a specific job.
 	// Returns a channel that receives log and status events. Closed when the job completes or context is cancelled.
 	StreamJobLogs(ctx context.Context, jobID string) (<-chan models.JobStreamEvent, error)
@@ -0,0 +1,227 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/models"
+)
+
+// DefaultUpdateCoalesceInterval is the coalescing window used when
+// daemon.update_coalesce_interval is unset.
+const DefaultUpdateCoalesceInterval = 250 * time.Millisecond
+
+// UpdateCoalesceInterval returns the window in which the daemon merges
+// bursts of state updates before broadcasting them, or 0 when coalescing is
+// disabled ("0").
+func UpdateCoalesceInterval(cfg *config.Config) time.Duration {
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.UpdateCoalesceInterval != "" {
+		if d, err := time.ParseDuration(cfg.Daemon.UpdateCoalesceInterval); err == nil {
+			return max(d, 0)
+		}
+	}
+	return DefaultUpdateCoalesceInterval
+}
+
+// coalescedUpdateTypes are the update types a Coalescer may merge. Each
+// carries the current state of what it describes, so a newer update of the
+// same type and source supersedes an older one; workspaces_delta updates
+// are merged per workspace instead. Everything else — events, boot phases,
+// config reloads — passes through untouched.
+var coalescedUpdateTypes = map[string]bool{
+	"workspaces":       true,
+	"workspaces_delta": true,
+	"sessions":         true,
+	"focus":            true,
+	"plans":            true,
+	"watcher_status":   true,
+	"memory_index":     true,
+}
+
+// CoalesceStats counts what a Coalescer did with the updates it received.
+type CoalesceStats struct {
+	Received uint64 `json:"received"`
+	Emitted  uint64 `json:"emitted"`
+	// Coalesced counts workspaces_delta updates merged into a pending one.
+	Coalesced uint64 `json:"coalesced"`
+	// Dropped counts updates discarded because a newer update of the same
+	// type and source replaced them before they were sent.
+	Dropped uint64 `json:"dropped"`
+	// Flushes counts the windows that ended with held updates to send.
+	Flushes uint64 `json:"flushes"`
+}
+
+// Coalescer rate-limits a stream of StateUpdates so a burst of changes
+// (fsnotify events from mass worktree creation, say) reaches clients as
+// one update per interval rather than one per event. The first update
+// after a quiet interval is sent at once and opens a window; updates of a
+// coalesced type arriving inside the window are held, merged with held
+// updates of the same type and source, and sent when it closes. Held
+// updates are sent in the order they last changed. Any other update flushes
+// what is held first, so ordering relative to it is kept.
+type Coalescer struct {
+	interval time.Duration
+
+	mu    sync.Mutex
+	stats CoalesceStats
+}
+
+// NewCoalescer returns a Coalescer with the given window. An interval <= 0
+// disables coalescing: Run forwards every update as it arrives.
+func NewCoalescer(interval time.Duration) *Coalescer {
+	return &Coalescer{interval: interval}
+}
+
+// Stats returns a snapshot of the counters.
+func (c *Coalescer) Stats() CoalesceStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Coalescer) count(fn func(*CoalesceStats)) {
+	c.mu.Lock()
+	fn(&c.stats)
+	c.mu.Unlock()
+}
+
+// Run coalesces in until it is closed or ctx is cancelled, and returns the
+// rate-limited stream. Updates still held when in closes are sent before
+// the returned channel is closed.
+func (c *Coalescer) Run(ctx context.Context, in <-chan StateUpdate) <-chan StateUpdate {
+	out := make(chan StateUpdate)
+	go c.run(ctx, in, out)
+	return out
+}
+
+func (c *Coalescer) run(ctx context.Context, in <-chan StateUpdate, out chan<- StateUpdate) {
+	defer close(out)
+
+	var (
+		pending []*StateUpdate
+		window  *time.Timer
+		windowC <-chan time.Time
+	)
+	send := func(u StateUpdate) bool {
+		select {
+		case out <- u:
+			c.count(func(s *CoalesceStats) { s.Emitted++ })
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	flush := func() bool {
+		for _, u := range pending {
+			if !send(*u) {
+				return false
+			}
+		}
+		pending = nil
+		return true
+	}
+	openWindow := func() {
+		if window == nil {
+			window = time.NewTimer(c.interval)
+		} else {
+			window.Reset(c.interval)
+		}
+		windowC = window.C
+	}
+	defer func() {
+		if window != nil {
+			window.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case u, ok := <-in:
+			if !ok {
+				flush()
+				return
+			}
+			c.count(func(s *CoalesceStats) { s.Received++ })
+			switch {
+			case c.interval <= 0 || !coalescedUpdateTypes[u.UpdateType]:
+				if !flush() || !send(u) {
+					return
+				}
+			case windowC == nil:
+				if !send(u) {
+					return
+				}
+				openWindow()
+			default:
+				pending = c.hold(pending, u)
+			}
+
+		case <-windowC:
+			if len(pending) == 0 {
+				windowC = nil
+				continue
+			}
+			c.count(func(s *CoalesceStats) { s.Flushes++ })
+			if !flush() {
+				return
+			}
+			openWindow()
+		}
+	}
+}
+
+// hold adds u to the held updates, merging it into or replacing a held
+// update of the same type and source, and moves that entry last.
+func (c *Coalescer) hold(pending []*StateUpdate, u StateUpdate) []*StateUpdate {
+	for i, held := range pending {
+		if held.UpdateType != u.UpdateType || held.Source != u.Source {
+			continue
+		}
+		if u.UpdateType == "workspaces_delta" {
+			mergeWorkspaceDeltas(held, u)
+			c.count(func(s *CoalesceStats) { s.Coalesced++ })
+		} else {
+			*held = u
+			c.count(func(s *CoalesceStats) { s.Dropped++ })
+		}
+		return append(append(pending[:i:i], pending[i+1:]...), held)
+	}
+	if u.UpdateType == "workspaces_delta" {
+		// Copy the deltas so merging never writes to the sender's values.
+		deltas := make([]*models.WorkspaceDelta, 0, len(u.WorkspaceDeltas))
+		for _, d := range u.WorkspaceDeltas {
+			if d != nil {
+				copied := *d
+				deltas = append(deltas, &copied)
+			}
+		}
+		u.WorkspaceDeltas = deltas
+	}
+	return append(pending, &u)
+}
+
+// mergeWorkspaceDeltas folds next's deltas into held, per workspace path.
+func mergeWorkspaceDeltas(held *StateUpdate, next StateUpdate) {
+	byPath := make(map[string]*models.WorkspaceDelta, len(held.WorkspaceDeltas))
+	for _, d := range held.WorkspaceDeltas {
+		byPath[d.Path] = d
+	}
+	for _, d := range next.WorkspaceDeltas {
+		if d == nil {
+			continue
+		}
+		if existing, ok := byPath[d.Path]; ok {
+			existing.Merge(d)
+			continue
+		}
+		copied := *d
+		held.WorkspaceDeltas = append(held.WorkspaceDeltas, &copied)
+		byPath[d.Path] = &copied
+	}
+	held.Scanned = next.Scanned
+}
@@ -0,0 +1,164 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestUpdateCoalesceInterval(t *testing.T) {
+	with := func(v string) *config.Config {
+		return &config.Config{Daemon: &config.DaemonConfig{UpdateCoalesceInterval: v}}
+	}
+	for _, tt := range []struct {
+		cfg  *config.Config
+		want time.Duration
+	}{
+		{nil, DefaultUpdateCoalesceInterval},
+		{with("1s"), time.Second},
+		{with("0"), 0},
+		{with("soon"), DefaultUpdateCoalesceInterval},
+	} {
+		if got := UpdateCoalesceInterval(tt.cfg); got != tt.want {
+			t.Errorf("UpdateCoalesceInterval(%v) = %v, want %v", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+// collect drains out until it closes.
+func collect(t *testing.T, out <-chan StateUpdate) []StateUpdate {
+	t.Helper()
+	var got []StateUpdate
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case u, ok := <-out:
+			if !ok {
+				return got
+			}
+			got = append(got, u)
+		case <-timeout:
+			t.Fatal("coalescer did not close its output")
+		}
+	}
+}
+
+func TestCoalescerCollapsesBurst(t *testing.T) {
+	in := make(chan StateUpdate, 64)
+	c := NewCoalescer(time.Hour)
+	out := c.Run(context.Background(), in)
+
+	for i := 0; i < 50; i++ {
+		in <- StateUpdate{UpdateType: "workspaces", Source: "workspace", Scanned: i}
+	}
+	close(in)
+
+	got := collect(t, out)
+	if len(got) != 2 || got[0].Scanned != 0 || got[1].Scanned != 49 {
+		t.Fatalf("want the first and the latest update, got %+v", got)
+	}
+	stats := c.Stats()
+	if stats.Received != 50 || stats.Emitted != 2 || stats.Dropped != 48 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestCoalescerMergesWorkspaceDeltas(t *testing.T) {
+	in := make(chan StateUpdate, 8)
+	c := NewCoalescer(time.Hour)
+	out := c.Run(context.Background(), in)
+
+	computed := true
+	first := &models.WorkspaceDelta{Path: "/a", NoteCounts: &models.NoteCounts{}}
+	in <- StateUpdate{UpdateType: "workspaces_delta"} // opens the window
+	in <- StateUpdate{UpdateType: "workspaces_delta", WorkspaceDeltas: []*models.WorkspaceDelta{first}}
+	in <- StateUpdate{UpdateType: "workspaces_delta", WorkspaceDeltas: []*models.WorkspaceDelta{
+		{Path: "/a", ChangedFilesComputed: &computed},
+		{Path: "/b", PlanStats: &models.PlanStats{}},
+	}}
+	close(in)
+
+	got := collect(t, out)
+	if len(got) != 2 {
+		t.Fatalf("want 2 updates, got %d", len(got))
+	}
+	deltas := got[1].WorkspaceDeltas
+	if len(deltas) != 2 || deltas[0].Path != "/a" || deltas[1].Path != "/b" {
+		t.Fatalf("merged deltas = %+v", deltas)
+	}
+	if deltas[0].NoteCounts == nil || deltas[0].ChangedFilesComputed == nil {
+		t.Errorf("delta for /a should carry both changes: %+v", deltas[0])
+	}
+	if first.ChangedFilesComputed != nil {
+		t.Error("merging must not modify the sender's delta")
+	}
+	if s := c.Stats(); s.Coalesced != 1 {
+		t.Errorf("coalesced = %d, want 1", s.Coalesced)
+	}
+}
+
+func TestCoalescerPassesEventsInOrder(t *testing.T) {
+	in := make(chan StateUpdate, 8)
+	c := NewCoalescer(time.Hour)
+	out := c.Run(context.Background(), in)
+
+	in <- StateUpdate{UpdateType: "sessions", Scanned: 1}
+	in <- StateUpdate{UpdateType: "sessions", Scanned: 2}
+	in <- StateUpdate{UpdateType: "job_started"}
+	in <- StateUpdate{UpdateType: "sessions", Scanned: 3}
+	close(in)
+
+	var types []string
+	for _, u := range collect(t, out) {
+		types = append(types, u.UpdateType)
+	}
+	want := []string{"sessions", "sessions", "job_started", "sessions"}
+	if len(types) != len(want) {
+		t.Fatalf("got %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("got %v, want %v", types, want)
+		}
+	}
+}
+
+func TestCoalescerFlushesWhenWindowCloses(t *testing.T) {
+	in := make(chan StateUpdate)
+	c := NewCoalescer(20 * time.Millisecond)
+	out := c.Run(context.Background(), in)
+	defer close(in)
+
+	in <- StateUpdate{UpdateType: "plans", Scanned: 1}
+	<-out
+	in <- StateUpdate{UpdateType: "plans", Scanned: 2}
+	in <- StateUpdate{UpdateType: "plans", Scanned: 3}
+
+	select {
+	case u := <-out:
+		if u.Scanned != 3 {
+			t.Errorf("flushed Scanned = %d, want 3", u.Scanned)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("held update was not sent when the window closed")
+	}
+	if s := c.Stats(); s.Flushes != 1 {
+		t.Errorf("flushes = %d, want 1", s.Flushes)
+	}
+}
+
+func TestCoalescerDisabled(t *testing.T) {
+	in := make(chan StateUpdate, 8)
+	c := NewCoalescer(0)
+	out := c.Run(context.Background(), in)
+	for i := 0; i < 5; i++ {
+		in <- StateUpdate{UpdateType: "workspaces"}
+	}
+	close(in)
+	if got := collect(t, out); len(got) != 5 {
+		t.Errorf("disabled coalescer sent %d updates, want 5", len(got))
+	}
+}
@@ -78,7 +78,7 @@ func NewConfigWatcher(debounceMs int, onReload func(string)) (*ConfigWatcher, er
 	entries, err := os.ReadDir(configDir)
 	if err == nil {
 		for _, entry := range entries {
-			if !strings.HasSuffix(entry.Name(), ".toml") && !strings.HasSuffix(entry.Name(), ".yml") {
+			if !strings.HasSuffix(entry.Name(), ".toml") && !strings.HasSuffix(entry.Name(), ".yml") && !strings.HasSuffix(entry.Name(), ".json5") {
 				continue
 			}
 
@@ -152,7 +152,7 @@ func (w *ConfigWatcher) Start(ctx context.Context) {
 			w.logger.Debugf("fsnotify event: %s op=%v", event.Name, event.Op)
 
 			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
-				if strings.HasSuffix(event.Name, ".toml") || strings.HasSuffix(event.Name, ".yml") || strings.HasSuffix(event.Name, ".yaml") {
+				if strings.HasSuffix(event.Name, ".toml") || strings.HasSuffix(event.Name, ".yml") || strings.HasSuffix(event.Name, ".yaml") || strings.HasSuffix(event.Name, ".json5") {
 					// Map target file changes back to symlink names
 					displayName := event.Name
 					if linkName, ok := w.targetToLink[event.Name]; ok {
@@ -193,8 +193,11 @@ func (w *ConfigWatcher) handleChange(file string) {
 	for _, hook := range w.hooks {
 		if w.sectionAffected(file, hook.Section) {
 			w.logger.Infof("Running config hook: %s", hook.Name)
+			stop := logging.Timer("config-watcher", "hook."+hook.Section, logging.RecordMetrics())
 			cmd := exec.Command(hook.Command[0], hook.Command[1:]...) //nolint:gosec // hook commands from trusted config
-			if err := cmd.Run(); err != nil {
+			err := cmd.Run()
+			stop(err)
+			if err != nil {
 				w.logger.Errorf("Hook %s failed: %v", hook.Name, err)
 			}
 		}
@@ -210,7 +213,7 @@ func (w *ConfigWatcher) handleChange(file string) {
 func (w *ConfigWatcher) sectionAffected(file, section string) bool {
 	base := filepath.Base(file)
 	// Main config affects everything
-	if base == "grove.toml" || base == "grove.yml" || base == "grove.yaml" {
+	if base == "grove.toml" || base == "grove.yml" || base == "grove.yaml" || base == "grove.json5" {
 		return true
 	}
 
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package daemon
+
+import "syscall"
+
+// signalDrain asks a running daemon to unlink its socket and drain
+// in-flight requests (SIGUSR1) ahead of a zero-downtime upgrade.
+func signalDrain(pid int) error {
+	return syscall.Kill(pid, syscall.SIGUSR1)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package daemon
+
+import "errors"
+
+// signalDrain is unsupported on Windows, which has no SIGUSR1; upgrades
+// there require stopping and restarting the daemon.
+func signalDrain(pid int) error {
+	return errors.New("zero-downtime daemon upgrade is not supported on Windows; restart the daemon instead")
+}
@@ -14,8 +14,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/internal/detach"
 	"github.com/grovetools/core/logging"
 	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/procman"
 	"github.com/grovetools/core/pkg/workspace"
 )
 
@@ -90,6 +93,9 @@ func resolveScopedTargets(dir string) (scope, socketPath, pidPath string) {
 // to know whether the daemon is running or not. The same API works
 // in both modes.
 func New(dir ...string) Client {
+	if client := snapshotClientFromEnv(); client != nil {
+		return client
+	}
 	resolvedDir := resolveDir(dir)
 	_, socketPath, _ := resolveScopedTargets(resolvedDir)
 
@@ -153,8 +159,8 @@ func NewWithAutoStartOpts(dir string, opts ...Option) Client {
 // auto-starting it if not running. The global daemon hosts the shared
 // proxy (port 8443) and serves proxy RegisterProxyRoute / UnregisterProxyRoutes
 // RPCs from every scoped daemon on the host. Unlike NewWithAutoStart(""),
-// the daemon started here never self-terminates via --auto-shutdown because
-// autoStartDaemon omits that flag when scope is empty (see autoStartDaemon).
+// the daemon started here never self-terminates via --auto-shutdown unless
+// daemon.idle_timeout is set (see IdleTimeout).
 func NewGlobalClient() Client {
 	return newAutoStart("", autoStartOptions{})
 }
@@ -171,6 +177,9 @@ func NewPaired(dir string, pairPID int) Client {
 }
 
 func newAutoStart(resolvedDir string, opts autoStartOptions) Client {
+	if client := snapshotClientFromEnv(); client != nil {
+		return client
+	}
 	scope, socketPath, pidPath := resolveScopedTargets(resolvedDir)
 	clearConnectDiagnosis()
 
@@ -193,6 +202,28 @@ func newAutoStart(resolvedDir string, opts autoStartOptions) Client {
 		return NewLocalClient()
 	}
 
+	// Another user's socket at our path means a misconfigured shared state
+	// dir; neither talk to that daemon nor spawn one next to it.
+	if errors.Is(dialErr, ErrForeignSocket) {
+		recordConnectDiagnosis(socketPath, fmt.Errorf("%s: %w", socketPath, dialErr))
+		return NewLocalClient()
+	}
+
+	// Serialize the spawn: clients racing a cold socket (several panes
+	// opening at once) would otherwise each launch a groved for the same
+	// scope. The winner spawns; the rest wait here, then find it serving.
+	release, locked := acquireSpawnLock(pidPath, readyHandshakeTimeout+5*time.Second)
+	if !locked {
+		if client := tryConnect(socketPath); client != nil {
+			return client
+		}
+		return NewLocalClient()
+	}
+	defer release()
+	if client := tryConnect(socketPath); client != nil {
+		return client
+	}
+
 	// Daemon not running, try to auto-start it for this scope. autoStartDaemon
 	// returns the read end of a pipe whose write end is inherited by groved
 	// (via --ready-fd); groved closes it after the socket is bound, giving us
@@ -275,11 +306,16 @@ func tryConnect(socketPath string) Client {
 // but the dial fails, the dial error is returned alongside the nil client so
 // callers can distinguish a dead daemon (ECONNREFUSED on a stale socket) from
 // one that is alive but unreachable from this process (EPERM/EACCES under the
-// Claude Code sandbox). A missing socket file returns (nil, nil).
+// Claude Code sandbox). A missing socket file returns (nil, nil), and a
+// socket owned by another user returns ErrForeignSocket without dialing.
 func tryConnectDiag(socketPath string) (Client, error) {
-	if _, err := os.Stat(socketPath); err != nil {
+	info, err := os.Stat(socketPath)
+	if err != nil {
 		return nil, nil
 	}
+	if err := checkSocketOwner(info); err != nil {
+		return nil, err
+	}
 
 	conn, err := net.DialTimeout("unix", socketPath, 100*time.Millisecond)
 	if err != nil {
@@ -376,7 +412,8 @@ func tryConnectWithRetry(socketPath string, maxRetries int, initialDelay time.Du
 //
 // Spawns groved with explicit --scope/--socket/--pidfile/--auto-shutdown
 // so the auto-started daemon binds the scope-keyed paths and exits on
-// idle. Empty scope falls through to groved's own unscoped defaults. When
+// idle (see IdleTimeout). Empty scope falls through to groved's own
+// unscoped defaults. When
 // pairPID > 0, --pair-with-pid is added so the daemon exits when that
 // parent process dies.
 func autoStartDaemon(scope, socketPath, pidPath string, pairPID int, earlyReady bool) (readyPipe *os.File, exited <-chan struct{}, ok bool) {
@@ -424,13 +461,22 @@ func autoStartDaemon(scope, socketPath, pidPath string, pairPID int, earlyReady
 	// process group and receives SIGHUP when the terminal closes, which triggers
 	// ptyManager.Shutdown() and kills every agent PTY the daemon owns.
 	//
-	// Auto-shutdown is only enabled for scoped daemons. The global (unscoped)
-	// daemon hosts the shared *.grove.local proxy on :8443 and the host-wide
-	// route table; if it self-terminates on idle, every scoped daemon's routing
-	// silently breaks. Scoped daemons stay self-reaping as before.
+	// Auto-shutdown is on by default only for scoped daemons. The global
+	// (unscoped) daemon hosts the shared *.grove.local proxy on :8443 and the
+	// host-wide route table; if it self-terminates on idle, every scoped
+	// daemon's routing breaks until the next client starts it again, so it
+	// only idles out when daemon.idle_timeout opts it in (see IdleTimeout).
+	// groved reads the duration from the same setting.
+	var cfg *config.Config
+	if loaded, err := config.LoadDefault(); err == nil {
+		cfg = loaded
+	}
+	idle := IdleTimeout(cfg, scope)
 	args := []string{"start"}
-	if scope != "" {
+	if idle > 0 {
 		args = append(args, "--auto-shutdown")
+	}
+	if scope != "" {
 		args = append(args, "--scope", scope)
 	}
 	if socketPath != "" {
@@ -464,9 +510,16 @@ func autoStartDaemon(scope, socketPath, pidPath string, pairPID int, earlyReady
 	}
 	cmd.Stdout = nil
 	cmd.Stderr = nil
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
-
-	if err := cmd.Start(); err != nil {
+	cmd.SysProcAttr = detach.SysProcAttr()
+
+	// Tracked as detached: it is listed by `core ps` but outlives this
+	// process and is never stopped by procman's shutdown.
+	child, err := procman.Default().Start(cmd, procman.Options{
+		Name:      "daemon",
+		StopOrder: procman.OrderDaemon,
+		Detached:  true,
+	})
+	if err != nil {
 		if readyR != nil {
 			readyR.Close()
 		}
@@ -491,9 +544,8 @@ func autoStartDaemon(scope, socketPath, pidPath string, pairPID int, earlyReady
 		scopeDesc = fmt.Sprintf("scope %q", scope)
 	}
 	notice := fmt.Sprintf("grove: started background daemon groved (pid %d, %s)", cmd.Process.Pid, scopeDesc)
-	if scope != "" {
-		// Scoped daemons are spawned with --auto-shutdown (see args above).
-		notice += "; exits after 2m idle"
+	if idle > 0 {
+		notice += fmt.Sprintf("; exits after %s idle", idle)
 	}
 	fmt.Fprintln(os.Stderr, notice)
 
@@ -503,7 +555,7 @@ func autoStartDaemon(scope, socketPath, pidPath string, pairPID int, earlyReady
 	// (channel open) so it only respawns in the former case.
 	exitedCh := make(chan struct{})
 	go func() {
-		_ = cmd.Wait()
+		_ = child.Wait()
 		close(exitedCh)
 	}()
 
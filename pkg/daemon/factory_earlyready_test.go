@@ -51,6 +51,13 @@ func runFakeGroved() {
 	if p := os.Getenv("GROVE_FAKE_PIDFILE"); p != "" {
 		_ = os.WriteFile(p, []byte(strconv.Itoa(os.Getpid())), 0o644)
 	}
+	// Append to the spawn log so tests can count how many daemons started.
+	if p := os.Getenv("GROVE_FAKE_SPAWNLOG"); p != "" {
+		if f, err := os.OpenFile(p, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644); err == nil {
+			_, _ = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			_ = f.Close()
+		}
+	}
 
 	sock := flagValue("--socket")
 	if sock == "" {
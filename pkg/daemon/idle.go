@@ -0,0 +1,133 @@
+package daemon
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+// DefaultScopedIdleTimeout is how long a scoped daemon stays up with no
+// connected clients when daemon.idle_timeout is unset. The global daemon
+// hosts the shared proxy and route table, so it only idles out when
+// daemon.idle_timeout is set explicitly.
+const DefaultScopedIdleTimeout = 2 * time.Minute
+
+// IdleTimeout returns how long the daemon for scope should run with no
+// clients before exiting, or 0 when it should run until stopped.
+// daemon.idle_timeout applies to every daemon; "0" disables idle shutdown.
+func IdleTimeout(cfg *config.Config, scope string) time.Duration {
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.IdleTimeout != "" {
+		if d, err := time.ParseDuration(cfg.Daemon.IdleTimeout); err == nil {
+			return max(d, 0)
+		}
+	}
+	if scope == "" {
+		return 0
+	}
+	return DefaultScopedIdleTimeout
+}
+
+// IdleMonitor tracks a daemon's clients and signals Done once it has had
+// none for the configured timeout. groved sets ConnState as its
+// http.Server.ConnState hook; long-lived streams that outlive their HTTP
+// connection (hijacked websockets) bracket themselves with Acquire and
+// Release.
+//
+// A pooled keep-alive connection counts as a client: a process holding an
+// open Client keeps the daemon alive until its transport closes the idle
+// connection.
+type IdleMonitor struct {
+	timeout time.Duration
+
+	mu     sync.Mutex
+	active int
+	timer  *time.Timer
+	// armed counts countdowns so a timer stopped too late to cancel its
+	// callback cannot fire for a newer idle period.
+	armed uint64
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewIdleMonitor returns a monitor that fires after timeout with no
+// clients. The countdown starts immediately, so a daemon nobody connects to
+// exits too. A timeout <= 0 disables the monitor: Done never fires.
+func NewIdleMonitor(timeout time.Duration) *IdleMonitor {
+	m := &IdleMonitor{timeout: timeout, done: make(chan struct{})}
+	m.mu.Lock()
+	m.arm()
+	m.mu.Unlock()
+	return m
+}
+
+// Done is closed when the idle timeout elapses.
+func (m *IdleMonitor) Done() <-chan struct{} {
+	return m.done
+}
+
+// Acquire registers a client that is not tracked by ConnState.
+func (m *IdleMonitor) Acquire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active++
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
+
+// Release ends a client registered with Acquire.
+func (m *IdleMonitor) Release() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.active > 0 {
+		m.active--
+	}
+	if m.active == 0 {
+		m.arm()
+	}
+}
+
+// ConnState is an http.Server.ConnState hook counting open connections.
+// Hijacked connections are released here; their handler owns them from
+// then on and should Acquire if it keeps the client alive.
+func (m *IdleMonitor) ConnState(_ net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateNew:
+		m.Acquire()
+	case http.StateClosed, http.StateHijacked:
+		m.Release()
+	}
+}
+
+// Stop cancels any pending countdown. Done will not fire afterwards.
+func (m *IdleMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timeout = 0
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
+
+// arm starts the idle countdown. Callers hold m.mu.
+func (m *IdleMonitor) arm() {
+	if m.timeout <= 0 || m.timer != nil {
+		return
+	}
+	m.armed++
+	gen := m.armed
+	m.timer = time.AfterFunc(m.timeout, func() {
+		m.mu.Lock()
+		idle := gen == m.armed && m.timer != nil && m.active == 0
+		m.mu.Unlock()
+		if idle {
+			m.doneOnce.Do(func() { close(m.done) })
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	withIdle := func(v string) *config.Config {
+		return &config.Config{Daemon: &config.DaemonConfig{IdleTimeout: v}}
+	}
+	tests := []struct {
+		name  string
+		cfg   *config.Config
+		scope string
+		want  time.Duration
+	}{
+		{"scoped default", nil, "/work/proj", DefaultScopedIdleTimeout},
+		{"global default runs forever", nil, "", 0},
+		{"configured applies to global", withIdle("10m"), "", 10 * time.Minute},
+		{"configured applies to scoped", withIdle("30s"), "/work/proj", 30 * time.Second},
+		{"zero disables", withIdle("0"), "/work/proj", 0},
+		{"invalid falls back", withIdle("soon"), "/work/proj", DefaultScopedIdleTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IdleTimeout(tt.cfg, tt.scope); got != tt.want {
+				t.Errorf("IdleTimeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdleMonitorFiresWithoutClients(t *testing.T) {
+	m := NewIdleMonitor(20 * time.Millisecond)
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("monitor never fired with no clients")
+	}
+}
+
+func TestIdleMonitorWaitsForClients(t *testing.T) {
+	m := NewIdleMonitor(30 * time.Millisecond)
+	m.ConnState(nil, http.StateNew)
+
+	select {
+	case <-m.Done():
+		t.Fatal("monitor fired while a client was connected")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Idle keep-alive connections still count; closing the last one starts
+	// the countdown.
+	m.ConnState(nil, http.StateIdle)
+	m.ConnState(nil, http.StateClosed)
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("monitor never fired after the last client left")
+	}
+}
+
+func TestIdleMonitorDisabled(t *testing.T) {
+	m := NewIdleMonitor(0)
+	select {
+	case <-m.Done():
+		t.Fatal("disabled monitor fired")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s := NewIdleMonitor(20 * time.Millisecond)
+	s.Stop()
+	select {
+	case <-s.Done():
+		t.Fatal("stopped monitor fired")
+	case <-time.After(80 * time.Millisecond):
+	}
+}
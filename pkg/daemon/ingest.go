@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/pkg/logging/logutil"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// LogIngestPath is the daemon endpoint that accepts batched structured log
+// entries (models.LogIngestRequest) from processes that cannot write to a
+// workspace's log files themselves, such as containers and remote jobs.
+const LogIngestPath = "/v1/logs"
+
+// MaxLogIngestBytes caps the body of one ingestion request.
+const MaxLogIngestBytes = 8 << 20
+
+// WorkspaceResolver maps the workspace named in a LogIngestRequest to its
+// node. A resolver reports a ref that names no workspace with an error
+// wrapping ErrUnknownWorkspace, which NewLogIngestHandler answers with 422;
+// any other error is a 500.
+type WorkspaceResolver func(ctx context.Context, ref string) (*workspace.WorkspaceNode, error)
+
+// ErrUnknownWorkspace is wrapped by WorkspaceResolver errors when ref names
+// no workspace.
+var ErrUnknownWorkspace = errors.New("unknown workspace")
+
+// ingestDiscoveryTTL is how long ResolveIngestWorkspace reuses a
+// discovery result before walking the groves again. Log batches arrive far
+// more often than workspaces are created.
+const ingestDiscoveryTTL = 30 * time.Second
+
+// ingestProvider caches the workspace provider ResolveIngestWorkspace looks
+// names up in.
+var ingestProvider struct {
+	mu       sync.Mutex
+	provider *workspace.Provider
+	loaded   time.Time
+}
+
+// cachedIngestProvider returns the cached provider, discovering workspaces
+// again once it is older than ingestDiscoveryTTL. A cancelled ctx stops the
+// discovery and leaves the cache as it was.
+func cachedIngestProvider(ctx context.Context) (*workspace.Provider, error) {
+	ingestProvider.mu.Lock()
+	defer ingestProvider.mu.Unlock()
+	if ingestProvider.provider != nil && time.Since(ingestProvider.loaded) < ingestDiscoveryTTL {
+		return ingestProvider.provider, nil
+	}
+	result, err := workspace.NewDiscoveryService(nil).WithContext(ctx).DiscoverAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspaces: %w", err)
+	}
+	ingestProvider.provider = workspace.NewProvider(result)
+	ingestProvider.loaded = time.Now()
+	return ingestProvider.provider, nil
+}
+
+// ResolveIngestWorkspace is the default WorkspaceResolver: an absolute path
+// resolves to the workspace containing it, anything else is looked up by
+// name or identifier (e.g. "eco:api") through discovery. Discovery results
+// are reused for ingestDiscoveryTTL, so a workspace created since may not
+// resolve by name until the next discovery.
+func ResolveIngestWorkspace(ctx context.Context, ref string) (*workspace.WorkspaceNode, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("%w: no workspace given", ErrUnknownWorkspace)
+	}
+	if filepath.IsAbs(ref) {
+		node, err := workspace.GetProjectByPath(ref)
+		if err != nil || node == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownWorkspace, ref)
+		}
+		return node, nil
+	}
+	provider, err := cachedIngestProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if node := provider.FindByIdentifier(ref, ""); node != nil {
+		return node, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownWorkspace, ref)
+}
+
+// NewLogIngestHandler returns the handler groved mounts at LogIngestPath
+// next to its /api routes, so it is served wherever they are. It accepts a
+// POSTed models.LogIngestRequest, resolves the workspace with resolve
+// (ResolveIngestWorkspace when nil) and writes the entries into that
+// workspace's log files with logutil.IngestEntries, answering with a
+// models.LogIngestResponse. Invalid entries are reported in the response
+// rather than failing the batch. An unknown workspace is answered with 422,
+// keeping 404 to mean a daemon without the endpoint.
+func NewLogIngestHandler(resolve WorkspaceResolver) http.Handler {
+	if resolve == nil {
+		resolve = ResolveIngestWorkspace
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req models.LogIngestRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxLogIngestBytes)).Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ws, err := resolve(r.Context(), req.Workspace)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrUnknownWorkspace) {
+				status = http.StatusUnprocessableEntity
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		resp, err := logutil.IngestEntries(ws, req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
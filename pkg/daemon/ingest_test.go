@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func TestLogIngestHandler(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GROVE_HOME", "")
+	dir := t.TempDir()
+	cfg := "version: \"1.0\"\nlogging:\n  file:\n    dir: logs\n    format: json\n"
+	if err := os.WriteFile(filepath.Join(dir, "grove.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ws := &workspace.WorkspaceNode{Name: "api", Path: dir}
+	resolve := func(ctx context.Context, ref string) (*workspace.WorkspaceNode, error) {
+		if ref != "api" {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownWorkspace, ref)
+		}
+		return ws, nil
+	}
+	handler := NewLogIngestHandler(resolve)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, LogIngestPath, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := post(`{"workspace":"api","component":"remote","entries":[{"msg":"hello"},{"level":"nope","msg":"x"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp models.LogIngestResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Accepted != 1 || len(resp.Rejected) != 1 || resp.Rejected[0].Index != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "logs", "api", "workspace-*.log"))
+	if len(files) != 1 {
+		t.Errorf("expected the entry in the workspace's log file, got %v", files)
+	}
+
+	if rec := post(`{"workspace":"web","entries":[]}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown workspace: expected 422, got %d", rec.Code)
+	}
+	if rec := post(`not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad body: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LogIngestPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected 405, got %d", rec.Code)
+	}
+}
+
+func TestRemoteClientIngestLogs(t *testing.T) {
+	resolve := func(ctx context.Context, ref string) (*workspace.WorkspaceNode, error) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownWorkspace, ref)
+	}
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle(LogIngestPath, NewLogIngestHandler(resolve))
+		c, err := NewRemoteClient(startUnixServer(t, mux))
+		if err != nil {
+			t.Fatalf("NewRemoteClient: %v", err)
+		}
+		_, err = c.IngestLogs(context.Background(), models.LogIngestRequest{Workspace: "ap"})
+		if err == nil {
+			t.Fatal("expected an error for an unknown workspace")
+		}
+		if strings.Contains(err.Error(), "upgrade groved") || !strings.Contains(err.Error(), "unknown workspace: ap") {
+			t.Errorf("expected the unknown workspace reported, got %v", err)
+		}
+	})
+
+	t.Run("old daemon", func(t *testing.T) {
+		c, err := NewRemoteClient(startUnixServer(t, http.NotFoundHandler()))
+		if err != nil {
+			t.Fatalf("NewRemoteClient: %v", err)
+		}
+		_, err = c.IngestLogs(context.Background(), models.LogIngestRequest{Workspace: "api"})
+		if err == nil || !strings.Contains(err.Error(), "upgrade groved") {
+			t.Errorf("expected an upgrade hint, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// instanceHeartbeat is how often a running daemon refreshes its instance
+// lock. A lock not refreshed for instanceStaleAfter belongs to a daemon
+// that is gone, even when the lock itself could not tell (flock over NFS).
+const (
+	instanceHeartbeat  = 30 * time.Second
+	instanceStaleAfter = 3 * instanceHeartbeat
+)
+
+// InstanceOwner identifies the daemon holding an instance lock.
+type InstanceOwner struct {
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	UID       int       `json:"uid"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// InstanceConflictError is returned by AcquireInstanceLock when another
+// daemon already serves the scope, on this host or, through a shared state
+// directory, on another one.
+type InstanceConflictError struct {
+	LockPath string
+	Owner    InstanceOwner
+	// OtherHost is set when the owner runs on a different host that shares
+	// this state directory (typically an NFS home).
+	OtherHost bool
+}
+
+func (e *InstanceConflictError) Error() string {
+	if e.OtherHost {
+		return fmt.Sprintf("the grove state directory %s is shared with host %q, where groved (pid %d) is running; set XDG_STATE_HOME or GROVE_HOME to a host-local directory",
+			filepath.Dir(e.LockPath), e.Owner.Host, e.Owner.PID)
+	}
+	return fmt.Sprintf("groved is already running for this scope (pid %d)", e.Owner.PID)
+}
+
+// ErrForeignSocket is returned when a daemon socket belongs to another user.
+var ErrForeignSocket = errors.New("daemon socket is owned by another user")
+
+// InstanceLock keeps a second daemon from starting for the same scope. It
+// is held for the daemon's lifetime; see AcquireInstanceLock.
+type InstanceLock struct {
+	path string
+	file *os.File
+	stop chan struct{}
+	once sync.Once
+}
+
+// AcquireInstanceLock takes the single-instance lock for the daemon whose
+// pidfile is pidPath. The lock is an exclusive flock on <pidPath>.lock that
+// records the owner's pid, host and uid, and is refreshed every
+// instanceHeartbeat until Release.
+//
+// It fails with *InstanceConflictError when the flock is held, or when the
+// recorded owner is a daemon on another host that refreshed the lock
+// recently: flock is not reliable across NFS clients, so the owner record
+// is what catches two hosts sharing a home directory.
+func AcquireInstanceLock(pidPath string) (*InstanceLock, error) {
+	lockPath := pidPath + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open instance lock: %w", err)
+	}
+
+	host, _ := os.Hostname()
+	owner, fresh := readInstanceOwner(lockPath)
+	if !tryLockFile(f) {
+		f.Close()
+		return nil, &InstanceConflictError{LockPath: lockPath, Owner: owner, OtherHost: owner.Host != "" && owner.Host != host}
+	}
+	if fresh && owner.Host != "" && owner.Host != host {
+		unlockFile(f)
+		f.Close()
+		return nil, &InstanceConflictError{LockPath: lockPath, Owner: owner, OtherHost: true}
+	}
+
+	data, _ := json.Marshal(InstanceOwner{PID: os.Getpid(), Host: host, UID: os.Getuid(), StartedAt: time.Now()})
+	if err := f.Truncate(0); err == nil {
+		_, err = f.WriteAt(data, 0)
+	}
+	if err != nil {
+		unlockFile(f)
+		f.Close()
+		return nil, fmt.Errorf("failed to write instance lock: %w", err)
+	}
+
+	l := &InstanceLock{path: lockPath, file: f, stop: make(chan struct{})}
+	go l.heartbeat()
+	return l, nil
+}
+
+// readInstanceOwner returns the owner recorded in the lock at path and
+// whether it was refreshed within instanceStaleAfter.
+func readInstanceOwner(path string) (InstanceOwner, bool) {
+	var owner InstanceOwner
+	info, err := os.Stat(path)
+	if err != nil {
+		return owner, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &owner) != nil {
+		return InstanceOwner{}, false
+	}
+	return owner, time.Since(info.ModTime()) < instanceStaleAfter
+}
+
+func (l *InstanceLock) heartbeat() {
+	ticker := time.NewTicker(instanceHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case now := <-ticker.C:
+			_ = os.Chtimes(l.path, now, now)
+		}
+	}
+}
+
+// Release clears the owner record and drops the lock. The lock file is
+// left in place: removing it would let a process that already opened it
+// lock an unlinked file while a third creates a new one.
+func (l *InstanceLock) Release() {
+	l.once.Do(func() {
+		close(l.stop)
+		_ = l.file.Truncate(0)
+		unlockFile(l.file)
+		l.file.Close()
+	})
+}
+
+// ListenUnix binds the daemon socket at socketPath for the current user
+// only: the socket dir is created (or tightened) to 0700 and must belong to
+// the current user, and the socket itself is made 0600. A stale socket
+// left by a crashed daemon is replaced; a live one is an error, so a second
+// daemon cannot take over the path.
+func ListenUnix(socketPath string) (net.Listener, error) {
+	if err := paths.EnsurePrivateDir(filepath.Dir(socketPath)); err != nil {
+		return nil, err
+	}
+	if info, err := os.Lstat(socketPath); err == nil {
+		if uid, ok := paths.FileOwner(info); ok && uid != os.Getuid() {
+			return nil, fmt.Errorf("%s: %w", socketPath, ErrForeignSocket)
+		}
+		if conn, err := net.DialTimeout("unix", socketPath, 100*time.Millisecond); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a daemon is already listening on %s", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to restrict socket: %w", err)
+	}
+	return ln, nil
+}
+
+// checkSocketOwner refuses a socket that belongs to another user, so a
+// client never talks to a daemon it did not start.
+func checkSocketOwner(info os.FileInfo) error {
+	if uid, ok := paths.FileOwner(info); ok && uid != os.Getuid() {
+		return ErrForeignSocket
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAcquireInstanceLockSameHost(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "groved.pid")
+
+	first, err := AcquireInstanceLock(pidPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = AcquireInstanceLock(pidPath)
+	var conflict *InstanceConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("second acquire err = %v, want InstanceConflictError", err)
+	}
+	if conflict.OtherHost || conflict.Owner.PID != os.Getpid() {
+		t.Errorf("conflict = %+v, want this host and pid %d", conflict, os.Getpid())
+	}
+
+	first.Release()
+	second, err := AcquireInstanceLock(pidPath)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	second.Release()
+}
+
+func TestAcquireInstanceLockOtherHost(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "groved.pid")
+	lockPath := pidPath + ".lock"
+	data, _ := json.Marshal(InstanceOwner{PID: 4242, Host: "some-other-host.invalid", UID: os.Getuid(), StartedAt: time.Now()})
+	if err := os.WriteFile(lockPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A recent heartbeat from another host: refused even though the flock
+	// was free, as it would be over NFS.
+	_, err := AcquireInstanceLock(pidPath)
+	var conflict *InstanceConflictError
+	if !errors.As(err, &conflict) || !conflict.OtherHost || conflict.Owner.Host != "some-other-host.invalid" {
+		t.Fatalf("err = %v, want other-host conflict", err)
+	}
+
+	// The same record without a heartbeat for a while is taken over.
+	old := time.Now().Add(-2 * instanceStaleAfter)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := AcquireInstanceLock(pidPath)
+	if err != nil {
+		t.Fatalf("stale record should be taken over: %v", err)
+	}
+	defer lock.Release()
+	if owner, _ := readInstanceOwner(lockPath); owner.PID != os.Getpid() {
+		t.Errorf("owner pid = %d, want %d", owner.PID, os.Getpid())
+	}
+}
+
+func TestListenUnixPermissions(t *testing.T) {
+	// Socket paths are length-limited, so avoid the long t.TempDir path.
+	base, err := os.MkdirTemp("", "gi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(base)
+	dir := filepath.Join(base, "run")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	socketPath := filepath.Join(dir, "d.sock")
+
+	ln, err := ListenUnix(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(dir); info.Mode().Perm() != 0o700 {
+		t.Errorf("socket dir mode = %o, want 700", info.Mode().Perm())
+	}
+	if info, _ := os.Stat(socketPath); info.Mode().Perm() != 0o600 {
+		t.Errorf("socket mode = %o, want 600", info.Mode().Perm())
+	}
+
+	if _, err := ListenUnix(socketPath); err == nil {
+		t.Fatal("second listener on a live socket should fail")
+	}
+	ln.Close()
+
+	// net.UnixListener.Close unlinks the socket; leave a stale file behind
+	// the way a crashed daemon would.
+	if err := os.WriteFile(socketPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ln, err = ListenUnix(socketPath)
+	if err != nil {
+		t.Fatalf("stale socket should be replaced: %v", err)
+	}
+	ln.Close()
+}
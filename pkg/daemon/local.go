@@ -13,6 +13,7 @@ import (
 
 	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/pkg/env"
+	"github.com/grovetools/core/pkg/logging/logutil"
 	"github.com/grovetools/core/pkg/models"
 	"github.com/grovetools/core/pkg/paths"
 	"github.com/grovetools/core/pkg/repo"
@@ -25,13 +26,14 @@ import (
 // but executing all operations in-process.
 type LocalClient struct {
 	logger *logrus.Logger
+	usage  *sessions.UsageCollector
 }
 
 // NewLocalClient creates a new LocalClient.
 func NewLocalClient() *LocalClient {
 	logger := logrus.New()
 	logger.SetLevel(logrus.WarnLevel)
-	return &LocalClient{logger: logger}
+	return &LocalClient{logger: logger, usage: sessions.NewUsageCollector()}
 }
 
 // GetWorkspaces returns all discovered workspaces by calling the discovery service directly.
@@ -79,8 +81,16 @@ func (c *LocalClient) GetPlansRaw(ctx context.Context, planDir string) ([]byte,
 // - OpenCode sessions (from ~/.local/share/opencode/storage)
 //
 // This provides full parity with the daemon's session registry when running in local mode.
+// Sessions are marked idle after daemon.collectors.session.idle_threshold,
+// an agent reported more than once is merged by SessionDedupPolicy, and
+// each session carries its accumulated Usage, as the daemon's do.
 func (c *LocalClient) GetSessions(ctx context.Context) ([]*models.Session, error) {
-	return sessions.DiscoverAll()
+	cfg, _ := config.LoadDefault()
+	return sessions.Discover(sessions.DiscoverOptions{
+		IdleThreshold: SessionIdleThreshold(cfg),
+		Dedup:         SessionDedupPolicy(cfg),
+		Usage:         c.usage,
+	})
 }
 
 // StreamState returns an error for LocalClient since streaming is only available via daemon.
@@ -95,6 +105,12 @@ func (c *LocalClient) StreamWorkspaceHUD(ctx context.Context, path string) (<-ch
 	return nil, errors.New("workspace HUD streaming requires the grove daemon; start groved for live HUD updates")
 }
 
+// StreamWorkspaceChanges returns an error for LocalClient since change
+// events come from the daemon's workspace collector.
+func (c *LocalClient) StreamWorkspaceChanges(ctx context.Context) (<-chan models.WorkspaceChange, error) {
+	return nil, errors.New("workspace change streaming requires the grove daemon; start groved for live workspace updates")
+}
+
 // GetConfig returns an error for LocalClient since config is only available via daemon.
 func (c *LocalClient) GetConfig(ctx context.Context) (*RunningConfig, error) {
 	return nil, errors.New("config not available in local mode; start the daemon to view running config")
@@ -206,7 +222,11 @@ func (c *LocalClient) ConfirmSession(ctx context.Context, confirmation SessionCo
 		return registry.Register(metadata)
 	}
 
-	// Update the existing entry with confirmation data
+	// Update the existing entry with confirmation data. A new PID needs a
+	// new fingerprint, which Register records.
+	if existing.PID != confirmation.PID {
+		existing.Fingerprint = nil
+	}
 	existing.ClaudeSessionID = confirmation.NativeID
 	existing.PID = confirmation.PID
 	existing.TranscriptPath = confirmation.TranscriptPath
@@ -291,6 +311,11 @@ func (c *LocalClient) StreamLogs(ctx context.Context, opts models.LogStreamOptio
 	return nil, errors.New("aggregated log streaming requires the grove daemon; start groved first")
 }
 
+// GetLogHistory returns an error since aggregated log history requires the daemon.
+func (c *LocalClient) GetLogHistory(ctx context.Context, opts models.LogHistoryOptions) (*models.LogHistoryPage, error) {
+	return nil, errors.New("log history requires the grove daemon; start groved first")
+}
+
 // StreamJobLogs returns an error since log streaming requires the daemon.
 func (c *LocalClient) StreamJobLogs(ctx context.Context, jobID string) (<-chan models.JobStreamEvent, error) {
 	return nil, errors.New("log streaming requires the grove daemon; use daemon.NewWithAutoStart()")
@@ -311,6 +336,16 @@ func (c *LocalClient) NotifyNoteEvent(ctx context.Context, event models.NoteEven
 	return nil
 }
 
+// IngestLogs writes the entries into the workspace's log files directly,
+// as the daemon's ingestion endpoint would.
+func (c *LocalClient) IngestLogs(ctx context.Context, req models.LogIngestRequest) (*models.LogIngestResponse, error) {
+	ws, err := ResolveIngestWorkspace(ctx, req.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	return logutil.IngestEntries(ws, req)
+}
+
 // PublishWorkflowEvent is a no-op for LocalClient since there's no daemon to
 // aggregate workflow state — daemonless consumers fall back to file-based
 // workflow monitoring (journal tailing).
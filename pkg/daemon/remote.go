@@ -674,6 +674,72 @@ func (c *RemoteClient) StreamWorkspaceHUD(ctx context.Context, path string) (<-c
 	return ch, nil
 }
 
+// StreamWorkspaceChanges subscribes to workspace added/removed/modified
+// events via SSE. The channel is closed when the context is cancelled or the
+// connection is lost.
+func (c *RemoteClient) StreamWorkspaceChanges(ctx context.Context) (<-chan models.WorkspaceChange, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/api/workspaces/changes/stream", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace change stream request: %w", err)
+	}
+
+	// Use a separate client with no timeout for streaming.
+	streamTransport := c.newStreamTransport()
+	streamClient := &http.Client{
+		Transport: streamTransport,
+		Timeout:   0,
+	}
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to workspace change stream: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, fmt.Errorf("workspace change stream not available; rebuild and restart groved")
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("workspace change stream returned status %d", resp.StatusCode)
+	}
+
+	ch := make(chan models.WorkspaceChange, 16)
+
+	go func() {
+		defer resp.Body.Close()
+		defer close(ch)
+		defer streamTransport.CloseIdleConnections()
+
+		scanner := bufio.NewScanner(resp.Body)
+		buf := make([]byte, 0, 64*1024)
+		scanner.Buffer(buf, 1*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, ":") || line == "" {
+				continue
+			}
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			var change models.WorkspaceChange
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &change); err != nil {
+				continue
+			}
+			if models.CheckWorkspaceSchemaVersion(change.Workspace.SchemaVersion) != nil {
+				continue
+			}
+			select {
+			case ch <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
 // Close cleans up any resources used by the client.
 func (c *RemoteClient) Close() error {
 	c.httpClient.CloseIdleConnections()
@@ -977,6 +1043,52 @@ func (c *RemoteClient) GetJobLogs(ctx context.Context, jobID string) ([]models.L
 	return lines, nil
 }
 
+// GetLogHistory fetches a page of the daemon's aggregated workspace log
+// history.
+func (c *RemoteClient) GetLogHistory(ctx context.Context, opts models.LogHistoryOptions) (*models.LogHistoryPage, error) {
+	params := url.Values{}
+	if opts.Scope != "" {
+		params.Set("scope", opts.Scope)
+	}
+	if opts.Workspace != "" {
+		params.Set("workspace", opts.Workspace)
+	}
+	if opts.Level != "" {
+		params.Set("level", opts.Level)
+	}
+	if opts.System {
+		params.Set("system", "true")
+	}
+	if !opts.Before.IsZero() {
+		params.Set("before", opts.Before.Format(time.RFC3339Nano))
+	}
+	params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"/api/logs/history?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("log history not available; rebuild and restart groved")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("daemon returned status %d", resp.StatusCode)
+	}
+
+	var page models.LogHistoryPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode log history: %w", err)
+	}
+	return &page, nil
+}
+
 // StreamLogs subscribes to the daemon's aggregated workspace log stream via SSE.
 func (c *RemoteClient) StreamLogs(ctx context.Context, opts models.LogStreamOptions) (<-chan models.LogStreamLine, error) {
 	params := url.Values{}
@@ -1196,6 +1308,41 @@ func (c *RemoteClient) NotifyNoteEvent(ctx context.Context, event models.NoteEve
 	return nil
 }
 
+// IngestLogs posts a batch of log entries to the daemon's ingestion
+// endpoint. A 404 means the running groved binary predates the endpoint;
+// an unknown workspace comes back as 422 with the daemon's message.
+func (c *RemoteClient) IngestLogs(ctx context.Context, ingest models.LogIngestRequest) (*models.LogIngestResponse, error) {
+	body, err := json.Marshal(ingest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entries: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+LogIngestPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send log entries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("daemon does not support log ingestion (upgrade groved)")
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("daemon returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var result models.LogIngestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode ingestion response: %w", err)
+	}
+	return &result, nil
+}
+
 // PublishWorkflowEvent sends a subagent/workflow lifecycle event to the
 // daemon. Modeled on NotifyNoteEvent: fire-and-forget from the caller's
 // perspective. A 404 means the running groved binary predates the
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/grovetools/core/pkg/models"
 )
 
 // shortTempSocket returns a short unix-socket path (macOS caps sun_path length,
@@ -120,3 +124,113 @@ func TestNewRemoteClientSeededDialer(t *testing.T) {
 		t.Fatal("no update received over seeded unix dialer")
 	}
 }
+
+// TestStreamWorkspaceChanges decodes change events and drops frames whose
+// workspace schema version this build cannot read.
+func TestStreamWorkspaceChanges(t *testing.T) {
+	sockPath := shortTempSocket(t)
+	ul, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen unix %s: %v", sockPath, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/workspaces/changes/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"WorkspaceAdded\",\"workspace\":{\"schema_version\":99,\"path\":\"/future\"}}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"WorkspaceRemoved\",\"workspace\":{\"schema_version\":1,\"name\":\"api\",\"path\":\"/src/api\"}}\n\n")
+		if fl, ok := w.(http.Flusher); ok {
+			fl.Flush()
+		}
+		<-r.Context().Done()
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ul)
+	t.Cleanup(func() { srv.Close(); ul.Close() })
+
+	client, err := NewRemoteClient(sockPath)
+	if err != nil {
+		t.Fatalf("NewRemoteClient: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := client.StreamWorkspaceChanges(ctx)
+	if err != nil {
+		t.Fatalf("StreamWorkspaceChanges: %v", err)
+	}
+	select {
+	case c := <-ch:
+		if c.Type != models.WorkspaceRemoved || c.Workspace.Path != "/src/api" || c.Workspace.Name != "api" {
+			t.Fatalf("unexpected change: %+v", c)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no change received")
+	}
+}
+
+// TestStreamWorkspaceChangesOldDaemon reports a daemon without the endpoint
+// as needing a restart rather than as a bare status code.
+func TestStreamWorkspaceChangesOldDaemon(t *testing.T) {
+	sockPath := shortTempSocket(t)
+	serveStreamUnix(t, sockPath)
+
+	client, err := NewRemoteClient(sockPath)
+	if err != nil {
+		t.Fatalf("NewRemoteClient: %v", err)
+	}
+	_, err = client.StreamWorkspaceChanges(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "restart groved") {
+		t.Fatalf("expected restart hint, got %v", err)
+	}
+}
+
+func TestGetLogHistory(t *testing.T) {
+	sockPath := shortTempSocket(t)
+	ul, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen unix %s: %v", sockPath, err)
+	}
+	var query url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/logs/history", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"lines":[{"workspace":"api","workspace_path":"/src/api","line":"{}"}],"remaining":41}`)
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ul)
+	t.Cleanup(func() { srv.Close(); ul.Close() })
+
+	client, err := NewRemoteClient(sockPath)
+	if err != nil {
+		t.Fatalf("NewRemoteClient: %v", err)
+	}
+	before := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	page, err := client.GetLogHistory(context.Background(), models.LogHistoryOptions{
+		Scope: "workspace", Workspace: "/src/api", Before: before, Limit: 500,
+	})
+	if err != nil {
+		t.Fatalf("GetLogHistory: %v", err)
+	}
+	if len(page.Lines) != 1 || page.Lines[0].WorkspacePath != "/src/api" || page.Remaining != 41 {
+		t.Errorf("unexpected page: %+v", page)
+	}
+	if query.Get("before") != "2026-01-02T03:04:05.000000006Z" || query.Get("limit") != "500" || query.Get("workspace") != "/src/api" {
+		t.Errorf("unexpected query: %v", query)
+	}
+}
+
+func TestGetLogHistoryOldDaemon(t *testing.T) {
+	sockPath := shortTempSocket(t)
+	serveStreamUnix(t, sockPath)
+
+	client, err := NewRemoteClient(sockPath)
+	if err != nil {
+		t.Fatalf("NewRemoteClient: %v", err)
+	}
+	_, err = client.GetLogHistory(context.Background(), models.LogHistoryOptions{Limit: 10})
+	if err == nil || !strings.Contains(err.Error(), "restart groved") {
+		t.Fatalf("expected restart hint, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package daemon
+
+import (
+	"slices"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/sessions"
+)
+
+// Session collector sources, as named under daemon.collectors.session.providers.
+const (
+	SessionProviderInteractive = sessions.SourceInteractive
+	SessionProviderFlowJobs    = sessions.SourceFlowJobs
+	SessionProviderOpenCode    = sessions.SourceOpenCode
+)
+
+// SessionProviderNames lists the session collector sources in scan order.
+var SessionProviderNames = []string{SessionProviderInteractive, SessionProviderFlowJobs, SessionProviderOpenCode}
+
+const (
+	// DefaultSessionInterval is the session poll interval used when
+	// daemon.session_interval is unset.
+	DefaultSessionInterval = 2 * time.Second
+
+	// DefaultOpenCodeScanInterval is how often OpenCode storage is scanned
+	// when its provider sets no interval.
+	DefaultOpenCodeScanInterval = 10 * time.Second
+)
+
+// SessionIdleThreshold returns daemon.collectors.session.idle_threshold,
+// the inactivity after which a live session is marked idle, or
+// sessions.DefaultIdleThreshold when it is unset or invalid. "0" disables
+// idle detection.
+func SessionIdleThreshold(cfg *config.Config) time.Duration {
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.Collectors != nil && cfg.Daemon.Collectors.Session != nil &&
+		cfg.Daemon.Collectors.Session.IdleThreshold != "" {
+		if d, err := time.ParseDuration(cfg.Daemon.Collectors.Session.IdleThreshold); err == nil {
+			return max(d, 0)
+		}
+	}
+	return sessions.DefaultIdleThreshold
+}
+
+// SessionDedupPolicy returns the policy that picks which report of an agent
+// the session collector keeps when several sources report it: the one with
+// a PID, then the one from the source listed first in
+// daemon.collectors.session.provider_priority, then the most recent. Sources
+// the setting leaves out, or all of them when it is unset, follow in
+// sessions.DefaultSourcePriority order; unknown names are ignored.
+func SessionDedupPolicy(cfg *config.Config) sessions.DedupPolicy {
+	var priority []string
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.Collectors != nil && cfg.Daemon.Collectors.Session != nil {
+		for _, name := range cfg.Daemon.Collectors.Session.ProviderPriority {
+			if slices.Contains(SessionProviderNames, name) && !slices.Contains(priority, name) {
+				priority = append(priority, name)
+			}
+		}
+	}
+	for _, name := range sessions.DefaultSourcePriority {
+		if !slices.Contains(priority, name) {
+			priority = append(priority, name)
+		}
+	}
+	return sessions.NewDedupPolicy(priority...)
+}
+
+// SessionProviderSettings is the resolved setting of one session source.
+type SessionProviderSettings struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+}
+
+// SessionInterval returns daemon.session_interval, or DefaultSessionInterval
+// when it is unset or invalid.
+func SessionInterval(cfg *config.Config) time.Duration {
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.SessionInterval != "" {
+		if d, err := time.ParseDuration(cfg.Daemon.SessionInterval); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultSessionInterval
+}
+
+// SessionProvider returns whether the session collector scans the named
+// source and how often. Sources are enabled by default; interactive and flow
+// job sessions are scanned every daemon.session_interval and OpenCode every
+// DefaultOpenCodeScanInterval unless the provider sets its own interval.
+func SessionProvider(cfg *config.Config, name string) SessionProviderSettings {
+	s := SessionProviderSettings{Enabled: true, Interval: SessionInterval(cfg)}
+	if name == SessionProviderOpenCode {
+		s.Interval = DefaultOpenCodeScanInterval
+	}
+
+	p := sessionProviderConfig(cfg, name)
+	if p == nil {
+		return s
+	}
+	if p.Enabled != nil {
+		s.Enabled = *p.Enabled
+	}
+	if p.Interval != "" {
+		if d, err := time.ParseDuration(p.Interval); err == nil && d > 0 {
+			s.Interval = d
+		}
+	}
+	return s
+}
+
+// SessionProviders resolves every session source, keyed by name.
+func SessionProviders(cfg *config.Config) map[string]SessionProviderSettings {
+	out := make(map[string]SessionProviderSettings, len(SessionProviderNames))
+	for _, name := range SessionProviderNames {
+		out[name] = SessionProvider(cfg, name)
+	}
+	return out
+}
+
+func sessionProviderConfig(cfg *config.Config, name string) *config.SessionProviderConfig {
+	if cfg == nil || cfg.Daemon == nil || cfg.Daemon.Collectors == nil ||
+		cfg.Daemon.Collectors.Session == nil || cfg.Daemon.Collectors.Session.Providers == nil {
+		return nil
+	}
+	providers := cfg.Daemon.Collectors.Session.Providers
+	switch name {
+	case SessionProviderInteractive:
+		return providers.Interactive
+	case SessionProviderFlowJobs:
+		return providers.FlowJobs
+	case SessionProviderOpenCode:
+		return providers.OpenCode
+	}
+	return nil
+}
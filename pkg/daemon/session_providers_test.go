@@ -0,0 +1,102 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/sessions"
+)
+
+func TestSessionProvider(t *testing.T) {
+	disabled := false
+	cfg := &config.Config{Daemon: &config.DaemonConfig{
+		SessionInterval: "5s",
+		Collectors: &config.DaemonCollectorsConfig{Session: &config.SessionCollectorConfig{
+			Providers: &config.SessionProvidersConfig{
+				FlowJobs: &config.SessionProviderConfig{Interval: "1m"},
+				OpenCode: &config.SessionProviderConfig{Enabled: &disabled, Interval: "soon"},
+			},
+		}},
+	}}
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		provider string
+		want     SessionProviderSettings
+	}{
+		{"interactive default", nil, SessionProviderInteractive, SessionProviderSettings{true, DefaultSessionInterval}},
+		{"opencode default", nil, SessionProviderOpenCode, SessionProviderSettings{true, DefaultOpenCodeScanInterval}},
+		{"follows session_interval", cfg, SessionProviderInteractive, SessionProviderSettings{true, 5 * time.Second}},
+		{"own interval", cfg, SessionProviderFlowJobs, SessionProviderSettings{true, time.Minute}},
+		{"disabled with invalid interval", cfg, SessionProviderOpenCode, SessionProviderSettings{false, DefaultOpenCodeScanInterval}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SessionProvider(tt.cfg, tt.provider); got != tt.want {
+				t.Errorf("SessionProvider = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := SessionProviders(cfg); len(got) != len(SessionProviderNames) || got[SessionProviderOpenCode].Enabled {
+		t.Errorf("SessionProviders = %+v", got)
+	}
+}
+
+func TestSessionIdleThreshold(t *testing.T) {
+	withThreshold := func(v string) *config.Config {
+		return &config.Config{Daemon: &config.DaemonConfig{Collectors: &config.DaemonCollectorsConfig{
+			Session: &config.SessionCollectorConfig{IdleThreshold: v},
+		}}}
+	}
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want time.Duration
+	}{
+		{"unset", nil, sessions.DefaultIdleThreshold},
+		{"configured", withThreshold("30m"), 30 * time.Minute},
+		{"disabled", withThreshold("0"), 0},
+		{"invalid", withThreshold("a while"), sessions.DefaultIdleThreshold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SessionIdleThreshold(tt.cfg); got != tt.want {
+				t.Errorf("SessionIdleThreshold = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionDedupPolicy(t *testing.T) {
+	hook := &models.Session{ID: "hook", ClaudeSessionID: "native", PID: 1}
+	job := &models.Session{ID: "job", ClaudeSessionID: "native", PID: 1, JobFilePath: "/plans/p/01-job.md"}
+	open := &models.Session{ID: "oc", ClaudeSessionID: "native", PID: 1, Provider: "opencode"}
+
+	withPriority := func(names ...string) *config.Config {
+		return &config.Config{Daemon: &config.DaemonConfig{Collectors: &config.DaemonCollectorsConfig{
+			Session: &config.SessionCollectorConfig{ProviderPriority: names},
+		}}}
+	}
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		a, b *models.Session
+		want string
+	}{
+		{"default prefers interactive", nil, job, hook, "hook"},
+		{"flow jobs first", withPriority(SessionProviderFlowJobs), hook, job, "job"},
+		{"unlisted sources follow the default order", withPriority(SessionProviderOpenCode), job, hook, "hook"},
+		{"listed source beats the rest", withPriority(SessionProviderOpenCode), hook, open, "oc"},
+		{"unknown names are ignored", withPriority("tmux", SessionProviderFlowJobs), hook, job, "job"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SessionDedupPolicy(tt.cfg).Prefer(tt.a, tt.b); got.ID != tt.want {
+				t.Errorf("kept %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package daemon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/version"
+)
+
+// SnapshotFormat is the version of the snapshot archive layout, bumped when
+// a section changes shape.
+const SnapshotFormat = 1
+
+// DefaultSnapshotEvents is how many recent log entries CaptureSnapshot
+// scans for events when SnapshotOptions.Events is zero.
+const DefaultSnapshotEvents = 2000
+
+// Snapshot is the daemon state captured for a bug report: the store
+// contents as clients see them, the collector intervals, recent events and
+// the effective configuration. It is written as a gzipped tar archive with
+// one JSON file per section (see WriteSnapshot) and loaded with
+// ReadSnapshot or LoadSnapshot.
+type Snapshot struct {
+	Manifest SnapshotManifest `json:"manifest"`
+
+	// Collectors is the daemon's running config: the collector intervals
+	// and start time.
+	Collectors *RunningConfig                `json:"collectors,omitempty"`
+	Workspaces []*models.EnrichedWorkspace   `json:"workspaces,omitempty"`
+	Sessions   []*models.Session             `json:"sessions,omitempty"`
+	PlanStats  map[string]*models.PlanStats  `json:"plan_stats,omitempty"`
+	NoteCounts map[string]*models.NoteCounts `json:"note_counts,omitempty"`
+	Workflows  *models.WorkflowSnapshot      `json:"workflows,omitempty"`
+	Jobs       []*models.JobInfo             `json:"jobs,omitempty"`
+	// Events are recent log entries that carry an event field or are at
+	// warn level and above, oldest first.
+	Events []models.LogStreamLine `json:"events,omitempty"`
+	// Config is the effective configuration tree. It is stored as given,
+	// so callers redact secrets before capture.
+	Config interface{} `json:"config,omitempty"`
+}
+
+// SnapshotManifest describes where and when a snapshot was taken and which
+// sections could not be captured.
+type SnapshotManifest struct {
+	Format    int       `json:"format"`
+	CreatedAt time.Time `json:"created_at"`
+	Host      string    `json:"host,omitempty"`
+	Version   string    `json:"version,omitempty"`
+	Commit    string    `json:"commit,omitempty"`
+	// Errors maps a section name to the error that kept it out of the
+	// snapshot (e.g. an endpoint an older daemon does not serve).
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// SnapshotOptions tunes CaptureSnapshot.
+type SnapshotOptions struct {
+	// Events is how many recent log entries to scan for events; zero uses
+	// DefaultSnapshotEvents.
+	Events int
+	// Config is the (redacted) effective configuration to include.
+	Config interface{}
+}
+
+// CaptureSnapshot reads the daemon's state through client. Sections are
+// captured independently: one that fails is recorded in Manifest.Errors and
+// left empty rather than failing the snapshot.
+func CaptureSnapshot(ctx context.Context, client Client, opts SnapshotOptions) *Snapshot {
+	info := version.GetInfo()
+	host, _ := os.Hostname()
+	s := &Snapshot{
+		Manifest: SnapshotManifest{
+			Format:    SnapshotFormat,
+			CreatedAt: time.Now().UTC(),
+			Host:      host,
+			Version:   info.Version,
+			Commit:    info.Commit,
+		},
+		Config: opts.Config,
+	}
+	record := func(section string, err error) {
+		if err == nil {
+			return
+		}
+		if s.Manifest.Errors == nil {
+			s.Manifest.Errors = make(map[string]string)
+		}
+		s.Manifest.Errors[section] = err.Error()
+	}
+
+	var err error
+	s.Collectors, err = client.GetConfig(ctx)
+	record("collectors", err)
+	s.Workspaces, err = client.GetEnrichedWorkspaces(ctx, nil)
+	record("workspaces", err)
+	s.Sessions, err = client.GetSessions(ctx)
+	record("sessions", err)
+	s.PlanStats, err = client.GetPlanStats(ctx)
+	record("plan_stats", err)
+	s.NoteCounts, err = client.GetNoteCounts(ctx)
+	record("note_counts", err)
+	s.Workflows, err = client.GetWorkflowSnapshot(ctx)
+	record("workflows", err)
+	s.Jobs, err = client.ListJobs(ctx, models.JobFilter{})
+	record("jobs", err)
+
+	limit := opts.Events
+	if limit <= 0 {
+		limit = DefaultSnapshotEvents
+	}
+	page, err := client.GetLogHistory(ctx, models.LogHistoryOptions{Scope: "all", System: true, Limit: limit})
+	record("events", err)
+	if page != nil {
+		for _, line := range page.Lines {
+			if isSnapshotEvent(line.Line) {
+				s.Events = append(s.Events, line)
+			}
+		}
+	}
+	return s
+}
+
+// isSnapshotEvent reports whether a raw log line carries an event field or
+// is at warn level and above, the entries the logs viewer's events-only
+// mode shows.
+func isSnapshotEvent(line string) bool {
+	var entry struct {
+		Event string `json:"event"`
+		Level string `json:"level"`
+	}
+	if json.Unmarshal([]byte(line), &entry) != nil {
+		return false
+	}
+	switch entry.Level {
+	case "warn", "warning", "error", "fatal", "panic":
+		return true
+	}
+	return entry.Event != ""
+}
+
+// snapshotSection is one file of the archive and the field it holds.
+type snapshotSection struct {
+	name  string
+	value interface{}
+}
+
+// sections lists the archive files in write order.
+func (s *Snapshot) sections() []snapshotSection {
+	return []snapshotSection{
+		{"manifest.json", &s.Manifest},
+		{"collectors.json", &s.Collectors},
+		{"workspaces.json", &s.Workspaces},
+		{"sessions.json", &s.Sessions},
+		{"plan_stats.json", &s.PlanStats},
+		{"note_counts.json", &s.NoteCounts},
+		{"workflows.json", &s.Workflows},
+		{"jobs.json", &s.Jobs},
+		{"events.json", &s.Events},
+		{"config.json", &s.Config},
+	}
+}
+
+// WriteSnapshot writes s to w as a gzipped tar archive with one indented
+// JSON file per section.
+func WriteSnapshot(w io.Writer, s *Snapshot) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := s.Manifest.CreatedAt
+	for _, section := range s.sections() {
+		data, err := json.MarshalIndent(section.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", section.name, err)
+		}
+		data = append(data, '\n')
+		hdr := &tar.Header{Name: section.name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadSnapshot loads a snapshot archive written by WriteSnapshot. Unknown
+// files are skipped and missing sections stay empty, so archives from
+// other versions still load.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a snapshot archive: %w", err)
+	}
+	defer gz.Close()
+
+	s := &Snapshot{}
+	sections := make(map[string]interface{})
+	for _, section := range s.sections() {
+		sections[section.name] = section.value
+	}
+	tr := tar.NewReader(gz)
+	found := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+		target, ok := sections[path.Base(hdr.Name)]
+		if !ok {
+			continue
+		}
+		if err := json.NewDecoder(tr).Decode(target); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", hdr.Name, err)
+		}
+		found = found || path.Base(hdr.Name) == "manifest.json"
+	}
+	if !found {
+		return nil, fmt.Errorf("not a snapshot archive: manifest.json is missing")
+	}
+	return s, nil
+}
+
+// LoadSnapshot reads the snapshot archive at file.
+func LoadSnapshot(file string) (*Snapshot, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSnapshot(f)
+}
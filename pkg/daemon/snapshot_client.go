@@ -0,0 +1,213 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// SnapshotEnv names a snapshot archive to replay in place of the daemon:
+// while it is set, the client factories (New, NewWithAutoStart and the
+// rest) return a SnapshotClient serving it instead of connecting to or
+// starting groved. Combined with a throwaway GROVE_HOME, this runs any
+// grove tool against the state captured in a bug report.
+const SnapshotEnv = "GROVE_DAEMON_SNAPSHOT"
+
+// SnapshotClient is a Client that replays a Snapshot: reads return the
+// captured store contents, StreamState sends them as one "initial" update
+// and StreamLogs replays the captured events. Methods the snapshot does
+// not cover fall through to the embedded LocalClient, so they act on the
+// (sandboxed) local state.
+type SnapshotClient struct {
+	*LocalClient
+	snap *Snapshot
+}
+
+// NewSnapshotClient returns a Client replaying snap.
+func NewSnapshotClient(snap *Snapshot) *SnapshotClient {
+	return &SnapshotClient{LocalClient: NewLocalClient(), snap: snap}
+}
+
+// snapshotClientFromEnv returns the client for SnapshotEnv, or nil when it
+// is unset or the archive cannot be loaded (logged, then the factory
+// carries on as usual).
+func snapshotClientFromEnv() Client {
+	file := os.Getenv(SnapshotEnv)
+	if file == "" {
+		return nil
+	}
+	snap, err := LoadSnapshot(file)
+	if err != nil {
+		logging.NewUnifiedLogger("daemon.factory").Warn("failed to load daemon snapshot").
+			Field("file", file).
+			Err(err).
+			Log(context.Background())
+		return nil
+	}
+	return NewSnapshotClient(snap)
+}
+
+// Snapshot returns the snapshot being replayed.
+func (c *SnapshotClient) Snapshot() *Snapshot {
+	return c.snap
+}
+
+// GetWorkspaces returns the captured workspaces.
+func (c *SnapshotClient) GetWorkspaces(ctx context.Context) ([]*workspace.WorkspaceNode, error) {
+	nodes := make([]*workspace.WorkspaceNode, 0, len(c.snap.Workspaces))
+	for _, ws := range c.snap.Workspaces {
+		if ws != nil && ws.WorkspaceNode != nil {
+			nodes = append(nodes, ws.WorkspaceNode)
+		}
+	}
+	return nodes, nil
+}
+
+// GetEnrichedWorkspaces returns the captured workspaces with their
+// enrichment as it was at capture time; opts is ignored.
+func (c *SnapshotClient) GetEnrichedWorkspaces(ctx context.Context, opts *models.EnrichmentOptions) ([]*models.EnrichedWorkspace, error) {
+	return c.snap.Workspaces, nil
+}
+
+// GetPlanStats returns the captured plan statistics.
+func (c *SnapshotClient) GetPlanStats(ctx context.Context) (map[string]*models.PlanStats, error) {
+	return c.snap.PlanStats, nil
+}
+
+// GetNoteCounts returns the captured note counts.
+func (c *SnapshotClient) GetNoteCounts(ctx context.Context) (map[string]*models.NoteCounts, error) {
+	return c.snap.NoteCounts, nil
+}
+
+// GetSessions returns the captured sessions.
+func (c *SnapshotClient) GetSessions(ctx context.Context) ([]*models.Session, error) {
+	return c.snap.Sessions, nil
+}
+
+// GetSession returns a captured session by ID.
+func (c *SnapshotClient) GetSession(ctx context.Context, sessionID string) (*models.Session, error) {
+	for _, s := range c.snap.Sessions {
+		if s != nil && s.ID == sessionID {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("session not found in snapshot: %s", sessionID)
+}
+
+// GetConfig returns the captured collector intervals.
+func (c *SnapshotClient) GetConfig(ctx context.Context) (*RunningConfig, error) {
+	if c.snap.Collectors == nil {
+		return nil, fmt.Errorf("snapshot has no daemon config")
+	}
+	return c.snap.Collectors, nil
+}
+
+// GetWorkflowSnapshot returns the captured workflow run state.
+func (c *SnapshotClient) GetWorkflowSnapshot(ctx context.Context) (*models.WorkflowSnapshot, error) {
+	if c.snap.Workflows == nil {
+		return nil, ErrWorkflowSnapshotUnavailable
+	}
+	return c.snap.Workflows, nil
+}
+
+// GetJob returns a captured job by ID.
+func (c *SnapshotClient) GetJob(ctx context.Context, jobID string) (*models.JobInfo, error) {
+	for _, j := range c.snap.Jobs {
+		if j != nil && j.ID == jobID {
+			return j, nil
+		}
+	}
+	return nil, fmt.Errorf("job not found in snapshot: %s", jobID)
+}
+
+// ListJobs returns the captured jobs matching filter.
+func (c *SnapshotClient) ListJobs(ctx context.Context, filter models.JobFilter) ([]*models.JobInfo, error) {
+	var jobs []*models.JobInfo
+	for _, j := range c.snap.Jobs {
+		if j == nil || (filter.Status != "" && j.Status != filter.Status) {
+			continue
+		}
+		jobs = append(jobs, j)
+		if filter.Limit > 0 && len(jobs) == filter.Limit {
+			break
+		}
+	}
+	return jobs, nil
+}
+
+// StreamState sends the captured workspaces and sessions as one "initial"
+// update and closes the channel when ctx is done.
+func (c *SnapshotClient) StreamState(ctx context.Context) (<-chan StateUpdate, error) {
+	ch := make(chan StateUpdate, 1)
+	ch <- StateUpdate{
+		UpdateType: "initial",
+		Source:     "snapshot",
+		Workspaces: c.snap.Workspaces,
+		Sessions:   c.snap.Sessions,
+	}
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// StreamLogs replays the captured events, oldest first, and closes the
+// channel when ctx is done. The captured lines are not re-filtered by
+// opts.
+func (c *SnapshotClient) StreamLogs(ctx context.Context, opts models.LogStreamOptions) (<-chan models.LogStreamLine, error) {
+	ch := make(chan models.LogStreamLine)
+	go func() {
+		defer close(ch)
+		for _, line := range c.snap.Events {
+			select {
+			case ch <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return ch, nil
+}
+
+// GetLogHistory pages through the captured events: the newest opts.Limit
+// entries at or before opts.Before.
+func (c *SnapshotClient) GetLogHistory(ctx context.Context, opts models.LogHistoryOptions) (*models.LogHistoryPage, error) {
+	end := len(c.snap.Events)
+	if !opts.Before.IsZero() {
+		end = 0
+		for i, line := range c.snap.Events {
+			if t, ok := snapshotLineTime(line.Line); !ok || !t.After(opts.Before) {
+				end = i + 1
+			}
+		}
+	}
+	start := max(end-opts.Limit, 0)
+	return &models.LogHistoryPage{
+		Lines:     append([]models.LogStreamLine(nil), c.snap.Events[start:end]...),
+		Remaining: start,
+	}, nil
+}
+
+// snapshotLineTime parses the time field of a raw log line.
+func snapshotLineTime(line string) (time.Time, bool) {
+	var entry struct {
+		Time interface{} `json:"time"`
+	}
+	if json.Unmarshal([]byte(line), &entry) != nil {
+		return time.Time{}, false
+	}
+	return logging.ParseTime(entry.Time, "")
+}
+
+// IsRunning reports true: the snapshot stands in for a running daemon.
+func (c *SnapshotClient) IsRunning() bool {
+	return true
+}
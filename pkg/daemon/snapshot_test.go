@@ -0,0 +1,164 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// snapshotSource serves fixed state for CaptureSnapshot; jobs are not
+// supported, as on an older daemon.
+type snapshotSource struct {
+	*LocalClient
+	lines []models.LogStreamLine
+}
+
+func (c *snapshotSource) GetConfig(ctx context.Context) (*RunningConfig, error) {
+	return &RunningConfig{GitInterval: 10 * time.Second}, nil
+}
+
+func (c *snapshotSource) GetEnrichedWorkspaces(ctx context.Context, opts *models.EnrichmentOptions) ([]*models.EnrichedWorkspace, error) {
+	return []*models.EnrichedWorkspace{{WorkspaceNode: &workspace.WorkspaceNode{Name: "api", Path: "/src/api"}}}, nil
+}
+
+func (c *snapshotSource) GetSessions(ctx context.Context) ([]*models.Session, error) {
+	return []*models.Session{{ID: "s1", Status: "running"}}, nil
+}
+
+func (c *snapshotSource) GetPlanStats(ctx context.Context) (map[string]*models.PlanStats, error) {
+	return nil, nil
+}
+
+func (c *snapshotSource) GetNoteCounts(ctx context.Context) (map[string]*models.NoteCounts, error) {
+	return nil, nil
+}
+
+func (c *snapshotSource) ListJobs(ctx context.Context, filter models.JobFilter) ([]*models.JobInfo, error) {
+	return nil, ErrNotSupported
+}
+
+func (c *snapshotSource) GetLogHistory(ctx context.Context, opts models.LogHistoryOptions) (*models.LogHistoryPage, error) {
+	return &models.LogHistoryPage{Lines: c.lines}, nil
+}
+
+func logLine(sec int, level, event string) models.LogStreamLine {
+	line := fmt.Sprintf(`{"time":"2026-01-01T12:00:%02dZ","level":%q,"msg":"m%d"`, sec, level, sec)
+	if event != "" {
+		line += fmt.Sprintf(`,"event":%q`, event)
+	}
+	return models.LogStreamLine{Workspace: "api", Line: line + "}"}
+}
+
+func TestCaptureSnapshotRoundTrip(t *testing.T) {
+	src := &snapshotSource{LocalClient: NewLocalClient(), lines: []models.LogStreamLine{
+		logLine(1, "info", ""),
+		logLine(2, "info", "startup"),
+		logLine(3, "error", ""),
+	}}
+	snap := CaptureSnapshot(context.Background(), src, SnapshotOptions{Config: map[string]interface{}{"name": "api"}})
+
+	if len(snap.Events) != 2 {
+		t.Fatalf("expected the event and the error entry, got %d events", len(snap.Events))
+	}
+	if _, ok := snap.Manifest.Errors["jobs"]; !ok {
+		t.Errorf("expected the jobs failure recorded, got %v", snap.Manifest.Errors)
+	}
+	if _, ok := snap.Manifest.Errors["workflows"]; !ok {
+		t.Errorf("expected the workflows failure recorded, got %v", snap.Manifest.Errors)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, snap); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Manifest.Format != SnapshotFormat || loaded.Collectors == nil || loaded.Collectors.GitInterval != 10*time.Second {
+		t.Errorf("manifest or collectors not restored: %+v %+v", loaded.Manifest, loaded.Collectors)
+	}
+	if len(loaded.Workspaces) != 1 || loaded.Workspaces[0].Name != "api" {
+		t.Errorf("workspaces not restored: %+v", loaded.Workspaces)
+	}
+	if len(loaded.Sessions) != 1 || len(loaded.Events) != 2 || loaded.Manifest.Errors["jobs"] == "" {
+		t.Errorf("sections not restored: %d sessions, %d events, errors %v", len(loaded.Sessions), len(loaded.Events), loaded.Manifest.Errors)
+	}
+	if cfg, ok := loaded.Config.(map[string]interface{}); !ok || cfg["name"] != "api" {
+		t.Errorf("config not restored: %v", loaded.Config)
+	}
+}
+
+func TestReadSnapshotRejectsOtherArchives(t *testing.T) {
+	if _, err := ReadSnapshot(bytes.NewReader([]byte("plain text"))); err == nil {
+		t.Error("expected an error for a non-gzip file")
+	}
+}
+
+func TestSnapshotClientReplaysState(t *testing.T) {
+	snap := &Snapshot{
+		Workspaces: []*models.EnrichedWorkspace{{WorkspaceNode: &workspace.WorkspaceNode{Name: "api"}}},
+		Sessions:   []*models.Session{{ID: "s1"}},
+		Events:     []models.LogStreamLine{logLine(1, "warn", ""), logLine(2, "info", "a"), logLine(3, "info", "b")},
+	}
+	c := NewSnapshotClient(snap)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes, _ := c.GetWorkspaces(ctx)
+	if len(nodes) != 1 || nodes[0].Name != "api" {
+		t.Errorf("unexpected workspaces: %v", nodes)
+	}
+	if _, err := c.GetSession(ctx, "missing"); err == nil {
+		t.Error("expected an error for an unknown session")
+	}
+	if _, err := c.GetWorkflowSnapshot(ctx); !errors.Is(err, ErrWorkflowSnapshotUnavailable) {
+		t.Errorf("expected ErrWorkflowSnapshotUnavailable, got %v", err)
+	}
+
+	updates, _ := c.StreamState(ctx)
+	if u := <-updates; u.UpdateType != "initial" || len(u.Sessions) != 1 {
+		t.Errorf("unexpected initial update: %+v", u)
+	}
+
+	page, _ := c.GetLogHistory(ctx, models.LogHistoryOptions{Limit: 1, Before: time.Date(2026, 1, 1, 12, 0, 2, 0, time.UTC)})
+	if len(page.Lines) != 1 || page.Lines[0].Line != snap.Events[1].Line || page.Remaining != 1 {
+		t.Errorf("unexpected history page: %+v", page)
+	}
+
+	lines, _ := c.StreamLogs(ctx, models.LogStreamOptions{})
+	for i := range snap.Events {
+		if got := <-lines; got.Line != snap.Events[i].Line {
+			t.Errorf("line %d: got %s", i, got.Line)
+		}
+	}
+}
+
+func TestFactoryServesSnapshotFromEnv(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "snap.tgz")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSnapshot(f, &Snapshot{Manifest: SnapshotManifest{Format: SnapshotFormat}}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	t.Setenv(SnapshotEnv, file)
+	if _, ok := New().(*SnapshotClient); !ok {
+		t.Error("expected New to return the snapshot client")
+	}
+	t.Setenv(SnapshotEnv, filepath.Join(t.TempDir(), "missing.tgz"))
+	if _, ok := New().(*SnapshotClient); ok {
+		t.Error("expected a missing archive to fall back")
+	}
+}
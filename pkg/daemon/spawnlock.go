@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// spawnLockPoll is how often a waiter retries the spawn lock.
+const spawnLockPoll = 25 * time.Millisecond
+
+// acquireSpawnLock serializes on-demand daemon starts for one scope across
+// processes, so several clients racing a cold socket start exactly one
+// groved. The lock lives next to the scope's pidfile. It returns a release
+// func, or false when the lock could not be taken within timeout (the
+// holder is still waiting on its own spawn).
+func acquireSpawnLock(pidPath string, timeout time.Duration) (func(), bool) {
+	lockPath := pidPath + ".spawn.lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o755); err != nil {
+		return nil, false
+	}
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, false
+	}
+
+	deadline := time.Now().Add(timeout)
+	for !tryLockFile(f) {
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, false
+		}
+		time.Sleep(spawnLockPoll)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, true
+}
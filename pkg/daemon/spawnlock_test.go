@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestConcurrentAutoStartSpawnsOnce races several auto-start clients at a
+// cold scope: the spawn lock must let exactly one of them launch groved and
+// hand every caller a client for that one daemon.
+func TestConcurrentAutoStartSpawnsOnce(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("GROVE_HOME", tmp)
+
+	binDir := filepath.Join(tmp, "bin")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(self, filepath.Join(binDir, "groved")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	spawnLog := filepath.Join(tmp, "spawns.log")
+	t.Setenv("GROVE_FAKE_GROVED", "1")
+	t.Setenv("GROVE_FAKE_SPAWNLOG", spawnLog)
+
+	scopeDir := filepath.Join(tmp, "scope")
+	if err := os.MkdirAll(scopeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	const callers = 4
+	clients := make([]Client, callers)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = NewWithAutoStart(scopeDir)
+		}(i)
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(spawnLog)
+	pids := strings.Fields(string(data))
+	t.Cleanup(func() {
+		for _, c := range clients {
+			if c != nil {
+				_ = c.Close()
+			}
+		}
+		for _, p := range pids {
+			if pid, err := strconv.Atoi(p); err == nil {
+				if proc, err := os.FindProcess(pid); err == nil {
+					_ = proc.Signal(syscall.SIGTERM)
+				}
+			}
+		}
+	})
+
+	if len(pids) != 1 {
+		t.Fatalf("expected exactly one daemon spawn, got %d (%v)", len(pids), pids)
+	}
+	for i, c := range clients {
+		if _, ok := c.(*RemoteClient); !ok {
+			t.Errorf("caller %d got %T, want a RemoteClient for the shared daemon", i, c)
+		}
+	}
+}
+
+func TestSpawnLockExcludesSecondHolder(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "groved.pid")
+
+	release, ok := acquireSpawnLock(pidPath, time.Second)
+	if !ok {
+		t.Fatal("first acquire failed")
+	}
+	if _, ok := acquireSpawnLock(pidPath, 100*time.Millisecond); ok {
+		t.Fatal("second acquire succeeded while the lock was held")
+	}
+	release()
+
+	release2, ok := acquireSpawnLock(pidPath, time.Second)
+	if !ok {
+		t.Fatal("acquire after release failed")
+	}
+	release2()
+}
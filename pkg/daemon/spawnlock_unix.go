@@ -0,0 +1,19 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+// tryLockFile takes a non-blocking exclusive flock on f. flock locks belong
+// to the open file description, so two opens of the same path conflict even
+// within one process, and the kernel drops the lock if the holder dies.
+func tryLockFile(f *os.File) bool {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB) == nil
+}
+
+func unlockFile(f *os.File) {
+	_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,15 @@
+package daemon
+
+import "github.com/grovetools/core/logging"
+
+// CollectorComponent is the log component of the daemon's collector timings.
+const CollectorComponent = "groved.collector"
+
+// TimeCollector starts timing one run of the named collector ("git",
+// "session", "workspace", ...) and returns the function that stops it, as
+// logging.Timer does. Runs are logged at trace level as op
+// "collect.<name>" and always kept in the ring buffer, so the daemon's
+// recent entries show how long each collector takes.
+func TimeCollector(name string) func(err error) {
+	return logging.Timer(CollectorComponent, "collect."+name, logging.RecordMetrics())
+}
@@ -0,0 +1,249 @@
+package daemon
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/grovetools/core/config"
+)
+
+// DefaultUpdateQueueSize is the per-priority capacity of an UpdateQueue
+// when daemon.update_queue_size is unset.
+const DefaultUpdateQueueSize = 256
+
+// UpdateQueueSize returns the per-priority capacity the daemon gives the
+// queue between its collectors and the store.
+func UpdateQueueSize(cfg *config.Config) int {
+	if cfg != nil && cfg.Daemon != nil && cfg.Daemon.UpdateQueueSize > 0 {
+		return cfg.Daemon.UpdateQueueSize
+	}
+	return DefaultUpdateQueueSize
+}
+
+// UpdatePriority orders the lanes of an UpdateQueue; a higher priority is
+// delivered first.
+type UpdatePriority int
+
+const (
+	// PriorityLogs is for log-derived updates, the most frequent and the
+	// cheapest to lose.
+	PriorityLogs UpdatePriority = iota
+	// PriorityWorkspaces is for workspace, git, plan and note state.
+	PriorityWorkspaces
+	// PrioritySessions is for session state and for rare control events
+	// (boot phases, config reloads, theme changes) that must not wait
+	// behind a workspace scan.
+	PrioritySessions
+
+	numUpdatePriorities = int(PrioritySessions) + 1
+)
+
+// String returns the lane name used in QueueStats.
+func (p UpdatePriority) String() string {
+	switch p {
+	case PriorityLogs:
+		return "logs"
+	case PriorityWorkspaces:
+		return "workspaces"
+	case PrioritySessions:
+		return "sessions"
+	}
+	return "unknown"
+}
+
+// workspaceUpdateTypes are the update types queued at PriorityWorkspaces.
+var workspaceUpdateTypes = map[string]bool{
+	"full":             true,
+	"workspace":        true,
+	"workspaces":       true,
+	"workspaces_delta": true,
+	"enrichment":       true,
+	"focus":            true,
+	"plans":            true,
+	"watcher_status":   true,
+	"memory_index":     true,
+}
+
+// PriorityOf returns the lane u is queued in: log updates (source "logs"
+// or a "log" update type prefix) go last, workspace state in the middle,
+// and sessions and everything else first.
+func PriorityOf(u StateUpdate) UpdatePriority {
+	switch {
+	case u.Source == "logs" || strings.HasPrefix(u.UpdateType, "log"):
+		return PriorityLogs
+	case workspaceUpdateTypes[u.UpdateType]:
+		return PriorityWorkspaces
+	}
+	return PrioritySessions
+}
+
+// LaneStats counts what an UpdateQueue did in one priority lane.
+type LaneStats struct {
+	Queued uint64 `json:"queued"`
+	// Superseded counts held updates replaced by a newer one of the same
+	// type and source, as the Coalescer would have done downstream.
+	Superseded uint64 `json:"superseded"`
+	// Overflowed counts updates discarded, oldest first, because the lane
+	// was full.
+	Overflowed uint64 `json:"overflowed"`
+	// Depth is the number of updates currently held.
+	Depth int `json:"depth"`
+	// MaxDepth is the largest Depth seen.
+	MaxDepth int `json:"max_depth"`
+}
+
+// QueueStats holds LaneStats by lane name ("sessions", "workspaces",
+// "logs").
+type QueueStats map[string]LaneStats
+
+// UpdateQueue sits between the daemon's collectors and a slower consumer
+// (the store and the Coalescer behind it). Send never blocks, so the
+// fsnotify event loop and the session collector keep running however far
+// behind the consumer falls. Updates wait in a bounded lane per priority
+// and are delivered highest priority first, oldest first within a lane.
+//
+// When a lane is full, a held update of a coalesced type with the same
+// type and source is replaced by the new one; failing that, the lane's
+// oldest update is discarded. Both are counted in Stats, so overflow is
+// visible rather than silent.
+type UpdateQueue struct {
+	size int
+
+	mu     sync.Mutex
+	lanes  [numUpdatePriorities][]StateUpdate
+	stats  [numUpdatePriorities]LaneStats
+	closed bool
+	ready  chan struct{}
+}
+
+// NewUpdateQueue returns a queue holding at most size updates per
+// priority. A size <= 0 uses DefaultUpdateQueueSize.
+func NewUpdateQueue(size int) *UpdateQueue {
+	if size <= 0 {
+		size = DefaultUpdateQueueSize
+	}
+	return &UpdateQueue{size: size, ready: make(chan struct{}, 1)}
+}
+
+// Send queues u at PriorityOf(u) without blocking. It reports false when u
+// was not queued because the queue is closed.
+func (q *UpdateQueue) Send(u StateUpdate) bool {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return false
+	}
+	p := PriorityOf(u)
+	lane, st := q.lanes[p], &q.stats[p]
+	st.Queued++
+	if len(lane) >= q.size {
+		if i := supersedable(lane, u); i >= 0 {
+			lane = append(lane[:i], lane[i+1:]...)
+			st.Superseded++
+		} else {
+			lane = lane[1:]
+			st.Overflowed++
+		}
+	}
+	lane = append(lane, u)
+	q.lanes[p] = lane
+	st.Depth = len(lane)
+	st.MaxDepth = max(st.MaxDepth, st.Depth)
+	q.mu.Unlock()
+
+	q.signal()
+	return true
+}
+
+// supersedable returns the index of the oldest held update that u makes
+// obsolete, or -1. workspaces_delta updates are partial, so they are never
+// superseded.
+func supersedable(lane []StateUpdate, u StateUpdate) int {
+	if !coalescedUpdateTypes[u.UpdateType] || u.UpdateType == "workspaces_delta" {
+		return -1
+	}
+	for i, held := range lane {
+		if held.UpdateType == u.UpdateType && held.Source == u.Source {
+			return i
+		}
+	}
+	return -1
+}
+
+// signal wakes Run without blocking.
+func (q *UpdateQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the queue accepting updates. Run delivers what is held and
+// then closes its channel.
+func (q *UpdateQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.signal()
+}
+
+// Stats returns a snapshot of the per-lane counters.
+func (q *UpdateQueue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stats := make(QueueStats, numUpdatePriorities)
+	for p, st := range q.stats {
+		stats[UpdatePriority(p).String()] = st
+	}
+	return stats
+}
+
+// Run delivers queued updates until the queue is closed and drained or ctx
+// is cancelled. Its result can feed a Coalescer directly.
+func (q *UpdateQueue) Run(ctx context.Context) <-chan StateUpdate {
+	out := make(chan StateUpdate)
+	go q.run(ctx, out)
+	return out
+}
+
+func (q *UpdateQueue) run(ctx context.Context, out chan<- StateUpdate) {
+	defer close(out)
+	for {
+		u, ok, closed := q.next()
+		if !ok {
+			if closed {
+				return
+			}
+			select {
+			case <-q.ready:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case out <- u:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// next pops the oldest update of the highest non-empty priority. ok is
+// false when nothing is held; closed then reports whether nothing ever
+// will be.
+func (q *UpdateQueue) next() (u StateUpdate, ok, closed bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for p := numUpdatePriorities - 1; p >= 0; p-- {
+		if lane := q.lanes[p]; len(lane) > 0 {
+			u = lane[0]
+			lane[0] = StateUpdate{}
+			q.lanes[p] = lane[1:]
+			q.stats[p].Depth = len(q.lanes[p])
+			return u, true, false
+		}
+	}
+	return StateUpdate{}, false, q.closed
+}
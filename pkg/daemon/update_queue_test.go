@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestUpdateQueueSize(t *testing.T) {
+	if got := UpdateQueueSize(nil); got != DefaultUpdateQueueSize {
+		t.Errorf("UpdateQueueSize(nil) = %d", got)
+	}
+	cfg := &config.Config{Daemon: &config.DaemonConfig{UpdateQueueSize: 16}}
+	if got := UpdateQueueSize(cfg); got != 16 {
+		t.Errorf("UpdateQueueSize = %d, want 16", got)
+	}
+}
+
+func TestPriorityOf(t *testing.T) {
+	for _, tt := range []struct {
+		u    StateUpdate
+		want UpdatePriority
+	}{
+		{StateUpdate{UpdateType: "sessions", Source: "session"}, PrioritySessions},
+		{StateUpdate{UpdateType: "config_reload"}, PrioritySessions},
+		{StateUpdate{UpdateType: "workspaces_delta", Source: "git"}, PriorityWorkspaces},
+		{StateUpdate{UpdateType: "plans", Source: "plan"}, PriorityWorkspaces},
+		{StateUpdate{UpdateType: "log_stats"}, PriorityLogs},
+		{StateUpdate{UpdateType: "workspaces_delta", Source: "logs"}, PriorityLogs},
+	} {
+		if got := PriorityOf(tt.u); got != tt.want {
+			t.Errorf("PriorityOf(%s/%s) = %s, want %s", tt.u.UpdateType, tt.u.Source, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateQueueDeliversByPriority(t *testing.T) {
+	q := NewUpdateQueue(8)
+	q.Send(StateUpdate{UpdateType: "log_stats"})
+	q.Send(StateUpdate{UpdateType: "workspaces_delta", Scanned: 1})
+	q.Send(StateUpdate{UpdateType: "sessions"})
+	q.Send(StateUpdate{UpdateType: "workspaces_delta", Scanned: 2})
+	q.Close()
+	if q.Send(StateUpdate{UpdateType: "sessions"}) {
+		t.Error("Send after Close should report false")
+	}
+
+	var got []string
+	for u := range q.Run(context.Background()) {
+		got = append(got, u.UpdateType)
+	}
+	want := []string{"sessions", "workspaces_delta", "workspaces_delta", "log_stats"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestUpdateQueueOverflowNeverBlocks(t *testing.T) {
+	q := NewUpdateQueue(2)
+	// No consumer is running: every Send must still return at once.
+	for i := 0; i < 5; i++ {
+		q.Send(StateUpdate{UpdateType: "workspaces_delta", Scanned: i})
+	}
+	q.Send(StateUpdate{UpdateType: "plans", Source: "plan", Scanned: 10})
+	q.Send(StateUpdate{UpdateType: "sessions", Scanned: 1})
+	q.Send(StateUpdate{UpdateType: "sessions", Scanned: 2})
+	q.Send(StateUpdate{UpdateType: "sessions", Scanned: 3})
+
+	stats := q.Stats()
+	ws, sess := stats["workspaces"], stats["sessions"]
+	if ws.Queued != 6 || ws.Overflowed != 4 || ws.Depth != 2 || ws.MaxDepth != 2 {
+		t.Errorf("workspaces lane stats = %+v", ws)
+	}
+	if sess.Queued != 3 || sess.Superseded != 1 || sess.Overflowed != 0 {
+		t.Errorf("sessions lane stats = %+v", sess)
+	}
+
+	q.Close()
+	var scanned []int
+	for u := range q.Run(context.Background()) {
+		scanned = append(scanned, u.Scanned)
+	}
+	// Sessions first (the first was superseded), then the newest workspace
+	// updates that fit.
+	want := []int{2, 3, 4, 10}
+	if len(scanned) != len(want) {
+		t.Fatalf("delivered %v, want %v", scanned, want)
+	}
+	for i := range want {
+		if scanned[i] != want[i] {
+			t.Fatalf("delivered %v, want %v", scanned, want)
+		}
+	}
+	if d := q.Stats()["workspaces"].Depth; d != 0 {
+		t.Errorf("depth after draining = %d", d)
+	}
+}
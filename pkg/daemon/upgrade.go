@@ -7,8 +7,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"syscall"
 	"time"
+
+	"github.com/grovetools/core/internal/detach"
 )
 
 // UpgradeRunning signals the running daemon to enter drain mode, waits for the socket
@@ -35,7 +36,7 @@ func UpgradeRunning(ctx context.Context, pidFilePath, socketPath, scope string)
 	}
 
 	// Signal the old daemon to enter drain mode
-	if err := syscall.Kill(oldPID, syscall.SIGUSR1); err != nil {
+	if err := signalDrain(oldPID); err != nil {
 		return fmt.Errorf("failed to signal daemon PID %d: %w", oldPID, err)
 	}
 
@@ -81,7 +82,7 @@ socketUnlinked:
 	newDaemon := exec.Command(newBinary, args...)
 	newDaemon.Stdout = nil
 	newDaemon.Stderr = nil
-	newDaemon.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	newDaemon.SysProcAttr = detach.SysProcAttr()
 
 	if err := newDaemon.Start(); err != nil {
 		return fmt.Errorf("failed to start new daemon: %w", err)
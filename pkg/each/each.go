@@ -0,0 +1,207 @@
+// Package each runs one command in many workspace directories with bounded
+// parallelism. Output is streamed line by line with a "[workspace] " prefix
+// and every line is also written as a structured log entry, so a fan-out
+// run can be followed live and searched later with `core logs`.
+package each
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// Component is the logging component of the entries written for a run.
+const Component = "grove.each"
+
+// Options controls a Run.
+type Options struct {
+	// Jobs is the number of workspaces the command runs in at once; zero or
+	// less uses the number of CPUs.
+	Jobs int
+	// Stdout and Stderr receive the prefixed output of the command's
+	// stdout and stderr. Nil writers use os.Stdout and os.Stderr.
+	Stdout io.Writer
+	Stderr io.Writer
+	// FailFast stops starting the command in further workspaces once it
+	// has failed in one; runs already started are left to finish.
+	FailFast bool
+}
+
+// Result is the outcome of the command in one workspace.
+type Result struct {
+	Workspace string        `json:"workspace"`
+	Path      string        `json:"path"`
+	ExitCode  int           `json:"exit_code"`
+	Duration  time.Duration `json:"duration"`
+	// Skipped is set for workspaces never started because of FailFast.
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Failed reports whether the command did not succeed in the workspace.
+func (r Result) Failed() bool {
+	return r.ExitCode != 0 || r.Error != ""
+}
+
+// Run runs argv in the directory of each node and returns one Result per
+// node, in the order of nodes. Cancelling ctx kills the running commands.
+func Run(ctx context.Context, nodes []*workspace.WorkspaceNode, argv []string, opts Options) []Result {
+	if len(argv) == 0 {
+		return nil
+	}
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	ulog := logging.NewUnifiedLogger(Component)
+	var (
+		out     sync.Mutex // serializes lines written to stdout and stderr
+		results = make([]Result, len(nodes))
+		sem     = make(chan struct{}, jobs)
+		wg      sync.WaitGroup
+		failed  bool
+		failMu  sync.Mutex
+	)
+	for i, node := range nodes {
+		sem <- struct{}{}
+		failMu.Lock()
+		stop := opts.FailFast && failed
+		failMu.Unlock()
+		if stop || ctx.Err() != nil {
+			<-sem
+			results[i] = Result{Workspace: node.Name, Path: node.Path, Skipped: true, ExitCode: -1}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, node *workspace.WorkspaceNode) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			lines := func(w io.Writer, stream string) io.WriteCloser {
+				return newLineWriter(func(line string) {
+					out.Lock()
+					fmt.Fprintf(w, "[%s] %s\n", node.Name, line)
+					out.Unlock()
+					ulog.Info(line).
+						Field("workspace", node.Name).
+						Field("workspace_path", node.Path).
+						Field("stream", stream).
+						StructuredOnly().
+						Emit()
+				})
+			}
+			results[i] = runOne(ctx, node, argv, lines(stdout, "stdout"), lines(stderr, "stderr"))
+			r := results[i]
+			entry := ulog.Info("Command finished")
+			if r.Failed() {
+				entry = ulog.Warn("Command failed")
+				failMu.Lock()
+				failed = true
+				failMu.Unlock()
+			}
+			entry.Field("workspace", node.Name).
+				Field("workspace_path", node.Path).
+				Field("command", argv).
+				Field("exit_code", r.ExitCode).
+				Field("duration_ms", r.Duration.Milliseconds()).
+				StructuredOnly().
+				Emit()
+		}(i, node)
+	}
+	wg.Wait()
+	return results
+}
+
+// runOne runs argv in node's directory, streaming its output to the line
+// writers.
+func runOne(ctx context.Context, node *workspace.WorkspaceNode, argv []string, stdout, stderr io.WriteCloser) Result {
+	res := Result{Workspace: node.Name, Path: node.Path}
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...) //nolint:gosec // the user's own command
+	cmd.Dir = node.Path
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	start := time.Now()
+	err := cmd.Run()
+	res.Duration = time.Since(start)
+	_ = stdout.Close()
+	_ = stderr.Close()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			res.ExitCode = exitErr.ExitCode()
+		} else {
+			res.ExitCode = -1
+			res.Error = err.Error()
+		}
+	}
+	return res
+}
+
+// lineWriter calls emit for every complete line written to it; Close
+// flushes a final line without a newline.
+type lineWriter struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+}
+
+func newLineWriter(emit func(line string)) *lineWriter {
+	pr, pw := io.Pipe()
+	w := &lineWriter{pw: pw, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			emit(scanner.Text())
+		}
+		// Drain whatever is left after an over-long line so the command
+		// never blocks on a full pipe.
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+	return w
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) { return w.pw.Write(p) }
+
+func (w *lineWriter) Close() error {
+	err := w.pw.Close()
+	<-w.done
+	return err
+}
+
+// HasAnyTag reports whether the config of node lists at least one of tags.
+// An empty tags list matches every node.
+func HasAnyTag(node *workspace.WorkspaceNode, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	cfg, err := config.LoadFrom(node.Path)
+	if err != nil || cfg == nil {
+		return false
+	}
+	for _, tag := range tags {
+		if slices.Contains(cfg.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package each
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func newNodes(t *testing.T, names ...string) []*workspace.WorkspaceNode {
+	t.Helper()
+	var nodes []*workspace.WorkspaceNode
+	for _, name := range names {
+		nodes = append(nodes, &workspace.WorkspaceNode{Name: name, Path: t.TempDir()})
+	}
+	return nodes
+}
+
+func TestRunPrefixesOutputAndReportsExitCodes(t *testing.T) {
+	nodes := newNodes(t, "api", "web")
+	if err := os.WriteFile(filepath.Join(nodes[1].Path, "fail"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	results := Run(context.Background(), nodes,
+		[]string{"sh", "-c", `echo "in $(basename $PWD)"; echo warn >&2; printf tail; test ! -f fail || exit 3`},
+		Options{Jobs: 2, Stdout: &stdout, Stderr: &stderr})
+
+	if len(results) != 2 || results[0].Workspace != "api" || results[1].Workspace != "web" {
+		t.Fatalf("expected results in node order, got %+v", results)
+	}
+	if results[0].Failed() || results[1].ExitCode != 3 {
+		t.Errorf("unexpected exit codes: %+v", results)
+	}
+	for _, want := range []string{"[api] in " + filepath.Base(nodes[0].Path), "[api] tail", "[web] tail"} {
+		if !strings.Contains(stdout.String(), want+"\n") {
+			t.Errorf("stdout missing %q:\n%s", want, stdout.String())
+		}
+	}
+	if strings.Count(stderr.String(), "] warn\n") != 2 {
+		t.Errorf("expected prefixed stderr from both projects:\n%s", stderr.String())
+	}
+}
+
+func TestRunFailFastSkipsRemaining(t *testing.T) {
+	nodes := newNodes(t, "a", "b", "c")
+	results := Run(context.Background(), nodes, []string{"sh", "-c", "exit 1"},
+		Options{Jobs: 1, FailFast: true, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	if results[0].ExitCode != 1 || !results[1].Skipped || !results[2].Skipped {
+		t.Errorf("expected the first run to fail and the rest to be skipped: %+v", results)
+	}
+}
+
+func TestRunMissingCommand(t *testing.T) {
+	results := Run(context.Background(), newNodes(t, "a"), []string{"definitely-not-a-grove-command"},
+		Options{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}})
+	if !results[0].Failed() || results[0].Error == "" {
+		t.Errorf("expected a start error, got %+v", results[0])
+	}
+}
+
+func TestHasAnyTag(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GROVE_HOME", "")
+	node := newNodes(t, "api")[0]
+	cfg := "version: \"1.0\"\nname: api\ntags: [backend, go]\n"
+	if err := os.WriteFile(filepath.Join(node.Path, "grove.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !HasAnyTag(node, nil) || !HasAnyTag(node, []string{"frontend", "go"}) {
+		t.Error("expected a match on any listed tag")
+	}
+	if HasAnyTag(node, []string{"frontend"}) {
+		t.Error("expected no match without a shared tag")
+	}
+}
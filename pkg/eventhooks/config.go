@@ -0,0 +1,138 @@
+// Package eventhooks runs user-defined commands and webhooks for grove
+// events, such as a session starting or an error being logged.
+//
+// Hooks are configured in the [hooks] extension block, alongside the
+// lifecycle settings owned by grove-hooks:
+//
+//	[hooks]
+//	max_concurrent = 4
+//	timeout = "30s"
+//
+//	[[hooks.on_session_start]]
+//	command = "notify-send \"session started\""
+//
+//	[[hooks.on_error_log]]
+//	url = "https://hooks.example.com/grove"
+//	headers = { Authorization = "Bearer ${GROVE_HOOK_TOKEN}" }
+//
+// The package is a library for the daemon (groved): it parses the block and
+// executes hooks, but nothing in this module fires events. The sources live
+// here — the session registry (pkg/sessions), the log stream and workspace
+// discovery — yet each CLI invocation uses them too, and hooks are meant to
+// run once per event, not once per process that notices it. The daemon
+// watches those sources, builds a Runner with NewRunner and calls
+// Runner.Fire for each event it observes.
+//
+// Every hook receives the same JSON payload: commands on stdin, webhooks as
+// the POST body. Execution is asynchronous, bounded by max_concurrent, and
+// each hook is killed after its timeout.
+package eventhooks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+// Event names, matching the on_<event> config keys.
+const (
+	EventSessionStart        = "session_start"
+	EventSessionEnd          = "session_end"
+	EventErrorLog            = "error_log"
+	EventWorkspaceDiscovered = "workspace_discovered"
+)
+
+const (
+	// DefaultMaxConcurrent bounds how many hooks run at once.
+	DefaultMaxConcurrent = 4
+	// DefaultTimeout bounds a single hook execution.
+	DefaultTimeout = 30 * time.Second
+)
+
+// Hook is one action to run for an event. Exactly one of Command or URL is
+// set.
+type Hook struct {
+	// Command is run with `sh -c`, or `cmd /C` on Windows. The payload is
+	// written to its stdin and the event name is exported as
+	// GROVE_HOOK_EVENT.
+	Command string `yaml:"command,omitempty" toml:"command,omitempty"`
+	// URL receives the payload as a JSON POST.
+	URL string `yaml:"url,omitempty" toml:"url,omitempty"`
+	// Headers are added to the webhook request.
+	Headers map[string]string `yaml:"headers,omitempty" toml:"headers,omitempty"`
+	// Timeout overrides the block-level timeout for this hook.
+	Timeout string `yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+}
+
+// Config is the event-hook subset of the [hooks] extension block.
+type Config struct {
+	MaxConcurrent int    `yaml:"max_concurrent,omitempty" toml:"max_concurrent,omitempty"`
+	Timeout       string `yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+
+	OnSessionStart        []Hook `yaml:"on_session_start,omitempty" toml:"on_session_start,omitempty"`
+	OnSessionEnd          []Hook `yaml:"on_session_end,omitempty" toml:"on_session_end,omitempty"`
+	OnErrorLog            []Hook `yaml:"on_error_log,omitempty" toml:"on_error_log,omitempty"`
+	OnWorkspaceDiscovered []Hook `yaml:"on_workspace_discovered,omitempty" toml:"on_workspace_discovered,omitempty"`
+}
+
+// LoadConfig reads the event hooks from cfg's [hooks] block and validates
+// them. A missing block yields an empty Config.
+func LoadConfig(cfg *config.Config) (Config, error) {
+	var hc Config
+	if cfg == nil {
+		return hc, nil
+	}
+	if err := cfg.UnmarshalExtension("hooks", &hc); err != nil {
+		return hc, fmt.Errorf("failed to parse hooks config: %w", err)
+	}
+	return hc, hc.Validate()
+}
+
+// Hooks returns the hooks configured for event.
+func (c Config) Hooks(event string) []Hook {
+	switch event {
+	case EventSessionStart:
+		return c.OnSessionStart
+	case EventSessionEnd:
+		return c.OnSessionEnd
+	case EventErrorLog:
+		return c.OnErrorLog
+	case EventWorkspaceDiscovered:
+		return c.OnWorkspaceDiscovered
+	default:
+		return nil
+	}
+}
+
+// Validate checks that each hook names one action and that timeouts parse.
+func (c Config) Validate() error {
+	if _, err := parseTimeout(c.Timeout, DefaultTimeout); err != nil {
+		return fmt.Errorf("hooks.timeout: %w", err)
+	}
+	for _, event := range []string{EventSessionStart, EventSessionEnd, EventErrorLog, EventWorkspaceDiscovered} {
+		for i, h := range c.Hooks(event) {
+			if (h.Command == "") == (h.URL == "") {
+				return fmt.Errorf("hooks.on_%s[%d]: set exactly one of command or url", event, i)
+			}
+			if _, err := parseTimeout(h.Timeout, 0); err != nil {
+				return fmt.Errorf("hooks.on_%s[%d].timeout: %w", event, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func parseTimeout(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got %q", s)
+	}
+	return d, nil
+}
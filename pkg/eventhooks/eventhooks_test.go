@@ -0,0 +1,136 @@
+package eventhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := config.LoadFromBytes([]byte(`
+hooks:
+  max_concurrent: 2
+  on_stop: "grove-hooks owns this key"
+  on_session_start:
+    - command: echo hi
+  on_error_log:
+    - url: http://localhost/hook
+      headers:
+        Authorization: Bearer x
+      timeout: 5s
+`))
+	if err != nil {
+		t.Fatalf("LoadFromBytes: %v", err)
+	}
+	hc, err := LoadConfig(cfg)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if hc.MaxConcurrent != 2 || len(hc.Hooks(EventSessionStart)) != 1 {
+		t.Errorf("unexpected config: %+v", hc)
+	}
+	if h := hc.Hooks(EventErrorLog); len(h) != 1 || h[0].Headers["Authorization"] != "Bearer x" {
+		t.Errorf("on_error_log = %+v", h)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []Config{
+		{OnSessionStart: []Hook{{}}},
+		{OnSessionStart: []Hook{{Command: "true", URL: "http://x"}}},
+		{OnErrorLog: []Hook{{Command: "true", Timeout: "soon"}}},
+		{Timeout: "-1s"},
+	}
+	for i, c := range bad {
+		if err := c.Validate(); err == nil {
+			t.Errorf("config %d should be invalid", i)
+		}
+	}
+}
+
+func TestRunnerCommandReceivesPayload(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	r := NewRunner(Config{OnSessionStart: []Hook{{
+		Command: `cat > "` + out + `"; echo "$GROVE_HOOK_EVENT" >> "` + out + `.event"`,
+	}}}, nil)
+	if n := r.Fire(EventSessionStart, map[string]string{"id": "s1"}); n != 1 {
+		t.Fatalf("Fire queued %d hooks, want 1", n)
+	}
+	r.Close()
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	var p struct {
+		Event string            `json:"event"`
+		Data  map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if p.Event != EventSessionStart || p.Data["id"] != "s1" {
+		t.Errorf("payload = %+v", p)
+	}
+	if ev, _ := os.ReadFile(out + ".event"); string(ev) != EventSessionStart+"\n" {
+		t.Errorf("GROVE_HOOK_EVENT = %q", ev)
+	}
+}
+
+func TestRunnerWebhookConcurrencyLimit(t *testing.T) {
+	var inFlight, peak, calls int32
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		mu.Lock()
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		body, _ := io.ReadAll(req.Body)
+		if req.Header.Get("X-Grove-Event") != EventErrorLog || !json.Valid(body) {
+			t.Errorf("bad webhook request: %s %s", req.Header.Get("X-Grove-Event"), body)
+		}
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	hooks := make([]Hook, 6)
+	for i := range hooks {
+		hooks[i] = Hook{URL: srv.URL}
+	}
+	r := NewRunner(Config{MaxConcurrent: 2, OnErrorLog: hooks}, nil)
+	r.Fire(EventErrorLog, nil)
+	r.Close()
+
+	if calls != 6 {
+		t.Errorf("webhook called %d times, want 6", calls)
+	}
+	if peak > 2 {
+		t.Errorf("%d hooks ran at once, want at most 2", peak)
+	}
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	r := NewRunner(Config{OnSessionEnd: []Hook{{Command: "sleep 10", Timeout: "100ms"}}}, nil)
+	start := time.Now()
+	r.Fire(EventSessionEnd, nil)
+	r.Close()
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timed-out hook held the runner for %s", elapsed)
+	}
+	if n := r.Fire(EventSessionEnd, nil); n != 0 {
+		t.Errorf("closed runner queued %d hooks", n)
+	}
+}
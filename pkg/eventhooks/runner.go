@@ -0,0 +1,182 @@
+package eventhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/internal/shell"
+)
+
+// queueFactor sizes the pending-execution queue relative to max_concurrent.
+// Fire drops executions beyond it rather than letting a log storm pile up
+// unbounded work behind slow hooks.
+const queueFactor = 16
+
+// Payload is the JSON document every hook receives.
+type Payload struct {
+	Event string      `json:"event"`
+	Time  time.Time   `json:"time"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+type job struct {
+	hook    Hook
+	payload []byte
+	event   string
+}
+
+// Runner executes hooks for fired events on a bounded worker pool.
+type Runner struct {
+	cfg     Config
+	timeout time.Duration
+	logger  *logrus.Entry
+	client  *http.Client
+
+	jobs      chan job
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	mu        sync.RWMutex
+	closed    bool
+}
+
+// NewRunner starts max_concurrent workers for cfg. cfg should have passed
+// Validate. Close stops the workers.
+func NewRunner(cfg Config, logger *logrus.Entry) *Runner {
+	workers := cfg.MaxConcurrent
+	if workers <= 0 {
+		workers = DefaultMaxConcurrent
+	}
+	timeout, err := parseTimeout(cfg.Timeout, DefaultTimeout)
+	if err != nil {
+		timeout = DefaultTimeout
+	}
+	if logger == nil {
+		logger = logrus.NewEntry(logrus.StandardLogger())
+	}
+	r := &Runner{
+		cfg:     cfg,
+		timeout: timeout,
+		logger:  logger,
+		client:  &http.Client{},
+		jobs:    make(chan job, workers*queueFactor),
+	}
+	for i := 0; i < workers; i++ {
+		r.wg.Add(1)
+		go r.work()
+	}
+	return r
+}
+
+// Fire queues every hook configured for event with data as the payload and
+// returns immediately. It reports how many executions were queued; hooks
+// are dropped with a warning when the queue is full or the runner closed.
+func (r *Runner) Fire(event string, data interface{}) int {
+	hooks := r.cfg.Hooks(event)
+	if len(hooks) == 0 {
+		return 0
+	}
+	payload, err := json.Marshal(Payload{Event: event, Time: time.Now().UTC(), Data: data})
+	if err != nil {
+		r.logger.WithError(err).WithField("event", event).Warn("Failed to encode hook payload")
+		return 0
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return 0
+	}
+	queued := 0
+	for _, h := range hooks {
+		select {
+		case r.jobs <- job{hook: h, payload: payload, event: event}:
+			queued++
+		default:
+			r.logger.WithField("event", event).Warn("Hook queue full, dropping hook execution")
+		}
+	}
+	return queued
+}
+
+// Close stops accepting events and waits for queued hooks to finish.
+func (r *Runner) Close() {
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		r.closed = true
+		close(r.jobs)
+		r.mu.Unlock()
+	})
+	r.wg.Wait()
+}
+
+func (r *Runner) work() {
+	defer r.wg.Done()
+	for j := range r.jobs {
+		if err := r.run(j); err != nil {
+			r.logger.WithError(err).WithField("event", j.event).Warn("Hook failed")
+		}
+	}
+}
+
+// run executes one hook under its timeout.
+func (r *Runner) run(j job) error {
+	timeout, err := parseTimeout(j.hook.Timeout, r.timeout)
+	if err != nil {
+		timeout = r.timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if j.hook.Command != "" {
+		return runCommand(ctx, j)
+	}
+	return r.post(ctx, j)
+}
+
+func runCommand(ctx context.Context, j job) error {
+	cmd := shell.Command(ctx, j.hook.Command)
+	cmd.Stdin = bytes.NewReader(j.payload)
+	cmd.Env = append(os.Environ(), "GROVE_HOOK_EVENT="+j.event)
+	// Children of the shell can outlive it and hold the output pipe open;
+	// stop waiting for them shortly after the timeout kills the shell.
+	cmd.WaitDelay = time.Second
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("command %q timed out", j.hook.Command)
+	}
+	if err != nil {
+		return fmt.Errorf("command %q failed: %w: %s", j.hook.Command, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func (r *Runner) post(ctx context.Context, j job) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, j.hook.URL, bytes.NewReader(j.payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Grove-Event", j.event)
+	for k, v := range j.hook.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s failed: %w", j.hook.URL, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", j.hook.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,509 @@
+// Package fixtures generates synthetic Grove workspace trees and log files
+// for tests and benchmarks. The same Spec and seed always produce the same
+// files, so a tree can be regenerated instead of checked in, and timings
+// taken by downstream tools against it are comparable between runs.
+//
+// A generated root looks like:
+//
+//	<root>/config/grove/grove.yml          global config listing the groves
+//	<root>/ecosystems/<eco>/...            ecosystems with sub-projects
+//	<root>/projects/<project>/...          standalone projects
+//	<root>/state/grove/logs/workspaces/... dated log files per workspace
+//	<root>/fixtures.json                   the Manifest
+//
+// Pointing XDG_CONFIG_HOME and XDG_STATE_HOME at <root>/config and
+// <root>/state (see Manifest.Env) makes discovery and `core logs` see the
+// fixture instead of the user's real setup. Git metadata is written
+// directly rather than by running git, so repositories and worktrees are
+// recognised by discovery but have no commits.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// ManifestFile is the name of the manifest written at the fixture root.
+const ManifestFile = "fixtures.json"
+
+// Spec controls the size and shape of a generated fixture.
+type Spec struct {
+	// Seed drives every random choice; equal seeds give equal trees.
+	Seed uint64 `json:"seed"`
+
+	Ecosystems           int `json:"ecosystems"`
+	ProjectsPerEcosystem int `json:"projects_per_ecosystem"`
+	// EcosystemWorktrees is the number of worktrees of each ecosystem root.
+	EcosystemWorktrees int `json:"ecosystem_worktrees"`
+	Standalone         int `json:"standalone"`
+	// WorktreesPerProject is the number of worktrees of each project,
+	// standalone or inside an ecosystem.
+	WorktreesPerProject int `json:"worktrees_per_project"`
+	// ConfigFormats are the grove config file names projects pick from,
+	// e.g. "grove.yml", "grove.yaml", "grove.toml".
+	ConfigFormats []string `json:"config_formats"`
+
+	Logs LogSpec `json:"logs"`
+}
+
+// LogSpec controls the synthetic log files written for each workspace.
+type LogSpec struct {
+	// EntriesPerWorkspace is the number of entries per workspace; 0 writes
+	// no logs.
+	EntriesPerWorkspace int `json:"entries_per_workspace"`
+	// Start and End bound the entry timestamps. Entries are spread
+	// randomly across the range and split into one file per day.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Format is "json" or "text", as logging.file.format.
+	Format string `json:"format"`
+	// Components are the component names entries are attributed to.
+	Components []string `json:"components"`
+}
+
+// DefaultSpec returns a small fixture: two ecosystems of three projects,
+// two standalone projects, one worktree per project and a day of logs.
+func DefaultSpec() Spec {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return Spec{
+		Seed:                 1,
+		Ecosystems:           2,
+		ProjectsPerEcosystem: 3,
+		EcosystemWorktrees:   1,
+		Standalone:           2,
+		WorktreesPerProject:  1,
+		ConfigFormats:        []string{"grove.yml", "grove.yaml", "grove.toml"},
+		Logs: LogSpec{
+			EntriesPerWorkspace: 200,
+			Start:               start,
+			End:                 start.Add(24 * time.Hour),
+			Format:              "json",
+			Components:          []string{"core", "flow", "daemon", "nb", "cx"},
+		},
+	}
+}
+
+// Manifest describes a generated fixture.
+type Manifest struct {
+	Root string `json:"root"`
+	Spec Spec   `json:"spec"`
+	// Env holds the environment variables that point Grove at the fixture.
+	Env        map[string]string `json:"env"`
+	Workspaces []Workspace       `json:"workspaces"`
+	LogFiles   []string          `json:"log_files,omitempty"`
+	LogEntries int               `json:"log_entries"`
+}
+
+// Workspace is one generated workspace.
+type Workspace struct {
+	Name       string                  `json:"name"`
+	Path       string                  `json:"path"`
+	Kind       workspace.WorkspaceKind `json:"kind"`
+	Identifier string                  `json:"identifier"`
+	ConfigFile string                  `json:"config_file,omitempty"`
+	LogEntries int                     `json:"log_entries,omitempty"`
+}
+
+// Count returns the number of generated workspaces of the given kind.
+func (m *Manifest) Count(kind workspace.WorkspaceKind) int {
+	n := 0
+	for _, w := range m.Workspaces {
+		if w.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// Generate writes the fixture described by spec under root, which must be
+// empty or not exist yet, and returns its manifest. The manifest is also
+// written to root/fixtures.json.
+func Generate(root string, spec Spec) (*Manifest, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve fixture root: %w", err)
+	}
+	if entries, err := os.ReadDir(root); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("fixture root %s is not empty", root)
+	}
+	if len(spec.ConfigFormats) == 0 {
+		spec.ConfigFormats = DefaultSpec().ConfigFormats
+	}
+	for _, name := range spec.ConfigFormats {
+		switch filepath.Ext(name) {
+		case ".yml", ".yaml", ".toml":
+		default:
+			return nil, fmt.Errorf("unsupported config format %q", name)
+		}
+	}
+
+	g := &generator{
+		root: root,
+		spec: spec,
+		rng:  rand.New(rand.NewPCG(spec.Seed, spec.Seed^0x9e3779b97f4a7c15)),
+	}
+	m := &Manifest{
+		Root: root,
+		Spec: spec,
+		Env: map[string]string{
+			"XDG_CONFIG_HOME": filepath.Join(root, "config"),
+			"XDG_STATE_HOME":  filepath.Join(root, "state"),
+		},
+	}
+	g.manifest = m
+
+	if err := g.writeGlobalConfig(); err != nil {
+		return nil, err
+	}
+	for i := 1; i <= spec.Ecosystems; i++ {
+		if err := g.ecosystem(fmt.Sprintf("eco%02d", i)); err != nil {
+			return nil, err
+		}
+	}
+	for i := 1; i <= spec.Standalone; i++ {
+		if err := g.project(filepath.Join(root, "projects"), fmt.Sprintf("proj%02d", i), ""); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.logs(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fixture manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ManifestFile), append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write fixture manifest: %w", err)
+	}
+	return m, nil
+}
+
+type generator struct {
+	root     string
+	spec     Spec
+	rng      *rand.Rand
+	manifest *Manifest
+}
+
+func (g *generator) add(node *workspace.WorkspaceNode, configFile string) {
+	g.manifest.Workspaces = append(g.manifest.Workspaces, Workspace{
+		Name:       node.Name,
+		Path:       node.Path,
+		Kind:       node.Kind,
+		Identifier: node.Identifier("/"),
+		ConfigFile: configFile,
+	})
+}
+
+func (g *generator) writeGlobalConfig() error {
+	content := fmt.Sprintf(`version: "1.0"
+groves:
+  ecosystems:
+    path: %s
+    enabled: true
+  projects:
+    path: %s
+    enabled: true
+`, filepath.Join(g.root, "ecosystems"), filepath.Join(g.root, "projects"))
+	return writeFile(filepath.Join(g.root, "config", "grove", "grove.yml"), content)
+}
+
+func (g *generator) ecosystem(name string) error {
+	path := filepath.Join(g.root, "ecosystems", name)
+	var members []string
+	for i := 1; i <= g.spec.ProjectsPerEcosystem; i++ {
+		members = append(members, fmt.Sprintf("%s-proj%02d", name, i))
+	}
+
+	configFile := g.configFormat()
+	if err := writeFile(filepath.Join(path, configFile), g.groveConfig(configFile, name, members)); err != nil {
+		return err
+	}
+	if err := writeGitRepo(path); err != nil {
+		return err
+	}
+	g.add(&workspace.WorkspaceNode{Name: name, Path: path, Kind: workspace.KindEcosystemRoot}, configFile)
+
+	for i := 1; i <= g.spec.EcosystemWorktrees; i++ {
+		wtName := fmt.Sprintf("eco-feature-%02d", i)
+		wtPath := filepath.Join(path, ".grove-worktrees", wtName)
+		if err := writeFile(filepath.Join(wtPath, configFile), g.groveConfig(configFile, name, nil)); err != nil {
+			return err
+		}
+		if err := writeGitWorktree(path, wtPath, wtName); err != nil {
+			return err
+		}
+		g.add(&workspace.WorkspaceNode{
+			Name:                wtName,
+			Path:                wtPath,
+			Kind:                workspace.KindEcosystemWorktree,
+			ParentProjectPath:   path,
+			ParentEcosystemPath: path,
+		}, configFile)
+	}
+
+	for _, member := range members {
+		if err := g.project(path, member, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// project writes a project under dir and its worktrees. ecosystem is the
+// containing ecosystem's path, or "" for a standalone project.
+func (g *generator) project(dir, name, ecosystem string) error {
+	path := filepath.Join(dir, name)
+	configFile := g.configFormat()
+	if err := writeFile(filepath.Join(path, configFile), g.groveConfig(configFile, name, nil)); err != nil {
+		return err
+	}
+	if err := writeGitRepo(path); err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(path, "README.md"), "# "+name+"\n"); err != nil {
+		return err
+	}
+
+	kind, wtKind := workspace.KindStandaloneProject, workspace.KindStandaloneProjectWorktree
+	if ecosystem != "" {
+		kind, wtKind = workspace.KindEcosystemSubProject, workspace.KindEcosystemSubProjectWorktree
+	}
+	g.add(&workspace.WorkspaceNode{Name: name, Path: path, Kind: kind, ParentEcosystemPath: ecosystem}, configFile)
+
+	for i := 1; i <= g.spec.WorktreesPerProject; i++ {
+		wtName := fmt.Sprintf("feature-%02d", i)
+		wtPath := filepath.Join(path, ".grove-worktrees", wtName)
+		if err := writeFile(filepath.Join(wtPath, configFile), g.groveConfig(configFile, name, nil)); err != nil {
+			return err
+		}
+		if err := writeGitWorktree(path, wtPath, wtName); err != nil {
+			return err
+		}
+		g.add(&workspace.WorkspaceNode{
+			Name:                wtName,
+			Path:                wtPath,
+			Kind:                wtKind,
+			ParentProjectPath:   path,
+			ParentEcosystemPath: ecosystem,
+		}, configFile)
+	}
+	return nil
+}
+
+func (g *generator) configFormat() string {
+	return g.spec.ConfigFormats[g.rng.IntN(len(g.spec.ConfigFormats))]
+}
+
+// groveConfig renders a grove config in the format of file's extension. A
+// random variant adds a description, a logging section or a default
+// notebook so fixtures exercise more than the minimal config.
+func (g *generator) groveConfig(file, name string, members []string) string {
+	variant := g.rng.IntN(4)
+	if filepath.Ext(file) == ".toml" {
+		var b strings.Builder
+		fmt.Fprintf(&b, "version = \"1.0\"\nname = %q\n", name)
+		if len(members) > 0 {
+			fmt.Fprintf(&b, "workspaces = [%s]\n", quoteList(members))
+		}
+		switch variant {
+		case 1:
+			fmt.Fprintf(&b, "description = \"Synthetic project %s\"\n", name)
+		case 2:
+			b.WriteString("\n[logging]\nlevel = \"debug\"\n")
+		case 3:
+			b.WriteString("\n[notebooks.rules]\ndefault = \"main\"\n")
+		}
+		return b.String()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: \"1.0\"\nname: %s\n", name)
+	if len(members) > 0 {
+		fmt.Fprintf(&b, "workspaces: [%s]\n", quoteList(members))
+	}
+	switch variant {
+	case 1:
+		fmt.Fprintf(&b, "description: Synthetic project %s\n", name)
+	case 2:
+		b.WriteString("logging:\n  level: debug\n")
+	case 3:
+		b.WriteString("notebooks:\n  rules:\n    default: main\n")
+	}
+	return b.String()
+}
+
+func quoteList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, s := range items {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+var (
+	logLevels = []struct {
+		level  logrus.Level
+		weight int
+	}{
+		{logrus.DebugLevel, 30},
+		{logrus.InfoLevel, 50},
+		{logrus.WarnLevel, 15},
+		{logrus.ErrorLevel, 5},
+	}
+	logMessages = []string{
+		"Loaded configuration",
+		"Discovered workspaces",
+		"Started job",
+		"Finished job",
+		"Synced notebook",
+		"Refreshed git status",
+		"Retrying request",
+		"Cache miss",
+		"Session attached",
+		"Request failed",
+	}
+)
+
+func (g *generator) level() logrus.Level {
+	total := 0
+	for _, l := range logLevels {
+		total += l.weight
+	}
+	n := g.rng.IntN(total)
+	for _, l := range logLevels {
+		if n < l.weight {
+			return l.level
+		}
+		n -= l.weight
+	}
+	return logrus.InfoLevel
+}
+
+// logs writes LogSpec.EntriesPerWorkspace entries for each workspace into
+// dated files under the fixture's state directory, where `core logs`
+// finds them once XDG_STATE_HOME points at the fixture.
+func (g *generator) logs() error {
+	spec := g.spec.Logs
+	if spec.EntriesPerWorkspace <= 0 {
+		return nil
+	}
+	if !spec.End.After(spec.Start) {
+		return fmt.Errorf("log end %s must be after start %s", spec.End, spec.Start)
+	}
+	components := spec.Components
+	if len(components) == 0 {
+		components = DefaultSpec().Logs.Components
+	}
+	formatter := logging.FileFormatter(&logging.Config{
+		Timezone: "utc",
+		File:     logging.FileSinkConfig{Format: spec.Format},
+	})
+	span := spec.End.Sub(spec.Start)
+
+	for i := range g.manifest.Workspaces {
+		ws := &g.manifest.Workspaces[i]
+		times := make([]time.Time, spec.EntriesPerWorkspace)
+		for j := range times {
+			times[j] = spec.Start.Add(time.Duration(g.rng.Int64N(int64(span)))).UTC()
+		}
+		sort.Slice(times, func(a, b int) bool { return times[a].Before(times[b]) })
+
+		dir := filepath.Join(g.root, "state", "grove", "logs", "workspaces", filepath.FromSlash(ws.Identifier))
+		byFile := make(map[string]*strings.Builder)
+		var files []string
+		for j, t := range times {
+			entry := &logrus.Entry{
+				Time:    t,
+				Level:   g.level(),
+				Message: logMessages[g.rng.IntN(len(logMessages))],
+				Data: logrus.Fields{
+					"component": components[g.rng.IntN(len(components))],
+					"seq":       j,
+				},
+			}
+			line, err := formatter.Format(entry)
+			if err != nil {
+				return fmt.Errorf("failed to format log entry: %w", err)
+			}
+			file := filepath.Join(dir, fmt.Sprintf("workspace-%s.log", t.Format("2006-01-02")))
+			b, ok := byFile[file]
+			if !ok {
+				b = &strings.Builder{}
+				byFile[file] = b
+				files = append(files, file)
+			}
+			b.Write(line)
+		}
+		for _, file := range files {
+			if err := writeFile(file, byFile[file].String()); err != nil {
+				return err
+			}
+			g.manifest.LogFiles = append(g.manifest.LogFiles, file)
+		}
+		ws.LogEntries = spec.EntriesPerWorkspace
+		g.manifest.LogEntries += spec.EntriesPerWorkspace
+	}
+	return nil
+}
+
+// writeGitRepo writes the minimal .git directory discovery and git
+// worktree detection look for.
+func writeGitRepo(path string) error {
+	gitDir := filepath.Join(path, ".git")
+	for name, content := range map[string]string{
+		"HEAD":   "ref: refs/heads/main\n",
+		"config": "[core]\n\trepositoryformatversion = 0\n\tbare = false\n",
+	} {
+		if err := writeFile(filepath.Join(gitDir, name), content); err != nil {
+			return err
+		}
+	}
+	for _, dir := range []string{"objects", "refs/heads", "refs/tags"} {
+		if err := os.MkdirAll(filepath.Join(gitDir, dir), 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// writeGitWorktree links wtPath to owner's repository the way `git
+// worktree add` does: a .git file pointing at owner/.git/worktrees/<name>,
+// which points back.
+func writeGitWorktree(owner, wtPath, name string) error {
+	adminDir := filepath.Join(owner, ".git", "worktrees", name)
+	files := map[string]string{
+		filepath.Join(wtPath, ".git"):        "gitdir: " + adminDir + "\n",
+		filepath.Join(adminDir, "gitdir"):    filepath.Join(wtPath, ".git") + "\n",
+		filepath.Join(adminDir, "HEAD"):      "ref: refs/heads/" + name + "\n",
+		filepath.Join(adminDir, "commondir"): "../..\n",
+	}
+	for path, content := range files {
+		if err := writeFile(path, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package fixtures
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// snapshot reads every file under root, keyed by its path relative to root
+// with the root itself replaced so two fixtures can be compared.
+func snapshot(t *testing.T, root string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		files[rel] = strings.ReplaceAll(string(data), root, "<root>")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	if _, err := Generate(a, DefaultSpec()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(b, DefaultSpec()); err != nil {
+		t.Fatal(err)
+	}
+	sa, sb := snapshot(t, a), snapshot(t, b)
+	if len(sa) != len(sb) {
+		t.Fatalf("file counts differ: %d vs %d", len(sa), len(sb))
+	}
+	for path, content := range sa {
+		if sb[path] != content {
+			t.Errorf("%s differs between runs with the same seed", path)
+		}
+	}
+
+	spec := DefaultSpec()
+	spec.Seed = 2
+	c := t.TempDir()
+	if _, err := Generate(c, spec); err != nil {
+		t.Fatal(err)
+	}
+	sc := snapshot(t, c)
+	same := true
+	for path, content := range sa {
+		if path != ManifestFile && sc[path] != content {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("a different seed should produce different content")
+	}
+}
+
+func TestGenerateCounts(t *testing.T) {
+	spec := DefaultSpec()
+	spec.Logs.EntriesPerWorkspace = 50
+	m, err := Generate(t.TempDir(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[workspace.WorkspaceKind]int{
+		workspace.KindEcosystemRoot:               2,
+		workspace.KindEcosystemWorktree:           2,
+		workspace.KindEcosystemSubProject:         6,
+		workspace.KindEcosystemSubProjectWorktree: 6,
+		workspace.KindStandaloneProject:           2,
+		workspace.KindStandaloneProjectWorktree:   2,
+	}
+	for kind, n := range want {
+		if got := m.Count(kind); got != n {
+			t.Errorf("Count(%s) = %d, want %d", kind, got, n)
+		}
+	}
+	if m.LogEntries != 50*len(m.Workspaces) {
+		t.Errorf("LogEntries = %d, want %d", m.LogEntries, 50*len(m.Workspaces))
+	}
+
+	lines := 0
+	for _, file := range m.LogFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines += strings.Count(string(data), "\n")
+	}
+	if lines != m.LogEntries {
+		t.Errorf("log files hold %d lines, manifest says %d", lines, m.LogEntries)
+	}
+}
+
+func TestGenerateIsDiscoverable(t *testing.T) {
+	root := t.TempDir()
+	m, err := Generate(root, DefaultSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", root)
+	for k, v := range m.Env {
+		t.Setenv(k, v)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logrus.ErrorLevel)
+	projects, err := workspace.NewDiscoveryService(logger).GetProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]workspace.WorkspaceKind)
+	for _, p := range projects {
+		found[p.Path] = p.Kind
+	}
+	for _, w := range m.Workspaces {
+		kind, ok := found[w.Path]
+		if !ok {
+			t.Errorf("%s (%s) was not discovered", w.Identifier, w.ConfigFile)
+			continue
+		}
+		if kind != w.Kind {
+			t.Errorf("%s discovered as %s, want %s", w.Identifier, kind, w.Kind)
+		}
+	}
+}
+
+func TestGenerateRejectsNonEmptyRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "keep"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(root, DefaultSpec()); err == nil {
+		t.Error("expected an error for a non-empty root")
+	}
+}
@@ -0,0 +1,65 @@
+package httpx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// cacheEntry is one cached response, stored as <sha256(url)>.json.
+type cacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+type diskCache struct {
+	dir string
+}
+
+func (d *diskCache) path(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the entry for rawURL, or nil when none is usable.
+func (d *diskCache) load(rawURL string) *cacheEntry {
+	data, err := os.ReadFile(d.path(rawURL))
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil || e.URL != rawURL {
+		return nil
+	}
+	return &e
+}
+
+// store writes e atomically so concurrent fetchers never read a torn file.
+func (d *diskCache) store(e *cacheEntry) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(e.URL))
+}
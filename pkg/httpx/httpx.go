@@ -0,0 +1,279 @@
+// Package httpx is a small HTTP GET client for fetching remote documents
+// (such as extension schemas) from endpoints that may be slow or flaky. It
+// adds a per-request timeout, retries with exponential backoff, a minimum
+// interval between requests to the same host, and an on-disk ETag cache so
+// repeated fetches in CI loops revalidate instead of re-downloading.
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultTimeout bounds a single attempt.
+	DefaultTimeout = 15 * time.Second
+	// DefaultRetries is how many times a failed attempt is retried.
+	DefaultRetries = 3
+	// DefaultBackoff is the delay before the first retry; it doubles for
+	// each further retry up to DefaultMaxBackoff.
+	DefaultBackoff    = 500 * time.Millisecond
+	DefaultMaxBackoff = 10 * time.Second
+)
+
+// Options configures a Client. Zero values take the defaults above; set
+// Retries to a negative value to disable retrying.
+type Options struct {
+	Timeout    time.Duration
+	Retries    int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// MinInterval is the minimum spacing between requests to the same host.
+	MinInterval time.Duration
+	// CacheDir, when set, stores response bodies with their ETag and
+	// Last-Modified validators and revalidates them on later fetches.
+	CacheDir string
+	// StaleOnError returns the cached body when every attempt fails.
+	StaleOnError bool
+	// Transport overrides http.DefaultTransport.
+	Transport http.RoundTripper
+	Logger    *logrus.Entry
+}
+
+// Client fetches URLs according to its Options. It is safe for concurrent use.
+type Client struct {
+	opts  Options
+	http  *http.Client
+	cache *diskCache
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+// StatusError is returned for responses that are neither 200 nor 304.
+type StatusError struct {
+	URL    string
+	Status string
+	Code   int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: %s", e.URL, e.Status)
+}
+
+// New returns a Client for opts.
+func New(opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultRetries
+	} else if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+	if opts.Logger == nil {
+		opts.Logger = logrus.NewEntry(logrus.StandardLogger())
+	}
+	c := &Client{
+		opts:     opts,
+		http:     &http.Client{Transport: opts.Transport},
+		sleep:    sleepCtx,
+		nextSlot: make(map[string]time.Time),
+	}
+	if opts.CacheDir != "" {
+		c.cache = &diskCache{dir: opts.CacheDir}
+	}
+	return c
+}
+
+// Get fetches rawURL and returns its body. Network errors, 429 and 5xx
+// responses are retried; other statuses fail immediately. With a cache, a
+// 304 response returns the cached body.
+func (c *Client) Get(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+
+	var cached *cacheEntry
+	if c.cache != nil {
+		cached = c.cache.load(rawURL)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.opts.Retries; attempt++ {
+		if attempt > 0 {
+			delay := c.backoff(attempt, lastErr)
+			c.opts.Logger.WithError(lastErr).WithField("url", rawURL).
+				Debugf("Retrying in %s (attempt %d/%d)", delay, attempt+1, c.opts.Retries+1)
+			if err := c.sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.wait(ctx, u.Host); err != nil {
+			return nil, err
+		}
+
+		body, err := c.attempt(ctx, rawURL, cached)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if !retryable(err) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if cached != nil && c.opts.StaleOnError {
+		c.opts.Logger.WithError(lastErr).WithField("url", rawURL).Warn("Fetch failed, using cached copy")
+		return cached.Body, nil
+	}
+	return nil, lastErr
+}
+
+// attempt performs one request under the per-attempt timeout.
+func (c *Client) attempt(ctx context.Context, rawURL string, cached *cacheEntry) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return cached.Body, nil
+	case resp.StatusCode != http.StatusOK:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &retryAfterError{
+			StatusError: &StatusError{URL: rawURL, Status: resp.Status, Code: resp.StatusCode},
+			after:       parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", rawURL, err)
+	}
+	if c.cache != nil {
+		entry := &cacheEntry{
+			URL:          rawURL,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		}
+		if entry.ETag != "" || entry.LastModified != "" {
+			if err := c.cache.store(entry); err != nil {
+				c.opts.Logger.WithError(err).Debug("Failed to write HTTP cache entry")
+			}
+		}
+	}
+	return body, nil
+}
+
+// wait blocks until host's next request slot when MinInterval is set.
+func (c *Client) wait(ctx context.Context, host string) error {
+	if c.opts.MinInterval <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	now := time.Now()
+	slot := c.nextSlot[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot[host] = slot.Add(c.opts.MinInterval)
+	c.mu.Unlock()
+	return c.sleep(ctx, time.Until(slot))
+}
+
+// backoff returns the delay before retry number attempt, honouring a
+// server's Retry-After when it asks for longer.
+func (c *Client) backoff(attempt int, lastErr error) time.Duration {
+	d := c.opts.Backoff << (attempt - 1)
+	if d <= 0 || d > c.opts.MaxBackoff {
+		d = c.opts.MaxBackoff
+	}
+	// Up to 20% jitter keeps parallel fetchers from retrying in lockstep.
+	d += time.Duration(rand.Int63n(int64(d)/5 + 1)) //nolint:gosec // jitter, not security
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) && ra.after > d {
+		d = min(ra.after, c.opts.MaxBackoff)
+	}
+	return d
+}
+
+// retryAfterError carries a StatusError and any Retry-After delay.
+type retryAfterError struct {
+	*StatusError
+	after time.Duration
+}
+
+func (e *retryAfterError) Unwrap() error { return e.StatusError }
+
+func retryable(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code == http.StatusTooManyRequests || se.Code >= 500
+	}
+	return true
+}
+
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastClient(opts Options) *Client {
+	opts.Backoff = time.Millisecond
+	opts.MaxBackoff = 5 * time.Millisecond
+	return New(opts)
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	body, err := fastClient(Options{}).Get(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true}`, string(body))
+	assert.EqualValues(t, 3, calls)
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := fastClient(Options{}).Get(context.Background(), srv.URL)
+	var se *StatusError
+	require.True(t, errors.As(err, &se), "want StatusError, got %v", err)
+	assert.Equal(t, http.StatusNotFound, se.Code)
+	assert.EqualValues(t, 1, calls)
+}
+
+func TestGetGivesUpAfterRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := fastClient(Options{Retries: 2}).Get(context.Background(), srv.URL)
+	require.Error(t, err)
+	assert.EqualValues(t, 3, calls)
+}
+
+func TestGetTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	_, err := fastClient(Options{Timeout: 50 * time.Millisecond, Retries: -1}).Get(context.Background(), srv.URL)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestGetRevalidatesWithETag(t *testing.T) {
+	var full, revalidated int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&revalidated, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("schema-v1"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		// A fresh client each time: the cache lives on disk, not in memory.
+		body, err := fastClient(Options{CacheDir: dir}).Get(context.Background(), srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "schema-v1", string(body))
+	}
+	assert.EqualValues(t, 1, full)
+	assert.EqualValues(t, 1, revalidated)
+}
+
+func TestGetStaleOnError(t *testing.T) {
+	healthy := int32(1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("cached"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	_, err := fastClient(Options{CacheDir: dir}).Get(context.Background(), srv.URL)
+	require.NoError(t, err)
+
+	atomic.StoreInt32(&healthy, 0)
+	_, err = fastClient(Options{CacheDir: dir, Retries: -1}).Get(context.Background(), srv.URL)
+	require.Error(t, err, "stale fallback is opt-in")
+
+	body, err := fastClient(Options{CacheDir: dir, Retries: -1, StaleOnError: true}).Get(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "cached", string(body))
+}
+
+func TestMinIntervalSpacesRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c := New(Options{MinInterval: 40 * time.Millisecond})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := c.Get(context.Background(), srv.URL)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	assert.Zero(t, parseRetryAfter(""))
+	assert.Zero(t, parseRetryAfter("soon"))
+}
@@ -33,7 +33,21 @@ type LogKeyMap struct {
 	ComponentSummary key.Binding
 	ClearBuffer      key.Binding
 	CopyRawText      key.Binding
+	CopyAs           key.Binding
+	Actions          key.Binding
 	OpenEditor       key.Binding
+	ToggleSplit      key.Binding
+	Bookmark         key.Binding
+	Annotate         key.Binding
+	NextBookmark     key.Binding
+	ToggleWrap       key.Binding
+	Workspaces       key.Binding
+	ToggleContext    key.Binding
+	ToggleTimestamps key.Binding
+	TogglePinned     key.Binding
+	NextPanel        key.Binding
+	Correlate        key.Binding
+	ClearCorrelation key.Binding
 }
 
 // NewLogKeyMap creates a new LogKeyMap with user configuration applied.
@@ -42,7 +56,7 @@ func NewLogKeyMap(cfg *config.Config) LogKeyMap {
 		Base: keymap.Load(cfg, "core.logs"),
 		PageUp: key.NewBinding(
 			key.WithKeys("pgup"),
-			key.WithHelp("pgup", "page up"),
+			key.WithHelp("pgup", "page up (at top: load older)"),
 		),
 		PageDown: key.NewBinding(
 			key.WithKeys("pgdown"),
@@ -58,7 +72,7 @@ func NewLogKeyMap(cfg *config.Config) LogKeyMap {
 		),
 		GotoTop: key.NewBinding(
 			key.WithKeys("gg"),
-			key.WithHelp("gg", "go to top"),
+			key.WithHelp("gg", "go to top (again: load older)"),
 		),
 		GotoEnd: key.NewBinding(
 			key.WithKeys("G"),
@@ -78,7 +92,7 @@ func NewLogKeyMap(cfg *config.Config) LogKeyMap {
 		),
 		ToggleFollow: key.NewBinding(
 			key.WithKeys("F"),
-			key.WithHelp("F", "toggle follow"),
+			key.WithHelp("F", "cycle follow mode (off/pin/pause/resume)"),
 		),
 		ToggleFilters: key.NewBinding(
 			key.WithKeys("f"),
@@ -98,7 +112,7 @@ func NewLogKeyMap(cfg *config.Config) LogKeyMap {
 		),
 		Yank: key.NewBinding(
 			key.WithKeys("y"),
-			key.WithHelp("y", "yank json"),
+			key.WithHelp("y", "yank (tui.logs.copy_format)"),
 		),
 		SwitchFocus: key.NewBinding(
 			key.WithKeys("tab"),
@@ -128,10 +142,66 @@ func NewLogKeyMap(cfg *config.Config) LogKeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "copy raw text"),
 		),
+		CopyAs: key.NewBinding(
+			key.WithKeys("\""),
+			key.WithHelp("\"", "copy as jsonl/json/jq/grep"),
+		),
+		Actions: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "actions (copy/export/issue/pipe/hide)"),
+		),
 		OpenEditor: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "open in editor"),
 		),
+		ToggleSplit: key.NewBinding(
+			key.WithKeys("|"),
+			key.WithHelp("|", "split view (compare components)"),
+		),
+		Bookmark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "toggle bookmark"),
+		),
+		Annotate: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "annotate bookmark"),
+		),
+		NextBookmark: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'", "next bookmark"),
+		),
+		ToggleWrap: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "cycle truncate/wrap/scroll (h/l pan)"),
+		),
+		Workspaces: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "workspace picker"),
+		),
+		ToggleContext: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "cycle context rows around matches"),
+		),
+		ToggleTimestamps: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "toggle absolute/relative timestamps"),
+		),
+		TogglePinned: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "pin recent errors (follow mode)"),
+		),
+		NextPanel: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "cycle plugin panels in the detail pane"),
+		),
+		Correlate: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "show entries with same request/trace/session id"),
+		),
+		ClearCorrelation: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "clear related-entries filter"),
+		),
 	}
 
 	// Apply TUI-specific overrides from config
@@ -163,9 +233,18 @@ func (k LogKeyMap) FullHelp() [][]key.Binding {
 			k.ToggleSystem,
 			k.CycleLevel,
 			k.ComponentSummary,
+			k.Workspaces,
 			k.ToggleFilters,
 			k.ToggleEvents,
 			k.ToggleFollow,
+			k.ToggleSplit,
+			k.ToggleWrap,
+			k.ToggleContext,
+			k.ToggleTimestamps,
+			k.TogglePinned,
+			k.NextPanel,
+			k.Correlate,
+			k.ClearCorrelation,
 			k.Search,
 		},
 		{ // Actions
@@ -173,8 +252,13 @@ func (k LogKeyMap) FullHelp() [][]key.Binding {
 			k.VisualModeStart,
 			k.Yank,
 			k.CopyRawText,
+			k.CopyAs,
+			k.Actions,
 			k.ClearBuffer,
 			k.OpenEditor,
+			k.Bookmark,
+			k.Annotate,
+			k.NextBookmark,
 			k.SwitchFocus,
 			k.Base.Help,
 			k.Base.Quit,
@@ -37,8 +37,9 @@ func FindLogFileForWorkspace(ws *workspace.WorkspaceNode) (logFile, logsDir stri
 		return expanded, filepath.Dir(expanded), nil
 	}
 
-	// Default: XDG state directory organized by workspace identifier
-	logsDir = filepath.Join(paths.StateDir(), "logs", "workspaces", ws.Identifier("/"))
+	// logging.file.dir from the workspace's own config, else the XDG state
+	// directory organized by workspace identifier.
+	logsDir = logging.WorkspaceLogsDir(&logCfg, ws)
 	logFile, err = FindLatestLogFile(logsDir)
 	return logFile, logsDir, err
 }
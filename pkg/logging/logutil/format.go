@@ -9,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/grovetools/core/logging"
 	"github.com/grovetools/core/tui/theme"
 )
 
@@ -42,26 +43,29 @@ func styleLevelStr(level string) string {
 		levelStyle = theme.DefaultTheme.Warning
 	case "info":
 		levelStyle = theme.DefaultTheme.Info
+	case "trace":
+		levelStyle = theme.DefaultTheme.Muted.Faint(true)
 	default:
 		levelStyle = theme.DefaultTheme.Muted
 	}
 	return levelStyle.Render(strings.ToUpper(level))
 }
 
-// parseTimeStr extracts and formats a time string from a log map.
+// parseTimeStr extracts a log map's time, in whatever logging.time_format it
+// was written, and formats it in local time.
 func parseTimeStr(logMap map[string]interface{}) string {
-	ts, _ := logMap["time"].(string)
-	parsedTime, err := time.Parse(time.RFC3339Nano, ts)
-	if err != nil {
-		parsedTime, _ = time.Parse(time.RFC3339, ts)
+	parsedTime, ok := logging.ParseTime(logMap["time"], logging.ConfiguredTimeFormat())
+	if !ok {
+		return time.Time{}.Format("15:04:05")
 	}
-	return parsedTime.Format("15:04:05")
+	return parsedTime.Local().Format("15:04:05")
 }
 
 // excludeStandardFields is the set of fields excluded from "other fields" display.
 var excludeStandardFields = map[string]bool{
 	"time": true, "level": true, "msg": true, "component": true,
 	"workspace": true, "pretty_ansi": true, "pretty_text": true,
+	logging.VerbosityField: true,
 }
 
 // formatOtherFields returns a formatted string of non-standard fields.
@@ -91,7 +95,9 @@ func formatJSON(logMap map[string]interface{}, workspace string) string {
 	}
 	out["workspace"] = workspace
 	jsonData, _ := json.Marshal(out)
-	return string(jsonData)
+	// Like the other formats, end the entry with a newline so the output
+	// is one object per line.
+	return string(jsonData) + "\n"
 }
 
 func formatPretty(logMap map[string]interface{}, withANSI, compact bool) string {
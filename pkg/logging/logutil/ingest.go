@@ -0,0 +1,135 @@
+package logutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// IngestSourceField is the field IngestEntries records the request's Source
+// in.
+const IngestSourceField = "ingest_source"
+
+// IngestEntries writes a batch of structured entries into ws's log files
+// the way a logger running in ws would: to logging.file.path when set (see
+// FindLogFileForWorkspace), otherwise to the dated workspace-<date>.log for
+// each entry's day, in the workspace's file format with its redact rules
+// applied. Entries without a
+// time are stamped now and entries without a level are info; an entry with
+// an unparseable time or level, or without a message or component, is
+// rejected and the rest are still written.
+func IngestEntries(ws *workspace.WorkspaceNode, req models.LogIngestRequest) (*models.LogIngestResponse, error) {
+	logCfg := logging.GetDefaultLoggingConfig()
+	if cfg, err := config.LoadFrom(ws.Path); err == nil && cfg != nil {
+		_ = cfg.UnmarshalExtension("logging", &logCfg)
+	}
+	formatter := logging.FileFormatter(&logCfg)
+
+	pathFor := func(t time.Time) (string, error) {
+		return filepath.Join(logging.WorkspaceLogsDir(&logCfg, ws), fmt.Sprintf("workspace-%s.log", t.Local().Format("2006-01-02"))), nil
+	}
+	if logCfg.File.Enabled && logCfg.File.Path != "" {
+		pathFor = func(time.Time) (string, error) {
+			return pathutil.Expand(logCfg.File.Path)
+		}
+	}
+
+	resp := &models.LogIngestResponse{}
+	reject := func(i int, err error) {
+		resp.Rejected = append(resp.Rejected, models.LogIngestRejection{Index: i, Error: err.Error()})
+	}
+	files := make(map[string]*os.File)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	for i, raw := range req.Entries {
+		entry, err := ingestEntry(raw, req)
+		if err != nil {
+			reject(i, err)
+			continue
+		}
+		line, err := formatter.Format(entry)
+		if err != nil {
+			reject(i, err)
+			continue
+		}
+		path, err := pathFor(entry.Time)
+		if err != nil {
+			return resp, err
+		}
+		f, ok := files[path]
+		if !ok {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return resp, fmt.Errorf("failed to create log directory: %w", err)
+			}
+			f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return resp, fmt.Errorf("failed to open log file: %w", err)
+			}
+			files[path] = f
+		}
+		// One write per entry, so lines from concurrent writers of the same
+		// file never interleave.
+		if _, err := f.Write(line); err != nil {
+			return resp, fmt.Errorf("failed to write log file: %w", err)
+		}
+		resp.Accepted++
+	}
+	return resp, nil
+}
+
+// ingestEntry turns one raw ingested entry into the logrus entry the file
+// formatter expects.
+func ingestEntry(raw map[string]interface{}, req models.LogIngestRequest) (*logrus.Entry, error) {
+	entry := &logrus.Entry{Time: time.Now(), Level: logrus.InfoLevel, Data: make(logrus.Fields, len(raw)+1)}
+	for k, v := range raw {
+		switch k {
+		case logrus.FieldKeyTime:
+			t, ok := logging.ParseTime(v, "")
+			if !ok {
+				return nil, fmt.Errorf("invalid time %v", v)
+			}
+			entry.Time = t
+		case logrus.FieldKeyLevel:
+			s, _ := v.(string)
+			level, err := logrus.ParseLevel(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid level %v", v)
+			}
+			entry.Level = level
+		case logrus.FieldKeyMsg:
+			msg, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("msg must be a string")
+			}
+			entry.Message = msg
+		default:
+			entry.Data[k] = v
+		}
+	}
+	if entry.Message == "" {
+		return nil, fmt.Errorf("missing msg")
+	}
+	if c, _ := entry.Data["component"].(string); c == "" {
+		if req.Component == "" {
+			return nil, fmt.Errorf("missing component")
+		}
+		entry.Data["component"] = req.Component
+	}
+	if req.Source != "" {
+		entry.Data[IngestSourceField] = req.Source
+	}
+	return entry, nil
+}
@@ -0,0 +1,105 @@
+package logutil
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// newIngestWorkspace creates a workspace whose logging config writes format
+// files under <ws>/logs, isolated from the user's global config.
+func newIngestWorkspace(t *testing.T, format string) *workspace.WorkspaceNode {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GROVE_HOME", "")
+	dir := t.TempDir()
+	cfg := "version: \"1.0\"\nname: api\nlogging:\n  redact: [token]\n  file:\n    dir: logs\n    format: " + format + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "grove.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return &workspace.WorkspaceNode{Name: "api", Path: dir}
+}
+
+func TestIngestEntries(t *testing.T) {
+	ws := newIngestWorkspace(t, "json")
+	resp, err := IngestEntries(ws, models.LogIngestRequest{
+		Workspace: ws.Path,
+		Component: "runner",
+		Source:    "ci-container",
+		Entries: []map[string]interface{}{
+			{"time": "2026-01-02T10:00:00Z", "level": "warn", "msg": "disk low", "component": "agent", "free_mb": 12},
+			{"time": "2026-01-02T10:00:01Z", "msg": "defaults", "token": "s3cret"},
+			{"time": "yesterday", "msg": "bad time"},
+			{"level": "loud", "msg": "bad level"},
+			{"level": "info"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("IngestEntries: %v", err)
+	}
+	if resp.Accepted != 2 {
+		t.Errorf("expected 2 accepted entries, got %d", resp.Accepted)
+	}
+	var rejected []int
+	for _, r := range resp.Rejected {
+		rejected = append(rejected, r.Index)
+	}
+	if len(rejected) != 3 || rejected[0] != 2 || rejected[1] != 3 || rejected[2] != 4 {
+		t.Errorf("unexpected rejections: %+v", resp.Rejected)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(ws.Path, "logs", ws.Identifier("/"), "workspace-*.log"))
+	if len(files) != 1 {
+		t.Fatalf("expected one dated log file, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", data)
+	}
+	var first, second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first["level"] != "warning" || first["component"] != "agent" || first["free_mb"] != float64(12) || first[IngestSourceField] != "ci-container" {
+		t.Errorf("unexpected first entry: %v", first)
+	}
+	if second["level"] != "info" || second["component"] != "runner" {
+		t.Errorf("expected the request defaults on the second entry: %v", second)
+	}
+	if second["token"] == "s3cret" {
+		t.Errorf("expected the workspace's redact rules to apply: %v", second)
+	}
+}
+
+func TestIngestEntriesTextFormat(t *testing.T) {
+	ws := newIngestWorkspace(t, "text")
+	resp, err := IngestEntries(ws, models.LogIngestRequest{
+		Workspace: ws.Path,
+		Entries:   []map[string]interface{}{{"time": "2026-01-02T10:00:00Z", "level": "error", "msg": "boom", "component": "job"}},
+	})
+	if err != nil || resp.Accepted != 1 {
+		t.Fatalf("IngestEntries = %+v, %v", resp, err)
+	}
+	files, _ := filepath.Glob(filepath.Join(ws.Path, "logs", ws.Identifier("/"), "workspace-*.log"))
+	if len(files) != 1 {
+		t.Fatalf("expected one dated log file, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "[ERROR] [job] boom") {
+		t.Errorf("expected a text-format line, got %q", data)
+	}
+}
@@ -0,0 +1,196 @@
+package logutil
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/logging"
+)
+
+// ReplayEntry is one log line read for replay, with the time it was logged.
+type ReplayEntry struct {
+	TailedLine
+	Time time.Time
+}
+
+// ReplayOptions controls the pacing of Replay.
+type ReplayOptions struct {
+	// Speed divides the gaps between entries: 1 replays in real time, 10
+	// ten times faster. Zero or less means 1.
+	Speed float64
+	// MaxGap caps the wait between two entries after Speed is applied, so
+	// idle stretches do not stall the replay. Zero leaves gaps uncapped.
+	MaxGap time.Duration
+	// Since and Until restrict the replay to entries logged in [Since,
+	// Until]. Zero values leave that end open.
+	Since, Until time.Time
+}
+
+// ReadReplayEntries reads every line of the log file at path. An entry's
+// time comes from its time field; lines without one (or that are not JSON)
+// take the time of the line before them, so they keep their place when
+// files are merged.
+func ReadReplayEntries(wsName, wsPath, path string) ([]ReplayEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := logging.ConfiguredTimeFormat()
+	var entries []ReplayEntry
+	var last time.Time
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if t, ok := lineTime(line, format); ok {
+			last = t
+		}
+		entries = append(entries, ReplayEntry{
+			TailedLine: TailedLine{Workspace: wsName, WorkspacePath: wsPath, Line: line},
+			Time:       last,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// ReadTailEntries reads the lines of the log file at path that TailFile
+// would replay for tailLines (see the tail-lines sentinel semantics), timed
+// as ReadReplayEntries times them. Bounded tails seek from the end, so
+// they do not load the whole file.
+func ReadTailEntries(wsName, wsPath, path string, tailLines int) ([]ReplayEntry, error) {
+	if tailLines < 0 {
+		return ReadReplayEntries(wsName, wsPath, path)
+	}
+	if tailLines == 0 {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lines, err := readLastNLines(f, tailLines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	format := logging.ConfiguredTimeFormat()
+	entries := make([]ReplayEntry, 0, len(lines))
+	var last time.Time
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		if t, ok := lineTime(line, format); ok {
+			last = t
+		}
+		entries = append(entries, ReplayEntry{
+			TailedLine: TailedLine{Workspace: wsName, WorkspacePath: wsPath, Line: line},
+			Time:       last,
+		})
+	}
+	return entries, nil
+}
+
+// lineTime returns the time field of a JSON log line.
+func lineTime(line, format string) (time.Time, bool) {
+	var logMap map[string]interface{}
+	if json.Unmarshal([]byte(line), &logMap) != nil {
+		return time.Time{}, false
+	}
+	return logging.ParseTime(logMap["time"], format)
+}
+
+// SortReplayEntries orders entries from several files by time, keeping the
+// file order of entries logged at the same instant.
+func SortReplayEntries(entries []ReplayEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+}
+
+// FilterReplayWindow returns the entries logged in [since, until]. Zero
+// values leave that end open, and entries without a time are kept.
+func FilterReplayWindow(entries []ReplayEntry, since, until time.Time) []ReplayEntry {
+	filtered := make([]ReplayEntry, 0, len(entries))
+	for _, e := range entries {
+		if !e.Time.IsZero() {
+			if !since.IsZero() && e.Time.Before(since) {
+				continue
+			}
+			if !until.IsZero() && e.Time.After(until) {
+				continue
+			}
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// Replay passes entries to emit in order, waiting between two entries for
+// the time that separated them when they were logged, scaled by
+// opts.Speed and capped at opts.MaxGap. The first entry is emitted at
+// once. It returns early with ctx's error when ctx is cancelled, or with
+// the first error emit returns.
+func Replay(ctx context.Context, entries []ReplayEntry, opts ReplayOptions, emit func(ReplayEntry) error) error {
+	return replay(ctx, entries, opts, emit, sleepContext)
+}
+
+func replay(ctx context.Context, entries []ReplayEntry, opts ReplayOptions, emit func(ReplayEntry) error, sleep func(context.Context, time.Duration) error) error {
+	speed := opts.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	var prev time.Time
+	for _, e := range entries {
+		if !e.Time.IsZero() {
+			if !opts.Since.IsZero() && e.Time.Before(opts.Since) {
+				continue
+			}
+			if !opts.Until.IsZero() && e.Time.After(opts.Until) {
+				continue
+			}
+			if !prev.IsZero() {
+				if gap := time.Duration(float64(e.Time.Sub(prev)) / speed); gap > 0 {
+					if opts.MaxGap > 0 && gap > opts.MaxGap {
+						gap = opts.MaxGap
+					}
+					if err := sleep(ctx, gap); err != nil {
+						return err
+					}
+				}
+			}
+			prev = e.Time
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := emit(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package logutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadReplayEntriesCarriesTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	content := `{"time":"2026-01-02T10:00:00Z","msg":"a"}
+not json
+{"time":"2026-01-02T10:00:05Z","msg":"b"}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadReplayEntries("ws", "/ws", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	if !entries[1].Time.Equal(base) || entries[1].Line != "not json" {
+		t.Errorf("line without time = %+v, want the previous entry's time", entries[1])
+	}
+	if !entries[2].Time.Equal(base.Add(5*time.Second)) || entries[2].Workspace != "ws" {
+		t.Errorf("entries[2] = %+v", entries[2])
+	}
+}
+
+func TestReadTailEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	content := `{"time":"2026-01-02T10:00:00Z","msg":"a"}
+{"time":"2026-01-02T10:00:05Z","msg":"b"}
+not json
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadTailEntries("api", "", path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Line != `{"time":"2026-01-02T10:00:05Z","msg":"b"}` {
+		t.Fatalf("entries = %+v, want the last two lines", entries)
+	}
+	want := time.Date(2026, 1, 2, 10, 0, 5, 0, time.UTC)
+	if !entries[1].Time.Equal(want) || entries[1].Workspace != "api" {
+		t.Errorf("entries[1] = %+v, want the time of the line before it", entries[1])
+	}
+	if entries, _ := ReadTailEntries("api", "", path, 0); len(entries) != 0 {
+		t.Errorf("tailLines 0 read %d entries, want none", len(entries))
+	}
+	if entries, _ := ReadTailEntries("api", "", path, -1); len(entries) != 3 {
+		t.Errorf("tailLines -1 read %d entries, want all 3", len(entries))
+	}
+}
+
+func TestReplayPacing(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	entry := func(line string, offset time.Duration) ReplayEntry {
+		return ReplayEntry{TailedLine: TailedLine{Line: line}, Time: base.Add(offset)}
+	}
+	entries := []ReplayEntry{
+		entry("a", 0),
+		entry("b", 4*time.Second),
+		entry("c", 4*time.Second),
+		entry("d", 2*time.Minute),
+		entry("e", 3*time.Minute),
+	}
+
+	var slept []time.Duration
+	var emitted []string
+	sleep := func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	opts := ReplayOptions{Speed: 2, MaxGap: 10 * time.Second, Until: base.Add(2 * time.Minute)}
+	err := replay(context.Background(), entries, opts, func(e ReplayEntry) error {
+		emitted = append(emitted, e.Line)
+		return nil
+	}, sleep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(emitted, want) {
+		t.Errorf("emitted %v, want %v", emitted, want)
+	}
+	if want := []time.Duration{2 * time.Second, 10 * time.Second}; !reflect.DeepEqual(slept, want) {
+		t.Errorf("slept %v, want %v", slept, want)
+	}
+}
+
+func TestReplayStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	base := time.Now()
+	entries := []ReplayEntry{{Time: base}, {Time: base.Add(time.Hour)}}
+	err := Replay(ctx, entries, ReplayOptions{}, func(ReplayEntry) error { return nil })
+	if err != context.Canceled {
+		t.Errorf("Replay after cancel = %v, want context.Canceled", err)
+	}
+}
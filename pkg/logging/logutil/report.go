@@ -0,0 +1,274 @@
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// ReportOptions describes the report WriteHTMLReport renders.
+type ReportOptions struct {
+	// Title heads the page; it defaults to "Grove logs".
+	Title string
+	// Since and Until are the window the entries were selected from, shown
+	// in the header. Zero values leave that end open.
+	Since, Until time.Time
+	// Filters lists the filters applied to the entries (e.g. "level >=
+	// warn"), shown in the header so readers know what was left out.
+	Filters []string
+	// Theme names the theme whose palette colors the report; it defaults
+	// to the active theme.
+	Theme string
+	// Generated is the report's timestamp; it defaults to now.
+	Generated time.Time
+}
+
+// reportColors is the palette subset the report's stylesheet uses.
+type reportColors struct {
+	Bg, BgDark, Fg, Comment, Border           string
+	Debug, Info, Warn, Error, Accent, Accent2 string
+}
+
+// reportPalette resolves the report colors from the named theme, falling
+// back to the default theme for unknown names and ANSI passthrough themes,
+// whose values are not CSS colors.
+func reportPalette(name string) reportColors {
+	p, ok := theme.Lookup(name)
+	if !ok || p.Meta.ANSI {
+		p, _ = theme.Lookup(theme.DefaultThemeName)
+	}
+	c := p.Colors
+	return reportColors{
+		Bg: c.Bg, BgDark: c.BgDark, Fg: c.Fg, Comment: c.Comment, Border: c.Border,
+		Debug: c.Comment, Info: c.Green, Warn: c.Yellow, Error: c.Red,
+		Accent: c.Blue, Accent2: c.Purple,
+	}
+}
+
+// reportEntry is one row of the report.
+type reportEntry struct {
+	Time      string
+	Level     string
+	Workspace string
+	Component string
+	Message   string
+	// Payload is the entry's JSON, indented, or the raw line when it is
+	// not JSON.
+	Payload string
+}
+
+// reportData is the template's input.
+type reportData struct {
+	Title     string
+	Generated string
+	Window    string
+	Filters   []string
+	Counts    []reportCount
+	Sources   []string
+	Entries   []reportEntry
+	Colors    reportColors
+}
+
+type reportCount struct {
+	Level string
+	Count int
+}
+
+// WriteHTMLReport renders entries as a standalone HTML page: a header
+// summarising the window, filters and level counts, then one row per entry
+// with its full JSON payload in a collapsible block. Levels are colored
+// with the theme palette. The page has no external resources, so it can be
+// attached to an issue or sent as a file.
+func WriteHTMLReport(w io.Writer, entries []ReplayEntry, opts ReportOptions) error {
+	if opts.Title == "" {
+		opts.Title = "Grove logs"
+	}
+	if opts.Generated.IsZero() {
+		opts.Generated = time.Now()
+	}
+	if opts.Theme == "" {
+		opts.Theme = theme.DefaultTheme.Name
+	}
+
+	data := reportData{
+		Title:     opts.Title,
+		Generated: opts.Generated.Format(time.RFC3339),
+		Window:    reportWindow(entries, opts.Since, opts.Until),
+		Filters:   opts.Filters,
+		Colors:    reportPalette(opts.Theme),
+	}
+	counts := make(map[string]int)
+	sources := make(map[string]bool)
+	for _, e := range entries {
+		row := newReportEntry(e)
+		counts[row.Level]++
+		if e.Workspace != "" {
+			sources[e.Workspace] = true
+		}
+		data.Entries = append(data.Entries, row)
+	}
+	for _, level := range []string{"error", "warn", "info", "debug"} {
+		if counts[level] > 0 {
+			data.Counts = append(data.Counts, reportCount{Level: level, Count: counts[level]})
+			delete(counts, level)
+		}
+	}
+	for level, n := range counts {
+		data.Counts = append(data.Counts, reportCount{Level: level, Count: n})
+	}
+	for s := range sources {
+		data.Sources = append(data.Sources, s)
+	}
+	sort.Strings(data.Sources)
+
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func newReportEntry(e ReplayEntry) reportEntry {
+	row := reportEntry{Workspace: e.Workspace, Level: "info", Payload: e.Line}
+	if !e.Time.IsZero() {
+		row.Time = e.Time.Format("2006-01-02 15:04:05.000")
+	}
+	var logMap map[string]interface{}
+	if json.Unmarshal([]byte(e.Line), &logMap) != nil {
+		row.Message = e.Line
+		return row
+	}
+	if level, ok := logMap["level"].(string); ok && level != "" {
+		row.Level = reportLevel(level)
+	}
+	row.Component, _ = logMap["component"].(string)
+	row.Message, _ = logMap["msg"].(string)
+	delete(logMap, "pretty_ansi")
+	delete(logMap, "pretty_text")
+	if b, err := json.MarshalIndent(logMap, "", "  "); err == nil {
+		row.Payload = string(b)
+	}
+	return row
+}
+
+// reportLevel folds level aliases into the four levels the report styles.
+func reportLevel(level string) string {
+	switch l := strings.ToLower(level); l {
+	case "warning":
+		return "warn"
+	case "fatal", "panic":
+		return "error"
+	case "trace":
+		return "debug"
+	default:
+		return l
+	}
+}
+
+// reportWindow describes the time window of the report: the requested
+// bounds where set, otherwise the times of the first and last entries.
+func reportWindow(entries []ReplayEntry, since, until time.Time) string {
+	var first, last time.Time
+	for _, e := range entries {
+		if e.Time.IsZero() {
+			continue
+		}
+		if first.IsZero() || e.Time.Before(first) {
+			first = e.Time
+		}
+		if e.Time.After(last) {
+			last = e.Time
+		}
+	}
+	if !since.IsZero() {
+		first = since
+	}
+	if !until.IsZero() {
+		last = until
+	}
+	switch {
+	case first.IsZero() && last.IsZero():
+		return ""
+	case first.IsZero():
+		return "until " + last.Format(time.RFC3339)
+	case last.IsZero():
+		return "since " + first.Format(time.RFC3339)
+	default:
+		return first.Format(time.RFC3339) + " – " + last.Format(time.RFC3339)
+	}
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+:root {
+  --bg: {{.Colors.Bg}}; --bg-dark: {{.Colors.BgDark}}; --fg: {{.Colors.Fg}};
+  --muted: {{.Colors.Comment}}; --border: {{.Colors.Border}};
+  --debug: {{.Colors.Debug}}; --info: {{.Colors.Info}}; --warn: {{.Colors.Warn}}; --error: {{.Colors.Error}};
+  --accent: {{.Colors.Accent}}; --accent2: {{.Colors.Accent2}};
+}
+* { box-sizing: border-box; }
+body { margin: 0; background: var(--bg); color: var(--fg); font: 13px/1.45 ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+header { background: var(--bg-dark); border-bottom: 1px solid var(--border); padding: 16px 24px; }
+h1 { margin: 0 0 6px; font-size: 18px; }
+.meta { color: var(--muted); }
+.meta span + span::before { content: " · "; }
+.counts { margin-top: 8px; }
+.count { display: inline-block; margin-right: 12px; font-weight: bold; }
+.filters { margin: 6px 0 0; padding-left: 18px; color: var(--muted); }
+main { padding: 8px 24px 32px; }
+.entry { border-bottom: 1px solid var(--border); padding: 4px 0; }
+.entry summary { cursor: pointer; list-style: none; white-space: pre-wrap; word-break: break-word; }
+.entry summary::-webkit-details-marker { display: none; }
+.entry summary::before { content: "▸ "; color: var(--muted); }
+.entry[open] summary::before { content: "▾ "; }
+.time { color: var(--muted); }
+.ws { color: var(--accent); }
+.component { color: var(--accent2); }
+.level { display: inline-block; width: 5ch; font-weight: bold; text-transform: uppercase; }
+.debug .level, .count.debug { color: var(--debug); }
+.info .level, .count.info { color: var(--info); }
+.warn .level, .count.warn { color: var(--warn); }
+.error .level, .count.error { color: var(--error); }
+.error { background: color-mix(in srgb, var(--error) 8%, transparent); }
+pre { margin: 6px 0 6px 2ch; padding: 8px 12px; background: var(--bg-dark); border: 1px solid var(--border); border-radius: 4px; overflow-x: auto; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<div class="meta">
+{{- if .Window}}<span>{{.Window}}</span>{{end -}}
+<span>{{len .Entries}} entries</span>
+{{- if .Sources}}<span>{{range $i, $s := .Sources}}{{if $i}}, {{end}}{{$s}}{{end}}</span>{{end -}}
+<span>generated {{.Generated}}</span>
+</div>
+{{- if .Counts}}
+<div class="counts">{{range .Counts}}<span class="count {{.Level}}">{{.Count}} {{.Level}}</span>{{end}}</div>
+{{- end}}
+{{- if .Filters}}
+<ul class="filters">{{range .Filters}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+</header>
+<main>
+{{- range .Entries}}
+<details class="entry {{.Level}}"><summary><span class="time">{{.Time}}</span> <span class="level">{{.Level}}</span> {{if .Workspace}}<span class="ws">[{{.Workspace}}]</span> {{end}}{{if .Component}}<span class="component">{{.Component}}</span> {{end}}{{.Message}}</summary>
+<pre>{{.Payload}}</pre>
+</details>
+{{- end}}
+</main>
+</body>
+</html>
+`))
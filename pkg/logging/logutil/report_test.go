@@ -0,0 +1,67 @@
+package logutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	base := time.Date(2026, 1, 2, 14, 0, 0, 0, time.UTC)
+	entries := []ReplayEntry{
+		{TailedLine: TailedLine{Workspace: "api", Line: `{"level":"info","component":"server","msg":"listening <script>","port":8080}`}, Time: base},
+		{TailedLine: TailedLine{Workspace: "api", Line: `{"level":"warning","component":"db","msg":"slow query"}`}, Time: base.Add(time.Minute)},
+		{TailedLine: TailedLine{Workspace: "worker", Line: "not json"}, Time: base.Add(2 * time.Minute)},
+	}
+
+	var buf bytes.Buffer
+	err := WriteHTMLReport(&buf, entries, ReportOptions{
+		Title:     "Incident",
+		Filters:   []string{"level ≥ info"},
+		Theme:     theme.DefaultThemeName,
+		Generated: base,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	palette, _ := theme.Lookup(theme.DefaultThemeName)
+	for _, want := range []string{
+		"<title>Incident</title>",
+		"2026-01-02T14:00:00Z – 2026-01-02T14:02:00Z",
+		"api, worker",
+		`<span class="count warn">1 warn</span>`,
+		`<details class="entry warn">`,
+		"listening &lt;script&gt;",
+		"&#34;port&#34;: 8080",
+		"<li>level ≥ info</li>",
+		"--error: " + palette.Colors.Red,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script>") {
+		t.Error("messages must be escaped")
+	}
+}
+
+func TestReportPaletteFallsBackForUnknownTheme(t *testing.T) {
+	want, _ := theme.Lookup(theme.DefaultThemeName)
+	if got := reportPalette("no-such-theme"); got.Bg != want.Colors.Bg || got.Error != want.Colors.Red {
+		t.Errorf("unknown theme should use the default palette, got %+v", got)
+	}
+}
+
+func TestFilterReplayWindow(t *testing.T) {
+	base := time.Date(2026, 1, 2, 14, 0, 0, 0, time.UTC)
+	entries := []ReplayEntry{{Time: base}, {Time: base.Add(time.Minute)}, {}, {Time: base.Add(2 * time.Minute)}}
+	got := FilterReplayWindow(entries, base.Add(30*time.Second), base.Add(time.Minute))
+	if len(got) != 2 || !got[0].Time.Equal(base.Add(time.Minute)) || !got[1].Time.IsZero() {
+		t.Errorf("FilterReplayWindow = %+v", got)
+	}
+}
@@ -0,0 +1,476 @@
+package logutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// Defaults for SummaryOptions.
+const (
+	DefaultSummaryGap          = time.Hour
+	DefaultSummaryClusters     = 10
+	DefaultSummaryBaselineDays = 7
+)
+
+// summaryTopHours is how many of the busiest hours a summary lists, and
+// summaryMaxGaps how many of the longest gaps.
+const (
+	summaryTopHours = 3
+	summaryMaxGaps  = 5
+)
+
+// SummaryOptions controls what a daily summary reports.
+type SummaryOptions struct {
+	// GapThreshold is the shortest silence between two entries reported
+	// as a notable gap. Zero uses DefaultSummaryGap.
+	GapThreshold time.Duration
+	// MaxClusters caps the error clusters listed. Zero uses
+	// DefaultSummaryClusters.
+	MaxClusters int
+	// BaselineDays is how many days before the summarized one are read to
+	// tell new components from known ones. Zero uses
+	// DefaultSummaryBaselineDays; a negative value skips the comparison.
+	BaselineDays int
+}
+
+// SummarySource is one log a summary reads: the dated files of Dir
+// (<prefix>-YYYY-MM-DD.log), or File when the log is a single file that is
+// not rotated by date.
+type SummarySource struct {
+	Name          string
+	WorkspacePath string
+	Dir           string
+	File          string
+}
+
+// LogSummary is the digest of one day of logs.
+type LogSummary struct {
+	Date          string         `json:"date"`
+	Entries       int            `json:"entries"`
+	Levels        map[string]int `json:"levels"`
+	Sources       []string       `json:"sources"`
+	ErrorClusters []ErrorCluster `json:"error_clusters"`
+	// NewComponents is nil when there was no baseline to compare with.
+	NewComponents []NewComponent `json:"new_components"`
+	BusiestHours  []HourCount    `json:"busiest_hours"`
+	Gaps          []LogGap       `json:"gaps"`
+}
+
+// ErrorCluster groups error entries whose messages differ only in numbers,
+// IDs and quoted values.
+type ErrorCluster struct {
+	Signature  string    `json:"signature"`
+	Count      int       `json:"count"`
+	Components []string  `json:"components,omitempty"`
+	First      time.Time `json:"first"`
+	Last       time.Time `json:"last"`
+	Example    string    `json:"example"`
+}
+
+// NewComponent is a component that logged on the day but not during the
+// baseline days before it.
+type NewComponent struct {
+	Name  string    `json:"name"`
+	First time.Time `json:"first"`
+}
+
+// HourCount is the number of entries logged in one hour of the day.
+type HourCount struct {
+	Hour    int `json:"hour"`
+	Entries int `json:"entries"`
+	Errors  int `json:"errors"`
+}
+
+// LogGap is a stretch with no entries between two that were logged.
+type LogGap struct {
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+// DayStart returns midnight at the start of t's day in t's location.
+func DayStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// SummaryDaySources returns the sources a summary of workspaces reads,
+// plus the system logs when includeSystem is set.
+func SummaryDaySources(workspaces []*workspace.WorkspaceNode, includeSystem bool) []SummarySource {
+	var sources []SummarySource
+	for _, ws := range workspaces {
+		logFile, logsDir, _ := FindLogFileForWorkspace(ws)
+		src := SummarySource{Name: ws.Name, WorkspacePath: ws.Path, Dir: logsDir}
+		if logFile != "" && !datedLogFile.MatchString(filepath.Base(logFile)) {
+			src.Dir, src.File = "", logFile
+		}
+		if src.Dir != "" || src.File != "" {
+			sources = append(sources, src)
+		}
+	}
+	if includeSystem {
+		sources = append(sources, SummarySource{Name: "system", Dir: GetSystemLogsDir()})
+	}
+	return sources
+}
+
+var datedLogFile = regexp.MustCompile(`-\d{4}-\d{2}-\d{2}\.log$`)
+
+// files returns the source's files that may hold entries logged in
+// [from, to).
+func (s SummarySource) files(from, to time.Time) []string {
+	if s.File != "" {
+		return []string{s.File}
+	}
+	var files []string
+	for day := DayStart(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		matches, _ := filepath.Glob(filepath.Join(s.Dir, "*-"+day.Format("2006-01-02")+".log"))
+		files = append(files, matches...)
+	}
+	return files
+}
+
+// readWindow reads the source's entries logged in [from, to).
+func (s SummarySource) readWindow(from, to time.Time) ([]ReplayEntry, error) {
+	var entries []ReplayEntry
+	for _, path := range s.files(from, to) {
+		fileEntries, err := ReadReplayEntries(s.Name, s.WorkspacePath, path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range fileEntries {
+			if !e.Time.Before(from) && e.Time.Before(to) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// SummarizeDay reads the entries sources logged on day (in day's location)
+// and summarizes them. The baseline days before it are read only for the
+// components they logged.
+func SummarizeDay(sources []SummarySource, day time.Time, opts SummaryOptions) (*LogSummary, error) {
+	start := DayStart(day)
+	end := start.AddDate(0, 0, 1)
+
+	var entries []ReplayEntry
+	for _, s := range sources {
+		sourceEntries, err := s.readWindow(start, end)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, sourceEntries...)
+	}
+	SortReplayEntries(entries)
+
+	baselineDays := opts.BaselineDays
+	if baselineDays == 0 {
+		baselineDays = DefaultSummaryBaselineDays
+	}
+	var known map[string]bool
+	if baselineDays > 0 {
+		known = make(map[string]bool)
+		for _, s := range sources {
+			baseline, err := s.readWindow(start.AddDate(0, 0, -baselineDays), start)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range baseline {
+				if component := entryComponent(e.Line); component != "" {
+					known[component] = true
+				}
+			}
+		}
+	}
+	return Summarize(entries, start, known, opts), nil
+}
+
+// Summarize builds the summary of entries, which must be sorted by time
+// and logged on the day starting at day. Components not in known are
+// reported as new; a nil known reports none.
+func Summarize(entries []ReplayEntry, day time.Time, known map[string]bool, opts SummaryOptions) *LogSummary {
+	if opts.GapThreshold <= 0 {
+		opts.GapThreshold = DefaultSummaryGap
+	}
+	if opts.MaxClusters <= 0 {
+		opts.MaxClusters = DefaultSummaryClusters
+	}
+
+	s := &LogSummary{
+		Date:          day.Format("2006-01-02"),
+		Entries:       len(entries),
+		Levels:        make(map[string]int),
+		ErrorClusters: []ErrorCluster{},
+		BusiestHours:  []HourCount{},
+		Gaps:          []LogGap{},
+	}
+	if known != nil {
+		s.NewComponents = []NewComponent{}
+	}
+
+	sources := make(map[string]bool)
+	clusters := make(map[string]*ErrorCluster)
+	clusterComponents := make(map[string]map[string]bool)
+	newSeen := make(map[string]bool)
+	var hours [24]HourCount
+	var prev time.Time
+	for _, e := range entries {
+		if e.Workspace != "" {
+			sources[e.Workspace] = true
+		}
+		level, component, msg := "info", "", e.Line
+		var logMap map[string]interface{}
+		if json.Unmarshal([]byte(e.Line), &logMap) == nil {
+			if l, ok := logMap["level"].(string); ok && l != "" {
+				level = reportLevel(l)
+			}
+			component, _ = logMap["component"].(string)
+			msg, _ = logMap["msg"].(string)
+		}
+		s.Levels[level]++
+
+		t := e.Time.In(day.Location())
+		hours[t.Hour()].Entries++
+		if level == "error" {
+			hours[t.Hour()].Errors++
+			sig := errorSignature(msg)
+			c, ok := clusters[sig]
+			if !ok {
+				c = &ErrorCluster{Signature: sig, First: e.Time, Example: msg}
+				clusters[sig] = c
+				clusterComponents[sig] = make(map[string]bool)
+			}
+			c.Count++
+			c.Last = e.Time
+			if component != "" {
+				clusterComponents[sig][component] = true
+			}
+		}
+
+		if known != nil && component != "" && !known[component] && !newSeen[component] {
+			newSeen[component] = true
+			s.NewComponents = append(s.NewComponents, NewComponent{Name: component, First: e.Time})
+		}
+
+		if !prev.IsZero() && e.Time.Sub(prev) >= opts.GapThreshold {
+			s.Gaps = append(s.Gaps, LogGap{Start: prev, End: e.Time, Duration: e.Time.Sub(prev)})
+		}
+		prev = e.Time
+	}
+
+	for name := range sources {
+		s.Sources = append(s.Sources, name)
+	}
+	sort.Strings(s.Sources)
+
+	for sig, c := range clusters {
+		for component := range clusterComponents[sig] {
+			c.Components = append(c.Components, component)
+		}
+		sort.Strings(c.Components)
+		s.ErrorClusters = append(s.ErrorClusters, *c)
+	}
+	sort.Slice(s.ErrorClusters, func(i, j int) bool {
+		a, b := s.ErrorClusters[i], s.ErrorClusters[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.First.Before(b.First)
+	})
+	if len(s.ErrorClusters) > opts.MaxClusters {
+		s.ErrorClusters = s.ErrorClusters[:opts.MaxClusters]
+	}
+
+	for h := range hours {
+		hours[h].Hour = h
+		if hours[h].Entries > 0 {
+			s.BusiestHours = append(s.BusiestHours, hours[h])
+		}
+	}
+	sort.SliceStable(s.BusiestHours, func(i, j int) bool { return s.BusiestHours[i].Entries > s.BusiestHours[j].Entries })
+	if len(s.BusiestHours) > summaryTopHours {
+		s.BusiestHours = s.BusiestHours[:summaryTopHours]
+	}
+
+	sort.SliceStable(s.Gaps, func(i, j int) bool { return s.Gaps[i].Duration > s.Gaps[j].Duration })
+	if len(s.Gaps) > summaryMaxGaps {
+		s.Gaps = s.Gaps[:summaryMaxGaps]
+	}
+	sort.Slice(s.Gaps, func(i, j int) bool { return s.Gaps[i].Start.Before(s.Gaps[j].Start) })
+	return s
+}
+
+// entryComponent returns the component of a JSON log line.
+func entryComponent(line string) string {
+	var logMap map[string]interface{}
+	if json.Unmarshal([]byte(line), &logMap) != nil {
+		return ""
+	}
+	component, _ := logMap["component"].(string)
+	return component
+}
+
+// Patterns errorSignature replaces, most specific first.
+var (
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	pathPattern   = regexp.MustCompile(`(?:/[\w.@-]+){2,}/?`)
+	hexPattern    = regexp.MustCompile(`(?i)\b(?:0x)?[0-9a-f]{6,}\b`)
+	numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// errorSignature reduces an error message to what stays the same between
+// occurrences: quoted values, UUIDs, paths, hex IDs and numbers are
+// replaced with placeholders.
+func errorSignature(msg string) string {
+	msg = quotedPattern.ReplaceAllString(msg, "{str}")
+	msg = uuidPattern.ReplaceAllString(msg, "{uuid}")
+	msg = pathPattern.ReplaceAllString(msg, "{path}")
+	// A run of hex letters alone is a word such as "deadbeef" or "facade";
+	// only runs with a digit in them are IDs.
+	msg = hexPattern.ReplaceAllStringFunc(msg, func(m string) string {
+		if strings.ContainsAny(m, "0123456789") {
+			return "{id}"
+		}
+		return m
+	})
+	msg = numberPattern.ReplaceAllString(msg, "{n}")
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// WriteSummaryMarkdown renders s as a markdown note: a headline with the
+// entry and level counts, then sections for error clusters, new
+// components, the busiest hours and notable gaps. Times are shown in
+// loc.
+func WriteSummaryMarkdown(w io.Writer, s *LogSummary, loc *time.Location) error {
+	clock := func(t time.Time) string { return t.In(loc).Format("15:04") }
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Log summary for %s\n\n", s.Date)
+	if s.Entries == 0 {
+		b.WriteString("No entries were logged.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	fmt.Fprintf(&b, "%d entries from %s: %d errors, %d warnings.\n",
+		s.Entries, strings.Join(s.Sources, ", "), s.Levels["error"], s.Levels["warn"])
+
+	b.WriteString("\n## Error clusters\n\n")
+	if len(s.ErrorClusters) == 0 {
+		b.WriteString("No errors.\n")
+	} else {
+		b.WriteString("| Count | Message | Components | First | Last |\n|---:|---|---|---|---|\n")
+		for _, c := range s.ErrorClusters {
+			fmt.Fprintf(&b, "| %d | %s | %s | %s | %s |\n", c.Count, markdownCell(c.Signature),
+				markdownCell(strings.Join(c.Components, ", ")), clock(c.First), clock(c.Last))
+		}
+	}
+
+	if s.NewComponents != nil {
+		b.WriteString("\n## New components\n\n")
+		if len(s.NewComponents) == 0 {
+			b.WriteString("None.\n")
+		}
+		for _, c := range s.NewComponents {
+			fmt.Fprintf(&b, "- `%s`, first at %s\n", c.Name, clock(c.First))
+		}
+	}
+
+	b.WriteString("\n## Busiest hours\n\n| Hour | Entries | Errors |\n|---|---:|---:|\n")
+	for _, h := range s.BusiestHours {
+		fmt.Fprintf(&b, "| %02d:00-%02d:00 | %d | %d |\n", h.Hour, (h.Hour+1)%24, h.Entries, h.Errors)
+	}
+
+	b.WriteString("\n## Notable gaps\n\n")
+	if len(s.Gaps) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, g := range s.Gaps {
+		fmt.Fprintf(&b, "- %s-%s, %s without entries\n", clock(g.Start), clock(g.End), gapDuration(g.Duration))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// gapDuration formats d to the minute without zero units, e.g. 3h or 1h5m.
+func gapDuration(d time.Duration) string {
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// markdownCell escapes text for a markdown table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
+
+// SummaryNoteName is the inbox file name of the summary of day.
+func SummaryNoteName(day time.Time) string {
+	return day.Format("2006-01-02") + "-log-summary.md"
+}
+
+// WriteSummaryNote writes s as a note in node's notebook inbox, with
+// frontmatter giving its title, date and tags, and returns its path.
+// Encrypted notebooks get an encrypted note.
+func WriteSummaryNote(locator *workspace.NotebookLocator, node *workspace.WorkspaceNode, s *LogSummary, loc *time.Location) (string, error) {
+	dir, err := locator.GetNotesDir(node, "inbox")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve notebook inbox: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create notebook inbox: %w", err)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\ntitle: Log summary for %s\ndate: %s\ntags: [logs, summary]\n---\n\n", s.Date, s.Date)
+	if err := WriteSummaryMarkdown(&b, s, loc); err != nil {
+		return "", err
+	}
+	day, err := time.ParseInLocation("2006-01-02", s.Date, loc)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, SummaryNoteName(day))
+	if err := locator.WriteFile(node, path, []byte(b.String()), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// RunAtMidnight calls fn with the day that just ended each time local
+// midnight passes, until ctx is done. The daemon runs this when
+// daemon.daily_log_summary is set, summarizing the day with SummarizeDay
+// and writing it with WriteSummaryNote.
+func RunAtMidnight(ctx context.Context, fn func(day time.Time)) {
+	runAtMidnight(ctx, time.Now, fn)
+}
+
+func runAtMidnight(ctx context.Context, now func() time.Time, fn func(day time.Time)) {
+	for {
+		next := DayStart(now()).AddDate(0, 0, 1)
+		timer := time.NewTimer(next.Sub(now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		fn(next.AddDate(0, 0, -1))
+	}
+}
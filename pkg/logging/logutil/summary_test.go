@@ -0,0 +1,137 @@
+package logutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorSignature(t *testing.T) {
+	tests := map[string]string{
+		`failed to connect to "db-1:5432": timeout after 30s`:       "failed to connect to {str}: timeout after {n}s",
+		"job 3f2a9c1e-77b0-4d2e-9a51-0c8e2b6f1d44 failed":           "job {uuid} failed",
+		"cannot open /home/me/.grove/logs/x.log":                    "cannot open {path}",
+		"commit 9fceb02d0ae598e95dc970b74767f19372d61af8 not found": "commit {id} not found",
+		"deadbeef cafe retried 3 times":                             "deadbeef cafe retried {n} times",
+		"  spaced   out  ":                                          "spaced out",
+	}
+	for msg, want := range tests {
+		if got := errorSignature(msg); got != want {
+			t.Errorf("errorSignature(%q) = %q, want %q", msg, got, want)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	entry := func(ws string, tm time.Time, level, component, msg string) ReplayEntry {
+		line := fmt.Sprintf(`{"time":%q,"level":%q,"component":%q,"msg":%q}`, tm.Format(time.RFC3339), level, component, msg)
+		return ReplayEntry{TailedLine: TailedLine{Workspace: ws, Line: line}, Time: tm}
+	}
+	entries := []ReplayEntry{
+		entry("api", at(9, 0), "info", "api.http", "started"),
+		entry("api", at(9, 5), "error", "api.db", "query 17 failed"),
+		entry("worker", at(9, 10), "error", "worker.db", "query 42 failed"),
+		entry("worker", at(9, 20), "warning", "worker.queue", "slow"),
+		entry("api", at(13, 0), "error", "api.cache", "cache miss storm"),
+		entry("api", at(13, 1), "info", "api.http", "ok"),
+	}
+
+	s := Summarize(entries, day, map[string]bool{"api.http": true, "api.db": true, "worker.db": true}, SummaryOptions{})
+
+	if s.Date != "2026-01-02" || s.Entries != 6 || s.Levels["error"] != 3 || s.Levels["warn"] != 1 {
+		t.Errorf("headline = %s %d %v", s.Date, s.Entries, s.Levels)
+	}
+	if len(s.ErrorClusters) != 2 {
+		t.Fatalf("got %d clusters, want 2: %+v", len(s.ErrorClusters), s.ErrorClusters)
+	}
+	top := s.ErrorClusters[0]
+	if top.Signature != "query {n} failed" || top.Count != 2 || strings.Join(top.Components, ",") != "api.db,worker.db" {
+		t.Errorf("top cluster = %+v", top)
+	}
+	if !top.First.Equal(at(9, 5)) || !top.Last.Equal(at(9, 10)) {
+		t.Errorf("top cluster window = %v-%v", top.First, top.Last)
+	}
+	if len(s.NewComponents) != 2 || s.NewComponents[0].Name != "worker.queue" || s.NewComponents[1].Name != "api.cache" {
+		t.Errorf("new components = %+v", s.NewComponents)
+	}
+	if len(s.BusiestHours) != 2 || s.BusiestHours[0].Hour != 9 || s.BusiestHours[0].Entries != 4 || s.BusiestHours[0].Errors != 2 {
+		t.Errorf("busiest hours = %+v", s.BusiestHours)
+	}
+	if len(s.Gaps) != 1 || !s.Gaps[0].Start.Equal(at(9, 20)) || s.Gaps[0].Duration != 3*time.Hour+40*time.Minute {
+		t.Errorf("gaps = %+v", s.Gaps)
+	}
+
+	if got := Summarize(entries, day, nil, SummaryOptions{}); got.NewComponents != nil {
+		t.Errorf("without a baseline, new components = %+v, want nil", got.NewComponents)
+	}
+
+	var md strings.Builder
+	if err := WriteSummaryMarkdown(&md, s, time.UTC); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Log summary for 2026-01-02",
+		"6 entries from api, worker: 3 errors, 1 warnings.",
+		"| 2 | query {n} failed | api.db, worker.db | 09:05 | 09:10 |",
+		"- `worker.queue`, first at 09:20",
+		"| 09:00-10:00 | 4 | 2 |",
+		"- 09:20-13:00, 3h40m without entries",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown is missing %q:\n%s", want, md.String())
+		}
+	}
+}
+
+func TestSummarizeDayReadsDatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("workspace-2026-01-01.log", `{"time":"2026-01-01T10:00:00Z","level":"info","component":"old","msg":"a"}
+`)
+	write("workspace-2026-01-02.log", `{"time":"2026-01-02T10:00:00Z","level":"info","component":"old","msg":"b"}
+{"time":"2026-01-02T11:00:00Z","level":"error","component":"fresh","msg":"c"}
+`)
+	write("workspace-2026-01-03.log", `{"time":"2026-01-03T10:00:00Z","level":"error","component":"later","msg":"d"}
+`)
+
+	day := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	s, err := SummarizeDay([]SummarySource{{Name: "ws", Dir: dir}}, day, SummaryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Entries != 2 || s.Levels["error"] != 1 {
+		t.Errorf("summary = %+v, want the 2 entries of 2026-01-02", s)
+	}
+	if len(s.NewComponents) != 1 || s.NewComponents[0].Name != "fresh" {
+		t.Errorf("new components = %+v, want fresh", s.NewComponents)
+	}
+}
+
+func TestRunAtMidnight(t *testing.T) {
+	now := time.Date(2026, 1, 2, 23, 59, 59, 990_000_000, time.Local)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	days := make(chan time.Time, 1)
+	go runAtMidnight(ctx, func() time.Time { return now }, func(day time.Time) {
+		days <- day
+		cancel()
+	})
+	select {
+	case day := <-days:
+		if want := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local); !day.Equal(want) {
+			t.Errorf("day = %v, want %v", day, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("fn was not called at midnight")
+	}
+}
@@ -38,27 +38,40 @@ type TailedLine struct {
 
 // readLastNLines seeks from the end of an open file and returns the
 // last n complete lines (not counting a trailing empty line from a
-// final newline). It reads in 8 KiB chunks backwards, stopping as
-// soon as the chunk buffer contains more than n newlines. This lets
-// callers tail gigabyte-sized log files without loading the whole
+// final newline). It reads backwards in chunks via ReadLinesBefore, so
+// callers can tail gigabyte-sized log files without loading the whole
 // thing into memory — the old implementation used io.ReadAll, which
 // OOM'd on stale multi-month workspace logs.
 func readLastNLines(f *os.File, n int) ([]string, error) {
-	if n <= 0 {
-		return nil, nil
-	}
 	stat, err := f.Stat()
 	if err != nil {
 		return nil, err
 	}
-	size := stat.Size()
-	if size == 0 {
-		return nil, nil
+	lines, _, err := ReadLinesBefore(f, stat.Size(), n)
+	return lines, err
+}
+
+// ReadLinesBefore returns up to n lines ending at byte offset end of f,
+// oldest first, together with the offset where the first returned line
+// starts. It reads backwards in 8 KiB chunks, stopping as soon as it has
+// n complete lines, so passing the returned offset back as end pages
+// through a huge log one chunk-walk at a time. end is clamped to the
+// file size.
+func ReadLinesBefore(f *os.File, end int64, n int) ([]string, int64, error) {
+	if n <= 0 || end <= 0 {
+		return nil, 0, nil
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	if end > stat.Size() {
+		end = stat.Size()
 	}
 
 	const chunkSize int64 = 8192
 	var buf []byte
-	pos := size
+	pos := end
 	for pos > 0 {
 		readSize := chunkSize
 		if pos < readSize {
@@ -67,26 +80,60 @@ func readLastNLines(f *os.File, n int) ([]string, error) {
 		pos -= readSize
 		chunk := make([]byte, readSize)
 		if _, err := f.ReadAt(chunk, pos); err != nil && err != io.EOF {
-			return nil, err
+			return nil, 0, err
 		}
 		buf = append(chunk, buf...)
-		// We need n+1 newlines to know that the first line we kept
-		// is a complete line (not a mid-line fragment from the first
-		// chunk we read). Once we have that, we can stop.
-		if bytes.Count(buf, []byte{'\n'}) > n {
+		// n newlines before the final one mean the first line we keep
+		// is complete, not a fragment from the start of the first chunk.
+		if bytes.Count(bytes.TrimSuffix(buf, []byte{'\n'}), []byte{'\n'}) >= n {
 			break
 		}
 	}
 
-	lines := strings.Split(string(buf), "\n")
-	// Drop a trailing empty string caused by the file ending in '\n'.
-	if len(lines) > 0 && lines[len(lines)-1] == "" {
-		lines = lines[:len(lines)-1]
+	body := bytes.TrimSuffix(buf, []byte{'\n'})
+	if len(body) == 0 {
+		return nil, pos, nil
+	}
+	start := 0
+	for i, seen := len(body)-1, 0; i >= 0; i-- {
+		if body[i] == '\n' {
+			if seen++; seen == n {
+				start = i + 1
+				break
+			}
+		}
+	}
+	return strings.Split(string(body[start:]), "\n"), pos + int64(start), nil
+}
+
+// CountLinesBefore counts the lines in the first end bytes of f, reading
+// in chunks so the count costs no more memory than a single chunk. A
+// final line without a newline counts.
+func CountLinesBefore(f *os.File, end int64) (int, error) {
+	if end <= 0 {
+		return 0, nil
+	}
+	r := io.NewSectionReader(f, 0, end)
+	chunk := make([]byte, 64*1024)
+	count := 0
+	last := byte('\n')
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			count += bytes.Count(chunk[:n], []byte{'\n'})
+			last = chunk[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
 	}
-	if len(lines) > n {
-		lines = lines[len(lines)-n:]
+	if last != '\n' {
+		count++
 	}
-	return lines, nil
+	return count, nil
 }
 
 // TailFile reads a file and sends new lines to a channel. It is the
@@ -0,0 +1,88 @@
+package logutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLines(t *testing.T, n int, trailingNewline bool) *os.File {
+	t.Helper()
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		// Long lines so pages span several 8 KiB chunks.
+		fmt.Fprintf(&b, "line-%04d %s", i, strings.Repeat("x", 300))
+		if i < n-1 || trailingNewline {
+			b.WriteByte('\n')
+		}
+	}
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestReadLinesBeforePagesBackwards(t *testing.T) {
+	f := writeLines(t, 250, true)
+	stat, _ := f.Stat()
+
+	var got []string
+	end := stat.Size()
+	for pages := 0; end > 0; pages++ {
+		if pages > 10 {
+			t.Fatal("paging did not reach the start of the file")
+		}
+		lines, start, err := ReadLinesBefore(f, end, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start >= end {
+			t.Fatalf("offset did not move back: %d >= %d", start, end)
+		}
+		got = append(lines, got...)
+		end = start
+	}
+	if len(got) != 250 {
+		t.Fatalf("got %d lines, want 250", len(got))
+	}
+	for i, line := range got {
+		if !strings.HasPrefix(line, fmt.Sprintf("line-%04d ", i)) {
+			t.Fatalf("line %d = %.12q", i, line)
+		}
+	}
+}
+
+func TestReadLinesBeforePartialLastLine(t *testing.T) {
+	f := writeLines(t, 3, false)
+	stat, _ := f.Stat()
+	lines, _, err := ReadLinesBefore(f, stat.Size(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "line-0001") || !strings.HasPrefix(lines[1], "line-0002") {
+		t.Errorf("unexpected lines: %d", len(lines))
+	}
+}
+
+func TestCountLinesBefore(t *testing.T) {
+	for _, trailing := range []bool{true, false} {
+		f := writeLines(t, 250, trailing)
+		stat, _ := f.Stat()
+		n, err := CountLinesBefore(f, stat.Size())
+		if err != nil || n != 250 {
+			t.Errorf("trailing=%v: CountLinesBefore = %d, %v; want 250", trailing, n, err)
+		}
+		_, start, _ := ReadLinesBefore(f, stat.Size(), 50)
+		if n, _ := CountLinesBefore(f, start); n != 200 {
+			t.Errorf("trailing=%v: lines before the last page = %d, want 200", trailing, n)
+		}
+	}
+}
@@ -278,11 +278,31 @@ type PlanRunOptions struct {
 type LogStreamOptions struct {
 	Scope     string `json:"scope"`     // "workspace", "ecosystem", "all", "system"
 	Workspace string `json:"workspace"` // Path of the active workspace context
-	Level     string `json:"level"`     // "debug", "info", "warn", "error"
+	Level     string `json:"level"`     // "trace", "debug", "info", "warn", "error"
 	System    bool   `json:"system"`    // Whether to interleave system logs
 	Replay    int    `json:"replay"`    // Number of historical lines to replay
 }
 
+// LogHistoryOptions requests a page of the aggregated log history, newest
+// first, for viewers that load older entries on demand. Scope, Workspace,
+// Level and System filter as in LogStreamOptions.
+type LogHistoryOptions struct {
+	Scope     string    `json:"scope"`
+	Workspace string    `json:"workspace"`
+	Level     string    `json:"level"`
+	System    bool      `json:"system"`
+	Before    time.Time `json:"before"` // Only entries at or before this time; zero means now
+	Limit     int       `json:"limit"`  // Maximum entries in the page; 0 only counts
+}
+
+// LogHistoryPage is one page of the aggregated log history. Entries sharing
+// the Before timestamp are included, so callers drop the ones they already
+// hold.
+type LogHistoryPage struct {
+	Lines     []LogStreamLine `json:"lines"`     // Oldest first
+	Remaining int             `json:"remaining"` // Entries older than the page (all at or before Before when empty); -1 when unknown
+}
+
 // LogStreamLine represents a single workspace log entry in the aggregated stream.
 type LogStreamLine struct {
 	Workspace     string `json:"workspace"`
@@ -290,6 +310,36 @@ type LogStreamLine struct {
 	Line          string `json:"line"`
 }
 
+// LogIngestRequest is a batch of structured log entries sent to the
+// daemon's ingestion endpoint by a process that cannot write to the
+// workspace's log files itself (a container, a remote job). Each entry uses
+// the JSON log entry fields: time, level, msg, component and any others.
+type LogIngestRequest struct {
+	// Workspace is the path of the workspace the entries belong to; they are
+	// written to its log files.
+	Workspace string `json:"workspace"`
+	// Component is used for entries that carry no component of their own.
+	Component string `json:"component,omitempty"`
+	// Source names the sender (a host or container) and is recorded on each
+	// entry as ingest_source.
+	Source  string                   `json:"source,omitempty"`
+	Entries []map[string]interface{} `json:"entries"`
+}
+
+// LogIngestResponse reports how many entries of a LogIngestRequest were
+// written and why the others were not.
+type LogIngestResponse struct {
+	Accepted int                  `json:"accepted"`
+	Rejected []LogIngestRejection `json:"rejected,omitempty"`
+}
+
+// LogIngestRejection is an entry of a LogIngestRequest that was not
+// written.
+type LogIngestRejection struct {
+	Index int    `json:"index"` // Position in LogIngestRequest.Entries
+	Error string `json:"error"`
+}
+
 // LogLine represents a single streamed log entry.
 type LogLine struct {
 	Line      string    `json:"line"`
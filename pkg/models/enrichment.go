@@ -143,3 +143,60 @@ type WorkspaceDelta struct {
 	// delta builders set it, so non-git deltas leave the stored flag intact.
 	ChangedFilesComputed *bool `json:"changed_files_computed,omitempty"`
 }
+
+// Merge folds next, a later delta for the same workspace, into d. Fields
+// next sets replace d's; TaskResults and TestReports are merged per key,
+// since a delta carries only the tasks and reports that changed. Maps are
+// copied rather than modified in place.
+func (d *WorkspaceDelta) Merge(next *WorkspaceDelta) {
+	if next == nil {
+		return
+	}
+	if next.GitStatus != nil {
+		d.GitStatus = next.GitStatus
+	}
+	if next.NoteCounts != nil {
+		d.NoteCounts = next.NoteCounts
+	}
+	if next.PlanStats != nil {
+		d.PlanStats = next.PlanStats
+	}
+	if next.ReleaseInfo != nil {
+		d.ReleaseInfo = next.ReleaseInfo
+	}
+	if next.ActiveBinary != nil {
+		d.ActiveBinary = next.ActiveBinary
+	}
+	if next.CxStats != nil {
+		d.CxStats = next.CxStats
+	}
+	if next.GitRemoteURL != nil {
+		d.GitRemoteURL = next.GitRemoteURL
+	}
+	if next.TaskResults != nil {
+		d.TaskResults = mergeDeltaMap(d.TaskResults, next.TaskResults)
+	}
+	if next.TestReports != nil {
+		d.TestReports = mergeDeltaMap(d.TestReports, next.TestReports)
+	}
+	if next.ChangedFiles != nil {
+		d.ChangedFiles = next.ChangedFiles
+	}
+	if next.BlobHashes != nil {
+		d.BlobHashes = next.BlobHashes
+	}
+	if next.ChangedFilesComputed != nil {
+		d.ChangedFilesComputed = next.ChangedFilesComputed
+	}
+}
+
+func mergeDeltaMap[V any](a, b map[string]V) map[string]V {
+	merged := make(map[string]V, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
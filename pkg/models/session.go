@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/grovetools/core/pkg/process"
 )
 
 // Mux values identify which multiplexer owns an agent session's PTY.
@@ -36,6 +38,10 @@ type Session struct {
 	PlanDirectory string `json:"plan_directory,omitempty" db:"plan_directory"`
 	JobTitle      string `json:"job_title,omitempty" db:"job_title"`
 	JobFilePath   string `json:"job_file_path,omitempty" db:"job_file_path"`
+	// JobFileIssues lists what is wrong with the frontmatter of the file at
+	// JobFilePath when it is not a valid job file; its fields are then not
+	// used. Set by session discovery.
+	JobFileIssues []string `json:"job_file_issues,omitempty" db:"-"`
 
 	// ClaudeSessionID stores the original UUID of a claude_code session when it's
 	// managed by a grove-flow interactive_agent job.
@@ -49,6 +55,11 @@ type Session struct {
 	// Daemon PTY session ID for agent processes owned by groved.
 	PtyID string `json:"pty_id,omitempty" db:"pty_id"`
 
+	// Fingerprint is the start time and argv hash recorded for PID at
+	// registration. Collectors check liveness with process.IsSameProcess so
+	// a recycled PID does not keep a dead session alive.
+	Fingerprint *process.Fingerprint `json:"process_fingerprint,omitempty" db:"-"`
+
 	// Live token usage for the agent's Claude session, computed by the daemon
 	// session collector from the Claude transcript (agentlogs/pkg/usage) and
 	// broadcast over /api/stream + /api/sessions. These are derived, throttled
@@ -74,6 +85,14 @@ type Session struct {
 	// known live children"; Status=="idle" && LiveChildren>0 is "idle-busy".
 	LiveChildren int `json:"live_children,omitempty" db:"-"`
 
+	// Idle is set when a live session has shown no activity — transcript
+	// writes, hook status updates — for the idle threshold
+	// (daemon.collectors.session.idle_threshold), so dashboards can pick out
+	// stalled agents. It is observed by sessions.MarkIdle from LastActivity,
+	// unlike Status "idle", which the agent's hooks report when it waits for
+	// input. A derived snapshot; not persisted.
+	Idle bool `json:"idle,omitempty" db:"-"`
+
 	// Channel & Autonomous support
 	Channels        []string          `json:"channels,omitempty" db:"-"`
 	Autonomous      *AutonomousConfig `json:"autonomous,omitempty" db:"-"`
@@ -84,6 +103,11 @@ type Session struct {
 	LastSenderGroup string            `json:"last_sender_group,omitempty" db:"-"`
 	SignalTarget    string            `json:"signal_target,omitempty" db:"-"`
 
+	// TmuxPane is the tmux pane the session's process runs in, when the
+	// session collector could correlate it. Not persisted in the database;
+	// the filesystem registry carries it across daemon restarts.
+	TmuxPane *TmuxPaneRef `json:"tmux_pane,omitempty" db:"-"`
+
 	// Origin namespaces a session to the satellite it came from (M2 contract C6).
 	// Empty == local (every existing session). A non-empty value is the
 	// satellite's registry NAME, stamped laptop-side by the SatelliteCollector
@@ -92,6 +116,20 @@ type Session struct {
 	// enter the local sessions registry / crash-recovery machinery.
 	Origin string `json:"origin,omitempty" db:"-"`
 
+	// Usage is the accumulated token, cost and duration accounting for the
+	// session, aggregated by the session collector from the agent provider's
+	// usage files (Claude transcripts, OpenCode message stats) with a
+	// sessions.UsageCollector. Unlike the live snapshot fields above it
+	// covers the whole session history; a Claude session's cost is its
+	// LiveCostUSD. Not persisted.
+	Usage *SessionUsage `json:"usage,omitempty" db:"-"`
+
+	// Activities is the session's timeline of messages, tool calls and file
+	// edits, oldest first, read from the agent provider's transcript by
+	// sessions.CollectActivities where the provider records them. Populated
+	// on demand; not persisted.
+	Activities []SessionActivity `json:"activities,omitempty" db:"-"`
+
 	// Test mode
 	IsTest    bool `json:"is_test" db:"is_test"`
 	IsDeleted bool `json:"-" db:"is_deleted"` // Keep as internal field
@@ -106,6 +144,85 @@ type Session struct {
 	Subagents     []SubagentExecution  `json:"subagents" db:"-"`
 }
 
+// SessionUsage accumulates token usage, cost and active duration for a
+// session (or, when summed with Add, for a group of sessions such as all
+// sessions of one repo).
+type SessionUsage struct {
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	// Duration is the span between the first and last recorded activity.
+	Duration time.Duration `json:"duration_ns"`
+	Messages int           `json:"messages"`
+	// FirstActivity and LastActivity bound the recorded usage.
+	FirstActivity time.Time `json:"first_activity,omitempty"`
+	LastActivity  time.Time `json:"last_activity,omitempty"`
+}
+
+// Activity kinds of a SessionActivity.
+const (
+	ActivityMessage  = "message"
+	ActivityToolCall = "tool_call"
+	ActivityFileEdit = "file_edit"
+)
+
+// SessionActivity is one entry of a session's timeline: a user or assistant
+// message, a tool call, or a tool call that edits a file.
+type SessionActivity struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"` // message|tool_call|file_edit
+	// Role is the message author ("user" or "assistant") for messages.
+	Role string `json:"role,omitempty"`
+	// Tool is the tool name for tool calls and file edits.
+	Tool string `json:"tool,omitempty"`
+	// File is the path a file edit wrote, or a tool call read.
+	File string `json:"file,omitempty"`
+	// Summary is a one-line description: the first line of a message, or
+	// the command, pattern or description of a tool call.
+	Summary string `json:"summary,omitempty"`
+}
+
+// TotalTokens returns the sum of all token counters.
+func (u SessionUsage) TotalTokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// Add accumulates other into u. Durations are summed, so for a group of
+// sessions Duration is the total agent time rather than wall-clock span.
+func (u *SessionUsage) Add(other SessionUsage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CacheWriteTokens += other.CacheWriteTokens
+	u.CostUSD += other.CostUSD
+	u.Duration += other.Duration
+	u.Messages += other.Messages
+	if !other.FirstActivity.IsZero() && (u.FirstActivity.IsZero() || other.FirstActivity.Before(u.FirstActivity)) {
+		u.FirstActivity = other.FirstActivity
+	}
+	if other.LastActivity.After(u.LastActivity) {
+		u.LastActivity = other.LastActivity
+	}
+}
+
+// Duration returns how long the session has been running: until EndedAt for
+// finished sessions, otherwise until LastActivity.
+func (s *Session) Duration() time.Duration {
+	if s.StartedAt.IsZero() {
+		return 0
+	}
+	end := s.LastActivity
+	if s.EndedAt != nil {
+		end = *s.EndedAt
+	}
+	if end.Before(s.StartedAt) {
+		return 0
+	}
+	return end.Sub(s.StartedAt)
+}
+
 // Summary represents the overall session summary including AI analysis
 type Summary struct {
 	// Summary statistics
@@ -1,5 +1,7 @@
 package models
 
+import "fmt"
+
 // TmuxSession represents a tmux session configuration
 type TmuxSession struct {
 	Key         string `json:"key"`
@@ -15,3 +17,23 @@ type GitStatus struct {
 	HasChanges bool   `json:"hasChanges"`
 	IsClean    bool   `json:"isClean"`
 }
+
+// TmuxPaneRef locates the tmux pane hosting a live session, so attach and
+// focus actions can jump straight to it.
+type TmuxPaneRef struct {
+	// Session is the tmux session name.
+	Session     string `json:"session"`
+	WindowIndex int    `json:"window_index"`
+	WindowName  string `json:"window_name,omitempty"`
+	PaneIndex   int    `json:"pane_index"`
+	// PaneID is tmux's stable pane identifier (e.g. "%12"). Unlike the
+	// indexes it survives window and pane reordering.
+	PaneID string `json:"pane_id"`
+	// Socket is the tmux server socket name (-L), empty for the default server.
+	Socket string `json:"socket,omitempty"`
+}
+
+// Target returns the "session:window.pane" form accepted by tmux -t.
+func (p TmuxPaneRef) Target() string {
+	return fmt.Sprintf("%s:%d.%d", p.Session, p.WindowIndex, p.PaneIndex)
+}
@@ -0,0 +1,298 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// WorkspaceSchemaVersion is the version of the public workspace types in
+// this file (Workspace, WorkspaceTree, Project, Ecosystem). It is emitted as
+// schema_version on every top-level value so consumers of `core ws --json`
+// and the daemon API can detect contract changes. Adding optional fields
+// does not bump the version; renaming, removing or changing the meaning of
+// a field does.
+const WorkspaceSchemaVersion = 1
+
+// CheckWorkspaceSchemaVersion reports whether a decoded value with the
+// given schema_version can be read by this package. Zero is accepted: it is
+// what output written before versioning decodes to, and that output has the
+// same shape as version 1.
+func CheckWorkspaceSchemaVersion(v int) error {
+	if v < 0 || v > WorkspaceSchemaVersion {
+		return fmt.Errorf("unsupported workspace schema version %d (this build understands up to %d)", v, WorkspaceSchemaVersion)
+	}
+	return nil
+}
+
+// Workspace is the stable public form of workspace.WorkspaceNode: one
+// discovered project, ecosystem or worktree. Field names match the JSON
+// that WorkspaceNode has always produced, so older consumers keep working.
+type Workspace struct {
+	SchemaVersion int `json:"schema_version"`
+
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Kind is one of the workspace.Kind* values, e.g. "StandaloneProject"
+	// or "EcosystemWorktree".
+	Kind string `json:"kind"`
+
+	// ParentProjectPath is the repository that manages this worktree. Set
+	// only for worktree kinds.
+	ParentProjectPath string `json:"parent_project_path,omitempty"`
+	// ParentEcosystemPath is the immediate ecosystem (root or worktree)
+	// containing this workspace.
+	ParentEcosystemPath string `json:"parent_ecosystem_path,omitempty"`
+	// RootEcosystemPath is the top-level ecosystem root.
+	RootEcosystemPath string `json:"root_ecosystem_path,omitempty"`
+	// NotebookName is the notebooks.definitions entry this workspace uses.
+	NotebookName string `json:"notebook_name,omitempty"`
+
+	// Fields below are set for repositories cloned by `core repo`.
+	Version       string `json:"version,omitempty"`
+	Commit        string `json:"commit,omitempty"`
+	AuditStatus   string `json:"audit_status,omitempty"`
+	ReportPath    string `json:"report_path,omitempty"`
+	RepoURL       string `json:"repo_url,omitempty"`
+	RepoShorthand string `json:"repo_shorthand,omitempty"`
+
+	// Metadata is the description, tags, owners and links from the
+	// workspace's grove.yml (its project's, for worktrees).
+	Metadata *WorkspaceMetadata `json:"metadata,omitempty"`
+
+	// Host is the machine of a workspace discovered through an ssh://
+	// grove; Path is a path on that host. Empty for local workspaces.
+	Host string `json:"host,omitempty"`
+}
+
+// WorkspaceMetadata is the stable public form of workspace.Metadata.
+type WorkspaceMetadata struct {
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Owners      []string          `json:"owners,omitempty"`
+	Links       map[string]string `json:"links,omitempty"`
+}
+
+// NewWorkspace converts a discovery node to its public form.
+func NewWorkspace(n *workspace.WorkspaceNode) Workspace {
+	if n == nil {
+		return Workspace{SchemaVersion: WorkspaceSchemaVersion}
+	}
+	return Workspace{
+		SchemaVersion:       WorkspaceSchemaVersion,
+		Name:                n.Name,
+		Path:                n.Path,
+		Kind:                string(n.Kind),
+		ParentProjectPath:   n.ParentProjectPath,
+		ParentEcosystemPath: n.ParentEcosystemPath,
+		RootEcosystemPath:   n.RootEcosystemPath,
+		NotebookName:        n.NotebookName,
+		Version:             n.Version,
+		Commit:              n.Commit,
+		AuditStatus:         n.AuditStatus,
+		ReportPath:          n.ReportPath,
+		RepoURL:             n.RepoURL,
+		RepoShorthand:       n.RepoShorthand,
+		Metadata:            (*WorkspaceMetadata)(n.Metadata),
+		Host:                n.Host,
+	}
+}
+
+// NewWorkspaces converts a slice of discovery nodes, skipping nil entries.
+func NewWorkspaces(nodes []*workspace.WorkspaceNode) []Workspace {
+	out := make([]Workspace, 0, len(nodes))
+	for _, n := range nodes {
+		if n != nil {
+			out = append(out, NewWorkspace(n))
+		}
+	}
+	return out
+}
+
+// Node converts the public form back to a discovery node. Presentation
+// fields (TreePrefix, Depth) are left zero.
+func (w Workspace) Node() *workspace.WorkspaceNode {
+	return &workspace.WorkspaceNode{
+		Name:                w.Name,
+		Path:                w.Path,
+		Kind:                workspace.WorkspaceKind(w.Kind),
+		ParentProjectPath:   w.ParentProjectPath,
+		ParentEcosystemPath: w.ParentEcosystemPath,
+		RootEcosystemPath:   w.RootEcosystemPath,
+		NotebookName:        w.NotebookName,
+		Version:             w.Version,
+		Commit:              w.Commit,
+		AuditStatus:         w.AuditStatus,
+		ReportPath:          w.ReportPath,
+		RepoURL:             w.RepoURL,
+		RepoShorthand:       w.RepoShorthand,
+		Metadata:            (*workspace.Metadata)(w.Metadata),
+		Host:                w.Host,
+	}
+}
+
+// Workspace returns the stable public form of the enriched workspace's
+// node, without enrichment data.
+func (e *EnrichedWorkspace) Workspace() Workspace {
+	return NewWorkspace(e.WorkspaceNode)
+}
+
+// WorkspaceTree is the stable public form of workspace.WorkspaceTree.
+type WorkspaceTree struct {
+	Node     Workspace       `json:"node"`
+	Children []WorkspaceTree `json:"children"`
+}
+
+// NewWorkspaceTree converts a discovery tree to its public form.
+func NewWorkspaceTree(t *workspace.WorkspaceTree) WorkspaceTree {
+	if t == nil {
+		return WorkspaceTree{Node: NewWorkspace(nil), Children: []WorkspaceTree{}}
+	}
+	out := WorkspaceTree{
+		Node:     NewWorkspace(t.Node),
+		Children: make([]WorkspaceTree, 0, len(t.Children)),
+	}
+	for _, c := range t.Children {
+		out.Children = append(out.Children, NewWorkspaceTree(c))
+	}
+	return out
+}
+
+// DiscoveredWorkspace is the stable public form of
+// workspace.DiscoveredWorkspace: one checkout (primary or worktree) of a
+// Project.
+type DiscoveredWorkspace struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Type is "Primary" or "Worktree".
+	Type              string `json:"type"`
+	ParentProjectPath string `json:"parent_project_path"`
+}
+
+// Submodule is the stable public form of workspace.Submodule: one
+// .gitmodules entry of a Project.
+type Submodule struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	URL         string `json:"url,omitempty"`
+	Initialized bool   `json:"initialized"`
+}
+
+// Project is the stable public form of workspace.Project.
+type Project struct {
+	SchemaVersion int `json:"schema_version"`
+
+	Name                string                `json:"name"`
+	Path                string                `json:"path"`
+	Type                string                `json:"type"`
+	ModulePath          string                `json:"module_path,omitempty"`
+	ParentEcosystemPath string                `json:"parent_ecosystem_path,omitempty"`
+	Workspaces          []DiscoveredWorkspace `json:"workspaces"`
+
+	WorktreeSourceBase string `json:"worktree_source_base,omitempty"`
+	WorktreeOwnerPath  string `json:"worktree_owner_path,omitempty"`
+
+	Version       string `json:"version,omitempty"`
+	Commit        string `json:"commit,omitempty"`
+	AuditStatus   string `json:"audit_status,omitempty"`
+	ReportPath    string `json:"report_path,omitempty"`
+	RepoURL       string `json:"repo_url,omitempty"`
+	RepoShorthand string `json:"repo_shorthand,omitempty"`
+
+	// Submodules is set only when discovery ran with submodule inventory.
+	Submodules []Submodule `json:"submodules,omitempty"`
+
+	Metadata *WorkspaceMetadata `json:"metadata,omitempty"`
+}
+
+// NewProject converts a discovered project to its public form.
+func NewProject(p workspace.Project) Project {
+	out := Project{
+		SchemaVersion:       WorkspaceSchemaVersion,
+		Name:                p.Name,
+		Path:                p.Path,
+		Type:                p.Type,
+		ModulePath:          p.ModulePath,
+		ParentEcosystemPath: p.ParentEcosystemPath,
+		Workspaces:          make([]DiscoveredWorkspace, 0, len(p.Workspaces)),
+		WorktreeSourceBase:  p.WorktreeSourceBase,
+		WorktreeOwnerPath:   p.WorktreeOwnerPath,
+		Version:             p.Version,
+		Commit:              p.Commit,
+		AuditStatus:         p.AuditStatus,
+		ReportPath:          p.ReportPath,
+		RepoURL:             p.RepoURL,
+		RepoShorthand:       p.RepoShorthand,
+		Metadata:            (*WorkspaceMetadata)(p.Metadata),
+	}
+	for _, w := range p.Workspaces {
+		out.Workspaces = append(out.Workspaces, DiscoveredWorkspace{
+			Name:              w.Name,
+			Path:              w.Path,
+			Type:              string(w.Type),
+			ParentProjectPath: w.ParentProjectPath,
+		})
+	}
+	for _, sub := range p.Submodules {
+		out.Submodules = append(out.Submodules, Submodule(sub))
+	}
+	return out
+}
+
+// Project converts the public form back to a discovered project.
+func (p Project) Project() workspace.Project {
+	out := workspace.Project{
+		Name:                p.Name,
+		Path:                p.Path,
+		Type:                p.Type,
+		ModulePath:          p.ModulePath,
+		ParentEcosystemPath: p.ParentEcosystemPath,
+		WorktreeSourceBase:  p.WorktreeSourceBase,
+		WorktreeOwnerPath:   p.WorktreeOwnerPath,
+		Version:             p.Version,
+		Commit:              p.Commit,
+		AuditStatus:         p.AuditStatus,
+		ReportPath:          p.ReportPath,
+		RepoURL:             p.RepoURL,
+		RepoShorthand:       p.RepoShorthand,
+		Metadata:            (*workspace.Metadata)(p.Metadata),
+	}
+	for _, w := range p.Workspaces {
+		out.Workspaces = append(out.Workspaces, workspace.DiscoveredWorkspace{
+			Name:              w.Name,
+			Path:              w.Path,
+			Type:              workspace.WorkspaceType(w.Type),
+			ParentProjectPath: w.ParentProjectPath,
+		})
+	}
+	for _, sub := range p.Submodules {
+		out.Submodules = append(out.Submodules, workspace.Submodule(sub))
+	}
+	return out
+}
+
+// Ecosystem is the stable public form of workspace.Ecosystem.
+type Ecosystem struct {
+	SchemaVersion int    `json:"schema_version"`
+	Name          string `json:"name"`
+	Path          string `json:"path"`
+	// Type is "Grove" or "User".
+	Type     string             `json:"type"`
+	Metadata *WorkspaceMetadata `json:"metadata,omitempty"`
+}
+
+// NewEcosystem converts a discovered ecosystem to its public form.
+func NewEcosystem(e workspace.Ecosystem) Ecosystem {
+	return Ecosystem{
+		SchemaVersion: WorkspaceSchemaVersion,
+		Name:          e.Name,
+		Path:          e.Path,
+		Type:          e.Type,
+		Metadata:      (*WorkspaceMetadata)(e.Metadata),
+	}
+}
+
+// Ecosystem converts the public form back to a discovered ecosystem.
+func (e Ecosystem) Ecosystem() workspace.Ecosystem {
+	return workspace.Ecosystem{Name: e.Name, Path: e.Path, Type: e.Type, Metadata: (*workspace.Metadata)(e.Metadata)}
+}
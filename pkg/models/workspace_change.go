@@ -0,0 +1,69 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// WorkspaceChangeType says how a workspace changed between two discovery
+// scans.
+type WorkspaceChangeType string
+
+const (
+	WorkspaceAdded    WorkspaceChangeType = "WorkspaceAdded"
+	WorkspaceRemoved  WorkspaceChangeType = "WorkspaceRemoved"
+	WorkspaceModified WorkspaceChangeType = "WorkspaceModified"
+)
+
+// WorkspaceChange is one event on the daemon's workspace change stream.
+// Workspace is the node after the change, or the last known node for
+// WorkspaceRemoved, so subscribers can update a picker without another
+// request.
+type WorkspaceChange struct {
+	Type      WorkspaceChangeType `json:"type"`
+	Workspace Workspace           `json:"workspace"`
+}
+
+// DiffWorkspaces compares two discovery scans by path and returns the
+// changes that turn prev into next: removals first, then additions, then
+// modifications, each sorted by path. A workspace is modified when any
+// field of its public form differs; presentation fields are ignored.
+func DiffWorkspaces(prev, next []*workspace.WorkspaceNode) []WorkspaceChange {
+	before := workspacesByPath(prev)
+	after := workspacesByPath(next)
+
+	var removed, added, modified []WorkspaceChange
+	for path, old := range before {
+		cur, ok := after[path]
+		switch {
+		case !ok:
+			removed = append(removed, WorkspaceChange{Type: WorkspaceRemoved, Workspace: old})
+		case !reflect.DeepEqual(cur, old):
+			modified = append(modified, WorkspaceChange{Type: WorkspaceModified, Workspace: cur})
+		}
+	}
+	for path, cur := range after {
+		if _, ok := before[path]; !ok {
+			added = append(added, WorkspaceChange{Type: WorkspaceAdded, Workspace: cur})
+		}
+	}
+
+	var out []WorkspaceChange
+	for _, cs := range [][]WorkspaceChange{removed, added, modified} {
+		sort.Slice(cs, func(i, j int) bool { return cs[i].Workspace.Path < cs[j].Workspace.Path })
+		out = append(out, cs...)
+	}
+	return out
+}
+
+func workspacesByPath(nodes []*workspace.WorkspaceNode) map[string]Workspace {
+	out := make(map[string]Workspace, len(nodes))
+	for _, n := range nodes {
+		if n != nil {
+			out[n.Path] = NewWorkspace(n)
+		}
+	}
+	return out
+}
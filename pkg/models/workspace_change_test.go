@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func TestDiffWorkspaces(t *testing.T) {
+	prev := []*workspace.WorkspaceNode{
+		{Name: "a", Path: "/a", Kind: workspace.KindStandaloneProject},
+		{Name: "b", Path: "/b", Kind: workspace.KindStandaloneProject},
+		{Name: "c", Path: "/c", Kind: workspace.KindStandaloneProject, TreePrefix: "├─ "},
+	}
+	next := []*workspace.WorkspaceNode{
+		{Name: "a", Path: "/a", Kind: workspace.KindStandaloneProject, NotebookName: "main"},
+		{Name: "c", Path: "/c", Kind: workspace.KindStandaloneProject, TreePrefix: "└─ "},
+		{Name: "d", Path: "/d", Kind: workspace.KindEcosystemRoot},
+		nil,
+	}
+
+	got := DiffWorkspaces(prev, next)
+	want := []struct {
+		typ  WorkspaceChangeType
+		path string
+	}{
+		{WorkspaceRemoved, "/b"},
+		{WorkspaceAdded, "/d"},
+		{WorkspaceModified, "/a"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Type != w.typ || got[i].Workspace.Path != w.path {
+			t.Errorf("change %d = %s %s, want %s %s", i, got[i].Type, got[i].Workspace.Path, w.typ, w.path)
+		}
+	}
+	if got[2].Workspace.NotebookName != "main" {
+		t.Errorf("modified payload should carry the new node, got %+v", got[2].Workspace)
+	}
+	if got[0].Workspace.Name != "b" {
+		t.Errorf("removed payload should carry the last known node, got %+v", got[0].Workspace)
+	}
+
+	if changes := DiffWorkspaces(next, next); len(changes) != 0 {
+		t.Errorf("identical scans should not differ, got %+v", changes)
+	}
+
+	// Metadata compares by value: a rescan allocates new metadata.
+	meta := func(desc string) []*workspace.WorkspaceNode {
+		return []*workspace.WorkspaceNode{{Name: "a", Path: "/a", Metadata: &workspace.Metadata{Description: desc, Tags: []string{"go"}}}}
+	}
+	if changes := DiffWorkspaces(meta("api"), meta("api")); len(changes) != 0 {
+		t.Errorf("equal metadata should not differ, got %+v", changes)
+	}
+	if changes := DiffWorkspaces(meta("api"), meta("billing api")); len(changes) != 1 || changes[0].Type != WorkspaceModified {
+		t.Errorf("changed metadata should modify the workspace, got %+v", changes)
+	}
+}
+
+func TestWorkspaceChangeJSON(t *testing.T) {
+	data, err := json.Marshal(WorkspaceChange{
+		Type:      WorkspaceAdded,
+		Workspace: NewWorkspace(&workspace.WorkspaceNode{Name: "a", Path: "/a"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["type"] != "WorkspaceAdded" {
+		t.Errorf("type = %v", decoded["type"])
+	}
+	ws, _ := decoded["workspace"].(map[string]interface{})
+	if ws["path"] != "/a" || ws["schema_version"] != float64(WorkspaceSchemaVersion) {
+		t.Errorf("workspace = %v", ws)
+	}
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// Edge kinds in a WorkspaceGraph.
+const (
+	// WorkspaceEdgeEcosystem links an ecosystem (root or worktree) to a
+	// workspace it contains.
+	WorkspaceEdgeEcosystem = "ecosystem"
+	// WorkspaceEdgeWorktree links a repository to one of its worktrees.
+	WorkspaceEdgeWorktree = "worktree"
+)
+
+// WorkspaceGraph is the ecosystem → project → worktree relationship graph
+// printed by `core ws graph`. Nodes are identified by path; edges point
+// from the containing workspace to the contained one.
+type WorkspaceGraph struct {
+	SchemaVersion int                  `json:"schema_version"`
+	Nodes         []WorkspaceGraphNode `json:"nodes"`
+	Edges         []WorkspaceGraphEdge `json:"edges"`
+}
+
+// WorkspaceGraphNode is one workspace in a WorkspaceGraph.
+type WorkspaceGraphNode struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// RepoURL is set for repositories cloned by `core repo`.
+	RepoURL string `json:"repo_url,omitempty"`
+}
+
+// WorkspaceGraphEdge is one relationship in a WorkspaceGraph.
+type WorkspaceGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"` // WorkspaceEdgeEcosystem or WorkspaceEdgeWorktree
+}
+
+// NewWorkspaceGraph builds the relationship graph of discovered workspaces.
+// A worktree is linked to the repository that manages it, and a workspace
+// inside an ecosystem to its immediate parent ecosystem unless that is the
+// same repository. Parents that are not among nodes are left out, so the
+// graph of a filtered node list stays self-contained.
+func NewWorkspaceGraph(nodes []*workspace.WorkspaceNode) WorkspaceGraph {
+	g := WorkspaceGraph{SchemaVersion: WorkspaceSchemaVersion, Nodes: []WorkspaceGraphNode{}, Edges: []WorkspaceGraphEdge{}}
+	known := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		if n == nil || known[n.Path] {
+			continue
+		}
+		known[n.Path] = true
+		g.Nodes = append(g.Nodes, WorkspaceGraphNode{Path: n.Path, Name: n.Name, Kind: string(n.Kind), RepoURL: n.RepoURL})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].Path < g.Nodes[j].Path })
+
+	seen := make(map[WorkspaceGraphEdge]bool)
+	addEdge := func(from, to, kind string) {
+		e := WorkspaceGraphEdge{From: from, To: to, Kind: kind}
+		if from == "" || from == to || !known[from] || seen[e] {
+			return
+		}
+		seen[e] = true
+		g.Edges = append(g.Edges, e)
+	}
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		addEdge(n.ParentProjectPath, n.Path, WorkspaceEdgeWorktree)
+		if n.ParentEcosystemPath != n.ParentProjectPath {
+			addEdge(n.ParentEcosystemPath, n.Path, WorkspaceEdgeEcosystem)
+		}
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g
+}
+
+// graphIDs assigns the short node IDs used by the DOT and Mermaid renderers,
+// in node order.
+func (g WorkspaceGraph) graphIDs() map[string]string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.Path] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+// WriteDOT renders the graph in Graphviz DOT. Ecosystems are drawn as
+// folders, bare and cloned repositories as cylinders and worktree edges
+// dashed.
+func (g WorkspaceGraph) WriteDOT(w io.Writer) error {
+	ids := g.graphIDs()
+	var b strings.Builder
+	b.WriteString("digraph workspaces {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + dotQuote(n.Name), "tooltip=" + dotQuote(n.Path)}
+		switch {
+		case isEcosystemKind(n.Kind):
+			attrs = append(attrs, "shape=folder")
+		case n.Kind == string(workspace.KindBareRepo) || n.RepoURL != "":
+			attrs = append(attrs, "shape=cylinder")
+		case workspace.WorkspaceKind(n.Kind).IsWorktree():
+			attrs = append(attrs, "style=rounded")
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", ids[n.Path], strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		style := ""
+		if e.Kind == WorkspaceEdgeWorktree {
+			style = " [style=dashed]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", ids[e.From], ids[e.To], style)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart, with the same
+// shape conventions as WriteDOT and dotted worktree edges.
+func (g WorkspaceGraph) WriteMermaid(w io.Writer) error {
+	ids := g.graphIDs()
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		label := mermaidQuote(n.Name)
+		var shape string
+		switch {
+		case isEcosystemKind(n.Kind):
+			shape = "[[" + label + "]]"
+		case n.Kind == string(workspace.KindBareRepo) || n.RepoURL != "":
+			shape = "[(" + label + ")]"
+		case workspace.WorkspaceKind(n.Kind).IsWorktree():
+			shape = "(" + label + ")"
+		default:
+			shape = "[" + label + "]"
+		}
+		fmt.Fprintf(&b, "  %s%s\n", ids[n.Path], shape)
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Kind == WorkspaceEdgeWorktree {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func isEcosystemKind(kind string) bool {
+	return kind == string(workspace.KindEcosystemRoot) || kind == string(workspace.KindEcosystemWorktree)
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func TestNewWorkspaceGraph(t *testing.T) {
+	nodes := []*workspace.WorkspaceNode{
+		{Name: "eco", Path: "/eco", Kind: workspace.KindEcosystemRoot},
+		{Name: "sub", Path: "/eco/sub", Kind: workspace.KindEcosystemSubProject, ParentEcosystemPath: "/eco", RootEcosystemPath: "/eco"},
+		{Name: "feat", Path: "/eco/.grove-worktrees/feat", Kind: workspace.KindEcosystemWorktree,
+			ParentProjectPath: "/eco", ParentEcosystemPath: "/eco", RootEcosystemPath: "/eco"},
+		{Name: "sub", Path: "/eco/.grove-worktrees/feat/sub", Kind: workspace.KindEcosystemWorktreeSubProjectWorktree,
+			ParentProjectPath: "/eco/sub", ParentEcosystemPath: "/eco/.grove-worktrees/feat", RootEcosystemPath: "/eco"},
+		{Name: `lib "x"`, Path: "/repos/lib.git", Kind: workspace.KindBareRepo, RepoURL: "https://example.com/lib"},
+		// Parent outside the node list: no dangling edge.
+		{Name: "orphan", Path: "/other/wt", Kind: workspace.KindStandaloneProjectWorktree, ParentProjectPath: "/other"},
+	}
+
+	g := NewWorkspaceGraph(nodes)
+	if len(g.Nodes) != 6 {
+		t.Fatalf("nodes = %d, want 6", len(g.Nodes))
+	}
+	var edges []string
+	for _, e := range g.Edges {
+		edges = append(edges, e.Kind+":"+e.From+"->"+e.To)
+	}
+	want := []string{
+		"worktree:/eco->/eco/.grove-worktrees/feat",
+		"ecosystem:/eco->/eco/sub",
+		"ecosystem:/eco/.grove-worktrees/feat->/eco/.grove-worktrees/feat/sub",
+		"worktree:/eco/sub->/eco/.grove-worktrees/feat/sub",
+	}
+	if strings.Join(edges, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("edges =\n%s\nwant\n%s", strings.Join(edges, "\n"), strings.Join(want, "\n"))
+	}
+
+	var dot strings.Builder
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	for _, frag := range []string{"digraph workspaces {", `label="eco", tooltip="/eco", shape=folder`, `label="lib \"x\""`, "shape=cylinder", "-> n", "[style=dashed]"} {
+		if !strings.Contains(dot.String(), frag) {
+			t.Errorf("DOT output missing %q:\n%s", frag, dot.String())
+		}
+	}
+
+	var mermaid strings.Builder
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+	for _, frag := range []string{"flowchart LR", `[["eco"]]`, `[("lib #quot;x#quot;")]`, " -.-> ", " --> "} {
+		if !strings.Contains(mermaid.String(), frag) {
+			t.Errorf("Mermaid output missing %q:\n%s", frag, mermaid.String())
+		}
+	}
+}
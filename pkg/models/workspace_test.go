@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func TestWorkspaceRoundTrip(t *testing.T) {
+	node := &workspace.WorkspaceNode{
+		Name:                "sub",
+		Path:                "/eco/.grove-worktrees/feat/sub",
+		Kind:                workspace.KindEcosystemWorktreeSubProjectWorktree,
+		ParentProjectPath:   "/eco/sub",
+		ParentEcosystemPath: "/eco/.grove-worktrees/feat",
+		RootEcosystemPath:   "/eco",
+		NotebookName:        "main",
+		RepoURL:             "https://github.com/a/b",
+		Metadata:            &workspace.Metadata{Description: "sub project", Owners: []string{"@team"}},
+		Host:                "devbox",
+		TreePrefix:          "  ├─ ",
+		Depth:               2,
+	}
+
+	pub := NewWorkspace(node)
+	if pub.SchemaVersion != WorkspaceSchemaVersion {
+		t.Fatalf("expected schema version %d, got %d", WorkspaceSchemaVersion, pub.SchemaVersion)
+	}
+
+	back := pub.Node()
+	node.TreePrefix, node.Depth = "", 0
+	if *back != *node {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", *back, *node)
+	}
+}
+
+// TestWorkspaceJSONCompatible guards the public contract: everything the
+// legacy WorkspaceNode JSON carried must decode from the versioned form.
+func TestWorkspaceJSONCompatible(t *testing.T) {
+	node := &workspace.WorkspaceNode{
+		Name:              "proj",
+		Path:              "/p",
+		Kind:              workspace.KindStandaloneProject,
+		ParentProjectPath: "/parent",
+		Commit:            "abc123",
+	}
+	data, err := json.Marshal(NewWorkspace(node))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var legacy workspace.WorkspaceNode
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if legacy != *node {
+		t.Errorf("legacy decode mismatch:\n got %+v\nwant %+v", legacy, *node)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["schema_version"] != float64(WorkspaceSchemaVersion) {
+		t.Errorf("expected schema_version in JSON, got %v", raw["schema_version"])
+	}
+}
+
+func TestProjectRoundTrip(t *testing.T) {
+	p := workspace.Project{
+		Name: "proj",
+		Path: "/p",
+		Type: "Grove",
+		Workspaces: []workspace.DiscoveredWorkspace{
+			{Name: "main", Path: "/p", Type: workspace.WorkspaceTypePrimary, ParentProjectPath: "/p"},
+			{Name: "feat", Path: "/p/.grove-worktrees/feat", Type: workspace.WorkspaceTypeWorktree, ParentProjectPath: "/p"},
+		},
+	}
+	back := NewProject(p).Project()
+	if back.Name != p.Name || len(back.Workspaces) != 2 || back.Workspaces[1] != p.Workspaces[1] {
+		t.Errorf("round trip mismatch: %+v", back)
+	}
+}
+
+func TestWorkspaceTreeConversion(t *testing.T) {
+	tree := &workspace.WorkspaceTree{
+		Node: &workspace.WorkspaceNode{Name: "eco", Kind: workspace.KindEcosystemRoot},
+		Children: []*workspace.WorkspaceTree{
+			{Node: &workspace.WorkspaceNode{Name: "child", Kind: workspace.KindEcosystemSubProject}},
+		},
+	}
+	pub := NewWorkspaceTree(tree)
+	if pub.Node.Name != "eco" || len(pub.Children) != 1 || pub.Children[0].Node.Name != "child" {
+		t.Errorf("unexpected tree: %+v", pub)
+	}
+	if pub.Children[0].Children == nil {
+		t.Error("leaf children should encode as [] rather than null")
+	}
+}
+
+func TestCheckWorkspaceSchemaVersion(t *testing.T) {
+	if err := CheckWorkspaceSchemaVersion(0); err != nil {
+		t.Errorf("pre-versioned output should be accepted: %v", err)
+	}
+	if err := CheckWorkspaceSchemaVersion(WorkspaceSchemaVersion); err != nil {
+		t.Errorf("current version should be accepted: %v", err)
+	}
+	if err := CheckWorkspaceSchemaVersion(WorkspaceSchemaVersion + 1); err == nil {
+		t.Error("newer version should be rejected")
+	}
+}
@@ -9,10 +9,11 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/grovetools/tuimux"
+
+	"github.com/grovetools/core/internal/detach"
 )
 
 type TuimuxEngine struct {
@@ -51,7 +52,7 @@ func (e *TuimuxEngine) StartServer(ctx context.Context, name string, opts ...Ses
 	if cfg.WorkDir != "" {
 		cmd.Dir = cfg.WorkDir
 	}
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	cmd.SysProcAttr = detach.SysProcAttr()
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 	if err := cmd.Start(); err != nil {
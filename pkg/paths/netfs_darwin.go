@@ -0,0 +1,28 @@
+//go:build darwin
+
+package paths
+
+import "syscall"
+
+// networkFSTypes lists the statfs f_fstypename values of network filesystems.
+var networkFSTypes = map[string]bool{
+	"nfs":    true,
+	"smbfs":  true,
+	"afpfs":  true,
+	"webdav": true,
+}
+
+func isNetworkFS(path string) bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return false
+	}
+	name := make([]byte, 0, len(st.Fstypename))
+	for _, c := range st.Fstypename {
+		if c == 0 {
+			break
+		}
+		name = append(name, byte(c))
+	}
+	return networkFSTypes[string(name)]
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package paths
+
+import "syscall"
+
+// networkFSMagic lists the statfs f_type values of network filesystems.
+var networkFSMagic = map[int64]bool{
+	0x6969:     true, // NFS
+	0x517b:     true, // SMB
+	0xff534d42: true, // CIFS
+	0xfe534d42: true, // SMB2
+	0x5346414f: true, // AFS
+	0x00c36400: true, // Ceph
+	0x0bd00bd0: true, // Lustre
+	0x47504653: true, // GPFS
+}
+
+func isNetworkFS(path string) bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return false
+	}
+	return networkFSMagic[int64(st.Type)] //nolint:unconvert // Type is int32 on some arches
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin
+
+package paths
+
+func isNetworkFS(path string) bool {
+	return false
+}
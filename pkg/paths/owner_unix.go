@@ -0,0 +1,17 @@
+//go:build !windows
+
+package paths
+
+import (
+	"os"
+	"syscall"
+)
+
+// FileOwner returns the uid that owns the file described by info.
+func FileOwner(info os.FileInfo) (int, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return int(st.Uid), true
+}
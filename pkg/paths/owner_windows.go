@@ -0,0 +1,11 @@
+//go:build windows
+
+package paths
+
+import "os"
+
+// FileOwner is not available on Windows, where per-user directories are
+// protected by ACLs instead of mode bits.
+func FileOwner(info os.FileInfo) (int, bool) {
+	return 0, false
+}
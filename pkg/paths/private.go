@@ -0,0 +1,57 @@
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// IsNetworkFilesystem reports whether path, or its nearest existing
+// ancestor, is on a network filesystem such as NFS or SMB. Unix sockets and
+// flock do not work reliably there, and a home directory mounted on several
+// hosts is shared by every host's daemon.
+func IsNetworkFilesystem(path string) bool {
+	for path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return isNetworkFS(path)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+	return false
+}
+
+// hostLocalRuntimeDir is the per-user fallback for sockets when the state
+// directory is on a network filesystem.
+func hostLocalRuntimeDir() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("grove-%d", os.Getuid()))
+}
+
+// EnsurePrivateDir creates dir with mode 0700 and checks that it belongs to
+// the current user. An existing dir with looser permissions is tightened;
+// one owned by another user, or a symlink in its place, is refused, since
+// sockets created inside it would be reachable by that user.
+func EnsurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if uid, ok := FileOwner(info); ok && uid != os.Getuid() {
+		return fmt.Errorf("%s is owned by uid %d, not the current user (uid %d); remove it or set XDG_RUNTIME_DIR", dir, uid, os.Getuid())
+	}
+	if goos != "windows" && info.Mode().Perm()&0o077 != 0 {
+		if err := os.Chmod(dir, 0o700); err != nil {
+			return fmt.Errorf("failed to restrict %s to its owner: %w", dir, err)
+		}
+	}
+	return nil
+}
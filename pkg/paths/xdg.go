@@ -4,6 +4,9 @@
 // 1. GROVE_HOME (portable root) → $GROVE_HOME/{config,data,state,cache}
 // 2. XDG env vars → $XDG_*_HOME/grove
 // 3. Platform defaults → ~/.config/grove, ~/.local/share/grove, etc.
+//
+// On Windows the platform defaults are %APPDATA%\grove for config and
+// %LOCALAPPDATA%\grove\{data,state,cache} for everything else.
 package paths
 
 import (
@@ -12,8 +15,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 )
 
+// goos is the platform used to pick default directories; a variable so
+// tests can exercise the Windows layout on any host.
+var goos = runtime.GOOS
+
+// windowsGroveDir returns the native Windows Grove directory for kind
+// ("config", "data", "state" or "cache") when neither GROVE_HOME nor the
+// matching XDG variable is set. It returns "" on other platforms, where the
+// XDG layout applies. Config roams (%APPDATA%); everything else is
+// machine-local (%LOCALAPPDATA%), with a subdirectory per kind so data, state
+// and cache never share a directory.
+func windowsGroveDir(kind, xdgEnv string) string {
+	if goos != "windows" || os.Getenv("GROVE_HOME") != "" || os.Getenv(xdgEnv) != "" {
+		return ""
+	}
+	local := os.Getenv("LOCALAPPDATA")
+	if kind == "config" {
+		if roaming := os.Getenv("APPDATA"); roaming != "" {
+			return filepath.Join(roaming, "grove")
+		}
+	}
+	if local == "" {
+		return ""
+	}
+	return filepath.Join(local, "grove", kind)
+}
+
 // getConfigHome returns the base config home directory.
 func getConfigHome() string {
 	if groveHome := os.Getenv("GROVE_HOME"); groveHome != "" {
@@ -73,6 +104,9 @@ func getCacheHome() string {
 // ConfigDir returns the Grove configuration directory.
 // Used for config files like grove.yml.
 func ConfigDir() string {
+	if dir := windowsGroveDir("config", "XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
 	base := getConfigHome()
 	if base == "" {
 		return ""
@@ -83,6 +117,9 @@ func ConfigDir() string {
 // DataDir returns the Grove data directory.
 // Used for binaries, versions, plugins, notebooks.
 func DataDir() string {
+	if dir := windowsGroveDir("data", "XDG_DATA_HOME"); dir != "" {
+		return dir
+	}
 	base := getDataHome()
 	if base == "" {
 		return ""
@@ -93,6 +130,9 @@ func DataDir() string {
 // StateDir returns the Grove state directory.
 // Used for runtime state, DBs, logs.
 func StateDir() string {
+	if dir := windowsGroveDir("state", "XDG_STATE_HOME"); dir != "" {
+		return dir
+	}
 	base := getStateHome()
 	if base == "" {
 		return ""
@@ -103,6 +143,9 @@ func StateDir() string {
 // CacheDir returns the Grove cache directory.
 // Used for temporary/regenerable data.
 func CacheDir() string {
+	if dir := windowsGroveDir("cache", "XDG_CACHE_HOME"); dir != "" {
+		return dir
+	}
 	base := getCacheHome()
 	if base == "" {
 		return ""
@@ -155,6 +198,9 @@ func BinDir() string {
 
 // RuntimeDir returns the Grove runtime directory for sockets and pipes.
 // Uses XDG_RUNTIME_DIR when available (Linux), falls back to StateDir (macOS).
+// When the state dir is on a network filesystem (an NFS home shared by
+// several hosts) the fallback is a per-user dir under the system temp dir
+// instead, so each host's daemon binds a socket only that host can see.
 func RuntimeDir() string {
 	if groveHome := os.Getenv("GROVE_HOME"); groveHome != "" {
 		return filepath.Join(groveHome, "run")
@@ -163,7 +209,11 @@ func RuntimeDir() string {
 		return filepath.Join(dir, "grove")
 	}
 	// Fallback: use state dir for socket on macOS/systems without XDG_RUNTIME_DIR
-	return StateDir()
+	state := StateDir()
+	if goos != "windows" && IsNetworkFilesystem(state) {
+		return hostLocalRuntimeDir()
+	}
+	return state
 }
 
 // SocketPath returns the path to the grove daemon unix socket.
@@ -232,3 +282,39 @@ func EnsureDirs() error {
 	}
 	return nil
 }
+
+// ExpandHome expands a leading "~" (alone, "~/" or, on Windows, "~\") to
+// the user's home directory. On Windows it also expands %VAR% references,
+// which os.ExpandEnv does not understand. Paths it cannot expand are
+// returned unchanged.
+func ExpandHome(path string) string {
+	if goos == "windows" {
+		path = expandWindowsEnv(path)
+	}
+	if path == "~" || (len(path) > 1 && path[0] == '~' && (path[1] == '/' || (goos == "windows" && path[1] == '\\'))) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// expandWindowsEnv replaces %VAR% references with their environment values,
+// leaving unknown variables and lone percent signs untouched.
+func expandWindowsEnv(path string) string {
+	var out []byte
+	for i := 0; i < len(path); i++ {
+		if path[i] == '%' {
+			if n := strings.IndexByte(path[i+1:], '%'); n > 0 {
+				end := i + 1 + n
+				if val, ok := os.LookupEnv(path[i+1 : end]); ok {
+					out = append(out, val...)
+					i = end
+					continue
+				}
+			}
+		}
+		out = append(out, path[i])
+	}
+	return string(out)
+}
@@ -0,0 +1,95 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withGOOS(t *testing.T, value string) {
+	t.Helper()
+	prev := goos
+	goos = value
+	t.Cleanup(func() { goos = prev })
+}
+
+func TestWindowsDefaultDirs(t *testing.T) {
+	withGOOS(t, "windows")
+	t.Setenv("GROVE_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("APPDATA", filepath.Join("C:", "Roaming"))
+	t.Setenv("LOCALAPPDATA", filepath.Join("C:", "Local"))
+
+	assert.Equal(t, filepath.Join("C:", "Roaming", "grove"), ConfigDir())
+	assert.Equal(t, filepath.Join("C:", "Local", "grove", "state"), StateDir())
+}
+
+func TestWindowsDefaultsYieldToOverrides(t *testing.T) {
+	withGOOS(t, "windows")
+	t.Setenv("LOCALAPPDATA", filepath.Join("C:", "Local"))
+	t.Setenv("GROVE_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "/xdg/state")
+	assert.Equal(t, filepath.Join("/xdg/state", "grove"), StateDir())
+
+	t.Setenv("GROVE_HOME", "/grove")
+	assert.Equal(t, "", windowsGroveDir("data", "XDG_DATA_HOME"))
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	assert.Equal(t, home, ExpandHome("~"))
+	assert.Equal(t, filepath.Join(home, "notes"), ExpandHome("~/notes"))
+	assert.Equal(t, "~user/notes", ExpandHome("~user/notes"))
+	assert.Equal(t, "/abs/path", ExpandHome("/abs/path"))
+}
+
+func TestExpandHomeWindowsEnv(t *testing.T) {
+	withGOOS(t, "windows")
+	t.Setenv("GROVE_TEST_DIR", "grove-dir")
+	assert.Equal(t, "grove-dir/logs", ExpandHome("%GROVE_TEST_DIR%/logs"))
+	assert.Equal(t, "%GROVE_UNSET_VAR%/x", ExpandHome("%GROVE_UNSET_VAR%/x"))
+	assert.Equal(t, "100%", ExpandHome("100%"))
+}
+
+func TestEnsurePrivateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions")
+	}
+	dir := filepath.Join(t.TempDir(), "run")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := EnsurePrivateDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Errorf("mode = %o, want 700", info.Mode().Perm())
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := EnsurePrivateDir(file); err == nil {
+		t.Error("a regular file should be refused")
+	}
+}
+
+func TestIsNetworkFilesystemMissingPath(t *testing.T) {
+	// A path that does not exist yet is judged by its nearest ancestor.
+	missing := filepath.Join(t.TempDir(), "a", "b")
+	if IsNetworkFilesystem(missing) != IsNetworkFilesystem(filepath.Dir(filepath.Dir(missing))) {
+		t.Error("missing path should inherit its ancestor's filesystem")
+	}
+}
@@ -0,0 +1,113 @@
+package process
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint identifies one process instance beyond its PID, which the OS
+// recycles once the process exits: its start time and a hash of its argv.
+// StartTime is an opaque token, only ever compared with another fingerprint
+// of the same machine.
+type Fingerprint struct {
+	StartTime string `json:"start_time,omitempty"`
+	ArgvHash  string `json:"argv_sha256,omitempty"`
+}
+
+// IsZero reports whether f records nothing, as for sessions registered
+// before fingerprints were recorded.
+func (f *Fingerprint) IsZero() bool {
+	return f == nil || (f.StartTime == "" && f.ArgvHash == "")
+}
+
+// FingerprintOf reads the fingerprint of the running process pid, from
+// /proc where it exists and from ps elsewhere (macOS).
+func FingerprintOf(pid int) (*Fingerprint, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("invalid pid %d", pid)
+	}
+	if f, err := procFingerprint(pid); err == nil {
+		return f, nil
+	} else if _, statErr := os.Stat("/proc/self/stat"); statErr == nil {
+		return nil, err
+	}
+	return psFingerprint(pid)
+}
+
+// procFingerprint reads the start time (field 22 of /proc/<pid>/stat, in
+// clock ticks since boot) and argv of pid.
+func procFingerprint(pid int) (*Fingerprint, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+	// comm, the second field, is parenthesized and may contain spaces.
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return nil, fmt.Errorf("malformed /proc/%d/stat", pid)
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return nil, fmt.Errorf("malformed /proc/%d/stat", pid)
+	}
+	cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return nil, err
+	}
+	argv := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	return &Fingerprint{StartTime: fields[19], ArgvHash: hashArgv(strings.Join(argv, " "))}, nil
+}
+
+// psFingerprint reads the start time and command line of pid through ps.
+func psFingerprint(pid int) (*Fingerprint, error) {
+	p := strconv.Itoa(pid)
+	start, err := exec.Command("ps", "-o", "lstart=", "-p", p).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read start time of pid %d: %w", pid, err)
+	}
+	command, err := exec.Command("ps", "-ww", "-o", "command=", "-p", p).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command line of pid %d: %w", pid, err)
+	}
+	return &Fingerprint{
+		StartTime: strings.Join(strings.Fields(string(start)), " "),
+		ArgvHash:  hashArgv(strings.TrimSpace(string(command))),
+	}, nil
+}
+
+func hashArgv(cmdline string) string {
+	sum := sha256.Sum256([]byte(cmdline))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsSameProcess reports whether pid is alive and is still the process that
+// fp was recorded from. A zero fingerprint falls back to IsProcessAlive, as
+// does a process whose fingerprint cannot be read (e.g. another user's
+// process on a system restricting /proc). Only the fields set in fp are
+// compared.
+func IsSameProcess(pid int, fp *Fingerprint) bool {
+	if !IsProcessAlive(pid) {
+		return false
+	}
+	if fp.IsZero() {
+		return true
+	}
+	current, err := FingerprintOf(pid)
+	if err != nil {
+		// The process may have exited since the check above.
+		return IsProcessAlive(pid)
+	}
+	if fp.StartTime != "" && fp.StartTime != current.StartTime {
+		return false
+	}
+	if fp.ArgvHash != "" && fp.ArgvHash != current.ArgvHash {
+		return false
+	}
+	return true
+}
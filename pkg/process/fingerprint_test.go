@@ -0,0 +1,43 @@
+package process
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFingerprintOfSelf(t *testing.T) {
+	fp, err := FingerprintOf(os.Getpid())
+	if err != nil {
+		t.Skipf("process fingerprints unavailable here: %v", err)
+	}
+	if fp.StartTime == "" || fp.ArgvHash == "" {
+		t.Fatalf("incomplete fingerprint: %+v", fp)
+	}
+	again, err := FingerprintOf(os.Getpid())
+	if err != nil || *again != *fp {
+		t.Errorf("fingerprint not stable: %+v then %+v (%v)", fp, again, err)
+	}
+}
+
+func TestIsSameProcess(t *testing.T) {
+	pid := os.Getpid()
+	fp, err := FingerprintOf(pid)
+	if err != nil {
+		t.Skipf("process fingerprints unavailable here: %v", err)
+	}
+	if !IsSameProcess(pid, fp) {
+		t.Error("IsSameProcess = false for the recording process")
+	}
+	if !IsSameProcess(pid, nil) {
+		t.Error("a missing fingerprint must fall back to the PID check")
+	}
+	if IsSameProcess(pid, &Fingerprint{StartTime: fp.StartTime + "0", ArgvHash: fp.ArgvHash}) {
+		t.Error("IsSameProcess = true despite a different start time")
+	}
+	if IsSameProcess(pid, &Fingerprint{StartTime: fp.StartTime, ArgvHash: "other"}) {
+		t.Error("IsSameProcess = true despite a different argv")
+	}
+	if IsSameProcess(99999999, fp) {
+		t.Error("IsSameProcess = true for a PID that cannot exist")
+	}
+}
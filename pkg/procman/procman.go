@@ -0,0 +1,341 @@
+// Package procman tracks the long-running child processes core spawns
+// (editors, helpers, the daemon) so they can be listed with `core ps` and
+// stopped in a predictable order when the parent is asked to shut down.
+//
+// Every tracked child has a pidfile under Dir(), written when it starts and
+// removed when the spawning process sees it exit. Pidfiles left behind by a
+// parent that died first are pruned by List once their process is gone.
+package procman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/process"
+)
+
+// DefaultGrace is how long Shutdown waits for a child to exit after asking
+// it to stop before killing it.
+const DefaultGrace = 3 * time.Second
+
+// Stop orders for common child roles. Shutdown stops lower orders first, so
+// helpers that read from an editor or daemon go before what they depend on.
+const (
+	OrderHelper = 0
+	OrderEditor = 10
+	OrderDaemon = 100
+)
+
+// Process is a tracked child as recorded in its pidfile.
+type Process struct {
+	PID int `json:"pid"`
+	// Name is the child's role, e.g. "editor", "daemon" or "tail".
+	Name      string    `json:"name"`
+	Command   []string  `json:"command"`
+	ParentPID int       `json:"parent_pid"`
+	StartedAt time.Time `json:"started_at"`
+	// StopOrder places the child in the shutdown sequence; lower stops
+	// first.
+	StopOrder int `json:"stop_order"`
+	// Detached children outlive their parent (the daemon) and are not
+	// stopped by Shutdown.
+	Detached bool `json:"detached,omitempty"`
+}
+
+// Options describes a child passed to Start.
+type Options struct {
+	Name      string
+	StopOrder int
+	Detached  bool
+	// Grace overrides DefaultGrace for this child.
+	Grace time.Duration
+}
+
+// Child is a started, tracked process.
+type Child struct {
+	Process
+	cmd   *exec.Cmd
+	grace time.Duration
+	done  chan struct{}
+	err   error
+}
+
+// Wait blocks until the child exits and returns its exit error.
+func (c *Child) Wait() error {
+	<-c.done
+	return c.err
+}
+
+// Done is closed when the child exits.
+func (c *Child) Done() <-chan struct{} {
+	return c.done
+}
+
+// Manager tracks the children started through it and their pidfiles.
+type Manager struct {
+	dir string
+
+	mu       sync.Mutex
+	children map[int]*Child
+
+	signalOnce sync.Once
+}
+
+// Dir returns the pidfile directory: paths.StateDir()/procs.
+func Dir() string {
+	return filepath.Join(paths.StateDir(), "procs")
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultManager *Manager
+)
+
+// Default returns the process-wide Manager writing pidfiles to Dir().
+func Default() *Manager {
+	defaultOnce.Do(func() {
+		defaultManager = New(Dir())
+	})
+	return defaultManager
+}
+
+// New returns a Manager writing pidfiles to dir.
+func New(dir string) *Manager {
+	return &Manager{dir: dir, children: make(map[int]*Child)}
+}
+
+// Start starts cmd and tracks it until it exits. The caller must not call
+// cmd.Wait; use the returned Child's Wait or Done instead. The first
+// non-detached child installs the SIGTERM handler (see HandleSignals).
+func (m *Manager) Start(cmd *exec.Cmd, opts Options) (*Child, error) {
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = filepath.Base(cmd.Path)
+	}
+	grace := opts.Grace
+	if grace <= 0 {
+		grace = DefaultGrace
+	}
+	c := &Child{
+		Process: Process{
+			PID:       cmd.Process.Pid,
+			Name:      name,
+			Command:   cmd.Args,
+			ParentPID: os.Getpid(),
+			StartedAt: time.Now().UTC(),
+			StopOrder: opts.StopOrder,
+			Detached:  opts.Detached,
+		},
+		cmd:   cmd,
+		grace: grace,
+		done:  make(chan struct{}),
+	}
+
+	// A pidfile that cannot be written only hides the child from `core ps`;
+	// the child itself is already running and stays tracked in memory.
+	if err := m.writePidfile(c.Process); err != nil {
+		fmt.Fprintf(os.Stderr, "grove: failed to record %s (pid %d): %v\n", name, c.PID, err)
+	}
+
+	m.mu.Lock()
+	m.children[c.PID] = c
+	m.mu.Unlock()
+
+	if !opts.Detached {
+		m.HandleSignals()
+	}
+
+	go func() {
+		c.err = cmd.Wait()
+		m.mu.Lock()
+		delete(m.children, c.PID)
+		m.mu.Unlock()
+		_ = os.Remove(m.pidfilePath(c.Process))
+		close(c.done)
+	}()
+	return c, nil
+}
+
+// Run starts cmd through Start and waits for it, like cmd.Run.
+func (m *Manager) Run(cmd *exec.Cmd, opts Options) error {
+	c, err := m.Start(cmd, opts)
+	if err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+// Children returns the children this Manager started that are still
+// running, in shutdown order.
+func (m *Manager) Children() []*Child {
+	m.mu.Lock()
+	out := make([]*Child, 0, len(m.children))
+	for _, c := range m.children {
+		out = append(out, c)
+	}
+	m.mu.Unlock()
+	sortForShutdown(out)
+	return out
+}
+
+// sortForShutdown orders children by StopOrder, and within an order stops
+// the most recently started first, the way deferred cleanup unwinds.
+func sortForShutdown(children []*Child) {
+	sort.SliceStable(children, func(i, j int) bool {
+		if children[i].StopOrder != children[j].StopOrder {
+			return children[i].StopOrder < children[j].StopOrder
+		}
+		return children[i].StartedAt.After(children[j].StartedAt)
+	})
+}
+
+// Shutdown stops every non-detached child in StopOrder. Each child is asked
+// to terminate, given its grace period, then killed; the next child is only
+// signalled once the previous one has exited. ctx bounds the whole
+// sequence: once it is done the remaining children are killed immediately.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	var errs []string
+	for _, c := range m.Children() {
+		if c.Detached {
+			continue
+		}
+		if err := stopChild(ctx, c); err != nil {
+			errs = append(errs, fmt.Sprintf("%s (pid %d): %v", c.Name, c.PID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to stop: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func stopChild(ctx context.Context, c *Child) error {
+	select {
+	case <-c.done:
+		return nil
+	default:
+	}
+	if ctx.Err() == nil {
+		if err := terminate(c.cmd.Process); err != nil && !isFinished(err) {
+			return err
+		}
+		timer := time.NewTimer(c.grace)
+		defer timer.Stop()
+		select {
+		case <-c.done:
+			return nil
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	if err := c.cmd.Process.Kill(); err != nil && !isFinished(err) {
+		return err
+	}
+	<-c.done
+	return nil
+}
+
+func isFinished(err error) bool {
+	return err == os.ErrProcessDone
+}
+
+// HandleSignals makes SIGTERM stop this Manager's children in order before
+// the process exits with the conventional 128+SIGTERM status. It is
+// installed once, by the first non-detached Start, so processes that never
+// spawn tracked children keep the default signal behavior.
+func (m *Manager) HandleSignals() {
+	m.signalOnce.Do(func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGTERM)
+		go func() {
+			<-ch
+			ctx, cancel := context.WithTimeout(context.Background(), 2*DefaultGrace)
+			if err := m.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "grove: %v\n", err)
+			}
+			cancel()
+			os.Exit(128 + int(syscall.SIGTERM))
+		}()
+	})
+}
+
+func (m *Manager) pidfilePath(p Process) string {
+	return filepath.Join(m.dir, fmt.Sprintf("%s-%d.json", sanitizeName(p.Name), p.PID))
+}
+
+func (m *Manager) writePidfile(p Process) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := m.pidfilePath(p)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil { //nolint:gosec // not sensitive
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// sanitizeName keeps pidfile names to characters safe on every platform.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// List reads the pidfiles in dir and returns the processes still running,
+// oldest first. Pidfiles whose process has exited are removed.
+func List(dir string) ([]Process, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var procs []Process
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var p Process
+		if err := json.Unmarshal(data, &p); err != nil || p.PID <= 0 {
+			continue
+		}
+		if !process.IsProcessAlive(p.PID) {
+			_ = os.Remove(path)
+			continue
+		}
+		procs = append(procs, p)
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].StartedAt.Before(procs[j].StartedAt) })
+	return procs, nil
+}
@@ -0,0 +1,133 @@
+//go:build !windows
+
+package procman
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartWritesAndRemovesPidfile(t *testing.T) {
+	dir := t.TempDir()
+	m := New(dir)
+
+	c, err := m.Start(exec.Command("sleep", "5"), Options{Name: "tail", Detached: true})
+	require.NoError(t, err)
+
+	procs, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, procs, 1)
+	assert.Equal(t, c.PID, procs[0].PID)
+	assert.Equal(t, "tail", procs[0].Name)
+	assert.Equal(t, os.Getpid(), procs[0].ParentPID)
+	assert.Equal(t, []string{"sleep", "5"}, procs[0].Command)
+
+	require.NoError(t, c.cmd.Process.Kill())
+	_ = c.Wait()
+
+	_, err = os.Stat(m.pidfilePath(c.Process))
+	assert.True(t, os.IsNotExist(err), "pidfile should be removed on exit")
+	assert.Empty(t, m.Children())
+}
+
+func TestListPrunesDeadProcesses(t *testing.T) {
+	dir := t.TempDir()
+
+	// A child that has already exited stands in for a pidfile left behind
+	// by a parent that died before it could clean up.
+	dead := exec.Command("true")
+	require.NoError(t, dead.Run())
+	data, err := json.Marshal(Process{PID: dead.Process.Pid, Name: "editor"})
+	require.NoError(t, err)
+	stale := filepath.Join(dir, "editor-1.json")
+	require.NoError(t, os.WriteFile(stale, data, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "garbage.json"), []byte("{"), 0o644))
+
+	procs, err := List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, procs)
+	_, err = os.Stat(stale)
+	assert.True(t, os.IsNotExist(err), "stale pidfile should be pruned")
+}
+
+func TestListMissingDir(t *testing.T) {
+	procs, err := List(filepath.Join(t.TempDir(), "absent"))
+	require.NoError(t, err)
+	assert.Nil(t, procs)
+}
+
+func TestShutdownOrder(t *testing.T) {
+	m := New(t.TempDir())
+	// Skip the process-wide SIGTERM handler; Shutdown is called directly.
+	m.signalOnce.Do(func() {})
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	start := func(name string, stopOrder int, detached bool) *Child {
+		c, err := m.Start(exec.Command("sleep", "30"), Options{Name: name, StopOrder: stopOrder, Detached: detached})
+		require.NoError(t, err)
+		go func() {
+			<-c.Done()
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}()
+		return c
+	}
+	editor := start("editor", OrderEditor, false)
+	helperA := start("helper-a", OrderHelper, false)
+	time.Sleep(10 * time.Millisecond) // distinct start times
+	helperB := start("helper-b", OrderHelper, false)
+	daemon := start("daemon", OrderDaemon, true)
+	t.Cleanup(func() { _ = daemon.cmd.Process.Kill() })
+
+	require.NoError(t, m.Shutdown(context.Background()))
+	for _, c := range []*Child{editor, helperA, helperB} {
+		<-c.Done()
+	}
+	// Wait for the order-recording goroutines.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, []string{"helper-b", "helper-a", "editor"}, order)
+	select {
+	case <-daemon.Done():
+		t.Fatal("detached child should survive Shutdown")
+	default:
+	}
+}
+
+func TestShutdownKillsAfterGrace(t *testing.T) {
+	m := New(t.TempDir())
+	m.signalOnce.Do(func() {})
+
+	// SIGTERM is ignored, and stays ignored across exec, so only the kill
+	// after the grace period stops it.
+	c, err := m.Start(exec.Command("sh", "-c", "trap '' TERM; exec sleep 30"), Options{Name: "stubborn", Grace: 50 * time.Millisecond})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond) // let the trap install
+
+	begin := time.Now()
+	require.NoError(t, m.Shutdown(context.Background()))
+	<-c.Done()
+	assert.Less(t, time.Since(begin), 5*time.Second)
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "nvim_-u_x.lua", sanitizeName("nvim -u x.lua"))
+	assert.Equal(t, "a_b", sanitizeName("a/b"))
+}
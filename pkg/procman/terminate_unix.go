@@ -0,0 +1,13 @@
+//go:build !windows
+
+package procman
+
+import (
+	"os"
+	"syscall"
+)
+
+// terminate asks p to exit with SIGTERM.
+func terminate(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
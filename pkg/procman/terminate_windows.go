@@ -0,0 +1,11 @@
+//go:build windows
+
+package procman
+
+import "os"
+
+// terminate stops p. Windows has no SIGTERM to deliver to another process,
+// so the grace period is skipped and the child is killed outright.
+func terminate(p *os.Process) error {
+	return p.Kill()
+}
@@ -0,0 +1,74 @@
+package progressx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// spinnerFrames are the frames of Spinner, the same set the TUI components
+// use.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner renders a task of unknown size. Each View advances it one frame.
+type Spinner struct {
+	frame int
+}
+
+// View renders s for snap, e.g. "⠹ Discovering workspaces: 120 (api) 2s".
+func (s *Spinner) View(snap Snapshot) string {
+	frame := spinnerFrames[s.frame%len(spinnerFrames)]
+	s.frame++
+	line := fmt.Sprintf("%s %s: %d", frame, snap.Name, snap.Done)
+	return withItem(line, snap)
+}
+
+// Bar renders a task with a known total.
+type Bar struct {
+	// Width is the number of cells between the brackets. Defaults to 24.
+	Width int
+}
+
+// View renders b for snap, e.g.
+// "[=========>              ] 12/30 40% Composing schema (flow) 2s".
+func (b Bar) View(snap Snapshot) string {
+	width := b.Width
+	if width <= 0 {
+		width = 24
+	}
+	frac := max(snap.Fraction(), 0)
+	filled := int(frac * float64(width))
+	var cells string
+	switch {
+	case filled >= width:
+		cells = strings.Repeat("=", width)
+	case filled > 0:
+		cells = strings.Repeat("=", filled-1) + ">" + strings.Repeat(" ", width-filled)
+	default:
+		cells = strings.Repeat(" ", width)
+	}
+	line := fmt.Sprintf("[%s] %d/%d %3.0f%% %s", cells, snap.Done, snap.Total, frac*100, snap.Name)
+	return withItem(line, snap)
+}
+
+// PlainLine renders snap as one line for output that is not a terminal,
+// e.g. "Discovering workspaces: 120 done (running, 5s)" or, with a total,
+// "Composing schema: 12/30 (40%, running, 5s)".
+func PlainLine(snap Snapshot, state string) string {
+	elapsed := snap.Elapsed.Round(time.Second)
+	if snap.Elapsed < time.Second {
+		elapsed = snap.Elapsed.Round(time.Millisecond)
+	}
+	if snap.Total > 0 {
+		return fmt.Sprintf("%s: %d/%d (%.0f%%, %s, %s)", snap.Name, snap.Done, snap.Total, snap.Fraction()*100, state, elapsed)
+	}
+	return fmt.Sprintf("%s: %d done (%s, %s)", snap.Name, snap.Done, state, elapsed)
+}
+
+// withItem appends the current item and elapsed time to a rendered line.
+func withItem(line string, snap Snapshot) string {
+	if snap.Item != "" {
+		line += " (" + snap.Item + ")"
+	}
+	return line + " " + snap.Elapsed.Round(time.Second).String()
+}
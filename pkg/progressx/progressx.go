@@ -0,0 +1,357 @@
+// Package progressx reports the progress of long-running CLI operations
+// such as workspace discovery, schema composition and locating log files.
+//
+// A Task counts completed units of work. While it runs, it is drawn on a
+// terminal as a spinner, or as a bar once its total is known. Anywhere
+// else it prints a plain status line now and then. It can also emit the
+// same state as structured log entries. Nothing is shown for operations
+// that finish within Options.Delay, so fast commands print exactly what
+// they did before.
+//
+// A nil *Task is valid and does nothing, so code can take a Task as an
+// optional argument without checking it:
+//
+//	task := progressx.Start("Discovering workspaces", 0, progressx.Options{})
+//	defer task.Done()
+//	for _, dir := range dirs {
+//		task.Describe(dir)
+//		...
+//		task.Add(1)
+//	}
+package progressx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/core/internal/tty"
+)
+
+// Mode selects how a Task is rendered.
+type Mode int
+
+const (
+	// ModeAuto draws a spinner or bar when Out is a terminal and plain
+	// lines otherwise.
+	ModeAuto Mode = iota
+	// ModeInteractive always redraws a single line in place.
+	ModeInteractive
+	// ModePlain prints a status line every PlainInterval.
+	ModePlain
+	// ModeOff renders nothing; structured log entries are still emitted.
+	ModeOff
+)
+
+const (
+	// DefaultDelay is how long a Task runs before anything is shown.
+	DefaultDelay = 500 * time.Millisecond
+	// DefaultInterval is the redraw interval on a terminal.
+	DefaultInterval = 100 * time.Millisecond
+	// DefaultPlainInterval is the interval between plain status lines and
+	// between structured progress log entries.
+	DefaultPlainInterval = 5 * time.Second
+)
+
+// Log fields of structured progress entries.
+const (
+	FieldTask    = "progress_task"
+	FieldDone    = "progress_done"
+	FieldTotal   = "progress_total"
+	FieldItem    = "progress_item"
+	FieldState   = "progress_state"
+	FieldElapsed = "elapsed_ms"
+)
+
+// States recorded in FieldState.
+const (
+	StateStarted  = "started"
+	StateRunning  = "running"
+	StateDone     = "done"
+	StateFailed   = "failed"
+	StateCanceled = "canceled"
+)
+
+// Options configures a Task. The zero value renders to stderr in ModeAuto
+// with the default delay and intervals and logs nothing.
+type Options struct {
+	// Out is where progress is drawn. Defaults to os.Stderr.
+	Out io.Writer
+	// Mode selects the renderer.
+	Mode Mode
+	// Delay is how long the task runs before it is first shown. Negative
+	// shows it immediately.
+	Delay time.Duration
+	// Interval is the redraw interval in ModeInteractive.
+	Interval time.Duration
+	// PlainInterval is the interval between lines in ModePlain and between
+	// progress log entries.
+	PlainInterval time.Duration
+	// Logger, when set, receives a debug entry when the task starts, every
+	// PlainInterval while it runs, and when it ends, with the Field*
+	// fields set.
+	Logger logrus.FieldLogger
+	// BarWidth is the width of the bar in cells. Defaults to 24.
+	BarWidth int
+}
+
+// Snapshot is the state of a Task at one moment.
+type Snapshot struct {
+	Name string
+	// Done is the number of units completed.
+	Done int
+	// Total is the number of units expected, or 0 while unknown.
+	Total int
+	// Item describes what the task is working on.
+	Item    string
+	Elapsed time.Duration
+}
+
+// Fraction returns how much of the task is complete, in [0, 1], or -1 when
+// the total is unknown.
+func (s Snapshot) Fraction() float64 {
+	if s.Total <= 0 {
+		return -1
+	}
+	f := float64(s.Done) / float64(s.Total)
+	return min(max(f, 0), 1)
+}
+
+// Task is one operation reporting progress. Its methods are safe for
+// concurrent use and do nothing on a nil Task.
+type Task struct {
+	opts  Options
+	name  string
+	start time.Time
+
+	mu      sync.Mutex
+	done    int
+	total   int
+	item    string
+	shown   bool
+	lastLog time.Time
+	ended   bool
+
+	spinner Spinner
+	bar     Bar
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// Start begins reporting progress for the operation name. total is the
+// number of units expected, or 0 when unknown; it can be set later with
+// SetTotal. End the task with Done, Fail or Cancel.
+func Start(name string, total int, opts Options) *Task {
+	if opts.Out == nil {
+		opts.Out = os.Stderr
+	}
+	if opts.Mode == ModeAuto {
+		opts.Mode = ModePlain
+		if tty.IsTerminal(opts.Out) {
+			opts.Mode = ModeInteractive
+		}
+	}
+	if opts.Delay == 0 {
+		opts.Delay = DefaultDelay
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.PlainInterval <= 0 {
+		opts.PlainInterval = DefaultPlainInterval
+	}
+
+	t := &Task{
+		opts:    opts,
+		name:    name,
+		start:   time.Now(),
+		total:   max(total, 0),
+		bar:     Bar{Width: opts.BarWidth},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	t.lastLog = t.start
+	t.log(StateStarted, t.snapshotLocked(), nil)
+	go t.run()
+	return t
+}
+
+// Add records n more completed units.
+func (t *Task) Add(n int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.done += n
+	t.mu.Unlock()
+}
+
+// SetTotal sets the number of units expected; 0 means unknown.
+func (t *Task) SetTotal(total int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.total = max(total, 0)
+	t.mu.Unlock()
+}
+
+// Describe sets what the task is currently working on.
+func (t *Task) Describe(item string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.item = item
+	t.mu.Unlock()
+}
+
+// Snapshot returns the task's current state.
+func (t *Task) Snapshot() Snapshot {
+	if t == nil {
+		return Snapshot{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshotLocked()
+}
+
+func (t *Task) snapshotLocked() Snapshot {
+	return Snapshot{
+		Name:    t.name,
+		Done:    t.done,
+		Total:   t.total,
+		Item:    t.item,
+		Elapsed: time.Since(t.start),
+	}
+}
+
+// Done ends the task successfully.
+func (t *Task) Done() { t.end(StateDone, nil) }
+
+// Fail ends the task with err.
+func (t *Task) Fail(err error) { t.end(StateFailed, err) }
+
+// Cancel ends the task without it completing, as when the user interrupts
+// it.
+func (t *Task) Cancel() { t.end(StateCanceled, nil) }
+
+// end stops rendering and clears or finishes the progress line. Only the
+// first call has an effect.
+func (t *Task) end(state string, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if t.ended {
+		t.mu.Unlock()
+		return
+	}
+	t.ended = true
+	t.mu.Unlock()
+
+	close(t.stop)
+	<-t.stopped
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	snap := t.snapshotLocked()
+	if t.shown {
+		switch t.opts.Mode {
+		case ModeInteractive:
+			// The line was transient; the command's own output follows.
+			fmt.Fprint(t.opts.Out, "\r\033[K")
+		case ModePlain:
+			fmt.Fprintln(t.opts.Out, PlainLine(snap, state))
+		}
+	}
+	t.log(state, snap, err)
+}
+
+// run draws the task until it ends.
+func (t *Task) run() {
+	defer close(t.stopped)
+
+	if t.opts.Delay > 0 {
+		select {
+		case <-t.stop:
+			return
+		case <-time.After(t.opts.Delay):
+		}
+	}
+	t.tick()
+
+	ticker := time.NewTicker(t.opts.Interval)
+	if t.opts.Mode != ModeInteractive {
+		ticker.Reset(t.opts.PlainInterval)
+	}
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.tick()
+		}
+	}
+}
+
+// tick renders the current state and emits a progress log entry when one
+// is due.
+func (t *Task) tick() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	snap := t.snapshotLocked()
+
+	switch t.opts.Mode {
+	case ModeInteractive:
+		line := t.spinner.View(snap)
+		if snap.Total > 0 {
+			line = t.bar.View(snap)
+		}
+		fmt.Fprint(t.opts.Out, "\r\033[K"+line)
+		t.shown = true
+	case ModePlain:
+		fmt.Fprintln(t.opts.Out, PlainLine(snap, StateRunning))
+		t.shown = true
+	}
+
+	if time.Since(t.lastLog) >= t.opts.PlainInterval {
+		t.log(StateRunning, snap, nil)
+	}
+}
+
+// log emits a structured progress entry. The caller holds t.mu, except in
+// Start before the task is shared.
+func (t *Task) log(state string, snap Snapshot, err error) {
+	if t.opts.Logger == nil {
+		return
+	}
+	t.lastLog = time.Now()
+	fields := logrus.Fields{
+		FieldTask:    snap.Name,
+		FieldState:   state,
+		FieldDone:    snap.Done,
+		FieldElapsed: snap.Elapsed.Milliseconds(),
+	}
+	if snap.Total > 0 {
+		fields[FieldTotal] = snap.Total
+	}
+	if snap.Item != "" && state == StateRunning {
+		fields[FieldItem] = snap.Item
+	}
+	entry := t.opts.Logger.WithFields(fields)
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	if state == StateFailed {
+		entry.Warnf("%s failed", snap.Name)
+		return
+	}
+	entry.Debugf("%s %s", snap.Name, state)
+}
@@ -0,0 +1,148 @@
+package progressx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// syncBuffer is a bytes.Buffer safe to write from the render goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestNilTaskIsNoOp(t *testing.T) {
+	var task *Task
+	task.Add(1)
+	task.SetTotal(3)
+	task.Describe("x")
+	task.Done()
+	task.Fail(errors.New("boom"))
+	if task.Snapshot() != (Snapshot{}) {
+		t.Error("expected an empty snapshot from a nil task")
+	}
+}
+
+func TestFastTaskShowsNothing(t *testing.T) {
+	var out syncBuffer
+	task := Start("Scanning", 10, Options{Out: &out, Mode: ModePlain, Delay: time.Hour})
+	task.Add(10)
+	task.Done()
+	if out.String() != "" {
+		t.Errorf("expected no output before the delay, got %q", out.String())
+	}
+}
+
+func TestPlainModePrintsStatusAndFinalLine(t *testing.T) {
+	var out syncBuffer
+	task := Start("Scanning", 4, Options{Out: &out, Mode: ModePlain, Delay: -1, PlainInterval: time.Hour})
+	task.Add(1)
+	waitFor(t, func() bool { return out.String() != "" })
+	task.Add(3)
+	task.Done()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a status line and a final line, got %q", out.String())
+	}
+	if !strings.HasPrefix(lines[1], "Scanning: 4/4 (100%, done, ") {
+		t.Errorf("unexpected final line %q", lines[1])
+	}
+}
+
+func TestInteractiveModeClearsItsLine(t *testing.T) {
+	var out syncBuffer
+	task := Start("Scanning", 0, Options{Out: &out, Mode: ModeInteractive, Delay: -1, Interval: time.Millisecond})
+	task.Describe("api")
+	waitFor(t, func() bool { return strings.Contains(out.String(), "(api)") })
+	task.Done()
+	if !strings.HasSuffix(out.String(), "\r\033[K") {
+		t.Errorf("expected the progress line cleared at the end, got %q", out.String())
+	}
+}
+
+func TestStructuredLogEntries(t *testing.T) {
+	var buf syncBuffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(logrus.DebugLevel)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	task := Start("Composing", 2, Options{Mode: ModeOff, Logger: logger})
+	task.Add(1)
+	task.Fail(errors.New("fetch failed"))
+	task.Done() // ignored after Fail
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected start and end entries, got %d", len(entries))
+	}
+	if entries[0][FieldState] != StateStarted || entries[0][FieldTask] != "Composing" {
+		t.Errorf("unexpected start entry %v", entries[0])
+	}
+	end := entries[1]
+	if end[FieldState] != StateFailed || end[FieldDone] != float64(1) || end[FieldTotal] != float64(2) {
+		t.Errorf("unexpected end entry %v", end)
+	}
+	if end["error"] != "fetch failed" || end["level"] != "warning" {
+		t.Errorf("expected the failure logged as a warning with its error, got %v", end)
+	}
+}
+
+func TestViews(t *testing.T) {
+	snap := Snapshot{Name: "Fetching", Done: 1, Total: 4, Item: "flow", Elapsed: 2 * time.Second}
+	if got := (Bar{Width: 8}).View(snap); got != "[=>      ] 1/4  25% Fetching (flow) 2s" {
+		t.Errorf("Bar.View = %q", got)
+	}
+	if got := (Bar{Width: 4}).View(Snapshot{Name: "x", Done: 9, Total: 3}); !strings.HasPrefix(got, "[====] 9/3 100%") {
+		t.Errorf("expected an overfull bar clamped, got %q", got)
+	}
+
+	var s Spinner
+	first := s.View(Snapshot{Name: "Discovering", Done: 12})
+	second := s.View(Snapshot{Name: "Discovering", Done: 12})
+	if first == second || !strings.HasSuffix(first, " Discovering: 12 0s") {
+		t.Errorf("expected the spinner to advance, got %q then %q", first, second)
+	}
+
+	if got := PlainLine(Snapshot{Name: "Discovering", Done: 5, Elapsed: 1500 * time.Millisecond}, StateRunning); got != "Discovering: 5 done (running, 2s)" {
+		t.Errorf("PlainLine = %q", got)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for progress output")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,283 @@
+package prune
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// DeadReason says why a worktree was flagged by DetectDeadWorktrees. The
+// strings are part of the JSON surface, keep them stable.
+type DeadReason string
+
+const (
+	// ReasonMissingDir is a git registration whose directory is gone.
+	// Removing it only runs `git worktree prune`.
+	ReasonMissingDir DeadReason = "missing_dir"
+	// ReasonMissingGit is a directory under a worktree base with no .git
+	// reference, neither at its root nor in any child repo of a container.
+	ReasonMissingGit DeadReason = "missing_git"
+	// ReasonStaleGitdir is a directory whose .git file points at a gitdir
+	// that no longer exists, so git has forgotten it.
+	ReasonStaleGitdir DeadReason = "stale_gitdir"
+	// ReasonBranchDeleted is a registered worktree whose branch no longer
+	// exists, or a detached one whose namesake branch is gone and whose
+	// HEAD holds no commit outside branches, tags and remotes.
+	ReasonBranchDeleted DeadReason = "branch_deleted"
+)
+
+// DeadWorktree is one worktree DetectDeadWorktrees flagged.
+type DeadWorktree struct {
+	Path       string     `json:"path"`
+	Name       string     `json:"name"`
+	GitRoot    string     `json:"git_root"`
+	Branch     string     `json:"branch,omitempty"`
+	Reason     DeadReason `json:"reason"`
+	Registered bool       `json:"registered"`
+}
+
+// FailedWorktreeRemoval carries one RemoveDeadWorktree error alongside the
+// worktree it was for.
+type FailedWorktreeRemoval struct {
+	Worktree DeadWorktree `json:"worktree"`
+	Error    string       `json:"error"`
+}
+
+// WorktreePruneResult is the result of a worktree prune run.
+type WorktreePruneResult struct {
+	Dead    []DeadWorktree          `json:"dead"`
+	Removed []DeadWorktree          `json:"removed,omitempty"`
+	Failed  []FailedWorktreeRemoval `json:"failed,omitempty"`
+	DryRun  bool                    `json:"dry_run"`
+}
+
+// gitWorktree is one entry of `git worktree list --porcelain`.
+type gitWorktree struct {
+	path     string
+	branch   string
+	prunable bool
+}
+
+// parseGitWorktrees parses `git worktree list --porcelain` output. The main
+// worktree comes first.
+func parseGitWorktrees(output string) []gitWorktree {
+	var worktrees []gitWorktree
+	var current *gitWorktree
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, gitWorktree{path: value})
+			current = &worktrees[len(worktrees)-1]
+		case "branch":
+			if current != nil {
+				current.branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "prunable":
+			if current != nil {
+				current.prunable = true
+			}
+		}
+	}
+	return worktrees
+}
+
+// DetectDeadWorktrees cross-references the directories under gitRoot's
+// worktree bases (.grove-worktrees and the XDG worktrees directory) with
+// `git worktree list` and returns the ones that are dead: registrations
+// whose directory is gone, directories whose git metadata is gone, and
+// registered worktrees whose branch was deleted. Detached worktrees with
+// commits no ref holds are kept. Only worktrees inside the bases are
+// considered; the main worktree and worktrees git tracks elsewhere are left
+// alone.
+func DetectDeadWorktrees(gitRoot string, bases []string, git Runner) ([]DeadWorktree, error) {
+	if len(bases) == 0 {
+		bases = workspace.WorktreeBases(gitRoot)
+	}
+	out, err := git.Run("git", "-C", gitRoot, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees of %s: %w", gitRoot, err)
+	}
+	registered := parseGitWorktrees(string(out))
+
+	var dead []DeadWorktree
+	for i, wt := range registered {
+		if i == 0 || !underAnyBase(wt.path, bases) {
+			continue
+		}
+		d := DeadWorktree{Path: wt.path, Name: filepath.Base(wt.path), GitRoot: gitRoot, Branch: wt.branch, Registered: true}
+		if _, statErr := os.Stat(wt.path); wt.prunable || os.IsNotExist(statErr) {
+			d.Reason = ReasonMissingDir
+			dead = append(dead, d)
+			continue
+		}
+		branch := wt.branch
+		if branch == "" {
+			branch = d.Name
+		}
+		if _, err := git.Run("git", "-C", gitRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+			// A detached HEAD may carry commits no ref holds; removing the
+			// worktree would leave them to the reflog alone.
+			if wt.branch == "" && !headReachable(wt.path, git) {
+				continue
+			}
+			d.Branch = branch
+			d.Reason = ReasonBranchDeleted
+			dead = append(dead, d)
+		}
+	}
+
+	for _, base := range bases {
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			dir := filepath.Join(base, e.Name())
+			if coversRegistered(dir, registered) {
+				continue
+			}
+			reason, ok := deadDirReason(dir)
+			if !ok {
+				continue
+			}
+			dead = append(dead, DeadWorktree{Path: dir, Name: e.Name(), GitRoot: gitRoot, Reason: reason})
+		}
+	}
+
+	sort.Slice(dead, func(i, j int) bool { return dead[i].Path < dead[j].Path })
+	return dead, nil
+}
+
+// headReachable reports whether every commit of the worktree's HEAD is
+// reachable from a branch, tag or remote-tracking ref.
+func headReachable(path string, git Runner) bool {
+	out, err := git.Run("git", "-C", path, "rev-list", "HEAD", "--not", "--branches", "--tags", "--remotes")
+	return err == nil && len(strings.TrimSpace(string(out))) == 0
+}
+
+// deadDirReason classifies an unregistered directory under a worktree
+// base. A directory with a live .git reference, or a unified container with
+// a live child repo, is not dead: it may belong to another repository.
+func deadDirReason(dir string) (DeadReason, bool) {
+	switch gitReferenceState(dir) {
+	case gitRefLive:
+		return "", false
+	case gitRefStale:
+		return ReasonStaleGitdir, true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	stale := false
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		switch gitReferenceState(filepath.Join(dir, e.Name())) {
+		case gitRefLive:
+			return "", false
+		case gitRefStale:
+			stale = true
+		}
+	}
+	if stale {
+		return ReasonStaleGitdir, true
+	}
+	return ReasonMissingGit, true
+}
+
+type gitRefState int
+
+const (
+	gitRefMissing gitRefState = iota
+	gitRefStale
+	gitRefLive
+)
+
+// gitReferenceState inspects dir/.git: a directory is a primary checkout
+// and live; a file is a linked worktree, live while the gitdir it points
+// at exists.
+func gitReferenceState(dir string) gitRefState {
+	gitPath := filepath.Join(dir, ".git")
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		return gitRefMissing
+	}
+	if info.IsDir() {
+		return gitRefLive
+	}
+	data, err := os.ReadFile(gitPath)
+	if err != nil {
+		return gitRefStale
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return gitRefStale
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+	if _, err := os.Stat(gitdir); err != nil {
+		return gitRefStale
+	}
+	return gitRefLive
+}
+
+// coversRegistered reports whether dir is, or contains, a registered
+// worktree. Registered worktrees are judged by their git state instead.
+func coversRegistered(dir string, registered []gitWorktree) bool {
+	for _, wt := range registered {
+		if wt.path == dir || strings.HasPrefix(wt.path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func underAnyBase(path string, bases []string) bool {
+	for _, base := range bases {
+		if strings.HasPrefix(filepath.Clean(path), filepath.Clean(base)+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveDeadWorktree removes a worktree flagged by DetectDeadWorktrees.
+// Registered worktrees go through `git worktree remove`, which refuses to
+// discard uncommitted changes; other directories are deleted, guarded to
+// stay strictly under bases. Git's registrations are pruned afterwards.
+func RemoveDeadWorktree(d DeadWorktree, bases []string, git Runner) error {
+	if len(bases) == 0 {
+		bases = workspace.WorktreeBases(d.GitRoot)
+	}
+	switch d.Reason {
+	case ReasonMissingDir:
+	case ReasonBranchDeleted:
+		if _, err := git.Run("git", "-C", d.GitRoot, "worktree", "remove", d.Path); err != nil {
+			return err
+		}
+	case ReasonMissingGit, ReasonStaleGitdir:
+		if err := removeHostPath(d.Path, bases); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown reason %s", d.Reason)
+	}
+	_, err := git.Run("git", "-C", d.GitRoot, "worktree", "prune")
+	return err
+}
@@ -0,0 +1,107 @@
+package prune
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestParseGitWorktrees(t *testing.T) {
+	out := "worktree /repo\nHEAD abc\nbranch refs/heads/main\n\n" +
+		"worktree /repo/.grove-worktrees/feat\nHEAD def\nbranch refs/heads/feat\n\n" +
+		"worktree /repo/.grove-worktrees/gone\nHEAD 123\ndetached\nprunable gitdir file points to non-existent location\n"
+	got := parseGitWorktrees(out)
+	if len(got) != 3 {
+		t.Fatalf("got %d worktrees, want 3: %+v", len(got), got)
+	}
+	if got[1].branch != "feat" || got[1].prunable {
+		t.Errorf("feat = %+v", got[1])
+	}
+	if got[2].branch != "" || !got[2].prunable {
+		t.Errorf("gone = %+v", got[2])
+	}
+}
+
+func TestDetectAndRemoveDeadWorktrees(t *testing.T) {
+	sandboxXDG(t)
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitCmd(t, root, "init", "-q", "-b", "main")
+	gitCmd(t, root, "commit", "-q", "--allow-empty", "-m", "init")
+	wtBase := filepath.Join(root, ".grove-worktrees")
+	bases := []string{wtBase}
+
+	live := filepath.Join(wtBase, "live")
+	gitCmd(t, root, "worktree", "add", "-q", "-b", "live", live)
+	gone := filepath.Join(wtBase, "gone")
+	gitCmd(t, root, "worktree", "add", "-q", "-b", "gone", gone)
+	if err := os.RemoveAll(gone); err != nil {
+		t.Fatal(err)
+	}
+	merged := filepath.Join(wtBase, "merged")
+	gitCmd(t, root, "worktree", "add", "-q", "--detach", merged)
+	detached := filepath.Join(wtBase, "detached")
+	gitCmd(t, root, "worktree", "add", "-q", "--detach", detached)
+	gitCmd(t, detached, "commit", "-q", "--allow-empty", "-m", "work in progress")
+	orphan := filepath.Join(wtBase, "orphan", ".grove")
+	if err := os.MkdirAll(orphan, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(wtBase, "stale")
+	if err := os.MkdirAll(stale, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(stale, ".git"), []byte("gitdir: "+filepath.Join(root, ".git", "worktrees", "stale")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dead, err := DetectDeadWorktrees(root, bases, ExecRunner{})
+	if err != nil {
+		t.Fatalf("DetectDeadWorktrees: %v", err)
+	}
+	want := map[string]DeadReason{
+		gone:                            ReasonMissingDir,
+		merged:                          ReasonBranchDeleted,
+		filepath.Join(wtBase, "orphan"): ReasonMissingGit,
+		stale:                           ReasonStaleGitdir,
+	}
+	if len(dead) != len(want) {
+		t.Fatalf("got %d dead worktrees, want %d: %+v", len(dead), len(want), dead)
+	}
+	for _, d := range dead {
+		if want[d.Path] != d.Reason {
+			t.Errorf("%s: reason %q, want %q", d.Path, d.Reason, want[d.Path])
+		}
+	}
+
+	for _, d := range dead {
+		if err := RemoveDeadWorktree(d, bases, ExecRunner{}); err != nil {
+			t.Errorf("RemoveDeadWorktree(%s): %v", d.Path, err)
+		}
+	}
+	if dead, err := DetectDeadWorktrees(root, bases, ExecRunner{}); err != nil || len(dead) != 0 {
+		t.Errorf("after removal: %+v, %v", dead, err)
+	}
+	if _, err := os.Stat(live); err != nil {
+		t.Errorf("live worktree was removed: %v", err)
+	}
+	if _, err := os.Stat(detached); err != nil {
+		t.Errorf("detached worktree with unreachable commits was removed: %v", err)
+	}
+}
@@ -228,7 +228,7 @@ func (m *Manager) EnsureVersion(ctx context.Context, repoURL, version string) (w
 
 	// Create worktree under the bare repo in .grove-worktrees/{commit-hash}
 	// This follows the standard workspace pattern
-	worktreeDir := filepath.Join(barePath, ".grove-worktrees")
+	worktreeDir := filepath.Join(barePath, WorktreeDirName)
 	worktreePath = filepath.Join(worktreeDir, resolvedCommit[:12])
 
 	// Create worktree directory if needed
@@ -581,3 +581,238 @@ func (m *Manager) saveManifest(manifest *Manifest) error {
 	successful = true
 	return nil
 }
+
+// WorktreeDirName is the directory under a bare clone that holds its
+// worktrees. Workspace discovery scans it to list a cloned repo's checkouts.
+const WorktreeDirName = ".grove-worktrees"
+
+// ResolveRepoURL expands a repository spec into a clonable URL. Full URLs
+// (https://, ssh://, git@host:...) and local paths are returned unchanged;
+// "owner/repo" shorthands resolve to GitHub.
+func ResolveRepoURL(spec string) string {
+	spec = strings.TrimSpace(spec)
+	if strings.Contains(spec, "://") || strings.HasPrefix(spec, "git@") ||
+		strings.HasPrefix(spec, "/") || strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "~") {
+		return spec
+	}
+	parts := strings.Split(strings.TrimSuffix(spec, ".git"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return fmt.Sprintf("https://github.com/%s/%s.git", parts[0], parts[1])
+	case len(parts) == 3 && strings.Contains(parts[0], "."):
+		// host/owner/repo
+		return fmt.Sprintf("https://%s/%s/%s.git", parts[0], parts[1], parts[2])
+	}
+	return spec
+}
+
+// Find returns the managed repository matching spec, which may be the
+// repository URL, its shorthand ("owner/repo"), or its bare repo name.
+func (m *Manager) Find(spec string) (RepoInfo, error) {
+	repos, err := m.List()
+	if err != nil {
+		return RepoInfo{}, err
+	}
+	resolved := ResolveRepoURL(spec)
+	var byName []RepoInfo
+	for _, r := range repos {
+		if r.URL == spec || r.URL == resolved || (r.Shorthand != "" && r.Shorthand == spec) {
+			return r, nil
+		}
+		name := r.Shorthand
+		if name == "" {
+			name = strings.TrimSuffix(r.URL, ".git")
+		}
+		if idx := strings.LastIndex(name, "/"); idx >= 0 {
+			name = name[idx+1:]
+		}
+		if name == spec {
+			byName = append(byName, r)
+		}
+	}
+	switch len(byName) {
+	case 1:
+		return byName[0], nil
+	case 0:
+		return RepoInfo{}, fmt.Errorf("repository %q is not managed (clone it first)", spec)
+	default:
+		return RepoInfo{}, fmt.Errorf("repository name %q is ambiguous; use the shorthand or URL", spec)
+	}
+}
+
+// Remove deletes a managed bare clone, including every worktree under its
+// worktree directory, and drops it from the manifest. Unless force is set,
+// it refuses when any of those worktrees has uncommitted changes.
+func (m *Manager) Remove(ctx context.Context, repoURL string, force bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	manifest, err := m.loadManifest()
+	if err != nil {
+		return fmt.Errorf("loading manifest: %w", err)
+	}
+
+	info, exists := manifest.Repositories[repoURL]
+	if !exists {
+		return fmt.Errorf("repository %s not found in manifest", repoURL)
+	}
+	barePath := info.BarePath
+	if barePath == "" {
+		barePath = m.getLocalPath(repoURL)
+	}
+
+	// Refuse to delete anything outside the managed repos directory, in case
+	// the manifest was edited by hand.
+	if rel, err := filepath.Rel(m.basePath, barePath); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("refusing to remove %s: not under %s", barePath, m.basePath)
+	}
+
+	if !force {
+		dirty, err := DirtyWorktrees(ctx, barePath)
+		if err != nil {
+			return err
+		}
+		if len(dirty) > 0 {
+			return fmt.Errorf("refusing to remove %s: worktrees with uncommitted changes: %s", repoURL, strings.Join(dirty, ", "))
+		}
+	}
+
+	if err := os.RemoveAll(barePath); err != nil {
+		return fmt.Errorf("removing %s: %w", barePath, err)
+	}
+
+	delete(manifest.Repositories, repoURL)
+	ensuredMu.Lock()
+	delete(ensuredRepos, repoURL)
+	ensuredMu.Unlock()
+
+	if err := m.saveManifest(manifest); err != nil {
+		return fmt.Errorf("saving manifest: %w", err)
+	}
+	return nil
+}
+
+// Worktrees returns the paths of the named worktrees under a bare clone's
+// worktree directory, sorted by name.
+func Worktrees(barePath string) ([]string, error) {
+	dir := filepath.Join(barePath, WorktreeDirName)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var worktrees []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			worktrees = append(worktrees, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return worktrees, nil
+}
+
+// DirtyWorktrees returns the worktrees under a bare clone's worktree
+// directory that have uncommitted or untracked changes. A worktree whose
+// status cannot be read is reported as dirty.
+func DirtyWorktrees(ctx context.Context, barePath string) ([]string, error) {
+	worktrees, err := Worktrees(barePath)
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees of %s: %w", barePath, err)
+	}
+	var dirty []string
+	for _, wt := range worktrees {
+		out, err := exec.CommandContext(ctx, "git", "-C", wt, "status", "--porcelain").Output()
+		if err != nil || len(strings.TrimSpace(string(out))) > 0 {
+			dirty = append(dirty, wt)
+		}
+	}
+	return dirty, nil
+}
+
+// AddWorktree checks out ref (a branch, tag or commit; empty means the
+// default branch) into <bare>/.grove-worktrees/<name> and returns its path.
+// Branch refs that exist locally in the bare clone are checked out as that
+// branch; anything else is checked out detached.
+func (m *Manager) AddWorktree(ctx context.Context, repoURL, name, ref string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid worktree name %q", name)
+	}
+	if err := m.Ensure(ctx, repoURL); err != nil {
+		return "", fmt.Errorf("ensuring bare clone: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	barePath := m.getLocalPath(repoURL)
+	worktreePath := filepath.Join(barePath, WorktreeDirName, name)
+	if _, err := os.Stat(worktreePath); err == nil {
+		return "", fmt.Errorf("worktree %s already exists", worktreePath)
+	}
+	if err := os.MkdirAll(filepath.Dir(worktreePath), 0o755); err != nil {
+		return "", fmt.Errorf("creating worktree directory: %w", err)
+	}
+
+	target := ref
+	if ref == "" || exec.CommandContext(ctx, "git", "-C", barePath, "show-ref", "--verify", "--quiet", "refs/heads/"+ref).Run() != nil { //nolint:gosec // ref from CLI args
+		commit, err := m.resolveVersion(ctx, barePath, ref)
+		if err != nil {
+			return "", err
+		}
+		target = commit
+	}
+
+	if err := m.createWorktree(ctx, barePath, worktreePath, target); err != nil {
+		return "", err
+	}
+	return worktreePath, nil
+}
+
+// RemoveWorktree removes the named worktree from a bare clone and prunes
+// any manifest entries pointing at it. Git refuses to remove a worktree
+// with uncommitted changes, and so does RemoveWorktree.
+func (m *Manager) RemoveWorktree(ctx context.Context, repoURL, name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid worktree name %q", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	manifest, err := m.loadManifest()
+	if err != nil {
+		return fmt.Errorf("loading manifest: %w", err)
+	}
+
+	barePath := m.getLocalPath(repoURL)
+	if info, ok := manifest.Repositories[repoURL]; ok && info.BarePath != "" {
+		barePath = info.BarePath
+	}
+	worktreePath := filepath.Join(barePath, WorktreeDirName, name)
+	if _, err := os.Stat(worktreePath); err != nil {
+		return fmt.Errorf("worktree %s not found", worktreePath)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", barePath, "worktree", "remove", worktreePath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git worktree remove failed: %w\nOutput: %s", err, string(output))
+	}
+
+	if info, ok := manifest.Repositories[repoURL]; ok {
+		changed := false
+		for commit, wt := range info.Worktrees {
+			if wt.Path == worktreePath {
+				delete(info.Worktrees, commit)
+				changed = true
+			}
+		}
+		if changed {
+			manifest.Repositories[repoURL] = info
+			if err := m.saveManifest(manifest); err != nil {
+				return fmt.Errorf("saving manifest: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveRepoURL(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"grovetools/core", "https://github.com/grovetools/core.git"},
+		{"grovetools/core.git", "https://github.com/grovetools/core.git"},
+		{"gitlab.com/group/proj", "https://gitlab.com/group/proj.git"},
+		{"https://github.com/a/b.git", "https://github.com/a/b.git"},
+		{"git@github.com:a/b.git", "git@github.com:a/b.git"},
+		{"/tmp/local/repo", "/tmp/local/repo"},
+	}
+	for _, tt := range tests {
+		if got := ResolveRepoURL(tt.spec); got != tt.want {
+			t.Errorf("ResolveRepoURL(%q) = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func run(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestWorktreeLifecycle(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "src")
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run(t, src, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(src, "README"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run(t, src, "add", ".")
+	run(t, src, "commit", "-q", "-m", "init")
+
+	m := &Manager{
+		basePath:     filepath.Join(tmp, "repos"),
+		manifestPath: filepath.Join(tmp, "manifest.json"),
+	}
+	if err := os.MkdirAll(m.basePath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	wt, err := m.AddWorktree(ctx, src, "feature", "main")
+	if err != nil {
+		t.Fatalf("AddWorktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt, "README")); err != nil {
+		t.Fatalf("worktree not checked out: %v", err)
+	}
+
+	info, err := m.Find("src")
+	if err != nil {
+		t.Fatalf("Find by name: %v", err)
+	}
+	worktrees, err := Worktrees(info.BarePath)
+	if err != nil || len(worktrees) != 1 || filepath.Base(worktrees[0]) != "feature" {
+		t.Fatalf("Worktrees = %v, %v", worktrees, err)
+	}
+
+	// Uncommitted changes keep both the worktree and the clone.
+	scratch := filepath.Join(wt, "scratch.txt")
+	if err := os.WriteFile(scratch, []byte("wip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RemoveWorktree(ctx, src, "feature"); err == nil {
+		t.Error("expected RemoveWorktree to refuse a dirty worktree")
+	}
+	if err := m.Remove(ctx, src, false); err == nil {
+		t.Error("expected Remove to refuse a clone with a dirty worktree")
+	}
+	if _, err := os.Stat(scratch); err != nil {
+		t.Fatalf("uncommitted file lost: %v", err)
+	}
+	if err := os.Remove(scratch); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.RemoveWorktree(ctx, src, "feature"); err != nil {
+		t.Fatalf("RemoveWorktree: %v", err)
+	}
+	if _, err := os.Stat(wt); !os.IsNotExist(err) {
+		t.Errorf("worktree still exists after removal")
+	}
+
+	if err := m.Remove(ctx, src, false); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(info.BarePath); !os.IsNotExist(err) {
+		t.Errorf("bare clone still exists after removal")
+	}
+	if _, err := m.Find(src); err == nil {
+		t.Error("expected Find to fail after removal")
+	}
+}
@@ -0,0 +1,284 @@
+package sessions
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// maxActivitySummary caps the length, in runes, of a SessionActivity
+// summary.
+const maxActivitySummary = 120
+
+// fileEditTools are the tools, lower-cased, whose calls are recorded as file
+// edits rather than plain tool calls: Claude Code's Edit, MultiEdit, Write
+// and NotebookEdit, and OpenCode's edit, write and patch.
+var fileEditTools = map[string]bool{
+	"edit":         true,
+	"multiedit":    true,
+	"write":        true,
+	"notebookedit": true,
+	"patch":        true,
+}
+
+// toolFileKeys are the input keys tools name their file in, in lookup
+// order.
+var toolFileKeys = []string{"file_path", "filePath", "notebook_path", "path"}
+
+// toolSummaryKeys are the input keys that best describe a tool call, in
+// lookup order.
+var toolSummaryKeys = []string{"command", "pattern", "query", "url", "description", "prompt"}
+
+// toolActivity builds the timeline entry for a call of tool with input.
+func toolActivity(t time.Time, tool string, input map[string]interface{}) models.SessionActivity {
+	a := models.SessionActivity{Time: t, Kind: models.ActivityToolCall, Tool: tool}
+	if fileEditTools[strings.ToLower(tool)] {
+		a.Kind = models.ActivityFileEdit
+	}
+	for _, key := range toolFileKeys {
+		if v, ok := input[key].(string); ok && v != "" {
+			a.File = v
+			break
+		}
+	}
+	for _, key := range toolSummaryKeys {
+		if v, ok := input[key].(string); ok && v != "" {
+			a.Summary = summarizeActivity(v)
+			break
+		}
+	}
+	return a
+}
+
+// summarizeActivity returns the first non-blank line of text, truncated to
+// maxActivitySummary runes.
+func summarizeActivity(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if r := []rune(line); len(r) > maxActivitySummary {
+			line = string(r[:maxActivitySummary-1]) + "…"
+		}
+		return line
+	}
+	return ""
+}
+
+// claudeActivityLine is the subset of a Claude Code transcript JSONL line
+// needed for the activity timeline.
+type claudeActivityLine struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	IsMeta    bool      `json:"isMeta"`
+	Message   struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// claudeContentBlock is one block of a transcript message's content array.
+type claudeContentBlock struct {
+	Type  string                 `json:"type"`
+	Text  string                 `json:"text"`
+	Name  string                 `json:"name"`
+	Input map[string]interface{} `json:"input"`
+}
+
+// ReadClaudeActivities reads the timeline of a Claude Code transcript
+// (JSONL): text blocks become messages and tool_use blocks become tool
+// calls or file edits. Tool results and meta lines (injected context) are
+// skipped.
+func ReadClaudeActivities(transcriptPath string) ([]models.SessionActivity, error) {
+	f, err := os.Open(transcriptPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer f.Close()
+
+	var activities []models.SessionActivity
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line claudeActivityLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		if (line.Type != "user" && line.Type != "assistant") || line.IsMeta || len(line.Message.Content) == 0 {
+			continue
+		}
+		role := line.Message.Role
+		if role == "" {
+			role = line.Type
+		}
+
+		// User prompts are usually a plain string; everything else is an
+		// array of content blocks.
+		var text string
+		if json.Unmarshal(line.Message.Content, &text) == nil {
+			if summary := summarizeActivity(text); summary != "" {
+				activities = append(activities, models.SessionActivity{Time: line.Timestamp, Kind: models.ActivityMessage, Role: role, Summary: summary})
+			}
+			continue
+		}
+		var blocks []claudeContentBlock
+		if json.Unmarshal(line.Message.Content, &blocks) != nil {
+			continue
+		}
+		for _, b := range blocks {
+			switch b.Type {
+			case "text":
+				if summary := summarizeActivity(b.Text); summary != "" {
+					activities = append(activities, models.SessionActivity{Time: line.Timestamp, Kind: models.ActivityMessage, Role: role, Summary: summary})
+				}
+			case "tool_use":
+				activities = append(activities, toolActivity(line.Timestamp, b.Name, b.Input))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return activities, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	return activities, nil
+}
+
+// openCodeActivityMessage is the subset of an OpenCode message file needed
+// for the activity timeline.
+type openCodeActivityMessage struct {
+	ID   string `json:"id"`
+	Role string `json:"role"`
+	Time struct {
+		Created int64 `json:"created"`
+	} `json:"time"`
+}
+
+// openCodePart is the subset of an OpenCode message part
+// (storage/part/<message-id>/*.json) needed for the activity timeline.
+type openCodePart struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Tool  string `json:"tool"`
+	State struct {
+		Input map[string]interface{} `json:"input"`
+		Title string                 `json:"title"`
+		Time  struct {
+			Start int64 `json:"start"`
+		} `json:"time"`
+	} `json:"state"`
+	Time struct {
+		Start int64 `json:"start"`
+	} `json:"time"`
+}
+
+// ReadOpenCodeActivities reads the timeline of an OpenCode session from its
+// message directory (storage/message/<session-id>) and the sibling part
+// directories (storage/part/<message-id>): text parts become messages and
+// tool parts become tool calls or file edits. A message whose parts are not
+// on disk is still listed, without a summary.
+func ReadOpenCodeActivities(messageDir string) ([]models.SessionActivity, error) {
+	files, err := filepath.Glob(filepath.Join(messageDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no OpenCode messages in %s", messageDir)
+	}
+	partRoot := filepath.Join(filepath.Dir(filepath.Dir(messageDir)), "part")
+
+	var activities []models.SessionActivity
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var msg openCodeActivityMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		created := time.UnixMilli(msg.Time.Created)
+		if msg.ID == "" {
+			msg.ID = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+
+		partFiles, _ := filepath.Glob(filepath.Join(partRoot, msg.ID, "*.json"))
+		sort.Strings(partFiles)
+		var text []string
+		var tools []models.SessionActivity
+		for _, pf := range partFiles {
+			data, err := os.ReadFile(pf)
+			if err != nil {
+				continue
+			}
+			var part openCodePart
+			if err := json.Unmarshal(data, &part); err != nil {
+				continue
+			}
+			switch part.Type {
+			case "text":
+				text = append(text, part.Text)
+			case "tool":
+				t := created
+				if part.State.Time.Start > 0 {
+					t = time.UnixMilli(part.State.Time.Start)
+				}
+				a := toolActivity(t, part.Tool, part.State.Input)
+				if a.Summary == "" {
+					a.Summary = summarizeActivity(part.State.Title)
+				}
+				tools = append(tools, a)
+			}
+		}
+		if summary := summarizeActivity(strings.Join(text, "\n")); summary != "" || len(partFiles) == 0 {
+			activities = append(activities, models.SessionActivity{Time: created, Kind: models.ActivityMessage, Role: msg.Role, Summary: summary})
+		}
+		activities = append(activities, tools...)
+	}
+	sortActivities(activities)
+	return activities, nil
+}
+
+// sortActivities orders a timeline oldest first, keeping the transcript
+// order of entries with the same time.
+func sortActivities(activities []models.SessionActivity) {
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Time.Before(activities[j].Time)
+	})
+}
+
+// CollectActivities reads the provider's transcript for a session and
+// returns its timeline, oldest first. Providers that record no transcript
+// grove can read return an error.
+func CollectActivities(s *models.Session) ([]models.SessionActivity, error) {
+	switch strings.ToLower(s.Provider) {
+	case "opencode":
+		id := s.ClaudeSessionID
+		if id == "" {
+			id = s.ID
+		}
+		return ReadOpenCodeActivities(openCodeMessageDir(id))
+	default:
+		var activities []models.SessionActivity
+		found := false
+		for _, path := range claudeTranscripts(s) {
+			a, err := ReadClaudeActivities(path)
+			if err != nil {
+				continue
+			}
+			activities = append(activities, a...)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("no transcript found for session %s", s.ID)
+		}
+		sortActivities(activities)
+		return activities, nil
+	}
+}
@@ -0,0 +1,147 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestReadClaudeActivities(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.jsonl")
+	lines := `{"type":"user","timestamp":"2026-01-01T10:00:00Z","message":{"role":"user","content":"Fix the flaky test\nin pkg/foo"}}
+{"type":"user","timestamp":"2026-01-01T10:00:00Z","isMeta":true,"message":{"role":"user","content":"<system context>"}}
+{"type":"assistant","timestamp":"2026-01-01T10:00:05Z","message":{"role":"assistant","content":[{"type":"text","text":"Looking at it."}]}}
+{"type":"assistant","timestamp":"2026-01-01T10:00:06Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./pkg/foo","description":"Run tests"}}]}}
+{"type":"user","timestamp":"2026-01-01T10:00:09Z","message":{"role":"user","content":[{"type":"tool_result","content":"ok"}]}}
+{"type":"assistant","timestamp":"2026-01-01T10:00:12Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Edit","input":{"file_path":"/repo/pkg/foo/foo_test.go","old_string":"a","new_string":"b"}}]}}
+{"type":"summary","summary":"ignored"}
+not json
+`
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadClaudeActivities(path)
+	if err != nil {
+		t.Fatalf("ReadClaudeActivities: %v", err)
+	}
+	want := []models.SessionActivity{
+		{Kind: models.ActivityMessage, Role: "user", Summary: "Fix the flaky test"},
+		{Kind: models.ActivityMessage, Role: "assistant", Summary: "Looking at it."},
+		{Kind: models.ActivityToolCall, Tool: "Bash", Summary: "go test ./pkg/foo"},
+		{Kind: models.ActivityFileEdit, Tool: "Edit", File: "/repo/pkg/foo/foo_test.go"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d activities, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		g := got[i]
+		g.Time = time.Time{}
+		if g != want[i] {
+			t.Errorf("activity %d = %+v, want %+v", i, g, want[i])
+		}
+	}
+	if !got[3].Time.Equal(time.Date(2026, 1, 1, 10, 0, 12, 0, time.UTC)) {
+		t.Errorf("unexpected time for the edit: %s", got[3].Time)
+	}
+}
+
+func TestReadOpenCodeActivities(t *testing.T) {
+	storage := t.TempDir()
+	messageDir := filepath.Join(storage, "message", "ses_1")
+	files := map[string]string{
+		"message/ses_1/msg_1.json":         `{"id":"msg_1","role":"user","time":{"created":1767261600000}}`,
+		"message/ses_1/msg_2.json":         `{"id":"msg_2","role":"assistant","time":{"created":1767261601000}}`,
+		"message/ses_1/msg_3.json":         `{"id":"msg_3","role":"user","time":{"created":1767261700000}}`,
+		"part/msg_1/prt_1.json":            `{"type":"text","text":"Add a README"}`,
+		"part/msg_2/prt_1.json":            `{"type":"text","text":"Writing it now."}`,
+		"part/msg_2/prt_2.json":            `{"type":"tool","tool":"write","state":{"input":{"filePath":"/repo/README.md"},"time":{"start":1767261602000}}}`,
+		"part/msg_2/prt_3.json":            `{"type":"tool","tool":"bash","state":{"input":{},"title":"ls -la","time":{"start":1767261603000}}}`,
+		"part/msg_2/prt_4.json":            `{"type":"step-finish"}`,
+		"message/ses_1/not-a-message.json": `not json`,
+		"message/ses_other/msg_9.json":     `{"id":"msg_9","role":"user","time":{"created":1767261500000}}`,
+		"part/msg_9/prt_1.json":            `{"type":"text","text":"other session"}`,
+	}
+	for name, body := range files {
+		path := filepath.Join(storage, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := ReadOpenCodeActivities(messageDir)
+	if err != nil {
+		t.Fatalf("ReadOpenCodeActivities: %v", err)
+	}
+	var kinds []string
+	for _, a := range got {
+		kinds = append(kinds, a.Kind+":"+a.Tool+a.Role+":"+a.File+a.Summary)
+	}
+	want := []string{
+		"message:user:Add a README",
+		"message:assistant:Writing it now.",
+		"file_edit:write:/repo/README.md",
+		"tool_call:bash:ls -la",
+		// msg_3 has no parts on disk.
+		"message:user:",
+	}
+	if strings.Join(kinds, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected timeline:\n got %q\nwant %q", kinds, want)
+	}
+
+	if _, err := ReadOpenCodeActivities(filepath.Join(storage, "message", "missing")); err == nil {
+		t.Error("expected an error for a session without messages")
+	}
+}
+
+func TestSummarizeActivity(t *testing.T) {
+	if got := summarizeActivity("\n  \n  first line  \nsecond"); got != "first line" {
+		t.Errorf("got %q", got)
+	}
+	long := summarizeActivity(strings.Repeat("é", 200))
+	if n := len([]rune(long)); n != maxActivitySummary {
+		t.Errorf("expected %d runes, got %d", maxActivitySummary, n)
+	}
+	if !strings.HasSuffix(long, "…") {
+		t.Errorf("expected an ellipsis, got %q", long)
+	}
+}
+
+func TestCollectActivitiesMergesTranscripts(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	id := "2b6d9e2c-0000-4000-8000-000000000000"
+	transcripts := map[string]string{
+		"-repo-a": `{"type":"user","timestamp":"2026-01-01T10:05:00Z","message":{"role":"user","content":"second"}}`,
+		"-repo-b": `{"type":"user","timestamp":"2026-01-01T10:00:00Z","message":{"role":"user","content":"first"}}`,
+	}
+	for slug, body := range transcripts {
+		dir := filepath.Join(home, ".claude", "projects", slug)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, id+".jsonl"), []byte(body+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := CollectActivities(&models.Session{ID: "job-1", ClaudeSessionID: id})
+	if err != nil {
+		t.Fatalf("CollectActivities: %v", err)
+	}
+	if len(got) != 2 || got[0].Summary != "first" || got[1].Summary != "second" {
+		t.Errorf("expected the transcripts merged in time order, got %+v", got)
+	}
+
+	if _, err := CollectActivities(&models.Session{ID: "missing"}); err == nil {
+		t.Error("expected an error for a session without a transcript")
+	}
+}
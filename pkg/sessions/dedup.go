@@ -0,0 +1,145 @@
+package sessions
+
+import (
+	"strings"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// Session sources, named as under daemon.collectors.session.providers.
+const (
+	SourceInteractive = "interactive"
+	SourceFlowJobs    = "flow_jobs"
+	SourceOpenCode    = "opencode"
+)
+
+// DefaultSourcePriority is the order in which sources win duplicates when
+// daemon.collectors.session.provider_priority is unset: the hook-registered
+// interactive session over the flow job that launched the same agent.
+var DefaultSourcePriority = []string{SourceInteractive, SourceFlowJobs, SourceOpenCode}
+
+// Source returns the source a session was reported by: flow jobs carry their
+// job file or plan, OpenCode sessions their provider, and everything else is
+// an interactive session registered by hooks.
+func Source(s *models.Session) string {
+	switch {
+	case s.JobFilePath != "" || s.PlanName != "":
+		return SourceFlowJobs
+	case strings.EqualFold(s.Provider, "opencode"):
+		return SourceOpenCode
+	default:
+		return SourceInteractive
+	}
+}
+
+// DedupKey is the identity under which sessions reported more than once are
+// merged: the agent's native session ID, which a flow job and the hooks of
+// the agent it launched both record, or the session ID when there is none.
+func DedupKey(s *models.Session) string {
+	if s.ClaudeSessionID != "" {
+		return s.ClaudeSessionID
+	}
+	return s.ID
+}
+
+// DedupRule is one precedence rule of a DedupPolicy. Compare returns a
+// positive number when a should win over b, a negative one when b should,
+// and 0 when the rule cannot tell them apart.
+type DedupRule struct {
+	Name    string
+	Compare func(a, b *models.Session) int
+}
+
+// DedupPolicy decides which of several sessions with the same DedupKey is
+// kept. Its rules are tried in order and the first that tells the sessions
+// apart decides; when none does, the session reported first is kept.
+type DedupPolicy []DedupRule
+
+// PreferPID prefers a session with a process over one without, such as an
+// intent registered before its agent started.
+func PreferPID() DedupRule {
+	return DedupRule{Name: "pid", Compare: func(a, b *models.Session) int {
+		return compareBool(a.PID > 0, b.PID > 0)
+	}}
+}
+
+// PreferSources prefers sessions by Source in the given order; sources not
+// listed lose to every listed one.
+func PreferSources(order ...string) DedupRule {
+	rank := make(map[string]int, len(order))
+	for i, source := range order {
+		if _, ok := rank[source]; !ok {
+			rank[source] = len(order) - i
+		}
+	}
+	return DedupRule{Name: "source", Compare: func(a, b *models.Session) int {
+		return rank[Source(a)] - rank[Source(b)]
+	}}
+}
+
+// PreferRecent prefers the session with the latest activity, then the one
+// started last.
+func PreferRecent() DedupRule {
+	return DedupRule{Name: "recency", Compare: func(a, b *models.Session) int {
+		if c := a.LastActivity.Compare(b.LastActivity); c != 0 {
+			return c
+		}
+		return a.StartedAt.Compare(b.StartedAt)
+	}}
+}
+
+// NewDedupPolicy returns the standard policy with sources ranked in the
+// given order: PID presence, then source priority, then recency.
+func NewDedupPolicy(sourcePriority ...string) DedupPolicy {
+	return DedupPolicy{PreferPID(), PreferSources(sourcePriority...), PreferRecent()}
+}
+
+// DefaultDedupPolicy is the standard policy with DefaultSourcePriority.
+func DefaultDedupPolicy() DedupPolicy {
+	return NewDedupPolicy(DefaultSourcePriority...)
+}
+
+// Prefer returns whichever of a and b the policy keeps, and a on a tie.
+func (p DedupPolicy) Prefer(a, b *models.Session) *models.Session {
+	for _, rule := range p {
+		if c := rule.Compare(a, b); c != 0 {
+			if c > 0 {
+				return a
+			}
+			return b
+		}
+	}
+	return a
+}
+
+// Dedup keeps one session per DedupKey, chosen by the policy, in the order
+// each key was first reported. Sessions without a key are all kept.
+func (p DedupPolicy) Dedup(sessions []*models.Session) []*models.Session {
+	index := make(map[string]int, len(sessions))
+	out := make([]*models.Session, 0, len(sessions))
+	for _, s := range sessions {
+		key := DedupKey(s)
+		if key == "" {
+			out = append(out, s)
+			continue
+		}
+		if i, ok := index[key]; ok {
+			out[i] = p.Prefer(out[i], s)
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, s)
+	}
+	return out
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
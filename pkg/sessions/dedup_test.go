@@ -0,0 +1,116 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		session *models.Session
+		want    string
+	}{
+		{&models.Session{Provider: "claude"}, SourceInteractive},
+		{&models.Session{Provider: "claude", JobFilePath: "/plans/p/01-job.md"}, SourceFlowJobs},
+		{&models.Session{PlanName: "p"}, SourceFlowJobs},
+		{&models.Session{Provider: "OpenCode"}, SourceOpenCode},
+	}
+	for _, tt := range tests {
+		if got := Source(tt.session); got != tt.want {
+			t.Errorf("Source(%+v) = %q, want %q", tt.session, got, tt.want)
+		}
+	}
+}
+
+func TestDedupPolicyPrefer(t *testing.T) {
+	now := time.Now()
+	interactive := func(pid int, activity time.Time) *models.Session {
+		return &models.Session{ID: "hook", ClaudeSessionID: "native", Provider: "claude", PID: pid, LastActivity: activity}
+	}
+	flowJob := func(pid int, activity time.Time) *models.Session {
+		return &models.Session{ID: "job", ClaudeSessionID: "native", Provider: "claude", JobFilePath: "/plans/p/01-job.md", PID: pid, LastActivity: activity}
+	}
+	openCode := func(pid int, activity time.Time) *models.Session {
+		return &models.Session{ID: "oc", ClaudeSessionID: "native", Provider: "opencode", PID: pid, LastActivity: activity}
+	}
+
+	defaults := DefaultDedupPolicy()
+	flowFirst := NewDedupPolicy(SourceFlowJobs, SourceInteractive, SourceOpenCode)
+
+	tests := []struct {
+		name   string
+		policy DedupPolicy
+		a, b   *models.Session
+		want   string
+	}{
+		// PID presence decides before anything else.
+		{"pid beats no pid", defaults, flowJob(0, now), interactive(42, now.Add(-time.Hour)), "hook"},
+		{"pid beats no pid either way", defaults, interactive(0, now), flowJob(42, now.Add(-time.Hour)), "job"},
+		{"pid beats source priority", flowFirst, flowJob(0, now), interactive(42, now), "hook"},
+
+		// Then source priority.
+		{"interactive beats flow job", defaults, flowJob(42, now), interactive(43, now.Add(-time.Hour)), "hook"},
+		{"flow job beats interactive when listed first", flowFirst, interactive(42, now), flowJob(43, now.Add(-time.Hour)), "job"},
+		{"flow job beats opencode", defaults, openCode(42, now), flowJob(43, now.Add(-time.Hour)), "job"},
+		{"both without pid use source priority", defaults, flowJob(0, now), interactive(0, now.Add(-time.Hour)), "hook"},
+
+		// Then recency.
+		{"more recent activity wins", defaults, &models.Session{ID: "a", PID: 42, LastActivity: now.Add(-time.Hour)}, &models.Session{ID: "b", PID: 43, LastActivity: now}, "b"},
+		{"later start breaks activity tie", defaults, &models.Session{ID: "a", StartedAt: now.Add(-time.Hour)}, &models.Session{ID: "b", StartedAt: now}, "b"},
+
+		// A full tie keeps the session reported first.
+		{"tie keeps first", defaults, &models.Session{ID: "a"}, &models.Session{ID: "b"}, "a"},
+		{"empty policy keeps first", DedupPolicy{}, interactive(0, now), flowJob(42, now), "hook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Prefer(tt.a, tt.b); got.ID != tt.want {
+				t.Errorf("Prefer kept %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreferSourcesUnlisted(t *testing.T) {
+	rule := PreferSources(SourceFlowJobs)
+	flow := &models.Session{JobFilePath: "/plans/p/01-job.md"}
+	hook := &models.Session{}
+	open := &models.Session{Provider: "opencode"}
+
+	if rule.Compare(flow, hook) <= 0 {
+		t.Error("listed source should beat an unlisted one")
+	}
+	if rule.Compare(hook, open) != 0 {
+		t.Error("unlisted sources should tie")
+	}
+}
+
+func TestDedupPolicyDedup(t *testing.T) {
+	now := time.Now()
+	sessions := []*models.Session{
+		{ID: "job", ClaudeSessionID: "native-1", JobFilePath: "/plans/p/01-job.md", PID: 10, LastActivity: now},
+		{ID: "solo", PID: 11},
+		{ID: "native-1", ClaudeSessionID: "native-1", PID: 10, LastActivity: now.Add(-time.Minute)},
+		{ID: "intent", ClaudeSessionID: "native-2"},
+		{ID: "native-2", ClaudeSessionID: "native-2", JobFilePath: "/plans/p/02-job.md", PID: 12},
+		{},
+		{},
+	}
+
+	got := DefaultDedupPolicy().Dedup(sessions)
+	var ids []string
+	for _, s := range got {
+		ids = append(ids, s.ID)
+	}
+	want := []string{"native-1", "solo", "native-2", "", ""}
+	if len(ids) != len(want) {
+		t.Fatalf("Dedup = %q, want %q", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("Dedup = %q, want %q", ids, want)
+		}
+	}
+}
@@ -65,26 +65,24 @@ func recoverSessions(filterByScope bool, scope string) ([]*models.Session, error
 			continue
 		}
 
-		// Check if process is alive
-		isAlive := process.IsProcessAlive(pid)
+		// Read metadata
+		var metadata SessionMetadata
+		hasMetadata := false
+		if metadataContent, err := os.ReadFile(metadataFile); err == nil {
+			hasMetadata = json.Unmarshal(metadataContent, &metadata) == nil
+		}
+
+		// Check that the process is alive and is still the session's agent
+		// rather than a later process that reused its PID.
+		isAlive := process.IsSameProcess(pid, metadata.Fingerprint)
 
 		if !isAlive {
 			// Clean up dead session recovery files. When filtering by scope, a
-			// daemon must only reap records it owns: read the metadata to learn
-			// the owning scope and leave records belonging to other scopes (or
-			// whose ownership can't be determined) untouched.
-			if filterByScope {
-				metadataContent, merr := os.ReadFile(metadataFile)
-				if merr != nil {
-					continue
-				}
-				var deadMeta SessionMetadata
-				if err := json.Unmarshal(metadataContent, &deadMeta); err != nil {
-					continue
-				}
-				if deadMeta.Scope != scope {
-					continue
-				}
+			// daemon must only reap records it owns: the metadata tells the
+			// owning scope, and records belonging to other scopes (or whose
+			// ownership can't be determined) are left untouched.
+			if filterByScope && (!hasMetadata || metadata.Scope != scope) {
+				continue
 			}
 			if registry != nil {
 				_ = registry.Unregister(dirName)
@@ -92,14 +90,7 @@ func recoverSessions(filterByScope bool, scope string) ([]*models.Session, error
 			continue
 		}
 
-		// Read metadata
-		metadataContent, err := os.ReadFile(metadataFile)
-		if err != nil {
-			continue
-		}
-
-		var metadata SessionMetadata
-		if err := json.Unmarshal(metadataContent, &metadata); err != nil {
+		if !hasMetadata {
 			continue
 		}
 
@@ -131,14 +122,18 @@ func recoverSessions(filterByScope bool, scope string) ([]*models.Session, error
 			User:             metadata.User,
 			Status:           status,
 			StartedAt:        metadata.StartedAt,
-			LastActivity:     time.Now(),
 			IsTest:           false,
 			JobTitle:         metadata.JobTitle,
 			PlanName:         metadata.PlanName,
 			JobFilePath:      metadata.JobFilePath,
 			Provider:         metadata.Provider,
 			PtyID:            metadata.PtyID,
+			TmuxPane:         metadata.TmuxPane,
+			Fingerprint:      metadata.Fingerprint,
+			TranscriptPath:   metadata.TranscriptPath,
 		}
+		session.LastActivity = LastActivity(session)
+		annotateJobFile(session)
 
 		sessions = append(sessions, session)
 	}
@@ -182,12 +177,53 @@ func ResolveClaudeSessionDirs(claudeSessionID string) ([]string, error) {
 // DiscoverAll returns sessions recovered from the filesystem crash-recovery registry.
 // This is used by LocalClient as a fallback when the daemon is not available.
 // The daemon is the single source of truth for live session state; this only returns
-// sessions with live PIDs found via crash-recovery scanning.
+// sessions with live PIDs found via crash-recovery scanning. Sessions inactive
+// for DefaultIdleThreshold are marked Idle, and an agent registered both by
+// its flow job and by its own hooks is reported once, as DefaultDedupPolicy
+// decides.
 func DiscoverAll() ([]*models.Session, error) {
+	return DiscoverAllWithIdleThreshold(DefaultIdleThreshold)
+}
+
+// DiscoverAllWithIdleThreshold is DiscoverAll with the idle threshold passed
+// to MarkIdle; 0 disables idle detection.
+func DiscoverAllWithIdleThreshold(idleThreshold time.Duration) ([]*models.Session, error) {
+	return Discover(DiscoverOptions{IdleThreshold: idleThreshold})
+}
+
+// DiscoverOptions configures Discover.
+type DiscoverOptions struct {
+	// IdleThreshold is passed to MarkIdle; 0 disables idle detection.
+	IdleThreshold time.Duration
+	// Dedup merges sessions reported more than once. Nil uses
+	// DefaultDedupPolicy.
+	Dedup DedupPolicy
+	// Usage, when set, attaches each session's accumulated usage (see
+	// UsageCollector.Attach). A collector kept across runs only re-reads
+	// usage files that changed.
+	Usage *UsageCollector
+}
+
+// Discover is DiscoverAll with the idle threshold, deduplication policy and
+// usage aggregation set by opts.
+func Discover(opts DiscoverOptions) ([]*models.Session, error) {
 	sessions, err := RecoverSessions()
 	if err != nil {
 		return nil, err
 	}
+	policy := opts.Dedup
+	if policy == nil {
+		policy = DefaultDedupPolicy()
+	}
+	sessions = policy.Dedup(sessions)
+
+	now := time.Now()
+	for _, s := range sessions {
+		MarkIdle(s, now, opts.IdleThreshold)
+	}
+	if opts.Usage != nil {
+		opts.Usage.Attach(sessions)
+	}
 
 	// Sort by last activity (most recent first)
 	sort.Slice(sessions, func(i, j int) bool {
@@ -0,0 +1,38 @@
+package sessions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/util/sanitize"
+)
+
+// EditorSessionType is the session type of interactive editor sessions
+// launched into a workspace by `core editor --workspace`.
+const EditorSessionType = "editor"
+
+// EditorServerSocket returns the socket the editor server for a workspace
+// listens on: one per workspace identifier, under the state directory.
+func EditorServerSocket(workspaceID string) string {
+	return filepath.Join(paths.StateDir(), "editor", sanitize.SanitizeForTmuxSession(workspaceID)+".sock")
+}
+
+// EditorSessionMetadata returns the registry entry for an editor process
+// running in workDir for a workspace. The ID is derived from the workspace
+// and pid, so registering the same editor again updates its entry.
+func EditorSessionMetadata(editor, workspace, workDir string, pid int) SessionMetadata {
+	return SessionMetadata{
+		SessionID:        fmt.Sprintf("editor-%s-%d", sanitize.SanitizeForTmuxSession(workspace), pid),
+		Provider:         editor,
+		PID:              pid,
+		Repo:             workspace,
+		WorkingDirectory: workDir,
+		User:             os.Getenv("USER"),
+		Status:           "running",
+		StartedAt:        time.Now(),
+		Type:             EditorSessionType,
+	}
+}
@@ -0,0 +1,46 @@
+package sessions
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEditorSessionIsRecovered(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+
+	meta := EditorSessionMetadata("nvim", "My Project", "/src/my-project", os.Getpid())
+	if want := "editor-my-project-"; !strings.HasPrefix(meta.SessionID, want) {
+		t.Errorf("SessionID = %q, want prefix %q", meta.SessionID, want)
+	}
+	registry, err := NewFileSystemRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(meta); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := RecoverSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recovered) != 1 {
+		t.Fatalf("recovered %d sessions, want 1", len(recovered))
+	}
+	s := recovered[0]
+	if s.ID != meta.SessionID || s.Type != EditorSessionType || s.Provider != "nvim" || s.WorkingDirectory != "/src/my-project" || s.Status != "running" {
+		t.Errorf("recovered session = %+v", s)
+	}
+}
+
+func TestEditorServerSocket(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	a := EditorServerSocket("eco:api")
+	if !strings.HasSuffix(a, "/editor/eco-api.sock") {
+		t.Errorf("EditorServerSocket = %q", a)
+	}
+	if EditorServerSocket("eco:web") == a {
+		t.Error("workspaces must not share an editor socket")
+	}
+}
@@ -0,0 +1,259 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/process"
+)
+
+// DefaultCleanupAge is how long a stale artifact must have been untouched
+// before CleanupStale removes it when no age is given.
+const DefaultCleanupAge = 24 * time.Hour
+
+// Kinds of artifacts reported by StaleArtifacts and CleanupStale.
+const (
+	ArtifactSession  = "session"   // hook session dir whose agent process is gone
+	ArtifactLock     = "lock"      // .lock file with no owner
+	ArtifactEmptyDir = "empty_dir" // empty dir under the hooks state tree, e.g. a job dir
+)
+
+// StaleArtifact is one file or directory CleanupStale removes.
+type StaleArtifact struct {
+	Path    string    `json:"path"`
+	Kind    string    `json:"kind"`
+	Reason  string    `json:"reason"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// CleanupReport lists what CleanupStale removed and what it failed to.
+type CleanupReport struct {
+	Removed []StaleArtifact `json:"removed"`
+	Failed  []StaleArtifact `json:"failed,omitempty"`
+}
+
+// StaleArtifacts returns the artifacts CleanupStale would remove, without
+// removing anything:
+//
+//   - hook session dirs (~/.local/state/grove/hooks/sessions/<id>) whose
+//     recorded PID is no longer running
+//   - .lock files with no owner: stray locks in the sessions dir and daemon
+//     spawn locks whose pidfile is gone
+//   - empty directories under the hooks state tree, such as job dirs whose
+//     contents were already removed
+//
+// Only artifacts untouched for at least olderThan are returned, so a
+// session that is still registering is never caught. A zero olderThan uses
+// DefaultCleanupAge.
+func StaleArtifacts(olderThan time.Duration) ([]StaleArtifact, error) {
+	if olderThan <= 0 {
+		olderThan = DefaultCleanupAge
+	}
+	return findStale(paths.StateDir(), time.Now().Add(-olderThan))
+}
+
+// CleanupStale removes the artifacts StaleArtifacts reports. A failure to
+// remove one entry is recorded in the report and does not stop the rest.
+func CleanupStale(olderThan time.Duration) (*CleanupReport, error) {
+	stale, err := StaleArtifacts(olderThan)
+	if err != nil {
+		return nil, err
+	}
+	return removeStale(stale), nil
+}
+
+// RunCleanup calls CleanupStale every interval until ctx is done, passing
+// each result to report. The daemon runs this when daemon.session_gc_interval
+// is set.
+func RunCleanup(ctx context.Context, interval, olderThan time.Duration, report func(*CleanupReport, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r, err := CleanupStale(olderThan)
+			if report != nil {
+				report(r, err)
+			}
+		}
+	}
+}
+
+func removeStale(stale []StaleArtifact) *CleanupReport {
+	report := &CleanupReport{}
+	for _, a := range stale {
+		var err error
+		if a.Kind == ArtifactSession {
+			err = os.RemoveAll(a.Path)
+		} else {
+			// os.Remove refuses a dir that gained entries since the scan.
+			err = os.Remove(a.Path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			a.Reason = err.Error()
+			report.Failed = append(report.Failed, a)
+			continue
+		}
+		report.Removed = append(report.Removed, a)
+	}
+	return report
+}
+
+func findStale(stateDir string, cutoff time.Time) ([]StaleArtifact, error) {
+	hooksDir := filepath.Join(stateDir, "hooks")
+	sessionsDir := filepath.Join(hooksDir, "sessions")
+
+	var stale []StaleArtifact
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+	removed := make(map[string]bool)
+	for _, entry := range entries {
+		path := filepath.Join(sessionsDir, entry.Name())
+		if !entry.IsDir() {
+			if strings.HasSuffix(entry.Name(), ".lock") {
+				if mod, ok := staleSince(path, cutoff); ok {
+					stale = append(stale, StaleArtifact{Path: path, Kind: ArtifactLock, Reason: "lock file outside a session dir", ModTime: mod})
+				}
+			}
+			continue
+		}
+		if a, ok := staleSession(path, cutoff); ok {
+			stale = append(stale, a)
+			removed[path] = true
+		}
+	}
+
+	// Spawn locks live next to the daemon pidfiles; one whose pidfile is gone
+	// belongs to a daemon that has exited.
+	locks, _ := filepath.Glob(filepath.Join(stateDir, "groved*.pid.spawn.lock"))
+	for _, lock := range locks {
+		if _, err := os.Stat(strings.TrimSuffix(lock, ".spawn.lock")); err == nil {
+			continue
+		}
+		if mod, ok := staleSince(lock, cutoff); ok {
+			stale = append(stale, StaleArtifact{Path: lock, Kind: ArtifactLock, Reason: "daemon pidfile is gone", ModTime: mod})
+		}
+	}
+
+	stale = append(stale, emptyDirs(hooksDir, sessionsDir, cutoff, removed)...)
+	return stale, nil
+}
+
+// staleSession reports a session dir whose agent process has exited, even
+// if its PID has since been reused (see process.IsSameProcess). The
+// PID comes from pid.lock, or from metadata.json once pid.lock was removed
+// at session end; a dir with neither is left to emptyDirs. The dir's age is
+// that of its newest entry, since hooks keep updating metadata.json while
+// the session runs.
+func staleSession(dir string, cutoff time.Time) (StaleArtifact, bool) {
+	pid, fp, ok := sessionProcess(dir)
+	if !ok || process.IsSameProcess(pid, fp) {
+		return StaleArtifact{}, false
+	}
+	mod, ok := staleSince(dir, cutoff)
+	if !ok {
+		return StaleArtifact{}, false
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return StaleArtifact{}, false
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(cutoff) {
+			return StaleArtifact{}, false
+		}
+		if info.ModTime().After(mod) {
+			mod = info.ModTime()
+		}
+	}
+	return StaleArtifact{Path: dir, Kind: ArtifactSession, Reason: fmt.Sprintf("pid %d is not running", pid), ModTime: mod}, true
+}
+
+// sessionProcess returns the agent PID of a session dir and, from
+// metadata.json, the fingerprint recorded for it.
+func sessionProcess(dir string) (int, *process.Fingerprint, bool) {
+	var meta SessionMetadata
+	if data, err := os.ReadFile(filepath.Join(dir, "metadata.json")); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+	var pid int
+	if data, err := os.ReadFile(filepath.Join(dir, "pid.lock")); err == nil {
+		if _, err := fmt.Sscanf(string(data), "%d", &pid); err == nil && pid > 0 {
+			return pid, meta.Fingerprint, true
+		}
+	}
+	if meta.PID <= 0 {
+		return 0, nil, false
+	}
+	return meta.PID, meta.Fingerprint, true
+}
+
+// emptyDirs returns the empty directories under root, deepest first so a
+// parent left empty by removing its children is reported after them. The
+// sessions dir itself is kept, as are dirs already reported for removal.
+func emptyDirs(root, keep string, cutoff time.Time, removed map[string]bool) []StaleArtifact {
+	var dirs []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if removed[path] {
+			return filepath.SkipDir
+		}
+		if path != root && path != keep {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+
+	var stale []StaleArtifact
+	gone := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		empty := true
+		for _, entry := range entries {
+			if !gone[filepath.Join(dir, entry.Name())] {
+				empty = false
+				break
+			}
+		}
+		if !empty {
+			continue
+		}
+		if mod, ok := staleSince(dir, cutoff); ok {
+			stale = append(stale, StaleArtifact{Path: dir, Kind: ArtifactEmptyDir, Reason: "empty directory", ModTime: mod})
+			gone[dir] = true
+		}
+	}
+	return stale
+}
+
+// staleSince returns path's modification time when it is before cutoff.
+func staleSince(path string, cutoff time.Time) (time.Time, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.ModTime().After(cutoff) {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
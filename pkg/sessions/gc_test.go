@@ -0,0 +1,110 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func writeAged(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	setAge(t, path, age)
+}
+
+func setAge(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	when := time.Now().Add(-age)
+	if err := os.Chtimes(path, when, when); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindStale(t *testing.T) {
+	state := t.TempDir()
+	sessionsDir := filepath.Join(state, "hooks", "sessions")
+	old := 48 * time.Hour
+	dead := "99999999"
+	live := strconv.Itoa(os.Getpid())
+
+	// Dead PID, untouched for two days: removed.
+	writeAged(t, filepath.Join(sessionsDir, "dead", "pid.lock"), dead, old)
+	writeAged(t, filepath.Join(sessionsDir, "dead", "metadata.json"), `{"pid":99999999}`, old)
+	setAge(t, filepath.Join(sessionsDir, "dead"), old)
+	// Ended session whose pid.lock was removed: PID comes from metadata.
+	writeAged(t, filepath.Join(sessionsDir, "ended", "metadata.json"), `{"pid":99999999}`, old)
+	setAge(t, filepath.Join(sessionsDir, "ended"), old)
+	// Dead PID but metadata touched recently: kept.
+	writeAged(t, filepath.Join(sessionsDir, "recent", "pid.lock"), dead, old)
+	writeAged(t, filepath.Join(sessionsDir, "recent", "metadata.json"), `{}`, time.Minute)
+	setAge(t, filepath.Join(sessionsDir, "recent"), old)
+	// Live PID: kept however old.
+	writeAged(t, filepath.Join(sessionsDir, "live", "pid.lock"), live, old)
+	setAge(t, filepath.Join(sessionsDir, "live"), old)
+	// Stray lock in the sessions dir.
+	writeAged(t, filepath.Join(sessionsDir, "stray.lock"), "", old)
+	// Spawn locks: one orphaned, one next to a live pidfile.
+	writeAged(t, filepath.Join(state, "groved-abc.pid.spawn.lock"), "", old)
+	writeAged(t, filepath.Join(state, "groved.pid.spawn.lock"), "", old)
+	writeAged(t, filepath.Join(state, "groved.pid"), live, old)
+	// Nested empty job dirs, and a job dir that still has output.
+	for _, dir := range []string{"jobs/a/b", "jobs/a", "jobs"} {
+		path := filepath.Join(state, "hooks", dir)
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAged(t, filepath.Join(state, "hooks", "runs", "x", "out.log"), "ok", old)
+	for _, dir := range []string{"jobs/a/b", "jobs/a", "jobs", "runs/x", "runs"} {
+		setAge(t, filepath.Join(state, "hooks", dir), old)
+	}
+
+	stale, err := findStale(state, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, a := range stale {
+		rel, _ := filepath.Rel(state, a.Path)
+		got = append(got, a.Kind+":"+rel)
+	}
+	sort.Strings(got)
+	want := []string{
+		"empty_dir:hooks/jobs",
+		"empty_dir:hooks/jobs/a",
+		"empty_dir:hooks/jobs/a/b",
+		"lock:groved-abc.pid.spawn.lock",
+		"lock:hooks/sessions/stray.lock",
+		"session:hooks/sessions/dead",
+		"session:hooks/sessions/ended",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("stale = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("stale = %v, want %v", got, want)
+		}
+	}
+
+	report := removeStale(stale)
+	if len(report.Failed) != 0 || len(report.Removed) != len(want) {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, keep := range []string{"hooks/sessions/live", "hooks/sessions/recent", "hooks/runs/x/out.log", "groved.pid.spawn.lock"} {
+		if _, err := os.Stat(filepath.Join(state, keep)); err != nil {
+			t.Errorf("%s was removed: %v", keep, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(state, "hooks", "jobs")); !os.IsNotExist(err) {
+		t.Errorf("hooks/jobs should be removed, stat err = %v", err)
+	}
+}
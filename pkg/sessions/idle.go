@@ -0,0 +1,75 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// DefaultIdleThreshold is how long a live session may go without activity
+// before MarkIdle flags it, when daemon.collectors.session.idle_threshold
+// is unset.
+const DefaultIdleThreshold = 10 * time.Minute
+
+// endedStatuses are the statuses of sessions that can no longer be idle.
+var endedStatuses = map[string]bool{
+	"stopped":   true,
+	"completed": true,
+	"failed":    true,
+	"error":     true,
+}
+
+// activityFiles returns the files whose modification times record a
+// session's activity: the provider's transcript (Claude's JSONL, or
+// OpenCode's message directory, which gains a file per message) and the
+// hook registry's metadata.json, rewritten on every status change.
+func activityFiles(s *models.Session) []string {
+	id := s.ClaudeSessionID
+	if id == "" {
+		id = s.ID
+	}
+	var files []string
+	if id != "" {
+		files = append(files, filepath.Join(paths.StateDir(), "hooks", "sessions", id, "metadata.json"))
+	}
+	switch strings.ToLower(s.Provider) {
+	case "opencode":
+		if id != "" {
+			files = append(files, openCodeMessageDir(id))
+		}
+	default:
+		files = append(files, claudeTranscripts(s)...)
+	}
+	return files
+}
+
+// LastActivity returns when s last showed activity: the latest of its
+// start time and the modification times of its activity files.
+func LastActivity(s *models.Session) time.Time {
+	last := s.StartedAt
+	for _, path := range activityFiles(s) {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last
+}
+
+// MarkIdle refreshes s.LastActivity from its activity files, keeping a
+// later time already recorded (by the daemon from hook events, say), and
+// sets s.Idle when s is live and has been inactive for at least threshold
+// as of now. A threshold of 0 or less disables idle detection.
+func MarkIdle(s *models.Session, now time.Time, threshold time.Duration) {
+	if last := LastActivity(s); last.After(s.LastActivity) {
+		s.LastActivity = last
+	}
+	s.Idle = threshold > 0 &&
+		s.EndedAt == nil &&
+		!endedStatuses[s.Status] &&
+		!s.LastActivity.IsZero() &&
+		now.Sub(s.LastActivity) >= threshold
+}
@@ -0,0 +1,81 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/paths"
+)
+
+func TestMarkIdleUsesTranscriptAndMetadataTimes(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	writeLiveSession(t, "agent-1", "")
+
+	now := time.Now()
+	old := now.Add(-30 * time.Minute)
+	metadata := filepath.Join(paths.StateDir(), "hooks", "sessions", "agent-1", "metadata.json")
+	if err := os.Chtimes(metadata, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := RecoverSessions()
+	if err != nil || len(recovered) != 1 {
+		t.Fatalf("RecoverSessions = %v, %v", recovered, err)
+	}
+	s := recovered[0]
+	if !s.LastActivity.Equal(old) {
+		t.Errorf("expected LastActivity from metadata.json (%v), got %v", old, s.LastActivity)
+	}
+	MarkIdle(s, now, 10*time.Minute)
+	if !s.Idle {
+		t.Fatal("expected a session untouched for 30m to be idle at a 10m threshold")
+	}
+
+	// A transcript write makes it active again.
+	transcript := filepath.Join(t.TempDir(), "agent-1.jsonl")
+	if err := os.WriteFile(transcript, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	recent := now.Add(-time.Minute)
+	if err := os.Chtimes(transcript, recent, recent); err != nil {
+		t.Fatal(err)
+	}
+	s.TranscriptPath = transcript
+	MarkIdle(s, now, 10*time.Minute)
+	if s.Idle || !s.LastActivity.Equal(recent) {
+		t.Errorf("expected active at %v, got idle=%v last=%v", recent, s.Idle, s.LastActivity)
+	}
+}
+
+func TestMarkIdle(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	now := time.Now()
+	ended := now.Add(-time.Hour)
+	tests := []struct {
+		name      string
+		session   models.Session
+		threshold time.Duration
+		want      bool
+	}{
+		{"inactive", models.Session{Status: "running", StartedAt: now.Add(-time.Hour)}, 10 * time.Minute, true},
+		{"recent", models.Session{Status: "running", StartedAt: now.Add(-time.Minute)}, 10 * time.Minute, false},
+		{"later recorded activity wins", models.Session{Status: "running", StartedAt: now.Add(-time.Hour), LastActivity: now}, 10 * time.Minute, false},
+		{"waiting for input", models.Session{Status: "idle", StartedAt: now.Add(-time.Hour)}, 10 * time.Minute, true},
+		{"completed", models.Session{Status: "completed", StartedAt: now.Add(-time.Hour)}, 10 * time.Minute, false},
+		{"ended", models.Session{Status: "running", StartedAt: now.Add(-2 * time.Hour), EndedAt: &ended}, 10 * time.Minute, false},
+		{"disabled", models.Session{Status: "running", StartedAt: now.Add(-time.Hour)}, 0, false},
+		{"no times", models.Session{Status: "running"}, 10 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.session
+			MarkIdle(&s, now, tt.threshold)
+			if s.Idle != tt.want {
+				t.Errorf("Idle = %v, want %v", s.Idle, tt.want)
+			}
+		})
+	}
+}
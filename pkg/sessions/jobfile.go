@@ -0,0 +1,53 @@
+package sessions
+
+import (
+	"errors"
+	"os"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/util/frontmatter"
+)
+
+// annotateJobFile checks the frontmatter of a session's job file. A valid
+// job fills the title and type the session metadata left empty. Anything
+// else, such as a note or malformed frontmatter, is recorded on
+// JobFileIssues instead of being read as a job. A job file that can no
+// longer be opened is left alone: flow moves and archives them.
+func annotateJobFile(s *models.Session) {
+	if s.JobFilePath == "" {
+		return
+	}
+	f, err := os.Open(s.JobFilePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	meta, err := frontmatter.Parse(f)
+	if err != nil {
+		s.JobFileIssues = []string{err.Error()}
+		return
+	}
+	if err := frontmatter.Validate(meta); err != nil {
+		var verr *frontmatter.ValidationError
+		if errors.As(err, &verr) {
+			for _, issue := range verr.Issues {
+				s.JobFileIssues = append(s.JobFileIssues, issue.String())
+			}
+		} else {
+			s.JobFileIssues = []string{err.Error()}
+		}
+		return
+	}
+	if meta.Kind() != frontmatter.KindJob {
+		s.JobFileIssues = []string{"frontmatter has no type: the file is a note, not a job"}
+		return
+	}
+
+	if s.JobTitle == "" {
+		s.JobTitle = meta.Title
+	}
+	if s.Type == "" {
+		s.Type = meta.Type
+	}
+}
@@ -0,0 +1,44 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestAnnotateJobFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	job := &models.Session{JobFilePath: write("job.md", "---\nid: j1\ntitle: Build it\ntype: interactive_agent\nstatus: running\n---\n")}
+	annotateJobFile(job)
+	if job.JobTitle != "Build it" || job.Type != "interactive_agent" || len(job.JobFileIssues) != 0 {
+		t.Errorf("valid job: %+v", job)
+	}
+
+	note := &models.Session{Type: "claude_session", JobFilePath: write("note.md", "---\ntitle: An idea\n---\n")}
+	annotateJobFile(note)
+	if note.Type != "claude_session" || note.JobTitle != "" || len(note.JobFileIssues) != 1 {
+		t.Errorf("note read as a job: %+v", note)
+	}
+
+	bad := &models.Session{JobFilePath: write("bad.md", "---\ntitle: Broken\ntype: oneshot\nstatus: whenever\n---\n")}
+	annotateJobFile(bad)
+	if bad.JobTitle != "" || len(bad.JobFileIssues) != 2 {
+		t.Errorf("malformed job: %+v", bad)
+	}
+
+	gone := &models.Session{JobFilePath: filepath.Join(dir, "missing.md")}
+	annotateJobFile(gone)
+	if len(gone.JobFileIssues) != 0 {
+		t.Errorf("missing job file annotated: %+v", gone)
+	}
+}
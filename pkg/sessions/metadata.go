@@ -1,6 +1,11 @@
 package sessions
 
-import "time"
+import (
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/process"
+)
 
 // SessionMetadata is the data stored on disk to track a live session.
 type SessionMetadata struct {
@@ -30,4 +35,12 @@ type SessionMetadata struct {
 	// field unmarshal as empty and are therefore owned by the unscoped daemon.
 	// Used so a daemon only seeds/reaps sessions whose owning scope == its own.
 	Scope string `json:"scope,omitempty"`
+	// TmuxPane is the pane the session was last seen running in, recorded
+	// by MapTmuxPanes.
+	TmuxPane *models.TmuxPaneRef `json:"tmux_pane,omitempty"`
+	// Fingerprint is the start time and argv hash of the process PID names,
+	// recorded by Register, so liveness checks can tell the agent from a
+	// later process that was given the same PID. Legacy records without it
+	// are checked by PID alone.
+	Fingerprint *process.Fingerprint `json:"process_fingerprint,omitempty"`
 }
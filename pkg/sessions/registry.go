@@ -30,8 +30,13 @@ func NewFileSystemRegistry() (*FileSystemRegistry, error) {
 	return &FileSystemRegistry{baseDir: baseDir}, nil
 }
 
-// Register creates the tracking files for a live session.
+// Register creates the tracking files for a live session. The process
+// fingerprint of metadata.PID is recorded when metadata carries none.
 func (r *FileSystemRegistry) Register(metadata SessionMetadata) error {
+	if metadata.PID > 0 && metadata.Fingerprint.IsZero() {
+		metadata.Fingerprint, _ = process.FingerprintOf(metadata.PID)
+	}
+
 	// The directory is named after the agent's native session ID (e.g., Claude's UUID, Codex's UUID).
 	sessionDirName := metadata.ClaudeSessionID
 	if sessionDirName == "" {
@@ -81,8 +86,12 @@ func (r *FileSystemRegistry) IsAlive(sessionID string) (bool, error) {
 		return false, fmt.Errorf("failed to parse PID: %w", err)
 	}
 
-	// Check if the process is running
-	return process.IsProcessAlive(pid), nil
+	// Check that the process is running and is still the session's agent.
+	var metadata SessionMetadata
+	if data, err := os.ReadFile(filepath.Join(sessionDir, "metadata.json")); err == nil {
+		_ = json.Unmarshal(data, &metadata)
+	}
+	return process.IsSameProcess(pid, metadata.Fingerprint), nil
 }
 
 // UpdateStatus updates the status field in the session's metadata.json file.
@@ -3,6 +3,8 @@ package sessions
 import (
 	"os"
 	"testing"
+
+	"github.com/grovetools/core/pkg/process"
 )
 
 func TestFileSystemRegistryIsAlive(t *testing.T) {
@@ -44,6 +46,36 @@ func TestFileSystemRegistryIsAlive(t *testing.T) {
 		}
 	})
 
+	t.Run("recycled pid", func(t *testing.T) {
+		meta := SessionMetadata{
+			SessionID: "recycled-session",
+			PID:       os.Getpid(),
+			// Recorded from an earlier process that was given this PID.
+			Fingerprint: &process.Fingerprint{StartTime: "1", ArgvHash: "stale"},
+		}
+		if err := registry.Register(meta); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+
+		alive, err := registry.IsAlive("recycled-session")
+		if err != nil {
+			t.Fatalf("IsAlive returned error: %v", err)
+		}
+		if alive {
+			t.Error("IsAlive = true for a PID whose fingerprint changed, want false")
+		}
+	})
+
+	t.Run("fingerprint recorded", func(t *testing.T) {
+		found, err := registry.Find("live-session")
+		if err != nil {
+			t.Fatalf("Find failed: %v", err)
+		}
+		if found.Fingerprint.IsZero() {
+			t.Error("Register did not record the process fingerprint")
+		}
+	})
+
 	t.Run("missing session", func(t *testing.T) {
 		alive, err := registry.IsAlive("no-such-session")
 		if err != nil {
@@ -0,0 +1,43 @@
+package sessions
+
+import (
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/tmuxinfo"
+)
+
+// MapTmuxPanes correlates live sessions with the tmux panes their processes
+// run in, setting Session.TmuxPane and persisting changes to the registry
+// so the mapping survives daemon restarts. Sessions no longer found in a
+// pane have a stale mapping cleared. It returns the sessions whose mapping
+// changed.
+func (r *FileSystemRegistry) MapTmuxPanes(snap *tmuxinfo.Snapshot, sessions []*models.Session) []*models.Session {
+	var changed []*models.Session
+	for _, s := range sessions {
+		if s == nil || s.PID <= 0 {
+			continue
+		}
+		var ref *models.TmuxPaneRef
+		if pane, ok := snap.PaneForPID(s.PID); ok {
+			ref = &pane.TmuxPaneRef
+		}
+		if samePane(s.TmuxPane, ref) {
+			continue
+		}
+		s.TmuxPane = ref
+
+		dir := s.ClaudeSessionID
+		if dir == "" {
+			dir = s.ID
+		}
+		_ = r.UpdateFields(dir, func(m *SessionMetadata) { m.TmuxPane = ref })
+		changed = append(changed, s)
+	}
+	return changed
+}
+
+func samePane(a, b *models.TmuxPaneRef) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
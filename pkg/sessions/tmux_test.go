@@ -0,0 +1,49 @@
+package sessions
+
+import (
+	"testing"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/tmuxinfo"
+)
+
+func TestMapTmuxPanes(t *testing.T) {
+	registry := &FileSystemRegistry{baseDir: t.TempDir()}
+	if err := registry.Register(SessionMetadata{SessionID: "s1", PID: 210}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	panes := tmuxinfo.ParseListPanes("work\t1\tagents\t0\t%7\t200\n")
+	tree := tmuxinfo.ProcessTree{200: 1, 210: 200}
+	snap := tmuxinfo.NewSnapshot(panes, tree)
+	session := &models.Session{ID: "s1", PID: 210}
+
+	if changed := registry.MapTmuxPanes(snap, []*models.Session{session}); len(changed) != 1 {
+		t.Fatalf("first pass changed %d sessions, want 1", len(changed))
+	}
+	if session.TmuxPane == nil || session.TmuxPane.PaneID != "%7" {
+		t.Fatalf("session pane = %+v, want %%7", session.TmuxPane)
+	}
+	meta, err := registry.Find("s1")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if meta.TmuxPane == nil || meta.TmuxPane.Target() != "work:1.0" {
+		t.Errorf("registry pane = %+v, want work:1.0", meta.TmuxPane)
+	}
+
+	// An unchanged mapping is not rewritten.
+	if changed := registry.MapTmuxPanes(snap, []*models.Session{session}); len(changed) != 0 {
+		t.Errorf("second pass changed %d sessions, want 0", len(changed))
+	}
+
+	// Once the pane is gone the stale mapping is cleared.
+	empty := tmuxinfo.NewSnapshot(nil, tree)
+	registry.MapTmuxPanes(empty, []*models.Session{session})
+	if session.TmuxPane != nil {
+		t.Errorf("session pane = %+v after pane closed, want nil", session.TmuxPane)
+	}
+	if meta, _ := registry.Find("s1"); meta.TmuxPane != nil {
+		t.Errorf("registry still records pane %+v", meta.TmuxPane)
+	}
+}
@@ -0,0 +1,350 @@
+package sessions
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// observe widens the activity window of u to include t.
+func observe(u *models.SessionUsage, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	if u.FirstActivity.IsZero() || t.Before(u.FirstActivity) {
+		u.FirstActivity = t
+	}
+	if t.After(u.LastActivity) {
+		u.LastActivity = t
+	}
+	u.Duration = u.LastActivity.Sub(u.FirstActivity)
+}
+
+// claudeTranscriptLine is the subset of a Claude Code transcript JSONL line
+// needed for usage accounting.
+type claudeTranscriptLine struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   struct {
+		ID    string `json:"id"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ReadClaudeUsage accumulates token usage from a Claude Code transcript
+// (JSONL). Claude writes one line per content block of a streamed message,
+// each repeating the message's usage, so lines are de-duplicated by message
+// ID. Cost is left at zero: CollectUsage takes it from the session's
+// LiveCostUSD, priced by the daemon session collector.
+func ReadClaudeUsage(transcriptPath string) (models.SessionUsage, error) {
+	var usage models.SessionUsage
+
+	f, err := os.Open(transcriptPath)
+	if err != nil {
+		return usage, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer f.Close()
+
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line claudeTranscriptLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		observe(&usage, line.Timestamp)
+
+		u := line.Message.Usage
+		if line.Type != "assistant" || u == nil {
+			continue
+		}
+		if line.Message.ID != "" {
+			if seen[line.Message.ID] {
+				continue
+			}
+			seen[line.Message.ID] = true
+		}
+
+		usage.Messages++
+		usage.InputTokens += u.InputTokens
+		usage.OutputTokens += u.OutputTokens
+		usage.CacheReadTokens += u.CacheReadInputTokens
+		usage.CacheWriteTokens += u.CacheCreationInputTokens
+	}
+	if err := scanner.Err(); err != nil {
+		return usage, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	return usage, nil
+}
+
+// openCodeMessage is the subset of an OpenCode message stats file needed
+// for usage accounting.
+type openCodeMessage struct {
+	Role   string  `json:"role"`
+	Cost   float64 `json:"cost"`
+	Tokens struct {
+		Input     int64 `json:"input"`
+		Output    int64 `json:"output"`
+		Reasoning int64 `json:"reasoning"`
+		Cache     struct {
+			Read  int64 `json:"read"`
+			Write int64 `json:"write"`
+		} `json:"cache"`
+	} `json:"tokens"`
+	Time struct {
+		Created   int64 `json:"created"`
+		Completed int64 `json:"completed"`
+	} `json:"time"`
+}
+
+// ReadOpenCodeUsage accumulates usage from an OpenCode session's message
+// directory (storage/message/<session-id>/*.json). OpenCode records cost per
+// message. Reasoning tokens count as output.
+func ReadOpenCodeUsage(messageDir string) (models.SessionUsage, error) {
+	var usage models.SessionUsage
+
+	files, err := filepath.Glob(filepath.Join(messageDir, "*.json"))
+	if err != nil {
+		return usage, err
+	}
+	if len(files) == 0 {
+		return usage, fmt.Errorf("no OpenCode messages in %s", messageDir)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var msg openCodeMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.Time.Created > 0 {
+			observe(&usage, time.UnixMilli(msg.Time.Created))
+		}
+		if msg.Time.Completed > 0 {
+			observe(&usage, time.UnixMilli(msg.Time.Completed))
+		}
+		if msg.Role != "assistant" {
+			continue
+		}
+		usage.Messages++
+		usage.InputTokens += msg.Tokens.Input
+		usage.OutputTokens += msg.Tokens.Output + msg.Tokens.Reasoning
+		usage.CacheReadTokens += msg.Tokens.Cache.Read
+		usage.CacheWriteTokens += msg.Tokens.Cache.Write
+		usage.CostUSD += msg.Cost
+	}
+	return usage, nil
+}
+
+// openCodeMessageDir returns the OpenCode message directory for a session,
+// honoring XDG_DATA_HOME.
+func openCodeMessageDir(sessionID string) string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "opencode", "storage", "message", sessionID)
+}
+
+// claudeTranscripts returns the transcript files for a Claude session: the
+// reported TranscriptPath when set, otherwise every
+// ~/.claude/projects/*/<id>.jsonl (transcripts fragment across project
+// slugs when the cwd changes mid-session, see ResolveClaudeSessionDirs).
+func claudeTranscripts(s *models.Session) []string {
+	if s.TranscriptPath != "" {
+		return []string{s.TranscriptPath}
+	}
+	id := s.ClaudeSessionID
+	if id == "" {
+		id = s.ID
+	}
+	if id == "" {
+		return nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, ".claude", "projects", "*", id+".jsonl"))
+	sort.Strings(matches)
+	return matches
+}
+
+// UsageCollector aggregates session usage for the session collector, which
+// attaches it to the sessions it reports (see DiscoverOptions.Usage), so
+// clients read Session.Usage instead of parsing usage files themselves. It
+// remembers what each usage file held, keyed by its size and modification
+// time, so a collector that runs every tick only re-reads files that
+// changed. It is safe for concurrent use.
+type UsageCollector struct {
+	mu    sync.Mutex
+	files map[string]cachedUsage
+}
+
+// cachedUsage is the usage read from one file or directory, valid while
+// its stamp is unchanged.
+type cachedUsage struct {
+	stamp usageStamp
+	usage models.SessionUsage
+}
+
+// usageStamp identifies a version of a usage file, or of a directory by its
+// file count, total size and latest modification.
+type usageStamp struct {
+	files   int
+	size    int64
+	modTime time.Time
+}
+
+// NewUsageCollector returns a UsageCollector with an empty cache.
+func NewUsageCollector() *UsageCollector {
+	return &UsageCollector{files: make(map[string]cachedUsage)}
+}
+
+// read returns the usage of path, from the cache when its stamp is
+// unchanged.
+func (c *UsageCollector) read(path string, stamp usageStamp, read func(string) (models.SessionUsage, error)) (models.SessionUsage, error) {
+	c.mu.Lock()
+	cached, ok := c.files[path]
+	c.mu.Unlock()
+	if ok && cached.stamp == stamp {
+		return cached.usage, nil
+	}
+	u, err := read(path)
+	if err != nil {
+		return u, err
+	}
+	c.mu.Lock()
+	if c.files == nil {
+		c.files = make(map[string]cachedUsage)
+	}
+	c.files[path] = cachedUsage{stamp: stamp, usage: u}
+	c.mu.Unlock()
+	return u, nil
+}
+
+// fileStamp returns the stamp of a single file.
+func fileStamp(path string) (usageStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return usageStamp{}, err
+	}
+	return usageStamp{files: 1, size: info.Size(), modTime: info.ModTime()}, nil
+}
+
+// dirStamp returns the stamp of an OpenCode message directory, whose files
+// are rewritten in place as messages complete.
+func dirStamp(dir string) (usageStamp, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return usageStamp{}, err
+	}
+	var stamp usageStamp
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stamp.files++
+		stamp.size += info.Size()
+		if info.ModTime().After(stamp.modTime) {
+			stamp.modTime = info.ModTime()
+		}
+	}
+	return stamp, nil
+}
+
+// Collect returns the accumulated usage of a session from the provider's
+// usage files. Claude sessions take their cost from LiveCostUSD, the
+// daemon session collector's price of the transcript; OpenCode records
+// cost per message. Sessions without readable usage data fall back to the
+// session's own start/activity window for Duration.
+func (c *UsageCollector) Collect(s *models.Session) (models.SessionUsage, error) {
+	var usage models.SessionUsage
+	var found bool
+
+	switch strings.ToLower(s.Provider) {
+	case "opencode":
+		id := s.ClaudeSessionID
+		if id == "" {
+			id = s.ID
+		}
+		dir := openCodeMessageDir(id)
+		if stamp, err := dirStamp(dir); err == nil {
+			if u, err := c.read(dir, stamp, ReadOpenCodeUsage); err == nil {
+				usage, found = u, true
+			}
+		}
+	default:
+		for _, path := range claudeTranscripts(s) {
+			stamp, err := fileStamp(path)
+			if err != nil {
+				continue
+			}
+			u, err := c.read(path, stamp, ReadClaudeUsage)
+			if err != nil {
+				continue
+			}
+			usage.Add(u)
+			found = true
+		}
+		if found {
+			// Add sums per-file durations; recompute the span across files.
+			usage.Duration = usage.LastActivity.Sub(usage.FirstActivity)
+		}
+		usage.CostUSD = s.LiveCostUSD
+	}
+
+	if !found {
+		usage.Duration = s.Duration()
+		if usage.Duration == 0 && s.EndedAt == nil && !s.StartedAt.IsZero() {
+			usage.Duration = time.Since(s.StartedAt)
+		}
+		return usage, fmt.Errorf("no usage data found for session %s", s.ID)
+	}
+	return usage, nil
+}
+
+// Attach populates Usage on each session, ignoring sessions whose usage
+// files cannot be found (their Usage still carries the session duration).
+func (c *UsageCollector) Attach(sessions []*models.Session) {
+	for _, s := range sessions {
+		u, _ := c.Collect(s)
+		s.Usage = &u
+	}
+}
+
+// AggregateByRepo sums session usage per repository. Sessions without
+// Usage are skipped; sessions without a repo are grouped under "".
+func AggregateByRepo(sessions []*models.Session) map[string]models.SessionUsage {
+	totals := make(map[string]models.SessionUsage)
+	for _, s := range sessions {
+		if s.Usage == nil {
+			continue
+		}
+		t := totals[s.Repo]
+		t.Add(*s.Usage)
+		totals[s.Repo] = t
+	}
+	return totals
+}
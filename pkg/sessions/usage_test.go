@@ -0,0 +1,122 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+func TestReadClaudeUsageDedupesMessages(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.jsonl")
+	lines := `{"type":"user","timestamp":"2026-01-01T10:00:00Z","message":{}}
+{"type":"assistant","timestamp":"2026-01-01T10:00:05Z","message":{"id":"m1","model":"claude-sonnet-4","usage":{"input_tokens":1000000,"output_tokens":0}}}
+{"type":"assistant","timestamp":"2026-01-01T10:00:06Z","message":{"id":"m1","model":"claude-sonnet-4","usage":{"input_tokens":1000000,"output_tokens":0}}}
+{"type":"assistant","timestamp":"2026-01-01T10:30:00Z","costUSD":0.5,"message":{"id":"m2","model":"claude-opus-4","usage":{"input_tokens":10,"output_tokens":20,"cache_read_input_tokens":30,"cache_creation_input_tokens":40}}}
+not json
+`
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := ReadClaudeUsage(path)
+	if err != nil {
+		t.Fatalf("ReadClaudeUsage: %v", err)
+	}
+	if u.Messages != 2 {
+		t.Errorf("expected 2 deduplicated messages, got %d", u.Messages)
+	}
+	if u.InputTokens != 1000010 || u.OutputTokens != 20 || u.CacheReadTokens != 30 || u.CacheWriteTokens != 40 {
+		t.Errorf("unexpected token totals: %+v", u)
+	}
+	if u.CostUSD != 0 {
+		t.Errorf("transcript costs should not be read, got %f", u.CostUSD)
+	}
+	if u.Duration != 30*time.Minute {
+		t.Errorf("expected 30m duration, got %s", u.Duration)
+	}
+}
+
+func TestReadOpenCodeUsage(t *testing.T) {
+	dir := t.TempDir()
+	msgs := map[string]string{
+		"msg_1.json": `{"role":"user","time":{"created":1767261600000}}`,
+		"msg_2.json": `{"role":"assistant","cost":0.25,"tokens":{"input":100,"output":50,"reasoning":5,"cache":{"read":10,"write":2}},"time":{"created":1767261601000,"completed":1767261660000}}`,
+	}
+	for name, body := range msgs {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	u, err := ReadOpenCodeUsage(dir)
+	if err != nil {
+		t.Fatalf("ReadOpenCodeUsage: %v", err)
+	}
+	if u.Messages != 1 || u.InputTokens != 100 || u.OutputTokens != 55 || u.CacheReadTokens != 10 || u.CacheWriteTokens != 2 {
+		t.Errorf("unexpected usage: %+v", u)
+	}
+	if u.CostUSD != 0.25 {
+		t.Errorf("unexpected cost: %+v", u)
+	}
+	if u.Duration != time.Minute {
+		t.Errorf("expected 1m duration, got %s", u.Duration)
+	}
+}
+
+func TestUsageCollector(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	write := func(lines string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"type":"assistant","timestamp":"2026-01-01T10:00:00Z","message":{"id":"m1","usage":{"input_tokens":10,"output_tokens":5}}}
+`)
+	s := &models.Session{ID: "s1", TranscriptPath: path, LiveCostUSD: 1.25}
+	c := NewUsageCollector()
+
+	u, err := c.Collect(s)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if u.TotalTokens() != 15 || u.CostUSD != 1.25 {
+		t.Errorf("expected 15 tokens at the live cost, got %+v", u)
+	}
+
+	// A changed transcript is read again; an unchanged one comes from the
+	// cache.
+	write(`{"type":"assistant","timestamp":"2026-01-01T10:00:00Z","message":{"id":"m1","usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"assistant","timestamp":"2026-01-01T10:05:00Z","message":{"id":"m2","usage":{"input_tokens":100,"output_tokens":50}}}
+`)
+	if u, _ = c.Collect(s); u.TotalTokens() != 165 {
+		t.Errorf("expected the rewritten transcript to be re-read, got %+v", u)
+	}
+	c.mu.Lock()
+	stamp := c.files[path].stamp
+	c.files[path] = cachedUsage{stamp: stamp, usage: models.SessionUsage{InputTokens: 7}}
+	c.mu.Unlock()
+	if u, _ = c.Collect(s); u.InputTokens != 7 {
+		t.Errorf("expected the unchanged transcript to come from the cache, got %+v", u)
+	}
+}
+
+func TestAggregateByRepo(t *testing.T) {
+	list := []*models.Session{
+		{Repo: "core", Usage: &models.SessionUsage{CostUSD: 1, InputTokens: 10, Duration: time.Minute}},
+		{Repo: "core", Usage: &models.SessionUsage{CostUSD: 2, InputTokens: 5, Duration: time.Minute}},
+		{Repo: "flow", Usage: &models.SessionUsage{CostUSD: 4}},
+		{Repo: "flow"},
+	}
+	totals := AggregateByRepo(list)
+	if got := totals["core"]; got.CostUSD != 3 || got.InputTokens != 15 || got.Duration != 2*time.Minute {
+		t.Errorf("unexpected core totals: %+v", got)
+	}
+	if got := totals["flow"]; got.CostUSD != 4 {
+		t.Errorf("unexpected flow totals: %+v", got)
+	}
+}
@@ -0,0 +1,175 @@
+// Package telemetry records an opt-in, strictly local log of grove CLI
+// usage: which command ran, which flags were set, how long it took and how
+// it exited. It exists so maintainers can learn which features are used
+// before deprecating anything. Flag values and arguments are never
+// recorded, and nothing is sent over the network; the log is a JSON-lines
+// file in the state directory that `core stats usage` summarizes.
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// maxLogSize is the size at which the log is rotated to telemetry.jsonl.1,
+// keeping at most twice this on disk.
+const maxLogSize = 5 << 20
+
+// Event is one recorded command run.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Tool       string    `json:"tool"`
+	Command    string    `json:"command"`
+	Flags      []string  `json:"flags,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	ExitStatus int       `json:"exit_status"`
+}
+
+// Path returns the telemetry log path, <state dir>/telemetry.jsonl.
+func Path() string {
+	return filepath.Join(paths.StateDir(), "telemetry.jsonl")
+}
+
+// Enabled reports whether usage is recorded. GROVE_TELEMETRY (1/true/on or
+// 0/false/off) wins over telemetry.enabled in the merged config; both
+// default to off.
+func Enabled() bool {
+	if v := strings.TrimSpace(os.Getenv("GROVE_TELEMETRY")); v != "" {
+		switch strings.ToLower(v) {
+		case "on", "yes":
+			return true
+		case "off", "no":
+			return false
+		}
+		enabled, _ := strconv.ParseBool(v)
+		return enabled
+	}
+	cfg, err := config.LoadDefault()
+	if err != nil || cfg == nil || cfg.Telemetry == nil || cfg.Telemetry.Enabled == nil {
+		return false
+	}
+	return *cfg.Telemetry.Enabled
+}
+
+// Record appends e to the log at path, rotating it once it passes
+// maxLogSize. Each event is a single write of one line, so concurrent
+// processes do not interleave.
+func Record(path string, e Event) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxLogSize {
+		_ = os.Rename(path, path+".1")
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the events in the log at path and its rotated predecessor,
+// oldest first. Malformed lines are skipped; a missing log reads as empty.
+func Read(path string) ([]Event, error) {
+	var events []Event
+	for _, p := range []string{path + ".1", path} {
+		f, err := os.Open(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to open telemetry log: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLogSize)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			var e Event
+			if json.Unmarshal(line, &e) == nil && e.Command != "" {
+				events = append(events, e)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read telemetry log: %w", err)
+		}
+	}
+	return events, nil
+}
+
+// CommandUsage aggregates the events for one command.
+type CommandUsage struct {
+	Command    string         `json:"command"`
+	Runs       int            `json:"runs"`
+	Failures   int            `json:"failures"`
+	AvgMs      int64          `json:"avg_ms"`
+	MaxMs      int64          `json:"max_ms"`
+	LastUsed   time.Time      `json:"last_used"`
+	FlagCounts map[string]int `json:"flags,omitempty"`
+}
+
+// Summarize aggregates events per command, most used first.
+func Summarize(events []Event) []CommandUsage {
+	byCommand := make(map[string]*CommandUsage)
+	totalMs := make(map[string]int64)
+	for _, e := range events {
+		u, ok := byCommand[e.Command]
+		if !ok {
+			u = &CommandUsage{Command: e.Command}
+			byCommand[e.Command] = u
+		}
+		u.Runs++
+		if e.ExitStatus != 0 {
+			u.Failures++
+		}
+		totalMs[e.Command] += e.DurationMs
+		if e.DurationMs > u.MaxMs {
+			u.MaxMs = e.DurationMs
+		}
+		if e.Time.After(u.LastUsed) {
+			u.LastUsed = e.Time
+		}
+		for _, flag := range e.Flags {
+			if u.FlagCounts == nil {
+				u.FlagCounts = make(map[string]int)
+			}
+			u.FlagCounts[flag]++
+		}
+	}
+
+	usage := make([]CommandUsage, 0, len(byCommand))
+	for cmd, u := range byCommand {
+		u.AvgMs = totalMs[cmd] / int64(u.Runs)
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Runs != usage[j].Runs {
+			return usage[i].Runs > usage[j].Runs
+		}
+		return usage[i].Command < usage[j].Command
+	})
+	return usage
+}
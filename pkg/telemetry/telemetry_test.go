@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordReadSummarize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "telemetry.jsonl")
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Time: base, Tool: "core", Command: "core ws list", DurationMs: 100},
+		{Time: base.Add(time.Hour), Tool: "core", Command: "core ws list", Flags: []string{"json"}, DurationMs: 300, ExitStatus: 1},
+		{Time: base.Add(2 * time.Hour), Tool: "core", Command: "core logs", Flags: []string{"follow", "json"}, DurationMs: 50},
+	}
+	for _, e := range events {
+		if err := Record(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A torn or foreign line does not hide the rest.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{not json\n")
+	f.Close()
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Read returned %d events, want 3", len(got))
+	}
+
+	usage := Summarize(got)
+	if len(usage) != 2 || usage[0].Command != "core ws list" {
+		t.Fatalf("usage = %+v", usage)
+	}
+	ws := usage[0]
+	if ws.Runs != 2 || ws.Failures != 1 || ws.AvgMs != 200 || ws.MaxMs != 300 || !ws.LastUsed.Equal(base.Add(time.Hour)) {
+		t.Errorf("ws list = %+v", ws)
+	}
+	if ws.FlagCounts["json"] != 1 {
+		t.Errorf("flag counts = %v", ws.FlagCounts)
+	}
+}
+
+func TestRecordRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("\n"), maxLogSize), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(path, Event{Command: "core ps"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path + ".1"); err != nil || info.Size() != maxLogSize {
+		t.Fatalf("rotated log = %v, %v", info, err)
+	}
+	got, err := Read(path)
+	if err != nil || len(got) != 1 || got[0].Command != "core ps" {
+		t.Errorf("Read after rotation = %v, %v", got, err)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	for value, want := range map[string]bool{"1": true, "on": true, "true": true, "0": false, "off": false, "bogus": false, "": false} {
+		t.Setenv("GROVE_TELEMETRY", value)
+		if got := Enabled(); got != want {
+			t.Errorf("GROVE_TELEMETRY=%q: Enabled() = %v, want %v", value, got, want)
+		}
+	}
+}
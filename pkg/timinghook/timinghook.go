@@ -0,0 +1,39 @@
+// Package timinghook lets packages that core/logging imports, such as
+// config and workspace, time their operations for self-profiling. They
+// cannot call logging.Timer without an import cycle, so each holds a Hook
+// that logging installs with its first logger (see logging.Timer); until
+// then operations are not timed.
+package timinghook
+
+import "sync"
+
+// StartFunc is called when an operation starts and returns the function
+// that stops it, as logging.Timer does. It runs inside the hooked package,
+// so it must not call back into that package or logging.NewLogger.
+type StartFunc func(op string) func(err error)
+
+// Hook is a process-wide, swappable StartFunc. The zero value times
+// nothing.
+type Hook struct {
+	mu sync.RWMutex
+	fn StartFunc
+}
+
+// Set installs fn. A nil fn disables timing.
+func (h *Hook) Set(fn StartFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fn = fn
+}
+
+// Start starts timing op, returning a no-op stop function when nothing is
+// installed.
+func (h *Hook) Start(op string) func(err error) {
+	h.mu.RLock()
+	fn := h.fn
+	h.mu.RUnlock()
+	if fn == nil {
+		return func(error) {}
+	}
+	return fn(op)
+}
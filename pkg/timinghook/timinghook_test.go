@@ -0,0 +1,30 @@
+package timinghook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHook(t *testing.T) {
+	var h Hook
+	h.Start("noop")(nil) // the zero value times nothing
+
+	var started string
+	var stopped error
+	h.Set(func(op string) func(error) {
+		started = op
+		return func(err error) { stopped = err }
+	})
+	failed := errors.New("failed")
+	h.Start("load")(failed)
+	if started != "load" || stopped != failed {
+		t.Fatalf("started %q, stopped %v", started, stopped)
+	}
+
+	h.Set(nil)
+	started = ""
+	h.Start("load")(nil)
+	if started != "" {
+		t.Fatal("a nil StartFunc should disable timing")
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
-	"syscall"
 
 	"github.com/grovetools/core/command"
 )
@@ -70,7 +69,7 @@ func (c *Client) KillServer(ctx context.Context) error {
 				continue
 			}
 			if pid, err := strconv.Atoi(pidStr); err == nil && pid > 1 {
-				_ = syscall.Kill(-pid, syscall.SIGTERM)
+				terminateProcessGroup(pid)
 			}
 		}
 	}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package tmux
+
+import "syscall"
+
+// terminateProcessGroup sends SIGTERM to the process group led by pid.
+func terminateProcessGroup(pid int) {
+	_ = syscall.Kill(-pid, syscall.SIGTERM)
+}
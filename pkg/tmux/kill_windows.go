@@ -0,0 +1,12 @@
+//go:build windows
+
+package tmux
+
+import "os"
+
+// terminateProcessGroup kills pid; Windows has no process-group signals.
+func terminateProcessGroup(pid int) {
+	if p, err := os.FindProcess(pid); err == nil {
+		_ = p.Kill()
+	}
+}
@@ -0,0 +1,145 @@
+// Package tmuxinfo reads tmux's pane layout and correlates processes with
+// the panes they run in. The session collector uses it to record which
+// pane hosts each live agent session.
+package tmuxinfo
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/grovetools/core/pkg/models"
+	"github.com/grovetools/core/pkg/tmux"
+)
+
+// listPanesFormat is the -F format for `tmux list-panes -a`. Fields are
+// tab-separated because session and window names may contain colons.
+const listPanesFormat = "#{session_name}\t#{window_index}\t#{window_name}\t#{pane_index}\t#{pane_id}\t#{pane_pid}"
+
+// Pane is one tmux pane and the PID of the process tmux started in it
+// (usually a shell).
+type Pane struct {
+	models.TmuxPaneRef
+	PID int
+}
+
+// ParseListPanes parses `tmux list-panes -a -F` output in listPanesFormat.
+// Malformed lines are skipped.
+func ParseListPanes(output string) []Pane {
+	var panes []Pane
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(fields) != 6 {
+			continue
+		}
+		window, err1 := strconv.Atoi(fields[1])
+		pane, err2 := strconv.Atoi(fields[3])
+		pid, err3 := strconv.Atoi(fields[5])
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		panes = append(panes, Pane{
+			TmuxPaneRef: models.TmuxPaneRef{
+				Session:     fields[0],
+				WindowIndex: window,
+				WindowName:  fields[2],
+				PaneIndex:   pane,
+				PaneID:      fields[4],
+			},
+			PID: pid,
+		})
+	}
+	return panes
+}
+
+// ListPanes returns every pane on client's tmux server, stamped with its
+// socket name.
+func ListPanes(ctx context.Context, client *tmux.Client) ([]Pane, error) {
+	output, err := client.Run(ctx, "list-panes", "-a", "-F", listPanesFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tmux panes: %w", err)
+	}
+	panes := ParseListPanes(output)
+	for i := range panes {
+		panes[i].Socket = client.Socket()
+	}
+	return panes, nil
+}
+
+// ProcessTree maps each PID to its parent PID.
+type ProcessTree map[int]int
+
+// ParseProcessTree parses `ps -A -o pid=,ppid=` output.
+func ParseProcessTree(output string) ProcessTree {
+	tree := make(ProcessTree)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		tree[pid] = ppid
+	}
+	return tree
+}
+
+// ReadProcessTree snapshots the system process table.
+func ReadProcessTree(ctx context.Context) (ProcessTree, error) {
+	output, err := exec.CommandContext(ctx, "ps", "-A", "-o", "pid=,ppid=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read process table: %w", err)
+	}
+	return ParseProcessTree(string(output)), nil
+}
+
+// Snapshot is a point-in-time view of the tmux panes and process tree,
+// taken once per collection pass and queried for each session.
+type Snapshot struct {
+	byPID map[int]Pane
+	tree  ProcessTree
+}
+
+// NewSnapshot indexes panes by their root PID.
+func NewSnapshot(panes []Pane, tree ProcessTree) *Snapshot {
+	byPID := make(map[int]Pane, len(panes))
+	for _, p := range panes {
+		byPID[p.PID] = p
+	}
+	return &Snapshot{byPID: byPID, tree: tree}
+}
+
+// Take lists client's panes and the process tree. It fails when tmux is not
+// running, which callers treat as "no panes".
+func Take(ctx context.Context, client *tmux.Client) (*Snapshot, error) {
+	panes, err := ListPanes(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := ReadProcessTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(panes, tree), nil
+}
+
+// PaneForPID returns the pane whose process is pid or one of its ancestors.
+func (s *Snapshot) PaneForPID(pid int) (Pane, bool) {
+	if s == nil {
+		return Pane{}, false
+	}
+	seen := make(map[int]bool)
+	for pid > 1 && !seen[pid] {
+		if p, ok := s.byPID[pid]; ok {
+			return p, true
+		}
+		seen[pid] = true
+		pid = s.tree[pid]
+	}
+	return Pane{}, false
+}
@@ -0,0 +1,41 @@
+package tmuxinfo
+
+import "testing"
+
+const samplePanes = "work:dev\t0\teditor\t0\t%1\t100\n" +
+	"work:dev\t1\tagents\t2\t%7\t200\n" +
+	"garbage line\n"
+
+func TestParseListPanes(t *testing.T) {
+	panes := ParseListPanes(samplePanes)
+	if len(panes) != 2 {
+		t.Fatalf("got %d panes, want 2", len(panes))
+	}
+	p := panes[1]
+	if p.Session != "work:dev" || p.WindowIndex != 1 || p.WindowName != "agents" || p.PaneIndex != 2 || p.PaneID != "%7" || p.PID != 200 {
+		t.Errorf("unexpected pane: %+v", p)
+	}
+	if got := p.Target(); got != "work:dev:1.2" {
+		t.Errorf("Target() = %q", got)
+	}
+}
+
+func TestSnapshotPaneForPID(t *testing.T) {
+	// 200 (pane shell) -> 210 (agent) -> 211 (tool subprocess)
+	tree := ParseProcessTree("  1     0\n200     1\n210   200\n211   210\n300     1\n")
+	snap := NewSnapshot(ParseListPanes(samplePanes), tree)
+
+	if p, ok := snap.PaneForPID(211); !ok || p.PaneID != "%7" {
+		t.Errorf("PaneForPID(211) = %+v, %v; want pane %%7", p, ok)
+	}
+	if p, ok := snap.PaneForPID(100); !ok || p.PaneID != "%1" {
+		t.Errorf("PaneForPID(100) = %+v, %v; want pane %%1", p, ok)
+	}
+	if _, ok := snap.PaneForPID(300); ok {
+		t.Error("a process outside tmux should not map to a pane")
+	}
+	var nilSnap *Snapshot
+	if _, ok := nilSnap.PaneForPID(211); ok {
+		t.Error("nil snapshot should map nothing")
+	}
+}
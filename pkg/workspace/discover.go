@@ -1,11 +1,13 @@
 package workspace
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -13,6 +15,7 @@ import (
 
 	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/progressx"
 	"github.com/grovetools/core/pkg/repo"
 	"github.com/grovetools/core/util/pathutil"
 )
@@ -25,9 +28,11 @@ func findGroveConfig(dir string) (string, *config.Config, error) {
 		"grove.yml",
 		"grove.yaml",
 		"grove.toml",
+		"grove.json5",
 		".grove.yml",
 		".grove.yaml",
 		".grove.toml",
+		".grove.json5",
 	}
 
 	for _, name := range configNames {
@@ -43,13 +48,7 @@ func findGroveConfig(dir string) (string, *config.Config, error) {
 
 // expandPath expands ~ to home directory and environment variables
 func expandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
-		if err == nil {
-			path = filepath.Join(home, path[2:])
-		}
-	}
-	return os.ExpandEnv(path)
+	return os.ExpandEnv(paths.ExpandHome(path))
 }
 
 // directoryType represents the classification of a directory during discovery
@@ -61,6 +60,7 @@ const (
 	typeProject
 	typeEcosystemWorktreeDir // The .grove-worktrees directory itself
 	typeNonGroveRepo
+	typeBareRepo
 	typeSkip // Already processed or should be skipped
 )
 
@@ -75,8 +75,8 @@ func classifyWorkspaceRoot(path string) (directoryType, *config.Config, error) {
 	cfgPath, cfg, err := findGroveConfig(path)
 	if err == nil {
 		// A config file was found and loaded successfully.
-		// Check if it's an ecosystem (has workspaces key)
-		if len(cfg.Workspaces) > 0 {
+		// Check if it's an ecosystem (workspaces or workspaces_from)
+		if cfg.IsEcosystem() {
 			return typeEcosystem, cfg, nil
 		}
 		// It's a project
@@ -96,6 +96,10 @@ func classifyWorkspaceRoot(path string) (directoryType, *config.Config, error) {
 		return typeNonGroveRepo, nil, nil
 	}
 
+	if isBareRepo(path) {
+		return typeBareRepo, nil, nil
+	}
+
 	return typeUnknown, nil, nil
 }
 
@@ -111,7 +115,7 @@ func classifyDirectory(path string, d os.DirEntry) (directoryType, *config.Confi
 		// Check if parent directory is an ecosystem
 		parentPath := filepath.Dir(path)
 		_, parentCfg, err := findGroveConfig(parentPath)
-		if err == nil && len(parentCfg.Workspaces) > 0 {
+		if err == nil && parentCfg.IsEcosystem() {
 			// Parent is an ecosystem - this is an ecosystem worktree directory
 			return typeEcosystemWorktreeDir, parentCfg, nil
 		}
@@ -136,9 +140,10 @@ func processEcosystem(path string, cfg *config.Config) Ecosystem {
 	}
 
 	eco := Ecosystem{
-		Name: ecosystemName,
-		Path: path,
-		Type: "User",
+		Name:     ecosystemName,
+		Path:     path,
+		Type:     "User",
+		Metadata: metadataFromConfig(cfg),
 	}
 
 	if eco.Name == "grove-ecosystem" {
@@ -148,6 +153,34 @@ func processEcosystem(path string, cfg *config.Config) Ecosystem {
 	return eco
 }
 
+// refreshManifest fetches the workspaces_from manifest of the ecosystem at
+// path when its cached copy has expired. Config loads only read the cache,
+// so discovery is where a published manifest is picked up. It is only
+// called for grove roots listed in the global config: an ecosystem found
+// further down may be a cloned third-party repository, whose manifest
+// source is not the user's to trust.
+//
+// cfg was parsed from the cache as it stood before the fetch, so after a
+// successful refresh the config is loaded again and returned; otherwise cfg
+// is returned unchanged.
+func (s *DiscoveryService) refreshManifest(path string, cfg *config.Config) *config.Config {
+	if cfg == nil || cfg.WorkspacesFrom == nil {
+		return cfg
+	}
+	status, err := config.RefreshManifest(context.Background(), cfg.WorkspacesFrom)
+	switch {
+	case err != nil:
+		s.logger.WithError(err).WithField("ecosystem", path).Warn("Failed to fetch workspaces_from manifest")
+	case status.Stale:
+		s.logger.WithField("ecosystem", path).WithField("error", status.RefreshError).Warn("Failed to refresh workspaces_from manifest; using the cached copy")
+	default:
+		if _, fresh, loadErr := findGroveConfig(path); loadErr == nil && fresh != nil {
+			return fresh
+		}
+	}
+	return cfg
+}
+
 // processProject handles discovery of a project directory and its worktrees
 func processProject(path string, cfg *config.Config) Project {
 	var projectName string
@@ -162,6 +195,7 @@ func processProject(path string, cfg *config.Config) Project {
 		Name:       projectName,
 		Path:       path,
 		Workspaces: []DiscoveredWorkspace{},
+		Metadata:   metadataFromConfig(cfg),
 	}
 
 	// Add the Primary Workspace
@@ -185,7 +219,7 @@ func processProject(path string, cfg *config.Config) Project {
 				// pass will discover them as EcosystemWorktree projects with full
 				// provenance. Adding them here as raw WorkspaceTypeWorktree leaves
 				// would cause misclassification and duplicates.
-				if _, cfg, cfgErr := findGroveConfig(wtPath); cfgErr == nil && cfg != nil && len(cfg.Workspaces) > 0 {
+				if _, cfg, cfgErr := findGroveConfig(wtPath); cfgErr == nil && cfg != nil && cfg.IsEcosystem() {
 					continue
 				}
 				proj.Workspaces = append(proj.Workspaces, DiscoveredWorkspace{
@@ -201,6 +235,24 @@ func processProject(path string, cfg *config.Config) Project {
 	return proj
 }
 
+// metadataFromConfig returns the human context a grove config gives about
+// its project, or nil when it gives none.
+func metadataFromConfig(cfg *config.Config) *Metadata {
+	if cfg == nil {
+		return nil
+	}
+	m := &Metadata{
+		Description: cfg.Description(),
+		Tags:        cfg.Tags,
+		Owners:      cfg.Owners,
+		Links:       cfg.Links,
+	}
+	if m.Description == "" && len(m.Tags) == 0 && len(m.Owners) == 0 && len(m.Links) == 0 {
+		return nil
+	}
+	return m
+}
+
 // processEcosystemWorktreeDir handles the special case of an ecosystem's
 // LEGACY worktree base directory, treating each subdirectory as a project.
 // The XDG base is enumerated separately for every discovered ecosystem by
@@ -253,7 +305,11 @@ func processNonGroveRepo(path string) string {
 // DiscoveryService scans the filesystem to find and classify Grove entities.
 type DiscoveryService struct {
 	logger     *logrus.Logger
-	configPath string // Optional: if set, used instead of HOME for config discovery
+	configPath string          // Optional: if set, used instead of HOME for config discovery
+	submodules bool            // Optional: list each project's .gitmodules entries
+	remote     bool            // Optional: discover ssh:// groves over ssh
+	progress   *progressx.Task // Optional: counts the directories scanned
+	ctx        context.Context // Optional: stops the grove walks when done
 }
 
 // NewDiscoveryService creates a new discovery service.
@@ -271,11 +327,82 @@ func (s *DiscoveryService) WithConfigPath(configPath string) *DiscoveryService {
 	return &DiscoveryService{
 		logger:     s.logger,
 		configPath: configPath,
+		submodules: s.submodules,
+		remote:     s.remote,
+		progress:   s.progress,
+		ctx:        s.ctx,
 	}
 }
 
+// WithSubmodules returns a new DiscoveryService that also reads each
+// discovered project's .gitmodules into Project.Submodules.
+func (s *DiscoveryService) WithSubmodules() *DiscoveryService {
+	return &DiscoveryService{
+		logger:     s.logger,
+		configPath: s.configPath,
+		submodules: true,
+		remote:     s.remote,
+		progress:   s.progress,
+		ctx:        s.ctx,
+	}
+}
+
+// WithRemote returns a new DiscoveryService that also discovers groves
+// whose path is an ssh:// URL, by running their command on the host (see
+// RemoteGrove), into DiscoveryResult.Remote. Without it those groves are
+// skipped.
+func (s *DiscoveryService) WithRemote() *DiscoveryService {
+	return &DiscoveryService{
+		logger:     s.logger,
+		configPath: s.configPath,
+		submodules: s.submodules,
+		remote:     true,
+		progress:   s.progress,
+		ctx:        s.ctx,
+	}
+}
+
+// WithProgress returns a new DiscoveryService that reports each directory
+// it scans to task, described by the grove being walked. The caller ends
+// the task.
+func (s *DiscoveryService) WithProgress(task *progressx.Task) *DiscoveryService {
+	return &DiscoveryService{
+		logger:     s.logger,
+		configPath: s.configPath,
+		submodules: s.submodules,
+		remote:     s.remote,
+		progress:   task,
+		ctx:        s.ctx,
+	}
+}
+
+// WithContext returns a new DiscoveryService whose DiscoverAll stops
+// walking groves, and returns ctx's error, once ctx is done.
+func (s *DiscoveryService) WithContext(ctx context.Context) *DiscoveryService {
+	return &DiscoveryService{
+		logger:     s.logger,
+		configPath: s.configPath,
+		submodules: s.submodules,
+		remote:     s.remote,
+		progress:   s.progress,
+		ctx:        ctx,
+	}
+}
+
+// context returns the service's context, or context.Background when
+// WithContext was not used.
+func (s *DiscoveryService) context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return context.Background()
+}
+
 // DiscoverAll scans all configured 'groves' and returns a comprehensive result.
-func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
+func (s *DiscoveryService) DiscoverAll() (_ *DiscoveryResult, err error) {
+	stop := timer.Start("discover")
+	defer func() { stop(err) }()
+
 	result := &DiscoveryResult{
 		Projects:            []Project{},
 		Ecosystems:          []Ecosystem{},
@@ -287,15 +414,17 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 	seenProjects := make(map[string]bool)
 	seenEcosystems := make(map[string]bool)
 	seenNonGrove := make(map[string]bool)
+	seenRemote := make(map[string]bool)
 
 	// 1. Load the global configuration to find 'groves' search paths.
 	// We use LoadLayered to ensure we get the global config reliably.
-	// If configPath is set (for testing), use it instead of HOME.
-	configDir := os.Getenv("HOME")
+	// If configPath is set (for testing), use it instead of HOME. Its
+	// warnings (deprecated keys, say) go through the service's logger.
+	configDir, _ := os.UserHomeDir()
 	if s.configPath != "" {
 		configDir = s.configPath
 	}
-	layeredCfg, err := config.LoadLayered(configDir)
+	layeredCfg, err := config.LoadLayeredWithLogger(configDir, s.logger)
 	if err != nil {
 		s.logger.Warnf("Failed to load layered config: %v. No 'groves' to scan.", err)
 		return result, nil // Not a fatal error, just means no paths to scan.
@@ -338,6 +467,8 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 		projects   []Project
 		ecosystems []Ecosystem
 		nonGrove   []string
+		repos      []Repository
+		remote     []WorkspaceNode
 	}
 
 	var wg sync.WaitGroup
@@ -362,6 +493,30 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 			continue
 		}
 
+		if IsRemoteGrovePath(groveCfg.Path) {
+			if !s.remote {
+				continue
+			}
+			remote, err := ParseRemoteGrove(key, groveCfg)
+			if err != nil {
+				s.logger.Warnf("%v", err)
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(s.context(), remoteDiscoveryTimeout)
+				defer cancel()
+				nodes, err := DiscoverRemote(ctx, remote)
+				if err != nil {
+					s.logger.Warnf("Could not discover grove '%s' on %s: %v", remote.Name, remote.Host, err)
+					return
+				}
+				resultsChan <- groveResult{remote: nodes}
+			}()
+			continue
+		}
+
 		// Expand path, e.g., ~/Work -> /Users/user/Work
 		expandedPath := expandPath(groveCfg.Path)
 		absPath, err := filepath.Abs(expandedPath)
@@ -387,6 +542,11 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 				nonGrove:   []string{},
 			}
 
+			patterns, badPatterns := newGrovePatterns(currentGroveCfg)
+			for _, bad := range badPatterns {
+				s.logger.Warnf("Ignoring invalid include/exclude pattern %q in grove '%s'", bad, groveName)
+			}
+
 			// 3. Scan the directory using the new helper-based approach.
 			err := filepath.WalkDir(grovePath, func(path string, d os.DirEntry, err error) error {
 				if err != nil {
@@ -395,6 +555,11 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 
 				// Hardcoded skip-list for heavy/irrelevant directories
 				if d.IsDir() {
+					if ctxErr := s.context().Err(); ctxErr != nil {
+						return ctxErr
+					}
+					s.progress.Describe(groveName)
+					s.progress.Add(1)
 					name := d.Name()
 					switch name {
 					case ".git", "node_modules", "vendor", "dist", "build", ".venv", "venv", "__pycache__", ".tox":
@@ -413,6 +578,17 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 					currentDepth = len(strings.Split(relPath, string(filepath.Separator)))
 				}
 
+				// Apply the include/exclude globs
+				switch patterns.action(relPath) {
+				case walkSkip:
+					if d.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				case walkDescend:
+					return nil
+				}
+
 				// Apply ExcludeRepos
 				for _, exc := range currentGroveCfg.ExcludeRepos {
 					if relPath == exc || filepath.Base(path) == exc {
@@ -459,7 +635,7 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 					// kitchen-core) be discovered without needing their own grove.toml.
 					if !shouldPromote {
 						parentDir := filepath.Dir(path)
-						if _, parentCfg, perr := findGroveConfig(parentDir); perr == nil && parentCfg != nil && len(parentCfg.Workspaces) > 0 {
+						if _, parentCfg, perr := findGroveConfig(parentDir); perr == nil && parentCfg != nil && parentCfg.IsEcosystem() {
 							childName := filepath.Base(path)
 							for _, ws := range parentCfg.Workspaces {
 								// Accept either an exact basename match or a path
@@ -490,6 +666,9 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 				switch entityType {
 				case typeEcosystem:
 					// This is an ecosystem root - add it and continue descending
+					if relPath == "." {
+						groveCfg = s.refreshManifest(path, groveCfg)
+					}
 					eco := processEcosystem(path, groveCfg)
 					groveRes.ecosystems = append(groveRes.ecosystems, eco)
 					return nil // Continue descending to find projects within
@@ -527,11 +706,31 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 						}
 					}
 
+					// Submodule checkouts that weren't promoted are
+					// inventoried separately from plain checkouts.
+					if super := submoduleSuperproject(path); super != "" {
+						groveRes.repos = append(groveRes.repos, Repository{
+							Name:             filepath.Base(path),
+							Path:             path,
+							Kind:             RepositorySubmodule,
+							SuperprojectPath: super,
+						})
+						return filepath.SkipDir
+					}
+
 					// This is a git repo without grove.yml
 					nonGrovePath := processNonGroveRepo(path)
 					groveRes.nonGrove = append(groveRes.nonGrove, nonGrovePath)
 					return filepath.SkipDir
 
+				case typeBareRepo:
+					groveRes.repos = append(groveRes.repos, Repository{
+						Name: filepath.Base(path),
+						Path: path,
+						Kind: RepositoryBare,
+					})
+					return filepath.SkipDir
+
 				case typeSkip:
 					// Already processed, skip this directory
 					return nil
@@ -626,8 +825,30 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 				seenNonGrove[pathKey] = true
 			}
 		}
+		for _, repo := range groveRes.repos {
+			pathKey := normalizeKey(repo.Path)
+			if !seenNonGrove[pathKey] {
+				result.Repositories = append(result.Repositories, repo)
+				seenNonGrove[pathKey] = true
+			}
+		}
+		for _, node := range groveRes.remote {
+			remoteKey := node.Host + ":" + node.Path
+			if !seenRemote[remoteKey] {
+				result.Remote = append(result.Remote, node)
+				seenRemote[remoteKey] = true
+			}
+		}
+	}
+	if err := s.context().Err(); err != nil {
+		return nil, err
 	}
 
+	// Hosts answer in any order; group them in a stable one.
+	sort.SliceStable(result.Remote, func(i, j int) bool {
+		return result.Remote[i].Host < result.Remote[j].Host
+	})
+
 	// 4. Process explicit projects from global config (use Final to include overrides)
 	if layeredCfg.Final != nil {
 		for _, ep := range layeredCfg.Final.ExplicitProjects {
@@ -755,6 +976,18 @@ func (s *DiscoveryService) DiscoverAll() (*DiscoveryResult, error) {
 		}
 	}
 
+	// 7. Optional submodule inventory from each project's .gitmodules.
+	if s.submodules {
+		for i := range result.Projects {
+			subs, err := ListSubmodules(result.Projects[i].Path)
+			if err != nil {
+				s.logger.Warnf("Could not read submodules of %s: %v", result.Projects[i].Path, err)
+				continue
+			}
+			result.Projects[i].Submodules = subs
+		}
+	}
+
 	return result, nil
 }
 
@@ -799,21 +1032,27 @@ func FindEcosystemRoot(startDir string) (string, error) {
 // returning a flat list of WorkspaceNodes ready for consumption with
 // pre-calculated tree prefixes for rendering.
 func GetProjects(logger *logrus.Logger) ([]*WorkspaceNode, error) {
+	return NewDiscoveryService(logger).GetProjects()
+}
+
+// GetProjects is GetProjects for a configured service, e.g. one built with
+// WithSubmodules. Workspaces of ssh:// groves (WithRemote) follow the local
+// ones, grouped by host.
+func (s *DiscoveryService) GetProjects() ([]*WorkspaceNode, error) {
 	// Load config to pass to transformation
 	cfg, err := config.LoadDefault()
 	if err != nil {
 		// Non-fatal, proceed with empty config, but log warning
-		logger.Warnf("Could not load grove config, notebook names will not be resolved: %v", err)
+		s.logger.Warnf("Could not load grove config, notebook names will not be resolved: %v", err)
 		cfg = &config.Config{}
 	}
 
-	discoveryService := NewDiscoveryService(logger)
-	result, err := discoveryService.DiscoverAll()
+	result, err := s.DiscoverAll()
 	if err != nil {
 		return nil, err
 	}
 	nodes := TransformToWorkspaceNodes(result, cfg)
-	return BuildWorkspaceTree(nodes), nil
+	return append(BuildWorkspaceTree(nodes), remoteWorkspaceNodes(result.Remote)...), nil
 }
 
 // GetWorkspaceTree performs discovery and returns a fully formed workspace hierarchy.
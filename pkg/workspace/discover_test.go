@@ -1,6 +1,8 @@
 package workspace
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,19 +13,20 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/progressx"
 )
 
 // setupMockFS creates a mock filesystem structure for testing.
 func setupMockFS(t *testing.T) (string, string) {
 	rootDir := resolveDir(t.TempDir())
 
-	// 1. Global config with 'search_paths'
+	// 1. Global config with 'groves'
 	globalConfigDir := filepath.Join(rootDir, "home", ".config", "grove")
 	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
 	emptyStr := ""
 	globalCfg := config.Config{
-		SearchPaths: map[string]config.SearchPathConfig{
-			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
 		},
 		// Disable cx repo discovery so tests don't pick up real user repos
 		Context: &config.ContextConfig{
@@ -132,6 +135,66 @@ func TestDiscoveryService(t *testing.T) {
 	})
 }
 
+func TestDiscoveryService_WithProgress(t *testing.T) {
+	_, homeDir := setupMockFS(t)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("HOME", homeDir)
+	t.Setenv("GROVE_CONFIG_OVERLAY", filepath.Join(homeDir, ".config", "grove", "grove.yml"))
+
+	task := progressx.Start("Discovering workspaces", 0, progressx.Options{Mode: progressx.ModeOff})
+	_, err := NewDiscoveryService(nil).WithProgress(task).WithSubmodules().DiscoverAll()
+	require.NoError(t, err)
+	task.Done()
+
+	snap := task.Snapshot()
+	assert.Positive(t, snap.Done, "every scanned directory should be counted")
+	assert.NotEmpty(t, snap.Item, "the grove being walked should be described")
+}
+
+func TestDiscoveryService_WithContext(t *testing.T) {
+	_, homeDir := setupMockFS(t)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("HOME", homeDir)
+	t.Setenv("GROVE_CONFIG_OVERLAY", filepath.Join(homeDir, ".config", "grove", "grove.yml"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewDiscoveryService(nil).WithContext(ctx).DiscoverAll()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	result, err := NewDiscoveryService(nil).WithContext(context.Background()).DiscoverAll()
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Projects)
+}
+
+// TestDiscoveryService_DeprecatedSearchPaths verifies that a global config
+// still using 'search_paths' is honoured and that the deprecation warning
+// goes through the service's logger.
+func TestDiscoveryService_DeprecatedSearchPaths(t *testing.T) {
+	rootDir, homeDir := setupMockFS(t)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("HOME", homeDir)
+	t.Setenv("GROVE_CONFIG_OVERLAY", filepath.Join(homeDir, ".config", "grove", "grove.yml"))
+
+	emptyStr := ""
+	globalBytes, _ := yaml.Marshal(config.Config{
+		SearchPaths: map[string]config.SearchPathConfig{
+			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		},
+		Context: &config.ContextConfig{ReposDir: &emptyStr},
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".config", "grove", "grove.yml"), globalBytes, 0o644))
+
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+
+	result, err := NewDiscoveryService(logger).DiscoverAll()
+	require.NoError(t, err)
+	assert.Len(t, result.Ecosystems, 1, "search_paths should still be walked")
+	assert.Contains(t, buf.String(), "deprecated key")
+}
+
 // TestDiscover_PromoteFromEcosystemWorkspaces verifies that a child git repo
 // without its own grove.toml is still discovered as a project when the
 // enclosing ecosystem's `workspaces` field explicitly enumerates it. This is
@@ -145,8 +208,8 @@ func TestDiscover_PromoteFromEcosystemWorkspaces(t *testing.T) {
 	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
 	emptyStr := ""
 	globalCfg := config.Config{
-		SearchPaths: map[string]config.SearchPathConfig{
-			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
 		},
 		Context: &config.ContextConfig{ReposDir: &emptyStr},
 	}
@@ -231,8 +294,8 @@ func TestDiscoverAll_NestedEcosystem_NoMisattribution(t *testing.T) {
 	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
 	emptyStr := ""
 	globalCfg := config.Config{
-		SearchPaths: map[string]config.SearchPathConfig{
-			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
 		},
 		Context: &config.ContextConfig{ReposDir: &emptyStr},
 	}
@@ -322,3 +385,39 @@ func TestClassifyWorkspaceRoot_NoConfigStaysSilent(t *testing.T) {
 	assert.Equal(t, typeNonGroveRepo, dirType)
 	assert.Nil(t, cfg)
 }
+
+func TestDiscoverAll_Metadata(t *testing.T) {
+	rootDir, homeDir := setupMockFS(t)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("HOME", homeDir)
+	t.Setenv("GROVE_CONFIG_OVERLAY", filepath.Join(homeDir, ".config", "grove", "grove.yml"))
+
+	projADir := filepath.Join(rootDir, "work", "my-ecosystem", "project-a")
+	data := `name: project-a
+description: Billing API
+tags: [backend, go]
+owners: ["@payments"]
+links:
+  docs: https://docs.example.com/billing
+`
+	require.NoError(t, os.WriteFile(filepath.Join(projADir, "grove.yml"), []byte(data), 0o644))
+
+	result, err := NewDiscoveryService(logrus.New()).DiscoverAll()
+	require.NoError(t, err)
+
+	want := &Metadata{
+		Description: "Billing API",
+		Tags:        []string{"backend", "go"},
+		Owners:      []string{"@payments"},
+		Links:       map[string]string{"docs": "https://docs.example.com/billing"},
+	}
+	byPath := make(map[string]*WorkspaceNode)
+	for _, n := range TransformToWorkspaceNodes(result, nil) {
+		byPath[n.Path] = n
+	}
+	assert.Equal(t, want, byPath[projADir].Metadata)
+	assert.Equal(t, want, byPath[filepath.Join(projADir, ".grove-worktrees", "feature-branch")].Metadata,
+		"worktrees share their project's metadata")
+	assert.Nil(t, byPath[filepath.Join(rootDir, "work", "my-ecosystem", "project-b")].Metadata,
+		"a config without metadata leaves the node's nil")
+}
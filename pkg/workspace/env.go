@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Environment variables describing the workspace a process was launched
+// into (see WorkspaceNode.EnvVars).
+const (
+	EnvWorkspace          = "GROVE_WORKSPACE"
+	EnvWorkspacePath      = "GROVE_WORKSPACE_PATH"
+	EnvWorkspaceID        = "GROVE_WORKSPACE_ID"
+	EnvWorkspaceKind      = "GROVE_WORKSPACE_KIND"
+	EnvWorkspaceEcosystem = "GROVE_WORKSPACE_ECOSYSTEM"
+)
+
+// Environment variables of the Grove context a shell or tool works in (see
+// WorkspaceNode.ContextEnvVars), on top of the GROVE_WORKSPACE* variables.
+const (
+	EnvProject       = "GROVE_PROJECT"
+	EnvEcosystem     = "GROVE_ECOSYSTEM"
+	EnvNotebookPlans = "GROVE_NOTEBOOK_PLANS_DIR"
+	EnvNotebookChats = "GROVE_NOTEBOOK_CHATS_DIR"
+	EnvNotebookNotes = "GROVE_NOTEBOOK_NOTES_DIR"
+	EnvLogDir        = "GROVE_LOG_DIR"
+)
+
+// EnvVars returns the GROVE_WORKSPACE* variables for a process launched
+// into the workspace: its name, path, colon identifier and kind, plus the
+// root ecosystem path when it belongs to one.
+func (w *WorkspaceNode) EnvVars() map[string]string {
+	env := map[string]string{
+		EnvWorkspace:     w.Name,
+		EnvWorkspacePath: w.Path,
+		EnvWorkspaceID:   w.Identifier(":"),
+		EnvWorkspaceKind: string(w.Kind),
+	}
+	if eco := w.RootEcosystemPath; eco != "" {
+		env[EnvWorkspaceEcosystem] = eco
+	} else if w.IsEcosystem() {
+		env[EnvWorkspaceEcosystem] = w.Path
+	}
+	return env
+}
+
+// ContextEnvVars returns EnvVars plus the names of the workspace's project
+// (a worktree's parent repository) and ecosystem, and its plans, chats and
+// notes directories as resolved by locator. GROVE_LOG_DIR is left to the
+// caller, since the log directory is resolved by core/logging.
+func (w *WorkspaceNode) ContextEnvVars(locator *NotebookLocator) (map[string]string, error) {
+	env := w.EnvVars()
+	env[EnvProject] = w.Name
+	if w.IsProjectWorktreeChild() {
+		env[EnvProject] = filepath.Base(w.ParentProjectPath)
+	}
+	if eco, ok := env[EnvWorkspaceEcosystem]; ok {
+		env[EnvEcosystem] = filepath.Base(eco)
+	}
+
+	dirs := []struct {
+		name string
+		get  func(*WorkspaceNode) (string, error)
+	}{
+		{EnvNotebookPlans, locator.GetPlansDir},
+		{EnvNotebookChats, locator.GetChatsDir},
+		// The notes directory is the parent of each note type's, as in
+		// GetAllContentDirs.
+		{EnvNotebookNotes, func(n *WorkspaceNode) (string, error) {
+			dir, err := locator.GetNotesDir(n, "inbox")
+			return filepath.Dir(dir), err
+		}},
+	}
+	for _, d := range dirs {
+		dir, err := d.get(w)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s: %w", d.name, err)
+		}
+		env[d.name] = dir
+	}
+	return env, nil
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestWorkspaceNode_EnvVars(t *testing.T) {
+	sub := &WorkspaceNode{
+		Name:                "api",
+		Path:                "/src/eco/api",
+		Kind:                KindEcosystemSubProject,
+		ParentEcosystemPath: "/src/eco",
+		RootEcosystemPath:   "/src/eco",
+	}
+	assert.Equal(t, map[string]string{
+		EnvWorkspace:          "api",
+		EnvWorkspacePath:      "/src/eco/api",
+		EnvWorkspaceID:        sub.Identifier(":"),
+		EnvWorkspaceKind:      string(KindEcosystemSubProject),
+		EnvWorkspaceEcosystem: "/src/eco",
+	}, sub.EnvVars())
+
+	root := &WorkspaceNode{Name: "eco", Path: "/src/eco", Kind: KindEcosystemRoot}
+	assert.Equal(t, "/src/eco", root.EnvVars()[EnvWorkspaceEcosystem])
+
+	standalone := &WorkspaceNode{Name: "tool", Path: "/src/tool", Kind: KindStandaloneProject}
+	assert.NotContains(t, standalone.EnvVars(), EnvWorkspaceEcosystem)
+}
+
+func TestWorkspaceNode_ContextEnvVars(t *testing.T) {
+	locator := NewNotebookLocator(&config.Config{
+		Notebooks: &config.NotebooksConfig{
+			Definitions: map[string]*config.Notebook{
+				"nb": {
+					RootDir:           "/nb",
+					ChatsPathTemplate: "{{ .Workspace.Name }}/chats",
+					NotesPathTemplate: "{{ .Workspace.Name }}/notes/{{ .NoteType }}",
+					PlansPathTemplate: "{{ .Workspace.Name }}/plans",
+				},
+			},
+			Rules: &config.NotebookRules{Default: "nb"},
+		},
+	})
+
+	sub := &WorkspaceNode{
+		Name:                "api",
+		Path:                "/src/eco/api",
+		Kind:                KindEcosystemSubProject,
+		ParentEcosystemPath: "/src/eco",
+		RootEcosystemPath:   "/src/eco",
+	}
+	env, err := sub.ContextEnvVars(locator)
+	require.NoError(t, err)
+	assert.Equal(t, "api", env[EnvWorkspace])
+	assert.Equal(t, "api", env[EnvProject])
+	assert.Equal(t, "eco", env[EnvEcosystem])
+	assert.Equal(t, "/nb/api/plans", env[EnvNotebookPlans])
+	assert.Equal(t, "/nb/api/chats", env[EnvNotebookChats])
+	assert.Equal(t, "/nb/api/notes", env[EnvNotebookNotes])
+
+	worktree := &WorkspaceNode{
+		Name:              "feature",
+		Path:              "/src/tool/.grove-worktrees/feature",
+		Kind:              KindStandaloneProjectWorktree,
+		ParentProjectPath: "/src/tool",
+	}
+	env, err = worktree.ContextEnvVars(locator)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", env[EnvWorkspace])
+	assert.Equal(t, "tool", env[EnvProject])
+	assert.NotContains(t, env, EnvEcosystem)
+}
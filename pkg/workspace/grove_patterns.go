@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/grovetools/core/config"
+)
+
+// grovePatterns holds a grove's include/exclude globs, split into path
+// segments. Patterns are slash-separated paths relative to the grove root;
+// each segment is a path.Match pattern, and a "**" segment matches any
+// number of directories, including none.
+type grovePatterns struct {
+	include [][]string
+	exclude [][]string
+}
+
+// newGrovePatterns compiles cfg's include/exclude lists. Malformed patterns
+// are returned separately so the caller can warn about them; they never
+// match.
+func newGrovePatterns(cfg config.GroveSourceConfig) (grovePatterns, []string) {
+	var p grovePatterns
+	var bad []string
+	compile := func(patterns []string) [][]string {
+		var out [][]string
+		for _, raw := range patterns {
+			segs := splitGlob(raw)
+			if len(segs) == 0 || !validGlob(segs) {
+				bad = append(bad, raw)
+				continue
+			}
+			out = append(out, segs)
+		}
+		return out
+	}
+	p.include = compile(cfg.Include)
+	p.exclude = compile(cfg.Exclude)
+	return p, bad
+}
+
+// walkAction is what the discovery walk does with one entry of a grove.
+type walkAction int
+
+const (
+	walkScan    walkAction = iota // classify the entry as usual
+	walkDescend                   // only descend: an include may match below
+	walkSkip                      // skip the entry and anything below it
+)
+
+// action decides how the walk treats relPath (relative to the grove root,
+// OS-separated). Excludes win over includes. Without includes everything
+// that is not excluded is scanned; with includes, an entry is scanned when
+// it or one of its ancestors matches one, only descended when a match is
+// still possible further down, and skipped otherwise. The grove root itself
+// is always scanned.
+func (p grovePatterns) action(relPath string) walkAction {
+	if relPath == "." {
+		return walkScan
+	}
+	segs := strings.Split(filepath.ToSlash(relPath), "/")
+	for _, pat := range p.exclude {
+		if globMatch(pat, segs) {
+			return walkSkip
+		}
+	}
+	if len(p.include) == 0 {
+		return walkScan
+	}
+	descend := false
+	for _, pat := range p.include {
+		for n := 1; n <= len(segs); n++ {
+			if globMatch(pat, segs[:n]) {
+				return walkScan
+			}
+		}
+		if !descend && globPrefix(pat, segs) {
+			descend = true
+		}
+	}
+	if descend {
+		return walkDescend
+	}
+	return walkSkip
+}
+
+func splitGlob(pattern string) []string {
+	pattern = strings.Trim(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+	pattern = strings.TrimPrefix(pattern, "./")
+	if pattern == "" {
+		return nil
+	}
+	return strings.Split(pattern, "/")
+}
+
+func validGlob(segs []string) bool {
+	for _, s := range segs {
+		if _, err := path.Match(s, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// globMatch reports whether the pattern segments match all of segs.
+func globMatch(pat, segs []string) bool {
+	if len(pat) == 0 {
+		return len(segs) == 0
+	}
+	if pat[0] == "**" {
+		return globMatch(pat[1:], segs) || (len(segs) > 0 && globMatch(pat, segs[1:]))
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pat[0], segs[0])
+	return ok && globMatch(pat[1:], segs[1:])
+}
+
+// globPrefix reports whether some path below segs could still match the
+// pattern segments.
+func globPrefix(pat, segs []string) bool {
+	if len(segs) == 0 {
+		return len(pat) > 0
+	}
+	if len(pat) == 0 {
+		return false
+	}
+	if pat[0] == "**" {
+		return true
+	}
+	ok, _ := path.Match(pat[0], segs[0])
+	return ok && globPrefix(pat[1:], segs[1:])
+}
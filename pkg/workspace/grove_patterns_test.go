@@ -0,0 +1,79 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestGrovePatternsAction(t *testing.T) {
+	p, bad := newGrovePatterns(config.GroveSourceConfig{
+		Include: []string{"work/*", "oss/grove-*", "[bad"},
+		Exclude: []string{"**/archive/**"},
+	})
+	assert.Equal(t, []string{"[bad"}, bad)
+
+	cases := map[string]walkAction{
+		".":                        walkScan,
+		"work":                     walkDescend,
+		"work/app":                 walkScan,
+		"work/app/src":             walkScan,
+		"work/archive":             walkSkip,
+		"work/app/archive/old":     walkSkip,
+		"oss":                      walkDescend,
+		"oss/grove-core":           walkScan,
+		"oss/other":                walkSkip,
+		"scratch":                  walkSkip,
+		filepath.Join("work", "x"): walkScan,
+	}
+	for rel, want := range cases {
+		assert.Equal(t, want, p.action(rel), rel)
+	}
+
+	excludeOnly, _ := newGrovePatterns(config.GroveSourceConfig{Exclude: []string{"tmp"}})
+	assert.Equal(t, walkScan, excludeOnly.action("work/tmp"))
+	assert.Equal(t, walkSkip, excludeOnly.action("tmp"))
+}
+
+func TestDiscover_GroveIncludeExclude(t *testing.T) {
+	rootDir := resolveDir(t.TempDir())
+	groveDir := filepath.Join(rootDir, "code")
+
+	globalConfigDir := filepath.Join(rootDir, "home", ".config", "grove")
+	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(globalConfigDir, "grove.toml"), []byte(`
+[context]
+repos_dir = ""
+
+[groves.code]
+path = "`+groveDir+`"
+include = ["work/*", "oss/grove-*"]
+exclude = ["**/archive/**"]
+`), 0o644))
+
+	for _, rel := range []string{"work/app", "work/archive/old-app", "oss/grove-core", "oss/other", "scratch/tool"} {
+		dir := filepath.Join(groveDir, rel)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "grove.toml"), []byte(`name = "`+filepath.Base(rel)+`"`+"\n"), 0o644))
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(rootDir, "home", ".config"))
+	t.Setenv("HOME", filepath.Join(rootDir, "home"))
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	result, err := NewDiscoveryService(logger).DiscoverAll()
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range result.Projects {
+		names = append(names, p.Name)
+	}
+	assert.ElementsMatch(t, []string{"app", "grove-core"}, names)
+}
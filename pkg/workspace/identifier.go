@@ -63,7 +63,7 @@ func (p *WorkspaceNode) Identifier(delim string) string {
 		// e.g., my-ecosystem_sub-project
 		return fmt.Sprintf("%s%s%s", ecoName, delim, s(p.Name))
 
-	case KindStandaloneProject, KindEcosystemRoot, KindNonGroveRepo:
+	case KindStandaloneProject, KindEcosystemRoot, KindNonGroveRepo, KindBareRepo, KindSubmodule:
 		return s(p.Name)
 
 	default:
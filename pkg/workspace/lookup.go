@@ -358,7 +358,7 @@ func GetProjectByPath(path string) (*WorkspaceNode, error) {
 		var parentProjectPath string
 		if ownerPath != "" {
 			_, cfg, err := findGroveConfig(ownerPath)
-			if err == nil && len(cfg.Workspaces) > 0 {
+			if err == nil && cfg.IsEcosystem() {
 				// Owner is itself an ecosystem root (non-anchored container or
 				// legacy linked worktree).
 				parentEcosystemPath = ownerPath
@@ -397,6 +397,7 @@ func GetProjectByPath(path string) (*WorkspaceNode, error) {
 			ParentProjectPath:   parentProjectPath,
 			ParentEcosystemPath: parentEcosystemPath,
 			RootEcosystemPath:   rootEcosystemPath,
+			Metadata:            metadataFromConfig(foundCfg),
 		}
 		nodes = append(nodes, ecoNode)
 
@@ -450,7 +451,7 @@ func GetProjectByPath(path string) (*WorkspaceNode, error) {
 			checkDir := startDir
 			for checkDir != filepath.Dir(checkDir) {
 				_, cfg, err := findGroveConfig(checkDir)
-				if err == nil && len(cfg.Workspaces) > 0 {
+				if err == nil && cfg.IsEcosystem() {
 					return checkDir
 				}
 				checkDir = filepath.Dir(checkDir)
@@ -542,6 +543,7 @@ func GetProjectByPath(path string) (*WorkspaceNode, error) {
 			ParentProjectPath:   parentProjectPath,
 			ParentEcosystemPath: parentEcosystemPath,
 			RootEcosystemPath:   rootEcosystemPath,
+			Metadata:            metadataFromConfig(foundCfg),
 		}
 		nodes = append(nodes, primaryNode)
 
@@ -24,12 +24,12 @@ func normalizePath(t *testing.T, p string) string {
 func setupMockFSForLookup(t *testing.T) (string, string) {
 	rootDir := t.TempDir()
 
-	// 1. Global config with 'search_paths'
+	// 1. Global config with 'groves'
 	globalConfigDir := filepath.Join(rootDir, "home", ".config", "grove")
 	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
 	globalCfg := config.Config{
-		SearchPaths: map[string]config.SearchPathConfig{
-			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
 		},
 	}
 	globalBytes, _ := yaml.Marshal(globalCfg)
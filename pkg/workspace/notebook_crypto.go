@@ -0,0 +1,541 @@
+package workspace
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// notebookCipherMagic starts every encrypted notebook file. It is followed
+// by a 24-byte nonce and a NaCl secretbox sealing the plaintext.
+const notebookCipherMagic = "GROVENC1\n"
+
+// NotebookKeyHeaderFile is the file at a notebook's root that records the
+// random salt its passphrase is stretched with (see NotebookKeySalt). It is
+// not secret, but it must travel with the notebook's files: without it a
+// passphrase no longer derives their key.
+const NotebookKeyHeaderFile = ".grove-notebook-key.json"
+
+// notebookSaltSize is the length of a notebook's scrypt salt.
+const notebookSaltSize = 16
+
+// notebookKeyHeader is the content of NotebookKeyHeaderFile.
+type notebookKeyHeader struct {
+	KDF  string `json:"kdf"`
+	Salt string `json:"salt"`
+}
+
+// DefaultNotebookUnlockTTL is how long `core notes unlock` keeps a key.
+const DefaultNotebookUnlockTTL = 8 * time.Hour
+
+// ErrNotebookLocked is returned when an encrypted notebook file is read and
+// no key is available: none is configured and the notebook is not unlocked.
+var ErrNotebookLocked = errors.New("notebook is locked")
+
+// ErrNotebookKeyHeaderMissing is returned by NotebookKeySalt when a
+// notebook holds encrypted files but its NotebookKeyHeaderFile is gone, so
+// its passphrase can no longer derive their key.
+var ErrNotebookKeyHeaderMissing = errors.New("notebook key header is missing")
+
+// NotebookKey is a notebook's 32-byte secretbox key.
+type NotebookKey [32]byte
+
+// IsEncryptedNotebookData reports whether data is an encrypted notebook file.
+func IsEncryptedNotebookData(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(notebookCipherMagic))
+}
+
+// DeriveNotebookKey turns resolved key material into a key. A base64
+// encoding of exactly 32 bytes (e.g. `openssl rand -base64 32`) is used
+// directly; anything else is treated as a passphrase and stretched with
+// scrypt using salt, the notebook's own (see NotebookKeySalt).
+func DeriveNotebookKey(material string, salt []byte) (*NotebookKey, error) {
+	if material == "" {
+		return nil, fmt.Errorf("notebook key is empty")
+	}
+	if key, ok := rawNotebookKey(material); ok {
+		return key, nil
+	}
+	var key NotebookKey
+	if len(salt) == 0 {
+		return nil, fmt.Errorf("notebook key salt is empty")
+	}
+	derived, err := scrypt.Key([]byte(material), salt, 1<<15, 8, 1, len(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive notebook key: %w", err)
+	}
+	copy(key[:], derived)
+	return &key, nil
+}
+
+// rawNotebookKey returns material as a key when it is a base64 encoding of
+// exactly 32 bytes, which needs no salt.
+func rawNotebookKey(material string) (*NotebookKey, bool) {
+	var key NotebookKey
+	raw, err := base64.StdEncoding.DecodeString(material)
+	if err != nil || len(raw) != len(key) {
+		return nil, false
+	}
+	copy(key[:], raw)
+	return &key, true
+}
+
+// NotebookKeySalt returns the scrypt salt of the notebook at root, read
+// from its NotebookKeyHeaderFile. A notebook without one gets a new random
+// salt, so no two notebooks derive the same key from the same passphrase.
+// A notebook that already holds encrypted files never gets a new salt: it
+// would derive a key that cannot open them, so ErrNotebookKeyHeaderMissing
+// is returned instead.
+func NotebookKeySalt(root string) ([]byte, error) {
+	path := filepath.Join(root, NotebookKeyHeaderFile)
+	if salt, err := readNotebookSalt(path); !os.IsNotExist(err) {
+		return salt, err
+	}
+	encrypted, err := hasEncryptedNotebookFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, fmt.Errorf("%w: %s holds encrypted files but has no %s; restore it from a backup or the notebook's history", ErrNotebookKeyHeaderMissing, root, NotebookKeyHeaderFile)
+	}
+
+	salt := make([]byte, notebookSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate notebook salt: %w", err)
+	}
+	data, err := json.MarshalIndent(notebookKeyHeader{KDF: "scrypt", Salt: base64.StdEncoding.EncodeToString(salt)}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	// O_EXCL: a process that created the header first wins, and its salt
+	// is the one used.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if os.IsExist(err) {
+		return readNotebookSalt(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return salt, nil
+}
+
+func readNotebookSalt(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var header notebookKeyHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	salt, err := base64.StdEncoding.DecodeString(header.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("invalid %s: bad salt", path)
+	}
+	return salt, nil
+}
+
+// EncryptNotebookData seals plaintext with key. Data that is already
+// encrypted is returned unchanged.
+func EncryptNotebookData(key *NotebookKey, plaintext []byte) ([]byte, error) {
+	if IsEncryptedNotebookData(plaintext) {
+		return plaintext, nil
+	}
+	var nonce [24]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	out := make([]byte, 0, len(notebookCipherMagic)+len(nonce)+len(plaintext)+secretbox.Overhead)
+	out = append(out, notebookCipherMagic...)
+	out = append(out, nonce[:]...)
+	return secretbox.Seal(out, plaintext, &nonce, (*[32]byte)(key)), nil
+}
+
+// DecryptNotebookData opens data sealed by EncryptNotebookData. Data that
+// is not encrypted is returned unchanged.
+func DecryptNotebookData(key *NotebookKey, data []byte) ([]byte, error) {
+	if !IsEncryptedNotebookData(data) {
+		return data, nil
+	}
+	body := data[len(notebookCipherMagic):]
+	if len(body) < 24+secretbox.Overhead {
+		return nil, fmt.Errorf("encrypted notebook file is truncated")
+	}
+	var nonce [24]byte
+	copy(nonce[:], body[:24])
+	plaintext, ok := secretbox.Open(nil, body[24:], &nonce, (*[32]byte)(key))
+	if !ok {
+		return nil, fmt.Errorf("failed to decrypt notebook file: wrong key or corrupted data")
+	}
+	return plaintext, nil
+}
+
+// notebookKeyCache holds the keys a locator has resolved, by notebook name.
+// It is shared by copies of the locator (see WithSearchIndex).
+type notebookKeyCache struct {
+	mu   sync.Mutex
+	keys map[string]*NotebookKey
+}
+
+// unlockedKey is an entry of the unlocked-key cache.
+type unlockedKey struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+}
+
+// ErrNoKeyRuntimeDir is returned by UnlockNotebook when there is no
+// XDG_RUNTIME_DIR to keep the key in.
+var ErrNoKeyRuntimeDir = errors.New("XDG_RUNTIME_DIR is not set; unlocked keys are only kept in a per-user runtime directory (use encryption.key_command instead)")
+
+// notebookKeyCachePath is where UnlockNotebook keeps a notebook's key:
+// XDG_RUNTIME_DIR, which is private to the user and removed at logout. Keys
+// are never kept in paths.RuntimeDir's fallbacks, which are ordinary
+// directories on disk (always the case on macOS), so there is no path when
+// XDG_RUNTIME_DIR is unset.
+func notebookKeyCachePath(name string) (string, bool) {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		return "", false
+	}
+	return filepath.Join(dir, "grove", "notebook-keys", name+".json"), true
+}
+
+// UnlockNotebook keeps key for the named notebook for ttl, so reads do not
+// run the notebook's key_command again until it expires or LockNotebook is
+// called. It returns ErrNoKeyRuntimeDir when XDG_RUNTIME_DIR is unset.
+func UnlockNotebook(name string, key *NotebookKey, ttl time.Duration) error {
+	path, ok := notebookKeyCachePath(name)
+	if !ok {
+		return ErrNoKeyRuntimeDir
+	}
+	if err := paths.EnsurePrivateDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	data, err := json.Marshal(unlockedKey{
+		Key:     base64.StdEncoding.EncodeToString(key[:]),
+		Expires: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to store notebook key: %w", err)
+	}
+	return nil
+}
+
+// LockNotebook forgets the named notebook's unlocked key. Locking a notebook
+// that is not unlocked is not an error.
+func LockNotebook(name string) error {
+	path, ok := notebookKeyCachePath(name)
+	if !ok {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove notebook key: %w", err)
+	}
+	return nil
+}
+
+// unlockedNotebookKey returns the named notebook's key kept by
+// UnlockNotebook, removing it once expired.
+func unlockedNotebookKey(name string) (*NotebookKey, bool) {
+	path, ok := notebookKeyCachePath(name)
+	if !ok {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cached unlockedKey
+	if json.Unmarshal(data, &cached) != nil {
+		return nil, false
+	}
+	if time.Now().After(cached.Expires) {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	raw, err := base64.StdEncoding.DecodeString(cached.Key)
+	var key NotebookKey
+	if err != nil || len(raw) != len(key) {
+		return nil, false
+	}
+	copy(key[:], raw)
+	return &key, true
+}
+
+// NotebookName returns the name of the notebook definition that holds
+// node's notebook files, or "" for the built-in default notebook.
+func (l *NotebookLocator) NotebookName(node *WorkspaceNode) string {
+	if l.config == nil || l.config.Notebooks == nil || l.config.Notebooks.Definitions == nil {
+		return ""
+	}
+	if node != nil && node.NotebookName != "" {
+		if nb := l.config.Notebooks.Definitions[node.NotebookName]; nb != nil {
+			return node.NotebookName
+		}
+	}
+	if l.config.Notebooks.Rules != nil && l.config.Notebooks.Rules.Default != "" {
+		if nb := l.config.Notebooks.Definitions[l.config.Notebooks.Rules.Default]; nb != nil {
+			return l.config.Notebooks.Rules.Default
+		}
+	}
+	return ""
+}
+
+// notebookEncryption returns the named notebook's encryption settings, or
+// nil when it is not encrypted.
+func (l *NotebookLocator) notebookEncryption(name string) *config.NotebookEncryptionConfig {
+	if name == "" || l.config == nil || l.config.Notebooks == nil {
+		return nil
+	}
+	nb := l.config.Notebooks.Definitions[name]
+	if nb == nil || nb.Encryption == nil || !nb.Encryption.Enabled {
+		return nil
+	}
+	return nb.Encryption
+}
+
+// IsEncrypted reports whether the named notebook has encryption enabled.
+func (l *NotebookLocator) IsEncrypted(name string) bool {
+	return l.notebookEncryption(name) != nil
+}
+
+// NotebookKey returns the named notebook's key: the one kept by
+// UnlockNotebook, else the one resolved from its encryption config. Keys
+// are remembered by the locator, so a key_command runs at most once.
+// Returns ErrNotebookLocked when neither is available.
+func (l *NotebookLocator) NotebookKey(name string) (*NotebookKey, error) {
+	if l.keys == nil {
+		l.keys = &notebookKeyCache{}
+	}
+	l.keys.mu.Lock()
+	defer l.keys.mu.Unlock()
+	if key, ok := l.keys.keys[name]; ok {
+		return key, nil
+	}
+
+	var (
+		key *NotebookKey
+		ok  bool
+		err error
+	)
+	if name != "" {
+		key, ok = unlockedNotebookKey(name)
+	}
+	if !ok {
+		if key, err = l.ResolveNotebookKey(name); err != nil {
+			return nil, err
+		}
+	}
+	if l.keys.keys == nil {
+		l.keys.keys = make(map[string]*NotebookKey)
+	}
+	l.keys.keys[name] = key
+	return key, nil
+}
+
+// ResolveNotebookKey resolves the named notebook's key from its encryption
+// config, ignoring any key kept by UnlockNotebook. Returns
+// ErrNotebookLocked when the notebook has no key source.
+func (l *NotebookLocator) ResolveNotebookKey(name string) (*NotebookKey, error) {
+	enc := l.notebookEncryption(name)
+	if enc == nil {
+		return nil, ErrNotebookLocked
+	}
+	material, err := enc.ResolveKey()
+	if err != nil {
+		return nil, err
+	}
+	if material == "" {
+		return nil, ErrNotebookLocked
+	}
+	if key, ok := rawNotebookKey(material); ok {
+		return key, nil
+	}
+	root, err := l.NotebookRoot(name)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := NotebookKeySalt(root)
+	if err != nil {
+		return nil, err
+	}
+	return DeriveNotebookKey(material, salt)
+}
+
+// NotebookRoot returns the expanded root directory of the named notebook.
+func (l *NotebookLocator) NotebookRoot(name string) (string, error) {
+	if l.config == nil || l.config.Notebooks == nil || l.config.Notebooks.Definitions[name] == nil {
+		return "", fmt.Errorf("notebook %q is not defined", name)
+	}
+	root := l.config.Notebooks.Definitions[name].RootDir
+	if root == "" {
+		return "", fmt.Errorf("notebook %q has no root_dir", name)
+	}
+	return pathutil.Expand(root)
+}
+
+// walkNotebookMarkdown calls fn for every markdown file under root,
+// skipping hidden directories such as .git and .obsidian.
+func walkNotebookMarkdown(root string, fn func(path string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".md" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(path, info)
+	})
+}
+
+// errStopWalk ends a walkNotebookMarkdown early.
+var errStopWalk = errors.New("stop walk")
+
+// hasEncryptedNotebookFiles reports whether any markdown file under root is
+// encrypted. A missing root holds none.
+func hasEncryptedNotebookFiles(root string) (bool, error) {
+	found := false
+	err := walkNotebookMarkdown(root, func(path string, _ fs.FileInfo) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		head := make([]byte, len(notebookCipherMagic))
+		n, _ := io.ReadFull(f, head)
+		f.Close()
+		if IsEncryptedNotebookData(head[:n]) {
+			found = true
+			return errStopWalk
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopWalk) && !os.IsNotExist(err) {
+		return false, err
+	}
+	return found, nil
+}
+
+// CheckNotebookKey reports whether key opens the first encrypted file under
+// root. Any key is accepted while root holds no encrypted files.
+func CheckNotebookKey(root string, key *NotebookKey) error {
+	var checkErr error
+	err := walkNotebookMarkdown(root, func(path string, _ fs.FileInfo) error {
+		data, err := os.ReadFile(path)
+		if err != nil || !IsEncryptedNotebookData(data) {
+			return nil
+		}
+		if _, err := DecryptNotebookData(key, data); err != nil {
+			checkErr = fmt.Errorf("%s: %w", path, err)
+		}
+		return errStopWalk
+	})
+	if err != nil && !errors.Is(err, errStopWalk) && !os.IsNotExist(err) {
+		return err
+	}
+	return checkErr
+}
+
+// EncryptNotebookFiles encrypts every plaintext markdown file under root in
+// place, for a notebook that had encryption turned on after it was created.
+// It returns the number of files encrypted.
+func EncryptNotebookFiles(root string, key *NotebookKey) (int, error) {
+	count := 0
+	err := walkNotebookMarkdown(root, func(path string, info fs.FileInfo) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if IsEncryptedNotebookData(data) {
+			return nil
+		}
+		sealed, err := EncryptNotebookData(key, data)
+		if err != nil {
+			return err
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, sealed, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", path, err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return fmt.Errorf("failed to encrypt %s: %w", path, err)
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// ReadFile reads a file from node's notebook, decrypting it when it is
+// encrypted. Plaintext files are returned as they are, so a notebook can
+// hold a mix while it is being converted.
+func (l *NotebookLocator) ReadFile(node *WorkspaceNode, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil || !IsEncryptedNotebookData(data) {
+		return data, err
+	}
+	key, err := l.NotebookKey(l.NotebookName(node))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	plaintext, err := DecryptNotebookData(key, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return plaintext, nil
+}
+
+// WriteFile writes data to a file in node's notebook, encrypting it when
+// the notebook has encryption enabled.
+func (l *NotebookLocator) WriteFile(node *WorkspaceNode, path string, data []byte, perm os.FileMode) error {
+	if name := l.NotebookName(node); l.IsEncrypted(name) {
+		key, err := l.NotebookKey(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = EncryptNotebookData(key, data); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, perm)
+}
@@ -0,0 +1,225 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/core/config"
+)
+
+var testNotebookSalt = []byte("0123456789abcdef")
+
+func TestNotebookData_RoundTrip(t *testing.T) {
+	key, err := DeriveNotebookKey("correct horse battery staple", testNotebookSalt)
+	require.NoError(t, err)
+
+	sealed, err := EncryptNotebookData(key, []byte("# Plan\n\nsecret\n"))
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedNotebookData(sealed))
+	assert.NotContains(t, string(sealed), "secret")
+
+	// Encrypting twice is a no-op.
+	again, err := EncryptNotebookData(key, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, sealed, again)
+
+	plain, err := DecryptNotebookData(key, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "# Plan\n\nsecret\n", string(plain))
+
+	other, err := DeriveNotebookKey("wrong", testNotebookSalt)
+	require.NoError(t, err)
+	_, err = DecryptNotebookData(other, sealed)
+	assert.Error(t, err)
+}
+
+func TestDeriveNotebookKey(t *testing.T) {
+	raw := make([]byte, 32)
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+	key, err := DeriveNotebookKey(base64.StdEncoding.EncodeToString(raw), nil)
+	require.NoError(t, err)
+	assert.Equal(t, raw, key[:], "a base64 32-byte key is used as is")
+
+	a, err := DeriveNotebookKey("passphrase", testNotebookSalt)
+	require.NoError(t, err)
+	b, err := DeriveNotebookKey("passphrase", testNotebookSalt)
+	require.NoError(t, err)
+	assert.Equal(t, a, b, "passphrases derive deterministically")
+
+	c, err := DeriveNotebookKey("passphrase", []byte("another notebook"))
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c, "the salt changes the key")
+
+	_, err = DeriveNotebookKey("passphrase", nil)
+	assert.Error(t, err)
+}
+
+func TestNotebookKeySalt(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "nb")
+	salt, err := NotebookKeySalt(root)
+	require.NoError(t, err)
+	assert.Len(t, salt, notebookSaltSize)
+	assert.FileExists(t, filepath.Join(root, NotebookKeyHeaderFile))
+
+	again, err := NotebookKeySalt(root)
+	require.NoError(t, err)
+	assert.Equal(t, salt, again, "the stored salt is reused")
+
+	other, err := NotebookKeySalt(t.TempDir())
+	require.NoError(t, err)
+	assert.NotEqual(t, salt, other, "each notebook gets its own salt")
+}
+
+func TestNotebookKeySalt_MissingHeader(t *testing.T) {
+	root := t.TempDir()
+	salt, err := NotebookKeySalt(root)
+	require.NoError(t, err)
+	key, err := DeriveNotebookKey("passphrase", salt)
+	require.NoError(t, err)
+	sealed, err := EncryptNotebookData(key, []byte("secret"))
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "inbox"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", "note.md"), sealed, 0o644))
+
+	// A sync that drops the header must not mint a salt the existing
+	// files cannot be opened with.
+	require.NoError(t, os.Remove(filepath.Join(root, NotebookKeyHeaderFile)))
+	_, err = NotebookKeySalt(root)
+	assert.ErrorIs(t, err, ErrNotebookKeyHeaderMissing)
+	assert.NoFileExists(t, filepath.Join(root, NotebookKeyHeaderFile))
+}
+
+func encryptedNotebookConfig(t *testing.T, root string) *config.Config {
+	t.Helper()
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("notebook passphrase\n"), 0o600))
+	return &config.Config{
+		Notebooks: &config.NotebooksConfig{
+			Definitions: map[string]*config.Notebook{"nb": {
+				RootDir:    root,
+				Encryption: &config.NotebookEncryptionConfig{Enabled: true, KeyFile: keyFile},
+			}},
+			Rules: &config.NotebookRules{Default: "nb"},
+		},
+	}
+}
+
+func TestNotebookLocator_ReadWriteEncrypted(t *testing.T) {
+	t.Setenv(config.NotebookKeyEnvVar, "")
+	root := t.TempDir()
+	locator := NewNotebookLocator(encryptedNotebookConfig(t, root))
+	node := &WorkspaceNode{Name: "api", Path: "/code/api", NotebookName: "nb"}
+
+	path := filepath.Join(root, "note.md")
+	require.NoError(t, locator.WriteFile(node, path, []byte("private plan"), 0o644))
+
+	onDisk, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedNotebookData(onDisk))
+
+	got, err := locator.ReadFile(node, path)
+	require.NoError(t, err)
+	assert.Equal(t, "private plan", string(got))
+
+	// Plaintext files in an encrypted notebook are still readable.
+	plainPath := filepath.Join(root, "old.md")
+	writeNote(t, plainPath, "legacy")
+	got, err = locator.ReadFile(node, plainPath)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", string(got))
+
+	n, err := EncryptNotebookFiles(root, mustNotebookKey(t, locator))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, err = locator.ReadFile(node, plainPath)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", string(got))
+}
+
+func mustNotebookKey(t *testing.T, l *NotebookLocator) *NotebookKey {
+	t.Helper()
+	key, err := l.NotebookKey("nb")
+	require.NoError(t, err)
+	return key
+}
+
+func TestNotebookLocator_LockedNotebook(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	t.Setenv(config.NotebookKeyEnvVar, "")
+	root := t.TempDir()
+	cfg := &config.Config{
+		Notebooks: &config.NotebooksConfig{
+			Definitions: map[string]*config.Notebook{"nb": {
+				RootDir:    root,
+				Encryption: &config.NotebookEncryptionConfig{Enabled: true},
+			}},
+		},
+	}
+	node := &WorkspaceNode{Name: "api", NotebookName: "nb"}
+
+	salt, err := NotebookKeySalt(root)
+	require.NoError(t, err)
+	key, err := DeriveNotebookKey("unlock me", salt)
+	require.NoError(t, err)
+	sealed, err := EncryptNotebookData(key, []byte("hidden"))
+	require.NoError(t, err)
+	path := filepath.Join(root, "note.md")
+	require.NoError(t, os.WriteFile(path, sealed, 0o644))
+
+	_, err = NewNotebookLocator(cfg).ReadFile(node, path)
+	assert.ErrorIs(t, err, ErrNotebookLocked)
+
+	require.NoError(t, CheckNotebookKey(root, key))
+	require.NoError(t, UnlockNotebook("nb", key, time.Hour))
+	got, err := NewNotebookLocator(cfg).ReadFile(node, path)
+	require.NoError(t, err)
+	assert.Equal(t, "hidden", string(got))
+
+	require.NoError(t, LockNotebook("nb"))
+	_, err = NewNotebookLocator(cfg).ReadFile(node, path)
+	assert.ErrorIs(t, err, ErrNotebookLocked)
+
+	// Expired unlocks are ignored.
+	require.NoError(t, UnlockNotebook("nb", key, -time.Minute))
+	_, err = NewNotebookLocator(cfg).ReadFile(node, path)
+	assert.ErrorIs(t, err, ErrNotebookLocked)
+
+	// Without XDG_RUNTIME_DIR the key is not persisted anywhere.
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	assert.ErrorIs(t, UnlockNotebook("nb", key, time.Hour), ErrNoKeyRuntimeDir)
+	require.NoError(t, LockNotebook("nb"))
+}
+
+func TestNotebookLocator_SearchEncrypted(t *testing.T) {
+	t.Setenv(config.NotebookKeyEnvVar, "")
+	root := t.TempDir()
+	cfg := encryptedNotebookConfig(t, root)
+	locator := NewNotebookLocator(cfg)
+	node := &WorkspaceNode{Name: "api", Path: filepath.Join(root, "code", "api"), Kind: KindStandaloneProject, NotebookName: "nb"}
+	provider := NewProviderFromNodes([]*WorkspaceNode{node})
+
+	path := filepath.Join(root, "workspaces", "api", "inbox", "secret.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, locator.WriteFile(node, path, []byte("# Acquisition\n\nConfidential merger plans.\n"), 0o644))
+
+	indexPath := filepath.Join(t.TempDir(), "index.json")
+	results, err := locator.WithSearchIndex(indexPath).Search("merger", provider)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Acquisition", results[0].Title)
+	assert.Equal(t, "Confidential merger plans.", results[0].Snippet)
+
+	// Decrypted content never reaches the on-disk index.
+	index, err := os.ReadFile(indexPath)
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(index), "merger"))
+}
@@ -43,6 +43,11 @@ type ScannedDir struct {
 // The mode is determined by whether notebook.root_dir is configured.
 type NotebookLocator struct {
 	config *config.Config
+	// searchIndexPath overrides where Search keeps its index; see
+	// WithSearchIndex.
+	searchIndexPath *string
+	// keys caches the keys of encrypted notebooks; see NotebookKey.
+	keys *notebookKeyCache
 }
 
 // NewNotebookLocator creates a new locator. It gracefully handles a nil config.
@@ -55,6 +60,7 @@ func NewNotebookLocator(cfg *config.Config) *NotebookLocator {
 
 	return &NotebookLocator{
 		config: cfg,
+		keys:   &notebookKeyCache{},
 	}
 }
 
@@ -0,0 +1,449 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/util/frontmatter"
+)
+
+// Notebook content kinds reported by Search.
+const (
+	NotebookKindNote = "note"
+	NotebookKindPlan = "plan"
+	NotebookKindChat = "chat"
+)
+
+// notebookIndexVersion is bumped whenever indexedDoc or the tokenizer
+// changes, invalidating indexes written by older binaries.
+const notebookIndexVersion = 1
+
+// Field weights: a term in the title counts for more than one in the
+// frontmatter, which counts for more than one in the body.
+const (
+	titleWeight       = 5.0
+	frontmatterWeight = 2.0
+	bodyWeight        = 1.0
+)
+
+// NotebookSearchResult is one document matched by NotebookLocator.Search.
+type NotebookSearchResult struct {
+	Path      string         `json:"path"`
+	Kind      string         `json:"kind"`
+	Title     string         `json:"title"`
+	Workspace string         `json:"workspace"`
+	Score     float64        `json:"score"`
+	Snippet   string         `json:"snippet,omitempty"`
+	Modified  time.Time      `json:"modified"`
+	Owner     *WorkspaceNode `json:"-"`
+}
+
+// notebookIndex is the on-disk search index: term frequencies per document,
+// keyed by path. Entries are reused while a file's size and mtime are
+// unchanged, so repeat searches only re-read edited files.
+type notebookIndex struct {
+	Version int                    `json:"version"`
+	Docs    map[string]*indexedDoc `json:"docs"`
+}
+
+type indexedDoc struct {
+	Size       int64          `json:"size"`
+	ModTime    time.Time      `json:"mod_time"`
+	Title      string         `json:"title"`
+	TitleTerms map[string]int `json:"title_terms,omitempty"`
+	FrontTerms map[string]int `json:"front_terms,omitempty"`
+	BodyTerms  map[string]int `json:"body_terms,omitempty"`
+	// Private marks a document read from an encrypted file. It is searched
+	// but never written to the on-disk index.
+	Private bool `json:"-"`
+}
+
+// WithSearchIndex returns a copy of the locator that keeps its search index
+// at path. An empty path keeps the index in memory only.
+func (l *NotebookLocator) WithSearchIndex(path string) *NotebookLocator {
+	c := *l
+	c.searchIndexPath = &path
+	return &c
+}
+
+func (l *NotebookLocator) indexPath() string {
+	if l.searchIndexPath != nil {
+		return *l.searchIndexPath
+	}
+	return filepath.Join(paths.CacheDir(), "notebook-search-index.json")
+}
+
+// Search finds notes, plans and chats across every workspace in provider
+// whose title, frontmatter or body contain all of the terms in query. Terms
+// match case-insensitively, by prefix, and ignoring simple plural and tense
+// suffixes, so "retries" finds "retry" and "retr" finds both. Results are
+// ranked by a weighted term-frequency score, newest first on ties.
+func (l *NotebookLocator) Search(query string, provider *Provider) ([]NotebookSearchResult, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("workspace provider is required")
+	}
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query is empty")
+	}
+
+	files, err := l.collectNotebookFiles(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	index := loadNotebookIndex(l.indexPath())
+	fresh := make(map[string]*indexedDoc, len(files))
+	changed := len(index.Docs) != len(files)
+	for path, f := range files {
+		doc := index.Docs[path]
+		if doc == nil || doc.Size != f.info.Size() || !doc.ModTime.Equal(f.info.ModTime()) {
+			if doc, err = l.indexNotebookFile(f.owner, path, f.info); err != nil {
+				continue
+			}
+			changed = true
+		}
+		fresh[path] = doc
+	}
+	index.Docs = fresh
+	if changed {
+		// The index is a cache; failing to persist it only costs speed.
+		_ = saveNotebookIndex(l.indexPath(), index)
+	}
+
+	var results []NotebookSearchResult
+	for path, doc := range index.Docs {
+		score, ok := scoreDoc(doc, terms)
+		if !ok {
+			continue
+		}
+		if strings.Contains(strings.ToLower(doc.Title), strings.ToLower(strings.TrimSpace(query))) {
+			score += titleWeight
+		}
+		f := files[path]
+		results = append(results, NotebookSearchResult{
+			Path:      path,
+			Kind:      f.kind,
+			Title:     doc.Title,
+			Workspace: f.owner.Name,
+			Score:     math.Round(score*100) / 100,
+			Modified:  doc.ModTime,
+			Owner:     f.owner,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if !results[i].Modified.Equal(results[j].Modified) {
+			return results[i].Modified.After(results[j].Modified)
+		}
+		return results[i].Path < results[j].Path
+	})
+	for i := range results {
+		if data, err := l.ReadFile(results[i].Owner, results[i].Path); err == nil {
+			results[i].Snippet = snippetFor(data, terms)
+		}
+	}
+	return results, nil
+}
+
+// notebookFile is a markdown file found under a notebook content directory.
+type notebookFile struct {
+	kind  string
+	owner *WorkspaceNode
+	info  fs.FileInfo
+}
+
+// collectNotebookFiles walks every plans, chats and notes directory known to
+// the locator. Plans and chats are walked first so that, when the notes root
+// contains them (as in the centralized layout), their files keep their kind.
+func (l *NotebookLocator) collectNotebookFiles(provider *Provider) (map[string]notebookFile, error) {
+	files := make(map[string]notebookFile)
+	scans := []struct {
+		kind string
+		scan func(*Provider) ([]ScannedDir, error)
+	}{
+		{NotebookKindPlan, l.ScanForAllPlans},
+		{NotebookKindChat, l.ScanForAllChats},
+		{NotebookKindNote, l.ScanForAllNotes},
+	}
+	for _, s := range scans {
+		dirs, err := s.scan(provider)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			skip := l.nonContentDirs(dir.Owner)
+			_ = filepath.WalkDir(dir.Path, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() {
+					if path != dir.Path && (strings.HasPrefix(d.Name(), ".") || skip[path]) {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if filepath.Ext(path) != ".md" {
+					return nil
+				}
+				if _, seen := files[path]; seen {
+					return nil
+				}
+				info, err := d.Info()
+				if err != nil {
+					return nil
+				}
+				files[path] = notebookFile{kind: s.kind, owner: dir.Owner, info: info}
+				return nil
+			})
+		}
+	}
+	return files, nil
+}
+
+// nonContentDirs returns the notebook directories for owner that hold
+// tooling rather than notes (templates, recipes, skills, docgen, context),
+// which a walk of the notes root should not index.
+func (l *NotebookLocator) nonContentDirs(owner *WorkspaceNode) map[string]bool {
+	skip := make(map[string]bool)
+	for _, get := range []func(*WorkspaceNode) (string, error){
+		l.GetTemplatesDir,
+		l.GetRecipesDir,
+		l.GetSkillsDir,
+		l.GetPlaybooksDir,
+		l.GetDocgenDir,
+		l.GetContextDir,
+	} {
+		if dir, err := get(owner); err == nil {
+			skip[dir] = true
+		}
+	}
+	return skip
+}
+
+// indexNotebookFile reads path and records the term frequencies of its
+// title, frontmatter and body. Encrypted files are decrypted with their
+// notebook's key and indexed as private; those of a locked notebook fail.
+func (l *NotebookLocator) indexNotebookFile(owner *WorkspaceNode, path string, info fs.FileInfo) (*indexedDoc, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data := raw
+	if IsEncryptedNotebookData(raw) {
+		if data, err = l.ReadFile(owner, path); err != nil {
+			return nil, err
+		}
+	}
+	front, body := frontmatter.Split(string(data))
+
+	title := frontmatterValue(front, "title")
+	if title == "" {
+		for _, line := range strings.Split(body, "\n") {
+			if h, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+				title = strings.TrimSpace(h)
+				break
+			}
+		}
+	}
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(path), ".md")
+	}
+
+	return &indexedDoc{
+		Size:       info.Size(),
+		ModTime:    info.ModTime(),
+		Title:      title,
+		TitleTerms: termFrequencies(title),
+		FrontTerms: termFrequencies(front),
+		BodyTerms:  termFrequencies(body),
+		Private:    IsEncryptedNotebookData(raw),
+	}, nil
+}
+
+// frontmatterValue returns the scalar value of key in a frontmatter block.
+func frontmatterValue(front, key string) string {
+	for _, line := range strings.Split(front, "\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if ok && strings.TrimSpace(k) == key {
+			return strings.Trim(strings.TrimSpace(v), `"'`)
+		}
+	}
+	return ""
+}
+
+func termFrequencies(text string) map[string]int {
+	terms := tokenize(text)
+	if len(terms) == 0 {
+		return nil
+	}
+	freq := make(map[string]int, len(terms))
+	for _, t := range terms {
+		freq[t]++
+	}
+	return freq
+}
+
+// tokenize lowercases text, splits it on anything other than letters and
+// digits, and stems each word.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, w := range words {
+		if len(w) < 2 {
+			continue
+		}
+		terms = append(terms, stem(w))
+	}
+	return terms
+}
+
+// stem strips the common English plural and tense suffixes, enough for
+// "retries", "retried" and "retrying" to meet at "retry".
+func stem(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 4 && strings.HasSuffix(w, "ied"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 5 && strings.HasSuffix(w, "ing"):
+		return w[:len(w)-3]
+	case len(w) > 4 && strings.HasSuffix(w, "ed"):
+		return w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// scoreDoc returns doc's score for terms, or false unless every term
+// matches at least one field.
+func scoreDoc(doc *indexedDoc, terms []string) (float64, bool) {
+	var total float64
+	for _, term := range terms {
+		s := titleWeight*fieldScore(doc.TitleTerms, term) +
+			frontmatterWeight*fieldScore(doc.FrontTerms, term) +
+			bodyWeight*fieldScore(doc.BodyTerms, term)
+		if s == 0 {
+			return 0, false
+		}
+		total += s
+	}
+	return total, true
+}
+
+// fieldScore sums a damped frequency over the field's terms that start with
+// term.
+func fieldScore(field map[string]int, term string) float64 {
+	var s float64
+	for t, n := range field {
+		if strings.HasPrefix(t, term) {
+			s += 1 + math.Log(float64(n))
+		}
+	}
+	return s
+}
+
+// snippetFor returns the first body line of content containing a query
+// term, trimmed to a single short line.
+func snippetFor(content []byte, terms []string) string {
+	const maxLen = 120
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	inFront := false
+	for lineNo := 0; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "---" && (lineNo == 0 || inFront) {
+			inFront = !inFront
+			continue
+		}
+		if inFront || line == "" {
+			continue
+		}
+		for _, t := range tokenize(line) {
+			if matchesAny(t, terms) {
+				if r := []rune(line); len(r) > maxLen {
+					line = string(r[:maxLen-1]) + "…"
+				}
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+func matchesAny(t string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(t, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func loadNotebookIndex(path string) *notebookIndex {
+	index := &notebookIndex{Version: notebookIndexVersion, Docs: map[string]*indexedDoc{}}
+	if path == "" {
+		return index
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return index
+	}
+	var loaded notebookIndex
+	if json.Unmarshal(data, &loaded) != nil || loaded.Version != notebookIndexVersion || loaded.Docs == nil {
+		return index
+	}
+	return &loaded
+}
+
+// saveNotebookIndex writes index atomically so concurrent searches never
+// read a torn file. Private documents are left out.
+func saveNotebookIndex(path string, index *notebookIndex) error {
+	if path == "" {
+		return nil
+	}
+	public := &notebookIndex{Version: index.Version, Docs: make(map[string]*indexedDoc, len(index.Docs))}
+	for p, doc := range index.Docs {
+		if !doc.Private {
+			public.Docs[p] = doc
+		}
+	}
+	index = public
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".notebook-index-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,90 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/core/config"
+)
+
+func writeNote(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestNotebookLocator_Search(t *testing.T) {
+	root := t.TempDir()
+	cfg := &config.Config{
+		Notebooks: &config.NotebooksConfig{
+			Definitions: map[string]*config.Notebook{"nb": {RootDir: root}},
+			Rules:       &config.NotebookRules{Default: "nb"},
+		},
+	}
+	provider := NewProviderFromNodes([]*WorkspaceNode{
+		{Name: "api", Path: filepath.Join(root, "code", "api"), Kind: KindStandaloneProject, NotebookName: "nb"},
+		{Name: "web", Path: filepath.Join(root, "code", "web"), Kind: KindStandaloneProject, NotebookName: "nb"},
+	})
+
+	ws := filepath.Join(root, "workspaces")
+	writeNote(t, filepath.Join(ws, "api", "plans", "http-client", "01-spec.md"),
+		"---\ntitle: HTTP client retries\nstatus: pending\n---\n\nRetry with backoff on 5xx.\n")
+	writeNote(t, filepath.Join(ws, "web", "inbox", "meeting.md"),
+		"# Standup\n\nWe discussed the retry budget for the web client.\n")
+	writeNote(t, filepath.Join(ws, "web", "chats", "chat.md"),
+		"---\ntitle: Pairing\ntags: [retrying]\n---\nnothing else here\n")
+	writeNote(t, filepath.Join(ws, "api", "inbox", "unrelated.md"), "# Lunch\n\nTacos.\n")
+	// Templates live under the notes root but are not notes.
+	writeNote(t, filepath.Join(ws, "api", "templates", "plan.md"), "# Retry template\n")
+
+	indexPath := filepath.Join(t.TempDir(), "index.json")
+	locator := NewNotebookLocator(cfg).WithSearchIndex(indexPath)
+
+	results, err := locator.Search("retries", provider)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// The title match ranks first.
+	assert.Equal(t, "HTTP client retries", results[0].Title)
+	assert.Equal(t, NotebookKindPlan, results[0].Kind)
+	assert.Equal(t, "api", results[0].Workspace)
+	assert.Equal(t, "Retry with backoff on 5xx.", results[0].Snippet)
+
+	byTitle := map[string]NotebookSearchResult{}
+	for _, r := range results {
+		byTitle[r.Title] = r
+	}
+	assert.Equal(t, NotebookKindNote, byTitle["Standup"].Kind)
+	assert.Equal(t, "We discussed the retry budget for the web client.", byTitle["Standup"].Snippet)
+	assert.Equal(t, NotebookKindChat, byTitle["Pairing"].Kind)
+
+	// All terms must match.
+	results, err = locator.Search("retry budget", provider)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Standup", results[0].Title)
+
+	// The index is persisted and picks up edits.
+	_, err = os.Stat(indexPath)
+	require.NoError(t, err)
+	lunch := filepath.Join(ws, "api", "inbox", "unrelated.md")
+	writeNote(t, lunch, "# Lunch\n\nTacos, and a retry of the order.\n")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(lunch, future, future))
+	results, err = locator.Search("retry", provider)
+	require.NoError(t, err)
+	assert.Len(t, results, 4)
+
+	_, err = locator.Search("  ", provider)
+	assert.Error(t, err)
+}
+
+func TestNotebookTokenize(t *testing.T) {
+	assert.Equal(t, []string{"retry", "retry", "retry", "backoff", "config"},
+		tokenize("Retries, retried; RETRYING backoff configs"))
+}
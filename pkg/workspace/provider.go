@@ -101,6 +101,7 @@ func sortNodesDeterministic(nodes []*WorkspaceNode) {
 type Provider struct {
 	nodes   []*WorkspaceNode
 	pathMap map[string]*WorkspaceNode
+	index   providerIndex
 }
 
 // NewProvider creates a new workspace provider from a discovery result.
@@ -123,18 +124,10 @@ func NewProvider(result *DiscoveryResult) *Provider {
 // This is useful when reusing already-discovered workspace data (e.g., from daemon cache)
 // to avoid expensive re-discovery and path normalization.
 func NewProviderFromNodes(nodes []*WorkspaceNode) *Provider {
-	pathMap := make(map[string]*WorkspaceNode, len(nodes))
-	for _, node := range nodes {
-		// Normalize the path for the lookup map.
-		normalizedPath, err := pathutil.NormalizeForLookup(node.Path)
-		if err == nil {
-			pathMap[normalizedPath] = node
-		}
-	}
-
 	p := &Provider{
 		nodes:   nodes,
-		pathMap: pathMap,
+		pathMap: buildPathMap(nodes),
+		index:   newProviderIndex(nodes),
 	}
 
 	// p.validateAndWarnCollisions()
@@ -156,17 +149,10 @@ func (p *Provider) All() []*WorkspaceNode {
 // applies the same canonical-first, shallowest-then-lexicographic ordering as
 // FindByIdentifier's fallback. It returns nil if no matching workspace is found.
 func (p *Provider) FindByName(name string) *WorkspaceNode {
-	var matches []*WorkspaceNode
-	for _, node := range p.nodes {
-		if node.Name == name {
-			matches = append(matches, node)
-		}
+	if matches := p.index.byName[name]; len(matches) > 0 {
+		return matches[0]
 	}
-	if len(matches) == 0 {
-		return nil
-	}
-	sortNodesDeterministic(matches)
-	return matches[0]
+	return nil
 }
 
 // FindSubProjectByName returns the CANONICAL sub-project named name within the
@@ -221,7 +207,7 @@ func (p *Provider) FindSubProjectByName(name, ecosystemRoot string) *WorkspaceNo
 	// via normalized paths so symlink/case spellings agree with discovery.
 	wantPath := filepath.Join(ecosystemRoot, name)
 	normalizedWant, werr := pathutil.NormalizeForLookup(wantPath)
-	for _, node := range p.nodes {
+	for _, node := range p.index.byName[name] {
 		if !canonical(node) {
 			continue
 		}
@@ -236,7 +222,7 @@ func (p *Provider) FindSubProjectByName(name, ecosystemRoot string) *WorkspaceNo
 	}
 
 	// Rule 2: any canonical-eligible direct child of this ecosystem root.
-	for _, node := range p.nodes {
+	for _, node := range p.index.byName[name] {
 		if !canonical(node) {
 			continue
 		}
@@ -248,8 +234,9 @@ func (p *Provider) FindSubProjectByName(name, ecosystemRoot string) *WorkspaceNo
 	return nil
 }
 
-// FindByPath returns the WorkspaceNode for a given absolute path.
-// It performs a fast lookup using an internal map.
+// FindByPath returns the WorkspaceNode for a given absolute path, or the
+// innermost workspace containing it. It looks up the normalized path and
+// then each of its parents in an internal map.
 func (p *Provider) FindByPath(path string) *WorkspaceNode {
 	normalizedPath, err := pathutil.NormalizeForLookup(path)
 	if err != nil {
@@ -257,25 +244,7 @@ func (p *Provider) FindByPath(path string) *WorkspaceNode {
 		return p.pathMap[path]
 	}
 
-	// First, try an exact match with the normalized path.
-	if node, exists := p.pathMap[normalizedPath]; exists {
-		return node
-	}
-
-	// If no exact match, find the containing workspace using pre-normalized paths from pathMap.
-	// The pathMap keys are already normalized, so we avoid expensive re-normalization.
-	var bestMatch *WorkspaceNode
-	var bestMatchLen int
-	for normalizedNodePath, node := range p.pathMap {
-		// Check if the normalized node's path is a prefix of the normalized search path.
-		if strings.HasPrefix(normalizedPath, normalizedNodePath+string(filepath.Separator)) {
-			if bestMatch == nil || len(normalizedNodePath) > bestMatchLen {
-				bestMatch = node
-				bestMatchLen = len(normalizedNodePath)
-			}
-		}
-	}
-	return bestMatch
+	return findContaining(p.pathMap, normalizedPath)
 }
 
 // FindByWorktree finds a workspace node for a worktree within an ecosystem.
@@ -305,8 +274,8 @@ func (p *Provider) FindByWorktree(baseProjectNode *WorkspaceNode, worktreeName s
 	// e.g., grove-mcp/.grove-worktrees/1986
 	if !baseProjectNode.IsEcosystem() {
 		// Prefer the discovered node index: it is layout-independent.
-		for _, node := range p.nodes {
-			if node.IsWorktree() && node.Name == worktreeName && node.ParentProjectPath == baseProjectNode.Path {
+		for _, node := range p.index.byParentProject[baseProjectNode.Path] {
+			if node.IsWorktree() && node.Name == worktreeName {
 				return node
 			}
 		}
@@ -322,9 +291,9 @@ func (p *Provider) FindByWorktree(baseProjectNode *WorkspaceNode, worktreeName s
 	// Case 2: If base is ecosystem root, search for worktree in any subproject
 	// This handles jobs stored at ecosystem level that reference subproject worktrees
 	if baseProjectNode.IsEcosystem() {
-		for _, node := range p.nodes {
+		for _, node := range p.index.byName[worktreeName] {
 			// Check if this is a worktree with the matching name in this ecosystem
-			if node.IsWorktree() && node.Name == worktreeName && node.RootEcosystemPath == ecosystemPath {
+			if node.IsWorktree() && node.RootEcosystemPath == ecosystemPath {
 				return node
 			}
 		}
@@ -335,8 +304,8 @@ func (p *Provider) FindByWorktree(baseProjectNode *WorkspaceNode, worktreeName s
 	if !baseProjectNode.IsEcosystem() {
 		// Prefer the discovered node index: find the ecosystem worktree by
 		// name, then the matching subproject inside it.
-		for _, node := range p.nodes {
-			if node.Kind == KindEcosystemWorktree && node.Name == worktreeName &&
+		for _, node := range p.index.byName[worktreeName] {
+			if node.Kind == KindEcosystemWorktree &&
 				(node.RootEcosystemPath == ecosystemPath || node.ParentEcosystemPath == ecosystemPath) {
 				targetPath := filepath.Join(node.Path, baseProjectNode.Name)
 				if sub := p.FindByPath(targetPath); sub != nil && sub.Path == targetPath {
@@ -387,26 +356,17 @@ func (p *Provider) FindByIdentifierWithInfo(identifier, currentPath string) (*Wo
 
 	// 1. For multi-component identifiers, try exact fully qualified match
 	if len(components) > 1 {
-		for _, node := range p.nodes {
-			if node.Identifier(":") == identifier {
-				return node, ExactIdentifier
-			}
+		if node, ok := p.index.byIdentifier[identifier]; ok {
+			return node, ExactIdentifier
 		}
 	}
 
 	// 2. Try matching against node names for short aliases (e.g. "cx")
 	if len(components) == 1 {
-		name := identifier
-		var matches []*WorkspaceNode
-		for _, node := range p.nodes {
-			if node.Name == name {
-				matches = append(matches, node)
-			}
-		}
-
-		// Sort candidates deterministically so every "return first match" branch
-		// below (context priorities and the final fallback) is reproducible.
-		sortNodesDeterministic(matches)
+		// Candidates are indexed in deterministic order, so every "return
+		// first match" branch below (context priorities and the final
+		// fallback) is reproducible.
+		matches := p.index.byName[identifier]
 
 		if len(matches) == 1 {
 			return matches[0], MatchedUnique
@@ -459,8 +419,9 @@ func (p *Provider) FindByIdentifierWithInfo(identifier, currentPath string) (*Wo
 
 	// 3. Try partial identifier matching for multi-component aliases
 	// e.g., "eco-worktree:project" should match nodes where the last components match
-	for _, node := range p.nodes {
-		nodeID := node.Identifier(":")
+	// Only nodes whose identifier ends in the same component can match.
+	for _, cand := range p.index.byLastComponent[components[len(components)-1]] {
+		node, nodeID := cand.node, cand.id
 		// Check if identifier matches a suffix of the node's full identifier
 		if strings.HasSuffix(nodeID, identifier) {
 			// Verify it's a clean component boundary (preceded by ":" or is the full string)
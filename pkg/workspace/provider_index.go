@@ -0,0 +1,158 @@
+package workspace
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// providerIndex holds the lookup tables a Provider builds once from its
+// nodes, so name, parent and identifier lookups do not scan every node.
+type providerIndex struct {
+	// byName lists the nodes sharing a name, sorted by
+	// sortNodesDeterministic so the first entry is the canonical checkout.
+	byName map[string][]*WorkspaceNode
+	// byLowerName groups names case-insensitively for Fuzzy.
+	byLowerName map[string][]string
+	// byParentProject lists the worktrees of a project, keyed by the
+	// project's path, in discovery order.
+	byParentProject map[string][]*WorkspaceNode
+	// byIdentifier maps a node's ":" identifier to the first node in
+	// discovery order that has it.
+	byIdentifier map[string]*WorkspaceNode
+	// byLastComponent lists nodes by the last component of their ":"
+	// identifier, in discovery order, for suffix matching.
+	byLastComponent map[string][]identifiedNode
+}
+
+// identifiedNode pairs a node with its precomputed ":" identifier.
+type identifiedNode struct {
+	node *WorkspaceNode
+	id   string
+}
+
+// buildPathMap maps each node's normalized path to the node. Nodes whose
+// path cannot be normalized are left out.
+func buildPathMap(nodes []*WorkspaceNode) map[string]*WorkspaceNode {
+	pathMap := make(map[string]*WorkspaceNode, len(nodes))
+	for _, node := range nodes {
+		if normalizedPath, err := pathutil.NormalizeForLookup(node.Path); err == nil {
+			pathMap[normalizedPath] = node
+		}
+	}
+	return pathMap
+}
+
+func newProviderIndex(nodes []*WorkspaceNode) providerIndex {
+	idx := providerIndex{
+		byName:          make(map[string][]*WorkspaceNode),
+		byLowerName:     make(map[string][]string),
+		byParentProject: make(map[string][]*WorkspaceNode),
+		byIdentifier:    make(map[string]*WorkspaceNode, len(nodes)),
+		byLastComponent: make(map[string][]identifiedNode),
+	}
+	for _, node := range nodes {
+		if _, seen := idx.byName[node.Name]; !seen {
+			lower := strings.ToLower(node.Name)
+			idx.byLowerName[lower] = append(idx.byLowerName[lower], node.Name)
+		}
+		idx.byName[node.Name] = append(idx.byName[node.Name], node)
+		if node.ParentProjectPath != "" {
+			idx.byParentProject[node.ParentProjectPath] = append(idx.byParentProject[node.ParentProjectPath], node)
+		}
+
+		id := node.Identifier(":")
+		if _, exists := idx.byIdentifier[id]; !exists {
+			idx.byIdentifier[id] = node
+		}
+		last := id[strings.LastIndex(id, ":")+1:]
+		idx.byLastComponent[last] = append(idx.byLastComponent[last], identifiedNode{node: node, id: id})
+	}
+	for _, matches := range idx.byName {
+		sortNodesDeterministic(matches)
+	}
+	return idx
+}
+
+// findContaining returns the node whose path is normalizedPath or its
+// nearest ancestor, walking up one directory at a time.
+func findContaining(pathMap map[string]*WorkspaceNode, normalizedPath string) *WorkspaceNode {
+	for dir := normalizedPath; ; {
+		if node, ok := pathMap[dir]; ok {
+			return node
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// Fuzzy returns the nodes whose name matches query case-insensitively,
+// best match first: exact names, then names starting with query, then
+// names containing it, then names containing its characters in order.
+// Within a tier shorter names come first and nodes sharing a name keep
+// FindByName's canonical-first order. It returns nil for an empty query.
+func (p *Provider) Fuzzy(query string) []*WorkspaceNode {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	type candidate struct {
+		lower string
+		tier  int
+	}
+	var candidates []candidate
+	for lower := range p.index.byLowerName {
+		if tier, ok := fuzzyTier(lower, query); ok {
+			candidates = append(candidates, candidate{lower: lower, tier: tier})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.tier != b.tier {
+			return a.tier < b.tier
+		}
+		if len(a.lower) != len(b.lower) {
+			return len(a.lower) < len(b.lower)
+		}
+		return a.lower < b.lower
+	})
+
+	var result []*WorkspaceNode
+	for _, c := range candidates {
+		names := append([]string(nil), p.index.byLowerName[c.lower]...)
+		sort.Strings(names)
+		for _, name := range names {
+			result = append(result, p.index.byName[name]...)
+		}
+	}
+	return result
+}
+
+// fuzzyTier ranks how name (lowercased) matches query: 0 exact, 1 prefix,
+// 2 substring, 3 subsequence.
+func fuzzyTier(name, query string) (int, bool) {
+	switch {
+	case name == query:
+		return 0, true
+	case strings.HasPrefix(name, query):
+		return 1, true
+	case strings.Contains(name, query):
+		return 2, true
+	}
+	rest := query
+	for _, r := range name {
+		if rest == "" {
+			break
+		}
+		if strings.HasPrefix(rest, string(r)) {
+			rest = rest[len(string(r)):]
+		}
+	}
+	return 3, rest == ""
+}
@@ -0,0 +1,169 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// benchEcosystem builds an ecosystem of projects sub-projects, each with
+// worktrees worktrees, plus the ecosystem root: 1+projects*(1+worktrees)
+// nodes.
+func benchEcosystem(root string, projects, worktrees int) []*WorkspaceNode {
+	nodes := []*WorkspaceNode{{Name: filepath.Base(root), Path: root, Kind: KindEcosystemRoot}}
+	for i := 0; i < projects; i++ {
+		projPath := filepath.Join(root, fmt.Sprintf("project-%04d", i))
+		nodes = append(nodes, &WorkspaceNode{
+			Name:                filepath.Base(projPath),
+			Path:                projPath,
+			Kind:                KindEcosystemSubProject,
+			ParentEcosystemPath: root,
+			RootEcosystemPath:   root,
+		})
+		for j := 0; j < worktrees; j++ {
+			wtName := fmt.Sprintf("feature-%d", j)
+			nodes = append(nodes, &WorkspaceNode{
+				Name:                wtName,
+				Path:                filepath.Join(projPath, ".grove-worktrees", wtName),
+				Kind:                KindEcosystemSubProjectWorktree,
+				ParentProjectPath:   projPath,
+				ParentEcosystemPath: root,
+				RootEcosystemPath:   root,
+			})
+		}
+	}
+	return nodes
+}
+
+func TestProvider_FindByPath_NearestAncestor(t *testing.T) {
+	root := resolveDir(t.TempDir())
+	p := NewProviderFromNodes(benchEcosystem(root, 3, 2))
+
+	wt := filepath.Join(root, "project-0001", ".grove-worktrees", "feature-1")
+	node := p.FindByPath(filepath.Join(wt, "pkg", "deep", "file.go"))
+	require.NotNil(t, node)
+	assert.Equal(t, wt, node.Path)
+
+	node = p.FindByPath(filepath.Join(root, "project-0002", "README.md"))
+	require.NotNil(t, node)
+	assert.Equal(t, "project-0002", node.Name)
+
+	assert.Equal(t, root, p.FindByPath(filepath.Join(root, "unknown")).Path)
+	assert.Nil(t, p.FindByPath(filepath.Dir(root)))
+}
+
+func TestProvider_FindByWorktree_UsesParentIndex(t *testing.T) {
+	root := resolveDir(t.TempDir())
+	p := NewProviderFromNodes(benchEcosystem(root, 3, 2))
+
+	base := p.FindByName("project-0002")
+	require.NotNil(t, base)
+	node := p.FindByWorktree(base, "feature-1")
+	require.NotNil(t, node)
+	assert.Equal(t, filepath.Join(root, "project-0002", ".grove-worktrees", "feature-1"), node.Path)
+
+	assert.Nil(t, p.FindByWorktree(base, "feature-9"))
+}
+
+func TestProvider_Fuzzy(t *testing.T) {
+	p := NewProviderFromNodes([]*WorkspaceNode{
+		{Name: "grove-core", Path: "/w/grove-core", Kind: KindStandaloneProject},
+		{Name: "core", Path: "/w/core", Kind: KindStandaloneProject},
+		{Name: "Core-Utils", Path: "/w/core-utils", Kind: KindStandaloneProject},
+		{Name: "cx", Path: "/w/cx", Kind: KindStandaloneProject},
+		{Name: "core", Path: "/w/core/.grove-worktrees/core", Kind: KindStandaloneProjectWorktree, ParentProjectPath: "/w/core"},
+		{Name: "flow", Path: "/w/flow", Kind: KindStandaloneProject},
+	})
+
+	var paths []string
+	for _, n := range p.Fuzzy("CORE") {
+		paths = append(paths, n.Path)
+	}
+	assert.Equal(t, []string{
+		"/w/core", // exact, canonical checkout before its worktree
+		"/w/core/.grove-worktrees/core",
+		"/w/core-utils", // prefix
+		"/w/grove-core", // substring
+	}, paths)
+
+	sub := p.Fuzzy("gcr")
+	require.Len(t, sub, 1)
+	assert.Equal(t, "grove-core", sub[0].Name)
+
+	assert.Nil(t, p.Fuzzy(""))
+	assert.Empty(t, p.Fuzzy("zzz"))
+}
+
+func TestProvider_FindByIdentifier_SuffixIndex(t *testing.T) {
+	root := resolveDir(t.TempDir())
+	p := NewProviderFromNodes(benchEcosystem(root, 3, 2))
+
+	node, reason := p.FindByIdentifierWithInfo("project-0001:feature-0", "")
+	require.NotNil(t, node)
+	assert.Equal(t, MatchedBySuffix, reason)
+	assert.Equal(t, filepath.Join(root, "project-0001", ".grove-worktrees", "feature-0"), node.Path)
+
+	full := node.Identifier(":")
+	node, reason = p.FindByIdentifierWithInfo(full, "")
+	require.NotNil(t, node)
+	assert.Equal(t, ExactIdentifier, reason)
+}
+
+func benchProvider(b *testing.B) (*Provider, string) {
+	root := resolveDir(b.TempDir())
+	return NewProviderFromNodes(benchEcosystem(root, 400, 3)), root
+}
+
+func BenchmarkNewProviderFromNodes(b *testing.B) {
+	root := resolveDir(b.TempDir())
+	nodes := benchEcosystem(root, 400, 3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewProviderFromNodes(nodes)
+	}
+}
+
+func BenchmarkProviderFindByName(b *testing.B) {
+	p, _ := benchProvider(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.FindByName("project-0399")
+	}
+}
+
+func BenchmarkProviderFindByPath(b *testing.B) {
+	p, root := benchProvider(b)
+	target := filepath.Join(root, "project-0399", ".grove-worktrees", "feature-2", "pkg", "file.go")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.FindByPath(target)
+	}
+}
+
+func BenchmarkProviderFindByWorktree(b *testing.B) {
+	p, _ := benchProvider(b)
+	base := p.FindByName("project-0399")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.FindByWorktree(base, "feature-2")
+	}
+}
+
+func BenchmarkProviderFindByIdentifier(b *testing.B) {
+	p, _ := benchProvider(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.FindByIdentifier("project-0399:feature-2", "")
+	}
+}
+
+func BenchmarkProviderFuzzy(b *testing.B) {
+	p, _ := benchProvider(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Fuzzy("prj399")
+	}
+}
@@ -0,0 +1,144 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+// DefaultRemoteCommand is run over ssh for an ssh:// grove that sets no
+// command. --local keeps the host from following its own ssh:// groves.
+const DefaultRemoteCommand = "core ws --json --local"
+
+// remoteDiscoveryTimeout bounds the discovery of one ssh:// grove.
+const remoteDiscoveryTimeout = 30 * time.Second
+
+// RemoteGrove is a grove whose path is an ssh:// URL,
+// ssh://[user@]host[:port][/path]: its workspaces are discovered by running
+// Command on the host, which prints them as `core ws --json` does.
+type RemoteGrove struct {
+	// Name is the grove's key in the groves map.
+	Name string
+	// Host is the host name, as set on the workspaces found.
+	Host string
+	// Target is the [user@]host argument passed to ssh.
+	Target string
+	// Port is the ssh port, when the URL sets one.
+	Port string
+	// Path limits the grove to workspaces at or under it on the host.
+	// Empty or "/" keeps every workspace the host reports.
+	Path    string
+	Command string
+}
+
+// IsRemoteGrovePath reports whether a grove path is an ssh:// URL.
+func IsRemoteGrovePath(p string) bool {
+	return strings.HasPrefix(p, "ssh://")
+}
+
+// ParseRemoteGrove parses the ssh:// path of the grove named name.
+func ParseRemoteGrove(name string, cfg config.GroveSourceConfig) (RemoteGrove, error) {
+	u, err := url.Parse(cfg.Path)
+	if err != nil {
+		return RemoteGrove{}, fmt.Errorf("invalid path for grove '%s': %w", name, err)
+	}
+	if u.Scheme != "ssh" || u.Hostname() == "" {
+		return RemoteGrove{}, fmt.Errorf("invalid path for grove '%s': %q is not ssh://[user@]host[:port]/path", name, cfg.Path)
+	}
+	g := RemoteGrove{
+		Name:    name,
+		Host:    u.Hostname(),
+		Target:  u.Hostname(),
+		Port:    u.Port(),
+		Path:    strings.TrimSuffix(path.Clean("/"+u.Path), "/"),
+		Command: cfg.Command,
+	}
+	if u.User != nil {
+		g.Target = u.User.Username() + "@" + g.Target
+	}
+	// ssh would parse a target starting with '-' as an option, such as
+	// -oProxyCommand=<command>.
+	if strings.HasPrefix(g.Target, "-") || strings.HasPrefix(g.Host, "-") {
+		return RemoteGrove{}, fmt.Errorf("invalid path for grove '%s': user and host must not start with '-'", name)
+	}
+	if g.Command == "" {
+		g.Command = DefaultRemoteCommand
+	}
+	return g, nil
+}
+
+// sshArgs are the arguments of the ssh invocation that runs the command.
+// BatchMode makes a host that would prompt for a password fail instead,
+// and "--" ends ssh's options before the target.
+func (g RemoteGrove) sshArgs() []string {
+	args := []string{"-o", "BatchMode=yes"}
+	if g.Port != "" {
+		args = append(args, "-p", g.Port)
+	}
+	return append(args, "--", g.Target, g.Command)
+}
+
+// DiscoverRemote runs the grove's command on its host over ssh and returns
+// the workspaces under its path, each with Host set.
+func DiscoverRemote(ctx context.Context, g RemoteGrove) (_ []WorkspaceNode, err error) {
+	stop := timer.Start("discover.remote")
+	defer func() { stop(err) }()
+
+	cmd := exec.CommandContext(ctx, "ssh", g.sshArgs()...) //nolint:gosec // host and command from trusted grove config
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("ssh %s: %w: %s", g.Target, err, msg)
+		}
+		return nil, fmt.Errorf("ssh %s: %w", g.Target, err)
+	}
+	return parseRemoteNodes(out, g)
+}
+
+// parseRemoteNodes decodes `core ws --json` output and keeps the workspaces
+// under the grove's path.
+func parseRemoteNodes(out []byte, g RemoteGrove) ([]WorkspaceNode, error) {
+	var nodes []WorkspaceNode
+	if err := json.Unmarshal(out, &nodes); err != nil {
+		return nil, fmt.Errorf("could not read workspaces from %s: %w", g.Host, err)
+	}
+	kept := nodes[:0]
+	for _, n := range nodes {
+		if g.Path != "" && n.Path != g.Path && !strings.HasPrefix(n.Path, g.Path+"/") {
+			continue
+		}
+		n.Host = g.Host
+		kept = append(kept, n)
+	}
+	return kept, nil
+}
+
+// remoteWorkspaceNodes builds the display tree of each host's workspaces,
+// in host order. Hosts are kept apart because their paths can repeat the
+// local machine's and each other's.
+func remoteWorkspaceNodes(remote []WorkspaceNode) []*WorkspaceNode {
+	var hosts []string
+	byHost := make(map[string][]*WorkspaceNode)
+	for i := range remote {
+		n := &remote[i]
+		if _, ok := byHost[n.Host]; !ok {
+			hosts = append(hosts, n.Host)
+		}
+		byHost[n.Host] = append(byHost[n.Host], n)
+	}
+	var out []*WorkspaceNode
+	for _, host := range hosts {
+		out = append(out, BuildWorkspaceTree(byHost[host])...)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestParseRemoteGrove(t *testing.T) {
+	g, err := ParseRemoteGrove("devbox", config.GroveSourceConfig{Path: "ssh://me@devbox.lan:2222/home/me/code/"})
+	require.NoError(t, err)
+	assert.Equal(t, RemoteGrove{
+		Name:    "devbox",
+		Host:    "devbox.lan",
+		Target:  "me@devbox.lan",
+		Port:    "2222",
+		Path:    "/home/me/code",
+		Command: DefaultRemoteCommand,
+	}, g)
+	assert.Equal(t, []string{"-o", "BatchMode=yes", "-p", "2222", "--", "me@devbox.lan", DefaultRemoteCommand}, g.sshArgs())
+
+	g, err = ParseRemoteGrove("all", config.GroveSourceConfig{Path: "ssh://devbox", Command: "~/bin/core ws --json --local"})
+	require.NoError(t, err)
+	assert.Equal(t, "", g.Path, "no path keeps every workspace")
+	assert.Equal(t, "~/bin/core ws --json --local", g.Command)
+
+	_, err = ParseRemoteGrove("bad", config.GroveSourceConfig{Path: "ssh:///home/me"})
+	assert.Error(t, err, "a URL without a host is rejected")
+
+	_, err = ParseRemoteGrove("bad", config.GroveSourceConfig{Path: "ssh://-oProxyCommand=id@host/code"})
+	assert.Error(t, err, "a user that ssh would read as an option is rejected")
+	_, err = ParseRemoteGrove("bad", config.GroveSourceConfig{Path: "ssh://-oProxyCommand=id/code"})
+	assert.Error(t, err, "a host that ssh would read as an option is rejected")
+}
+
+func TestDiscoverAllRemoteGroves(t *testing.T) {
+	rootDir := resolveDir(t.TempDir())
+	homeDir := filepath.Join(rootDir, "home")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("HOME", homeDir)
+	t.Setenv("GROVE_CONFIG_OVERLAY", filepath.Join(homeDir, ".config", "grove", "grove.yml"))
+
+	// A fake ssh that records its arguments and prints what the host's
+	// `core ws --json` would.
+	binDir := filepath.Join(rootDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0o755))
+	argsFile := filepath.Join(rootDir, "ssh-args")
+	remoteJSON := `[
+  {"schema_version":1,"name":"api","path":"/srv/code/api","kind":"StandaloneProject"},
+  {"schema_version":1,"name":"feat","path":"/srv/code/api/.grove-worktrees/feat","kind":"StandaloneProjectWorktree","parent_project_path":"/srv/code/api"},
+  {"schema_version":1,"name":"scratch","path":"/tmp/scratch","kind":"StandaloneProject"}
+]`
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat <<'EOF'\n" + remoteJSON + "\nEOF\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "ssh"), []byte(script), 0o755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	emptyStr := ""
+	globalCfg := config.Config{
+		Groves: map[string]config.GroveSourceConfig{
+			"devbox": {Path: "ssh://devbox/srv/code"},
+		},
+		Context: &config.ContextConfig{ReposDir: &emptyStr},
+	}
+	globalBytes, err := yaml.Marshal(globalCfg)
+	require.NoError(t, err)
+	globalConfigDir := filepath.Join(homeDir, ".config", "grove")
+	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(globalConfigDir, "grove.yml"), globalBytes, 0o644))
+
+	result, err := NewDiscoveryService(nil).DiscoverAll()
+	require.NoError(t, err)
+	assert.Empty(t, result.Remote, "ssh:// groves are skipped without WithRemote")
+	_, statErr := os.Stat(argsFile)
+	assert.True(t, os.IsNotExist(statErr), "ssh should not run without WithRemote")
+
+	result, err = NewDiscoveryService(nil).WithRemote().DiscoverAll()
+	require.NoError(t, err)
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "-o BatchMode=yes -- devbox "+DefaultRemoteCommand, strings.TrimSpace(string(args)))
+
+	require.Len(t, result.Remote, 2, "workspaces outside the grove path are dropped")
+	for _, n := range result.Remote {
+		assert.Equal(t, "devbox", n.Host)
+	}
+	assert.Empty(t, result.Projects, "remote workspaces are not local projects")
+
+	nodes := remoteWorkspaceNodes(result.Remote)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "api", nodes[0].Name)
+	assert.Equal(t, "feat", nodes[1].Name)
+	assert.NotEmpty(t, nodes[1].TreePrefix, "the worktree is drawn under its project")
+}
@@ -0,0 +1,119 @@
+package workspace
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// isBareRepo reports whether path is a bare git repository: a git directory
+// (HEAD, objects/, refs/) with no working tree of its own.
+func isBareRepo(path string) bool {
+	if filepath.Base(path) == ".git" {
+		return false
+	}
+	if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+		return false
+	}
+	if info, err := os.Stat(filepath.Join(path, "HEAD")); err != nil || info.IsDir() {
+		return false
+	}
+	for _, dir := range []string{"objects", "refs"} {
+		if info, err := os.Stat(filepath.Join(path, dir)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
+// submoduleSuperproject returns the working tree containing path when path
+// is a submodule checkout, or "" otherwise. Submodule checkouts have a .git
+// file pointing into the superproject's .git/modules directory; linked
+// worktrees point into .git/worktrees instead and are not submodules.
+func submoduleSuperproject(path string) string {
+	data, err := os.ReadFile(filepath.Join(path, ".git"))
+	if err != nil {
+		return ""
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitdir = filepath.ToSlash(strings.TrimSpace(gitdir))
+	if !strings.Contains(gitdir, "/modules/") || strings.Contains(gitdir, "/worktrees/") {
+		return ""
+	}
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// ListSubmodules reads repoPath/.gitmodules and returns its entries sorted by
+// path, with Path made absolute. A repository without .gitmodules has no
+// submodules and returns nil without error.
+func ListSubmodules(repoPath string) ([]Submodule, error) {
+	file, err := os.Open(filepath.Join(repoPath, ".gitmodules"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var subs []Submodule
+	var cur *Submodule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			cur = nil
+			if strings.HasPrefix(line, "[submodule") {
+				start, end := strings.Index(line, "\""), strings.LastIndex(line, "\"")
+				if start != -1 && start < end {
+					subs = append(subs, Submodule{Name: line[start+1 : end]})
+					cur = &subs[len(subs)-1]
+				}
+			}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		v = strings.Trim(strings.TrimSpace(v), "\"")
+		switch strings.TrimSpace(k) {
+		case "path":
+			cur.Path = v
+		case "url":
+			cur.URL = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	out := subs[:0]
+	for _, s := range subs {
+		if s.Path == "" {
+			continue
+		}
+		s.Path = filepath.Join(repoPath, filepath.FromSlash(s.Path))
+		// An initialized submodule has its own .git entry; an uninitialized
+		// one is an empty directory or missing entirely.
+		if _, err := os.Lstat(filepath.Join(s.Path, ".git")); err == nil {
+			s.Initialized = true
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
@@ -0,0 +1,145 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/core/config"
+)
+
+func makeBareRepo(t *testing.T, dir string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "objects"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "refs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+}
+
+func TestIsBareRepo(t *testing.T) {
+	root := t.TempDir()
+	bare := filepath.Join(root, "mirror.git")
+	makeBareRepo(t, bare)
+	assert.True(t, isBareRepo(bare))
+
+	// A normal checkout's .git directory looks bare but is not a repo root.
+	checkout := filepath.Join(root, "checkout")
+	makeBareRepo(t, filepath.Join(checkout, ".git"))
+	assert.False(t, isBareRepo(checkout))
+	assert.False(t, isBareRepo(filepath.Join(checkout, ".git")))
+
+	assert.False(t, isBareRepo(root))
+}
+
+func TestSubmoduleSuperproject(t *testing.T) {
+	root := resolveDir(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "modules", "lib"), 0o755))
+
+	sub := filepath.Join(root, "vendor", "lib")
+	require.NoError(t, os.MkdirAll(sub, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, ".git"), []byte("gitdir: ../../.git/modules/lib\n"), 0o644))
+	assert.Equal(t, root, submoduleSuperproject(sub))
+
+	wt := filepath.Join(root, "wt")
+	require.NoError(t, os.MkdirAll(wt, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: /repo/.git/worktrees/wt\n"), 0o644))
+	assert.Empty(t, submoduleSuperproject(wt), "linked worktrees are not submodules")
+
+	assert.Empty(t, submoduleSuperproject(root), "a .git directory is not a submodule")
+}
+
+func TestListSubmodules(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".gitmodules"), []byte(`[submodule "lib"]
+	path = vendor/lib
+	url = https://example.com/lib.git
+[core]
+	path = ignored
+[submodule "docs"]
+	path = "docs"
+	url = ../docs.git
+`), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "vendor", "lib"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "vendor", "lib", ".git"), []byte("gitdir: ../../.git/modules/lib\n"), 0o644))
+
+	subs, err := ListSubmodules(repo)
+	require.NoError(t, err)
+	assert.Equal(t, []Submodule{
+		{Name: "docs", Path: filepath.Join(repo, "docs"), URL: "../docs.git"},
+		{Name: "lib", Path: filepath.Join(repo, "vendor", "lib"), URL: "https://example.com/lib.git", Initialized: true},
+	}, subs)
+
+	none, err := ListSubmodules(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, none)
+}
+
+func TestDiscoverAll_BareReposAndSubmodules(t *testing.T) {
+	rootDir := resolveDir(t.TempDir())
+
+	globalConfigDir := filepath.Join(rootDir, "home", ".config", "grove")
+	require.NoError(t, os.MkdirAll(globalConfigDir, 0o755))
+	emptyStr := ""
+	globalBytes, _ := yaml.Marshal(config.Config{
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
+		},
+		Context: &config.ContextConfig{ReposDir: &emptyStr},
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(globalConfigDir, "grove.yml"), globalBytes, 0o644))
+
+	// Ecosystem repo with one promoted child, one submodule checkout that
+	// is not listed in workspaces, and a bare mirror.
+	ecoDir := filepath.Join(rootDir, "work", "eco")
+	require.NoError(t, os.MkdirAll(filepath.Join(ecoDir, ".git", "modules", "vendor-lib"), 0o755))
+	ecoBytes, _ := yaml.Marshal(config.Config{Name: "eco", Workspaces: []string{"app"}})
+	require.NoError(t, os.WriteFile(filepath.Join(ecoDir, "grove.yml"), ecoBytes, 0o644))
+
+	appDir := filepath.Join(ecoDir, "app")
+	require.NoError(t, os.MkdirAll(filepath.Join(appDir, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, ".gitmodules"), []byte("[submodule \"proto\"]\n\tpath = proto\n"), 0o644))
+
+	libDir := filepath.Join(ecoDir, "vendor-lib")
+	require.NoError(t, os.MkdirAll(libDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, ".git"), []byte("gitdir: ../.git/modules/vendor-lib\n"), 0o644))
+
+	mirrorDir := filepath.Join(ecoDir, "mirror.git")
+	makeBareRepo(t, mirrorDir)
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(rootDir, "home", ".config"))
+	t.Setenv("HOME", filepath.Join(rootDir, "home"))
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	result, err := NewDiscoveryService(logger).WithSubmodules().DiscoverAll()
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []Repository{
+		{Name: "mirror.git", Path: mirrorDir, Kind: RepositoryBare},
+		{Name: "vendor-lib", Path: libDir, Kind: RepositorySubmodule, SuperprojectPath: ecoDir},
+	}, result.Repositories)
+	assert.NotContains(t, result.NonGroveDirectories, libDir, "submodule checkouts are reported in Repositories")
+
+	var app *Project
+	for i := range result.Projects {
+		if result.Projects[i].Path == appDir {
+			app = &result.Projects[i]
+		}
+	}
+	require.NotNil(t, app)
+	require.Len(t, app.Submodules, 1)
+	assert.Equal(t, filepath.Join(appDir, "proto"), app.Submodules[0].Path)
+	assert.False(t, app.Submodules[0].Initialized)
+
+	kinds := make(map[string]WorkspaceKind)
+	for _, n := range TransformToWorkspaceNodes(result, &config.Config{}) {
+		kinds[n.Path] = n.Kind
+	}
+	assert.Equal(t, KindBareRepo, kinds[mirrorDir])
+	assert.Equal(t, KindSubmodule, kinds[libDir])
+	assert.Equal(t, KindSubmodule, kinds[filepath.Join(appDir, "proto")])
+}
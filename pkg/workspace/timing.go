@@ -0,0 +1,11 @@
+package workspace
+
+import "github.com/grovetools/core/pkg/timinghook"
+
+var timer timinghook.Hook
+
+// SetTimer installs the process-wide timer for workspace discovery (see
+// timinghook). A nil fn disables timing.
+func SetTimer(fn timinghook.StartFunc) {
+	timer.Set(fn)
+}
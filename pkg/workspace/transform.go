@@ -222,6 +222,39 @@ func TransformToWorkspaceNodes(result *DiscoveryResult, cfg *config.Config) []*W
 		})
 	}
 
+	// Bare repositories and submodule checkouts found by the walk.
+	for _, repo := range result.Repositories {
+		kind := KindBareRepo
+		if repo.Kind == RepositorySubmodule {
+			kind = KindSubmodule
+		}
+		nodes = append(nodes, &WorkspaceNode{
+			Name: repo.Name,
+			Path: repo.Path,
+			Kind: kind,
+		})
+	}
+
+	// Submodule inventory (DiscoveryService.WithSubmodules): add .gitmodules
+	// entries that discovery did not already report under another kind.
+	seen := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		seen[n.Path] = true
+	}
+	for _, proj := range result.Projects {
+		for _, sub := range proj.Submodules {
+			if seen[sub.Path] {
+				continue
+			}
+			seen[sub.Path] = true
+			nodes = append(nodes, &WorkspaceNode{
+				Name: filepath.Base(sub.Path),
+				Path: sub.Path,
+				Kind: KindSubmodule,
+			})
+		}
+	}
+
 	// Hierarchy resolution pass: set RootEcosystemPath for all nodes
 	nodeMap := make(map[string]*WorkspaceNode)
 	for _, node := range nodes {
@@ -252,9 +285,27 @@ func TransformToWorkspaceNodes(result *DiscoveryResult, cfg *config.Config) []*W
 		}
 	}
 
-	// Final pass: set NotebookName for all nodes based on which grove they belong to
+	// Final pass: set NotebookName for all nodes based on which grove they
+	// belong to, and carry grove.yml metadata onto them. Worktrees have no
+	// metadata of their own and take their project's or ecosystem's.
+	metadata := make(map[string]*Metadata)
+	for _, eco := range result.Ecosystems {
+		if eco.Metadata != nil {
+			metadata[eco.Path] = eco.Metadata
+		}
+	}
+	for _, proj := range result.Projects {
+		if proj.Metadata != nil {
+			metadata[proj.Path] = proj.Metadata
+		}
+	}
 	for _, node := range nodes {
 		assignNotebookName(node, cfg)
+		if m, ok := metadata[node.Path]; ok {
+			node.Metadata = m
+		} else if node.IsWorktree() {
+			node.Metadata = metadata[node.ParentProjectPath]
+		}
 	}
 
 	return nodes
@@ -58,6 +58,7 @@ const (
 )
 
 // DiscoveredWorkspace represents a specific, checked-out instance of a Project.
+// Its versioned public form is models.DiscoveredWorkspace.
 type DiscoveredWorkspace struct {
 	Name              string        `json:"name"`
 	Path              string        `json:"path"`
@@ -65,7 +66,8 @@ type DiscoveredWorkspace struct {
 	ParentProjectPath string        `json:"parent_project_path"`
 }
 
-// Project represents a single software repository.
+// Project represents a single software repository. Its versioned public
+// form, used for JSON output, is models.Project.
 type Project struct {
 	Name                string                `json:"name"`
 	Path                string                `json:"path"`
@@ -90,13 +92,66 @@ type Project struct {
 	ReportPath    string `json:"report_path,omitempty"`
 	RepoURL       string `json:"repo_url,omitempty"`
 	RepoShorthand string `json:"repo_shorthand,omitempty"`
+
+	// Submodules lists the project's .gitmodules entries. Populated only
+	// when discovery runs with WithSubmodules.
+	Submodules []Submodule `json:"submodules,omitempty"`
+
+	// Metadata is the human context from the project's grove.yml.
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// Metadata is what a grove.yml says about its project for people rather
+// than tools: description, tags, owners and links. Discovery copies it onto
+// nodes so dashboards and pickers need not re-read every config file.
+type Metadata struct {
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Owners      []string          `json:"owners,omitempty"`
+	Links       map[string]string `json:"links,omitempty"`
+}
+
+// Submodule is one entry of a repository's .gitmodules file.
+type Submodule struct {
+	Name string `json:"name"`
+	// Path is the absolute checkout path.
+	Path string `json:"path"`
+	URL  string `json:"url,omitempty"`
+	// Initialized is true when the submodule has been checked out.
+	Initialized bool `json:"initialized"`
+}
+
+// RepositoryKind classifies a git repository that discovery found but did
+// not treat as a Grove project.
+type RepositoryKind string
+
+const (
+	// RepositoryBare is a bare repository with no working tree.
+	RepositoryBare RepositoryKind = "bare"
+	// RepositorySubmodule is a submodule checkout inside another repository.
+	RepositorySubmodule RepositoryKind = "submodule"
+)
+
+// Repository is a bare repository or submodule checkout found during
+// discovery. Plain non-Grove checkouts are still reported in
+// DiscoveryResult.NonGroveDirectories.
+type Repository struct {
+	Name string         `json:"name"`
+	Path string         `json:"path"`
+	Kind RepositoryKind `json:"kind"`
+	// SuperprojectPath is the working tree containing a submodule checkout.
+	SuperprojectPath string `json:"superproject_path,omitempty"`
 }
 
-// Ecosystem represents a top-level meta-repository.
+// Ecosystem represents a top-level meta-repository. Its versioned public
+// form is models.Ecosystem.
 type Ecosystem struct {
 	Name string `json:"name"`
 	Path string `json:"path"`
 	Type string `json:"type"` // "Grove" or "User"
+
+	// Metadata is the human context from the ecosystem's grove.yml.
+	Metadata *Metadata `json:"metadata,omitempty"`
 }
 
 // DiscoveryResult is the comprehensive output of the DiscoveryService.
@@ -104,6 +159,13 @@ type DiscoveryResult struct {
 	Projects            []Project   `json:"projects"`
 	Ecosystems          []Ecosystem `json:"ecosystems"`
 	NonGroveDirectories []string    `json:"non_grove_directories,omitempty"`
+	// Repositories lists bare repositories and submodule checkouts that
+	// are not Grove projects.
+	Repositories []Repository `json:"repositories,omitempty"`
+	// Remote lists the workspaces found on ssh:// groves, each with its
+	// Host set. Populated only when discovery runs with WithRemote; they
+	// are kept apart from Projects and Ecosystems, whose paths are local.
+	Remote []WorkspaceNode `json:"remote,omitempty"`
 }
 
 // WorkspaceKind provides an unambiguous classification for a discovered workspace entity.
@@ -177,10 +239,37 @@ const (
 	// Diagram:
 	// /path/to/other-repo/ (.git/ only, no grove.yml) <-- This
 	KindNonGroveRepo WorkspaceKind = "NonGroveRepo"
+
+	// KindBareRepo: A bare git repository (no working tree, no grove.yml).
+	// Diagram:
+	// /path/to/mirror.git/ (HEAD, objects/, refs/) <-- This
+	KindBareRepo WorkspaceKind = "BareRepo"
+
+	// KindSubmodule: A git submodule checkout that is not a Grove project,
+	// or, with submodule inventory enabled, a project's .gitmodules entry.
+	// Diagram:
+	// /path/to/ecosystem/
+	//   └─ vendor-lib/ (.git file -> ../.git/modules/vendor-lib) <-- This
+	KindSubmodule WorkspaceKind = "Submodule"
 )
 
+// IsWorktree reports whether k is one of the worktree kinds (see
+// WorkspaceNode.IsWorktree).
+func (k WorkspaceKind) IsWorktree() bool {
+	switch k {
+	case KindStandaloneProjectWorktree,
+		KindEcosystemWorktree,
+		KindEcosystemSubProjectWorktree,
+		KindEcosystemWorktreeSubProjectWorktree:
+		return true
+	default:
+		return false
+	}
+}
+
 // WorkspaceTree represents a node in the hierarchical workspace tree.
 // It's designed for consumers that need to render or traverse the full hierarchy.
+// Its versioned public form is models.WorkspaceTree.
 type WorkspaceTree struct {
 	Node     *WorkspaceNode   `json:"node"`
 	Children []*WorkspaceTree `json:"children"`
@@ -189,6 +278,8 @@ type WorkspaceTree struct {
 // WorkspaceNode is the enriched display model for workspace entities.
 // It represents a flattened, view-friendly node suitable for UIs, with explicit
 // parent-child relationships that form a hierarchical tree structure.
+// External consumers (`core ws --json`) receive the versioned public form,
+// models.Workspace; convert with models.NewWorkspace.
 type WorkspaceNode struct {
 	Name string        `json:"name"`
 	Path string        `json:"path"`
@@ -225,6 +316,14 @@ type WorkspaceNode struct {
 	ReportPath    string `json:"report_path,omitempty"`
 	RepoURL       string `json:"repo_url,omitempty"`
 	RepoShorthand string `json:"repo_shorthand,omitempty"`
+
+	// Metadata is the human context from the node's grove.yml. Worktrees
+	// share the metadata of the project or ecosystem they belong to.
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// Host is the machine of a workspace found through an ssh:// grove;
+	// its paths are paths on that host. Empty for local workspaces.
+	Host string `json:"host,omitempty"`
 }
 
 // IsWorktree returns true if this node represents a worktree.
@@ -239,15 +338,7 @@ type WorkspaceNode struct {
 //	      └─ my-branch/ (EcosystemWorktree) - IsWorktree()=true, IsEcosystem()=true
 //	          └─ grove-hooks/ (EcosystemWorktreeSubProject) - IsWorktree()=false, IsEcosystemChild()=true
 func (w *WorkspaceNode) IsWorktree() bool {
-	switch w.Kind {
-	case KindStandaloneProjectWorktree,
-		KindEcosystemWorktree,
-		KindEcosystemSubProjectWorktree,
-		KindEcosystemWorktreeSubProjectWorktree:
-		return true
-	default:
-		return false
-	}
+	return w.Kind.IsWorktree()
 }
 
 // IsEcosystem returns true if this node represents an ecosystem (root or worktree)
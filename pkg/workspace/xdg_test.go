@@ -58,8 +58,8 @@ func setupXDGFixture(t *testing.T) *xdgFixture {
 	globalConfigDir := filepath.Join(rootDir, "home", ".config", "grove")
 	emptyStr := ""
 	writeGroveYML(t, globalConfigDir, "grove.yml", config.Config{
-		SearchPaths: map[string]config.SearchPathConfig{
-			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
 		},
 		Context: &config.ContextConfig{ReposDir: &emptyStr},
 	})
@@ -502,8 +502,8 @@ func TestDiscoverAll_AnchoredContainer(t *testing.T) {
 	globalConfigDir := filepath.Join(rootDir, "home", ".config", "grove")
 	emptyStr := ""
 	writeGroveYML(t, globalConfigDir, "grove.yml", config.Config{
-		SearchPaths: map[string]config.SearchPathConfig{
-			"work": {Path: filepath.Join(rootDir, "work"), Enabled: true},
+		Groves: map[string]config.GroveSourceConfig{
+			"work": {Path: filepath.Join(rootDir, "work")},
 		},
 		Context: &config.ContextConfig{ReposDir: &emptyStr},
 	})
@@ -0,0 +1,387 @@
+// Package wshistory keeps a durable journal of workspace lifecycle events:
+// projects added and removed, worktrees created and removed, ecosystems
+// moved. The daemon records a discovery snapshot after each rescan, and
+// `core ws history` records one before reading, so the journal answers
+// "when did this worktree appear or disappear" during cleanup audits.
+//
+// Events are derived by diffing each snapshot against the previous one, so
+// their times are when a change was first observed, not when it happened.
+// The first snapshot only sets the baseline.
+package wshistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// EventType is the kind of lifecycle change an Event records.
+type EventType string
+
+const (
+	ProjectAdded     EventType = "project_added"
+	ProjectRemoved   EventType = "project_removed"
+	WorktreeCreated  EventType = "worktree_created"
+	WorktreeRemoved  EventType = "worktree_removed"
+	EcosystemAdded   EventType = "ecosystem_added"
+	EcosystemRemoved EventType = "ecosystem_removed"
+	EcosystemMoved   EventType = "ecosystem_moved"
+)
+
+// EventTypes lists every EventType.
+var EventTypes = []EventType{
+	ProjectAdded, ProjectRemoved, WorktreeCreated, WorktreeRemoved,
+	EcosystemAdded, EcosystemRemoved, EcosystemMoved,
+}
+
+// Event is one line of the journal.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	Name string    `json:"name"`
+	Path string    `json:"path"`
+	Kind string    `json:"kind"`
+	// PreviousPath is the old location of a moved ecosystem.
+	PreviousPath string `json:"previous_path,omitempty"`
+	// ParentPath is the repository a worktree belongs to, or the ecosystem
+	// containing a project.
+	ParentPath string `json:"parent_path,omitempty"`
+	// Source names the process that observed the change (e.g. "daemon").
+	Source string `json:"source,omitempty"`
+}
+
+// entry is one workspace of a snapshot.
+type entry struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// snapshot is the last recorded workspace set, keyed by path.
+type snapshot struct {
+	Time       time.Time        `json:"time"`
+	Workspaces map[string]entry `json:"workspaces"`
+}
+
+// Journal is an append-only JSON-lines file of events, with the snapshot
+// the next Record diffs against stored next to it.
+type Journal struct {
+	path string
+}
+
+// journalMu serializes Record's read-diff-append-save cycle within a
+// process. Like the worktree registry, the journal uses no on-disk lock; a
+// race between two processes can at worst record a change twice.
+var journalMu sync.Mutex
+
+// DefaultPath returns the journal location under the state directory.
+func DefaultPath() string {
+	return filepath.Join(paths.StateDir(), "workspaces", "history.jsonl")
+}
+
+// Open returns the journal at path, or at DefaultPath when path is empty.
+// The file is created on the first Record.
+func Open(path string) *Journal {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Journal{path: path}
+}
+
+// Path returns the journal file.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+func (j *Journal) snapshotPath() string {
+	return strings.TrimSuffix(j.path, filepath.Ext(j.path)) + ".snapshot.json"
+}
+
+// Record diffs nodes against the previous snapshot, appends the resulting
+// events and stores nodes as the new snapshot. It returns the events
+// appended; the first call only stores the baseline.
+func (j *Journal) Record(nodes []*workspace.WorkspaceNode, source string) ([]Event, error) {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+
+	now := time.Now().UTC()
+	next := snapshotOf(nodes, now)
+	prev, err := j.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	if prev != nil {
+		events = diff(prev.Workspaces, next.Workspaces, now)
+		for i := range events {
+			events[i].Source = source
+		}
+		if err := j.Append(events...); err != nil {
+			return nil, err
+		}
+	}
+	if err := j.saveSnapshot(next); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Append writes events to the end of the journal.
+func (j *Journal) Append(events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var buf strings.Builder
+	for _, ev := range events {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return fmt.Errorf("failed to marshal workspace event: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644) //nolint:gosec // journal is not sensitive
+	if err != nil {
+		return fmt.Errorf("failed to open workspace journal: %w", err)
+	}
+	// One write per batch keeps concurrent appenders from interleaving
+	// within a line.
+	if _, err := f.WriteString(buf.String()); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to append to workspace journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Filter selects events for Read. Zero fields match everything.
+type Filter struct {
+	// Path matches events whose path, previous path or parent path is
+	// Path or lies under it.
+	Path string
+	// Name matches events whose workspace name contains Name.
+	Name  string
+	Since time.Time
+	Types []EventType
+}
+
+func (f Filter) matches(ev Event) bool {
+	if !f.Since.IsZero() && ev.Time.Before(f.Since) {
+		return false
+	}
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if ev.Type == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Name != "" && !strings.Contains(strings.ToLower(ev.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.Path != "" && !within(ev.Path, f.Path) && !within(ev.PreviousPath, f.Path) && !within(ev.ParentPath, f.Path) {
+		return false
+	}
+	return true
+}
+
+func within(path, root string) bool {
+	if path == "" {
+		return false
+	}
+	root = filepath.Clean(root)
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+// Read returns the journal's events matching filter, oldest first. A
+// missing journal has no events; malformed lines are skipped.
+func (j *Journal) Read(filter Filter) ([]Event, error) {
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open workspace journal: %w", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var ev Event
+		if json.Unmarshal(scanner.Bytes(), &ev) != nil {
+			continue
+		}
+		if filter.matches(ev) {
+			events = append(events, ev)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read workspace journal: %w", err)
+	}
+	return events, nil
+}
+
+func (j *Journal) loadSnapshot() (*snapshot, error) {
+	data, err := os.ReadFile(j.snapshotPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read workspace snapshot: %w", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil || snap.Workspaces == nil {
+		// A corrupt snapshot restarts the baseline rather than recording
+		// every workspace as new.
+		return nil, nil
+	}
+	return &snap, nil
+}
+
+func (j *Journal) saveSnapshot(snap *snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace snapshot: %w", err)
+	}
+	path := j.snapshotPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil { //nolint:gosec // snapshot is not sensitive
+		return fmt.Errorf("failed to write workspace snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write workspace snapshot: %w", err)
+	}
+	return nil
+}
+
+func snapshotOf(nodes []*workspace.WorkspaceNode, now time.Time) *snapshot {
+	snap := &snapshot{Time: now, Workspaces: make(map[string]entry, len(nodes))}
+	for _, n := range nodes {
+		parent := n.ParentEcosystemPath
+		if n.IsWorktree() && n.ParentProjectPath != "" {
+			parent = n.ParentProjectPath
+		}
+		snap.Workspaces[n.Path] = entry{Name: n.Name, Kind: string(n.Kind), Parent: parent}
+	}
+	return snap
+}
+
+// diff returns the events that turn prev into next, keyed by path, sorted
+// by path. An ecosystem root that disappears from one path while one of the
+// same name appears at another is reported as moved, and the workspaces
+// under it that moved along are not reported separately.
+func diff(prev, next map[string]entry, now time.Time) []Event {
+	var added, removed []string
+	for path := range next {
+		if _, ok := prev[path]; !ok {
+			added = append(added, path)
+		}
+	}
+	for path := range prev {
+		if _, ok := next[path]; !ok {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	skip := make(map[string]bool)
+	var events []Event
+	for _, oldPath := range removed {
+		old := prev[oldPath]
+		if old.Kind != string(workspace.KindEcosystemRoot) {
+			continue
+		}
+		for _, newPath := range added {
+			cur := next[newPath]
+			if skip[newPath] || cur.Kind != old.Kind || cur.Name != old.Name {
+				continue
+			}
+			events = append(events, Event{
+				Time: now, Type: EcosystemMoved, Name: cur.Name, Path: newPath,
+				Kind: cur.Kind, PreviousPath: oldPath,
+			})
+			skip[oldPath], skip[newPath] = true, true
+			// Drop the add/remove pairs of everything that moved along.
+			for _, r := range removed {
+				if rel, ok := relUnder(r, oldPath); ok {
+					moved := filepath.Join(newPath, rel)
+					if _, ok := next[moved]; ok {
+						skip[r], skip[moved] = true, true
+					}
+				}
+			}
+			break
+		}
+	}
+
+	for _, path := range removed {
+		if !skip[path] {
+			e := prev[path]
+			events = append(events, newEvent(now, removedType(e.Kind), path, e))
+		}
+	}
+	for _, path := range added {
+		if !skip[path] {
+			e := next[path]
+			events = append(events, newEvent(now, addedType(e.Kind), path, e))
+		}
+	}
+	sort.SliceStable(events, func(i, k int) bool { return events[i].Path < events[k].Path })
+	return events
+}
+
+func newEvent(now time.Time, t EventType, path string, e entry) Event {
+	return Event{Time: now, Type: t, Name: e.Name, Path: path, Kind: e.Kind, ParentPath: e.Parent}
+}
+
+func relUnder(path, root string) (string, bool) {
+	if !within(path, root) || path == root {
+		return "", false
+	}
+	return strings.TrimPrefix(path, root+string(filepath.Separator)), true
+}
+
+func addedType(kind string) EventType {
+	switch {
+	case kind == string(workspace.KindEcosystemRoot):
+		return EcosystemAdded
+	case workspace.WorkspaceKind(kind).IsWorktree():
+		return WorktreeCreated
+	default:
+		return ProjectAdded
+	}
+}
+
+func removedType(kind string) EventType {
+	switch {
+	case kind == string(workspace.KindEcosystemRoot):
+		return EcosystemRemoved
+	case workspace.WorkspaceKind(kind).IsWorktree():
+		return WorktreeRemoved
+	default:
+		return ProjectRemoved
+	}
+}
@@ -0,0 +1,108 @@
+package wshistory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func node(name, path string, kind workspace.WorkspaceKind, parent string) *workspace.WorkspaceNode {
+	n := &workspace.WorkspaceNode{Name: name, Path: path, Kind: kind, ParentEcosystemPath: parent}
+	if n.IsWorktree() {
+		n.ParentProjectPath = parent
+	}
+	return n
+}
+
+func TestRecordJournalsChanges(t *testing.T) {
+	j := Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	base := []*workspace.WorkspaceNode{
+		node("api", "/code/api", workspace.KindStandaloneProject, ""),
+		node("old-fix", "/code/api/.grove-worktrees/old-fix", workspace.KindStandaloneProjectWorktree, "/code/api"),
+	}
+	events, err := j.Record(base, "daemon")
+	if err != nil || len(events) != 0 {
+		t.Fatalf("first Record should only set the baseline, got %v, %v", events, err)
+	}
+	if _, err := os.Stat(j.Path()); !os.IsNotExist(err) {
+		t.Errorf("baseline should not write the journal, stat err = %v", err)
+	}
+
+	next := []*workspace.WorkspaceNode{
+		base[0],
+		node("feature", "/code/api/.grove-worktrees/feature", workspace.KindStandaloneProjectWorktree, "/code/api"),
+		node("web", "/code/web", workspace.KindStandaloneProject, ""),
+	}
+	events, err = j.Record(next, "daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]EventType{}
+	for _, ev := range events {
+		got[ev.Name] = ev.Type
+		if ev.Source != "daemon" {
+			t.Errorf("%s: source = %q", ev.Name, ev.Source)
+		}
+	}
+	want := map[string]EventType{"feature": WorktreeCreated, "old-fix": WorktreeRemoved, "web": ProjectAdded}
+	if len(got) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for name, typ := range want {
+		if got[name] != typ {
+			t.Errorf("%s: type = %q, want %q", name, got[name], typ)
+		}
+	}
+
+	// No change, no events.
+	if events, _ := j.Record(next, "cli"); len(events) != 0 {
+		t.Errorf("unchanged set recorded %v", events)
+	}
+
+	all, err := j.Read(Filter{})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("Read = %v, %v", all, err)
+	}
+	removed, _ := j.Read(Filter{Path: "/code/api", Types: []EventType{WorktreeRemoved}})
+	if len(removed) != 1 || removed[0].Name != "old-fix" || removed[0].ParentPath != "/code/api" {
+		t.Errorf("filtered Read = %+v", removed)
+	}
+	if later, _ := j.Read(Filter{Since: time.Now().Add(time.Hour)}); len(later) != 0 {
+		t.Errorf("--since in the future returned %v", later)
+	}
+}
+
+func TestDiffDetectsEcosystemMove(t *testing.T) {
+	prev := snapshotOf([]*workspace.WorkspaceNode{
+		node("grove", "/old/grove", workspace.KindEcosystemRoot, ""),
+		node("core", "/old/grove/core", workspace.KindEcosystemSubProject, "/old/grove"),
+		node("gone", "/old/grove/gone", workspace.KindEcosystemSubProject, "/old/grove"),
+	}, time.Now())
+	next := snapshotOf([]*workspace.WorkspaceNode{
+		node("grove", "/new/grove", workspace.KindEcosystemRoot, ""),
+		node("core", "/new/grove/core", workspace.KindEcosystemSubProject, "/new/grove"),
+	}, time.Now())
+
+	events := diff(prev.Workspaces, next.Workspaces, time.Now())
+	if len(events) != 2 {
+		t.Fatalf("events = %+v", events)
+	}
+	var moved, removed *Event
+	for i := range events {
+		switch events[i].Type {
+		case EcosystemMoved:
+			moved = &events[i]
+		case ProjectRemoved:
+			removed = &events[i]
+		}
+	}
+	if moved == nil || moved.PreviousPath != "/old/grove" || moved.Path != "/new/grove" {
+		t.Errorf("moved = %+v", moved)
+	}
+	if removed == nil || removed.Name != "gone" {
+		t.Errorf("a project that did not move along should be removed, got %+v", removed)
+	}
+}
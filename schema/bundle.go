@@ -0,0 +1,29 @@
+package schema
+
+import _ "embed"
+
+// Both schema artifacts produced by tools/schema-composer are compiled into
+// the binary, so consumers never need the files on disk.
+
+//go:embed grove.embedded.schema.json
+var embeddedSchemaData []byte
+
+//go:embed dist/grove.schema.json
+var resolvableSchemaData []byte
+
+// Bundled returns the bundled configuration schema: the base schema with
+// every extension schema inlined, so it validates without network access.
+// It is the schema NewValidator and Lookup use. Callers must not modify the
+// returned slice.
+func Bundled() []byte {
+	return embeddedSchemaData
+}
+
+// Resolvable returns the resolvable configuration schema: the base schema
+// with extension properties left as remote $refs to their published
+// schemas. It is the one to hand to editors and language servers, which
+// fetch (and keep current) the extension schemas themselves. Callers must
+// not modify the returned slice.
+func Resolvable() []byte {
+	return resolvableSchemaData
+}
@@ -0,0 +1,42 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grovetools/core/schema"
+)
+
+func TestBundledArtifactsParse(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"bundled":    schema.Bundled(),
+		"resolvable": schema.Resolvable(),
+	} {
+		var root map[string]interface{}
+		if err := json.Unmarshal(data, &root); err != nil {
+			t.Fatalf("%s schema is not valid JSON: %v", name, err)
+		}
+		props, ok := root["properties"].(map[string]interface{})
+		if !ok || props["logging"] == nil {
+			t.Errorf("%s schema is missing the core properties", name)
+		}
+	}
+}
+
+func TestResolvableReferencesExtensions(t *testing.T) {
+	var root struct {
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema.Resolvable(), &root); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := schema.DefaultManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ext := range manifest.Extensions() {
+		if got := root.Properties[ext.Name]["$ref"]; got != ext.URL {
+			t.Errorf("extension %q: $ref = %v, want %s", ext.Name, got, ext.URL)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Embedded returns the bundled configuration schema compiled into the
+// binary.
+//
+// Deprecated: use Bundled.
+func Embedded() []byte {
+	return Bundled()
+}
+
+// Lookup returns the schema for a dotted configuration key (e.g. "logging"
+// or "logging.file") from the embedded bundle and registered extensions
+// (see Composed). Local $refs are inlined so
+// the result is self-contained and can be printed or walked directly.
+func Lookup(key string) (map[string]interface{}, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(Composed(), &root); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded schema: %w", err)
+	}
+	return lookupIn(root, key)
+}
+
+func lookupIn(root map[string]interface{}, key string) (map[string]interface{}, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("schema key is empty")
+	}
+
+	// doc is the document that "#/..." refs resolve against. Extension
+	// schemas are bundled whole under their key, so their refs point at
+	// their own $defs rather than the bundle's.
+	doc := root
+	node := root
+	walked := make([]string, 0, strings.Count(key, ".")+1)
+	for _, part := range strings.Split(key, ".") {
+		props, _ := node["properties"].(map[string]interface{})
+		next, ok := props[part].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unknown config key %q (available under %q: %s)",
+				key, strings.Join(walked, "."), strings.Join(sortedKeys(props), ", "))
+		}
+		walked = append(walked, part)
+		if hasDefs(next) {
+			doc = next
+		}
+		resolved, err := deref(doc, next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %q: %w", strings.Join(walked, "."), err)
+		}
+		node = resolved
+	}
+	return inlineRefs(doc, node, map[string]bool{}), nil
+}
+
+func hasDefs(node map[string]interface{}) bool {
+	_, defs := node["$defs"]
+	_, definitions := node["definitions"]
+	return defs || definitions
+}
+
+// deref follows a local $ref on node, keeping node's own annotations
+// (description, x-* extras) over the referenced definition's.
+func deref(doc, node map[string]interface{}) (map[string]interface{}, error) {
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node, nil
+	}
+	target, err := resolvePointer(doc, ref)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]interface{}, len(target)+len(node))
+	for k, v := range target {
+		merged[k] = v
+	}
+	for k, v := range node {
+		if k != "$ref" {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
+
+// resolvePointer resolves a local JSON pointer such as "#/$defs/Name".
+func resolvePointer(doc map[string]interface{}, ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q (only local refs are bundled)", ref)
+	}
+	var cur interface{} = doc
+	for _, tok := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("dangling $ref %q", ref)
+		}
+		if cur, ok = m[tok]; !ok {
+			return nil, fmt.Errorf("dangling $ref %q", ref)
+		}
+	}
+	m, ok := cur.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("$ref %q does not point at a schema", ref)
+	}
+	return m, nil
+}
+
+// inlineRefs returns a copy of v with every local $ref replaced by its
+// target. active holds the refs being expanded on the current path, so a
+// recursive definition is left as a $ref instead of looping forever.
+func inlineRefs(doc map[string]interface{}, v interface{}, active map[string]bool) map[string]interface{} {
+	out, _ := inlineValue(doc, v, active).(map[string]interface{})
+	return out
+}
+
+func inlineValue(doc map[string]interface{}, v interface{}, active map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := t["$ref"].(string); ok && !active[ref] {
+			if resolved, err := deref(doc, t); err == nil {
+				active[ref] = true
+				defer delete(active, ref)
+				t = resolved
+			}
+		}
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			if k == "$defs" || k == "definitions" {
+				continue
+			}
+			out[k] = inlineValue(doc, val, active)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = inlineValue(doc, val, active)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Field is one settable leaf of a schema, as listed by Fields.
+type Field struct {
+	// Key is the dotted path relative to the schema passed to Fields.
+	Key         string
+	Type        string
+	Default     string
+	Description string
+	Enum        []string
+}
+
+// Fields flattens an object schema (as returned by Lookup) into its leaf
+// settings, sorted by key. Nested objects with declared properties are
+// descended into; maps and arrays are reported as a single field.
+func Fields(node map[string]interface{}) []Field {
+	var out []Field
+	collectFields(node, "", &out)
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// DefaultFields returns the settings under a dotted key that declare a
+// default, as Fields lists them but with keys from the top of the config
+// (e.g. "logging.file.format"). An empty key covers every key the embedded
+// bundle and registered extensions declare.
+func DefaultFields(key string) ([]Field, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(Composed(), &root); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded schema: %w", err)
+	}
+	keys := []string{strings.TrimSpace(key)}
+	if keys[0] == "" {
+		props, _ := root["properties"].(map[string]interface{})
+		keys = sortedKeys(props)
+	}
+	var out []Field
+	for _, k := range keys {
+		node, err := lookupIn(root, k)
+		if err != nil {
+			return nil, err
+		}
+		var fields []Field
+		if _, nested := node["properties"].(map[string]interface{}); nested {
+			collectFields(node, k, &fields)
+		} else {
+			collectFields(map[string]interface{}{"properties": map[string]interface{}{k: node}}, "", &fields)
+		}
+		for _, f := range fields {
+			if f.Default != "" {
+				out = append(out, f)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+func collectFields(node map[string]interface{}, prefix string, out *[]Field) {
+	props, _ := node["properties"].(map[string]interface{})
+	for name, raw := range props {
+		child, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if _, nested := child["properties"].(map[string]interface{}); nested {
+			collectFields(child, key, out)
+			continue
+		}
+		f := Field{
+			Key:         key,
+			Type:        typeName(child),
+			Description: stringValue(child["description"]),
+		}
+		if d, ok := child["default"]; ok {
+			f.Default = jsonValue(d)
+		}
+		if enum, ok := child["enum"].([]interface{}); ok {
+			for _, e := range enum {
+				f.Enum = append(f.Enum, stringValue(e))
+			}
+		}
+		*out = append(*out, f)
+	}
+}
+
+// typeName renders a schema's type compactly: "string", "array of string",
+// "map of array of string", "string|integer".
+func typeName(node map[string]interface{}) string {
+	var base string
+	switch t := node["type"].(type) {
+	case string:
+		base = t
+	case []interface{}:
+		parts := make([]string, 0, len(t))
+		for _, p := range t {
+			parts = append(parts, stringValue(p))
+		}
+		base = strings.Join(parts, "|")
+	}
+	switch base {
+	case "array":
+		if items, ok := node["items"].(map[string]interface{}); ok {
+			if it := typeName(items); it != "" {
+				return "array of " + it
+			}
+		}
+	case "object":
+		if ap, ok := node["additionalProperties"].(map[string]interface{}); ok {
+			if vt := typeName(ap); vt != "" {
+				return "map of " + vt
+			}
+		}
+	}
+	return base
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	if v == nil {
+		return ""
+	}
+	return jsonValue(v)
+}
+
+func jsonValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+package schema_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/schema"
+)
+
+func TestLookupInlinesRefs(t *testing.T) {
+	node, err := schema.Lookup("logging")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	props, ok := node["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("logging schema has no properties: %v", node)
+	}
+	file, ok := props["file"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("logging.file missing")
+	}
+	if _, hasRef := file["$ref"]; hasRef {
+		t.Errorf("logging.file still carries a $ref: %v", file)
+	}
+	if _, ok := file["properties"].(map[string]interface{})["enabled"]; !ok {
+		t.Errorf("logging.file was not inlined: %v", file)
+	}
+}
+
+func TestLookupNestedKey(t *testing.T) {
+	node, err := schema.Lookup("logging.file")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if got := node["description"]; got != "File logging sink configuration" {
+		t.Errorf("description = %v", got)
+	}
+}
+
+func TestLookupUnknownKey(t *testing.T) {
+	_, err := schema.Lookup("logging.nope")
+	if err == nil {
+		t.Fatal("expected an error for an unknown key")
+	}
+	if !strings.Contains(err.Error(), "level") {
+		t.Errorf("error should list the available keys, got: %v", err)
+	}
+}
+
+func TestFields(t *testing.T) {
+	node, err := schema.Lookup("logging")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	byKey := make(map[string]schema.Field)
+	for _, f := range schema.Fields(node) {
+		byKey[f.Key] = f
+	}
+
+	level := byKey["level"]
+	if level.Type != "string" || level.Default != `"info"` {
+		t.Errorf("level = %+v", level)
+	}
+	if strings.Join(level.Enum, ",") != "trace,debug,info,warn,error" {
+		t.Errorf("level enum = %v", level.Enum)
+	}
+	if got := byKey["file.retention_days"]; got.Type != "integer" || got.Default != "14" {
+		t.Errorf("file.retention_days = %+v", got)
+	}
+	if got := byKey["groups"].Type; got != "map of array of string" {
+		t.Errorf("groups type = %q", got)
+	}
+	if _, ok := byKey["file"]; ok {
+		t.Error("nested objects should be flattened, not listed as a field")
+	}
+}
+
+// TestLoggingSchemaCoversConfig guards the mirror structs in
+// config/schema.go: every key logging.Config accepts must appear in the
+// embedded schema, or `config schema print` (and validation) miss it.
+func TestLoggingSchemaCoversConfig(t *testing.T) {
+	node, err := schema.Lookup("logging")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	have := make(map[string]bool)
+	for _, f := range schema.Fields(node) {
+		have[f.Key] = true
+	}
+
+	var want []string
+	collectYAMLKeys(reflect.TypeOf(logging.Config{}), "", &want)
+	for _, k := range want {
+		if !have[k] {
+			t.Errorf("logging.%s is missing from the embedded schema", k)
+		}
+	}
+}
+
+func collectYAMLKeys(t reflect.Type, prefix string, out *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			collectYAMLKeys(ft, key, out)
+			continue
+		}
+		*out = append(*out, key)
+	}
+}
+
+func TestDefaultFields(t *testing.T) {
+	fields, err := schema.DefaultFields("logging")
+	if err != nil {
+		t.Fatalf("DefaultFields: %v", err)
+	}
+	byKey := map[string]string{}
+	for _, f := range fields {
+		if f.Default == "" {
+			t.Errorf("%s has no default", f.Key)
+		}
+		byKey[f.Key] = f.Default
+	}
+	if byKey["logging.level"] != `"info"` || byKey["logging.file.retention_days"] != "14" {
+		t.Errorf("unexpected logging defaults: %v", byKey)
+	}
+	if _, ok := byKey["logging.time_format"]; ok {
+		t.Error("settings without a default should be left out")
+	}
+
+	all, err := schema.DefaultFields("")
+	if err != nil {
+		t.Fatalf("DefaultFields(\"\"): %v", err)
+	}
+	found := false
+	for _, f := range all {
+		found = found || f.Key == "tui.logs.max_entries"
+	}
+	if !found {
+		t.Error("an empty key should cover every section, e.g. tui.logs.max_entries")
+	}
+}
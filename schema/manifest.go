@@ -1,18 +1,194 @@
 package schema
 
-// ExtensionSchemaURLs maps Grove extension keys to the canonical URL of their JSON schema.
-// Tools in the ecosystem publish their own schemas, and this manifest is used to compose them
-// into a unified schema for validation and IDE support.
-//
-// NOTE: For now using placeholder GitHub release URLs. In production, these should redirect
-// through schemas.grove.sh for clean, versioned URLs.
-//
-// Extension schemas are currently commented out for testing. Once schemas are published as
-// GitHub release assets or through a schema hosting service, they can be uncommented.
-var ExtensionSchemaURLs = map[string]string{
-	// "proxy": "https://github.com/mattsolo1/grove-proxy/releases/download/v0.4.0/proxy.schema.json",
-	// Additional extensions will be added here as they publish their schemas:
-	// "flow":   "https://schemas.grove.sh/flow/v1.schema.json",
-	// "gemini": "https://schemas.grove.sh/gemini/v1.schema.json",
-	// "hooks":  "https://schemas.grove.sh/hooks/v1.schema.json",
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The registry manifest lists the extension schemas that tools in the
+// ecosystem publish, grouped by the registry that publishes them.
+// tools/schema-composer composes them into the resolvable and bundled
+// schemas. Every extension is pinned to the sha256 of its schema, and a
+// registry with a public key also signs each schema, so bundling fails
+// instead of embedding a schema a compromised endpoint swapped.
+
+//go:embed registry.json
+var defaultManifestData []byte
+
+// Manifest is the on-disk shape of a registry manifest.
+type Manifest struct {
+	Registries []Registry `json:"registries"`
+}
+
+// Registry is one publisher of extension schemas.
+type Registry struct {
+	Name string `json:"name"`
+	// PublicKey is the base64 ed25519 public key the registry signs its
+	// schemas with. When empty, schemas are verified by checksum only.
+	PublicKey  string      `json:"public_key,omitempty"`
+	Extensions []Extension `json:"extensions"`
+}
+
+// Extension is the published schema of one extension config key.
+type Extension struct {
+	// Name is the top-level grove.yml key the schema describes.
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Version string `json:"version,omitempty"`
+	// SHA256 is the hex checksum of the schema document at URL.
+	SHA256 string `json:"sha256"`
+	// SignatureURL locates the base64 ed25519 signature of the schema,
+	// defaulting to URL + ".sig". Only used when the registry has a
+	// public key.
+	SignatureURL string `json:"signature_url,omitempty"`
+
+	// Registry is the name of the registry listing the extension, filled
+	// in by Extensions.
+	Registry string `json:"-"`
+	// publicKey is the registry's decoded key, filled in by Extensions.
+	publicKey ed25519.PublicKey
+}
+
+// DefaultManifest returns the registry manifest compiled into the binary.
+func DefaultManifest() (*Manifest, error) {
+	return ParseManifest(defaultManifestData)
+}
+
+// LoadManifest reads and validates the registry manifest at path.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry manifest: %w", err)
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// ParseManifest decodes and validates a registry manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid registry manifest: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Merge appends the registries of other to m. The result is validated, so
+// an extension listed by two registries is an error.
+func (m *Manifest) Merge(other *Manifest) error {
+	merged := Manifest{Registries: append(append([]Registry{}, m.Registries...), other.Registries...)}
+	if err := merged.Validate(); err != nil {
+		return err
+	}
+	m.Registries = merged.Registries
+	return nil
+}
+
+// Validate checks that every registry is named and has a well-formed key,
+// and that every extension has a URL and checksum and is listed once.
+func (m *Manifest) Validate() error {
+	registries := make(map[string]bool)
+	extensions := make(map[string]string)
+	for _, r := range m.Registries {
+		if r.Name == "" {
+			return fmt.Errorf("registry without a name")
+		}
+		if registries[r.Name] {
+			return fmt.Errorf("registry %q is listed twice", r.Name)
+		}
+		registries[r.Name] = true
+		if _, err := decodePublicKey(r.PublicKey); err != nil {
+			return fmt.Errorf("registry %q: %w", r.Name, err)
+		}
+		for _, ext := range r.Extensions {
+			if ext.Name == "" {
+				return fmt.Errorf("registry %q: extension without a name", r.Name)
+			}
+			if prev, ok := extensions[ext.Name]; ok {
+				return fmt.Errorf("extension %q is listed by registries %q and %q", ext.Name, prev, r.Name)
+			}
+			extensions[ext.Name] = r.Name
+			if ext.URL == "" {
+				return fmt.Errorf("extension %q: missing url", ext.Name)
+			}
+			if sum, err := hex.DecodeString(ext.SHA256); err != nil || len(sum) != sha256.Size {
+				return fmt.Errorf("extension %q: sha256 must be %d hex characters", ext.Name, 2*sha256.Size)
+			}
+		}
+	}
+	return nil
+}
+
+// Extensions returns every extension of every registry, sorted by name.
+func (m *Manifest) Extensions() []Extension {
+	var out []Extension
+	for _, r := range m.Registries {
+		key, _ := decodePublicKey(r.PublicKey)
+		for _, ext := range r.Extensions {
+			ext.Registry = r.Name
+			ext.publicKey = key
+			out = append(out, ext)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Signed reports whether the extension's registry signs its schemas.
+func (e Extension) Signed() bool {
+	return len(e.publicKey) > 0
+}
+
+// SignatureLocation returns the URL of the extension's schema signature.
+func (e Extension) SignatureLocation() string {
+	if e.SignatureURL != "" {
+		return e.SignatureURL
+	}
+	return e.URL + ".sig"
+}
+
+// Verify checks a downloaded schema against the pinned checksum and, when
+// the registry signs its schemas, against sig (the base64 signature read
+// from SignatureLocation).
+func (e Extension) Verify(body, sig []byte) error {
+	sum := sha256.Sum256(body)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, e.SHA256) {
+		return fmt.Errorf("extension %q: schema sha256 %s does not match the pinned %s", e.Name, got, e.SHA256)
+	}
+	if !e.Signed() {
+		return nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return fmt.Errorf("extension %q: malformed signature: %w", e.Name, err)
+	}
+	if !ed25519.Verify(e.publicKey, body, raw) {
+		return fmt.Errorf("extension %q: signature does not verify with the key of registry %q", e.Name, e.Registry)
+	}
+	return nil
+}
+
+func decodePublicKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public_key must be a base64 ed25519 public key")
+	}
+	return ed25519.PublicKey(key), nil
 }
@@ -0,0 +1,138 @@
+package schema_test
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/schema"
+)
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDefaultManifestParses(t *testing.T) {
+	if _, err := schema.DefaultManifest(); err != nil {
+		t.Fatalf("embedded registry manifest: %v", err)
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	sum := sha256Hex([]byte("{}"))
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			manifest: fmt.Sprintf(`{"registries":[{"name":"a","extensions":[{"name":"flow","url":"https://a/flow.json","sha256":%q}]}]}`, sum),
+		},
+		{
+			name:     "missing checksum",
+			manifest: `{"registries":[{"name":"a","extensions":[{"name":"flow","url":"https://a/flow.json"}]}]}`,
+			wantErr:  "sha256",
+		},
+		{
+			name: "extension in two registries",
+			manifest: fmt.Sprintf(`{"registries":[
+				{"name":"a","extensions":[{"name":"flow","url":"https://a/flow.json","sha256":%q}]},
+				{"name":"b","extensions":[{"name":"flow","url":"https://b/flow.json","sha256":%q}]}]}`, sum, sum),
+			wantErr: "listed by registries",
+		},
+		{
+			name:     "bad public key",
+			manifest: `{"registries":[{"name":"a","public_key":"bm90LWEta2V5","extensions":[]}]}`,
+			wantErr:  "public_key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.ParseManifest([]byte(tt.manifest))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManifestMergeRejectsDuplicates(t *testing.T) {
+	sum := sha256Hex([]byte("{}"))
+	m, err := schema.ParseManifest([]byte(fmt.Sprintf(`{"registries":[{"name":"a","extensions":[{"name":"flow","url":"https://a/flow.json","sha256":%q}]}]}`, sum)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := schema.ParseManifest([]byte(fmt.Sprintf(`{"registries":[{"name":"b","extensions":[{"name":"hooks","url":"https://b/hooks.json","sha256":%q}]}]}`, sum)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Merge(other); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	exts := m.Extensions()
+	if len(exts) != 2 || exts[0].Name != "flow" || exts[1].Name != "hooks" || exts[1].Registry != "b" {
+		t.Fatalf("Extensions() = %+v", exts)
+	}
+	if err := m.Merge(other); err == nil {
+		t.Fatal("merging a registry twice should fail")
+	}
+	if len(m.Extensions()) != 2 {
+		t.Error("a failed merge must leave the manifest unchanged")
+	}
+}
+
+func TestExtensionVerify(t *testing.T) {
+	body := []byte(`{"type":"object"}`)
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := schema.ParseManifest([]byte(fmt.Sprintf(`{"registries":[
+		{"name":"signed","public_key":%q,"extensions":[{"name":"flow","url":"https://a/flow.json","sha256":%q}]},
+		{"name":"plain","extensions":[{"name":"hooks","url":"https://b/hooks.json","sha256":%q}]}]}`,
+		base64.StdEncoding.EncodeToString(pub), sha256Hex(body), sha256Hex(body))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exts := m.Extensions()
+	flow, hooks := exts[0], exts[1]
+	if !flow.Signed() || hooks.Signed() {
+		t.Fatalf("Signed() = %v, %v", flow.Signed(), hooks.Signed())
+	}
+	if got := flow.SignatureLocation(); got != "https://a/flow.json.sig" {
+		t.Errorf("SignatureLocation() = %s", got)
+	}
+
+	sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, body)) + "\n")
+	if err := flow.Verify(body, sig); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+	if err := hooks.Verify(body, nil); err != nil {
+		t.Errorf("checksum-only extension rejected: %v", err)
+	}
+
+	swapped := []byte(`{"type":"object","additionalProperties":true}`)
+	if err := hooks.Verify(swapped, nil); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("swapped schema: err = %v", err)
+	}
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	forged := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(otherKey, body)))
+	if err := flow.Verify(body, forged); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("forged signature: err = %v", err)
+	}
+	if err := flow.Verify(body, nil); err == nil {
+		t.Error("a signed registry's schema must not verify without a signature")
+	}
+}
@@ -0,0 +1,332 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// Installed tools register the schemas of their grove.yml extension keys
+// by dropping a registration file into ExtensionsDir, typically from their
+// installer or with `core schema register`. The registration is the whole
+// contract: no daemon or RPC is involved, so a tool registered while no
+// grove process runs is still picked up by the next one. Composed and
+// ComposedResolvable add the registered schemas to the compiled-in ones,
+// and NewValidator and Lookup use the result, so a tool's config is
+// validated against the version installed rather than the one that was
+// current when this binary was built.
+
+// RegistrationExt is the file extension of registration files.
+const RegistrationExt = ".json"
+
+// toolNamePattern restricts tool names to what is safe as a file name.
+var toolNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Registration is the on-disk shape of one tool's registration file,
+// <ExtensionsDir>/<tool>.json.
+type Registration struct {
+	// Tool is the registering tool's name and the registration file's base
+	// name.
+	Tool    string `json:"tool"`
+	Version string `json:"version,omitempty"`
+	// Extensions are the grove.yml keys the tool owns.
+	Extensions []RegisteredExtension `json:"extensions"`
+
+	// Path is the file the registration was read from.
+	Path string `json:"-"`
+}
+
+// RegisteredExtension is the schema of one extension key. Exactly one of
+// Schema and SchemaPath is set.
+type RegisteredExtension struct {
+	// Name is the top-level grove.yml key the schema describes.
+	Name string `json:"name"`
+	// Schema is the JSON schema inline.
+	Schema json.RawMessage `json:"schema,omitempty"`
+	// SchemaPath is a schema file installed with the tool, absolute or
+	// relative to the registration file.
+	SchemaPath string `json:"schema_path,omitempty"`
+	// URL is where the schema is published. The resolvable schema refers
+	// to it so editors fetch it themselves; without one the schema is
+	// inlined there too.
+	URL string `json:"url,omitempty"`
+}
+
+// ExtensionsDir returns the directory tools register their extension
+// schemas in (~/.config/grove/extensions.d).
+func ExtensionsDir() string {
+	return filepath.Join(paths.ConfigDir(), "extensions.d")
+}
+
+// ParseRegistration decodes and validates a registration read from path,
+// resolving SchemaPath relative to it and loading the schema inline. Each
+// schema must compile and may not claim a key defined by core itself.
+func ParseRegistration(data []byte, path string) (*Registration, error) {
+	var reg Registration
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reg); err != nil {
+		return nil, fmt.Errorf("invalid extension registration: %w", err)
+	}
+	reg.Path = path
+	if !toolNamePattern.MatchString(reg.Tool) {
+		return nil, fmt.Errorf("invalid tool name %q: use lowercase letters, digits, '.', '_' and '-'", reg.Tool)
+	}
+	if len(reg.Extensions) == 0 {
+		return nil, fmt.Errorf("tool %q registers no extensions", reg.Tool)
+	}
+
+	core, err := coreKeys()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for i := range reg.Extensions {
+		ext := &reg.Extensions[i]
+		if ext.Name == "" {
+			return nil, fmt.Errorf("tool %q: extension without a name", reg.Tool)
+		}
+		if seen[ext.Name] {
+			return nil, fmt.Errorf("tool %q: extension %q is listed twice", reg.Tool, ext.Name)
+		}
+		seen[ext.Name] = true
+		if core[ext.Name] {
+			return nil, fmt.Errorf("tool %q: %q is a core config key and cannot be registered", reg.Tool, ext.Name)
+		}
+		switch {
+		case len(ext.Schema) > 0 && ext.SchemaPath != "":
+			return nil, fmt.Errorf("extension %q: set schema or schema_path, not both", ext.Name)
+		case ext.SchemaPath != "":
+			schemaPath := ext.SchemaPath
+			if !filepath.IsAbs(schemaPath) {
+				schemaPath = filepath.Join(filepath.Dir(path), schemaPath)
+			}
+			body, err := os.ReadFile(schemaPath)
+			if err != nil {
+				return nil, fmt.Errorf("extension %q: failed to read schema: %w", ext.Name, err)
+			}
+			ext.SchemaPath = schemaPath
+			ext.Schema = body
+		case len(ext.Schema) == 0:
+			return nil, fmt.Errorf("extension %q: missing schema or schema_path", ext.Name)
+		}
+		if err := compileExtension(ext); err != nil {
+			return nil, err
+		}
+	}
+	return &reg, nil
+}
+
+// compileExtension checks that ext's schema is a JSON schema that
+// compiles on its own, so one broken registration cannot break validation
+// of every config.
+func compileExtension(ext *RegisteredExtension) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(ext.Schema, &doc); err != nil {
+		return fmt.Errorf("extension %q: schema is not a JSON object: %w", ext.Name, err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(ext.Name+".json", bytes.NewReader(ext.Schema)); err != nil {
+		return fmt.Errorf("extension %q: %w", ext.Name, err)
+	}
+	if _, err := compiler.Compile(ext.Name + ".json"); err != nil {
+		return fmt.Errorf("extension %q: schema does not compile: %w", ext.Name, err)
+	}
+	return nil
+}
+
+// LoadRegistrations reads every registration in dir, sorted by file name.
+// A missing dir has no registrations. Files that fail to parse, and
+// extensions already claimed by an earlier file, are left out and reported
+// in the returned error; the rest are still returned.
+func LoadRegistrations(dir string) ([]Registration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read extension registrations: %w", err)
+	}
+
+	var regs []Registration
+	var errs []error
+	owner := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != RegistrationExt {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		reg, err := ParseRegistration(data, path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		kept := reg.Extensions[:0]
+		for _, ext := range reg.Extensions {
+			if prev, ok := owner[ext.Name]; ok {
+				errs = append(errs, fmt.Errorf("%s: extension %q is already registered by %q", path, ext.Name, prev))
+				continue
+			}
+			owner[ext.Name] = reg.Tool
+			kept = append(kept, ext)
+		}
+		reg.Extensions = kept
+		regs = append(regs, *reg)
+	}
+	return regs, errors.Join(errs...)
+}
+
+// Register validates reg and writes it to dir as <tool>.json, replacing
+// any earlier registration of the same tool. Extensions with a SchemaPath
+// keep referring to the file, so reinstalling the tool updates the schema
+// without registering again. It returns the file written.
+func Register(dir string, reg *Registration) (string, error) {
+	out := *reg
+	out.Extensions = make([]RegisteredExtension, len(reg.Extensions))
+	for i, ext := range reg.Extensions {
+		if ext.SchemaPath != "" {
+			abs, err := filepath.Abs(ext.SchemaPath)
+			if err != nil {
+				return "", fmt.Errorf("extension %q: %w", ext.Name, err)
+			}
+			ext.SchemaPath, ext.Schema = abs, nil
+		}
+		out.Extensions[i] = ext
+	}
+	data, err := json.MarshalIndent(&out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode extension registration: %w", err)
+	}
+	path := filepath.Join(dir, reg.Tool+RegistrationExt)
+	if _, err := ParseRegistration(data, path); err != nil {
+		return "", err
+	}
+	others, _ := LoadRegistrations(dir)
+	for _, other := range others {
+		if other.Tool == reg.Tool {
+			continue
+		}
+		for _, theirs := range other.Extensions {
+			for _, ours := range reg.Extensions {
+				if theirs.Name == ours.Name {
+					return "", fmt.Errorf("extension %q is already registered by %q", ours.Name, other.Tool)
+				}
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil { //nolint:gosec // registrations are not sensitive
+		return "", fmt.Errorf("failed to write extension registration: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("failed to write extension registration: %w", err)
+	}
+	return path, nil
+}
+
+// Unregister removes tool's registration from dir.
+func Unregister(dir, tool string) error {
+	if !toolNamePattern.MatchString(tool) {
+		return fmt.Errorf("invalid tool name %q", tool)
+	}
+	if err := os.Remove(filepath.Join(dir, tool+RegistrationExt)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no extension registration for %q in %s", tool, dir)
+		}
+		return fmt.Errorf("failed to remove extension registration: %w", err)
+	}
+	return nil
+}
+
+// AddRegistered sets root's property for every registered extension: the
+// schema inline, or with resolvable a $ref to its published URL when it
+// has one. Registered schemas replace compiled-in ones of the same key.
+func AddRegistered(root map[string]interface{}, regs []Registration, resolvable bool) error {
+	props, _ := root["properties"].(map[string]interface{})
+	if props == nil {
+		props = make(map[string]interface{})
+		root["properties"] = props
+	}
+	for _, reg := range regs {
+		for _, ext := range reg.Extensions {
+			if resolvable && ext.URL != "" {
+				props[ext.Name] = map[string]interface{}{"$ref": ext.URL}
+				continue
+			}
+			var doc map[string]interface{}
+			if err := json.Unmarshal(ext.Schema, &doc); err != nil {
+				return fmt.Errorf("extension %q: %w", ext.Name, err)
+			}
+			props[ext.Name] = doc
+		}
+	}
+	return nil
+}
+
+// Composed returns the bundled schema with the extensions registered in
+// ExtensionsDir added. Registrations that fail to load are skipped; with
+// none it is Bundled.
+func Composed() []byte {
+	return compose(Bundled(), false)
+}
+
+// ComposedResolvable is Composed for the resolvable schema.
+func ComposedResolvable() []byte {
+	return compose(Resolvable(), true)
+}
+
+func compose(base []byte, resolvable bool) []byte {
+	regs, _ := LoadRegistrations(ExtensionsDir())
+	if len(regs) == 0 {
+		return base
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(base, &root); err != nil {
+		return base
+	}
+	if err := AddRegistered(root, regs, resolvable); err != nil {
+		return base
+	}
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return base
+	}
+	return data
+}
+
+// coreKeys returns the top-level keys the resolvable schema defines itself
+// rather than by an external $ref to an extension schema.
+func coreKeys() (map[string]bool, error) {
+	var root struct {
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(Resolvable(), &root); err != nil {
+		return nil, fmt.Errorf("failed to parse resolvable schema: %w", err)
+	}
+	keys := make(map[string]bool, len(root.Properties))
+	for name, prop := range root.Properties {
+		if ref, _ := prop["$ref"].(string); ref != "" && !strings.HasPrefix(ref, "#") {
+			continue
+		}
+		keys[name] = true
+	}
+	return keys, nil
+}
@@ -0,0 +1,155 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/core/schema"
+)
+
+const widgetSchema = `{
+  "type": "object",
+  "properties": {"size": {"type": "integer"}},
+  "additionalProperties": false
+}`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseRegistration(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "widget.schema.json"), widgetSchema)
+
+	reg, err := schema.ParseRegistration([]byte(`{"tool":"widget","extensions":[{"name":"widget","schema_path":"widget.schema.json"}]}`),
+		filepath.Join(dir, "widget.json"))
+	if err != nil {
+		t.Fatalf("ParseRegistration: %v", err)
+	}
+	if got := reg.Extensions[0].SchemaPath; got != filepath.Join(dir, "widget.schema.json") {
+		t.Errorf("schema_path should resolve against the registration, got %s", got)
+	}
+	if len(reg.Extensions[0].Schema) == 0 {
+		t.Error("expected the schema file loaded inline")
+	}
+
+	tests := []struct {
+		name, data, wantErr string
+	}{
+		{"bad tool name", `{"tool":"../x","extensions":[{"name":"x","schema":{}}]}`, "invalid tool name"},
+		{"no extensions", `{"tool":"x","extensions":[]}`, "no extensions"},
+		{"core key", `{"tool":"x","extensions":[{"name":"logging","schema":{}}]}`, "core config key"},
+		{"missing schema", `{"tool":"x","extensions":[{"name":"x"}]}`, "missing schema"},
+		{"both", `{"tool":"x","extensions":[{"name":"x","schema":{},"schema_path":"a.json"}]}`, "not both"},
+		{"does not compile", `{"tool":"x","extensions":[{"name":"x","schema":{"type":7}}]}`, "does not compile"},
+		{"unknown field", `{"tool":"x","extension":[]}`, "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.ParseRegistration([]byte(tt.data), filepath.Join(dir, "x.json"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRegisterAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "extensions.d")
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "widget.schema.json"), widgetSchema)
+
+	reg := &schema.Registration{
+		Tool:       "widget",
+		Version:    "1.0.0",
+		Extensions: []schema.RegisteredExtension{{Name: "widget", SchemaPath: filepath.Join(src, "widget.schema.json")}},
+	}
+	path, err := schema.Register(dir, reg)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if path != filepath.Join(dir, "widget.json") {
+		t.Errorf("unexpected registration path %s", path)
+	}
+
+	_, err = schema.Register(dir, &schema.Registration{
+		Tool:       "gadget",
+		Extensions: []schema.RegisteredExtension{{Name: "widget", Schema: json.RawMessage(`{}`)}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `already registered by "widget"`) {
+		t.Errorf("expected a conflict with the widget registration, got %v", err)
+	}
+
+	// A broken file is reported but does not hide the others.
+	writeFile(t, filepath.Join(dir, "broken.json"), `{"tool":`)
+	regs, err := schema.LoadRegistrations(dir)
+	if err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("expected the broken registration reported, got %v", err)
+	}
+	if len(regs) != 1 || regs[0].Tool != "widget" || regs[0].Version != "1.0.0" {
+		t.Fatalf("expected the widget registration, got %+v", regs)
+	}
+
+	if err := schema.Unregister(dir, "widget"); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if err := schema.Unregister(dir, "widget"); err == nil {
+		t.Error("expected an error unregistering twice")
+	}
+}
+
+func TestLoadRegistrationsMissingDir(t *testing.T) {
+	regs, err := schema.LoadRegistrations(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || regs != nil {
+		t.Errorf("expected no registrations and no error, got %v, %v", regs, err)
+	}
+}
+
+func TestValidatorUsesRegisteredExtensions(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	reg := &schema.Registration{
+		Tool: "widget",
+		Extensions: []schema.RegisteredExtension{{
+			Name:   "widget",
+			Schema: json.RawMessage(widgetSchema),
+			URL:    "https://example.com/widget.schema.json",
+		}},
+	}
+	if _, err := schema.Register(schema.ExtensionsDir(), reg); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	v, err := schema.NewValidator()
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	if err := v.Validate(map[string]interface{}{"widget": map[string]interface{}{"size": 3}}); err != nil {
+		t.Errorf("valid widget config rejected: %v", err)
+	}
+	if err := v.Validate(map[string]interface{}{"widget": map[string]interface{}{"size": "big"}}); err == nil {
+		t.Error("expected the registered schema to reject a string size")
+	}
+
+	if _, err := schema.Lookup("widget.size"); err != nil {
+		t.Errorf("Lookup of a registered key: %v", err)
+	}
+
+	var resolvable struct {
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema.ComposedResolvable(), &resolvable); err != nil {
+		t.Fatal(err)
+	}
+	if ref := resolvable.Properties["widget"]["$ref"]; ref != "https://example.com/widget.schema.json" {
+		t.Errorf("expected the resolvable schema to reference the published URL, got %v", ref)
+	}
+}
@@ -1,7 +1,7 @@
 package schema
 
 import (
-	_ "embed"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -10,18 +10,16 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-//go:embed grove.embedded.schema.json
-var embeddedSchemaData []byte
-
 // Validator validates configuration against the embedded JSON Schema.
 type Validator struct {
 	schema *jsonschema.Schema
 }
 
-// NewValidator creates a new schema validator, loading the embedded schema.
+// NewValidator creates a new schema validator, loading the embedded schema
+// with the extensions registered by installed tools added (see Composed).
 func NewValidator() (*Validator, error) {
 	compiler := jsonschema.NewCompiler()
-	if err := compiler.AddResource("grove.json", strings.NewReader(string(embeddedSchemaData))); err != nil {
+	if err := compiler.AddResource("grove.json", bytes.NewReader(Composed())); err != nil {
 		return nil, fmt.Errorf("failed to add embedded schema resource: %w", err)
 	}
 
@@ -55,9 +55,11 @@ func dirIsEcosystemRoot(dir string) bool {
 		"grove.toml",
 		"grove.yml",
 		"grove.yaml",
+		"grove.json5",
 		".grove.toml",
 		".grove.yml",
 		".grove.yaml",
+		".grove.json5",
 	}
 	for _, m := range markers {
 		if info, err := os.Stat(filepath.Join(dir, m)); err == nil && !info.IsDir() {
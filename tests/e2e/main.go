@@ -75,6 +75,9 @@ func main() {
 		NbTomlsRelaxedDiscoveryScenario(),
 		NbTomlsNotebookConfigScenario(),
 		NbTomlsFullFeatureFlowScenario(),
+
+		// Synthetic fixture scenarios
+		FixturesDiscoveryScenario(),
 	}
 
 	// Setup signal handling for graceful shutdown.
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grovetools/tend/pkg/harness"
+
+	"github.com/grovetools/core/pkg/fixtures"
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// fixtureEnv returns the Env of m as KEY=value pairs for a command.
+func fixtureEnv(m *fixtures.Manifest) []string {
+	env := []string{"HOME=" + m.Root}
+	for k, v := range m.Env {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+// FixturesDiscoveryScenario generates a synthetic tree with pkg/fixtures and
+// checks that `core ws` and `core logs` see exactly what the manifest lists,
+// and that `core dev fixtures` reproduces the same tree from the same seed.
+func FixturesDiscoveryScenario() *harness.Scenario {
+	return &harness.Scenario{
+		Name:        "core-dev-fixtures",
+		Description: "Generates a synthetic workspace tree and verifies discovery and logs against its manifest.",
+		Tags:        []string{"core", "workspace", "logging", "fixtures"},
+		Steps: []harness.Step{
+			harness.NewStep("Generate fixture", func(ctx *harness.Context) error {
+				spec := fixtures.DefaultSpec()
+				spec.Logs.EntriesPerWorkspace = 20
+				m, err := fixtures.Generate(filepath.Join(ctx.RootDir, "fixture"), spec)
+				if err != nil {
+					return err
+				}
+				ctx.Set("manifest", m)
+				return nil
+			}),
+			harness.NewStep("Discover fixture workspaces", func(ctx *harness.Context) error {
+				m := ctx.Get("manifest").(*fixtures.Manifest)
+				result := ctx.Bin("ws", "--json").Dir(m.Root).Env(fixtureEnv(m)...).Run()
+				ctx.ShowCommandOutput("core ws --json", result.Stdout, result.Stderr)
+				if err := result.AssertSuccess(); err != nil {
+					return err
+				}
+				var nodes []workspace.WorkspaceNode
+				if err := json.Unmarshal([]byte(result.Stdout), &nodes); err != nil {
+					return fmt.Errorf("failed to parse ws JSON: %w", err)
+				}
+				kinds := make(map[string]workspace.WorkspaceKind, len(nodes))
+				for _, n := range nodes {
+					kinds[n.Path] = n.Kind
+				}
+				for _, w := range m.Workspaces {
+					if err := ctx.Check(fmt.Sprintf("%s discovered as %s", w.Identifier, w.Kind), func() error {
+						if kinds[w.Path] != w.Kind {
+							return fmt.Errorf("got kind %q", kinds[w.Path])
+						}
+						return nil
+					}()); err != nil {
+						return err
+					}
+				}
+				return nil
+			}),
+			harness.NewStep("Read fixture logs", func(ctx *harness.Context) error {
+				m := ctx.Get("manifest").(*fixtures.Manifest)
+				w := m.Workspaces[0]
+				result := ctx.Bin("logs", "--json", "--show-all", "-w", w.Path).Dir(w.Path).Env(fixtureEnv(m)...).Run()
+				ctx.ShowCommandOutput("core logs --json", result.Stdout, result.Stderr)
+				if err := result.AssertSuccess(); err != nil {
+					return err
+				}
+				lines := strings.Count(strings.TrimSpace(result.Stdout), "\n") + 1
+				return ctx.Check("logs holds the generated entries", func() error {
+					if lines < w.LogEntries {
+						return fmt.Errorf("got %d lines, want at least %d", lines, w.LogEntries)
+					}
+					return nil
+				}())
+			}),
+			harness.NewStep("Regenerate with core dev fixtures", func(ctx *harness.Context) error {
+				m := ctx.Get("manifest").(*fixtures.Manifest)
+				dir := filepath.Join(ctx.RootDir, "fixture-cli")
+				result := ctx.Bin("dev", "fixtures", dir, "--entries", "20").Run()
+				ctx.ShowCommandOutput("core dev fixtures", result.Stdout, result.Stderr)
+				if err := result.AssertSuccess(); err != nil {
+					return err
+				}
+				for _, w := range m.Workspaces {
+					rel, err := filepath.Rel(m.Root, filepath.Join(w.Path, w.ConfigFile))
+					if err != nil {
+						return err
+					}
+					want, err := os.ReadFile(filepath.Join(m.Root, rel))
+					if err != nil {
+						return err
+					}
+					got, err := os.ReadFile(filepath.Join(dir, rel))
+					if err := ctx.Check(rel+" matches", func() error {
+						if err != nil {
+							return err
+						}
+						if string(got) != string(want) {
+							return fmt.Errorf("content differs")
+						}
+						return nil
+					}()); err != nil {
+						return err
+					}
+				}
+				return nil
+			}),
+		},
+	}
+}
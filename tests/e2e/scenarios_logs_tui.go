@@ -279,10 +279,17 @@ logging:
 					return fmt.Errorf("follow mode indicator did not appear: %w\nContent: %s", err, content)
 				}
 
-				// Press 'F' again to toggle it off
+				// 'F' cycles on through the pause and resume modes, then off
 				if err := session.SendKeys("F"); err != nil {
 					return fmt.Errorf("failed to send F key again: %w", err)
 				}
+				if err := session.WaitForText("[Follow:PAUSE]", 2*time.Second); err != nil {
+					content, _ := session.Capture()
+					return fmt.Errorf("follow pause mode indicator did not appear: %w\nContent: %s", err, content)
+				}
+				if err := session.SendKeys("F", "F"); err != nil {
+					return fmt.Errorf("failed to send F keys: %w", err)
+				}
 
 				// Wait for UI to stabilize
 				if err := session.WaitStable(); err != nil {
@@ -317,7 +324,7 @@ logging:
 				}
 
 				// Verify help is showing by checking for a visible heading.
-				// "toggle follow" may be below the fold depending on terminal height.
+				// "cycle follow mode" may be below the fold depending on terminal height.
 				if err := session.AssertContains("Navigation"); err != nil {
 					content, _ := session.Capture()
 					return fmt.Errorf("help menu content missing: %w\nContent: %s", err, content)
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/grovetools/core/util/frontmatter"
+)
+
+func main() {
+	r := &jsonschema.Reflector{
+		AllowAdditionalProperties:  true,
+		ExpandedStruct:             true,
+		FieldNameTag:               "yaml",
+		RequiredFromJSONSchemaTags: true,
+	}
+
+	job := r.Reflect(&frontmatter.JobFrontmatter{})
+	note := r.Reflect(&frontmatter.NoteFrontmatter{})
+	job.Version, note.Version = "", ""
+	job.ID, note.ID = "", ""
+
+	// The allowed values live in the frontmatter package, where Validate
+	// reads them too.
+	setEnum(job, "type", frontmatter.JobTypes)
+	for _, s := range []*jsonschema.Schema{job, note} {
+		setEnum(s, "status", frontmatter.Statuses)
+		setEnum(s, "priority", frontmatter.Priorities)
+	}
+
+	// A file is a job when it has a type, and a note otherwise.
+	schema := &jsonschema.Schema{
+		Version:     jsonschema.Version,
+		Title:       "Grove Frontmatter",
+		Description: "Schema for the YAML frontmatter of flow job files and notebook notes.",
+		Definitions: jsonschema.Definitions{"job": job, "note": note},
+		If:          &jsonschema.Schema{Required: []string{"type"}},
+		Then:        &jsonschema.Schema{Ref: "#/$defs/job"},
+		Else:        &jsonschema.Schema{Ref: "#/$defs/note"},
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		log.Fatalf("Error marshaling schema: %v", err)
+	}
+
+	path := "util/frontmatter/frontmatter.schema.json"
+	if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // schema file is not sensitive
+		log.Fatalf("Error writing schema file: %v", err)
+	}
+
+	log.Printf("Successfully generated frontmatter schema at %s", path)
+}
+
+func setEnum(s *jsonschema.Schema, key string, values []string) {
+	prop, ok := s.Properties.Get(key)
+	if !ok {
+		log.Fatalf("schema has no %q property", key)
+	}
+	prop.Enum = make([]any, len(values))
+	for i, v := range values {
+		prop.Enum[i] = v
+	}
+}
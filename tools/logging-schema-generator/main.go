@@ -7,6 +7,7 @@ import (
 
 	"github.com/invopop/jsonschema"
 
+	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/logging"
 )
 
@@ -24,6 +25,11 @@ func main() {
 	// Make all fields optional - Grove configs should not require any fields
 	schema.Required = nil
 
+	// Defaults are declared with default tags on logging.Config's fields.
+	if err := config.AddSchemaDefaults(schema, &logging.Config{}); err != nil {
+		log.Fatalf("Error adding schema defaults: %v", err)
+	}
+
 	data, err := json.MarshalIndent(schema, "", "  ")
 	if err != nil {
 		log.Fatalf("Error marshaling schema: %v", err)
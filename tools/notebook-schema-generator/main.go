@@ -24,6 +24,11 @@ func main() {
 	// Make all fields optional - Grove configs should not require any fields
 	schema.Required = nil
 
+	// Defaults are declared with default tags on config.Notebook's fields.
+	if err := config.AddSchemaDefaults(schema, &config.Notebook{}); err != nil {
+		log.Fatalf("Error adding schema defaults: %v", err)
+	}
+
 	data, err := json.MarshalIndent(schema, "", "  ")
 	if err != nil {
 		log.Fatalf("Error marshaling schema: %v", err)
@@ -1,19 +1,79 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/grovetools/core/pkg/httpx"
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/grovetools/core/pkg/progressx"
 	groveSchema "github.com/grovetools/core/schema"
 )
 
+// manifestFlags collects the repeatable -manifest flag.
+type manifestFlags []string
+
+func (f *manifestFlags) String() string { return strings.Join(*f, ",") }
+
+func (f *manifestFlags) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
 func main() {
+	var manifests manifestFlags
+	flag.Var(&manifests, "manifest", "additional registry manifest to compose extensions from (repeatable)")
+	timeout := flag.Duration("timeout", httpx.DefaultTimeout, "timeout for each extension schema fetch attempt")
+	retries := flag.Int("retries", httpx.DefaultRetries, "retries per extension schema after a failed fetch")
+	minInterval := flag.Duration("min-interval", 0, "minimum delay between requests to the same host")
+	cacheDir := flag.String("cache-dir", filepath.Join(paths.CacheDir(), "schema-composer"), "directory for ETag-cached schemas (empty disables caching)")
+	extensionsDir := flag.String("extensions-dir", "", "also compose the extension schemas registered in this directory (e.g. ~/.config/grove/extensions.d)")
+	flag.Parse()
+
+	// httpx treats 0 retries as "use the default"; -retries=0 means none.
+	if *retries == 0 {
+		*retries = -1
+	}
+	// Cached copies stand in for endpoints that stay down through every
+	// retry, so one flaky registry does not fail the whole composition.
+	client := httpx.New(httpx.Options{
+		Timeout:      *timeout,
+		Retries:      *retries,
+		MinInterval:  *minInterval,
+		CacheDir:     *cacheDir,
+		StaleOnError: true,
+	})
+
+	manifest, err := groveSchema.DefaultManifest()
+	if err != nil {
+		log.Fatalf("Failed to load the registry manifest: %v", err)
+	}
+	for _, path := range manifests {
+		extra, err := groveSchema.LoadManifest(path)
+		if err != nil {
+			log.Fatalf("Failed to load registry manifest: %v", err)
+		}
+		if err := manifest.Merge(extra); err != nil {
+			log.Fatalf("Failed to merge registry manifest %s: %v", path, err)
+		}
+	}
+	extensions := manifest.Extensions()
+
+	var registered []groveSchema.Registration
+	if *extensionsDir != "" {
+		registered, err = groveSchema.LoadRegistrations(*extensionsDir)
+		if err != nil {
+			log.Fatalf("Failed to load extension registrations: %v", err)
+		}
+	}
+
 	log.Println("Starting schema composition...")
 
 	baseSchemaPath := "schema/definitions/base.schema.json"
@@ -25,10 +85,16 @@ func main() {
 	}
 
 	// 1. Generate the resolvable schema (with remote $refs) for IDEs.
-	resolvableSchema, err := createResolvableSchema(baseSchemaPath)
+	resolvableSchema, err := createResolvableSchema(baseSchemaPath, extensions)
 	if err != nil {
 		log.Fatalf("Failed to create resolvable schema: %v", err)
 	}
+	// Registered schemas are local, so they are added after the bundled
+	// copy is taken and need no fetching.
+	bundleBase := deepCopyMap(resolvableSchema)
+	if err := groveSchema.AddRegistered(resolvableSchema, registered, true); err != nil {
+		log.Fatalf("Failed to add registered extensions: %v", err)
+	}
 	resolvablePath := filepath.Join(distDir, "grove.schema.json")
 	if err := writeJSONFile(resolvablePath, resolvableSchema); err != nil {
 		log.Fatalf("Failed to write resolvable schema: %v", err)
@@ -36,10 +102,13 @@ func main() {
 	log.Printf("Generated resolvable schema at %s", resolvablePath)
 
 	// 2. Generate the bundled schema (with resolved $refs) for embedding.
-	bundledSchema, err := createBundledSchema(resolvableSchema)
+	bundledSchema, err := createBundledSchema(client, bundleBase, extensions)
 	if err != nil {
 		log.Fatalf("Failed to create bundled schema: %v", err)
 	}
+	if err := groveSchema.AddRegistered(bundledSchema, registered, false); err != nil {
+		log.Fatalf("Failed to add registered extensions: %v", err)
+	}
 	bundledPath := "schema/grove.embedded.schema.json"
 	if err := writeJSONFile(bundledPath, bundledSchema); err != nil {
 		log.Fatalf("Failed to write bundled schema: %v", err)
@@ -49,7 +118,7 @@ func main() {
 	log.Println("Schema composition complete.")
 }
 
-func createResolvableSchema(basePath string) (map[string]interface{}, error) {
+func createResolvableSchema(basePath string, extensions []groveSchema.Extension) (map[string]interface{}, error) {
 	baseBytes, err := os.ReadFile(basePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not read base schema: %w", err)
@@ -67,9 +136,9 @@ func createResolvableSchema(basePath string) (map[string]interface{}, error) {
 	properties := schema["properties"].(map[string]interface{})
 
 	// Add extension properties with remote $ref
-	for key, url := range groveSchema.ExtensionSchemaURLs {
-		properties[key] = map[string]interface{}{
-			"$ref": url,
+	for _, ext := range extensions {
+		properties[ext.Name] = map[string]interface{}{
+			"$ref": ext.URL,
 		}
 	}
 
@@ -81,62 +150,67 @@ func createResolvableSchema(basePath string) (map[string]interface{}, error) {
 	return schema, nil
 }
 
-func createBundledSchema(resolvableSchema map[string]interface{}) (map[string]interface{}, error) {
+func createBundledSchema(client *httpx.Client, resolvableSchema map[string]interface{}, extensions []groveSchema.Extension) (map[string]interface{}, error) {
 	bundledSchema := deepCopyMap(resolvableSchema)
 
 	// If there are no extension schemas to fetch, just return the base schema
-	if len(groveSchema.ExtensionSchemaURLs) == 0 {
+	if len(extensions) == 0 {
 		return bundledSchema, nil
 	}
 
 	properties := bundledSchema["properties"].(map[string]interface{})
 
 	var wg sync.WaitGroup
-	errs := make(chan error, len(groveSchema.ExtensionSchemaURLs))
+	errs := make(chan error, len(extensions))
 	var mu sync.Mutex
 
-	for key, url := range groveSchema.ExtensionSchemaURLs {
+	progress := progressx.Start("Fetching extension schemas", len(extensions), progressx.Options{})
+	for _, ext := range extensions {
 		wg.Add(1)
-		go func(key, url string) {
+		go func(ext groveSchema.Extension) {
 			defer wg.Done()
-			log.Printf("Fetching schema for '%s' from %s", key, url)
+			defer progress.Add(1)
+			progress.Describe(ext.Name)
 
-			resp, err := http.Get(url) //nolint:gosec // URL from trusted config
+			body, err := client.Get(context.Background(), ext.URL)
 			if err != nil {
-				errs <- fmt.Errorf("failed to fetch schema for %s: %w", key, err)
+				errs <- fmt.Errorf("failed to fetch schema for %s: %w", ext.Name, err)
 				return
 			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				errs <- fmt.Errorf("bad status fetching schema for %s: %s", key, resp.Status)
-				return
+			var sig []byte
+			if ext.Signed() {
+				if sig, err = client.Get(context.Background(), ext.SignatureLocation()); err != nil {
+					errs <- fmt.Errorf("failed to fetch schema signature for %s: %w", ext.Name, err)
+					return
+				}
 			}
-
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				errs <- fmt.Errorf("failed to read schema body for %s: %w", key, err)
+			// Verify before parsing, so neither a swapped endpoint nor a
+			// stale cached copy reaches the embedded schema.
+			if err := ext.Verify(body, sig); err != nil {
+				errs <- err
 				return
 			}
 
 			var subSchema map[string]interface{}
 			if err := json.Unmarshal(body, &subSchema); err != nil {
-				errs <- fmt.Errorf("failed to parse schema for %s: %w", key, err)
+				errs <- fmt.Errorf("failed to parse schema for %s: %w", ext.Name, err)
 				return
 			}
 
 			mu.Lock()
-			properties[key] = subSchema
+			properties[ext.Name] = subSchema
 			mu.Unlock()
-		}(key, url)
+		}(ext)
 	}
 
 	wg.Wait()
 	close(errs)
 
 	for err := range errs {
+		progress.Fail(err)
 		return nil, err
 	}
+	progress.Done()
 
 	return bundledSchema, nil
 }
@@ -0,0 +1,155 @@
+package jsontree
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Filter mode prunes the tree to the paths that contain a match for the
+// active search: matching nodes, their ancestors (expanded, for context)
+// and everything under a node whose own key or value matched. Containers
+// show how many matches they hold, so a large payload can be narrowed
+// down without stepping through every hit with n/N.
+
+// matchesQuery reports whether n's key or, for a leaf, its value contains
+// query, which must already be lower-cased.
+func matchesQuery(n *node, query string) bool {
+	if strings.Contains(strings.ToLower(n.key), query) {
+		return true
+	}
+	if n.valueType == "object" || n.valueType == "array" {
+		return false
+	}
+	return strings.Contains(strings.ToLower(fmt.Sprintf("%v", n.value)), query)
+}
+
+// markMatches records on n and every node below it whether it matches
+// query, how many matches its subtree holds and whether filter mode keeps
+// it. inMatch is set under a node that matched itself. It returns the
+// number of matches in n's subtree.
+func markMatches(n *node, query string, inMatch bool) int {
+	n.matched = matchesQuery(n, query)
+	count := 0
+	if n.matched {
+		count = 1
+	}
+	for _, c := range n.children {
+		count += markMatches(c, query, inMatch || n.matched)
+	}
+	n.subtreeMatches = count
+	n.filterKeep = inMatch || count > 0
+	return count
+}
+
+// expandMatches uncollapses every container with a match below it.
+func expandMatches(n *node) {
+	inner := n.subtreeMatches
+	if n.matched {
+		inner--
+	}
+	if inner > 0 {
+		n.collapsed = false
+		for _, c := range n.children {
+			expandMatches(c)
+		}
+	}
+}
+
+// innerMatches returns the number of matches below n, excluding n itself.
+func innerMatches(n *node) int {
+	if n.matched {
+		return n.subtreeMatches - 1
+	}
+	return n.subtreeMatches
+}
+
+// toggleFilter turns filter mode on for the active search, or back off.
+func (m *Model) toggleFilter() tea.Cmd {
+	if m.filterMode {
+		m.setFilterMode(false)
+		return nil
+	}
+	if m.searchQuery == "" {
+		m.statusMessage = "Search with / before filtering"
+		return m.clearStatusAfter()
+	}
+	if !m.applyFilter() {
+		m.statusMessage = fmt.Sprintf("No matches for /%s", m.searchQuery)
+		return m.clearStatusAfter()
+	}
+	return nil
+}
+
+// applyFilter marks the matches of the active search and, when there are
+// any, enters filter mode with the cursor on the first one. It reports
+// whether filter mode is on.
+func (m *Model) applyFilter() bool {
+	if m.root == nil {
+		return false
+	}
+	m.filterMatches = markMatches(m.root, strings.ToLower(m.searchQuery), false)
+	if m.filterMatches == 0 {
+		if m.filterMode {
+			m.setFilterMode(false)
+		}
+		return false
+	}
+	expandMatches(m.root)
+	m.filterMode = true
+	m.flatten()
+	m.cursor = 0
+	m.performSearch()
+	m.updateContent()
+	return true
+}
+
+// setFilterMode leaves or enters filter mode, keeping the cursor on the
+// node it was on.
+func (m *Model) setFilterMode(on bool) {
+	current := m.cursorNode()
+	m.filterMode = on
+	m.flatten()
+	m.cursor = 0
+	for i, n := range m.nodes {
+		if n == current {
+			m.cursor = i
+			break
+		}
+	}
+	if m.searchQuery != "" {
+		cursor := m.cursor
+		m.performSearch()
+		m.cursor = cursor
+		for i, r := range m.searchResults {
+			if r == cursor {
+				m.currentResult = i
+			}
+		}
+	}
+	m.updateContent()
+}
+
+// flatten rebuilds the visible node list, pruned to the filter in filter
+// mode.
+func (m *Model) flatten() {
+	var keep func(*node) bool
+	if m.filterMode {
+		keep = func(n *node) bool { return n.filterKeep }
+	}
+	m.nodes = flattenTree(m.root, keep)
+	if m.cursor >= len(m.nodes) {
+		m.cursor = len(m.nodes) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func pluralMatches(n int) string {
+	if n == 1 {
+		return "match"
+	}
+	return "matches"
+}
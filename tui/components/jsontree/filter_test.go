@@ -0,0 +1,147 @@
+package jsontree
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func keyMsg(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func search(t *testing.T, m Model, query string) Model {
+	t.Helper()
+	updated, _ := m.Update(keyMsg("/"))
+	m = updated.(Model)
+	m.searchInput.SetValue(query)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(Model)
+}
+
+func visibleKeys(m Model) []string {
+	var keys []string
+	for _, n := range m.nodes {
+		if n.key != "" {
+			keys = append(keys, n.key)
+		}
+	}
+	return keys
+}
+
+func filterData() map[string]interface{} {
+	return map[string]interface{}{
+		"build": map[string]interface{}{
+			"target": "linux",
+			"flags":  []interface{}{"-race", "-v"},
+		},
+		"deploy": map[string]interface{}{
+			"region": "eu-west",
+			"hosts":  []interface{}{"web-1", "web-2", "db-1"},
+		},
+		"owner": "web team",
+	}
+}
+
+func TestFilterPrunesToMatchingPaths(t *testing.T) {
+	m := New(filterData())
+	m.SetSize(80, 40)
+	m = search(t, m, "web")
+
+	updated, _ := m.Update(keyMsg("F"))
+	m = updated.(Model)
+	if !m.filterMode {
+		t.Fatal("F should enter filter mode after a search")
+	}
+	if m.filterMatches != 3 {
+		t.Errorf("filterMatches = %d, want 3 (web-1, web-2, web team)", m.filterMatches)
+	}
+	got := strings.Join(visibleKeys(m), ",")
+	if want := "deploy,hosts,[0],[1],owner"; got != want {
+		t.Errorf("visible keys = %s, want %s", got, want)
+	}
+	if len(m.searchResults) != 3 {
+		t.Errorf("n/N should step through the 3 leaf matches, got %d results", len(m.searchResults))
+	}
+	if n := m.cursorNode(); n == nil || !n.matched {
+		t.Errorf("cursor should be on the first match, got %+v", n)
+	}
+
+	content := ansi.Strip(m.renderedContent)
+	if !strings.Contains(content, "deploy: { (2 matches)") {
+		t.Errorf("containers should show their match counts:\n%s", content)
+	}
+	if !strings.Contains(ansi.Strip(m.View()), "filtered to 3 matches") {
+		t.Errorf("status bar should report the filter:\n%s", ansi.Strip(m.View()))
+	}
+
+	// Esc leaves filter mode first, keeping the search.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.filterMode || cmd != nil || m.searchQuery != "web" {
+		t.Fatalf("esc should only leave filter mode: filter=%v cmd=%v query=%q", m.filterMode, cmd != nil, m.searchQuery)
+	}
+	if !strings.Contains(strings.Join(visibleKeys(m), ","), "build") {
+		t.Error("the full tree should be back after leaving filter mode")
+	}
+}
+
+func TestFilterKeepsSubtreeOfMatchingKey(t *testing.T) {
+	m := New(filterData())
+	m.SetSize(80, 40)
+	m = search(t, m, "build")
+	updated, _ := m.Update(keyMsg("F"))
+	m = updated.(Model)
+
+	build := m.nodes[m.cursor]
+	if build.key != "build" {
+		t.Fatalf("cursor on %q, want build", build.key)
+	}
+	// A matching container keeps its own fold state and content.
+	m.toggleNode(build)
+	got := strings.Join(visibleKeys(m), ",")
+	if want := "build,flags,target"; got != want {
+		t.Errorf("visible keys = %s, want %s", got, want)
+	}
+}
+
+func TestFilterNeedsMatchingSearch(t *testing.T) {
+	m := New(filterData())
+	m.SetSize(80, 40)
+
+	updated, _ := m.Update(keyMsg("F"))
+	m = updated.(Model)
+	if m.filterMode || !strings.Contains(m.statusMessage, "Search") {
+		t.Errorf("F without a search: filter=%v status=%q", m.filterMode, m.statusMessage)
+	}
+
+	m = search(t, m, "nothing-matches")
+	updated, _ = m.Update(keyMsg("F"))
+	m = updated.(Model)
+	if m.filterMode || !strings.Contains(m.statusMessage, "No matches") {
+		t.Errorf("F without matches: filter=%v status=%q", m.filterMode, m.statusMessage)
+	}
+}
+
+func TestNewSearchRefiltersInFilterMode(t *testing.T) {
+	m := New(filterData())
+	m.SetSize(80, 40)
+	m = search(t, m, "web")
+	updated, _ := m.Update(keyMsg("F"))
+	m = updated.(Model)
+
+	m = search(t, m, "race")
+	if !m.filterMode || m.filterMatches != 1 {
+		t.Fatalf("a new search should re-filter: filter=%v matches=%d", m.filterMode, m.filterMatches)
+	}
+	if got := strings.Join(visibleKeys(m), ","); got != "build,flags,[0]" {
+		t.Errorf("visible keys = %s", got)
+	}
+
+	m = search(t, m, "nothing-matches")
+	if m.filterMode {
+		t.Error("a search without matches should leave filter mode")
+	}
+}
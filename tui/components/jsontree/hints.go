@@ -0,0 +1,152 @@
+package jsontree
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/config"
+)
+
+// RenderHints selects the number fields shown in readable units, by glob
+// patterns (path.Match syntax) on their lower-cased key. Yanked values and
+// search always use the raw number; the ToggleRaw key ("H") switches the
+// view between readable and raw values.
+type RenderHints struct {
+	// Disabled starts the viewer with raw values.
+	Disabled bool
+	// Timestamps are unix times shown as RFC 3339 local times. Seconds,
+	// milliseconds, microseconds and nanoseconds are told apart by
+	// magnitude; numbers that are not plausible times stay raw.
+	Timestamps []string
+	// Durations are milliseconds shown like "1m23.4s".
+	Durations []string
+	// Bytes are byte counts shown like "1.5 MiB".
+	Bytes []string
+}
+
+// DefaultRenderHints returns the built-in key patterns.
+func DefaultRenderHints() RenderHints {
+	return RenderHints{
+		Timestamps: []string{"*_at", "ts", "timestamp", "time", "*_time", "*_ts", "*_unix", "unix_ms"},
+		Durations:  []string{"*_ms"},
+		Bytes:      []string{"*bytes"},
+	}
+}
+
+// RenderHintsFromConfig returns the hints configured under
+// tui.jsontree.render_hints, with the built-in patterns for kinds it does
+// not set. c may be nil.
+func RenderHintsFromConfig(c *config.TUIConfig) RenderHints {
+	h := DefaultRenderHints()
+	if c == nil || c.JSONTree == nil || c.JSONTree.RenderHints == nil {
+		return h
+	}
+	cfg := c.JSONTree.RenderHints
+	if cfg.Enabled != nil {
+		h.Disabled = !*cfg.Enabled
+	}
+	if cfg.Timestamps != nil {
+		h.Timestamps = cfg.Timestamps
+	}
+	if cfg.Durations != nil {
+		h.Durations = cfg.Durations
+	}
+	if cfg.Bytes != nil {
+		h.Bytes = cfg.Bytes
+	}
+	return h
+}
+
+// WithRenderHints sets the hints the viewer renders numbers with, in place
+// of DefaultRenderHints.
+func WithRenderHints(h RenderHints) Option {
+	return func(m *Model) {
+		m.hints = h
+		m.raw = h.Disabled
+	}
+}
+
+// readable returns the readable form of the number v under key, or ""
+// when raw values are shown or no hint applies.
+func (m *Model) readable(key string, v float64) string {
+	if m.raw {
+		return ""
+	}
+	return m.hints.format(key, v)
+}
+
+// Plausible unix time ranges, 2001-09-09 to 2286-11-20, in each unit.
+const (
+	minUnixSeconds = 1e9
+	maxUnixSeconds = 1e10
+)
+
+// format returns the readable form of the number v under key, or "" when
+// no hint applies.
+func (h RenderHints) format(key string, v float64) string {
+	key = strings.ToLower(key)
+	switch {
+	case matchesAny(h.Timestamps, key):
+		if t, ok := unixTime(v); ok {
+			return t.Local().Format(time.RFC3339)
+		}
+	case matchesAny(h.Durations, key):
+		if v >= 0 {
+			return formatMillis(v)
+		}
+	case matchesAny(h.Bytes, key):
+		if v >= 1024 {
+			return formatBytes(v)
+		}
+	}
+	return ""
+}
+
+func matchesAny(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unixTime interprets v as a unix time in seconds, milliseconds,
+// microseconds or nanoseconds, whichever puts it in the plausible range.
+func unixTime(v float64) (time.Time, bool) {
+	for _, unit := range []float64{1, 1e3, 1e6, 1e9} {
+		if v >= minUnixSeconds*unit && v < maxUnixSeconds*unit {
+			sec, frac := math.Modf(v / unit)
+			return time.Unix(int64(sec), int64(frac*1e9)), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatMillis renders a millisecond duration at a precision that suits
+// its size: "850ms", "12.3s", "1m23s".
+func formatMillis(ms float64) string {
+	d := time.Duration(ms * float64(time.Millisecond))
+	switch {
+	case d < time.Second:
+		return d.Round(time.Microsecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
+// formatBytes renders a byte count in binary units with one decimal.
+func formatBytes(n float64) string {
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	unit := -1
+	for n >= 1024 && unit < len(units)-1 {
+		n /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", n, units[unit])
+}
@@ -0,0 +1,75 @@
+package jsontree
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/config"
+)
+
+func TestRenderHintsFormat(t *testing.T) {
+	h := DefaultRenderHints()
+	at := time.Unix(1700000000, 0).Local().Format(time.RFC3339)
+	cases := []struct {
+		key  string
+		v    float64
+		want string
+	}{
+		{"created_at", 1700000000, at},
+		{"Timestamp", 1700000000000, at},
+		{"ts", 1700000000000000000, at},
+		{"created_at", 42, ""}, // not a plausible time
+		{"duration_ms", 850, "850ms"},
+		{"elapsed_ms", 12345, "12.3s"},
+		{"elapsed_ms", 83400, "1m23s"},
+		{"total_bytes", 1536, "1.5 KiB"},
+		{"bytes", 3 * 1024 * 1024, "3.0 MiB"},
+		{"bytes", 512, ""},
+		{"count", 1700000000, ""},
+	}
+	for _, c := range cases {
+		if got := h.format(c.key, c.v); got != c.want {
+			t.Errorf("format(%q, %v) = %q, want %q", c.key, c.v, got, c.want)
+		}
+	}
+}
+
+func TestRenderHintsFromConfig(t *testing.T) {
+	if got := RenderHintsFromConfig(nil); len(got.Timestamps) == 0 || got.Disabled {
+		t.Errorf("expected the defaults for a nil config, got %+v", got)
+	}
+
+	enabled := false
+	got := RenderHintsFromConfig(&config.TUIConfig{JSONTree: &config.TUIJSONTreeConfig{
+		RenderHints: &config.JSONTreeRenderHints{Enabled: &enabled, Bytes: []string{"size"}},
+	}})
+	if !got.Disabled || len(got.Bytes) != 1 || got.Bytes[0] != "size" {
+		t.Errorf("unexpected hints %+v", got)
+	}
+	if len(got.Durations) == 0 {
+		t.Error("expected unset kinds to keep the default patterns")
+	}
+}
+
+func TestToggleRawValues(t *testing.T) {
+	m := New(map[string]interface{}{"size_bytes": 2048.0})
+	m.SetSize(80, 10)
+	if view := m.View(); !strings.Contains(view, "2.0 KiB") {
+		t.Fatalf("expected a readable size, got %q", view)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = updated.(Model)
+	if view := m.View(); !strings.Contains(view, "2048") || strings.Contains(view, "KiB") {
+		t.Errorf("expected the raw number after toggling, got %q", view)
+	}
+
+	m = New(map[string]interface{}{"size_bytes": 2048.0}, WithRenderHints(RenderHints{Disabled: true, Bytes: []string{"*bytes"}}))
+	m.SetSize(80, 10)
+	if view := m.View(); strings.Contains(view, "KiB") {
+		t.Errorf("expected raw values when hints start disabled, got %q", view)
+	}
+}
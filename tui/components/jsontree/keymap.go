@@ -22,9 +22,13 @@ type KeyMap struct {
 	Search       key.Binding
 	NextResult   key.Binding
 	PrevResult   key.Binding
+	Filter       key.Binding
 	YankValue    key.Binding
 	YankAll      key.Binding
 	VisualMode   key.Binding
+	NextIssue    key.Binding
+	PrevIssue    key.Binding
+	ToggleRaw    key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings for the component.
@@ -86,6 +90,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("N"),
 			key.WithHelp("N", "prev result"),
 		),
+		Filter: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "filter to matches"),
+		),
 		YankValue: key.NewBinding(
 			key.WithKeys("y"),
 			key.WithHelp("y", "yank value"),
@@ -98,6 +106,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("V"),
 			key.WithHelp("V", "visual mode"),
 		),
+		NextIssue: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "next schema issue"),
+		),
+		PrevIssue: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "prev schema issue"),
+		),
+		ToggleRaw: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "toggle raw values"),
+		),
 	}
 }
 
@@ -112,8 +132,8 @@ var _ keymap.SectionedKeyMap = KeyMap{}
 func (k KeyMap) Sections() []keymap.Section {
 	return []keymap.Section{
 		keymap.NavigationSection(k.Up, k.Down, k.HalfPageUp, k.HalfPageDown, k.GotoTop, k.GotoEnd),
-		keymap.NewSection("Tree", k.Toggle, k.Fold, k.ExpandAll, k.CollapseAll),
-		keymap.SearchSection(k.Search, k.NextResult, k.PrevResult),
+		keymap.NewSection("Tree", k.Toggle, k.Fold, k.ExpandAll, k.CollapseAll, k.NextIssue, k.PrevIssue, k.ToggleRaw),
+		keymap.SearchSection(k.Search, k.NextResult, k.PrevResult, k.Filter),
 		keymap.NewSection("Yank", k.VisualMode, k.YankValue, k.YankAll),
 		keymap.SystemSection(k.Back),
 	}
@@ -128,8 +148,8 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Toggle},
-		{k.ExpandAll, k.CollapseAll, k.Back},
-		{k.Search, k.NextResult, k.PrevResult},
+		{k.ExpandAll, k.CollapseAll, k.NextIssue, k.PrevIssue, k.ToggleRaw, k.Back},
+		{k.Search, k.NextResult, k.PrevResult, k.Filter},
 		{k.VisualMode, k.YankValue, k.YankAll},
 	}
 }
@@ -3,8 +3,6 @@ package jsontree
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
-	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -14,7 +12,9 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/grovetools/core/pkg/clipboard"
 	"github.com/grovetools/core/tui/keymap"
 	"github.com/grovetools/core/tui/theme"
 )
@@ -28,6 +28,19 @@ type node struct {
 	children  []*node
 	collapsed bool
 	isLast    bool // Is this the last child of its parent?
+
+	// Schema annotations (see WithSchema). path is the node's JSON
+	// pointer; subtreeIssues counts findings on the node and below.
+	path          string
+	issues        []issue
+	subtreeIssues int
+
+	// Filter annotations (see filter.go), set by markMatches for the
+	// active search: whether the node matches, the matches in its subtree
+	// and whether filter mode keeps it.
+	matched        bool
+	subtreeMatches int
+	filterKeep     bool
 }
 
 // Model is the Bubble Tea model for the JSON tree viewer.
@@ -50,6 +63,10 @@ type Model struct {
 	searchResults []int  // Indices of nodes matching the search
 	currentResult int    // Index into searchResults (-1 if no results)
 
+	// Filter mode prunes the tree to the search's matches (see filter.go).
+	filterMode    bool
+	filterMatches int
+
 	// Status message for yank confirmations
 	statusMessage string
 
@@ -60,13 +77,26 @@ type Model struct {
 	visualMode  bool
 	visualStart int
 	visualEnd   int
+
+	// schemaDoc is the JSON Schema set by WithSchema; schemaErr reports a
+	// schema that could not be applied.
+	schemaDoc []byte
+	schemaErr string
+
+	// mouse enables click and wheel handling (see WithMouse).
+	mouse bool
+
+	// hints selects the numbers shown in readable units (see hints.go);
+	// raw shows every number as is.
+	hints RenderHints
+	raw   bool
 }
 
 // BackMsg is sent when the user wants to exit the JSON viewer
 type BackMsg struct{}
 
 // New creates a new JSON tree model.
-func New(data interface{}) Model {
+func New(data interface{}, opts ...Option) Model {
 	// Initialize search input
 	ti := textinput.New()
 	ti.Placeholder = "Search..."
@@ -81,11 +111,19 @@ func New(data interface{}) Model {
 		currentResult: -1,
 		originalData:  data,
 		sequence:      keymap.NewSequenceState(),
+		hints:         DefaultRenderHints(),
+	}
+
+	for _, opt := range opts {
+		opt(&m)
 	}
 
 	if data != nil {
-		m.root = buildTree("root", data, 0)
-		m.nodes = flattenTree(m.root)
+		m.root = buildTree("root", "", data, 0)
+		if err := m.applySchema(data); err != nil {
+			m.schemaErr = err.Error()
+		}
+		m.nodes = flattenTree(m.root, nil)
 	}
 
 	return m
@@ -106,11 +144,12 @@ func (m *Model) SetSize(width, height int) {
 }
 
 // buildTree recursively builds a tree of nodes from JSON data.
-func buildTree(key string, value interface{}, depth int) *node {
+func buildTree(key, path string, value interface{}, depth int) *node {
 	n := &node{
 		key:   key,
 		value: value,
 		depth: depth,
+		path:  path,
 	}
 
 	switch v := value.(type) {
@@ -126,7 +165,7 @@ func buildTree(key string, value interface{}, depth int) *node {
 		sort.Strings(keys)
 
 		for i, k := range keys {
-			child := buildTree(k, v[k], depth+1)
+			child := buildTree(k, childPath(path, k), v[k], depth+1)
 			child.isLast = i == len(keys)-1
 			n.children = append(n.children, child)
 		}
@@ -136,7 +175,7 @@ func buildTree(key string, value interface{}, depth int) *node {
 		n.collapsed = depth > 0 // Start collapsed except root
 
 		for i, item := range v {
-			child := buildTree(fmt.Sprintf("[%d]", i), item, depth+1)
+			child := buildTree(fmt.Sprintf("[%d]", i), indexPath(path, i), item, depth+1)
 			child.isLast = i == len(v)-1
 			n.children = append(n.children, child)
 		}
@@ -157,7 +196,8 @@ func buildTree(key string, value interface{}, depth int) *node {
 }
 
 // flattenTree creates a flattened list of visible nodes for rendering.
-func flattenTree(root *node) []*node {
+// When keep is non-nil, only the nodes below root it accepts are listed.
+func flattenTree(root *node, keep func(*node) bool) []*node {
 	if root == nil {
 		return nil
 	}
@@ -168,7 +208,9 @@ func flattenTree(root *node) []*node {
 		nodes = append(nodes, n)
 		if !n.collapsed && len(n.children) > 0 {
 			for _, child := range n.children {
-				flatten(child)
+				if keep == nil || keep(child) {
+					flatten(child)
+				}
 			}
 			// Add closing bracket node after children
 			if n.valueType == "object" || n.valueType == "array" {
@@ -194,7 +236,9 @@ func flattenTree(root *node) []*node {
 
 		// Add children
 		for _, child := range root.children {
-			flatten(child)
+			if keep == nil || keep(child) {
+				flatten(child)
+			}
 		}
 
 		// Add closing bracket
@@ -228,7 +272,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case tea.KeyEnter:
 				// Perform search and exit search input mode
 				m.searchQuery = m.searchInput.Value()
-				m.performSearch()
+				if !m.filterMode || !m.applyFilter() {
+					m.performSearch()
+				}
 				m.isSearching = false
 				m.updateContent()
 				return m, nil
@@ -247,6 +293,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		if m.mouse {
+			m.handleMouse(msg)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		// Multi-key chords (gg, zR, zM) run through the shared sequence
 		// engine so the keymap can advertise real two-key bindings. A
@@ -299,6 +351,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Filter):
+			return m, m.toggleFilter()
+
+		case key.Matches(msg, m.keys.NextIssue):
+			return m, m.jumpToIssue(1)
+
+		case key.Matches(msg, m.keys.PrevIssue):
+			return m, m.jumpToIssue(-1)
+
 		case key.Matches(msg, m.keys.VisualMode):
 			// Toggle visual mode
 			if !m.visualMode {
@@ -359,6 +420,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateContent()
 			return m, m.clearStatusAfter()
 
+		case key.Matches(msg, m.keys.ToggleRaw):
+			m.raw = !m.raw
+			if m.raw {
+				m.statusMessage = "Showing raw values"
+			} else {
+				m.statusMessage = "Showing readable times, durations and sizes"
+			}
+			m.updateContent()
+			return m, m.clearStatusAfter()
+
 		case key.Matches(msg, m.keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
@@ -407,19 +478,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, m.keys.Toggle):
 			if m.cursor < len(m.nodes) {
-				n := m.nodes[m.cursor]
-				if len(n.children) > 0 {
-					n.collapsed = !n.collapsed
-					m.nodes = flattenTree(m.root)
-					// Ensure cursor is still valid
-					if m.cursor >= len(m.nodes) {
-						m.cursor = len(m.nodes) - 1
-					}
-					// Re-run search to update result indices after tree change
-					if m.searchQuery != "" {
-						m.performSearch()
-					}
-					m.updateContent()
+				if n := m.nodes[m.cursor]; len(n.children) > 0 {
+					m.toggleNode(n)
 				}
 			}
 			return m, nil
@@ -430,7 +490,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				n := m.nodes[m.cursor]
 				if len(n.children) > 0 && !n.collapsed {
 					n.collapsed = true
-					m.nodes = flattenTree(m.root)
+					m.flatten()
 					// Re-run search to update result indices after tree change
 					if m.searchQuery != "" {
 						m.performSearch()
@@ -448,6 +508,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateContent()
 				return m, nil
 			}
+			// Then leave filter mode, keeping the search
+			if m.filterMode {
+				m.setFilterMode(false)
+				return m, nil
+			}
 			// Clear search when exiting
 			m.searchQuery = ""
 			m.searchResults = nil
@@ -468,6 +533,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleNode expands or collapses n and re-flattens the tree.
+func (m *Model) toggleNode(n *node) {
+	n.collapsed = !n.collapsed
+	m.flatten()
+	// Ensure cursor is still valid
+	if m.cursor >= len(m.nodes) {
+		m.cursor = len(m.nodes) - 1
+	}
+	// Re-run search to update result indices after tree change
+	if m.searchQuery != "" {
+		m.performSearch()
+	}
+	m.updateContent()
+}
+
 // expandAll expands all nodes in the tree.
 func (m *Model) expandAll() {
 	var expand func(n *node)
@@ -479,7 +559,7 @@ func (m *Model) expandAll() {
 	}
 	if m.root != nil {
 		expand(m.root)
-		m.nodes = flattenTree(m.root)
+		m.flatten()
 		m.updateContent()
 	}
 }
@@ -497,7 +577,7 @@ func (m *Model) collapseAll() {
 	}
 	if m.root != nil {
 		collapse(m.root)
-		m.nodes = flattenTree(m.root)
+		m.flatten()
 		// Reset cursor to start
 		m.cursor = 0
 		// Re-run search to update result indices after tree change
@@ -524,6 +604,15 @@ func (m *Model) performSearch() {
 			continue
 		}
 
+		// Filter mode shows every match, so containers are not stops
+		// for the matches inside them.
+		if m.filterMode {
+			if matchesQuery(n, query) {
+				m.searchResults = append(m.searchResults, i)
+			}
+			continue
+		}
+
 		// Check if key matches
 		if strings.Contains(strings.ToLower(n.key), query) {
 			m.searchResults = append(m.searchResults, i)
@@ -560,27 +649,7 @@ func (m *Model) isSearchResult(idx int) bool {
 
 // copyToClipboard writes the given string to the system clipboard.
 func (m *Model) copyToClipboard(content string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("pbcopy")
-	case "linux":
-		if _, err := exec.LookPath("xclip"); err == nil {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
-		} else if _, err := exec.LookPath("xsel"); err == nil {
-			cmd = exec.Command("xsel", "--clipboard", "--input")
-		} else {
-			return fmt.Errorf("no clipboard utility found (install xclip or xsel)")
-		}
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "clip")
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	cmd.Stdin = strings.NewReader(content)
-	return cmd.Run()
+	return clipboard.Write(content)
 }
 
 // getNodeValueString returns a string representation of a node's value.
@@ -830,7 +899,9 @@ func (m *Model) renderNode(n *node, selected, isResult, isVisual bool) string {
 		numStyle := lipgloss.NewStyle().Foreground(theme.DefaultTheme.Colors.Yellow)
 		var valStr string
 		if v, ok := n.value.(float64); ok {
-			if v == float64(int64(v)) {
+			if readable := m.readable(n.key, v); readable != "" {
+				valStr = readable
+			} else if v == float64(int64(v)) {
 				valStr = fmt.Sprintf("%.0f", v)
 			} else {
 				valStr = fmt.Sprintf("%v", v)
@@ -873,6 +944,18 @@ func (m *Model) renderNode(n *node, selected, isResult, isVisual bool) string {
 
 	// Combine parts
 	line := fmt.Sprintf("%s%s%s: %s", indent, prefix, keyDisplay, valueDisplay)
+	if m.filterMode && !isVisual {
+		if inner := innerMatches(n); inner > 0 && len(n.children) > 0 {
+			line += " " + theme.DefaultTheme.Muted.Render(fmt.Sprintf("(%d %s)", inner, pluralMatches(inner)))
+		}
+	}
+	if badge := issueBadge(n); badge != "" {
+		if isVisual {
+			line += " " + ansi.Strip(badge)
+		} else {
+			line += " " + badge
+		}
+	}
 
 	// Apply selection styling - visual mode takes priority
 	if isVisual {
@@ -929,8 +1012,20 @@ func (m Model) View() string {
 	} else if m.statusMessage != "" {
 		// Show status message (yank confirmation, etc.)
 		statusBar = theme.DefaultTheme.Success.Render(m.statusMessage)
+	} else if m.schemaErr != "" {
+		statusBar = theme.DefaultTheme.Error.Render(m.schemaErr)
+	} else if n := m.cursorNode(); !m.isSearching && n != nil && len(n.issues) > 0 {
+		// The cursor node's schema findings, shown as its tooltip.
+		style := theme.DefaultTheme.Error
+		if n.issues[0].kind == issueDeprecated {
+			style = theme.DefaultTheme.Warning
+		}
+		statusBar = style.Render(truncateString(n.path+": "+issueSummary(n), max(m.width-1, 20)))
 	} else if m.isSearching {
 		statusBar = m.searchInput.View()
+	} else if m.filterMode {
+		statusBar = theme.DefaultTheme.Muted.Render(fmt.Sprintf("/%s [%d/%d] filtered to %d %s (F to show all)",
+			m.searchQuery, m.currentResult+1, len(m.searchResults), m.filterMatches, pluralMatches(m.filterMatches)))
 	} else if m.searchQuery != "" {
 		if len(m.searchResults) > 0 {
 			statusBar = fmt.Sprintf("/%s [%d/%d] (n/N to navigate, / to search again)",
@@ -0,0 +1,53 @@
+package jsontree
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// mouseWheelStep is how many rows one wheel notch moves the cursor.
+const mouseWheelStep = 3
+
+// WithMouse makes the tree handle tea.MouseMsg: a left click selects the
+// row under the pointer (and toggles it when the click lands on its fold
+// icon) and the wheel moves the cursor. Coordinates are relative to the
+// tree's top-left corner, so a host that draws the tree inside a frame
+// translates them before forwarding. The program must be started with
+// mouse reporting enabled (tea.WithMouseCellMotion).
+func WithMouse() Option {
+	return func(m *Model) {
+		m.mouse = true
+	}
+}
+
+// handleMouse applies a mouse event to the cursor and fold state.
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress || len(m.nodes) == 0 {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.moveCursor(-mouseWheelStep)
+	case tea.MouseButtonWheelDown:
+		m.moveCursor(mouseWheelStep)
+	case tea.MouseButtonLeft:
+		if msg.Y < 0 || msg.Y >= m.viewport.Height {
+			return
+		}
+		row := m.viewport.YOffset + msg.Y
+		if row >= len(m.nodes) {
+			return
+		}
+		m.moveCursor(row - m.cursor)
+		n := m.nodes[row]
+		if icon := 2 * n.depth; len(n.children) > 0 && msg.X >= icon && msg.X < icon+2 {
+			m.toggleNode(n)
+		}
+	}
+}
+
+// moveCursor moves the cursor by delta rows, clamped to the tree.
+func (m *Model) moveCursor(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), len(m.nodes)-1)
+	if m.visualMode {
+		m.visualEnd = m.cursor
+	}
+	m.updateContent()
+}
@@ -0,0 +1,68 @@
+package jsontree
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func press(button tea.MouseButton, x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Button: button, Action: tea.MouseActionPress}
+}
+
+func TestMouseClickSelectsAndTogglesFoldIcon(t *testing.T) {
+	data := map[string]interface{}{
+		"a": map[string]interface{}{"x": 1.0, "y": 2.0},
+		"b": "text",
+	}
+	m := New(data, WithMouse())
+	m.SetSize(80, 20)
+	row := -1
+	for i, n := range m.nodes {
+		if n.key == "a" {
+			row = i
+		}
+	}
+	if row < 0 {
+		t.Fatal("node a not found")
+	}
+	before := len(m.nodes)
+	a := m.nodes[row]
+
+	// A click on the key selects the row without folding it.
+	updated, _ := m.Update(press(tea.MouseButtonLeft, 2*a.depth+4, row))
+	m = updated.(Model)
+	if m.cursor != row || !a.collapsed {
+		t.Fatalf("click on key: cursor=%d collapsed=%v, want cursor=%d still collapsed", m.cursor, a.collapsed, row)
+	}
+
+	// A click on the fold icon expands it.
+	updated, _ = m.Update(press(tea.MouseButtonLeft, 2*a.depth, row))
+	m = updated.(Model)
+	if a.collapsed || len(m.nodes) <= before {
+		t.Fatalf("click on icon: collapsed=%v nodes=%d (was %d)", a.collapsed, len(m.nodes), before)
+	}
+
+	// The wheel moves the cursor and stops at the ends.
+	updated, _ = m.Update(press(tea.MouseButtonWheelDown, 0, 0))
+	m = updated.(Model)
+	if m.cursor != min(row+mouseWheelStep, len(m.nodes)-1) {
+		t.Errorf("wheel down: cursor=%d", m.cursor)
+	}
+	for i := 0; i < 10; i++ {
+		updated, _ = m.Update(press(tea.MouseButtonWheelUp, 0, 0))
+		m = updated.(Model)
+	}
+	if m.cursor != 0 {
+		t.Errorf("wheel up: cursor=%d, want 0", m.cursor)
+	}
+}
+
+func TestMouseIgnoredWithoutOption(t *testing.T) {
+	m := New(map[string]interface{}{"a": 1.0, "b": 2.0, "c": 3.0})
+	m.SetSize(80, 20)
+	updated, _ := m.Update(press(tea.MouseButtonWheelDown, 0, 0))
+	if got := updated.(Model).cursor; got != 0 {
+		t.Errorf("cursor moved to %d without WithMouse", got)
+	}
+}
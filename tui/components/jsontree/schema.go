@@ -0,0 +1,371 @@
+package jsontree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// Option configures a Model at construction.
+type Option func(*Model)
+
+// WithSchema validates the tree against a JSON Schema document. Values that
+// violate it get an error badge, values the schema marks x-deprecated get a
+// deprecation badge, and the messages are shown in the status bar when the
+// cursor is on the node. A schema that fails to compile is reported in the
+// status bar and the tree renders without badges.
+func WithSchema(doc []byte) Option {
+	return func(m *Model) {
+		m.schemaDoc = doc
+	}
+}
+
+// issueKind distinguishes the two badge types.
+type issueKind int
+
+const (
+	issueInvalid issueKind = iota
+	issueDeprecated
+)
+
+// issue is one schema finding attached to a node.
+type issue struct {
+	kind    issueKind
+	message string
+}
+
+// applySchema annotates the tree built from data with the findings of doc.
+func (m *Model) applySchema(data interface{}) error {
+	if m.root == nil || len(m.schemaDoc) == 0 {
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(m.schemaDoc, &raw); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", bytes.NewReader(m.schemaDoc)); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	byPath := make(map[string]*node)
+	indexNodes(m.root, byPath)
+
+	// Validate canonical JSON shapes (float64 numbers, string-keyed maps),
+	// whatever decoder produced data.
+	var doc interface{}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode data for validation: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return fmt.Errorf("failed to encode data for validation: %w", err)
+	}
+	if err := compiled.Validate(doc); err != nil {
+		verr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return err
+		}
+		for loc, msgs := range leafErrors(verr) {
+			n := byPath[loc]
+			if n == nil {
+				continue
+			}
+			for _, msg := range msgs {
+				n.issues = append(n.issues, issue{kind: issueInvalid, message: msg})
+			}
+		}
+	}
+
+	markDeprecated(raw, raw, m.root)
+	countIssues(m.root)
+	return nil
+}
+
+// indexNodes maps each node's JSON pointer to the node.
+func indexNodes(n *node, out map[string]*node) {
+	out[n.path] = n
+	for _, c := range n.children {
+		indexNodes(c, out)
+	}
+}
+
+// leafErrors collects the most specific validation messages by instance
+// location. Parent errors ("doesn't validate with ...") only restate their
+// causes, so only errors without causes are kept. An additionalProperties
+// error is reported on the object; it is moved to each key it names so the
+// badge lands on the offending key.
+func leafErrors(err *jsonschema.ValidationError) map[string][]string {
+	out := make(map[string][]string)
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, c := range e.Causes {
+				walk(c)
+			}
+			return
+		}
+		if strings.HasSuffix(e.KeywordLocation, "/additionalProperties") {
+			if keys := quotedNames(e.Message); len(keys) > 0 {
+				for _, k := range keys {
+					loc := childPath(e.InstanceLocation, k)
+					out[loc] = appendUnique(out[loc], "not allowed by the schema")
+				}
+				return
+			}
+		}
+		out[e.InstanceLocation] = appendUnique(out[e.InstanceLocation], e.Message)
+	}
+	walk(err)
+	for loc := range out {
+		sort.Strings(out[loc])
+	}
+	return out
+}
+
+// quotedNames extracts the single-quoted names from a validator message
+// such as "additionalProperties 'a', 'b' not allowed".
+func quotedNames(msg string) []string {
+	var names []string
+	for {
+		start := strings.IndexByte(msg, '\'')
+		if start == -1 {
+			return names
+		}
+		end := strings.IndexByte(msg[start+1:], '\'')
+		if end == -1 {
+			return names
+		}
+		names = append(names, msg[start+1:start+1+end])
+		msg = msg[start+1+end+1:]
+	}
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// markDeprecated walks the tree alongside its schema node, flagging values
+// whose schema carries x-deprecated.
+func markDeprecated(doc, schemaNode map[string]interface{}, n *node) {
+	schemaNode = resolveRef(doc, schemaNode, 0)
+	if schemaNode == nil {
+		return
+	}
+	if dep, _ := schemaNode["x-deprecated"].(bool); dep && n.depth > 0 {
+		msg := "deprecated"
+		if s, _ := schemaNode["x-deprecated-message"].(string); s != "" {
+			msg = "deprecated: " + s
+		} else if r, _ := schemaNode["x-deprecated-replacement"].(string); r != "" {
+			msg = "deprecated: use " + r
+		}
+		n.issues = append(n.issues, issue{kind: issueDeprecated, message: msg})
+	}
+
+	for _, c := range n.children {
+		var child map[string]interface{}
+		switch n.valueType {
+		case "object":
+			if props, ok := schemaNode["properties"].(map[string]interface{}); ok {
+				child, _ = props[c.key].(map[string]interface{})
+			}
+			if child == nil {
+				child, _ = schemaNode["additionalProperties"].(map[string]interface{})
+			}
+		case "array":
+			child, _ = schemaNode["items"].(map[string]interface{})
+		}
+		if child != nil {
+			markDeprecated(doc, child, c)
+		}
+	}
+}
+
+// resolveRef follows local "$ref" pointers ("#/$defs/Name") within doc.
+func resolveRef(doc, schemaNode map[string]interface{}, hops int) map[string]interface{} {
+	ref, ok := schemaNode["$ref"].(string)
+	if !ok {
+		return schemaNode
+	}
+	if hops > 32 || !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	var cur interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur = obj[part]
+	}
+	target, ok := cur.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return resolveRef(doc, target, hops+1)
+}
+
+// countIssues records on each node how many findings its subtree holds, so
+// collapsed containers can show that something inside needs attention.
+func countIssues(n *node) int {
+	total := len(n.issues)
+	for _, c := range n.children {
+		total += countIssues(c)
+	}
+	n.subtreeIssues = total
+	return total
+}
+
+// childPath extends a JSON pointer with one reference token.
+func childPath(parent, token string) string {
+	token = strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	return parent + "/" + token
+}
+
+// indexPath is childPath for an array element.
+func indexPath(parent string, i int) string {
+	return parent + "/" + strconv.Itoa(i)
+}
+
+// IssueCount returns the number of schema findings in the tree.
+func (m *Model) IssueCount() int {
+	if m.root == nil {
+		return 0
+	}
+	return m.root.subtreeIssues
+}
+
+// issueSummary is the status-bar "tooltip" for n's findings.
+func issueSummary(n *node) string {
+	msgs := make([]string, 0, len(n.issues))
+	for _, is := range n.issues {
+		msgs = append(msgs, is.message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// jumpToIssue moves the cursor to the next (dir 1) or previous (dir -1) node
+// carrying a finding, expanding its ancestors so it is visible.
+func (m *Model) jumpToIssue(dir int) tea.Cmd {
+	var all []*node
+	var walk func(n *node)
+	walk = func(n *node) {
+		if len(n.issues) > 0 {
+			all = append(all, n)
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	if m.root == nil {
+		return nil
+	}
+	walk(m.root)
+	if len(all) == 0 {
+		m.statusMessage = "No schema issues"
+		return m.clearStatusAfter()
+	}
+
+	cur := -1
+	if m.cursor >= 0 && m.cursor < len(m.nodes) {
+		for i, n := range all {
+			if n == m.nodes[m.cursor] {
+				cur = i
+				break
+			}
+		}
+	}
+	next := 0
+	switch {
+	case cur == -1 && dir < 0:
+		next = len(all) - 1
+	case cur != -1:
+		next = (cur + dir + len(all)) % len(all)
+	}
+	target := all[next]
+
+	expandTo(m.root, target)
+	if m.filterMode && !target.filterKeep {
+		// The issue is outside the filtered tree.
+		m.filterMode = false
+	}
+	m.flatten()
+	for i, n := range m.nodes {
+		if n == target {
+			m.cursor = i
+			break
+		}
+	}
+	m.updateContent()
+	return nil
+}
+
+// expandTo uncollapses every ancestor of target under n.
+func expandTo(n, target *node) bool {
+	if n == target {
+		return true
+	}
+	for _, c := range n.children {
+		if expandTo(c, target) {
+			n.collapsed = false
+			return true
+		}
+	}
+	return false
+}
+
+// issueBadge renders n's findings after its value: an error badge for
+// schema violations, a warning badge for deprecated keys, and, on a
+// collapsed container, a count of the findings hidden inside it.
+func issueBadge(n *node) string {
+	t := theme.DefaultTheme
+	var parts []string
+	invalid, deprecated := 0, 0
+	for _, is := range n.issues {
+		if is.kind == issueDeprecated {
+			deprecated++
+		} else {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		parts = append(parts, t.Error.Render("✗ invalid"))
+	}
+	if deprecated > 0 {
+		parts = append(parts, t.Warning.Render("⚠ deprecated"))
+	}
+	if hidden := n.subtreeIssues - len(n.issues); hidden > 0 && n.collapsed {
+		parts = append(parts, t.Error.Render(fmt.Sprintf("✗ %d inside", hidden)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// cursorNode returns the node under the cursor, or nil.
+func (m *Model) cursorNode() *node {
+	if m.cursor < 0 || m.cursor >= len(m.nodes) {
+		return nil
+	}
+	return m.nodes[m.cursor]
+}
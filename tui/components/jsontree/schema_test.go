@@ -0,0 +1,126 @@
+package jsontree
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const testSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string"},
+    "search_paths": {"$ref": "#/$defs/legacy"},
+    "logging": {
+      "type": "object",
+      "properties": {
+        "level": {"type": "string", "enum": ["debug", "info"]},
+        "tags": {"type": "array", "items": {"type": "string"}}
+      }
+    }
+  },
+  "$defs": {
+    "legacy": {
+      "type": "object",
+      "x-deprecated": true,
+      "x-deprecated-message": "Use 'groves' for project discovery"
+    }
+  }
+}`
+
+func newSchemaTree(t *testing.T, doc string) Model {
+	t.Helper()
+	var data interface{}
+	if err := json.Unmarshal([]byte(doc), &data); err != nil {
+		t.Fatal(err)
+	}
+	m := New(data, WithSchema([]byte(testSchema)))
+	m.SetSize(100, 30)
+	return m
+}
+
+func findNode(n *node, path string) *node {
+	if n.path == path {
+		return n
+	}
+	for _, c := range n.children {
+		if found := findNode(c, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestSchemaBadges(t *testing.T) {
+	m := newSchemaTree(t, `{
+		"name": "api",
+		"search_paths": {},
+		"extra": true,
+		"logging": {"level": "loud", "tags": ["a", 2]}
+	}`)
+	if m.schemaErr != "" {
+		t.Fatalf("schema error: %s", m.schemaErr)
+	}
+
+	tests := []struct {
+		path string
+		kind issueKind
+		want string
+	}{
+		{"/logging/level", issueInvalid, "must be one of"},
+		{"/logging/tags/1", issueInvalid, "expected string"},
+		{"/extra", issueInvalid, "not allowed by the schema"},
+		{"/search_paths", issueDeprecated, "Use 'groves'"},
+	}
+	for _, tt := range tests {
+		n := findNode(m.root, tt.path)
+		if n == nil {
+			t.Fatalf("no node at %s", tt.path)
+		}
+		if len(n.issues) != 1 || n.issues[0].kind != tt.kind || !strings.Contains(n.issues[0].message, tt.want) {
+			t.Errorf("%s issues = %+v, want one %v containing %q", tt.path, n.issues, tt.kind, tt.want)
+		}
+	}
+	if n := findNode(m.root, "/name"); len(n.issues) != 0 {
+		t.Errorf("/name should be clean, got %+v", n.issues)
+	}
+	if got := m.IssueCount(); got != 4 {
+		t.Errorf("IssueCount() = %d, want 4", got)
+	}
+
+	// The collapsed logging object advertises the findings inside it.
+	logging := findNode(m.root, "/logging")
+	if badge := ansi.Strip(issueBadge(logging)); badge != "✗ 2 inside" {
+		t.Errorf("logging badge = %q", badge)
+	}
+}
+
+func TestJumpToIssueShowsTooltip(t *testing.T) {
+	m := newSchemaTree(t, `{"name": "api", "logging": {"level": "loud"}}`)
+
+	m.jumpToIssue(1)
+	n := m.cursorNode()
+	if n == nil || n.path != "/logging/level" {
+		t.Fatalf("cursor on %+v, want /logging/level", n)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "/logging/level: value must be one of") {
+		t.Errorf("status bar missing tooltip:\n%s", view)
+	}
+	if !strings.Contains(ansi.Strip(m.renderedContent), "level: \"loud\" ✗ invalid") {
+		t.Errorf("rendered tree missing badge:\n%s", m.renderedContent)
+	}
+}
+
+func TestInvalidSchemaIsReported(t *testing.T) {
+	m := New(map[string]interface{}{"a": 1.0}, WithSchema([]byte(`{"type": 7}`)))
+	if !strings.Contains(m.schemaErr, "invalid schema") {
+		t.Errorf("schemaErr = %q", m.schemaErr)
+	}
+	if m.IssueCount() != 0 {
+		t.Errorf("IssueCount() = %d, want 0", m.IssueCount())
+	}
+}
@@ -7,13 +7,13 @@ import (
 	stdlog "log"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/hpcloud/tail"
 
+	"github.com/grovetools/core/logging"
 	"github.com/grovetools/core/tui/theme"
 	"github.com/grovetools/core/tui/utils/scrollbar"
 )
@@ -283,16 +283,11 @@ func formatLogLine(workspace, line string, noPrefix bool) string {
 
 	msg, _ := logMap["msg"].(string)
 	level, _ := logMap["level"].(string)
-	ts, _ := logMap["time"].(string)
 
-	// Parse time for formatting
+	// Parse time for formatting, displayed in local time
 	var timeStr string
-	parsedTime, err := time.Parse(time.RFC3339Nano, ts)
-	if err != nil {
-		parsedTime, _ = time.Parse(time.RFC3339, ts)
-	}
-	if !parsedTime.IsZero() {
-		timeStr = parsedTime.Format("15:04:05")
+	if parsedTime, ok := logging.ParseTime(logMap["time"], logging.ConfiguredTimeFormat()); ok {
+		timeStr = parsedTime.Local().Format("15:04:05")
 	}
 
 	var levelStyle lipgloss.Style
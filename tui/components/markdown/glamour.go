@@ -0,0 +1,207 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grovetools/core/tui/theme"
+	"github.com/grovetools/core/util/frontmatter"
+)
+
+// Renderer renders markdown with glamour, styled from a Grove theme so
+// rendered note bodies match the rest of the TUI. Unlike Render, which only
+// highlights markdown syntax line by line, it lays the document out:
+// wrapping paragraphs, drawing tables and highlighting fenced code.
+//
+// A Renderer is not safe for concurrent use.
+type Renderer struct {
+	th    *theme.Theme
+	width int
+	opts  []glamour.TermRendererOption
+	tr    *glamour.TermRenderer
+}
+
+// NewRenderer returns a Renderer for th that wraps at width columns. A
+// width of zero or less keeps glamour's default of 80. opts are applied
+// after the theme styles, so callers can override them (e.g.
+// glamour.WithEmoji()).
+func NewRenderer(th *theme.Theme, width int, opts ...glamour.TermRendererOption) (*Renderer, error) {
+	r := &Renderer{th: th, width: width, opts: opts}
+	if err := r.build(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetWidth changes the wrap width, for views that resize.
+func (r *Renderer) SetWidth(width int) error {
+	if width == r.width {
+		return nil
+	}
+	r.width = width
+	return r.build()
+}
+
+// Width returns the wrap width.
+func (r *Renderer) Width() int {
+	return r.width
+}
+
+func (r *Renderer) build() error {
+	opts := []glamour.TermRendererOption{
+		glamour.WithStyles(StyleConfig(r.th)),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+	}
+	if r.width > 0 {
+		opts = append(opts, glamour.WithWordWrap(r.width))
+	}
+	tr, err := glamour.NewTermRenderer(append(opts, r.opts...)...)
+	if err != nil {
+		return err
+	}
+	r.tr = tr
+	return nil
+}
+
+// Render renders content. YAML frontmatter is kept verbatim and muted, as
+// Render does, rather than being laid out as markdown.
+func (r *Renderer) Render(content string) (string, error) {
+	front, body := frontmatter.Split(content)
+	out, err := r.tr.Render(body)
+	if err != nil {
+		return "", err
+	}
+	if front != "" {
+		out = r.th.Muted.Italic(true).Render(strings.TrimSuffix(front, "\n")) + "\n" + out
+	}
+	return out, nil
+}
+
+// StyleConfig maps th onto a glamour style: headings in the colors Render
+// uses, inline code on the subtle background, fenced code highlighted from
+// the theme's accent colors, and muted quotes and rules. Adaptive theme
+// colors resolve for the terminal's background. The document has no margin
+// so rendered output fits the pane it is placed in.
+func StyleConfig(th *theme.Theme) ansi.StyleConfig {
+	return styleConfig(th, lipgloss.HasDarkBackground())
+}
+
+func styleConfig(th *theme.Theme, dark bool) ansi.StyleConfig {
+	c := th.Colors
+	color := func(tc lipgloss.TerminalColor) *string { return colorValue(tc, dark) }
+
+	cfg := styles.LightStyleConfig
+	if dark {
+		cfg = styles.DarkStyleConfig
+	}
+
+	cfg.Document = ansi.StyleBlock{
+		StylePrimitive: ansi.StylePrimitive{Color: color(c.LightText)},
+		Margin:         uintPtr(0),
+	}
+	cfg.BlockQuote = ansi.StyleBlock{
+		StylePrimitive: ansi.StylePrimitive{Color: color(c.MutedText), Italic: boolPtr(true)},
+		Indent:         uintPtr(1),
+		IndentToken:    stringPtr("│ "),
+	}
+	cfg.Heading = ansi.StyleBlock{
+		StylePrimitive: ansi.StylePrimitive{BlockSuffix: "\n", Color: color(c.Blue), Bold: boolPtr(true)},
+	}
+	cfg.H1 = ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Prefix: "# ", Color: color(c.Cyan), Bold: boolPtr(true)}}
+	cfg.H2 = ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Prefix: "## ", Color: color(c.Blue)}}
+	cfg.H3 = ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Prefix: "### ", Color: color(c.Violet)}}
+	cfg.H4 = ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Prefix: "#### ", Color: color(c.Violet)}}
+	cfg.H5 = ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Prefix: "##### ", Color: color(c.MutedText)}}
+	cfg.H6 = ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Prefix: "###### ", Color: color(c.MutedText), Bold: boolPtr(false)}}
+	cfg.HorizontalRule = ansi.StylePrimitive{Color: color(c.Border), Format: "\n────────\n"}
+	cfg.Task = ansi.StyleTask{
+		Ticked:   theme.IconStatusCompleted + " ",
+		Unticked: theme.IconStatusTodo + " ",
+	}
+	cfg.Link = ansi.StylePrimitive{Color: color(c.Blue), Underline: boolPtr(true)}
+	cfg.LinkText = ansi.StylePrimitive{Color: color(c.Cyan), Bold: boolPtr(true)}
+	cfg.Image = ansi.StylePrimitive{Color: color(c.Pink), Underline: boolPtr(true)}
+	cfg.ImageText = ansi.StylePrimitive{Color: color(c.MutedText), Format: "Image: {{.text}} →"}
+	cfg.Code = ansi.StyleBlock{
+		StylePrimitive: ansi.StylePrimitive{
+			Color:           color(c.Cyan),
+			BackgroundColor: color(c.SubtleBackground),
+		},
+	}
+	cfg.CodeBlock = ansi.StyleCodeBlock{
+		StyleBlock: ansi.StyleBlock{
+			StylePrimitive: ansi.StylePrimitive{Color: color(c.Green)},
+			Margin:         uintPtr(1),
+		},
+		Chroma: &ansi.Chroma{
+			Text:                ansi.StylePrimitive{Color: color(c.LightText)},
+			Error:               ansi.StylePrimitive{Color: color(c.Red)},
+			Comment:             ansi.StylePrimitive{Color: color(c.MutedText), Italic: boolPtr(true)},
+			CommentPreproc:      ansi.StylePrimitive{Color: color(c.Orange)},
+			Keyword:             ansi.StylePrimitive{Color: color(c.Violet)},
+			KeywordReserved:     ansi.StylePrimitive{Color: color(c.Violet)},
+			KeywordNamespace:    ansi.StylePrimitive{Color: color(c.Pink)},
+			KeywordType:         ansi.StylePrimitive{Color: color(c.Yellow)},
+			Operator:            ansi.StylePrimitive{Color: color(c.Orange)},
+			Punctuation:         ansi.StylePrimitive{Color: color(c.MutedText)},
+			Name:                ansi.StylePrimitive{Color: color(c.LightText)},
+			NameBuiltin:         ansi.StylePrimitive{Color: color(c.Cyan)},
+			NameTag:             ansi.StylePrimitive{Color: color(c.Blue)},
+			NameAttribute:       ansi.StylePrimitive{Color: color(c.Yellow)},
+			NameClass:           ansi.StylePrimitive{Color: color(c.Yellow), Bold: boolPtr(true)},
+			NameDecorator:       ansi.StylePrimitive{Color: color(c.Orange)},
+			NameFunction:        ansi.StylePrimitive{Color: color(c.Blue)},
+			LiteralNumber:       ansi.StylePrimitive{Color: color(c.Orange)},
+			LiteralString:       ansi.StylePrimitive{Color: color(c.Green)},
+			LiteralStringEscape: ansi.StylePrimitive{Color: color(c.Cyan)},
+			GenericDeleted:      ansi.StylePrimitive{Color: color(c.Red)},
+			GenericEmph:         ansi.StylePrimitive{Italic: boolPtr(true)},
+			GenericInserted:     ansi.StylePrimitive{Color: color(c.Green)},
+			GenericStrong:       ansi.StylePrimitive{Bold: boolPtr(true)},
+			GenericSubheading:   ansi.StylePrimitive{Color: color(c.MutedText)},
+			Background:          ansi.StylePrimitive{BackgroundColor: color(c.SubtleBackground)},
+		},
+	}
+	cfg.Table = ansi.StyleTable{
+		StyleBlock:      ansi.StyleBlock{StylePrimitive: ansi.StylePrimitive{Color: color(c.LightText)}},
+		CenterSeparator: stringPtr("┼"),
+		ColumnSeparator: stringPtr("│"),
+		RowSeparator:    stringPtr("─"),
+	}
+	return cfg
+}
+
+// colorValue converts a theme color to a glamour color: a hex value or an
+// ANSI index, picking the variant of an adaptive color for the background.
+// It returns nil for no color, leaving the terminal default.
+func colorValue(tc lipgloss.TerminalColor, dark bool) *string {
+	var s string
+	switch v := tc.(type) {
+	case lipgloss.Color:
+		s = string(v)
+	case lipgloss.AdaptiveColor:
+		s = v.Light
+		if dark {
+			s = v.Dark
+		}
+	case lipgloss.CompleteColor:
+		s = v.TrueColor
+	case lipgloss.CompleteAdaptiveColor:
+		s = v.Light.TrueColor
+		if dark {
+			s = v.Dark.TrueColor
+		}
+	}
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func boolPtr(b bool) *bool       { return &b }
+func stringPtr(s string) *string { return &s }
+func uintPtr(u uint) *uint       { return &u }
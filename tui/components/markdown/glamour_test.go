@@ -0,0 +1,78 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+func TestStyleConfigUsesThemeColors(t *testing.T) {
+	th := *theme.DefaultTheme
+	th.Colors.Cyan = lipgloss.AdaptiveColor{Light: "#005f87", Dark: "#5fd7ff"}
+	th.Colors.Green = lipgloss.Color("2")
+	th.Colors.SubtleBackground = lipgloss.NoColor{}
+
+	dark := styleConfig(&th, true)
+	if got := *dark.H1.Color; got != "#5fd7ff" {
+		t.Errorf("dark H1 color = %s, want the dark variant", got)
+	}
+	if got := *styleConfig(&th, false).H1.Color; got != "#005f87" {
+		t.Errorf("light H1 color = %s, want the light variant", got)
+	}
+	if got := *dark.CodeBlock.Color; got != "2" {
+		t.Errorf("code block color = %s, want the ANSI index", got)
+	}
+	if dark.Code.BackgroundColor != nil {
+		t.Errorf("NoColor should leave the terminal default, got %s", *dark.Code.BackgroundColor)
+	}
+	if dark.CodeBlock.Chroma == nil || dark.CodeBlock.Chroma.Keyword.Color == nil {
+		t.Error("fenced code should be highlighted from theme colors")
+	}
+}
+
+func TestRendererRender(t *testing.T) {
+	r, err := NewRenderer(theme.DefaultTheme, 40, glamour.WithColorProfile(termenv.Ascii))
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	content := "---\nid: plan-1\n---\n# Plan\n\nThis paragraph is long enough that it has to wrap at forty columns.\n\n- [x] done\n- [ ] todo\n"
+	out, err := r.Render(content)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out = ansi.Strip(out)
+
+	lines := strings.Split(out, "\n")
+	for i, want := range []string{"---", "id: plan-1", "---"} {
+		if strings.TrimSpace(lines[i]) != want {
+			t.Fatalf("frontmatter should be kept verbatim:\n%s", out)
+		}
+	}
+	if !strings.Contains(out, "# Plan") {
+		t.Errorf("missing heading:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if w := ansi.StringWidth(line); w > 40 {
+			t.Errorf("line %q is %d columns wide, want at most 40", line, w)
+		}
+	}
+	if !strings.Contains(out, theme.IconStatusCompleted+" done") || !strings.Contains(out, theme.IconStatusTodo+" todo") {
+		t.Errorf("task items should use the theme status icons:\n%s", out)
+	}
+
+	if err := r.SetWidth(20); err != nil {
+		t.Fatalf("SetWidth: %v", err)
+	}
+	out, _ = r.Render("This paragraph is long enough that it has to wrap.")
+	for _, line := range strings.Split(ansi.Strip(out), "\n") {
+		if w := ansi.StringWidth(line); w > 20 {
+			t.Errorf("after SetWidth(20), line %q is %d columns wide", line, w)
+		}
+	}
+}
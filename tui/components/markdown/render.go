@@ -6,23 +6,16 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/grovetools/core/tui/theme"
+	"github.com/grovetools/core/util/frontmatter"
 )
 
 // Render applies basic syntax highlighting to markdown content.
 // It accepts a theme parameter for styling and returns the styled string.
 func Render(content string, th *theme.Theme) string {
 	var styledFrontmatter string
-	bodyBlock := content
-
-	// Check for frontmatter and extract it if present
-	if strings.HasPrefix(content, "---\n") {
-		secondSeparator := strings.Index(content[4:], "\n---\n")
-		if secondSeparator != -1 {
-			frontmatterEnd := 4 + secondSeparator + 5
-			frontmatterBlock := content[:frontmatterEnd]
-			bodyBlock = content[frontmatterEnd:]
-			styledFrontmatter = th.Muted.Italic(true).Render(frontmatterBlock)
-		}
+	frontmatterBlock, bodyBlock := frontmatter.Split(content)
+	if frontmatterBlock != "" {
+		styledFrontmatter = th.Muted.Italic(true).Render(frontmatterBlock)
 	}
 
 	var bodyBuilder strings.Builder
@@ -0,0 +1,70 @@
+package picker
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/grovetools/core/tui/keymap"
+)
+
+// KeyMap defines the keybindings for the picker. In a fuzzy picker, typed
+// characters go to the filter, so the letter bindings (j, k, q) only apply
+// to plain pickers.
+type KeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Toggle    key.Binding
+	ToggleAll key.Binding
+	Confirm   key.Binding
+	Cancel    key.Binding
+}
+
+// DefaultKeyMap returns the default keybindings for the component.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+p", "k"),
+			key.WithHelp("k/↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+n", "j"),
+			key.WithHelp("j/↓", "down"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("tab", " "),
+			key.WithHelp("tab", "toggle"),
+		),
+		ToggleAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "toggle all"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc", "ctrl+c", "q"),
+			key.WithHelp("esc/q", "cancel"),
+		),
+	}
+}
+
+// Sections returns the picker bindings grouped for the help view.
+func (k KeyMap) Sections() []keymap.Section {
+	return []keymap.Section{
+		keymap.NavigationSection(k.Up, k.Down),
+		keymap.SelectionSection(k.Toggle, k.ToggleAll, k.Confirm, k.Cancel),
+	}
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Confirm, k.Cancel}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Toggle, k.ToggleAll, k.Confirm, k.Cancel},
+	}
+}
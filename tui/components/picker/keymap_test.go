@@ -0,0 +1,17 @@
+package picker
+
+import (
+	"testing"
+
+	"github.com/grovetools/core/tui/keymap"
+)
+
+// TestKeyMapAuditCoverage asserts every enabled binding in the picker
+// KeyMap appears in a section and that no help label contradicts its keys.
+func TestKeyMapAuditCoverage(t *testing.T) {
+	if gaps := keymap.AuditCoverage(DefaultKeyMap()); len(gaps) != 0 {
+		for _, g := range gaps {
+			t.Errorf("audit gap: field=%s kind=%s detail=%s", g.Field, g.Kind, g.Detail)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+// Package picker provides a small inline chooser for CLI commands: pick
+// one item, several items, or one item from a fuzzy-filtered list. It is
+// drawn below the prompt rather than on the alternate screen, so the
+// command's output carries on where the picker was.
+//
+// Commands normally use it through cli.PickOne, cli.PickMany and
+// cli.PickFuzzy, which fall back to an error when there is no terminal.
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// DefaultHeight is the number of items shown at once.
+const DefaultHeight = 10
+
+// ErrCanceled is returned by Run when the user dismisses the picker.
+var ErrCanceled = errors.New("selection canceled")
+
+// Item is one choice.
+type Item struct {
+	// Label is what the item is chosen by, and what a fuzzy picker
+	// matches first.
+	Label string
+	// Detail is shown dimmed after the label, e.g. a path.
+	Detail string
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithMulti lets several items be chosen: Toggle marks the item under the
+// cursor and Confirm returns the marked items, or the item under the
+// cursor when none is marked.
+func WithMulti() Option {
+	return func(m *Model) { m.multi = true }
+}
+
+// WithFuzzy adds a filter line: typed characters narrow the list to the
+// items matching them, best match first.
+func WithFuzzy() Option {
+	return func(m *Model) { m.fuzzy = true }
+}
+
+// WithHeight sets how many items are shown at once.
+func WithHeight(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.height = n
+		}
+	}
+}
+
+// WithKeyMap replaces the default keybindings.
+func WithKeyMap(km KeyMap) Option {
+	return func(m *Model) { m.keys = km }
+}
+
+// Model is the picker's Bubble Tea model.
+type Model struct {
+	title  string
+	items  []Item
+	keys   KeyMap
+	multi  bool
+	fuzzy  bool
+	height int
+
+	filter textinput.Model
+	// visible holds the indexes of the items shown, in display order.
+	visible []int
+	cursor  int
+	offset  int
+	marked  map[int]bool
+
+	done     bool
+	canceled bool
+}
+
+// New returns a picker titled title over items.
+func New(title string, items []Item, opts ...Option) Model {
+	m := Model{
+		title:  title,
+		items:  items,
+		keys:   DefaultKeyMap(),
+		height: DefaultHeight,
+		marked: make(map[int]bool),
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	m.filter = textinput.New()
+	m.filter.Prompt = "> "
+	m.filter.Placeholder = "type to filter"
+	if m.fuzzy {
+		m.filter.Focus()
+	}
+	m.applyFilter()
+	return m
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	if m.fuzzy {
+		return textinput.Blink
+	}
+	return nil
+}
+
+// Update implements tea.Model.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.done {
+		return m, nil
+	}
+
+	// Typed characters belong to the filter of a fuzzy picker.
+	if m.fuzzy && m.filterKey(keyMsg) {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.applyFilter()
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Cancel):
+		m.done, m.canceled = true, true
+		return m, tea.Quit
+	case key.Matches(keyMsg, m.keys.Confirm):
+		if len(m.Chosen()) == 0 {
+			return m, nil
+		}
+		m.done = true
+		return m, tea.Quit
+	case key.Matches(keyMsg, m.keys.Up):
+		m.move(-1)
+	case key.Matches(keyMsg, m.keys.Down):
+		m.move(1)
+	case m.multi && key.Matches(keyMsg, m.keys.Toggle):
+		if len(m.visible) > 0 {
+			i := m.visible[m.cursor]
+			m.marked[i] = !m.marked[i]
+			m.move(1)
+		}
+	case m.multi && key.Matches(keyMsg, m.keys.ToggleAll):
+		all := true
+		for _, i := range m.visible {
+			all = all && m.marked[i]
+		}
+		for _, i := range m.visible {
+			m.marked[i] = !all
+		}
+	}
+	return m, nil
+}
+
+// filterKey reports whether msg edits the filter text rather than the
+// list. Space toggles in a multi picker and is typed otherwise.
+func (m Model) filterKey(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeyBackspace, tea.KeyDelete, tea.KeyCtrlW, tea.KeyCtrlU:
+		return true
+	case tea.KeySpace:
+		return !m.multi
+	}
+	return false
+}
+
+// move moves the cursor by delta, keeping it on screen.
+func (m *Model) move(delta int) {
+	if len(m.visible) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.visible)-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+// applyFilter recomputes the visible items for the filter text.
+func (m *Model) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	type ranked struct{ index, tier int }
+	var hits []ranked
+	for i, item := range m.items {
+		if tier, ok := matchTier(item, query); ok {
+			hits = append(hits, ranked{i, tier})
+		}
+	}
+	sort.SliceStable(hits, func(a, b int) bool { return hits[a].tier < hits[b].tier })
+	m.visible = m.visible[:0]
+	for _, h := range hits {
+		m.visible = append(m.visible, h.index)
+	}
+	m.cursor, m.offset = 0, 0
+}
+
+// matchTier ranks how item matches query (lowercased): 0 exact label,
+// 1 label prefix, 2 label substring, 3 label subsequence, 4 detail
+// substring. An empty query matches everything at tier 0.
+func matchTier(item Item, query string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+	label := strings.ToLower(item.Label)
+	switch {
+	case label == query:
+		return 0, true
+	case strings.HasPrefix(label, query):
+		return 1, true
+	case strings.Contains(label, query):
+		return 2, true
+	case isSubsequence(label, query):
+		return 3, true
+	case strings.Contains(strings.ToLower(item.Detail), query):
+		return 4, true
+	}
+	return 0, false
+}
+
+func isSubsequence(s, sub string) bool {
+	rest := []rune(sub)
+	for _, r := range s {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
+
+// Chosen returns the indexes into the items of the current choice: the
+// marked items in order, or else the item under the cursor.
+func (m Model) Chosen() []int {
+	var chosen []int
+	for i := range m.items {
+		if m.marked[i] {
+			chosen = append(chosen, i)
+		}
+	}
+	if len(chosen) == 0 && len(m.visible) > 0 {
+		chosen = []int{m.visible[m.cursor]}
+	}
+	return chosen
+}
+
+// Canceled reports whether the user dismissed the picker.
+func (m Model) Canceled() bool { return m.canceled }
+
+// View implements tea.Model. Once the picker is done it renders nothing,
+// so no trace of it is left above the command's output.
+func (m Model) View() string {
+	if m.done {
+		return ""
+	}
+	t := theme.DefaultTheme
+	var b strings.Builder
+	b.WriteString(t.Title.Render(m.title))
+	b.WriteString("\n")
+	if m.fuzzy {
+		b.WriteString(m.filter.View())
+		b.WriteString("\n")
+	}
+
+	if len(m.visible) == 0 {
+		b.WriteString(t.Muted.Render("  no matches"))
+		b.WriteString("\n")
+	}
+	end := min(m.offset+m.height, len(m.visible))
+	for row := m.offset; row < end; row++ {
+		i := m.visible[row]
+		item := m.items[i]
+		cursor := "  "
+		if row == m.cursor {
+			cursor = theme.IconArrow + " "
+		}
+		box := ""
+		if m.multi {
+			box = "[ ] "
+			if m.marked[i] {
+				box = "[x] "
+			}
+		}
+		line := cursor + box + item.Label
+		if row == m.cursor {
+			line = t.Selected.Render(line)
+		}
+		if item.Detail != "" {
+			line += "  " + t.Muted.Render(item.Detail)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	status := fmt.Sprintf("%d/%d", len(m.visible), len(m.items))
+	if m.multi {
+		marked := 0
+		for _, on := range m.marked {
+			if on {
+				marked++
+			}
+		}
+		status += fmt.Sprintf(", %d marked", marked)
+	}
+	var help []string
+	bindings := []key.Binding{m.keys.Confirm, m.keys.Cancel}
+	if m.multi {
+		bindings = append([]key.Binding{m.keys.Toggle}, bindings...)
+	}
+	for _, kb := range bindings {
+		help = append(help, kb.Help().Key+" "+kb.Help().Desc)
+	}
+	b.WriteString(t.Muted.Render(status + " · " + strings.Join(help, " · ")))
+	return b.String()
+}
+
+// Run shows m on out, reading keys from in, until the user confirms or
+// cancels. It returns the chosen item indexes (see Chosen), or
+// ErrCanceled.
+func Run(m Model, in io.Reader, out io.Writer) ([]int, error) {
+	if len(m.items) == 0 {
+		return nil, errors.New("nothing to pick from")
+	}
+	final, err := tea.NewProgram(m, tea.WithInput(in), tea.WithOutput(out)).Run()
+	if err != nil {
+		return nil, err
+	}
+	result := final.(Model)
+	if result.canceled {
+		return nil, ErrCanceled
+	}
+	return result.Chosen(), nil
+}
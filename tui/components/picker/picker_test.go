@@ -0,0 +1,91 @@
+package picker
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var testItems = []Item{
+	{Label: "grove-core", Detail: "/src/grove-core"},
+	{Label: "core", Detail: "/src/eco/core"},
+	{Label: "flow", Detail: "/src/eco/flow"},
+	{Label: "cx", Detail: "/src/core-tools/cx"},
+}
+
+func press(m Model, keys ...string) Model {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func TestFuzzyRanksBestMatchFirst(t *testing.T) {
+	m := press(New("Workspace", testItems, WithFuzzy()), "c", "o", "r", "e")
+	// exact, prefix-less substring, then the detail-only match.
+	if want := []int{1, 0, 3}; !reflect.DeepEqual(m.visible, want) {
+		t.Errorf("visible = %v, want %v", m.visible, want)
+	}
+	if got := m.Chosen(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("chosen = %v", got)
+	}
+
+	// j and q are filter text in a fuzzy picker, not navigation or cancel.
+	m = press(New("Workspace", testItems, WithFuzzy()), "q")
+	if m.Canceled() || len(m.visible) != 0 {
+		t.Errorf("q should filter, got canceled=%v visible=%v", m.Canceled(), m.visible)
+	}
+	if m = press(m, "backspace"); len(m.visible) != len(testItems) {
+		t.Errorf("clearing the filter should show all items, got %v", m.visible)
+	}
+}
+
+func TestMultiToggle(t *testing.T) {
+	m := press(New("Workspaces", testItems, WithMulti()), "tab", "down", "tab")
+	if got := m.Chosen(); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("chosen = %v, want [0 2]", got)
+	}
+	if !strings.Contains(m.View(), "2 marked") {
+		t.Errorf("view should count marked items:\n%s", m.View())
+	}
+
+	// With nothing marked, confirm takes the item under the cursor.
+	m = press(New("Workspaces", testItems, WithMulti()), "j", "j", "enter")
+	if got := m.Chosen(); !reflect.DeepEqual(got, []int{2}) || m.Canceled() {
+		t.Errorf("chosen = %v, canceled = %v", got, m.Canceled())
+	}
+}
+
+func TestRunConfirmAndCancel(t *testing.T) {
+	var out bytes.Buffer
+	got, err := Run(New("Workspace", testItems), strings.NewReader("j\r"), &out)
+	if err != nil || !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Run = %v, %v", got, err)
+	}
+	if _, err := Run(New("Workspace", testItems), strings.NewReader("q"), &out); !errors.Is(err, ErrCanceled) {
+		t.Errorf("expected ErrCanceled, got %v", err)
+	}
+	if _, err := Run(New("Workspace", nil), strings.NewReader(""), &out); err == nil {
+		t.Error("expected an error for an empty picker")
+	}
+}
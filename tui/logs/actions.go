@@ -0,0 +1,335 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// pipeTimeout bounds a command the selection is piped to.
+const pipeTimeout = 30 * time.Second
+
+// selectionActions lists the entries of the actions menu opened with the
+// Actions key, keyed by the letter that runs them.
+var selectionActions = []struct {
+	key   string
+	label string
+}{
+	{"y", "copy"},
+	{"w", "export to file"},
+	{"i", "issue snippet"},
+	{"|", "pipe to command"},
+	{"h", "hide components"},
+}
+
+// actionsState holds the actions menu and the prompt some actions open.
+// items is the selection captured when the menu opened.
+type actionsState struct {
+	menu   bool
+	prompt string // "export" or "pipe" while its input is open
+	input  textinput.Model
+	items  []logItem
+}
+
+// pipeResultMsg reports the end of a command the selection was piped to.
+type pipeResultMsg struct {
+	command string
+	output  string
+	err     error
+}
+
+// openActionsMenu captures the selection (or the highlighted entry) and
+// shows the actions menu.
+func (m *Model) openActionsMenu() tea.Cmd {
+	items := m.selectedItems()
+	if len(items) == 0 {
+		return nil
+	}
+	m.actions = actionsState{menu: true, items: items}
+	return nil
+}
+
+// actionsMenuView is the status-line menu shown after the Actions key.
+func (m *Model) actionsMenuView() string {
+	parts := make([]string, 0, len(selectionActions))
+	for _, a := range selectionActions {
+		parts = append(parts, fmt.Sprintf("[%s] %s", a.key, a.label))
+	}
+	return fmt.Sprintf(" %d selected: %s  (esc to cancel)", len(m.actions.items), strings.Join(parts, "  "))
+}
+
+// updateActionsMenu runs the action picked by the key typed after Actions.
+// Any other key closes the menu.
+func (m *Model) updateActionsMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.actions.menu = false
+	items := m.actions.items
+	switch msg.String() {
+	case "y":
+		m.endSelection()
+		return m, m.copyItems(items, m.copyFormat)
+	case "w":
+		name := fmt.Sprintf("grove-logs-%s.jsonl", time.Now().Format("20060102-150405"))
+		return m, m.openActionPrompt("export", "file (.json for an array, otherwise JSONL)", name)
+	case "i":
+		m.endSelection()
+		err := m.copyToClipboard(issueSnippet(items))
+		return m, m.actionStatus(err, fmt.Sprintf("Copied issue snippet for %d entries", len(items)))
+	case "|":
+		return m, m.openActionPrompt("pipe", "shell command reading the entries as JSONL", "")
+	case "h":
+		m.endSelection()
+		names := m.hideItemComponents(items)
+		if len(names) == 0 {
+			m.statusMessage = "No components to hide"
+		} else {
+			m.statusMessage = "Hidden: " + strings.Join(names, ", ")
+		}
+		return m, m.clearStatusMessageAfter(3 * time.Second)
+	}
+	m.actions.items = nil
+	return m, nil
+}
+
+// openActionPrompt opens the text input for the export and pipe actions.
+func (m *Model) openActionPrompt(prompt, placeholder, value string) tea.Cmd {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 1000
+	ti.Width = m.width - 20
+	ti.SetValue(value)
+	ti.CursorEnd()
+	m.actions.input = ti
+	m.actions.prompt = prompt
+	return m.actions.input.Focus()
+}
+
+// actionPromptView is the status line while an action prompt is open.
+func (m *Model) actionPromptView() string {
+	label := "Export to"
+	if m.actions.prompt == "pipe" {
+		label = "Pipe to"
+	}
+	return fmt.Sprintf(" %s: %s", label, m.actions.input.View())
+}
+
+// updateActionPrompt handles input while an action prompt is open: enter
+// runs the action on the captured selection and esc cancels it.
+func (m *Model) updateActionPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.actions = actionsState{}
+		return m, nil
+	case tea.KeyEnter:
+		prompt, items := m.actions.prompt, m.actions.items
+		value := strings.TrimSpace(m.actions.input.Value())
+		m.actions.prompt = ""
+		if value == "" {
+			m.actions.items = nil
+			return m, nil
+		}
+		m.endSelection()
+		if prompt == "pipe" {
+			m.statusMessage = "Running " + value
+			return m, pipeItems(m.ctx, value, items)
+		}
+		path, err := exportItems(value, items)
+		return m, m.actionStatus(err, fmt.Sprintf("Exported %d entries to %s", len(items), path))
+	}
+	var cmd tea.Cmd
+	m.actions.input, cmd = m.actions.input.Update(msg)
+	return m, cmd
+}
+
+// endSelection drops the captured selection and leaves visual mode.
+func (m *Model) endSelection() {
+	m.actions.items = nil
+	if m.visualMode {
+		m.visualMode = false
+		m.list.SetDelegate(itemDelegate{model: m})
+	}
+}
+
+// actionStatus reports an action's outcome on the status line.
+func (m *Model) actionStatus(err error, success string) tea.Cmd {
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m.clearStatusMessageAfter(4 * time.Second)
+	}
+	m.statusMessage = success
+	return m.clearStatusMessageAfter(3 * time.Second)
+}
+
+// handlePipeResult shows the first line of the command's output, or its
+// error.
+func (m *Model) handlePipeResult(msg pipeResultMsg) tea.Cmd {
+	out := strings.TrimSpace(msg.output)
+	if i := strings.IndexByte(out, '\n'); i >= 0 {
+		out = out[:i] + " …"
+	}
+	switch {
+	case msg.err != nil && out != "":
+		return m.actionStatus(fmt.Errorf("%s: %v: %s", msg.command, msg.err, out), "")
+	case msg.err != nil:
+		return m.actionStatus(fmt.Errorf("%s: %v", msg.command, msg.err), "")
+	case out == "":
+		return m.actionStatus(nil, msg.command+": done")
+	default:
+		return m.actionStatus(nil, out)
+	}
+}
+
+// hideItemComponents hides every component among items for the session and
+// returns the names newly hidden.
+func (m *Model) hideItemComponents(items []logItem) []string {
+	var names []string
+	for _, it := range items {
+		if it.component != "" && !m.hiddenComponents[it.component] {
+			m.hiddenComponents[it.component] = true
+			names = append(names, it.component)
+		}
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		m.rebuildVisible()
+	}
+	return names
+}
+
+// exportItems writes items to path: a JSON array for .json files and JSONL
+// otherwise. It returns the path written.
+func exportItems(path string, items []logItem) (string, error) {
+	expanded, err := pathutil.Expand(path)
+	if err != nil {
+		return "", fmt.Errorf("export failed: %w", err)
+	}
+	var content string
+	if strings.EqualFold(filepath.Ext(expanded), ".json") {
+		entries := make([]map[string]interface{}, 0, len(items))
+		for _, it := range items {
+			entries = append(entries, copyEntry(it))
+		}
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("export failed: %w", err)
+		}
+		content = string(b)
+	} else if content, err = formatCopy(items, CopyJSONL, ""); err != nil {
+		return "", fmt.Errorf("export failed: %w", err)
+	}
+	if err := os.WriteFile(expanded, []byte(content+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("export failed: %w", err)
+	}
+	return expanded, nil
+}
+
+// pipeItems runs command through sh with the entries as JSONL on stdin.
+func pipeItems(ctx context.Context, command string, items []logItem) tea.Cmd {
+	return func() tea.Msg {
+		content, err := formatCopy(items, CopyJSONL, "")
+		if err != nil {
+			return pipeResultMsg{command: command, err: err}
+		}
+		ctx, cancel := context.WithTimeout(ctx, pipeTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Stdin = strings.NewReader(content + "\n")
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		err = cmd.Run()
+		return pipeResultMsg{command: command, output: out.String(), err: err}
+	}
+}
+
+// issueSnippet renders items as markdown ready to paste into a GitHub
+// issue: a summary line and the entries in a collapsed code block.
+func issueSnippet(items []logItem) string {
+	components := make(map[string]bool)
+	workspaces := make(map[string]bool)
+	var first, last time.Time
+	for _, it := range items {
+		if it.component != "" {
+			components[it.component] = true
+		}
+		if it.workspace != "" {
+			workspaces[it.workspace] = true
+		}
+		if it.timestamp.IsZero() {
+			continue
+		}
+		if first.IsZero() || it.timestamp.Before(first) {
+			first = it.timestamp
+		}
+		if it.timestamp.After(last) {
+			last = it.timestamp
+		}
+	}
+
+	var b strings.Builder
+	noun := "entries"
+	if len(items) == 1 {
+		noun = "entry"
+	}
+	fmt.Fprintf(&b, "**Logs:** %d %s", len(items), noun)
+	if len(workspaces) > 0 {
+		fmt.Fprintf(&b, " from %s", codeList(workspaces))
+	}
+	if len(components) > 0 {
+		fmt.Fprintf(&b, ", components %s", codeList(components))
+	}
+	if !first.IsZero() {
+		fmt.Fprintf(&b, ", %s", first.Format("2006-01-02 15:04:05"))
+		if last.After(first) {
+			fmt.Fprintf(&b, " to %s", last.Format("15:04:05"))
+		}
+	}
+	b.WriteString("\n\n<details>\n<summary>Log entries</summary>\n\n```\n")
+	for _, it := range items {
+		line := fmt.Sprintf("%s %-5s [%s] %s", it.timestamp.Format("15:04:05.000"), strings.ToUpper(it.level), it.component, it.message)
+		if fields := issueFields(it.rawData); fields != "" {
+			line += " " + fields
+		}
+		b.WriteString(strings.ReplaceAll(line, "```", "'''") + "\n")
+	}
+	b.WriteString("```\n\n</details>\n")
+	return b.String()
+}
+
+// issueFields renders an entry's extra fields as sorted key=value pairs.
+func issueFields(raw map[string]interface{}) string {
+	var keys []string
+	for k := range raw {
+		switch k {
+		case "time", "level", "msg", "component", "workspace", "pretty_ansi", "pretty_text":
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, raw[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func codeList(set map[string]bool) string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, "`"+name+"`")
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
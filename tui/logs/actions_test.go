@@ -0,0 +1,111 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIssueSnippetSummarizesSelection(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	items := copyItems()
+	for i := range items {
+		items[i].timestamp = base.Add(time.Duration(i) * time.Minute)
+	}
+	items[1].rawData["request_id"] = "r-1"
+
+	out := issueSnippet(items)
+	for _, want := range []string{
+		"**Logs:** 3 entries from `api`, components `daemon`, 2026-03-01 09:30:00 to 09:32:00",
+		"<details>",
+		"09:31:00.000 INFO  [daemon] ready request_id=r-1",
+		"```\n\n</details>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("snippet missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportItemsFormatFollowsExtension(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := exportItems(filepath.Join(dir, "sel.json"), copyItems()[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(data, &entries); err != nil || len(entries) != 1 {
+		t.Errorf(".json export should be an array even for one entry, got %s (%v)", data, err)
+	}
+
+	path, err = exportItems(filepath.Join(dir, "sel.log"), copyItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 {
+		t.Errorf("other exports should be JSONL, got %d lines", len(lines))
+	}
+}
+
+func TestPipeItemsFeedsJSONL(t *testing.T) {
+	msg := pipeItems(context.Background(), "wc -l", copyItems())().(pipeResultMsg)
+	if msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	if got := strings.TrimSpace(msg.output); got != "3" {
+		t.Errorf("command saw %q lines, want 3", got)
+	}
+}
+
+func TestActionsMenuHidesSelectionComponents(t *testing.T) {
+	m := newSplitTestModel()
+	m.items = []logItem{
+		{component: "api", message: "a"},
+		{component: "db", message: "b"},
+		{component: "worker", message: "c"},
+	}
+	m.rebuildVisible()
+	m.list.SetDelegate(itemDelegate{model: m})
+	m.visualMode, m.visualStart, m.visualEnd = true, 0, 1
+
+	m.openActionsMenu()
+	if !m.actions.menu || len(m.actions.items) != 2 {
+		t.Fatalf("menu should open on the 2-entry selection, got %+v", m.actions)
+	}
+	m.updateActionsMenu(keyMsg("h"))
+
+	if !m.hiddenComponents["api"] || !m.hiddenComponents["db"] || m.hiddenComponents["worker"] {
+		t.Errorf("hidden components = %v, want api and db", m.hiddenComponents)
+	}
+	if m.visualMode || m.actions.menu {
+		t.Error("the action should close the menu and leave visual mode")
+	}
+	if got := len(m.list.Items()); got != 1 {
+		t.Errorf("%d rows visible, want 1", got)
+	}
+}
+
+func TestActionsPromptEscCancels(t *testing.T) {
+	m := newSplitTestModel()
+	m.list.SetItems([]list.Item{logItem{component: "api", message: "a"}})
+	m.openActionsMenu()
+	m.updateActionsMenu(keyMsg("|"))
+	if m.actions.prompt != "pipe" {
+		t.Fatalf("prompt = %q, want pipe", m.actions.prompt)
+	}
+	m.updateActionPrompt(keyMsg("x"))
+	m.updateActionPrompt(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.actions.prompt != "" || m.actions.items != nil {
+		t.Errorf("esc should drop the prompt and selection, got %+v", m.actions)
+	}
+}
@@ -0,0 +1,264 @@
+package logs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// annotationsFile is the per-workspace bookmark store, relative to the
+// workspace root.
+var annotationsFile = filepath.Join(".grove", "logs", "annotations.json")
+
+// bookmark is one persisted anchor point. Time, Component and Message are
+// stored alongside the key so the file stays readable on its own.
+type bookmark struct {
+	Time      time.Time `json:"time"`
+	Component string    `json:"component"`
+	Message   string    `json:"message"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// annotationsDoc is the on-disk shape of annotations.json.
+type annotationsDoc struct {
+	Bookmarks map[string]bookmark `json:"bookmarks"`
+}
+
+// bookmarkState holds the bookmarks for the active workspace and the
+// annotation prompt. Bookmarks are keyed by bookmarkKey so they re-attach
+// to the same entries when the daemon replays history after a restart.
+type bookmarkState struct {
+	path  string
+	marks map[string]bookmark
+
+	// annotating is true while the note prompt is open for key editKey.
+	annotating bool
+	editKey    string
+	input      textinput.Model
+}
+
+// bookmarkKey identifies a log entry across TUI restarts: its timestamp,
+// component and a short hash of its message.
+func bookmarkKey(it logItem) string {
+	sum := sha256.Sum256([]byte(it.message))
+	return fmt.Sprintf("%s|%s|%s", it.timestamp.UTC().Format(time.RFC3339Nano), it.component, hex.EncodeToString(sum[:])[:12])
+}
+
+// annotationsPath returns the annotations file for a workspace root,
+// falling back to the current directory when no workspace is active.
+func annotationsPath(workspacePath string) string {
+	root := workspacePath
+	if root == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		root = cwd
+	}
+	return filepath.Join(root, annotationsFile)
+}
+
+// loadBookmarks reads the bookmark store for workspacePath. A missing or
+// unreadable file yields an empty set.
+func (m *Model) loadBookmarks(workspacePath string) {
+	m.bookmarks.path = annotationsPath(workspacePath)
+	m.bookmarks.marks = make(map[string]bookmark)
+	if m.bookmarks.path == "" {
+		return
+	}
+	data, err := os.ReadFile(m.bookmarks.path)
+	if err != nil {
+		return
+	}
+	var doc annotationsDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return
+	}
+	for k, v := range doc.Bookmarks {
+		m.bookmarks.marks[k] = v
+	}
+}
+
+// saveBookmarks writes the bookmark store, replacing the file atomically.
+func (m *Model) saveBookmarks() error {
+	if m.bookmarks.path == "" {
+		return fmt.Errorf("no workspace directory for annotations")
+	}
+	data, err := json.MarshalIndent(annotationsDoc{Bookmarks: m.bookmarks.marks}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal annotations: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.bookmarks.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create annotations directory: %w", err)
+	}
+	tmp := m.bookmarks.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write annotations: %w", err)
+	}
+	if err := os.Rename(tmp, m.bookmarks.path); err != nil {
+		return fmt.Errorf("failed to write annotations: %w", err)
+	}
+	return nil
+}
+
+// bookmarkFor returns the bookmark attached to it, if any.
+func (m *Model) bookmarkFor(it logItem) (bookmark, bool) {
+	if len(m.bookmarks.marks) == 0 {
+		return bookmark{}, false
+	}
+	b, ok := m.bookmarks.marks[bookmarkKey(it)]
+	return b, ok
+}
+
+// selectedLogItem returns the entry under the list cursor.
+func (m *Model) selectedLogItem() (logItem, bool) {
+	if selectedItem := m.list.SelectedItem(); selectedItem != nil {
+		if li, ok := selectedItem.(logItem); ok {
+			return li, true
+		}
+	}
+	return logItem{}, false
+}
+
+// toggleBookmark bookmarks the selected entry and opens the note prompt,
+// or removes the bookmark when the entry already has one.
+func (m *Model) toggleBookmark() tea.Cmd {
+	li, ok := m.selectedLogItem()
+	if !ok {
+		return nil
+	}
+	k := bookmarkKey(li)
+	if _, exists := m.bookmarks.marks[k]; exists {
+		delete(m.bookmarks.marks, k)
+		m.statusMessage = "Bookmark removed"
+		if err := m.saveBookmarks(); err != nil {
+			m.statusMessage = fmt.Sprintf("Bookmark removed (not saved: %v)", err)
+		}
+		return m.clearStatusMessageAfter(2 * time.Second)
+	}
+	m.addBookmark(li)
+	return m.openAnnotationPrompt(k)
+}
+
+// annotateSelected opens the note prompt for the selected entry,
+// bookmarking it first if needed.
+func (m *Model) annotateSelected() tea.Cmd {
+	li, ok := m.selectedLogItem()
+	if !ok {
+		return nil
+	}
+	k := bookmarkKey(li)
+	if _, exists := m.bookmarks.marks[k]; !exists {
+		m.addBookmark(li)
+	}
+	return m.openAnnotationPrompt(k)
+}
+
+// addBookmark records an unannotated bookmark for li.
+func (m *Model) addBookmark(li logItem) {
+	if m.bookmarks.marks == nil {
+		m.bookmarks.marks = make(map[string]bookmark)
+	}
+	m.bookmarks.marks[bookmarkKey(li)] = bookmark{
+		Time:      li.timestamp,
+		Component: li.component,
+		Message:   li.message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// openAnnotationPrompt shows the note prompt for bookmark k, prefilled with
+// its current note.
+func (m *Model) openAnnotationPrompt(k string) tea.Cmd {
+	ti := textinput.New()
+	ti.Placeholder = "optional note (enter to save, esc to skip)"
+	ti.CharLimit = 500
+	ti.Width = m.width - 20
+	ti.SetValue(m.bookmarks.marks[k].Note)
+	ti.CursorEnd()
+	m.bookmarks.input = ti
+	m.bookmarks.editKey = k
+	m.bookmarks.annotating = true
+	return m.bookmarks.input.Focus()
+}
+
+// updateAnnotationPrompt handles input while the note prompt is open. Both
+// enter and esc keep the bookmark; only enter stores the typed note.
+func (m *Model) updateAnnotationPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter, tea.KeyEsc:
+		if msg.Type == tea.KeyEnter {
+			b := m.bookmarks.marks[m.bookmarks.editKey]
+			b.Note = m.bookmarks.input.Value()
+			m.bookmarks.marks[m.bookmarks.editKey] = b
+		}
+		m.bookmarks.annotating = false
+		m.bookmarks.editKey = ""
+		m.statusMessage = "Bookmarked"
+		if err := m.saveBookmarks(); err != nil {
+			m.statusMessage = fmt.Sprintf("Bookmark not saved: %v", err)
+		}
+		return m, m.clearStatusMessageAfter(2 * time.Second)
+	}
+	var cmd tea.Cmd
+	m.bookmarks.input, cmd = m.bookmarks.input.Update(msg)
+	return m, cmd
+}
+
+// jumpToNextBookmark moves the cursor to the next bookmarked entry below
+// the cursor, wrapping to the top.
+func (m *Model) jumpToNextBookmark() tea.Cmd {
+	items := m.list.VisibleItems()
+	if len(items) == 0 || len(m.bookmarks.marks) == 0 {
+		m.statusMessage = "No bookmarks"
+		return m.clearStatusMessageAfter(2 * time.Second)
+	}
+	start := m.list.Index()
+	for step := 1; step <= len(items); step++ {
+		idx := (start + step) % len(items)
+		li, ok := items[idx].(logItem)
+		if !ok {
+			continue
+		}
+		if b, ok := m.bookmarkFor(li); ok {
+			m.list.Select(idx)
+			m.viewport.SetContent(li.FormatDetails())
+			m.viewport.GotoTop()
+			m.statusMessage = "Bookmark"
+			if b.Note != "" {
+				m.statusMessage = "Bookmark: " + b.Note
+			}
+			return m.clearStatusMessageAfter(3 * time.Second)
+		}
+	}
+	m.statusMessage = "No bookmarks in view"
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// bookmarkedTitle decorates a list row with the bookmark marker and note.
+// Rows are only indented when at least one bookmark exists, so the list
+// looks unchanged for users who never bookmark.
+func (m *Model) bookmarkedTitle(it logItem, title string) string {
+	if len(m.bookmarks.marks) == 0 {
+		return title
+	}
+	b, ok := m.bookmarks.marks[bookmarkKey(it)]
+	if !ok {
+		return "  " + title
+	}
+	marker := theme.DefaultTheme.Highlight.Render("◆ ")
+	if b.Note != "" {
+		title += theme.DefaultTheme.Muted.Render("  " + theme.IconNote + " " + b.Note)
+	}
+	return marker + title
+}
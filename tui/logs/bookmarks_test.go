@@ -0,0 +1,84 @@
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBookmarkPersistsAcrossModels(t *testing.T) {
+	ws := t.TempDir()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	items := []logItem{
+		{component: "api", timestamp: base, message: "first"},
+		{component: "api", timestamp: base.Add(time.Second), message: "second"},
+		{component: "db", timestamp: base.Add(2 * time.Second), message: "third"},
+	}
+
+	m := newSplitTestModel()
+	m.loadBookmarks(ws)
+	m.items = items
+	m.rebuildVisible()
+	m.list.Select(1)
+
+	m.toggleBookmark()
+	if !m.bookmarks.annotating {
+		t.Fatal("expected the note prompt after bookmarking")
+	}
+	for _, r := range "slow path" {
+		m.updateAnnotationPrompt(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.updateAnnotationPrompt(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if _, err := os.Stat(filepath.Join(ws, ".grove", "logs", "annotations.json")); err != nil {
+		t.Fatalf("annotations file not written: %v", err)
+	}
+
+	// A fresh model over the same workspace sees the bookmark again.
+	m2 := newSplitTestModel()
+	m2.loadBookmarks(ws)
+	m2.items = items
+	m2.rebuildVisible()
+
+	b, ok := m2.bookmarkFor(items[1])
+	if !ok || b.Note != "slow path" {
+		t.Fatalf("expected persisted bookmark with note, got %+v (found=%v)", b, ok)
+	}
+	if _, ok := m2.bookmarkFor(items[0]); ok {
+		t.Error("unexpected bookmark on unmarked entry")
+	}
+
+	m2.jumpToNextBookmark()
+	if m2.list.Index() != 1 {
+		t.Errorf("expected jump to bookmarked entry at 1, got %d", m2.list.Index())
+	}
+}
+
+func TestToggleBookmarkRemovesExisting(t *testing.T) {
+	m := newSplitTestModel()
+	m.loadBookmarks(t.TempDir())
+	m.items = []logItem{{component: "api", message: "only"}}
+	m.rebuildVisible()
+
+	m.toggleBookmark()
+	m.updateAnnotationPrompt(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.bookmarks.marks) != 1 {
+		t.Fatalf("expected one bookmark, got %d", len(m.bookmarks.marks))
+	}
+	m.toggleBookmark()
+	if len(m.bookmarks.marks) != 0 {
+		t.Errorf("expected bookmark to be removed, got %d", len(m.bookmarks.marks))
+	}
+}
+
+func TestBookmarkKeyDistinguishesMessages(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := bookmarkKey(logItem{component: "api", timestamp: ts, message: "a"})
+	b := bookmarkKey(logItem{component: "api", timestamp: ts, message: "b"})
+	if a == b {
+		t.Error("entries with the same time and component but different messages must not share a key")
+	}
+}
@@ -0,0 +1,90 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// contextSteps are the context sizes cycled with the ToggleContext key ("X").
+var contextSteps = []int{0, 2, 5}
+
+// contextActive reports whether context rows apply: a size is set and a
+// client-side filter (events-only, the component or workspace picker or a
+// correlation filter) is hiding rows.
+// Level and scope are filtered by the daemon, so those entries never reach
+// the model and cannot be shown as context.
+func (m *Model) contextActive() bool {
+	return m.contextLines > 0 && (m.eventsOnly || len(m.hiddenComponents) > 0 || len(m.workspaces.hidden) > 0 || m.correlation.key != "")
+}
+
+// visibleWithContext returns the items passing the client-side filters plus,
+// marked as context, up to contextLines filtered-out items before and after
+// each match from the same workspace.
+func (m *Model) visibleWithContext() []logItem {
+	matched := make([]bool, len(m.items))
+	include := make([]bool, len(m.items))
+	streams := make(map[string][]int)
+	for i, it := range m.items {
+		matched[i] = m.matchesWorkspaceFilter(it) && m.matchesComponentFilter(it) && m.matchesEventsFilter(it) && m.matchesCorrelation(it)
+		streams[it.workspace] = append(streams[it.workspace], i)
+	}
+	for _, idxs := range streams {
+		for pos, i := range idxs {
+			if !matched[i] {
+				continue
+			}
+			lo := max(pos-m.contextLines, 0)
+			hi := min(pos+m.contextLines, len(idxs)-1)
+			for _, j := range idxs[lo : hi+1] {
+				include[j] = true
+			}
+		}
+	}
+
+	var out []logItem
+	for i, it := range m.items {
+		if !include[i] {
+			continue
+		}
+		it.context = !matched[i]
+		out = append(out, it)
+	}
+	return out
+}
+
+// cycleContext advances the context size through contextSteps.
+func (m *Model) cycleContext() tea.Cmd {
+	next := contextSteps[0]
+	for i, n := range contextSteps {
+		if n == m.contextLines {
+			next = contextSteps[(i+1)%len(contextSteps)]
+			break
+		}
+	}
+	m.contextLines = next
+	if next == 0 {
+		m.statusMessage = "Context rows: off"
+	} else {
+		m.statusMessage = fmt.Sprintf("Context rows: %d around each match (dimmed)", next)
+	}
+	m.rebuildVisible()
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// dimContextRow renders a context row in the muted style so matches stand out.
+func dimContextRow(row string) string {
+	return theme.DefaultTheme.Muted.Render(ansi.Strip(row))
+}
+
+// contextIndicator is the status bar segment shown while context rows apply.
+func (m *Model) contextIndicator() string {
+	if !m.contextActive() {
+		return ""
+	}
+	return fmt.Sprintf(" [CTX %d]", m.contextLines)
+}
@@ -0,0 +1,41 @@
+package logs
+
+import "testing"
+
+func TestVisibleWithContext(t *testing.T) {
+	m := &Model{eventsOnly: true, contextLines: 1, hiddenComponents: map[string]bool{}}
+	info := func(ws, msg string) logItem {
+		return logItem{workspace: ws, level: "info", message: msg, rawData: map[string]interface{}{}}
+	}
+	m.items = []logItem{
+		info("api", "a"),
+		info("worker", "w1"),
+		info("api", "b"),
+		{workspace: "api", level: "error", message: "boom"},
+		info("worker", "w2"),
+		info("api", "c"),
+		info("api", "d"),
+	}
+	if !m.contextActive() {
+		t.Fatal("context should apply while events-only hides rows")
+	}
+
+	got := m.visibleWithContext()
+	want := []struct {
+		msg     string
+		context bool
+	}{{"b", true}, {"boom", false}, {"c", true}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].message != w.msg || got[i].context != w.context {
+			t.Errorf("row %d = %q (context=%v), want %q (context=%v)", i, got[i].message, got[i].context, w.msg, w.context)
+		}
+	}
+
+	m.eventsOnly = false
+	if m.contextActive() {
+		t.Error("context should not apply when no client-side filter is active")
+	}
+}
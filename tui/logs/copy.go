@@ -0,0 +1,255 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/util/sanitize"
+)
+
+// CopyFormat selects how yanked entries are written to the clipboard.
+type CopyFormat string
+
+const (
+	// CopyJSONL writes one compact JSON object per line, as in the log files.
+	CopyJSONL CopyFormat = "jsonl"
+	// CopyJSON writes pretty-printed JSON: an object for a single entry and
+	// an array for a visual selection.
+	CopyJSON CopyFormat = "json"
+	// CopyJQ writes a `jq` command that selects entries with the same
+	// component, level and message as the selection.
+	CopyJQ CopyFormat = "jq"
+	// CopyGrep writes a `grep -F` command matching the active search term,
+	// or the selected messages when no search is applied.
+	CopyGrep CopyFormat = "grep"
+)
+
+// DefaultCopyFormat is used by the Yank key when tui.logs.copy_format is unset.
+const DefaultCopyFormat = CopyJSON
+
+// copyFormats lists the choices offered by the copy-as prompt, keyed by the
+// register letter that selects them.
+var copyFormats = []struct {
+	key    string
+	format CopyFormat
+	label  string
+}{
+	{"r", CopyJSONL, "JSONL"},
+	{"j", CopyJSON, "JSON"},
+	{"q", CopyJQ, "jq filter"},
+	{"g", CopyGrep, "grep command"},
+}
+
+// ParseCopyFormat maps a tui.logs.copy_format value to a CopyFormat,
+// falling back to DefaultCopyFormat for empty or unknown values.
+func ParseCopyFormat(s string) CopyFormat {
+	for _, f := range copyFormats {
+		if string(f.format) == strings.ToLower(strings.TrimSpace(s)) {
+			return f.format
+		}
+	}
+	return DefaultCopyFormat
+}
+
+func copyFormatLabel(format CopyFormat) string {
+	for _, f := range copyFormats {
+		if f.format == format {
+			return f.label
+		}
+	}
+	return string(format)
+}
+
+// copyPromptView is the status-line prompt shown after the CopyAs key.
+func copyPromptView() string {
+	parts := make([]string, 0, len(copyFormats))
+	for _, f := range copyFormats {
+		parts = append(parts, fmt.Sprintf("[%s] %s", f.key, f.label))
+	}
+	return " Copy as: " + strings.Join(parts, "  ") + "  (esc to cancel)"
+}
+
+// updateCopyPrompt handles the register key typed after CopyAs.
+func (m *Model) updateCopyPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.copyPrompt = false
+	for _, f := range copyFormats {
+		if msg.String() == f.key {
+			return m, m.copySelection(f.format)
+		}
+	}
+	return m, nil
+}
+
+// selectedItems returns the visual selection, or the highlighted entry when
+// visual mode is off.
+func (m *Model) selectedItems() []logItem {
+	if !m.visualMode {
+		if li, ok := m.selectedLogItem(); ok {
+			return []logItem{li}
+		}
+		return nil
+	}
+	lo, hi := m.visualStart, m.visualEnd
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	visibleItems := m.list.VisibleItems()
+	var items []logItem
+	for i := lo; i <= hi && i < len(visibleItems); i++ {
+		if li, ok := visibleItems[i].(logItem); ok {
+			items = append(items, li)
+		}
+	}
+	return items
+}
+
+// copySelection copies the selected entries in format and leaves visual mode.
+func (m *Model) copySelection(format CopyFormat) tea.Cmd {
+	items := m.selectedItems()
+	if m.visualMode {
+		m.visualMode = false
+		m.list.SetDelegate(itemDelegate{model: m})
+	}
+	return m.copyItems(items, format)
+}
+
+// copyItems copies items to the clipboard in format and reports the result
+// on the status line.
+func (m *Model) copyItems(items []logItem, format CopyFormat) tea.Cmd {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var term string
+	if m.list.FilterState() == list.FilterApplied {
+		term = m.list.FilterValue()
+	}
+	content, err := formatCopy(items, format, term)
+	if err == nil {
+		err = m.copyToClipboard(content)
+	}
+	switch {
+	case err != nil:
+		m.statusMessage = fmt.Sprintf("Copy failed: %v", err)
+	case len(items) == 1:
+		m.statusMessage = "Copied log entry as " + copyFormatLabel(format)
+	default:
+		m.statusMessage = fmt.Sprintf("Copied %d log entries as %s", len(items), copyFormatLabel(format))
+	}
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// copyEntry is the JSON form of an entry: its raw fields plus the workspace
+// it was streamed from.
+func copyEntry(it logItem) map[string]interface{} {
+	entry := make(map[string]interface{}, len(it.rawData)+1)
+	for k, v := range it.rawData {
+		entry[k] = v
+	}
+	entry["workspace"] = it.workspace
+	return entry
+}
+
+// formatCopy renders items in format. searchTerm is the applied list search,
+// which the grep format reproduces in preference to the selected messages.
+func formatCopy(items []logItem, format CopyFormat, searchTerm string) (string, error) {
+	switch format {
+	case CopyJSONL:
+		lines := make([]string, 0, len(items))
+		for _, it := range items {
+			b, err := json.Marshal(copyEntry(it))
+			if err != nil {
+				return "", fmt.Errorf("failed to encode entry: %w", err)
+			}
+			lines = append(lines, string(b))
+		}
+		return strings.Join(lines, "\n"), nil
+
+	case CopyJQ:
+		return jqCommand(items), nil
+
+	case CopyGrep:
+		return grepCommand(items, searchTerm), nil
+
+	default:
+		var v interface{}
+		if len(items) == 1 {
+			v = copyEntry(items[0])
+		} else {
+			entries := make([]map[string]interface{}, 0, len(items))
+			for _, it := range items {
+				entries = append(entries, copyEntry(it))
+			}
+			v = entries
+		}
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode entries: %w", err)
+		}
+		return string(b), nil
+	}
+}
+
+// jqCommand builds a jq invocation that selects log lines sharing the
+// component, level and message of any selected entry.
+func jqCommand(items []logItem) string {
+	seen := make(map[string]bool)
+	var clauses []string
+	for _, it := range items {
+		var conds []string
+		for _, f := range []struct{ field, value string }{
+			{"component", it.component},
+			{"level", it.level},
+			{"msg", it.message},
+		} {
+			if f.value != "" {
+				lit, _ := json.Marshal(f.value)
+				conds = append(conds, fmt.Sprintf(".%s == %s", f.field, lit))
+			}
+		}
+		if len(conds) == 0 {
+			continue
+		}
+		clause := strings.Join(conds, " and ")
+		if !seen[clause] {
+			seen[clause] = true
+			clauses = append(clauses, clause)
+		}
+	}
+	if len(clauses) == 0 {
+		return "jq -c ."
+	}
+	if len(clauses) > 1 {
+		for i, c := range clauses {
+			clauses[i] = "(" + c + ")"
+		}
+	}
+	return "jq -c " + sanitize.ForShell("select("+strings.Join(clauses, " or ")+")")
+}
+
+// grepCommand builds a fixed-string grep for the search term, or for each
+// distinct selected message when no search is applied.
+func grepCommand(items []logItem, searchTerm string) string {
+	patterns := []string{searchTerm}
+	if searchTerm == "" {
+		patterns = patterns[:0]
+		seen := make(map[string]bool)
+		for _, it := range items {
+			if it.message != "" && !seen[it.message] {
+				seen[it.message] = true
+				patterns = append(patterns, it.message)
+			}
+		}
+	}
+	var b strings.Builder
+	b.WriteString("grep -F")
+	for _, p := range patterns {
+		b.WriteString(" -e " + sanitize.ForShell(p))
+	}
+	return b.String()
+}
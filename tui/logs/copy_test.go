@@ -0,0 +1,87 @@
+package logs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func copyItems() []logItem {
+	entry := func(component, level, msg string) logItem {
+		return logItem{
+			workspace: "api",
+			component: component,
+			level:     level,
+			message:   msg,
+			rawData:   map[string]interface{}{"component": component, "level": level, "msg": msg},
+		}
+	}
+	return []logItem{
+		entry("daemon", "error", "it's broken"),
+		entry("daemon", "info", "ready"),
+		entry("daemon", "error", "it's broken"),
+	}
+}
+
+func TestParseCopyFormat(t *testing.T) {
+	for in, want := range map[string]CopyFormat{
+		"":       DefaultCopyFormat,
+		"JQ":     CopyJQ,
+		" jsonl": CopyJSONL,
+		"yaml":   DefaultCopyFormat,
+	} {
+		if got := ParseCopyFormat(in); got != want {
+			t.Errorf("ParseCopyFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFormatCopyJSON(t *testing.T) {
+	items := copyItems()
+
+	out, err := formatCopy(items[:1], CopyJSON, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var one map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &one); err != nil || one["workspace"] != "api" {
+		t.Errorf("single entry should be one object with workspace, got %s (%v)", out, err)
+	}
+
+	out, _ = formatCopy(items, CopyJSON, "")
+	var many []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &many); err != nil || len(many) != 3 {
+		t.Errorf("selection should be a 3-element array, got %s (%v)", out, err)
+	}
+
+	out, _ = formatCopy(items, CopyJSONL, "")
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("jsonl has %d lines, want 3", len(lines))
+	}
+	for _, l := range lines {
+		if !json.Valid([]byte(l)) || strings.Contains(l, "\n  ") {
+			t.Errorf("jsonl line is not compact JSON: %s", l)
+		}
+	}
+}
+
+func TestFormatCopyJQ(t *testing.T) {
+	out, _ := formatCopy(copyItems(), CopyJQ, "")
+	want := `jq -c 'select((.component == "daemon" and .level == "error" and .msg == "it'\''s broken") or ` +
+		`(.component == "daemon" and .level == "info" and .msg == "ready"))'`
+	if out != want {
+		t.Errorf("jq command:\n got %s\nwant %s", out, want)
+	}
+}
+
+func TestFormatCopyGrep(t *testing.T) {
+	out, _ := formatCopy(copyItems(), CopyGrep, "")
+	if want := `grep -F -e 'it'\''s broken' -e 'ready'`; out != want {
+		t.Errorf("grep from selection = %s, want %s", out, want)
+	}
+	out, _ = formatCopy(copyItems(), CopyGrep, "daemon")
+	if want := `grep -F -e 'daemon'`; out != want {
+		t.Errorf("grep from search term = %s, want %s", out, want)
+	}
+}
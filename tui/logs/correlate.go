@@ -0,0 +1,95 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// correlationKeys are the structured fields that tie entries of one request
+// across components, most specific first: the Correlate key ("r") filters
+// on the first of them the selected entry carries.
+var correlationKeys = []string{"request_id", "trace_id", "session_id"}
+
+// correlationFilter restricts the list to entries sharing one correlation
+// value. An empty key means no filter.
+type correlationFilter struct {
+	key   string
+	value string
+}
+
+// correlationOf returns the first correlation field set on it.
+func correlationOf(it logItem) (correlationFilter, bool) {
+	for _, key := range correlationKeys {
+		if v, ok := it.rawData[key]; ok && v != nil {
+			if s := fmt.Sprint(v); s != "" {
+				return correlationFilter{key: key, value: s}, true
+			}
+		}
+	}
+	return correlationFilter{}, false
+}
+
+// matchesCorrelation returns true when no correlation filter is set or the
+// item carries the filtered value under the same key.
+func (m *Model) matchesCorrelation(it logItem) bool {
+	if m.correlation.key == "" {
+		return true
+	}
+	v, ok := it.rawData[m.correlation.key]
+	return ok && v != nil && fmt.Sprint(v) == m.correlation.value
+}
+
+// correlateSelected filters the list to the entries sharing the selected
+// entry's correlation value, keeping that entry selected.
+func (m *Model) correlateSelected() tea.Cmd {
+	li, ok := m.list.SelectedItem().(logItem)
+	if !ok {
+		return nil
+	}
+	filter, ok := correlationOf(li)
+	if !ok {
+		m.statusMessage = "No request_id, trace_id or session_id on this entry"
+		return m.clearStatusMessageAfter(2 * time.Second)
+	}
+	m.correlation = filter
+	m.rebuildVisible()
+	m.selectItem(li)
+	m.statusMessage = fmt.Sprintf("Showing %d entries with %s=%s (R to clear)", len(m.visible), filter.key, filter.value)
+	return m.clearStatusMessageAfter(3 * time.Second)
+}
+
+// clearCorrelation removes the correlation filter, keeping the selected
+// entry selected.
+func (m *Model) clearCorrelation() tea.Cmd {
+	if m.correlation.key == "" {
+		return nil
+	}
+	li, _ := m.list.SelectedItem().(logItem)
+	m.correlation = correlationFilter{}
+	m.rebuildVisible()
+	m.selectItem(li)
+	m.statusMessage = "Correlation filter cleared"
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// selectItem moves the cursor to the visible row for li, if present.
+func (m *Model) selectItem(li logItem) {
+	for i, v := range m.visible {
+		it, ok := v.(logItem)
+		if ok && it.timestamp.Equal(li.timestamp) && it.message == li.message && it.component == li.component {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// correlationIndicator is the status bar segment shown while a correlation
+// filter applies.
+func (m *Model) correlationIndicator() string {
+	if m.correlation.key == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s=%s]", m.correlation.key, m.correlation.value)
+}
@@ -0,0 +1,62 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+)
+
+func TestCorrelateFiltersToSharedRequest(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSplitTestModel()
+	m.workspaceColorMap = map[string]lipgloss.Style{}
+	m.sequence = tuikeymap.NewSequenceState()
+	m.keys.Correlate = key.NewBinding(key.WithKeys("r"))
+	m.keys.ClearCorrelation = key.NewBinding(key.WithKeys("R"))
+	m.items = []logItem{
+		{component: "api", timestamp: base, message: "request in", rawData: map[string]interface{}{"request_id": "a1", "session_id": "s"}},
+		{component: "db", timestamp: base.Add(time.Second), message: "query", rawData: map[string]interface{}{"request_id": "a1"}},
+		{component: "api", timestamp: base.Add(2 * time.Second), message: "other request", rawData: map[string]interface{}{"request_id": "b2", "session_id": "s"}},
+		{component: "worker", timestamp: base.Add(3 * time.Second), message: "untagged"},
+	}
+	m.rebuildVisible()
+	m.resizeList()
+	m.list.Select(1)
+
+	m.Update(keyMsg("r"))
+	if m.correlation.key != "request_id" || m.correlation.value != "a1" {
+		t.Fatalf("correlation = %+v, want request_id=a1", m.correlation)
+	}
+	if len(m.visible) != 2 {
+		t.Fatalf("expected 2 related entries, got %d", len(m.visible))
+	}
+	if li, _ := m.list.SelectedItem().(logItem); li.message != "query" {
+		t.Errorf("selection moved to %q, want the correlated entry", li.message)
+	}
+
+	// New entries for the same request show up; others stay hidden.
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "info", "msg": "done", "request_id": "a1", "time": base.Add(4 * time.Second).Format(time.RFC3339)}})
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "info", "msg": "noise", "time": base.Add(5 * time.Second).Format(time.RFC3339)}})
+	if len(m.visible) != 3 {
+		t.Fatalf("expected 3 related entries after new logs, got %d", len(m.visible))
+	}
+
+	m.Update(keyMsg("R"))
+	if m.correlation.key != "" || len(m.visible) != 6 {
+		t.Fatalf("after clear: correlation = %+v, visible = %d", m.correlation, len(m.visible))
+	}
+	if li, _ := m.list.SelectedItem().(logItem); li.message != "query" {
+		t.Errorf("selection after clear = %q, want query", li.message)
+	}
+
+	// An entry with no correlation field leaves the list alone.
+	m.list.Select(3)
+	m.Update(keyMsg("r"))
+	if m.correlation.key != "" || len(m.visible) != 6 {
+		t.Errorf("untagged entry set correlation %+v", m.correlation)
+	}
+}
@@ -0,0 +1,217 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultFollowResumeAfter is how long the resume follow mode waits
+// without input before returning to the newest entry, when
+// tui.logs.follow_resume_after is unset.
+const DefaultFollowResumeAfter = 10 * time.Second
+
+// followBehavior is how follow mode reacts to the cursor being moved
+// (tui.logs.follow_mode). The ToggleFollow key ("F") cycles follow mode
+// through each behavior and off.
+type followBehavior int
+
+const (
+	// followPin keeps the newest entry selected; the cursor snaps back
+	// with the next entry however it was moved.
+	followPin followBehavior = iota
+	// followPause stops following when the cursor is moved off the newest
+	// entry and counts the entries that arrive until it is moved back.
+	followPause
+	// followResume is followPause that also returns to the newest entry
+	// after the resume delay without input.
+	followResume
+)
+
+func (b followBehavior) String() string {
+	switch b {
+	case followPause:
+		return "pause"
+	case followResume:
+		return "resume"
+	default:
+		return "pin"
+	}
+}
+
+// parseFollowBehavior maps tui.logs.follow_mode to a behavior; unknown
+// values pin.
+func parseFollowBehavior(s string) followBehavior {
+	switch s {
+	case "pause":
+		return followPause
+	case "resume":
+		return followResume
+	default:
+		return followPin
+	}
+}
+
+// followState is follow mode's auto-scroll behavior and, for the pausing
+// behaviors, whether it is paused and what arrived since.
+type followState struct {
+	// behavior is the active behavior; configured is tui.logs.follow_mode,
+	// the one follow mode starts with.
+	behavior    followBehavior
+	configured  followBehavior
+	resumeAfter time.Duration
+	// paused is set when the cursor leaves the newest entry under a
+	// pausing behavior; newCount counts the entries shown since.
+	paused   bool
+	newCount int
+	// lastInput is the time of the last key or mouse message, from which
+	// the resume behavior measures idleness.
+	lastInput time.Time
+}
+
+// cycleFollow steps follow mode from off through each behavior, starting
+// with the configured one, and back to off. Turning it on or changing the
+// behavior selects the newest entry.
+func (m *Model) cycleFollow() tea.Cmd {
+	next := (m.follow.behavior + 1) % (followResume + 1)
+	switch {
+	case !m.followMode:
+		m.followMode = true
+		m.follow.behavior = m.follow.configured
+	case next == m.follow.configured:
+		m.followMode = false
+	default:
+		m.follow.behavior = next
+	}
+	m.follow.paused, m.follow.newCount = false, 0
+	if m.followMode {
+		m.selectNewest()
+	}
+	m.statusMessage = m.followStatus()
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// followStatus describes the current follow mode for the status message.
+func (m *Model) followStatus() string {
+	if !m.followMode {
+		return "Follow mode disabled"
+	}
+	switch m.follow.behavior {
+	case followPause:
+		return "Follow: pause when the cursor moves"
+	case followResume:
+		return fmt.Sprintf("Follow: pause when the cursor moves, resume after %s idle", m.followResumeAfter())
+	default:
+		return "Follow: pin to newest"
+	}
+}
+
+// followResumeAfter is the resume behavior's idle delay.
+func (m *Model) followResumeAfter() time.Duration {
+	if m.follow.resumeAfter > 0 {
+		return m.follow.resumeAfter
+	}
+	return DefaultFollowResumeAfter
+}
+
+// followCursor is the index of the selected row in the list, or in the
+// split view while it is open.
+func (m *Model) followCursor() int {
+	if m.split.active {
+		return m.split.cursor
+	}
+	return m.list.Index()
+}
+
+// atNewest reports whether the cursor is on the last row.
+func (m *Model) atNewest() bool {
+	if m.split.active {
+		return m.split.cursor >= len(m.split.rows)-1
+	}
+	return m.list.Index() >= len(m.visible)-1
+}
+
+// noteInput records a key or mouse message handled with the cursor at
+// before. Under a pausing behavior, moving the cursor off the newest entry
+// pauses following and moving it back to the newest entry resumes it.
+func (m *Model) noteInput(msg tea.Msg, before int) {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+	default:
+		return
+	}
+	m.follow.lastInput = time.Now()
+	if !m.followMode || m.follow.behavior == followPin || m.followCursor() == before {
+		return
+	}
+	if m.atNewest() {
+		m.follow.paused, m.follow.newCount = false, 0
+	} else {
+		m.follow.paused = true
+	}
+}
+
+// followNewEntry moves the cursor to an entry that just arrived, or counts
+// it while following is paused. shown is false when the entry was filtered
+// out of the rows on screen.
+func (m *Model) followNewEntry(shown bool) {
+	if !m.followMode {
+		return
+	}
+	if m.follow.paused {
+		if shown {
+			m.follow.newCount++
+		}
+		return
+	}
+	m.selectNewest()
+}
+
+// resumeFollowIfIdle returns a paused resume behavior to the newest entry
+// once there has been no input for the resume delay.
+func (m *Model) resumeFollowIfIdle(now time.Time) {
+	if !m.followMode || !m.follow.paused || m.follow.behavior != followResume {
+		return
+	}
+	if now.Sub(m.follow.lastInput) < m.followResumeAfter() {
+		return
+	}
+	m.follow.paused, m.follow.newCount = false, 0
+	m.selectNewest()
+}
+
+// selectNewest selects the last row and shows its details.
+func (m *Model) selectNewest() {
+	if m.split.active {
+		if len(m.split.rows) > 0 {
+			m.splitSelect(len(m.split.rows) - 1)
+		}
+		return
+	}
+	if len(m.visible) == 0 {
+		return
+	}
+	m.list.Select(len(m.visible) - 1)
+	if li, ok := m.selectedLogItem(); ok {
+		m.viewport.SetContent(li.FormatDetails())
+		m.viewport.GotoTop()
+	}
+}
+
+// followIndicator is the status line's note of the follow mode, with the
+// count of entries that arrived while paused.
+func (m *Model) followIndicator() string {
+	switch {
+	case !m.followMode:
+		return " [Follow:OFF]"
+	case m.follow.paused:
+		return fmt.Sprintf(" [Follow:PAUSED +%d NEW]", m.follow.newCount)
+	case m.follow.behavior == followPause:
+		return " [Follow:PAUSE]"
+	case m.follow.behavior == followResume:
+		return " [Follow:RESUME]"
+	default:
+		return " [Follow:ON]"
+	}
+}
@@ -0,0 +1,96 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+)
+
+func newFollowTestModel(behavior followBehavior, entries int) *Model {
+	m := newSplitTestModel()
+	m.workspaceColorMap = map[string]lipgloss.Style{}
+	m.sequence = tuikeymap.NewSequenceState()
+	m.followMode = true
+	m.follow.behavior, m.follow.configured = behavior, behavior
+	m.resizeList()
+	for i := 0; i < entries; i++ {
+		m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "info", "msg": "entry"}})
+	}
+	return m
+}
+
+func TestFollowPinSnapsBack(t *testing.T) {
+	m := newFollowTestModel(followPin, 5)
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.follow.paused {
+		t.Fatal("pin should not pause on navigation")
+	}
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "info", "msg": "entry"}})
+	if m.list.Index() != 5 {
+		t.Errorf("pin: cursor at %d, want the newest entry 5", m.list.Index())
+	}
+}
+
+func TestFollowPauseCountsNewEntries(t *testing.T) {
+	m := newFollowTestModel(followPause, 5)
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if !m.follow.paused || m.list.Index() != 3 {
+		t.Fatalf("moving up should pause at 3: paused %v, cursor %d", m.follow.paused, m.list.Index())
+	}
+
+	for i := 0; i < 2; i++ {
+		m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "info", "msg": "entry"}})
+	}
+	if m.list.Index() != 3 {
+		t.Errorf("paused: cursor moved to %d", m.list.Index())
+	}
+	if got := m.followIndicator(); !strings.Contains(got, "+2 NEW") {
+		t.Errorf("indicator = %q, want the NEW count", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if m.follow.paused || m.follow.newCount != 0 {
+		t.Errorf("returning to the newest entry should resume: paused %v, new %d", m.follow.paused, m.follow.newCount)
+	}
+}
+
+func TestFollowResumesAfterIdle(t *testing.T) {
+	m := newFollowTestModel(followResume, 5)
+	m.follow.resumeAfter = time.Second
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "info", "msg": "entry"}})
+	if !m.follow.paused {
+		t.Fatal("expected navigation to pause following")
+	}
+
+	m.Update(tickMsg(m.follow.lastInput.Add(500 * time.Millisecond)))
+	if !m.follow.paused {
+		t.Fatal("resumed before the idle delay")
+	}
+	m.Update(tickMsg(m.follow.lastInput.Add(time.Second)))
+	if m.follow.paused || m.list.Index() != 5 {
+		t.Errorf("after the idle delay: paused %v, cursor %d, want the newest entry 5", m.follow.paused, m.list.Index())
+	}
+}
+
+func TestCycleFollowStartsAtConfiguredMode(t *testing.T) {
+	m := newFollowTestModel(followPause, 0)
+	m.followMode = false
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		m.cycleFollow()
+		got = append(got, strings.TrimSpace(m.followIndicator()))
+	}
+	want := []string{"[Follow:PAUSE]", "[Follow:RESUME]", "[Follow:ON]", "[Follow:OFF]"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("cycle = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,237 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/pkg/models"
+)
+
+// DefaultMaxEntries is the number of entries the viewer holds in memory
+// when tui.logs.max_entries is unset.
+const DefaultMaxEntries = 10000
+
+// historyProbeDelay lets the connect-time replay arrive before the viewer
+// asks the daemon how many older entries there are.
+const historyProbeDelay = time.Second
+
+// historyState tracks the entries older than the oldest one loaded. The
+// daemon replays only the latest entries on connect; older pages are
+// loaded on demand with gg or pgup at the top of the list, and entries
+// dropped by the memory cap can be loaded again the same way.
+type historyState struct {
+	// gen identifies the stream connection; replies for an earlier one
+	// are dropped.
+	gen int
+	// older is how many entries precede the oldest loaded one, or -1
+	// while unknown.
+	older   int
+	loading bool
+	// unavailable is set when the daemon cannot serve history.
+	unavailable bool
+}
+
+type historyProbeMsg struct{ gen int }
+
+type historyPageMsg struct {
+	gen   int
+	probe bool
+	page  *models.LogHistoryPage
+	err   error
+}
+
+// maxEntries is the memory cap on m.items.
+func (m *Model) maxEntries() int {
+	if m.cfg.MaxEntries > 0 {
+		return m.cfg.MaxEntries
+	}
+	return DefaultMaxEntries
+}
+
+// enforceMaxEntries drops the oldest entries once the buffer is 10% over
+// the cap, so trimming does not run on every arrival. Dropped entries join
+// the older count and can be loaded again. It reports whether it trimmed,
+// in which case the visible rows were rebuilt.
+func (m *Model) enforceMaxEntries() bool {
+	limit := m.maxEntries()
+	if len(m.items) <= limit+limit/10 {
+		return false
+	}
+	drop := len(m.items) - limit
+	m.items = append(m.items[:0], m.items[drop:]...)
+	if m.history.older >= 0 {
+		m.history.older += drop
+	}
+	m.rebuildVisible()
+	return true
+}
+
+// resetHistory starts history tracking for a new stream connection and
+// schedules the probe for the older count.
+func (m *Model) resetHistory() tea.Cmd {
+	m.history = historyState{gen: m.history.gen + 1, older: -1}
+	gen := m.history.gen
+	return tea.Tick(historyProbeDelay, func(time.Time) tea.Msg {
+		return historyProbeMsg{gen: gen}
+	})
+}
+
+// fetchHistory requests up to limit entries at or before the oldest
+// loaded one. A zero limit only counts them.
+func (m *Model) fetchHistory(limit int, probe bool) tea.Cmd {
+	client := m.cfg.DaemonClient
+	if client == nil {
+		return nil
+	}
+	opts := models.LogHistoryOptions{
+		Scope:     m.activeScope.scopeToParam(),
+		Workspace: m.activeWorkspacePath,
+		Level:     levelToParam(m.minLevel),
+		System:    m.includeSystem,
+		Limit:     limit,
+	}
+	if len(m.items) > 0 {
+		opts.Before = m.items[0].timestamp
+	}
+	ctx, gen := m.ctx, m.history.gen
+	return func() tea.Msg {
+		page, err := client.GetLogHistory(ctx, opts)
+		return historyPageMsg{gen: gen, probe: probe, page: page, err: err}
+	}
+}
+
+// loadOlder requests the page of entries before the oldest loaded one,
+// sized to the replay count and to the room left under the memory cap.
+func (m *Model) loadOlder() tea.Cmd {
+	switch {
+	case m.activeScope == ScopeDaemon || m.history.unavailable:
+		m.statusMessage = "Older entries are not available"
+	case m.history.loading:
+		return nil
+	case m.history.older == 0:
+		m.statusMessage = "No older entries"
+	default:
+		limit := m.cfg.Replay
+		if room := m.maxEntries() - len(m.items); room < limit {
+			limit = room
+		}
+		if limit <= 0 {
+			m.statusMessage = fmt.Sprintf("Holding the maximum of %d entries; clear the buffer to load older ones", m.maxEntries())
+			return m.clearStatusMessageAfter(3 * time.Second)
+		}
+		cmd := m.fetchHistory(limit, false)
+		if cmd == nil {
+			return nil
+		}
+		m.history.loading = true
+		return cmd
+	}
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// handleHistoryPage applies a history reply: a probe sets the older count,
+// a page is prepended with the selection kept on the same row.
+func (m *Model) handleHistoryPage(msg historyPageMsg) tea.Cmd {
+	if msg.gen != m.history.gen {
+		return nil
+	}
+	if !msg.probe {
+		m.history.loading = false
+	}
+	if msg.err != nil {
+		m.history.unavailable = true
+		if msg.probe {
+			return nil
+		}
+		m.statusMessage = fmt.Sprintf("Loading older entries failed: %v", msg.err)
+		return m.clearStatusMessageAfter(5 * time.Second)
+	}
+
+	if msg.probe {
+		// An empty page counts every entry at or before the oldest loaded
+		// one, which includes the loaded entries sharing its timestamp.
+		if msg.page.Remaining >= 0 {
+			m.history.older = max(msg.page.Remaining-m.heldAtOldest(), 0)
+		}
+		return nil
+	}
+
+	var older []logItem
+	for _, line := range msg.page.Lines {
+		parsed := parseStreamLine(line)
+		if parsed == nil {
+			continue
+		}
+		it, ok := m.newItem(*parsed)
+		if !ok || m.holds(it) {
+			continue
+		}
+		older = append(older, it)
+	}
+	m.history.older = msg.page.Remaining
+	if len(msg.page.Lines) == 0 {
+		m.history.older = 0
+	}
+	if len(older) == 0 {
+		m.statusMessage = "No older entries"
+		return m.clearStatusMessageAfter(2 * time.Second)
+	}
+
+	sort.SliceStable(older, func(i, j int) bool {
+		return older[i].timestamp.Before(older[j].timestamp)
+	})
+	before := len(m.visible)
+	index := m.list.Index()
+	m.items = append(older, m.items...)
+	m.rebuildVisible()
+	m.list.Select(index + len(m.visible) - before)
+	m.statusMessage = fmt.Sprintf("Loaded %d older entries", len(older))
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// heldAtOldest counts the loaded entries sharing the oldest timestamp.
+func (m *Model) heldAtOldest() int {
+	if len(m.items) == 0 {
+		return 0
+	}
+	n := 0
+	for _, it := range m.items {
+		if !it.timestamp.Equal(m.items[0].timestamp) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// holds reports whether an entry from a history page is already loaded.
+// Pages include entries sharing the oldest loaded timestamp, so only those
+// are compared.
+func (m *Model) holds(it logItem) bool {
+	for _, held := range m.items {
+		if !held.timestamp.Equal(it.timestamp) {
+			if held.timestamp.After(it.timestamp) {
+				return false
+			}
+			continue
+		}
+		if held.workspacePath == it.workspacePath && held.component == it.component && held.message == it.message {
+			return true
+		}
+	}
+	return false
+}
+
+// historyIndicator shows the number of unloaded older entries.
+func (m *Model) historyIndicator() string {
+	switch {
+	case m.history.loading:
+		return " [Loading older…]"
+	case m.history.older > 0:
+		return fmt.Sprintf(" [%d older - gg to load]", m.history.older)
+	}
+	return ""
+}
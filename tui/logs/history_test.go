@@ -0,0 +1,160 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/models"
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+)
+
+// historyClient serves GetLogHistory from a fixed page and records the
+// request; every other Client method is unimplemented.
+type historyClient struct {
+	daemon.Client
+	page *models.LogHistoryPage
+	opts models.LogHistoryOptions
+}
+
+func (c *historyClient) GetLogHistory(ctx context.Context, opts models.LogHistoryOptions) (*models.LogHistoryPage, error) {
+	c.opts = opts
+	return c.page, nil
+}
+
+var historyBase = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func historyLine(t *testing.T, sec int, msg string) models.LogStreamLine {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{
+		"time":      historyBase.Add(time.Duration(sec) * time.Second).Format(time.RFC3339Nano),
+		"level":     "info",
+		"component": "api",
+		"msg":       msg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return models.LogStreamLine{Workspace: "api", WorkspacePath: "/src/api", Line: string(data)}
+}
+
+func newHistoryTestModel(client *historyClient) *Model {
+	m := newSplitTestModel()
+	m.ctx = context.Background()
+	m.workspaceColorMap = map[string]lipgloss.Style{}
+	m.sequence = tuikeymap.NewSequenceState()
+	m.keys.GotoTop = key.NewBinding(key.WithKeys("gg"))
+	m.keys.PageUp = key.NewBinding(key.WithKeys("pgup"))
+	m.cfg = Config{DaemonClient: client, Replay: 3}
+	m.history.older = -1
+	m.resizeList()
+	return m
+}
+
+func (m *Model) streamLine(t *testing.T, sec int, msg string) {
+	t.Helper()
+	m.handleNewLog(*parseStreamLine(historyLine(t, sec, msg)))
+}
+
+func TestMemoryCapCountsDroppedEntries(t *testing.T) {
+	m := newHistoryTestModel(&historyClient{})
+	m.cfg.MaxEntries = 100
+	m.history.older = 5
+
+	for i := 0; i < 111; i++ {
+		m.streamLine(t, i, "line")
+	}
+	if len(m.items) != 100 {
+		t.Fatalf("items = %d, want the cap of 100", len(m.items))
+	}
+	if len(m.visible) != 100 {
+		t.Errorf("visible = %d, want the trimmed items listed once", len(m.visible))
+	}
+	if m.history.older != 16 {
+		t.Errorf("older = %d, want 5 + 11 dropped", m.history.older)
+	}
+	if got := m.historyIndicator(); got != " [16 older - gg to load]" {
+		t.Errorf("indicator = %q", got)
+	}
+}
+
+func TestLoadOlderPrependsPage(t *testing.T) {
+	client := &historyClient{}
+	m := newHistoryTestModel(client)
+	m.streamLine(t, 10, "boundary")
+	m.streamLine(t, 11, "newest")
+
+	// gg at the top of the list requests the page before the oldest entry.
+	m.Update(keyMsg("g"))
+	_, cmd := m.Update(keyMsg("g"))
+	if cmd == nil || !m.history.loading {
+		t.Fatal("gg at the top should request older entries")
+	}
+	client.page = &models.LogHistoryPage{
+		Lines: []models.LogStreamLine{
+			historyLine(t, 8, "older-1"),
+			historyLine(t, 9, "older-2"),
+			historyLine(t, 10, "boundary"), // already held
+		},
+		Remaining: 40,
+	}
+	m.Update(cmd())
+
+	if !client.opts.Before.Equal(historyBase.Add(10*time.Second)) || client.opts.Limit != 3 {
+		t.Errorf("request = before %v limit %d", client.opts.Before, client.opts.Limit)
+	}
+	var got []string
+	for _, it := range m.items {
+		got = append(got, it.message)
+	}
+	if len(got) != 4 || got[0] != "older-1" || got[1] != "older-2" || got[2] != "boundary" {
+		t.Fatalf("items = %v", got)
+	}
+	if sel, _ := m.list.SelectedItem().(logItem); sel.message != "boundary" {
+		t.Errorf("selection moved to %q, want it kept on boundary", sel.message)
+	}
+	if m.history.loading || m.history.older != 40 {
+		t.Errorf("history = %+v", m.history)
+	}
+}
+
+func TestLoadOlderRespectsMemoryCap(t *testing.T) {
+	client := &historyClient{page: &models.LogHistoryPage{}}
+	m := newHistoryTestModel(client)
+	m.cfg.MaxEntries = 2
+	m.streamLine(t, 1, "a")
+	m.streamLine(t, 2, "b")
+
+	if cmd := m.loadOlder(); cmd == nil || m.history.loading {
+		t.Fatalf("a full buffer should not request a page (loading=%v)", m.history.loading)
+	}
+}
+
+func TestHistoryProbeSetsOlderCount(t *testing.T) {
+	client := &historyClient{page: &models.LogHistoryPage{Remaining: 12}}
+	m := newHistoryTestModel(client)
+	m.streamLine(t, 5, "a")
+	m.streamLine(t, 5, "b")
+	m.streamLine(t, 6, "c")
+
+	m.Update(historyProbeMsg{gen: m.history.gen})
+	cmd := m.fetchHistory(0, true)
+	m.Update(cmd())
+	if client.opts.Limit != 0 {
+		t.Errorf("probe limit = %d, want 0", client.opts.Limit)
+	}
+	if m.history.older != 10 {
+		t.Errorf("older = %d, want 12 minus the 2 held at the oldest timestamp", m.history.older)
+	}
+
+	// Replies from an earlier connection are ignored.
+	m.Update(historyPageMsg{gen: m.history.gen - 1, probe: true, page: &models.LogHistoryPage{Remaining: 99}})
+	if m.history.older != 10 {
+		t.Errorf("stale probe changed older to %d", m.history.older)
+	}
+}
@@ -18,8 +18,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os/exec"
-	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -34,6 +32,7 @@ import (
 
 	"github.com/grovetools/core/config"
 	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/pkg/clipboard"
 	"github.com/grovetools/core/pkg/daemon"
 	logskeymap "github.com/grovetools/core/pkg/keymap"
 	"github.com/grovetools/core/pkg/models"
@@ -41,6 +40,7 @@ import (
 	"github.com/grovetools/core/tui/components/jsontree"
 	"github.com/grovetools/core/tui/embed"
 	tuikeymap "github.com/grovetools/core/tui/keymap"
+	"github.com/grovetools/core/tui/panel"
 	"github.com/grovetools/core/tui/theme"
 )
 
@@ -58,21 +58,80 @@ type Config struct {
 	OverrideOpts *logging.OverrideOptions
 	// Follow turns on auto-scroll on new entries at construction.
 	Follow bool
+	// FollowMode is how follow mode auto-scrolls (tui.logs.follow_mode):
+	// "pin" keeps the newest entry selected, "pause" stops following while
+	// the cursor is off the newest entry and counts what arrives, and
+	// "resume" also returns to the newest entry after FollowResumeAfter
+	// without input. Empty or unknown values pin. The ToggleFollow key
+	// ("F") cycles the modes and off.
+	FollowMode string
+	// FollowResumeAfter is the "resume" mode's idle delay
+	// (tui.logs.follow_resume_after); zero uses DefaultFollowResumeAfter.
+	FollowResumeAfter time.Duration
 	// InitialWorkspacePath seeds the active-workspace filter before
 	// the host has had a chance to broadcast embed.SetWorkspaceMsg.
 	InitialWorkspacePath string
+	// Workspaces, when set, is the selection the viewer starts with
+	// (workspace paths or names, e.g. `core logs -w a,b`): entries from any
+	// other workspace the stream carries start deselected. The selection
+	// is changed at runtime with the Workspaces key ("w").
+	Workspaces []string
 	// Replay is the number of historical lines the daemon should replay on connect.
 	Replay int
 	// Compact suppresses the detail split pane and focus-switching keys,
 	// rendering only the streaming log list.
 	Compact bool
-	// InitialLevel sets the starting minimum log level (e.g. "debug", "info", "warn", "error").
+	// InitialLevel sets the starting minimum log level (e.g. "trace", "debug", "info", "warn", "error").
 	// Empty string defaults to "info".
 	InitialLevel string
 	// EventsOnly starts the viewer in events-only mode: only entries
 	// carrying a non-empty `event` field or at warn level and above are
 	// shown. Toggleable at runtime with the ToggleEvents key ("E").
 	EventsOnly bool
+	// ContextLines shows this many filtered-out entries, dimmed, around each
+	// match while a client-side filter is active. Cycled at runtime with the
+	// ToggleContext key ("X").
+	ContextLines int
+	// CopyFormat is the Yank key's clipboard format (tui.logs.copy_format):
+	// "jsonl", "json", "jq" or "grep". Empty or unknown values use
+	// DefaultCopyFormat; the CopyAs key picks a format per copy.
+	CopyFormat string
+	// PinnedErrors is how many error/fatal entries the pinned error panel
+	// keeps (tui.logs.pinned_errors); zero uses DefaultPinnedErrors. The
+	// panel is shown in follow mode and toggled with the TogglePinned key
+	// ("!").
+	PinnedErrors int
+	// Verbosity is the highest field verbosity (basic, verbose, debug,
+	// metrics) the detail pane shows; fields tagged above it through
+	// _verbosity are hidden. Empty shows every field.
+	Verbosity string
+	// MaxEntries caps the entries held in memory (tui.logs.max_entries);
+	// zero uses DefaultMaxEntries. Older entries beyond the replay are
+	// loaded on demand, Replay at a time, with gg or pgup at the top.
+	MaxEntries int
+	// Mouse handles tea.MouseMsg (tui.mouse): a click selects the list row
+	// under the pointer, the wheel scrolls the list or the detail pane it
+	// is over, and the JSON view gets clicks on its fold icons. The host
+	// program must enable mouse reporting, and coordinates are taken as
+	// relative to the viewer's top-left corner.
+	Mouse bool
+	// Stream, when set, is the source of log entries in place of
+	// DaemonClient's aggregated stream (e.g. `core logs replay -i`). It is
+	// called again on every reconnect, such as a level change.
+	Stream func(ctx context.Context, opts models.LogStreamOptions) (<-chan models.LogStreamLine, error)
+	// Timestamps is how the timestamp column starts (tui.logs.timestamps):
+	// "absolute" times or "relative" ages such as "2m ago", refreshed on
+	// every tick. Empty or unknown values are absolute. Toggled at runtime
+	// with the ToggleTimestamps key ("t").
+	Timestamps string
+	// RenderHints picks the numbers the JSON view shows as readable times,
+	// durations and sizes (tui.jsontree.render_hints). Nil uses
+	// jsontree.DefaultRenderHints.
+	RenderHints *jsontree.RenderHints
+	// ViewState, when set, restores a view state saved by a previous run
+	// (see SaveViewState) over the settings above: its filters replace
+	// theirs and the cursor returns to the saved entry once it is replayed.
+	ViewState *ViewState
 }
 
 // paneFocus tracks which pane has focus.
@@ -143,9 +202,18 @@ type logItem struct {
 	timestamp     time.Time
 	rawData       map[string]interface{}
 	styleFn       func(string) lipgloss.Style
+	// context marks a row shown only because it surrounds a filter match.
+	context bool
+	// maxVerbosity hides detail fields tagged above it.
+	maxVerbosity logging.Verbosity
 }
 
 func (i logItem) Title() string {
+	return i.titleWithTime(i.timestamp.Format("2006-01-02 15:04:05"))
+}
+
+// titleWithTime renders the list row with ts in the timestamp column.
+func (i logItem) titleWithTime(ts string) string {
 	wsStyle := i.workspaceStyle()
 	levelStyle := themeLevelStyle(i.level)
 	timeStyle := theme.DefaultTheme.Muted
@@ -154,7 +222,7 @@ func (i logItem) Title() string {
 	return fmt.Sprintf("%s %s %s %s %s",
 		wsStyle.Render(fmt.Sprintf("[%s]", i.workspace)),
 		levelStyle.Render(fmt.Sprintf("[%s]", strings.ToUpper(i.level))),
-		timeStyle.Render(i.timestamp.Format("2006-01-02 15:04:05")),
+		timeStyle.Render(ts),
 		componentStyle.Render(fmt.Sprintf("[%s]", i.component)),
 		i.message,
 	)
@@ -209,17 +277,7 @@ func (i logItem) FormatDetails() string {
 		funcInfo = fn
 	}
 
-	var verbosityMap map[string]int
-	if verbosityRaw, exists := i.rawData["_verbosity"]; exists {
-		if verbosityMapInterface, ok := verbosityRaw.(map[string]interface{}); ok {
-			verbosityMap = make(map[string]int)
-			for k, val := range verbosityMapInterface {
-				if intVal, ok := val.(float64); ok {
-					verbosityMap[k] = int(intVal)
-				}
-			}
-		}
-	}
+	verbosityMap := logging.FieldVerbosity(i.rawData)
 
 	fieldStyle := theme.DefaultTheme.Muted
 	fileStyle := theme.DefaultTheme.Muted
@@ -264,14 +322,12 @@ func (i logItem) FormatDetails() string {
 				formattedValue = fmt.Sprintf("%v", v)
 			}
 
-			verbosityLevel := 0
-			if verbosityMap != nil {
-				if level, exists := verbosityMap[k]; exists {
-					verbosityLevel = level
-				}
+			if verbosityMap[k] > i.maxVerbosity {
+				continue
 			}
 
-			if verbosityLevel < 4 {
+			verbosityLevel := int(verbosityMap[k])
+			if verbosityLevel >= 0 && verbosityLevel < 4 {
 				fieldsByLevel[verbosityLevel] = append(fieldsByLevel[verbosityLevel], fmt.Sprintf("%-20s %s", k+":", formattedValue))
 			}
 		}
@@ -325,8 +381,10 @@ func themeLevelStyle(level string) lipgloss.Style {
 		return theme.DefaultTheme.Warning
 	case "error", "fatal", "panic":
 		return theme.DefaultTheme.Error
-	case "debug", "trace":
+	case "debug":
 		return theme.DefaultTheme.Muted
+	case "trace":
+		return theme.DefaultTheme.Muted.Faint(true)
 	default:
 		return lipgloss.NewStyle()
 	}
@@ -336,7 +394,12 @@ type itemDelegate struct {
 	model *Model
 }
 
-func (d itemDelegate) Height() int                               { return 1 }
+func (d itemDelegate) Height() int {
+	if d.model != nil {
+		return d.model.wrap.rowHeight()
+	}
+	return 1
+}
 func (d itemDelegate) Spacing() int                              { return 0 }
 func (d itemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
 
@@ -346,6 +409,19 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		return
 	}
 	str := i.Title()
+	if d.model != nil {
+		str = d.model.rowTitle(i)
+	}
+	if i.isRunStart() {
+		str = i.runSeparator(m.Width() - theme.DefaultTheme.Selected.GetHorizontalFrameSize())
+	}
+	if i.context {
+		str = dimContextRow(str)
+	}
+	if d.model != nil {
+		str = d.model.bookmarkedTitle(i, str)
+		str = d.model.wrap.layout(str, m.Width()-theme.DefaultTheme.Selected.GetHorizontalFrameSize())
+	}
 
 	isVisuallySelected := false
 	if d.model != nil && d.model.visualMode {
@@ -396,6 +472,7 @@ type Model struct {
 	width          int
 	height         int
 	followMode     bool
+	follow         followState
 	filtersEnabled bool
 	eventsOnly     bool
 	filteredCount  int
@@ -419,12 +496,57 @@ type Model struct {
 	pickerItems         []string // sorted component names
 	pickerCursor        int
 
+	// Workspace picker overlay and the workspaces it deselected.
+	workspaces workspaceState
+
+	// Split view comparing two components side-by-side.
+	split splitState
+
+	// Bookmarks and annotations, persisted per workspace.
+	bookmarks bookmarkState
+
+	// Row layout for messages wider than the list pane.
+	wrap wrapState
+
+	// Timestamp column: absolute times or ages relative to now, the time
+	// of the last tick.
+	timestamps timestampMode
+	now        time.Time
+
+	// contextLines is how many filtered-out rows to show around each match.
+	contextLines int
+
+	// copyFormat is the Yank key's format; copyPrompt is true while the
+	// copy-as prompt waits for a format key.
+	copyFormat CopyFormat
+	copyPrompt bool
+
+	// Actions menu opened on the selection with the Actions key.
+	actions actionsState
+
+	// Pinned error panel shown above the list in follow mode.
+	pinned pinnedState
+
+	// Plugin panels shown in place of the detail pane.
+	panels *panel.Stack
+
+	// Correlation filter set with the Correlate key.
+	correlation correlationFilter
+
+	// Older entries not yet loaded from the daemon's history.
+	history historyState
+
+	// pendingCursor is the restored cursor timestamp, cleared once the
+	// entry is selected or the user moves the cursor.
+	pendingCursor time.Time
+
 	// Filter config
 	logConfig     *logging.Config
 	overrideOpts  *logging.OverrideOptions
 	activeScope   LogScope
 	includeSystem bool
-	minLevel      int // 0=debug, 1=info, 2=warn, 3=error
+	minLevel      int // -1=trace, 0=debug, 1=info, 2=warn, 3=error
+	maxVerbosity  logging.Verbosity
 
 	// Stream lifecycle: streamCtx bounds the active SSE connection.
 	// On filter changes we cancel it and reconnect with new params.
@@ -444,10 +566,8 @@ type Model struct {
 func New(ctx context.Context, cfg Config) *Model {
 	ctx, cancel := context.WithCancel(ctx)
 
-	keys := func() logskeymap.LogKeyMap {
-		c, _ := config.LoadDefault()
-		return logskeymap.NewLogKeyMap(c)
-	}()
+	loaded, _ := config.LoadDefault()
+	keys := logskeymap.NewLogKeyMap(loaded)
 
 	logCfg := cfg.LogConfig
 	if logCfg == nil {
@@ -496,25 +616,38 @@ func New(ctx context.Context, cfg Config) *Model {
 		includeSystem:       cfg.IncludeSystem,
 		workspaceColorMap:   make(map[string]lipgloss.Style),
 		minLevel:            parseLevelConfig(cfg.InitialLevel),
+		maxVerbosity:        parseVerbosityConfig(cfg.Verbosity),
 		hiddenComponents:    make(map[string]bool),
 		compact:             cfg.Compact,
 		sequence:            tuikeymap.NewSequenceState(),
-	}
-
-	// Resolve initial scope
-	switch cfg.InitialScope {
-	case "ecosystem":
-		m.activeScope = ScopeEcosystem
-	case "all":
-		m.activeScope = ScopeAll
-	case "system":
-		m.activeScope = ScopeSystem
-	case "daemon":
-		m.activeScope = ScopeDaemon
-	default:
-		m.activeScope = ScopeProject
-	}
-
+		contextLines:        cfg.ContextLines,
+		copyFormat:          ParseCopyFormat(cfg.CopyFormat),
+		pinned:              pinnedState{limit: cfg.PinnedErrors},
+		timestamps:          parseTimestampMode(cfg.Timestamps),
+		follow: followState{
+			behavior:    parseFollowBehavior(cfg.FollowMode),
+			configured:  parseFollowBehavior(cfg.FollowMode),
+			resumeAfter: cfg.FollowResumeAfter,
+		},
+		panels: panel.NewStack(panel.Env{
+			Context:       ctx,
+			Host:          panel.HostLogs,
+			WorkspacePath: cfg.InitialWorkspacePath,
+			DaemonClient:  cfg.DaemonClient,
+			Config:        loaded,
+		}),
+	}
+	if m.pinned.limit <= 0 {
+		m.pinned.limit = DefaultPinnedErrors
+	}
+
+	m.activeScope = parseScope(cfg.InitialScope)
+	m.setInitialWorkspaces(cfg.Workspaces)
+	if cfg.ViewState != nil {
+		m.applyViewState(cfg.ViewState)
+	}
+
+	m.loadBookmarks(cfg.InitialWorkspacePath)
 	m.list.SetDelegate(itemDelegate{model: m})
 	return m
 }
@@ -525,7 +658,7 @@ func (m *Model) Close() error {
 	if m.cancel != nil {
 		m.cancel()
 	}
-	return nil
+	return m.panels.Close()
 }
 
 // Init kicks off the daemon stream connection and arms the spinner
@@ -561,6 +694,8 @@ type (
 // numeric minLevel value. Returns 1 (INFO) for empty or unrecognized input.
 func parseLevelConfig(s string) int {
 	switch strings.ToLower(s) {
+	case "trace":
+		return -1
 	case "debug":
 		return 0
 	case "info":
@@ -574,9 +709,37 @@ func parseLevelConfig(s string) int {
 	}
 }
 
+// parseVerbosityConfig converts Config.Verbosity to the detail pane's
+// ceiling. Empty or unrecognized input shows every field.
+func parseVerbosityConfig(s string) logging.Verbosity {
+	if v, err := logging.ParseVerbosity(s); err == nil {
+		return v
+	}
+	return logging.VerbosityMetrics
+}
+
+// parseScope converts a scope string from Config.InitialScope to a
+// LogScope. Empty or unrecognized input selects the workspace scope.
+func parseScope(s string) LogScope {
+	switch s {
+	case "ecosystem":
+		return ScopeEcosystem
+	case "all":
+		return ScopeAll
+	case "system":
+		return ScopeSystem
+	case "daemon":
+		return ScopeDaemon
+	default:
+		return ScopeProject
+	}
+}
+
 // levelToParam converts the numeric minLevel to the daemon API string.
 func levelToParam(minLevel int) string {
 	switch minLevel {
+	case -1:
+		return "trace"
 	case 0:
 		return "debug"
 	case 1:
@@ -611,12 +774,17 @@ func (m *Model) connectToDaemon() tea.Cmd {
 	}
 
 	client := m.cfg.DaemonClient
-	if client == nil {
+	stream := m.cfg.Stream
+	if stream == nil && client != nil {
+		stream = client.StreamLogs
+	}
+	if stream == nil || (m.activeScope == ScopeDaemon && client == nil) {
 		return func() tea.Msg {
 			return streamErrMsg{err: fmt.Errorf("no daemon client configured")}
 		}
 	}
 
+	probe := m.resetHistory()
 	if m.activeScope == ScopeDaemon {
 		return func() tea.Msg {
 			ch, err := client.StreamState(sCtx)
@@ -627,13 +795,13 @@ func (m *Model) connectToDaemon() tea.Cmd {
 		}
 	}
 
-	return func() tea.Msg {
-		ch, err := client.StreamLogs(sCtx, opts)
+	return tea.Batch(func() tea.Msg {
+		ch, err := stream(sCtx, opts)
 		if err != nil {
 			return streamErrMsg{err: err}
 		}
 		return m.pumpFirstLine(sCtx, ch)
-	}
+	}, probe)
 }
 
 // pumpFirstLine reads the first line from the stream channel. This is
@@ -733,19 +901,35 @@ func levelRank(level string) int {
 	}
 }
 
-var levelLabels = [4]string{"DEBUG", "INFO", "WARN", "ERROR"}
+// levelLabels names the minLevel values, offset by one for trace (-1).
+var levelLabels = [5]string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
+
+// levelLabel returns the label of a minLevel value.
+func levelLabel(minLevel int) string {
+	return levelLabels[minLevel+1]
+}
 
 // rebuildVisible recomputes m.visible from m.items under the current
-// component filter. Level/scope filtering is done server-side by the
-// daemon; only component visibility filtering happens client-side.
+// workspace and component filters. Level/scope filtering is done
+// server-side by the daemon; only workspace and component visibility
+// filtering happens client-side.
 func (m *Model) rebuildVisible() {
 	m.visible = m.visible[:0]
+	if m.contextActive() {
+		for _, it := range m.visibleWithContext() {
+			m.visible = append(m.visible, it)
+		}
+		m.list.SetItems(m.visible)
+		m.rebuildSplit()
+		return
+	}
 	for _, it := range m.items {
-		if m.matchesComponentFilter(it) && m.matchesEventsFilter(it) {
+		if m.matchesWorkspaceFilter(it) && m.matchesComponentFilter(it) && m.matchesEventsFilter(it) && m.matchesCorrelation(it) {
 			m.visible = append(m.visible, it)
 		}
 	}
 	m.list.SetItems(m.visible)
+	m.rebuildSplit()
 }
 
 // matchesComponentFilter returns true when the item passes the client-side
@@ -795,54 +979,8 @@ func absInt(n int) int {
 	return n
 }
 
-func (m *Model) getSelectedContent() string {
-	minIdx := m.visualStart
-	maxIdx := m.visualEnd
-	if minIdx > maxIdx {
-		minIdx, maxIdx = maxIdx, minIdx
-	}
-
-	visibleItems := m.list.VisibleItems()
-
-	var logs []map[string]interface{}
-	for i := minIdx; i <= maxIdx && i < len(visibleItems); i++ {
-		if item, ok := visibleItems[i].(logItem); ok {
-			logEntry := make(map[string]interface{})
-			for k, v := range item.rawData {
-				logEntry[k] = v
-			}
-			logEntry["workspace"] = item.workspace
-			logs = append(logs, logEntry)
-		}
-	}
-
-	jsonBytes, err := json.MarshalIndent(logs, "", "  ")
-	if err != nil {
-		return fmt.Sprintf("Error formatting JSON: %v", err)
-	}
-	return string(jsonBytes)
-}
-
 func (m *Model) copyToClipboard(content string) error {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("pbcopy")
-	case "linux":
-		if _, err := exec.LookPath("xclip"); err == nil {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
-		} else if _, err := exec.LookPath("xsel"); err == nil {
-			cmd = exec.Command("xsel", "--clipboard", "--input")
-		} else {
-			return fmt.Errorf("no clipboard utility found (install xclip or xsel)")
-		}
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "clip")
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-	cmd.Stdin = strings.NewReader(content)
-	return cmd.Run()
+	return clipboard.Write(content)
 }
 
 func (m *Model) openComponentPicker() {
@@ -901,6 +1039,20 @@ func doneCmd() tea.Cmd {
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cursor := m.followCursor()
+	model, cmd := m.update(msg)
+	m.noteInput(msg, cursor)
+	// Plugin panels see every message but keys, which reach the panel
+	// with focus through updatePanelKey.
+	if _, ok := msg.(tea.KeyMsg); !ok {
+		if panelCmd := m.panels.Update(msg); panelCmd != nil {
+			cmd = tea.Batch(cmd, panelCmd)
+		}
+	}
+	return model, cmd
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	// Embed contract messages
@@ -915,6 +1067,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.activeWorkspacePath = newPath
+		m.loadBookmarks(newPath)
 		m.items = nil
 		m.visible = m.visible[:0]
 		m.list.SetItems(m.visible)
@@ -1004,6 +1157,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The workspace picker takes over key input while open.
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.workspaces.picking {
+		return m.updateWorkspacePicker(kmsg)
+	}
+
+	// The copy-as prompt consumes the next key.
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.copyPrompt {
+		return m.updateCopyPrompt(kmsg)
+	}
+
+	// The actions menu consumes the next key; its prompts take over key
+	// input while open.
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.actions.menu {
+		return m.updateActionsMenu(kmsg)
+	}
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.actions.prompt != "" {
+		return m.updateActionPrompt(kmsg)
+	}
+
+	// The annotation prompt takes over key input while open.
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.bookmarks.annotating {
+		return m.updateAnnotationPrompt(kmsg)
+	}
+
+	// Split view chooser and active split view take over key input.
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.split.picking {
+		return m.updateSplitPicker(kmsg)
+	}
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.split.active && !m.jsonView && m.focus == listPane &&
+		!key.Matches(kmsg, m.keys.SwitchFocus) {
+		return m.updateSplit(kmsg)
+	}
+
+	// A focused plugin panel takes over key input.
+	if kmsg, ok := msg.(tea.KeyMsg); ok && m.panelShown() && m.focus == viewportPane {
+		return m.updatePanelKey(kmsg)
+	}
+
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		m.pendingCursor = time.Time{}
+	}
+
+	if mmsg, ok := msg.(tea.MouseMsg); ok {
+		return m, m.handleMouse(mmsg)
+	}
+
 	// If in JSON view, delegate updates to the JSON tree component
 	if m.jsonView && !m.compact {
 		switch msg := msg.(type) {
@@ -1058,6 +1258,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch seqResult {
 			case tuikeymap.SequenceMatch:
 				m.sequence.Clear()
+				if m.focus == listPane && m.list.Index() == 0 {
+					return m, m.loadOlder()
+				}
 				m.list.Select(0)
 				return m, nil
 			case tuikeymap.SequencePending:
@@ -1086,6 +1289,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					listHeight := m.height / 2
 					m.viewport.Height = m.height - listHeight - 3
 				}
+				m.resizePanels()
 				return m, nil
 			}
 
@@ -1139,7 +1343,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								}
 							}
 							if jsonData != nil {
-								m.jsonTree = jsontree.New(jsonData)
+								m.jsonTree = m.newJSONTree(jsonData)
 								m.jsonTree.SetSize(m.width-4, m.height-3)
 								m.jsonView = true
 							} else {
@@ -1169,34 +1373,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case key.Matches(msg, m.keys.Yank):
-				if m.visualMode {
-					content := m.getSelectedContent()
-					if err := m.copyToClipboard(content); err == nil {
-						lineCount := absInt(m.visualEnd-m.visualStart) + 1
-						m.statusMessage = fmt.Sprintf("Copied %d log entries as JSON", lineCount)
-					} else {
-						m.statusMessage = fmt.Sprintf("Copy failed: %v", err)
-					}
-					m.visualMode = false
-					m.list.SetDelegate(itemDelegate{model: m})
-					return m, m.clearStatusMessageAfter(2 * time.Second)
-				}
-				// Single item yank: copy selected item's JSON
-				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
-					if li, ok := selectedItem.(logItem); ok {
-						jsonBytes, err := json.MarshalIndent(li.rawData, "", "  ")
-						if err == nil {
-							if clipErr := m.copyToClipboard(string(jsonBytes)); clipErr == nil {
-								m.statusMessage = "Copied log entry JSON"
-							} else {
-								m.statusMessage = fmt.Sprintf("Copy failed: %v", clipErr)
-							}
-						}
-						return m, m.clearStatusMessageAfter(2 * time.Second)
-					}
-				}
+				return m, m.copySelection(m.copyFormat)
+
+			case key.Matches(msg, m.keys.CopyAs):
+				m.copyPrompt = true
 				return m, nil
 
+			case key.Matches(msg, m.keys.Actions):
+				return m, m.openActionsMenu()
+
 			case key.Matches(msg, m.keys.CopyRawText):
 				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
 					if li, ok := selectedItem.(logItem); ok {
@@ -1218,6 +1403,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.items = nil
 				m.visible = m.visible[:0]
 				m.list.SetItems(nil)
+				m.pinned.reset()
+				m.history.older = -1
 				m.statusMessage = "Buffer cleared"
 				return m, m.clearStatusMessageAfter(2 * time.Second)
 
@@ -1252,6 +1439,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.list.Select(len(m.visible) - 1)
 				return m, nil
 
+			case key.Matches(msg, m.keys.PageUp) && m.list.Index() == 0 && !m.split.active:
+				return m, m.loadOlder()
+
 			case key.Matches(msg, m.keys.HalfUp):
 				visibleHeight := m.height - 4
 				halfPage := visibleHeight / 2
@@ -1278,14 +1468,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Fall through to list.Update so it can start filtering.
 
 			case key.Matches(msg, m.keys.ToggleFollow):
-				m.followMode = !m.followMode
-				if m.followMode {
-					m.statusMessage = "Follow mode enabled"
-				} else {
-					m.statusMessage = "Follow mode disabled"
-				}
+				cmd := m.cycleFollow()
+				m.resizeList()
+				return m, cmd
+
+			case key.Matches(msg, m.keys.TogglePinned):
+				m.togglePinned()
 				return m, m.clearStatusMessageAfter(2 * time.Second)
 
+			case key.Matches(msg, m.keys.NextPanel):
+				return m, m.cyclePanel()
+
 			case key.Matches(msg, m.keys.ToggleFilters):
 				m.filtersEnabled = !m.filtersEnabled
 				if m.filtersEnabled {
@@ -1338,8 +1531,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Batch(m.connectToDaemon(), m.clearStatusMessageAfter(2*time.Second))
 
 			case key.Matches(msg, m.keys.CycleLevel):
-				m.minLevel = (m.minLevel + 1) % 4
-				m.statusMessage = fmt.Sprintf("Level filter: %s+", levelLabels[m.minLevel])
+				// Cycle trace (-1) through error (3).
+				m.minLevel = (m.minLevel+2)%len(levelLabels) - 1
+				m.statusMessage = fmt.Sprintf("Level filter: %s+", levelLabel(m.minLevel))
 				m.items = nil
 				m.visible = m.visible[:0]
 				m.list.SetItems(m.visible)
@@ -1349,6 +1543,47 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.openComponentPicker()
 				return m, nil
 
+			case key.Matches(msg, m.keys.Workspaces):
+				m.openWorkspacePicker()
+				return m, nil
+
+			case key.Matches(msg, m.keys.ToggleSplit):
+				m.visualMode = false
+				m.openSplitPicker()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Bookmark):
+				return m, m.toggleBookmark()
+
+			case key.Matches(msg, m.keys.Annotate):
+				return m, m.annotateSelected()
+
+			case key.Matches(msg, m.keys.NextBookmark):
+				return m, m.jumpToNextBookmark()
+
+			case key.Matches(msg, m.keys.ToggleWrap):
+				return m, m.cycleWrapMode()
+
+			case key.Matches(msg, m.keys.ToggleContext):
+				return m, m.cycleContext()
+
+			case key.Matches(msg, m.keys.ToggleTimestamps):
+				return m, m.toggleTimestamps()
+
+			case key.Matches(msg, m.keys.Correlate):
+				return m, m.correlateSelected()
+
+			case key.Matches(msg, m.keys.ClearCorrelation):
+				return m, m.clearCorrelation()
+
+			case m.wrap.mode == wrapScroll && key.Matches(msg, m.keys.Base.Left):
+				m.panRows(-hscrollStep)
+				return m, nil
+
+			case m.wrap.mode == wrapScroll && key.Matches(msg, m.keys.Base.Right):
+				m.panRows(hscrollStep)
+				return m, nil
+
 			case key.Matches(msg, m.keys.ViewJSON) && !m.compact:
 				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
 					if li, ok := selectedItem.(logItem); ok {
@@ -1363,11 +1598,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							}
 						}
 						if jsonData != nil {
-							m.jsonTree = jsontree.New(jsonData)
+							m.jsonTree = m.newJSONTree(jsonData)
 							listHeight := m.height / 2
 							viewportHeight := m.height - listHeight - 3
 							m.jsonTree.SetSize(m.width-4, viewportHeight)
 							m.jsonView = true
+							m.panels.Hide()
 						} else {
 							m.statusMessage = "No JSON data in this log entry"
 							return m, m.clearStatusMessageAfter(2 * time.Second)
@@ -1384,8 +1620,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.help.SetSize(msg.Width, msg.Height)
 
+		m.resizeList()
+		m.resizePanels()
+
 		if m.compact || m.height < 15 {
-			m.list.SetSize(msg.Width, m.height-1)
 			viewportWidth := msg.Width - 12
 			if viewportWidth < 1 {
 				viewportWidth = 1
@@ -1403,8 +1641,6 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		listHeight := m.height / 2
 		viewportHeight := m.height - listHeight - 3
 
-		m.list.SetSize(msg.Width, listHeight)
-
 		viewportWidth := msg.Width - 12
 		if !m.ready {
 			m.viewport = viewport.New(viewportWidth, viewportHeight)
@@ -1439,17 +1675,31 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case pumpStateMsg:
 		return m, pumpStateStream(msg.ctx, msg.ch)
 
+	case historyProbeMsg:
+		if msg.gen != m.history.gen || m.activeScope == ScopeDaemon {
+			return m, nil
+		}
+		return m, m.fetchHistory(0, true)
+
+	case historyPageMsg:
+		return m, m.handleHistoryPage(msg)
+
 	case streamErrMsg:
 		m.statusMessage = fmt.Sprintf("Stream error: %v", msg.err)
 		return m, m.clearStatusMessageAfter(5 * time.Second)
 
 	case tickMsg:
+		m.now = time.Time(msg)
+		m.resumeFollowIfIdle(m.now)
 		return m, tick()
 
 	case clearStatusMsg:
 		m.statusMessage = ""
 		return m, nil
 
+	case pipeResultMsg:
+		return m, m.handlePipeResult(msg)
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -1482,9 +1732,6 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // handleNewLog processes a single newLogMsg and returns any follow-up commands.
 func (m *Model) handleNewLog(msg newLogMsg) tea.Cmd {
 	level, _ := msg.data["level"].(string)
-	message, _ := msg.data["msg"].(string)
-	component, _ := msg.data["component"].(string)
-	timeStr, _ := msg.data["time"].(string)
 
 	// Count warn- and error-level arrivals regardless of filters/visibility;
 	// the counter is cleared when the panel regains focus. Warn is included
@@ -1494,30 +1741,12 @@ func (m *Model) handleNewLog(msg newLogMsg) tea.Cmd {
 		m.unseenAlerts++
 	}
 
-	// Component visibility filter (client-side only)
-	if m.filtersEnabled && m.logConfig != nil {
-		visibilityResult := logging.GetComponentVisibility(component, m.logConfig, m.overrideOpts)
-		if !visibilityResult.Visible {
-			m.filteredCount++
-			return nil
-		}
-	}
-
-	var logTime time.Time
-	if parsedTime, err := time.Parse(time.RFC3339, timeStr); err == nil {
-		logTime = parsedTime
-	}
-
-	newItem := logItem{
-		workspace:     msg.workspace,
-		workspacePath: msg.workspacePath,
-		level:         level,
-		message:       message,
-		component:     component,
-		timestamp:     logTime,
-		rawData:       msg.data,
-		styleFn:       m.workspaceStyleFor,
+	newItem, ok := m.newItem(msg)
+	if !ok {
+		return nil
 	}
+	m.pinned.record(newItem)
+	visibleRows, splitRows := len(m.visible), len(m.split.rows)
 
 	// Append to master slice in timestamp order.
 	i := sort.Search(len(m.items), func(j int) bool {
@@ -1531,35 +1760,103 @@ func (m *Model) handleNewLog(msg newLogMsg) tea.Cmd {
 		m.items[i] = newItem
 	}
 
-	// Enforce 10,000 cap.
-	if len(m.items) > 11000 {
-		m.items = m.items[len(m.items)-10000:]
-		m.rebuildVisible()
-	}
+	// A trim rebuilds the visible and split rows, new entry included, so
+	// the incremental appends below only run when nothing was trimmed.
+	atEnd := i == len(m.items)-1
+	trimmed := m.enforceMaxEntries()
+	splitMatch := m.matchesWorkspaceFilter(newItem) && m.matchesEventsFilter(newItem) && m.matchesCorrelation(newItem)
+	visibleMatch := splitMatch && m.matchesComponentFilter(newItem)
 
-	// Append to visible (daemon already filtered by scope/level).
-	if i == len(m.items)-1 {
-		if m.matchesEventsFilter(newItem) {
+	// Append to visible (daemon already filtered by scope/level). Context
+	// rows depend on neighbouring entries, so they always rebuild.
+	switch {
+	case trimmed:
+	case atEnd && !m.contextActive():
+		if visibleMatch {
 			m.visible = append(m.visible, newItem)
 			m.list.SetItems(m.visible)
 		}
-	} else {
+	default:
 		m.rebuildVisible()
 	}
 
-	if m.followMode && len(m.visible) > 0 {
-		m.list.Select(len(m.visible) - 1)
-		if selectedItem := m.list.SelectedItem(); selectedItem != nil {
-			if li, ok := selectedItem.(logItem); ok {
-				m.viewport.SetContent(li.FormatDetails())
-				m.viewport.GotoTop()
-			}
+	// Out-of-order arrivals already rebuilt the split rows via
+	// rebuildVisible; in-order ones are appended here.
+	if m.split.active && (newItem.component == m.split.left || newItem.component == m.split.right) {
+		if atEnd && !trimmed && splitMatch {
+			m.split.rows = append(m.split.rows, newItem)
 		}
+		m.followNewEntry(len(m.split.rows) > splitRows || trimmed && splitMatch)
+		return nil
 	}
 
+	m.restoreCursor()
+	m.followNewEntry(len(m.visible) > visibleRows || trimmed && visibleMatch)
+
 	return nil
 }
 
+// newItem builds the list item for a log line, or reports false when the
+// client-side component visibility filter hides it.
+func (m *Model) newItem(msg newLogMsg) (logItem, bool) {
+	level, _ := msg.data["level"].(string)
+	message, _ := msg.data["msg"].(string)
+	component, _ := msg.data["component"].(string)
+
+	// Component visibility filter (client-side only)
+	if m.filtersEnabled && m.logConfig != nil {
+		visibilityResult := logging.GetComponentVisibility(component, m.logConfig, m.overrideOpts)
+		if !visibilityResult.Visible {
+			m.filteredCount++
+			return logItem{}, false
+		}
+	}
+
+	// Entries may be stored in any zone (logging.timezone); display them in
+	// local time.
+	var logTime time.Time
+	timeFormat := ""
+	if m.logConfig != nil {
+		timeFormat = m.logConfig.TimeFormat
+	}
+	if parsedTime, ok := logging.ParseTime(msg.data["time"], timeFormat); ok {
+		logTime = parsedTime.Local()
+	}
+
+	it := logItem{
+		workspace:     msg.workspace,
+		workspacePath: msg.workspacePath,
+		level:         level,
+		message:       message,
+		component:     component,
+		timestamp:     logTime,
+		rawData:       msg.data,
+		styleFn:       m.workspaceStyleFor,
+		maxVerbosity:  m.maxVerbosity,
+	}
+	m.noteWorkspace(it)
+	return it, true
+}
+
+// listPaneHeight is the height of the list (or split panes), leaving room
+// for the detail pane and status line and for the pinned error panel.
+func (m *Model) listPaneHeight() int {
+	h := m.height / 2
+	if m.compact || m.height < 15 {
+		h = m.height - 1
+	}
+	h -= m.pinnedHeight()
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// resizeList applies listPaneHeight, e.g. after the pinned panel appears.
+func (m *Model) resizeList() {
+	m.list.SetSize(m.width, m.listPaneHeight())
+}
+
 // UnseenAlerts returns the number of warn- and error-level records that
 // arrived since the panel was last focused; the count is cleared on
 // embed.FocusMsg.
@@ -1576,16 +1873,21 @@ func (m *Model) View() string {
 		return m.componentPickerView()
 	}
 
+	if m.split.picking {
+		return m.splitPickerView()
+	}
+
+	if m.workspaces.picking {
+		return m.workspacePickerView()
+	}
+
 	if !m.ready {
 		return "Initializing..."
 	}
 
 	statusStyle := theme.DefaultTheme.Muted
 
-	followIndicator := " [Follow:OFF]"
-	if m.followMode {
-		followIndicator = " [Follow:ON]"
-	}
+	followIndicator := m.followIndicator()
 
 	filtersIndicator := " [Filters:OFF]"
 	if m.filtersEnabled {
@@ -1637,16 +1939,32 @@ func (m *Model) View() string {
 		systemIndicator = " [+System]"
 	}
 
-	levelIndicator := fmt.Sprintf(" [Level: %s+]", levelLabels[m.minLevel])
+	levelIndicator := fmt.Sprintf(" [Level: %s+]", levelLabel(m.minLevel))
 
 	eventsIndicator := ""
 	if m.eventsOnly {
 		eventsIndicator = " [Events]"
 	}
 
+	marksIndicator := ""
+	if n := len(m.bookmarks.marks); n > 0 {
+		marksIndicator = fmt.Sprintf(" [Marks: %d]", n)
+	}
+
+	if m.split.active {
+		position = fmt.Sprintf("%d/%d", m.split.cursor+1, len(m.split.rows))
+		if len(m.split.rows) == 0 {
+			position = "0/0"
+		}
+	}
+
 	modeIndicator := ""
-	if m.jsonView {
+	if m.split.active && m.focus == listPane && !m.jsonView {
+		modeIndicator = fmt.Sprintf(" [SPLIT: %s | %s - esc to exit]", m.split.left, m.split.right)
+	} else if m.jsonView {
 		modeIndicator = " [JSON VIEW - esc to exit]"
+	} else if m.focus == viewportPane && m.panelShown() {
+		modeIndicator = " [PANEL - tab to return]"
 	} else if m.focus == viewportPane {
 		modeIndicator = " [SCROLLING - tab to return]"
 	} else if m.visualMode {
@@ -1655,8 +1973,20 @@ func (m *Model) View() string {
 		modeIndicator = fmt.Sprintf(" [%s]", m.statusMessage)
 	}
 
-	status := statusStyle.Render(fmt.Sprintf(" Logs: %s%s%s%s%s%s%s%s%s%s | ? for help | q to quit",
-		position, scopeIndicator, systemIndicator, levelIndicator, eventsIndicator, followIndicator, filtersIndicator, filteredCountIndicator, filterIndicator, modeIndicator))
+	status := statusStyle.Render(fmt.Sprintf(" Logs: %s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s%s | ? for help | q to quit",
+		position, m.historyIndicator(), scopeIndicator, systemIndicator, levelIndicator, eventsIndicator, marksIndicator, followIndicator, filtersIndicator, filteredCountIndicator, m.workspaceIndicator(), filterIndicator, m.correlationIndicator(), m.contextIndicator(), m.wrapIndicator(), m.timestampIndicator(), m.panelIndicator(), modeIndicator))
+	if m.bookmarks.annotating {
+		status = " Note: " + m.bookmarks.input.View()
+	}
+	if m.copyPrompt {
+		status = statusStyle.Render(copyPromptView())
+	}
+	if m.actions.menu {
+		status = statusStyle.Render(m.actionsMenuView())
+	}
+	if m.actions.prompt != "" {
+		status = m.actionPromptView()
+	}
 
 	if m.compact || m.height < 15 {
 		var listView string
@@ -1668,6 +1998,12 @@ func (m *Model) View() string {
 			}()
 			listView = m.list.View()
 		}()
+		if m.split.active {
+			listView = m.splitListView()
+		}
+		if m.pinnedVisible() {
+			return lipgloss.JoinVertical(lipgloss.Left, m.pinnedView(), listView, status)
+		}
 		return lipgloss.JoinVertical(lipgloss.Left, listView, status)
 	}
 
@@ -1679,6 +2015,8 @@ func (m *Model) View() string {
 		var detailsContent string
 		if m.jsonView {
 			detailsContent = m.jsonTree.View()
+		} else if m.panelShown() {
+			detailsContent = m.panelView()
 		} else {
 			detailsContent = m.viewport.View()
 		}
@@ -1701,6 +2039,12 @@ func (m *Model) View() string {
 		}()
 		listView = m.list.View()
 	}()
+	if m.split.active {
+		listView = m.splitListView()
+	}
+	if m.pinnedVisible() {
+		listView = lipgloss.JoinVertical(lipgloss.Left, m.pinnedView(), listView)
+	}
 
 	detailsStyle := theme.DefaultTheme.DetailsBox.
 		Padding(0, 2).
@@ -1710,6 +2054,8 @@ func (m *Model) View() string {
 	var detailsContent string
 	if m.jsonView {
 		detailsContent = m.jsonTree.View()
+	} else if m.panelShown() {
+		detailsContent = m.panelView()
 	} else {
 		detailsContent = m.viewport.View()
 	}
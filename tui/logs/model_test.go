@@ -1,6 +1,7 @@
 package logs
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -99,3 +100,43 @@ func TestUnseenAlertsCountsWarnAndError(t *testing.T) {
 		t.Fatalf("UnseenAlerts after clear = %d, want 0", got)
 	}
 }
+
+func TestFormatDetailsVerbosityCeiling(t *testing.T) {
+	raw := map[string]interface{}{
+		"plan":       "p1",
+		"elapsed_ms": float64(12),
+		"untagged":   "x",
+		"_verbosity": map[string]interface{}{"plan": float64(0), "elapsed_ms": float64(3)},
+	}
+
+	all := logItem{rawData: raw, maxVerbosity: parseVerbosityConfig("")}
+	if out := all.FormatDetails(); !strings.Contains(out, "elapsed_ms:") || !strings.Contains(out, "plan:") {
+		t.Errorf("empty Verbosity should show every field:\n%s", out)
+	}
+
+	basic := logItem{rawData: raw, maxVerbosity: parseVerbosityConfig("basic")}
+	out := basic.FormatDetails()
+	if strings.Contains(out, "elapsed_ms:") {
+		t.Errorf("metrics field shown at basic:\n%s", out)
+	}
+	if !strings.Contains(out, "plan:") || !strings.Contains(out, "untagged:") {
+		t.Errorf("basic and untagged fields should be shown:\n%s", out)
+	}
+	if strings.Contains(out, "_verbosity") {
+		t.Errorf("metadata field shown:\n%s", out)
+	}
+}
+
+func TestTraceLevelRoundTrip(t *testing.T) {
+	if got := parseLevelConfig("TRACE"); got != -1 {
+		t.Fatalf("parseLevelConfig(TRACE) = %d, want -1", got)
+	}
+	for minLevel := -1; minLevel <= 3; minLevel++ {
+		if got := parseLevelConfig(levelToParam(minLevel)); got != minLevel {
+			t.Errorf("level %d (%s) round-tripped to %d", minLevel, levelLabel(minLevel), got)
+		}
+	}
+	if levelLabel(-1) != "TRACE" || levelLabel(3) != "ERROR" {
+		t.Errorf("unexpected labels %q, %q", levelLabel(-1), levelLabel(3))
+	}
+}
@@ -0,0 +1,132 @@
+package logs
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/tui/components/jsontree"
+)
+
+// mouseWheelStep is how many rows (list) or lines (detail pane) one wheel
+// notch scrolls.
+const mouseWheelStep = 3
+
+// newJSONTree builds the JSON view for data, with mouse handling when the
+// viewer has it enabled and the configured render hints.
+func (m *Model) newJSONTree(data interface{}) jsontree.Model {
+	var opts []jsontree.Option
+	if m.cfg.Mouse {
+		opts = append(opts, jsontree.WithMouse())
+	}
+	if m.cfg.RenderHints != nil {
+		opts = append(opts, jsontree.WithRenderHints(*m.cfg.RenderHints))
+	}
+	return jsontree.New(data, opts...)
+}
+
+// handleMouse routes a mouse event by the region of the layout (see View)
+// it lands in: the list rows, or the content of the details box. Events
+// are ignored while a prompt, menu or picker is open.
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if !m.cfg.Mouse || !m.ready || m.actions.menu || m.actions.prompt != "" ||
+		m.bookmarks.annotating || m.copyPrompt || m.split.picking || m.showComponentPicker || m.workspaces.picking {
+		return nil
+	}
+
+	splitLayout := !m.compact && m.height >= 15
+	if m.focus == listPane || !splitLayout {
+		top := m.pinnedHeight()
+		if msg.Y >= top && msg.Y < top+m.listPaneHeight() {
+			// The JSON view holds the selection, as it does for keys.
+			if !m.jsonView {
+				m.mouseList(msg, msg.Y-top)
+			}
+			return nil
+		}
+		if !splitLayout {
+			return nil
+		}
+	}
+
+	// The details box: a border row above the content, and the border,
+	// padding and (beside the list) margin to its left.
+	top, left := 1, 3
+	if m.focus == listPane {
+		top, left = m.pinnedHeight()+m.listPaneHeight()+1, 4
+	}
+	msg.X -= left
+	msg.Y -= top
+
+	if m.jsonView {
+		newTree, cmd := m.jsonTree.Update(msg)
+		m.jsonTree = newTree.(jsontree.Model)
+		return cmd
+	}
+	if msg.Action != tea.MouseActionPress {
+		return nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.viewport.ScrollUp(mouseWheelStep)
+	case tea.MouseButtonWheelDown:
+		m.viewport.ScrollDown(mouseWheelStep)
+	}
+	return nil
+}
+
+// mouseList applies a mouse event at row y of the list pane: the wheel
+// moves the selection and a left click selects the row under the pointer.
+func (m *Model) mouseList(msg tea.MouseMsg, y int) {
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+
+	if m.split.active {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.splitSelect(m.split.cursor - mouseWheelStep)
+		case tea.MouseButtonWheelDown:
+			m.splitSelect(m.split.cursor + mouseWheelStep)
+		case tea.MouseButtonLeft:
+			// Row 0 holds the column headers.
+			if idx := m.split.offset + y - 1; y > 0 && idx < len(m.split.rows) {
+				m.splitSelect(idx)
+			}
+		}
+		return
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.selectListIndex(m.list.Index() - mouseWheelStep)
+	case tea.MouseButtonWheelDown:
+		m.selectListIndex(m.list.Index() + mouseWheelStep)
+	case tea.MouseButtonLeft:
+		start, end := m.list.Paginator.GetSliceBounds(len(m.list.VisibleItems()))
+		rowHeight := itemDelegate{model: m}.Height()
+		if idx := start + y/rowHeight; idx < end {
+			m.selectListIndex(idx)
+		}
+	}
+}
+
+// selectListIndex selects a row of the list, clamped to the visible items,
+// and shows it in the detail pane.
+func (m *Model) selectListIndex(idx int) {
+	n := len(m.list.VisibleItems())
+	if n == 0 {
+		return
+	}
+	idx = min(max(idx, 0), n-1)
+	if idx == m.list.Index() {
+		return
+	}
+	m.list.Select(idx)
+	if m.visualMode {
+		m.visualEnd = idx
+		m.list.SetDelegate(itemDelegate{model: m})
+	}
+	if li, ok := m.list.SelectedItem().(logItem); ok {
+		m.viewport.SetContent(li.FormatDetails())
+		m.viewport.GotoTop()
+	}
+}
@@ -0,0 +1,81 @@
+package logs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newMouseTestModel(n int) *Model {
+	m := newSplitTestModel()
+	m.cfg.Mouse = true
+	m.ready = true
+	m.viewport = viewport.New(100, 10)
+	for i := 0; i < n; i++ {
+		m.visible = append(m.visible, logItem{component: "api", message: fmt.Sprintf("line %d", i)})
+	}
+	m.list.SetItems(m.visible)
+	m.resizeList()
+	return m
+}
+
+func TestMouseClickSelectsListRow(t *testing.T) {
+	m := newMouseTestModel(5)
+	m.Update(tea.MouseMsg{X: 10, Y: 3, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if got := m.list.Index(); got != 3 {
+		t.Fatalf("expected row 3 selected, got %d", got)
+	}
+	if m.viewport.View() == "" {
+		t.Error("expected the detail pane to show the clicked entry")
+	}
+
+	// Clicks below the last entry and in the detail pane leave the
+	// selection alone.
+	m.Update(tea.MouseMsg{X: 10, Y: 8, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	m.Update(tea.MouseMsg{X: 10, Y: 30, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if got := m.list.Index(); got != 3 {
+		t.Errorf("expected selection to stay on row 3, got %d", got)
+	}
+}
+
+func TestMouseWheelMovesListSelection(t *testing.T) {
+	m := newMouseTestModel(10)
+	m.Update(tea.MouseMsg{Y: 1, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if got := m.list.Index(); got != mouseWheelStep {
+		t.Fatalf("expected wheel down to select row %d, got %d", mouseWheelStep, got)
+	}
+	m.Update(tea.MouseMsg{Y: 1, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+	m.Update(tea.MouseMsg{Y: 1, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+	if got := m.list.Index(); got != 0 {
+		t.Errorf("expected wheel up to stop at row 0, got %d", got)
+	}
+}
+
+func TestMouseDisabledIgnoresEvents(t *testing.T) {
+	m := newMouseTestModel(5)
+	m.cfg.Mouse = false
+	m.Update(tea.MouseMsg{X: 10, Y: 3, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if got := m.list.Index(); got != 0 {
+		t.Errorf("expected no selection change with mouse disabled, got %d", got)
+	}
+}
+
+func TestMouseClickSelectsSplitRow(t *testing.T) {
+	m := newMouseTestModel(0)
+	m.items = []logItem{
+		{component: "api", message: "a"},
+		{component: "db", message: "b"},
+		{component: "api", message: "c"},
+	}
+	m.startSplit("api", "db")
+	m.list = list.New(nil, itemDelegate{}, 0, 0)
+
+	// Row 0 is the column header; row 1 the first entry.
+	m.Update(tea.MouseMsg{X: 5, Y: 1, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.split.cursor != 0 {
+		t.Errorf("expected split cursor 0, got %d", m.split.cursor)
+	}
+}
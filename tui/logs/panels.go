@@ -0,0 +1,102 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/grovetools/core/tui/panel"
+	"github.com/grovetools/core/tui/theme"
+)
+
+// Plugin panels (see tui/panel) registered for panel.HostLogs are shown
+// one at a time in place of the detail pane, cycled with the NextPanel key
+// ("P"); after the last panel the entry details return. While the detail
+// pane has focus (tab), keys other than quit, help, NextPanel and tab go to
+// the panel.
+
+// panelShown reports whether a plugin panel replaces the detail pane.
+func (m *Model) panelShown() bool {
+	_, ok := m.panels.Active()
+	return ok && !m.compact
+}
+
+// cyclePanel shows the next registered panel, or the entry details after
+// the last one.
+func (m *Model) cyclePanel() tea.Cmd {
+	if m.compact {
+		return nil
+	}
+	if m.panels.Len() == 0 {
+		m.statusMessage = "No plugin panels registered"
+		return m.clearStatusMessageAfter(2 * time.Second)
+	}
+	m.resizePanels()
+	return m.panels.Next()
+}
+
+// panelSize is the space inside the detail pane, less the title row.
+func (m *Model) panelSize() (width, height int) {
+	height = m.height - m.height/2 - 3
+	if m.focus == viewportPane {
+		height = m.height - 3
+	}
+	return max(m.width-7, 1), max(height-1, 1)
+}
+
+// resizePanels passes panelSize to the panels, e.g. after a focus switch.
+func (m *Model) resizePanels() {
+	m.panels.SetSize(m.panelSize())
+}
+
+// updatePanelKey handles a key while a panel has focus.
+func (m *Model) updatePanelKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Base.Quit):
+		return m, doneCmd()
+	case key.Matches(msg, m.keys.Base.Help):
+		m.help.Toggle()
+		return m, nil
+	case key.Matches(msg, m.keys.NextPanel):
+		return m, m.cyclePanel()
+	case key.Matches(msg, m.keys.SwitchFocus):
+		m.focus = listPane
+		m.viewport.Height = m.height - m.height/2 - 3
+		m.resizePanels()
+		return m, nil
+	}
+	return m, m.panels.Update(msg)
+}
+
+// panelView renders the active panel under its title, clipped to
+// panelSize so a panel that overflows cannot break the layout.
+func (m *Model) panelView() string {
+	meta, _ := m.panels.Active()
+	width, height := m.panelSize()
+	t := theme.DefaultTheme
+	header := t.Highlight.Bold(true).Render(panelTitle(meta)) +
+		t.Muted.Render(fmt.Sprintf(" - %s for the next panel", m.keys.NextPanel.Help().Key))
+	body := lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(m.panels.View())
+	return lipgloss.JoinVertical(lipgloss.Left, ansi.Truncate(header, width, "…"), body)
+}
+
+// panelIndicator is the status line's note of the panel shown.
+func (m *Model) panelIndicator() string {
+	meta, ok := m.panels.Active()
+	if !ok || m.compact {
+		return ""
+	}
+	return fmt.Sprintf(" [Panel: %s]", panelTitle(meta))
+}
+
+// panelTitle is the panel's title, or its ID when it has none.
+func panelTitle(meta panel.Meta) string {
+	if meta.Title != "" {
+		return meta.Title
+	}
+	return meta.ID
+}
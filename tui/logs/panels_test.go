@@ -0,0 +1,78 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+	"github.com/grovetools/core/tui/panel"
+)
+
+// jobsPanel is a plugin panel that shows the last key it received.
+type jobsPanel struct {
+	width, height int
+	lastKey       string
+}
+
+func (p *jobsPanel) Init() tea.Cmd { return nil }
+func (p *jobsPanel) Update(msg tea.Msg) (panel.Panel, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		p.lastKey = k.String()
+	}
+	return p, nil
+}
+func (p *jobsPanel) View() string     { return "jobs panel, last key " + p.lastKey }
+func (p *jobsPanel) SetSize(w, h int) { p.width, p.height = w, h }
+
+func TestPanelsReplaceDetailPane(t *testing.T) {
+	jobs := &jobsPanel{}
+	panel.Register(panel.Registration{
+		Meta: panel.Meta{ID: "test.jobs", Title: "Jobs", Hosts: []string{panel.HostLogs}},
+		New:  func(panel.Env) (panel.Panel, error) { return jobs, nil },
+	})
+
+	m := newSplitTestModel()
+	m.workspaceColorMap = map[string]lipgloss.Style{}
+	m.sequence = tuikeymap.NewSequenceState()
+	m.keys.NextPanel = key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "panels"))
+	m.keys.SwitchFocus = key.NewBinding(key.WithKeys("tab"))
+	m.panels = panel.NewStack(panel.Env{Host: panel.HostLogs})
+	m.ready = true
+
+	m.Update(keyMsg("P"))
+	if !m.panelShown() {
+		t.Fatal("expected P to show the registered panel")
+	}
+	if jobs.width != m.width-7 || jobs.height != m.height-m.height/2-4 {
+		t.Errorf("panel size = %dx%d", jobs.width, jobs.height)
+	}
+	if view := m.View(); !strings.Contains(view, "jobs panel") || !strings.Contains(view, "[Panel: Jobs]") {
+		t.Errorf("view does not show the panel:\n%s", view)
+	}
+
+	m.Update(keyMsg("x"))
+	if jobs.lastKey != "" {
+		t.Error("keys should stay with the list until the panel has focus")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.focus != viewportPane || jobs.height != m.height-4 {
+		t.Fatalf("after tab: focus %v, panel height %d", m.focus, jobs.height)
+	}
+	m.Update(keyMsg("x"))
+	if jobs.lastKey != "x" {
+		t.Errorf("focused panel got key %q, want x", jobs.lastKey)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.focus != listPane {
+		t.Error("tab should return focus to the list")
+	}
+
+	m.Update(keyMsg("P"))
+	if m.panelShown() {
+		t.Error("cycling past the last panel should bring back the details")
+	}
+}
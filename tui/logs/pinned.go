@@ -0,0 +1,91 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// DefaultPinnedErrors is the pinned error panel's size when
+// tui.logs.pinned_errors is unset.
+const DefaultPinnedErrors = 5
+
+// pinnedState is the pinned error panel: the last few error/fatal entries,
+// shown above the list in follow mode so scrolling info noise cannot push
+// them out of sight. It is toggled with the TogglePinned key ("!").
+type pinnedState struct {
+	enabled bool
+	limit   int
+	entries []logItem
+}
+
+// record keeps it when it is error level or worse, dropping the oldest
+// entry beyond the limit. Entries are collected while the panel is hidden
+// too, so toggling it on shows errors that already happened.
+func (p *pinnedState) record(it logItem) {
+	if levelRank(it.level) < 3 || p.limit <= 0 {
+		return
+	}
+	p.entries = append(p.entries, it)
+	if over := len(p.entries) - p.limit; over > 0 {
+		p.entries = append(p.entries[:0], p.entries[over:]...)
+	}
+}
+
+func (p *pinnedState) reset() {
+	p.entries = nil
+}
+
+// pinnedVisible reports whether the panel takes screen space: it is shown
+// only while enabled and following.
+func (m *Model) pinnedVisible() bool {
+	return m.pinned.enabled && m.followMode && m.pinned.limit > 0
+}
+
+// pinnedHeight is the number of rows the panel occupies: a header plus one
+// row per slot. The height is fixed while visible so the list does not jump
+// each time an error arrives.
+func (m *Model) pinnedHeight() int {
+	if !m.pinnedVisible() {
+		return 0
+	}
+	return m.pinned.limit + 1
+}
+
+// pinnedView renders the panel at exactly pinnedHeight rows.
+func (m *Model) pinnedView() string {
+	t := theme.DefaultTheme
+	header := t.Error.Bold(true).Render(fmt.Sprintf(" Pinned errors (%d)", len(m.pinned.entries))) +
+		t.Muted.Render(fmt.Sprintf(" - %s to hide", m.keys.TogglePinned.Help().Key))
+
+	width := m.width - 1
+	rows := make([]string, 0, m.pinned.limit+1)
+	rows = append(rows, ansi.Truncate(header, m.width, "…"))
+	if len(m.pinned.entries) == 0 {
+		rows = append(rows, t.Muted.Render("  no errors yet"))
+	}
+	for _, it := range m.pinned.entries {
+		rows = append(rows, " "+ansi.Truncate(m.rowTitle(it), width, "…"))
+	}
+	for len(rows) < m.pinned.limit+1 {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// togglePinned flips the panel and resizes the list to make room for it.
+func (m *Model) togglePinned() {
+	m.pinned.enabled = !m.pinned.enabled
+	switch {
+	case m.pinned.enabled && !m.followMode:
+		m.statusMessage = "Pinned errors on (shown in follow mode)"
+	case m.pinned.enabled:
+		m.statusMessage = "Pinned errors on"
+	default:
+		m.statusMessage = "Pinned errors off"
+	}
+	m.resizeList()
+}
@@ -0,0 +1,77 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+)
+
+func newPinnedTestModel(limit int) *Model {
+	m := newSplitTestModel()
+	m.workspaceColorMap = map[string]lipgloss.Style{}
+	m.keys.TogglePinned = key.NewBinding(key.WithKeys("!"), key.WithHelp("!", "pin"))
+	m.sequence = tuikeymap.NewSequenceState()
+	m.pinned.limit = limit
+	m.followMode = true
+	m.resizeList()
+	return m
+}
+
+func TestPinnedKeepsLastErrors(t *testing.T) {
+	m := newPinnedTestModel(2)
+
+	for _, e := range []struct{ level, msg string }{
+		{"error", "first"},
+		{"info", "noise"},
+		{"fatal", "second"},
+		{"warn", "advisory"},
+		{"error", "third"},
+	} {
+		m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": e.level, "msg": e.msg}})
+	}
+
+	if len(m.pinned.entries) != 2 {
+		t.Fatalf("expected 2 pinned entries, got %d", len(m.pinned.entries))
+	}
+	if m.pinned.entries[0].message != "second" || m.pinned.entries[1].message != "third" {
+		t.Errorf("expected the last two errors, got %q and %q", m.pinned.entries[0].message, m.pinned.entries[1].message)
+	}
+}
+
+func TestPinnedPanelReservesRowsInFollowMode(t *testing.T) {
+	m := newPinnedTestModel(3)
+	base := m.list.Height()
+
+	m.Update(keyMsg("!"))
+	if !m.pinned.enabled {
+		t.Fatal("expected ! to enable the panel")
+	}
+	if got := m.list.Height(); got != base-4 {
+		t.Errorf("list height = %d, want %d (header + 3 slots)", got, base-4)
+	}
+
+	view := m.pinnedView()
+	if lines := strings.Count(view, "\n") + 1; lines != m.pinnedHeight() {
+		t.Errorf("panel renders %d lines, want %d", lines, m.pinnedHeight())
+	}
+	if !strings.Contains(ansi.Strip(view), "no errors yet") {
+		t.Errorf("empty panel should say so: %q", ansi.Strip(view))
+	}
+
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{"level": "error", "msg": "disk full"}})
+	if !strings.Contains(ansi.Strip(m.pinnedView()), "disk full") {
+		t.Errorf("panel should list the error: %q", ansi.Strip(m.pinnedView()))
+	}
+
+	// Leaving follow mode hides the panel and gives the rows back.
+	m.followMode = false
+	m.resizeList()
+	if m.pinnedVisible() || m.list.Height() != base {
+		t.Errorf("panel should be hidden outside follow mode (height %d, want %d)", m.list.Height(), base)
+	}
+}
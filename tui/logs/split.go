@@ -0,0 +1,299 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+	"github.com/grovetools/core/tui/theme"
+)
+
+// splitState holds the side-by-side comparison view toggled with the
+// ToggleSplit key ("|"). Both panes share one timeline: every row belongs
+// to exactly one of the two components and is rendered in that component's
+// column, so entries from the two sides stay aligned by timestamp and a
+// single cursor scrolls both panes together.
+type splitState struct {
+	// active is true while the split view is shown.
+	active bool
+
+	// picking is true while the two-component chooser is open.
+	picking      bool
+	pickerItems  []string
+	pickerCursor int
+	// chosen holds the components picked so far (left first).
+	chosen []string
+
+	left  string
+	right string
+	rows  []logItem
+
+	cursor int
+	offset int
+}
+
+// openSplitPicker lists the components seen so far so the user can choose
+// the left and right panes.
+func (m *Model) openSplitPicker() {
+	seen := make(map[string]bool)
+	for _, item := range m.items {
+		if item.component != "" {
+			seen[item.component] = true
+		}
+	}
+	m.split.pickerItems = nil
+	for name := range seen {
+		m.split.pickerItems = append(m.split.pickerItems, name)
+	}
+	sort.Strings(m.split.pickerItems)
+	m.split.pickerCursor = 0
+	m.split.chosen = nil
+	m.split.picking = true
+}
+
+// startSplit activates the split view for the two given components.
+func (m *Model) startSplit(left, right string) {
+	m.split.left = left
+	m.split.right = right
+	m.split.active = true
+	m.split.picking = false
+	m.split.cursor = 0
+	m.split.offset = 0
+	m.rebuildSplit()
+	m.splitSelect(len(m.split.rows) - 1)
+}
+
+// stopSplit returns to the regular single-list view.
+func (m *Model) stopSplit() {
+	m.split = splitState{}
+}
+
+// rebuildSplit recomputes the merged timeline for the two split components
+// from m.items, honoring the workspace and events-only filters. m.items is
+// kept in timestamp order, so the rows are too.
+func (m *Model) rebuildSplit() {
+	if !m.split.active {
+		return
+	}
+	m.split.rows = m.split.rows[:0]
+	for _, it := range m.items {
+		if it.component != m.split.left && it.component != m.split.right {
+			continue
+		}
+		if m.matchesWorkspaceFilter(it) && m.matchesEventsFilter(it) && m.matchesCorrelation(it) {
+			m.split.rows = append(m.split.rows, it)
+		}
+	}
+	if m.split.cursor >= len(m.split.rows) {
+		m.split.cursor = len(m.split.rows) - 1
+	}
+	if m.split.cursor < 0 {
+		m.split.cursor = 0
+	}
+}
+
+// splitListHeight returns the number of rows available to the split panes.
+func (m *Model) splitListHeight() int {
+	// One line for the column headers.
+	h := m.listPaneHeight() - 1
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// splitSelect moves the shared cursor, keeps it in view and refreshes the
+// detail pane with the selected entry.
+func (m *Model) splitSelect(idx int) {
+	if len(m.split.rows) == 0 {
+		m.split.cursor = 0
+		m.split.offset = 0
+		return
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(m.split.rows) {
+		idx = len(m.split.rows) - 1
+	}
+	m.split.cursor = idx
+
+	height := m.splitListHeight()
+	if m.split.cursor < m.split.offset {
+		m.split.offset = m.split.cursor
+	} else if m.split.cursor >= m.split.offset+height {
+		m.split.offset = m.split.cursor - height + 1
+	}
+
+	m.viewport.SetContent(m.split.rows[m.split.cursor].FormatDetails())
+	m.viewport.GotoTop()
+}
+
+// updateSplitPicker handles input while the component chooser is open.
+// The first pick becomes the left pane and the second the right pane.
+func (m *Model) updateSplitPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Base.Quit) {
+		return m, doneCmd()
+	}
+	switch msg.String() {
+	case "esc", "|":
+		m.split.picking = false
+		m.split.chosen = nil
+	case "j", "down":
+		if m.split.pickerCursor < len(m.split.pickerItems)-1 {
+			m.split.pickerCursor++
+		}
+	case "k", "up":
+		if m.split.pickerCursor > 0 {
+			m.split.pickerCursor--
+		}
+	case " ", "enter":
+		if m.split.pickerCursor >= len(m.split.pickerItems) {
+			return m, nil
+		}
+		name := m.split.pickerItems[m.split.pickerCursor]
+		if len(m.split.chosen) == 1 && m.split.chosen[0] == name {
+			m.split.chosen = nil
+			return m, nil
+		}
+		m.split.chosen = append(m.split.chosen, name)
+		if len(m.split.chosen) == 2 {
+			m.startSplit(m.split.chosen[0], m.split.chosen[1])
+			m.statusMessage = fmt.Sprintf("Split: %s | %s", m.split.left, m.split.right)
+			return m, m.clearStatusMessageAfter(2 * time.Second)
+		}
+	}
+	return m, nil
+}
+
+// updateSplit handles input while the split view is active. Navigation
+// moves the shared cursor; everything else that makes sense only for the
+// single list (visual mode, search) is ignored until the split is closed.
+func (m *Model) updateSplit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	seqResult, _ := m.sequence.Process(msg, m.keys.GotoTop)
+	switch seqResult {
+	case tuikeymap.SequenceMatch:
+		m.sequence.Clear()
+		m.splitSelect(0)
+		return m, nil
+	case tuikeymap.SequencePending:
+		return m, nil
+	}
+	m.sequence.Clear()
+
+	half := m.splitListHeight() / 2
+	switch {
+	case key.Matches(msg, m.keys.Base.Quit):
+		return m, doneCmd()
+	case key.Matches(msg, m.keys.Base.Help):
+		m.help.Toggle()
+	case key.Matches(msg, m.keys.ToggleSplit), key.Matches(msg, m.keys.Clear):
+		m.stopSplit()
+		if selectedItem := m.list.SelectedItem(); selectedItem != nil {
+			if li, ok := selectedItem.(logItem); ok {
+				m.viewport.SetContent(li.FormatDetails())
+			}
+		}
+	case key.Matches(msg, m.keys.Base.Up):
+		m.splitSelect(m.split.cursor - 1)
+	case key.Matches(msg, m.keys.Base.Down):
+		m.splitSelect(m.split.cursor + 1)
+	case key.Matches(msg, m.keys.HalfUp):
+		m.splitSelect(m.split.cursor - half)
+	case key.Matches(msg, m.keys.HalfDown):
+		m.splitSelect(m.split.cursor + half)
+	case key.Matches(msg, m.keys.PageUp):
+		m.splitSelect(m.split.cursor - m.splitListHeight())
+	case key.Matches(msg, m.keys.PageDown):
+		m.splitSelect(m.split.cursor + m.splitListHeight())
+	case key.Matches(msg, m.keys.GotoEnd):
+		m.splitSelect(len(m.split.rows) - 1)
+	case key.Matches(msg, m.keys.ToggleFollow):
+		return m, m.cycleFollow()
+	}
+	return m, nil
+}
+
+// splitPickerView renders the two-component chooser.
+func (m *Model) splitPickerView() string {
+	titleStyle := theme.DefaultTheme.Header
+	prompt := "choose LEFT component"
+	if len(m.split.chosen) == 1 {
+		prompt = fmt.Sprintf("left: %s, choose RIGHT component", m.split.chosen[0])
+	}
+	lines := []string{titleStyle.Render("Split View") + "  (" + prompt + "; enter: select, esc: cancel)", ""}
+
+	if len(m.split.pickerItems) == 0 {
+		lines = append(lines, theme.DefaultTheme.Muted.Render("  No components seen yet"))
+	}
+	for i, name := range m.split.pickerItems {
+		cursor := "  "
+		if i == m.split.pickerCursor {
+			cursor = "> "
+		}
+		mark := " "
+		if len(m.split.chosen) == 1 && m.split.chosen[0] == name {
+			mark = "L"
+		}
+		lines = append(lines, fmt.Sprintf("%s[%s] %s", cursor, mark, name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitListView renders the two synchronized columns. Each row occupies
+// the column of the component it belongs to; the other column is left
+// blank so the relative ordering of the two streams is visible at a glance.
+func (m *Model) splitListView() string {
+	colWidth := (m.width - 3) / 2
+	if colWidth < 10 {
+		colWidth = 10
+	}
+	cell := lipgloss.NewStyle().Width(colWidth).MaxWidth(colWidth)
+	sep := theme.DefaultTheme.Muted.Render(" │ ")
+	header := theme.DefaultTheme.Header
+
+	lines := []string{
+		cell.Render(header.Render(m.split.left)) + sep + cell.Render(header.Render(m.split.right)),
+	}
+
+	height := m.splitListHeight()
+	end := m.split.offset + height
+	if end > len(m.split.rows) {
+		end = len(m.split.rows)
+	}
+	for idx := m.split.offset; idx < end; idx++ {
+		it := m.split.rows[idx]
+		text := splitCellText(it)
+		if idx == m.split.cursor {
+			text = theme.DefaultTheme.Selected.Render(text)
+		}
+		left, right := "", ""
+		if it.component == m.split.left {
+			left = text
+		} else {
+			right = text
+		}
+		lines = append(lines, cell.Render(left)+sep+cell.Render(right))
+	}
+	for len(lines) < height+1 {
+		lines = append(lines, cell.Render("")+sep+cell.Render(""))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitCellText is the compact single-line rendering used inside a split
+// column; the component name is implied by the column.
+func splitCellText(it logItem) string {
+	levelStyle := themeLevelStyle(it.level)
+	return fmt.Sprintf("%s %s %s",
+		theme.DefaultTheme.Muted.Render(it.timestamp.Format("15:04:05.000")),
+		levelStyle.Render(fmt.Sprintf("%-5s", strings.ToUpper(it.level))),
+		it.message,
+	)
+}
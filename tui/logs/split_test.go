@@ -0,0 +1,88 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newSplitTestModel() *Model {
+	m := &Model{
+		hiddenComponents: map[string]bool{},
+		list:             list.New([]list.Item{}, itemDelegate{}, 0, 0),
+		height:           40,
+		width:            120,
+	}
+	m.keys = logKeyMapT{}
+	return m
+}
+
+func TestRebuildSplitKeepsTwoComponentsInTimeOrder(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSplitTestModel()
+	m.items = []logItem{
+		{component: "api", timestamp: base, message: "request"},
+		{component: "db", timestamp: base.Add(time.Second), message: "query"},
+		{component: "worker", timestamp: base.Add(2 * time.Second), message: "job"},
+		{component: "api", timestamp: base.Add(3 * time.Second), message: "response"},
+	}
+
+	m.startSplit("api", "worker")
+
+	if len(m.split.rows) != 3 {
+		t.Fatalf("expected 3 split rows, got %d", len(m.split.rows))
+	}
+	want := []string{"request", "job", "response"}
+	for i, w := range want {
+		if m.split.rows[i].message != w {
+			t.Errorf("row %d: expected %q, got %q", i, w, m.split.rows[i].message)
+		}
+	}
+	if m.split.cursor != 2 {
+		t.Errorf("expected cursor on newest row, got %d", m.split.cursor)
+	}
+}
+
+func TestSplitPickerSelectsLeftThenRight(t *testing.T) {
+	m := newSplitTestModel()
+	m.items = []logItem{{component: "worker"}, {component: "api"}}
+	m.openSplitPicker()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	m.updateSplitPicker(enter) // api (sorted first)
+	if m.split.active {
+		t.Fatal("split should not be active after one pick")
+	}
+	m.updateSplitPicker(down)
+	m.updateSplitPicker(enter) // worker
+
+	if !m.split.active || m.split.picking {
+		t.Fatal("split should be active after two picks")
+	}
+	if m.split.left != "api" || m.split.right != "worker" {
+		t.Errorf("expected api | worker, got %s | %s", m.split.left, m.split.right)
+	}
+}
+
+func TestHandleNewLogAppendsToSplit(t *testing.T) {
+	m := newSplitTestModel()
+	m.startSplit("api", "worker")
+
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{
+		"component": "api", "msg": "hello", "level": "info", "time": "2026-01-01T12:00:00Z",
+	}})
+	m.handleNewLog(newLogMsg{data: map[string]interface{}{
+		"component": "other", "msg": "ignored", "level": "info", "time": "2026-01-01T12:00:01Z",
+	}})
+
+	if len(m.split.rows) != 1 || m.split.rows[0].message != "hello" {
+		t.Fatalf("expected only the api entry in the split, got %+v", m.split.rows)
+	}
+	if len(m.visible) != 2 {
+		t.Errorf("regular list should still receive every entry, got %d", len(m.visible))
+	}
+}
@@ -0,0 +1,55 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/grovetools/core/logging"
+	"github.com/grovetools/core/tui/theme"
+)
+
+// isRunStart reports whether the item is a process startup banner, which
+// the list renders as a separator between runs.
+func (i logItem) isRunStart() bool {
+	return logging.IsStartupEntry(i.rawData)
+}
+
+// runSeparator renders a startup banner as a rule spanning width columns:
+// "── ▶ grove-flow v1.2.0 (abc1234) · pid 4242 · host · 15:04:05 ──────".
+func (i logItem) runSeparator(width int) string {
+	parts := []string{"▶ " + i.component}
+	if v, _ := i.rawData["version"].(string); v != "" {
+		if c, _ := i.rawData["commit"].(string); c != "" {
+			v += fmt.Sprintf(" (%s)", shortCommit(c))
+		}
+		parts[0] += " " + v
+	}
+	if pid, ok := i.rawData["pid"].(float64); ok && pid > 0 {
+		parts = append(parts, fmt.Sprintf("pid %d", int(pid)))
+	}
+	if host, _ := i.rawData["hostname"].(string); host != "" {
+		parts = append(parts, host)
+	}
+	if !i.timestamp.IsZero() {
+		parts = append(parts, i.timestamp.Format("2006-01-02 15:04:05"))
+	}
+
+	label := " " + strings.Join(parts, " · ") + " "
+	if width <= 0 {
+		width = ansi.StringWidth(label) + 4
+	}
+	label = ansi.Truncate(label, max(width-2, 0), "…")
+	rule := theme.DefaultTheme.Separator
+	tail := max(width-2-ansi.StringWidth(label), 0)
+	return rule.Render("──") + theme.DefaultTheme.Accent.Render(label) + rule.Render(strings.Repeat("─", tail))
+}
+
+// shortCommit trims a commit hash to the conventional seven characters.
+func shortCommit(c string) string {
+	if len(c) > 7 {
+		return c[:7]
+	}
+	return c
+}
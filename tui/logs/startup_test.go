@@ -0,0 +1,46 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/grovetools/core/logging"
+)
+
+func TestRunSeparatorRow(t *testing.T) {
+	m := newWrapTestModel(logging.StartupMessage)
+	m.items[0].component = "grove-flow"
+	m.items[0].rawData = map[string]interface{}{
+		"event":    logging.StartupEvent,
+		"version":  "v1.2.0",
+		"commit":   "abc1234def5678",
+		"pid":      float64(4242),
+		"hostname": "devbox",
+	}
+	m.rebuildVisible()
+
+	row := ansi.Strip(renderRow(m))
+	for _, want := range []string{"── ▶ grove-flow v1.2.0 (abc1234)", "pid 4242", "devbox", "──"} {
+		if !strings.Contains(row, want) {
+			t.Errorf("separator %q missing %q", row, want)
+		}
+	}
+	if strings.Contains(row, logging.StartupMessage) {
+		t.Errorf("separator should replace the plain row, got %q", row)
+	}
+	if w := ansi.StringWidth(row); w > 60 {
+		t.Errorf("separator is %d columns wide, want at most 60", w)
+	}
+}
+
+func TestRunSeparatorOnlyForStartupEntries(t *testing.T) {
+	m := newWrapTestModel("ordinary entry")
+	m.items[0].rawData = map[string]interface{}{"event": "job.created"}
+	m.rebuildVisible()
+
+	if row := ansi.Strip(renderRow(m)); !strings.Contains(row, "ordinary entry") || strings.Contains(row, "▶") {
+		t.Errorf("ordinary entry rendered as %q", row)
+	}
+}
@@ -0,0 +1,92 @@
+package logs
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// timestampMode selects how the list's timestamp column is rendered. It is
+// toggled with the ToggleTimestamps key ("t").
+type timestampMode int
+
+const (
+	// timestampAbsolute shows the entry's date and time.
+	timestampAbsolute timestampMode = iota
+	// timestampRelative shows the entry's age, e.g. "2m ago".
+	timestampRelative
+)
+
+// ageWidth is the width of the relative age column; ages are right-aligned
+// in it so messages stay in one column as ages change.
+const ageWidth = len("just now")
+
+func (t timestampMode) String() string {
+	if t == timestampRelative {
+		return "relative"
+	}
+	return "absolute"
+}
+
+// parseTimestampMode maps tui.logs.timestamps to a mode; anything other
+// than "relative" is absolute.
+func parseTimestampMode(s string) timestampMode {
+	if s == "relative" {
+		return timestampRelative
+	}
+	return timestampAbsolute
+}
+
+// formatAge renders how long before now t was, in the largest whole unit:
+// "just now", "42s ago", "5m ago", "3h ago" or "2d ago". Times in the
+// future, as with clock skew between writers, count as just now.
+func formatAge(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < 5*time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+}
+
+// rowTitle renders an entry's list row in the current timestamp mode.
+// Ages are measured from the last tick so every row on screen agrees.
+func (m *Model) rowTitle(i logItem) string {
+	if m.timestamps != timestampRelative {
+		return i.Title()
+	}
+	now := m.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return i.titleWithTime(fmt.Sprintf("%*s", ageWidth, formatAge(i.timestamp, now)))
+}
+
+// toggleTimestamps switches the timestamp column between absolute times
+// and relative ages.
+func (m *Model) toggleTimestamps() tea.Cmd {
+	if m.timestamps == timestampRelative {
+		m.timestamps = timestampAbsolute
+		m.statusMessage = "Absolute timestamps"
+	} else {
+		m.timestamps = timestampRelative
+		m.statusMessage = "Relative timestamps"
+	}
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// timestampIndicator is the status bar segment shown while ages are shown.
+func (m *Model) timestampIndicator() string {
+	if m.timestamps == timestampRelative {
+		return " [AGE]"
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/x/ansi"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+)
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{-time.Minute, "just now"},
+		{0, "just now"},
+		{4 * time.Second, "just now"},
+		{42 * time.Second, "42s ago"},
+		{5*time.Minute + 30*time.Second, "5m ago"},
+		{3 * time.Hour, "3h ago"},
+		{50 * time.Hour, "2d ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("formatAge(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+		if len(tt.want) > ageWidth {
+			t.Errorf("%q is wider than the age column", tt.want)
+		}
+	}
+}
+
+func TestToggleTimestampsShowsAgesFromTick(t *testing.T) {
+	m := newWrapTestModel("request handled")
+	m.keys.ToggleTimestamps = key.NewBinding(key.WithKeys("t"))
+	m.sequence = tuikeymap.NewSequenceState()
+	m.now = m.items[0].timestamp.Add(2 * time.Minute)
+
+	if row := ansi.Strip(renderRow(m)); !strings.Contains(row, "2026-01-01 12:00:00") {
+		t.Fatalf("expected an absolute timestamp by default, got %q", row)
+	}
+
+	m.Update(keyMsg("t"))
+	if m.timestamps != timestampRelative {
+		t.Fatal("expected t to switch to relative timestamps")
+	}
+	if row := ansi.Strip(renderRow(m)); !strings.Contains(row, "  2m ago") || strings.Contains(row, "12:00:00") {
+		t.Errorf("expected a right-aligned age, got %q", row)
+	}
+	if m.timestampIndicator() == "" {
+		t.Error("expected a status bar indicator in relative mode")
+	}
+
+	m.Update(tickMsg(m.items[0].timestamp.Add(3 * time.Hour)))
+	if row := ansi.Strip(renderRow(m)); !strings.Contains(row, "3h ago") {
+		t.Errorf("expected the age refreshed on tick, got %q", row)
+	}
+}
+
+func TestTimestampModeFromConfigAndViewState(t *testing.T) {
+	if parseTimestampMode("relative") != timestampRelative || parseTimestampMode("") != timestampAbsolute {
+		t.Error("unexpected mode for tui.logs.timestamps")
+	}
+
+	m := newSplitTestModel()
+	m.timestamps = timestampRelative
+	m.applyViewState(&ViewState{})
+	if m.timestamps != timestampRelative {
+		t.Error("a view state without timestamps should keep the configured mode")
+	}
+	m.applyViewState(&ViewState{Timestamps: "absolute"})
+	if m.timestamps != timestampAbsolute {
+		t.Error("a saved timestamp mode should be restored")
+	}
+}
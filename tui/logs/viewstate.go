@@ -0,0 +1,181 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// viewStateFile is the per-workspace view state store, relative to the
+// workspace root.
+var viewStateFile = filepath.Join(".grove", "state", "logs-tui.json")
+
+// ViewState is the investigation context the standalone TUI saves on quit
+// and restores on the next launch: the filters, the timestamp of the entry
+// under the cursor and follow mode. Bookmarks are not part of it; they are
+// saved to the workspace's annotations file as they change.
+type ViewState struct {
+	Scope            string    `json:"scope,omitempty"`
+	Level            string    `json:"level,omitempty"`
+	IncludeSystem    bool      `json:"include_system,omitempty"`
+	FiltersEnabled   bool      `json:"filters_enabled,omitempty"`
+	EventsOnly       bool      `json:"events_only,omitempty"`
+	ContextLines     int       `json:"context_lines,omitempty"`
+	HiddenComponents []string  `json:"hidden_components,omitempty"`
+	HiddenWorkspaces []string  `json:"hidden_workspaces,omitempty"`
+	CorrelationKey   string    `json:"correlation_key,omitempty"`
+	CorrelationValue string    `json:"correlation_value,omitempty"`
+	Timestamps       string    `json:"timestamps,omitempty"`
+	Follow           bool      `json:"follow"`
+	FollowMode       string    `json:"follow_mode,omitempty"`
+	Cursor           time.Time `json:"cursor,omitzero"`
+	SavedAt          time.Time `json:"saved_at"`
+}
+
+// ViewStatePath returns the view state file for a workspace root, falling
+// back to the current directory when no workspace is active.
+func ViewStatePath(workspacePath string) string {
+	root := workspacePath
+	if root == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		root = cwd
+	}
+	return filepath.Join(root, viewStateFile)
+}
+
+// LoadViewState reads the view state saved at path. A missing or unreadable
+// file reports false.
+func LoadViewState(path string) (*ViewState, bool) {
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var st ViewState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, false
+	}
+	return &st, true
+}
+
+// SaveViewState writes st to path, replacing the file atomically.
+func SaveViewState(path string, st *ViewState) error {
+	if path == "" {
+		return fmt.Errorf("no workspace directory for the view state")
+	}
+	st.SavedAt = time.Now()
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal view state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write view state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write view state: %w", err)
+	}
+	return nil
+}
+
+// ViewState captures the model's current view state.
+func (m *Model) ViewState() *ViewState {
+	st := &ViewState{
+		Scope:            m.activeScope.scopeToParam(),
+		Level:            levelToParam(m.minLevel),
+		IncludeSystem:    m.includeSystem,
+		FiltersEnabled:   m.filtersEnabled,
+		EventsOnly:       m.eventsOnly,
+		ContextLines:     m.contextLines,
+		CorrelationKey:   m.correlation.key,
+		CorrelationValue: m.correlation.value,
+		Timestamps:       m.timestamps.String(),
+		Follow:           m.followMode,
+	}
+	if m.followMode {
+		st.FollowMode = m.follow.behavior.String()
+	}
+	for name := range m.hiddenComponents {
+		st.HiddenComponents = append(st.HiddenComponents, name)
+	}
+	sort.Strings(st.HiddenComponents)
+	for k := range m.workspaces.hidden {
+		st.HiddenWorkspaces = append(st.HiddenWorkspaces, k)
+	}
+	sort.Strings(st.HiddenWorkspaces)
+	if li, ok := m.selectedLogItem(); ok && !m.followMode {
+		st.Cursor = li.timestamp
+	}
+	return st
+}
+
+// applyViewState restores st over the settings New derived from Config.
+// The cursor is placed once the entry it points at has been replayed.
+func (m *Model) applyViewState(st *ViewState) {
+	if st.Scope != "" {
+		m.activeScope = parseScope(st.Scope)
+	}
+	if st.Level != "" {
+		m.minLevel = parseLevelConfig(st.Level)
+	}
+	m.includeSystem = st.IncludeSystem
+	m.filtersEnabled = st.FiltersEnabled
+	m.eventsOnly = st.EventsOnly
+	m.contextLines = st.ContextLines
+	for _, name := range st.HiddenComponents {
+		m.hiddenComponents[name] = true
+	}
+	for _, k := range st.HiddenWorkspaces {
+		m.setWorkspaceHidden(k, true)
+	}
+	m.correlation = correlationFilter{key: st.CorrelationKey, value: st.CorrelationValue}
+	if st.Timestamps != "" {
+		m.timestamps = parseTimestampMode(st.Timestamps)
+	}
+	m.followMode = st.Follow
+	if st.FollowMode != "" {
+		m.follow.behavior = parseFollowBehavior(st.FollowMode)
+	}
+	if !st.Follow {
+		m.pendingCursor = st.Cursor
+	}
+}
+
+// restoreCursor moves the cursor to the last visible entry at or before the
+// restored cursor timestamp. It stops trying once that exact entry has
+// arrived or the user moves the cursor.
+func (m *Model) restoreCursor() {
+	if m.pendingCursor.IsZero() {
+		return
+	}
+	idx := -1
+	for i, v := range m.visible {
+		it, ok := v.(logItem)
+		if !ok || it.timestamp.After(m.pendingCursor) {
+			break
+		}
+		idx = i
+	}
+	if idx < 0 {
+		return
+	}
+	m.list.Select(idx)
+	if it, ok := m.visible[idx].(logItem); ok {
+		m.viewport.SetContent(it.FormatDetails())
+		m.viewport.GotoTop()
+		if it.timestamp.Equal(m.pendingCursor) {
+			m.pendingCursor = time.Time{}
+		}
+	}
+}
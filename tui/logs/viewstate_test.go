@@ -0,0 +1,78 @@
+package logs
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestViewStateRoundTrip(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSplitTestModel()
+	m.items = []logItem{
+		{component: "api", timestamp: base, message: "first"},
+		{component: "api", timestamp: base.Add(time.Second), message: "second"},
+		{component: "db", timestamp: base.Add(2 * time.Second), message: "third"},
+	}
+	m.rebuildVisible()
+	m.list.Select(1)
+	m.activeScope = ScopeEcosystem
+	m.minLevel = 2
+	m.eventsOnly = true
+	m.contextLines = 3
+	m.hiddenComponents["worker"] = true
+	m.correlation = correlationFilter{key: "request_id", value: "r1"}
+
+	path := ViewStatePath(t.TempDir())
+	if err := SaveViewState(path, m.ViewState()); err != nil {
+		t.Fatalf("SaveViewState: %v", err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "state" {
+		t.Errorf("expected the file under .grove/state, got %s", path)
+	}
+	st, ok := LoadViewState(path)
+	if !ok {
+		t.Fatal("expected the saved state to load")
+	}
+
+	restored := newSplitTestModel()
+	restored.applyViewState(st)
+	if restored.activeScope != ScopeEcosystem || restored.minLevel != 2 || !restored.eventsOnly ||
+		restored.contextLines != 3 || !restored.hiddenComponents["worker"] || restored.correlation.value != "r1" {
+		t.Errorf("filters not restored: %+v", st)
+	}
+	if restored.followMode || !restored.pendingCursor.Equal(base.Add(time.Second)) {
+		t.Errorf("expected the cursor at the second entry, got %v (follow %v)", restored.pendingCursor, restored.followMode)
+	}
+}
+
+func TestRestoredCursorFollowsReplay(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := newSplitTestModel()
+	m.workspaceColorMap = map[string]lipgloss.Style{}
+	m.minLevel = 0
+	m.applyViewState(&ViewState{Cursor: base.Add(time.Second)})
+
+	for i, msg := range []string{"first", "second", "third"} {
+		m.handleNewLog(newLogMsg{workspace: "api", data: map[string]interface{}{
+			"time":      base.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+			"level":     "info",
+			"msg":       msg,
+			"component": "api",
+		}})
+	}
+	if li, ok := m.selectedLogItem(); !ok || li.message != "second" {
+		t.Errorf("expected the restored cursor on the second entry, got %+v", li)
+	}
+	if !m.pendingCursor.IsZero() {
+		t.Error("expected the pending cursor cleared once its entry arrived")
+	}
+}
+
+func TestLoadViewStateMissing(t *testing.T) {
+	if _, ok := LoadViewState(filepath.Join(t.TempDir(), "missing.json")); ok {
+		t.Error("expected a missing file to report false")
+	}
+}
@@ -0,0 +1,246 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grovetools/core/tui/theme"
+)
+
+// workspaceState holds the workspace picker opened with the Workspaces key
+// ("w") and the selection it edits. Entries from every workspace the
+// daemon streams are kept; deselected workspaces are only hidden, so
+// selecting one again brings its entries straight back into the merged
+// list.
+type workspaceState struct {
+	// picking is true while the picker overlay is open.
+	picking bool
+	cursor  int
+	// items are the workspaces contributing entries when the picker was
+	// opened, sorted by name.
+	items []workspaceSummary
+
+	// hidden holds the keys (see workspaceKey) of deselected workspaces.
+	hidden map[string]bool
+	// initial, when set, is the selection the viewer started with
+	// (Config.Workspaces): any other workspace is deselected the first
+	// time it contributes an entry. seen records those first sightings.
+	initial map[string]bool
+	seen    map[string]bool
+}
+
+// workspaceSummary is one row of the workspace picker.
+type workspaceSummary struct {
+	key     string
+	name    string
+	path    string
+	entries int
+	latest  time.Time
+}
+
+// workspaceKey identifies the workspace an entry came from: its path, or
+// its name for entries without one such as system logs.
+func workspaceKey(it logItem) string {
+	if it.workspacePath != "" {
+		return it.workspacePath
+	}
+	return it.workspace
+}
+
+// matchesWorkspaceFilter returns true unless the item's workspace was
+// deselected in the workspace picker.
+func (m *Model) matchesWorkspaceFilter(it logItem) bool {
+	return !m.workspaces.hidden[workspaceKey(it)]
+}
+
+// setWorkspaceHidden selects or deselects a workspace.
+func (m *Model) setWorkspaceHidden(k string, hidden bool) {
+	if !hidden {
+		delete(m.workspaces.hidden, k)
+		return
+	}
+	if m.workspaces.hidden == nil {
+		m.workspaces.hidden = make(map[string]bool)
+	}
+	m.workspaces.hidden[k] = true
+}
+
+// setInitialWorkspaces restricts the selection to paths: workspaces
+// outside it start deselected when their first entry arrives.
+func (m *Model) setInitialWorkspaces(paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	m.workspaces.initial = make(map[string]bool, len(paths))
+	for _, p := range paths {
+		m.workspaces.initial[p] = true
+	}
+}
+
+// noteWorkspace deselects a workspace outside the initial selection the
+// first time one of its entries arrives. Later toggles in the picker win.
+func (m *Model) noteWorkspace(it logItem) {
+	if m.workspaces.initial == nil {
+		return
+	}
+	k := workspaceKey(it)
+	if m.workspaces.seen[k] {
+		return
+	}
+	if m.workspaces.seen == nil {
+		m.workspaces.seen = make(map[string]bool)
+	}
+	m.workspaces.seen[k] = true
+	if !m.workspaces.initial[k] && !m.workspaces.initial[it.workspace] {
+		m.setWorkspaceHidden(k, true)
+	}
+}
+
+// workspaceSummaries counts the held entries of each workspace and finds
+// its latest timestamp.
+func (m *Model) workspaceSummaries() []workspaceSummary {
+	byKey := make(map[string]*workspaceSummary)
+	var out []*workspaceSummary
+	for _, it := range m.items {
+		k := workspaceKey(it)
+		if k == "" {
+			continue
+		}
+		s, ok := byKey[k]
+		if !ok {
+			s = &workspaceSummary{key: k, name: it.workspace, path: it.workspacePath}
+			if s.name == "" {
+				s.name = k
+			}
+			byKey[k] = s
+			out = append(out, s)
+		}
+		s.entries++
+		if it.timestamp.After(s.latest) {
+			s.latest = it.timestamp
+		}
+	}
+	summaries := make([]workspaceSummary, 0, len(out))
+	for _, s := range out {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].name != summaries[j].name {
+			return summaries[i].name < summaries[j].name
+		}
+		return summaries[i].key < summaries[j].key
+	})
+	return summaries
+}
+
+// openWorkspacePicker lists the workspaces contributing entries so the
+// user can choose which ones the merged list shows.
+func (m *Model) openWorkspacePicker() {
+	m.workspaces.items = m.workspaceSummaries()
+	m.workspaces.cursor = 0
+	m.workspaces.picking = true
+}
+
+// updateWorkspacePicker handles input while the workspace picker is open.
+// Changes apply to the list immediately.
+func (m *Model) updateWorkspacePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Base.Quit) {
+		return m, doneCmd()
+	}
+	items := m.workspaces.items
+	switch msg.String() {
+	case "esc", "w":
+		m.workspaces.picking = false
+		return m, nil
+	case "j", "down":
+		if m.workspaces.cursor < len(items)-1 {
+			m.workspaces.cursor++
+		}
+		return m, nil
+	case "k", "up":
+		if m.workspaces.cursor > 0 {
+			m.workspaces.cursor--
+		}
+		return m, nil
+	case " ", "enter":
+		if m.workspaces.cursor >= len(items) {
+			return m, nil
+		}
+		k := items[m.workspaces.cursor].key
+		m.setWorkspaceHidden(k, !m.workspaces.hidden[k])
+	case "o":
+		if m.workspaces.cursor >= len(items) {
+			return m, nil
+		}
+		for i, s := range items {
+			m.setWorkspaceHidden(s.key, i != m.workspaces.cursor)
+		}
+	case "a":
+		m.workspaces.hidden = nil
+	case "n":
+		for _, s := range items {
+			m.setWorkspaceHidden(s.key, true)
+		}
+	default:
+		return m, nil
+	}
+	m.rebuildVisible()
+	return m, nil
+}
+
+// workspacePickerView renders the workspace picker overlay.
+func (m *Model) workspacePickerView() string {
+	titleStyle := theme.DefaultTheme.Header
+	lines := []string{titleStyle.Render("Workspaces") + "  (space: toggle, o: only, a: all, n: none, esc: close)", ""}
+
+	if len(m.workspaces.items) == 0 {
+		lines = append(lines, theme.DefaultTheme.Muted.Render("  No workspaces seen yet"))
+	}
+	nameWidth := 0
+	for _, s := range m.workspaces.items {
+		nameWidth = max(nameWidth, lipgloss.Width(s.name))
+	}
+	hiddenCount := 0
+	for i, s := range m.workspaces.items {
+		check := "✓"
+		style := lipgloss.NewStyle()
+		if m.workspaces.hidden[s.key] {
+			check = " "
+			style = theme.DefaultTheme.Muted
+			hiddenCount++
+		}
+		cursor := "  "
+		if i == m.workspaces.cursor {
+			cursor = "> "
+		}
+		latest := "-"
+		if !s.latest.IsZero() {
+			latest = s.latest.Format("2006-01-02 15:04:05")
+		}
+		line := fmt.Sprintf("%s[%s] %-*s %6d entries  latest %s", cursor, check, nameWidth, s.name, s.entries, latest)
+		if s.path != "" && s.path != s.name {
+			line += "  " + s.path
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	if hiddenCount > 0 {
+		lines = append(lines, "", theme.DefaultTheme.Warning.Render(fmt.Sprintf("  %d workspace(s) hidden", hiddenCount)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// workspaceIndicator is the status bar note shown while workspaces are
+// deselected.
+func (m *Model) workspaceIndicator() string {
+	if n := len(m.workspaces.hidden); n > 0 {
+		return fmt.Sprintf(" [hiding: %d workspaces]", n)
+	}
+	return ""
+}
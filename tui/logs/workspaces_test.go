@@ -0,0 +1,70 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func wsLog(ws, path, msg, ts string) newLogMsg {
+	return newLogMsg{workspace: ws, workspacePath: path, data: map[string]interface{}{
+		"msg": msg, "level": "info", "time": ts,
+	}}
+}
+
+func TestWorkspacePickerSummariesAndToggle(t *testing.T) {
+	m := newSplitTestModel()
+	m.handleNewLog(wsLog("api", "/code/api", "one", "2026-01-01T12:00:00Z"))
+	m.handleNewLog(wsLog("web", "/code/web", "two", "2026-01-01T12:00:01Z"))
+	m.handleNewLog(wsLog("api", "/code/api", "three", "2026-01-01T12:00:02Z"))
+
+	m.openWorkspacePicker()
+	items := m.workspaces.items
+	if len(items) != 2 || items[0].name != "api" || items[1].name != "web" {
+		t.Fatalf("unexpected summaries: %+v", items)
+	}
+	if items[0].entries != 2 || !items[0].latest.Equal(time.Date(2026, 1, 1, 12, 0, 2, 0, time.UTC)) {
+		t.Errorf("api summary = %+v", items[0])
+	}
+	if view := m.workspacePickerView(); !strings.Contains(view, "/code/web") {
+		t.Errorf("picker view missing path:\n%s", view)
+	}
+
+	m.updateWorkspacePicker(tea.KeyMsg{Type: tea.KeyEnter}) // deselect api
+	if len(m.visible) != 1 || m.visible[0].(logItem).message != "two" {
+		t.Fatalf("expected only web entries, got %v", m.visible)
+	}
+	m.handleNewLog(wsLog("api", "/code/api", "four", "2026-01-01T12:00:03Z"))
+	if len(m.visible) != 1 {
+		t.Errorf("deselected workspace entry was shown: %v", m.visible)
+	}
+
+	m.updateWorkspacePicker(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if len(m.visible) != 4 {
+		t.Errorf("expected all 4 entries after selecting all, got %d", len(m.visible))
+	}
+	m.updateWorkspacePicker(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.workspaces.picking {
+		t.Error("esc should close the picker")
+	}
+}
+
+func TestInitialWorkspacesDeselectOthers(t *testing.T) {
+	m := newSplitTestModel()
+	m.setInitialWorkspaces([]string{"/code/api"})
+	m.handleNewLog(wsLog("api", "/code/api", "one", "2026-01-01T12:00:00Z"))
+	m.handleNewLog(wsLog("web", "/code/web", "two", "2026-01-01T12:00:01Z"))
+	if len(m.visible) != 1 || !m.workspaces.hidden["/code/web"] {
+		t.Fatalf("expected web to start deselected, visible=%v hidden=%v", m.visible, m.workspaces.hidden)
+	}
+
+	// Selecting it in the picker sticks for later entries.
+	m.setWorkspaceHidden("/code/web", false)
+	m.rebuildVisible()
+	m.handleNewLog(wsLog("web", "/code/web", "three", "2026-01-01T12:00:02Z"))
+	if len(m.visible) != 3 {
+		t.Errorf("expected 3 visible entries, got %d", len(m.visible))
+	}
+}
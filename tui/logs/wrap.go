@@ -0,0 +1,142 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// wrapMode selects how list rows wider than the pane are laid out. It is
+// cycled with the ToggleWrap key ("W").
+type wrapMode int
+
+const (
+	// wrapTruncate cuts each row at the pane edge with an ellipsis.
+	wrapTruncate wrapMode = iota
+	// wrapWrap soft-wraps each row over up to wrapRowLines lines.
+	wrapWrap
+	// wrapScroll keeps rows on one line and pans them with h/l.
+	wrapScroll
+)
+
+const (
+	// wrapRowLines is the row height in wrap mode. bubbles/list needs a
+	// fixed delegate height, so longer messages are cut on the last line.
+	wrapRowLines = 3
+	// hscrollStep is how many columns one h/l press pans in scroll mode.
+	hscrollStep = 8
+)
+
+func (w wrapMode) String() string {
+	switch w {
+	case wrapWrap:
+		return "wrap"
+	case wrapScroll:
+		return "scroll"
+	default:
+		return "truncate"
+	}
+}
+
+// wrapState holds the row layout mode and the horizontal pan offset used
+// by wrapScroll.
+type wrapState struct {
+	mode   wrapMode
+	offset int
+}
+
+// rowHeight is the delegate height for the current mode.
+func (w wrapState) rowHeight() int {
+	if w.mode == wrapWrap {
+		return wrapRowLines
+	}
+	return 1
+}
+
+// layout fits one rendered row into width columns according to the mode,
+// always returning exactly rowHeight lines so list pagination stays exact.
+func (w wrapState) layout(row string, width int) string {
+	if width <= 0 {
+		return row
+	}
+	switch w.mode {
+	case wrapWrap:
+		lines := strings.Split(ansi.Wrap(row, width, ""), "\n")
+		if len(lines) > wrapRowLines {
+			lines = lines[:wrapRowLines]
+			lines[wrapRowLines-1] = ansi.Truncate(lines[wrapRowLines-1], width-1, "") + "…"
+		}
+		for len(lines) < wrapRowLines {
+			lines = append(lines, "")
+		}
+		return strings.Join(lines, "\n")
+	case wrapScroll:
+		return ansi.Cut(row, w.offset, w.offset+width)
+	default:
+		return ansi.Truncate(row, width, "…")
+	}
+}
+
+// cycleWrapMode advances truncate → wrap → scroll → truncate and resets the
+// pan offset.
+func (m *Model) cycleWrapMode() tea.Cmd {
+	m.wrap.mode = (m.wrap.mode + 1) % 3
+	m.wrap.offset = 0
+	// The list caches pagination from the delegate height.
+	m.list.SetDelegate(itemDelegate{model: m})
+	switch m.wrap.mode {
+	case wrapWrap:
+		m.statusMessage = fmt.Sprintf("Wrap: long messages span up to %d lines", wrapRowLines)
+	case wrapScroll:
+		m.statusMessage = "Horizontal scroll: h/l to pan"
+	default:
+		m.statusMessage = "Truncate long messages"
+	}
+	return m.clearStatusMessageAfter(2 * time.Second)
+}
+
+// panRows moves the scroll-mode pan offset by delta columns, clamped so
+// the widest visible row still reaches the pane.
+func (m *Model) panRows(delta int) {
+	offset := m.wrap.offset + delta
+	if maxOffset := m.widestVisibleRow() - m.list.Width(); offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	m.wrap.offset = offset
+}
+
+// widestVisibleRow returns the printable width of the widest row on the
+// current list page.
+func (m *Model) widestVisibleRow() int {
+	items := m.list.VisibleItems()
+	start, end := m.list.Paginator.GetSliceBounds(len(items))
+	widest := 0
+	for _, it := range items[start:end] {
+		li, ok := it.(logItem)
+		if !ok {
+			continue
+		}
+		if w := ansi.StringWidth(m.bookmarkedTitle(li, m.rowTitle(li))); w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// wrapIndicator is the status bar segment for non-default row layouts.
+func (m *Model) wrapIndicator() string {
+	switch m.wrap.mode {
+	case wrapWrap:
+		return " [WRAP]"
+	case wrapScroll:
+		return fmt.Sprintf(" [HSCROLL +%d]", m.wrap.offset)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,118 @@
+package logs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	tuikeymap "github.com/grovetools/core/tui/keymap"
+)
+
+func newWrapTestModel(message string) *Model {
+	m := newSplitTestModel()
+	m.keys.ToggleWrap = key.NewBinding(key.WithKeys("W"))
+	m.keys.Base.Left = key.NewBinding(key.WithKeys("h"))
+	m.keys.Base.Right = key.NewBinding(key.WithKeys("l"))
+	m.sequence = tuikeymap.NewSequenceState()
+	m.list.SetDelegate(itemDelegate{model: m})
+	m.list.SetSize(60, 20)
+	m.items = []logItem{{
+		component: "api",
+		level:     "info",
+		timestamp: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		message:   message,
+	}}
+	m.rebuildVisible()
+	return m
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func renderRow(m *Model) string {
+	var buf bytes.Buffer
+	itemDelegate{model: m}.Render(&buf, m.list, 0, m.list.Items()[0])
+	return buf.String()
+}
+
+func TestWrapModeTruncatesByDefault(t *testing.T) {
+	m := newWrapTestModel(strings.Repeat("x", 200))
+
+	row := renderRow(m)
+	if strings.Contains(row, "\n") {
+		t.Fatalf("truncate mode should render one line, got %q", row)
+	}
+	if w := ansi.StringWidth(row); w > 60 {
+		t.Errorf("row is %d columns wide, want <= 60", w)
+	}
+	if !strings.Contains(row, "…") {
+		t.Errorf("truncated row should end in an ellipsis: %q", row)
+	}
+}
+
+func TestWrapModeSpansFixedLines(t *testing.T) {
+	m := newWrapTestModel(strings.Repeat("word ", 60))
+	m.cycleWrapMode()
+
+	if got := (itemDelegate{model: m}).Height(); got != wrapRowLines {
+		t.Fatalf("delegate height = %d, want %d", got, wrapRowLines)
+	}
+	lines := strings.Split(renderRow(m), "\n")
+	if len(lines) != wrapRowLines {
+		t.Fatalf("wrapped row has %d lines, want %d", len(lines), wrapRowLines)
+	}
+	for i, l := range lines {
+		if w := ansi.StringWidth(l); w > 60 {
+			t.Errorf("line %d is %d columns wide", i, w)
+		}
+	}
+
+	// Short rows are padded so every row has the same height.
+	short := newWrapTestModel("short")
+	short.cycleWrapMode()
+	if got := strings.Count(renderRow(short), "\n"); got != wrapRowLines-1 {
+		t.Errorf("short wrapped row has %d newlines, want %d", got, wrapRowLines-1)
+	}
+}
+
+func TestWrapScrollPansWithHL(t *testing.T) {
+	m := newWrapTestModel("BEGIN" + strings.Repeat("-", 100) + "END")
+	m.cycleWrapMode()
+	m.cycleWrapMode()
+	if m.wrap.mode != wrapScroll {
+		t.Fatalf("mode = %v, want scroll", m.wrap.mode)
+	}
+
+	m.Update(keyMsg("l"))
+	if m.wrap.offset != hscrollStep {
+		t.Fatalf("offset after l = %d, want %d", m.wrap.offset, hscrollStep)
+	}
+
+	// Panning stops once the end of the widest row is in view.
+	for i := 0; i < 50; i++ {
+		m.Update(keyMsg("l"))
+	}
+	if row := ansi.Strip(renderRow(m)); !strings.Contains(row, "END") {
+		t.Errorf("fully panned row should show the message end: %q", row)
+	}
+
+	for i := 0; i < 50; i++ {
+		m.Update(keyMsg("h"))
+	}
+	if m.wrap.offset != 0 {
+		t.Errorf("offset after panning back = %d, want 0", m.wrap.offset)
+	}
+
+	// Cycling back to truncate resets the pan offset.
+	m.Update(keyMsg("l"))
+	m.cycleWrapMode()
+	if m.wrap.mode != wrapTruncate || m.wrap.offset != 0 {
+		t.Errorf("after cycling: mode=%v offset=%d", m.wrap.mode, m.wrap.offset)
+	}
+}
@@ -0,0 +1,144 @@
+// Package panel defines the plugin interface through which grovetools
+// contribute panels to core's TUIs (for example a flow jobs panel inside
+// the logs viewer), and the registry hosts read them from.
+//
+// Panels are compiled in: the owning package calls Register from an init
+// function, and a binary that links it in gets the panel in every host the
+// registration names. The Panel interface is kept stable; new capabilities
+// are added as optional interfaces (such as Focusable) that hosts check
+// for, so existing panels keep compiling.
+package panel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/config"
+	"github.com/grovetools/core/pkg/daemon"
+)
+
+// Hosts a panel can be shown in, for Meta.Hosts.
+const (
+	// HostLogs is the logs viewer (`core logs --tui`, tui/logs).
+	HostLogs = "logs"
+	// HostDash is the dashboard.
+	HostDash = "dash"
+)
+
+// Panel is a view contributed to a host TUI. It follows the bubbletea
+// model lifecycle, but Update returns a Panel and sizing is explicit: the
+// host calls SetSize before the first View and whenever the space given to
+// the panel changes, and the panel must render within it.
+//
+// Like embedded TUIs, a panel must never return tea.Quit. Key messages are
+// delivered only while the panel is shown and focused; other messages go to
+// every panel the host has created, so a panel must ignore messages it does
+// not own. Window size and mouse messages are not delivered.
+type Panel interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (Panel, tea.Cmd)
+	View() string
+	SetSize(width, height int)
+}
+
+// Focusable is implemented by panels that track whether they are shown,
+// e.g. to pause polling while hidden. Hosts call Focus when the panel is
+// shown and Blur when it is hidden or replaced.
+type Focusable interface {
+	Focus() tea.Cmd
+	Blur()
+}
+
+// Meta describes a panel.
+type Meta struct {
+	// ID identifies the panel, prefixed with the owning tool (e.g.
+	// "flow.jobs").
+	ID string
+	// Title is shown in the host's chrome when the panel is open.
+	Title string
+	// Description is a one-line summary for listings.
+	Description string
+	// Hosts lists the hosts (HostLogs, HostDash, ...) that show the panel.
+	Hosts []string
+	// Order sorts panels within a host, lowest first; ties sort by ID.
+	Order int
+}
+
+// ShownIn reports whether the panel is registered for host.
+func (m Meta) ShownIn(host string) bool {
+	for _, h := range m.Hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// Env is what a host passes to the panels it creates.
+type Env struct {
+	// Context is cancelled when the host closes; background work started
+	// by the panel should stop with it.
+	Context context.Context
+	// Host is the host creating the panel (HostLogs, HostDash, ...).
+	Host string
+	// WorkspacePath is the host's workspace when the panel is created.
+	// Later changes arrive as embed.SetWorkspaceMsg.
+	WorkspacePath string
+	// DaemonClient is the host's daemon connection. May be nil.
+	DaemonClient daemon.Client
+	// Config is the host's loaded configuration. May be nil.
+	Config *config.Config
+}
+
+// Factory creates a panel for a host. It is called the first time the host
+// shows the panel, not at registration.
+type Factory func(env Env) (Panel, error)
+
+// Registration is a panel's metadata and its factory.
+type Registration struct {
+	Meta
+	New Factory
+}
+
+// registered maps a panel ID to its registration.
+var registered = map[string]Registration{}
+
+// Register registers (or overrides) a panel. Intended to be called at init
+// by the package providing the panel. It panics when the ID or factory is
+// missing, so a broken registration fails at start-up.
+func Register(r Registration) {
+	if r.ID == "" {
+		panic("panel: Register called without an ID")
+	}
+	if r.New == nil {
+		panic(fmt.Sprintf("panel: Register called without a factory for %q", r.ID))
+	}
+	registered[r.ID] = r
+}
+
+// Lookup returns the registration for a panel ID.
+func Lookup(id string) (Registration, bool) {
+	r, ok := registered[id]
+	return r, ok
+}
+
+// Registered returns the panels registered for host, sorted by Order and
+// then ID. An empty host returns every panel.
+func Registered(host string) []Registration {
+	var out []Registration
+	for _, r := range registered {
+		if host == "" || r.ShownIn(host) {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Order != out[j].Order {
+			return out[i].Order < out[j].Order
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
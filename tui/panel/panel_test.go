@@ -0,0 +1,139 @@
+package panel
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakePanel records what the stack delivers to it.
+type fakePanel struct {
+	name          string
+	width, height int
+	msgs          []tea.Msg
+	focused       bool
+	closed        bool
+}
+
+type fakeInitMsg struct{}
+
+func (p *fakePanel) Init() tea.Cmd { return func() tea.Msg { return fakeInitMsg{} } }
+func (p *fakePanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	p.msgs = append(p.msgs, msg)
+	return p, nil
+}
+func (p *fakePanel) View() string     { return p.name }
+func (p *fakePanel) SetSize(w, h int) { p.width, p.height = w, h }
+func (p *fakePanel) Focus() tea.Cmd   { p.focused = true; return nil }
+func (p *fakePanel) Blur()            { p.focused = false }
+func (p *fakePanel) Close() error     { p.closed = true; return nil }
+func (p *fakePanel) received(msg tea.Msg) bool {
+	return len(p.msgs) > 0 && reflect.DeepEqual(p.msgs[len(p.msgs)-1], msg)
+}
+
+// withRegistry runs the test against an empty registry.
+func withRegistry(t *testing.T) {
+	t.Helper()
+	saved := registered
+	registered = map[string]Registration{}
+	t.Cleanup(func() { registered = saved })
+}
+
+func TestRegistered(t *testing.T) {
+	withRegistry(t)
+	factory := func(Env) (Panel, error) { return &fakePanel{}, nil }
+	Register(Registration{Meta: Meta{ID: "flow.jobs", Hosts: []string{HostLogs, HostDash}, Order: 10}, New: factory})
+	Register(Registration{Meta: Meta{ID: "hooks.runs", Hosts: []string{HostLogs}}, New: factory})
+	Register(Registration{Meta: Meta{ID: "cx.stats", Hosts: []string{HostDash}}, New: factory})
+	Register(Registration{Meta: Meta{ID: "agent.usage", Hosts: []string{HostLogs}}, New: factory})
+
+	var ids []string
+	for _, r := range Registered(HostLogs) {
+		ids = append(ids, r.ID)
+	}
+	if got := strings.Join(ids, ","); got != "agent.usage,hooks.runs,flow.jobs" {
+		t.Errorf("logs panels = %s", got)
+	}
+	if n := len(Registered("")); n != 4 {
+		t.Errorf("all panels = %d, want 4", n)
+	}
+	if _, ok := Lookup("cx.stats"); !ok {
+		t.Error("Lookup(cx.stats) found nothing")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register without a factory should panic")
+		}
+	}()
+	Register(Registration{Meta: Meta{ID: "broken"}})
+}
+
+func TestStack(t *testing.T) {
+	withRegistry(t)
+	panels := map[string]*fakePanel{}
+	newFake := func(name string) Factory {
+		return func(Env) (Panel, error) {
+			p := &fakePanel{name: name}
+			panels[name] = p
+			return p, nil
+		}
+	}
+	Register(Registration{Meta: Meta{ID: "a", Hosts: []string{HostLogs}}, New: newFake("a")})
+	Register(Registration{Meta: Meta{ID: "b", Hosts: []string{HostLogs}}, New: newFake("b")})
+	Register(Registration{Meta: Meta{ID: "c", Hosts: []string{HostLogs}}, New: func(Env) (Panel, error) {
+		return nil, errors.New("no daemon")
+	}})
+
+	s := NewStack(Env{Host: HostLogs})
+	s.SetSize(80, 20)
+	if s.Len() != 3 || s.View() != "" {
+		t.Fatalf("new stack: len %d, view %q", s.Len(), s.View())
+	}
+
+	if cmd := s.Next(); cmd == nil {
+		t.Error("opening a panel should return its Init")
+	}
+	a := panels["a"]
+	if a == nil || a.width != 80 || a.height != 20 || !a.focused || s.View() != "a" {
+		t.Fatalf("first panel = %+v, view %q", a, s.View())
+	}
+	if _, created := panels["b"]; created {
+		t.Error("panel b was created before it was shown")
+	}
+
+	s.Next()
+	b := panels["b"]
+	if a.focused || !b.focused {
+		t.Errorf("focus after Next: a %v, b %v", a.focused, b.focused)
+	}
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+	s.Update(key)
+	if a.received(key) || !b.received(key) {
+		t.Error("a key should reach the active panel only")
+	}
+	s.Update(fakeInitMsg{})
+	if !a.received(fakeInitMsg{}) || !b.received(fakeInitMsg{}) {
+		t.Error("other messages should reach every created panel")
+	}
+	s.SetSize(100, 30)
+	if a.width != 100 || b.height != 30 {
+		t.Errorf("resize: a %dx%d, b %dx%d", a.width, a.height, b.width, b.height)
+	}
+
+	s.Next()
+	if meta, ok := s.Active(); !ok || meta.ID != "c" || !strings.Contains(s.View(), "no daemon") {
+		t.Errorf("failed panel: %v %v, view %q", meta, ok, s.View())
+	}
+	s.Next()
+	if _, ok := s.Active(); ok || s.View() != "" {
+		t.Error("Next after the last panel should show none")
+	}
+
+	if err := s.Close(); err != nil || !a.closed || !b.closed {
+		t.Errorf("Close: %v, a %v, b %v", err, a.closed, b.closed)
+	}
+}
@@ -0,0 +1,196 @@
+package panel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/tui"
+)
+
+// Stack holds the panels registered for a host and shows at most one of
+// them at a time. Each panel is created the first time it is shown and
+// kept, with its state, until the stack is closed. A nil Stack holds no
+// panels.
+type Stack struct {
+	env    Env
+	regs   []Registration
+	panels []Panel
+	errs   []error
+	active int // index into regs, or -1 when no panel is shown
+	width  int
+	height int
+}
+
+// NewStack returns a stack over the panels registered for env.Host,
+// created with env.
+func NewStack(env Env) *Stack {
+	regs := Registered(env.Host)
+	return &Stack{
+		env:    env,
+		regs:   regs,
+		panels: make([]Panel, len(regs)),
+		errs:   make([]error, len(regs)),
+		active: -1,
+	}
+}
+
+// Len is the number of panels the stack can show.
+func (s *Stack) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.regs)
+}
+
+// Active returns the metadata of the panel shown, if any.
+func (s *Stack) Active() (Meta, bool) {
+	if s == nil || s.active < 0 {
+		return Meta{}, false
+	}
+	return s.regs[s.active].Meta, true
+}
+
+// Next shows the next panel, creating it on first use; after the last
+// panel no panel is shown. The returned command is the new panel's Init,
+// or its Focus when it was created before.
+func (s *Stack) Next() tea.Cmd {
+	if s.Len() == 0 {
+		return nil
+	}
+	s.blur()
+	s.active++
+	if s.active >= len(s.regs) {
+		s.active = -1
+		return nil
+	}
+	return s.open(s.active)
+}
+
+// Hide stops showing the active panel. It keeps its state and receives
+// non-key messages as before.
+func (s *Stack) Hide() {
+	if s == nil {
+		return
+	}
+	s.blur()
+	s.active = -1
+}
+
+func (s *Stack) open(i int) tea.Cmd {
+	if p := s.panels[i]; p != nil {
+		if f, ok := p.(Focusable); ok {
+			return f.Focus()
+		}
+		return nil
+	}
+	if s.errs[i] != nil {
+		return nil
+	}
+	p, err := s.regs[i].New(s.env)
+	if err == nil && p == nil {
+		err = errors.New("factory returned no panel")
+	}
+	if err != nil {
+		s.errs[i] = err
+		return nil
+	}
+	p.SetSize(s.width, s.height)
+	s.panels[i] = p
+	cmds := []tea.Cmd{p.Init()}
+	if f, ok := p.(Focusable); ok {
+		cmds = append(cmds, f.Focus())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (s *Stack) blur() {
+	if s.active < 0 {
+		return
+	}
+	if f, ok := s.panels[s.active].(Focusable); ok {
+		f.Blur()
+	}
+}
+
+// SetSize sets the space given to panels, passing it to every panel
+// created so far when it changes.
+func (s *Stack) SetSize(width, height int) {
+	if s == nil || (width == s.width && height == s.height) {
+		return
+	}
+	s.width, s.height = width, height
+	for _, p := range s.panels {
+		if p != nil {
+			p.SetSize(width, height)
+		}
+	}
+}
+
+// Update delivers msg to the panels: a key message to the active panel
+// only, anything else to every panel created so far, so hidden panels keep
+// receiving their own ticks and results. Window size and mouse messages
+// are the host's; panels are sized through SetSize.
+func (s *Stack) Update(msg tea.Msg) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	switch msg.(type) {
+	case tea.KeyMsg:
+		if s.active < 0 || s.panels[s.active] == nil {
+			return nil
+		}
+		return s.update(s.active, msg)
+	case tea.WindowSizeMsg, tea.MouseMsg:
+		return nil
+	}
+	var cmds []tea.Cmd
+	for i, p := range s.panels {
+		if p != nil {
+			cmds = append(cmds, s.update(i, msg))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (s *Stack) update(i int, msg tea.Msg) tea.Cmd {
+	p, cmd := s.panels[i].Update(msg)
+	if p != nil {
+		s.panels[i] = p
+	}
+	return cmd
+}
+
+// View renders the active panel, or the error that kept it from being
+// created. A panic in the panel's View is shown in its place.
+func (s *Stack) View() (view string) {
+	if s == nil || s.active < 0 {
+		return ""
+	}
+	if err := s.errs[s.active]; err != nil {
+		return fmt.Sprintf("Panel %s failed to start: %v", s.regs[s.active].ID, err)
+	}
+	defer tui.RecoverView(&view)
+	return s.panels[s.active].View()
+}
+
+// Close closes every created panel that implements io.Closer and hides
+// the active one. Closed panels are created again if shown later.
+func (s *Stack) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.Hide()
+	var errs []error
+	for i, p := range s.panels {
+		if c, ok := p.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("panel %s: %w", s.regs[i].ID, err))
+			}
+		}
+		s.panels[i] = nil
+	}
+	return errors.Join(errs...)
+}
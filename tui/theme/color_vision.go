@@ -0,0 +1,111 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grovetools/core/config"
+)
+
+// ColorVision names the color vision a theme is adapted for. It is set with
+// tui.color_vision (or GROVE_COLOR_VISION) and applies on top of any theme.
+type ColorVision string
+
+const (
+	// ColorVisionNormal leaves theme colors as they are.
+	ColorVisionNormal ColorVision = "normal"
+	// ColorVisionDeuteranopia adapts colors for green-weak vision.
+	ColorVisionDeuteranopia ColorVision = "deuteranopia"
+	// ColorVisionProtanopia adapts colors for red-weak vision.
+	ColorVisionProtanopia ColorVision = "protanopia"
+)
+
+// ColorVisions returns every accepted tui.color_vision value.
+func ColorVisions() []ColorVision {
+	return []ColorVision{ColorVisionNormal, ColorVisionDeuteranopia, ColorVisionProtanopia}
+}
+
+// ParseColorVision parses a tui.color_vision value. The empty string is
+// normal vision.
+func ParseColorVision(s string) (ColorVision, error) {
+	v := ColorVision(NormalizeName(s))
+	if v == "" {
+		return ColorVisionNormal, nil
+	}
+	for _, known := range ColorVisions() {
+		if v == known {
+			return v, nil
+		}
+	}
+	return ColorVisionNormal, fmt.Errorf("unknown color vision %q: must be normal, deuteranopia or protanopia", s)
+}
+
+// redGreen reports whether v cannot rely on telling red from green.
+func (v ColorVision) redGreen() bool {
+	return v == ColorVisionDeuteranopia || v == ColorVisionProtanopia
+}
+
+// activeColorVision is the color vision applied to every theme this process
+// resolves. It is read once at init and changed with SetColorVision.
+var activeColorVision = getColorVision()
+
+// CurrentColorVision returns the color vision applied to resolved themes.
+func CurrentColorVision() ColorVision {
+	return activeColorVision
+}
+
+// SetColorVision changes the color vision applied to resolved themes and
+// rebuilds DefaultTheme and the exported colors for the current theme.
+func SetColorVision(mode string) error {
+	v, err := ParseColorVision(mode)
+	if err != nil {
+		return err
+	}
+	activeColorVision = v
+	colors := resolveThemeColors(DefaultTheme.Name)
+	applyColors(colors)
+	DefaultTheme = newThemeFromColors(colors, DefaultTheme.Name)
+	return nil
+}
+
+// getColorVision reads GROVE_COLOR_VISION, then the resolved config's
+// tui.color_vision. Unknown values fall back to normal vision.
+func getColorVision() ColorVision {
+	value := os.Getenv("GROVE_COLOR_VISION")
+	if strings.TrimSpace(value) == "" {
+		if cfg, err := config.LoadDefault(); err == nil && cfg != nil && cfg.TUI != nil {
+			value = cfg.TUI.ColorVision
+		}
+	}
+	v, _ := ParseColorVision(value)
+	return v
+}
+
+// ForColorVision returns c with the success/error pair moved off the
+// red/green axis for red-green color vision: success takes the theme's blue
+// and error its orange, a pair that differs in both hue and lightness.
+// Other colors, and every color under normal vision, are unchanged.
+func (c Colors) ForColorVision(v ColorVision) Colors {
+	if !v.redGreen() {
+		return c
+	}
+	c.Green, c.Red = c.Blue, c.Orange
+	return c
+}
+
+// ForColorVision returns a copy of p with the same remap as
+// Colors.ForColorVision applied to the semantic roles: the green and red
+// accents, git add/change/delete and error diagnostics. Git changes move to
+// yellow so they stay distinct from additions. Terminal slots are left
+// alone since programs use them for their literal hues.
+func (p Palette) ForColorVision(v ColorVision) Palette {
+	if !v.redGreen() {
+		return p
+	}
+	c := &p.Colors
+	c.Green, c.Red = c.Blue, c.Orange
+	c.Git.Add, c.Git.Change, c.Git.Delete = c.Blue, c.Yellow, c.Orange
+	c.Diagnostics.Error = c.Orange
+	return p
+}
@@ -0,0 +1,101 @@
+package theme
+
+import "testing"
+
+func TestParseColorVision(t *testing.T) {
+	cases := map[string]ColorVision{
+		"":              ColorVisionNormal,
+		"normal":        ColorVisionNormal,
+		" Deuteranopia": ColorVisionDeuteranopia,
+		"protanopia":    ColorVisionProtanopia,
+	}
+	for in, want := range cases {
+		got, err := ParseColorVision(in)
+		if err != nil || got != want {
+			t.Errorf("ParseColorVision(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseColorVision("sepia"); err == nil {
+		t.Error("ParseColorVision(sepia) = nil error, want error")
+	}
+}
+
+func TestColorsForColorVision(t *testing.T) {
+	c := goldenGruvboxColors()
+	if got := c.ForColorVision(ColorVisionNormal); got != c {
+		t.Error("normal vision should leave colors unchanged")
+	}
+	got := c.ForColorVision(ColorVisionDeuteranopia)
+	if got.Green != c.Blue || got.Red != c.Orange {
+		t.Errorf("deuteranopia success/error = %v/%v, want blue %v / orange %v", got.Green, got.Red, c.Blue, c.Orange)
+	}
+	if got.Yellow != c.Yellow || got.Cyan != c.Cyan {
+		t.Error("deuteranopia should only remap the success/error pair")
+	}
+}
+
+func TestPaletteForColorVision(t *testing.T) {
+	p := *registry.palettes["gruvbox-dark"]
+	got := p.ForColorVision(ColorVisionProtanopia)
+	c := p.Colors
+	if got.Colors.Git.Add != c.Blue || got.Colors.Git.Change != c.Yellow || got.Colors.Git.Delete != c.Orange {
+		t.Errorf("git colors = %+v", got.Colors.Git)
+	}
+	if got.Colors.Diagnostics.Error != c.Orange || got.Colors.Diagnostics.Warning != c.Diagnostics.Warning {
+		t.Errorf("diagnostics = %+v", got.Colors.Diagnostics)
+	}
+	if got.Terminal != p.Terminal {
+		t.Error("terminal slots should keep their literal hues")
+	}
+	if registry.palettes["gruvbox-dark"].Colors.Green != c.Green {
+		t.Error("ForColorVision mutated the registry palette")
+	}
+}
+
+func TestSetColorVision(t *testing.T) {
+	t.Setenv("GROVE_THEME", "")
+	restoreDefaultTheme(t)
+	prev := activeColorVision
+	t.Cleanup(func() { activeColorVision = prev })
+
+	if err := SetTheme("gruvbox"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetColorVision("deuteranopia"); err != nil {
+		t.Fatal(err)
+	}
+	want := goldenGruvboxColors()
+	if DefaultTheme.Success.GetForeground() != want.Blue || Red != want.Orange {
+		t.Errorf("success = %v, red = %v; want blue and orange", DefaultTheme.Success.GetForeground(), Red)
+	}
+	p, _ := Lookup("gruvbox-dark")
+	if p.Colors.Git.Add != p.Colors.Blue {
+		t.Errorf("Lookup should apply the active color vision, git add = %s", p.Colors.Git.Add)
+	}
+
+	if err := SetColorVision("normal"); err != nil {
+		t.Fatal(err)
+	}
+	compareColors(t, "gruvbox", want, DefaultColors)
+
+	if err := SetColorVision("sepia"); err == nil {
+		t.Error("SetColorVision(sepia) = nil, want error")
+	}
+}
+
+func TestAccessibleFamilies(t *testing.T) {
+	for _, family := range []string{"high-contrast", "deuteranopia-safe"} {
+		entry, ok := registry.families[family]
+		if !ok || entry.dark == nil || entry.light == nil {
+			t.Errorf("family %q should have dark and light variants", family)
+		}
+	}
+	// The deuteranopia-safe palettes keep success and error apart without
+	// the remap.
+	for _, name := range []string{"deuteranopia-safe-dark", "deuteranopia-safe-light"} {
+		c := registry.palettes[name].Colors
+		if c.Git.Add != c.Green || c.Git.Delete != c.Red || c.Green == c.Red {
+			t.Errorf("%s: add %s / delete %s should be its green/red roles", name, c.Git.Add, c.Git.Delete)
+		}
+	}
+}
@@ -214,23 +214,24 @@ func List() []PaletteMeta {
 	return metas
 }
 
-// Lookup returns the fully derived palette registered under name. The name
-// is normalized and theme aliases are honored; alias targets that are family
-// names resolve to the family's default dark (or only) variant.
+// Lookup returns the fully derived palette registered under name, adapted to
+// the active color vision. The name is normalized and theme aliases are
+// honored; alias targets that are family names resolve to the family's
+// default dark (or only) variant.
 func Lookup(name string) (Palette, bool) {
 	key := normalizeThemeName(name)
 	if alias, ok := themeAliases[key]; ok {
 		key = alias
 	}
 	if p, ok := registry.palettes[key]; ok {
-		return *p, true
+		return p.ForColorVision(activeColorVision), true
 	}
 	if entry, ok := registry.families[key]; ok {
 		if entry.dark != nil {
-			return *entry.dark, true
+			return entry.dark.ForColorVision(activeColorVision), true
 		}
 		if entry.light != nil {
-			return *entry.light, true
+			return entry.light.ForColorVision(activeColorVision), true
 		}
 	}
 	return Palette{}, false
@@ -280,7 +281,7 @@ func SetTheme(name string) error {
 	if !ok {
 		return fmt.Errorf("unknown theme %q", name)
 	}
-	colors := builder()
+	colors := builder().ForColorVision(activeColorVision)
 	applyColors(colors)
 	DefaultTheme = newThemeFromColors(colors, key)
 	return nil
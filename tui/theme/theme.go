@@ -431,13 +431,13 @@ func resolveThemeColors(name string) Colors {
 		key = alias
 	}
 	if builder, ok := themeRegistry[key]; ok {
-		return builder()
+		return builder().ForColorVision(activeColorVision)
 	}
 	if builder, ok := themeRegistry[DefaultThemeName]; ok {
-		return builder()
+		return builder().ForColorVision(activeColorVision)
 	}
 	// The embedded registry failed to load entirely; fall back to ANSI.
-	return fallbackColors()
+	return fallbackColors().ForColorVision(activeColorVision)
 }
 
 // NormalizeName canonicalizes a theme selection for registry lookup:
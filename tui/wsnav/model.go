@@ -31,6 +31,9 @@ func (m *Model) Init() tea.Cmd {
 	// populate enrichment data when the TUI starts.
 	filtered := m.navigator.GetFiltered()
 	for _, p := range filtered {
+		if p.Host != "" {
+			continue // no local checkout to inspect
+		}
 		cmds = append(cmds, m.fetchGitStatusCmd(p.Path))
 	}
 
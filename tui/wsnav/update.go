@@ -27,6 +27,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// When projects are refreshed, dispatch new enrichment commands.
 		// This ensures enrichment data stays fresh as the project list changes.
 		for _, p := range msg.Projects {
+			if p.Host != "" {
+				continue // no local checkout to inspect
+			}
 			cmds = append(cmds, m.fetchGitStatusCmd(p.Path))
 		}
 	}
@@ -19,6 +19,10 @@ func kindAbbreviation(kind workspace.WorkspaceKind) string {
 		return "w" // Worktree
 	case workspace.KindNonGroveRepo:
 		return "g" // Git Repo
+	case workspace.KindBareRepo:
+		return "b" // Bare Repo
+	case workspace.KindSubmodule:
+		return "s" // Submodule
 	default:
 		return "?"
 	}
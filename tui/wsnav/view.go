@@ -228,6 +228,9 @@ func (m *Model) buildTableRows(projects []*workspace.WorkspaceNode) [][]string {
 		}
 
 		path := shortenPath(p.Path)
+		if p.Host != "" {
+			path = p.Host + ":" + p.Path
+		}
 
 		rows = append(rows, []string{
 			kind,
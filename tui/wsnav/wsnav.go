@@ -24,6 +24,15 @@ func New(projects []*workspace.WorkspaceNode, refreshInterval int) *Model {
 		Projects: projectValues,
 	})
 
+	// Workspaces on ssh:// groves are kept as first discovered: the
+	// refresh rescans local groves only, without an ssh round trip per tick.
+	var remote []workspace.WorkspaceNode
+	for _, p := range projects {
+		if p.Host != "" {
+			remote = append(remote, *p)
+		}
+	}
+
 	// Configure refresh functionality
 	nav.RefreshInterval = refreshInterval
 	nav.ProjectsLoader = func() ([]workspace.WorkspaceNode, error) {
@@ -37,11 +46,11 @@ func New(projects []*workspace.WorkspaceNode, refreshInterval int) *Model {
 			return nil, err
 		}
 		// Convert pointers to values
-		projects := make([]workspace.WorkspaceNode, len(projectPtrs))
+		projects := make([]workspace.WorkspaceNode, len(projectPtrs), len(projectPtrs)+len(remote))
 		for i, p := range projectPtrs {
 			projects[i] = *p
 		}
-		return projects, nil
+		return append(projects, remote...), nil
 	}
 
 	// ENRICHMENT EXAMPLE: Initialize enrichment data structures in the constructor.
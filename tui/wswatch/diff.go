@@ -0,0 +1,58 @@
+// Package wswatch implements `core ws watch`: a live view of the discovered
+// workspace tree that highlights workspaces as they appear and disappear.
+package wswatch
+
+import (
+	"sort"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+// ChangeKind says whether a workspace appeared or disappeared.
+type ChangeKind string
+
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+)
+
+// Change is one workspace that appeared or disappeared between two scans.
+type Change struct {
+	Kind ChangeKind
+	Node *workspace.WorkspaceNode
+}
+
+// Diff compares two scans by workspace path. Removals come first, then
+// additions, each sorted by path so output is stable for scripts.
+func Diff(prev, next []*workspace.WorkspaceNode) []Change {
+	before := indexByPath(prev)
+	after := indexByPath(next)
+
+	var removed, added []Change
+	for path, n := range before {
+		if _, ok := after[path]; !ok {
+			removed = append(removed, Change{Kind: Removed, Node: n})
+		}
+	}
+	for path, n := range after {
+		if _, ok := before[path]; !ok {
+			added = append(added, Change{Kind: Added, Node: n})
+		}
+	}
+	byPath := func(cs []Change) {
+		sort.Slice(cs, func(i, j int) bool { return cs[i].Node.Path < cs[j].Node.Path })
+	}
+	byPath(removed)
+	byPath(added)
+	return append(removed, added...)
+}
+
+func indexByPath(nodes []*workspace.WorkspaceNode) map[string]*workspace.WorkspaceNode {
+	out := make(map[string]*workspace.WorkspaceNode, len(nodes))
+	for _, n := range nodes {
+		if n != nil {
+			out[n.Path] = n
+		}
+	}
+	return out
+}
@@ -0,0 +1,307 @@
+package wswatch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/core/pkg/daemon"
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/grovetools/core/tui/daemonstream"
+	"github.com/grovetools/core/tui/keymap"
+	"github.com/grovetools/core/tui/theme"
+)
+
+const (
+	// DefaultInterval is how often discovery re-runs when polling.
+	DefaultInterval = 2 * time.Second
+	// DefaultHighlight is how long additions and removals stay highlighted.
+	DefaultHighlight = 5 * time.Second
+)
+
+// Config is the constructor payload for New.
+type Config struct {
+	// Load runs workspace discovery. It is polled every Interval unless
+	// Daemon is set.
+	Load func(ctx context.Context) ([]*workspace.WorkspaceNode, error)
+	// Daemon, when set and running, replaces polling: the tree is seeded
+	// from the daemon's workspace list and updated from its state stream,
+	// and Refresh asks the daemon to re-discover.
+	Daemon daemon.Client
+	// Interval between discovery runs when polling. Defaults to DefaultInterval.
+	Interval time.Duration
+	// Highlight is how long changes stay highlighted. Defaults to DefaultHighlight.
+	Highlight time.Duration
+	// Keys provides Quit and Refresh. Defaults to keymap.NewBase().
+	Keys *keymap.Base
+}
+
+// removedNode is a workspace kept on screen, struck through, after it
+// disappeared.
+type removedNode struct {
+	node  *workspace.WorkspaceNode
+	until time.Time
+}
+
+type scanMsg struct {
+	nodes []*workspace.WorkspaceNode
+	err   error
+	at    time.Time
+}
+
+type tickMsg time.Time
+
+// Model is the bubbletea model for `core ws watch`.
+type Model struct {
+	ctx  context.Context
+	cfg  Config
+	keys keymap.Base
+
+	current  []*workspace.WorkspaceNode
+	loaded   bool
+	added    map[string]time.Time
+	removed  map[string]removedNode
+	scanning bool
+	lastScan time.Time
+	err      error
+
+	// Running totals since the watch started.
+	totalAdded, totalRemoved int
+
+	stream <-chan daemon.StateUpdate
+	width  int
+	height int
+	now    func() time.Time
+}
+
+// New returns a watch model bound to ctx.
+func New(ctx context.Context, cfg Config) *Model {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Highlight <= 0 {
+		cfg.Highlight = DefaultHighlight
+	}
+	keys := keymap.NewBase()
+	if cfg.Keys != nil {
+		keys = *cfg.Keys
+	}
+	if cfg.Daemon != nil && !cfg.Daemon.IsRunning() {
+		cfg.Daemon = nil
+	}
+	return &Model{
+		ctx:     ctx,
+		cfg:     cfg,
+		keys:    keys,
+		added:   make(map[string]time.Time),
+		removed: make(map[string]removedNode),
+		now:     time.Now,
+	}
+}
+
+// Init starts the first scan, the highlight ticker and, with a daemon, the
+// state stream.
+func (m *Model) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.scan(), tick()}
+	if m.cfg.Daemon != nil {
+		cmds = append(cmds, daemonstream.StartStreamCmd(m.cfg.Daemon))
+	}
+	return tea.Batch(cmds...)
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// scan loads the workspace list from the daemon or discovery.
+func (m *Model) scan() tea.Cmd {
+	m.scanning = true
+	ctx, load, d := m.ctx, m.cfg.Load, m.cfg.Daemon
+	return func() tea.Msg {
+		var nodes []*workspace.WorkspaceNode
+		var err error
+		if d != nil {
+			nodes, err = d.GetWorkspaces(ctx)
+		} else {
+			nodes, err = load(ctx)
+		}
+		return scanMsg{nodes: nodes, err: err, at: time.Now()}
+	}
+}
+
+// Update implements tea.Model.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Refresh):
+			if m.cfg.Daemon != nil {
+				d, ctx := m.cfg.Daemon, m.ctx
+				return m, func() tea.Msg { _ = d.Refresh(ctx); return nil }
+			}
+			if !m.scanning {
+				return m, m.scan()
+			}
+		}
+
+	case scanMsg:
+		m.scanning = false
+		m.lastScan = msg.at
+		m.err = msg.err
+		if msg.err == nil {
+			m.apply(msg.nodes, msg.at)
+		}
+
+	case tickMsg:
+		m.expire(time.Time(msg))
+		cmds := []tea.Cmd{tick()}
+		if m.cfg.Daemon == nil && !m.scanning && time.Time(msg).Sub(m.lastScan) >= m.cfg.Interval {
+			cmds = append(cmds, m.scan())
+		}
+		return m, tea.Batch(cmds...)
+
+	case daemonstream.StreamReadyMsg:
+		m.stream = msg.Ch
+		return m, daemonstream.WaitForNextMsg(m.stream)
+
+	case daemonstream.StateMsg:
+		if nodes := workspacesFromUpdate(msg.Update); nodes != nil {
+			at := m.now()
+			m.lastScan = at
+			m.apply(nodes, at)
+		}
+		return m, daemonstream.WaitForNextMsg(m.stream)
+
+	case daemonstream.StreamErrorMsg:
+		// The daemon went away: fall back to polling discovery.
+		m.stream = nil
+		m.cfg.Daemon = nil
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+	}
+	return m, nil
+}
+
+// workspacesFromUpdate returns the full workspace list carried by a state
+// update, or nil when the update is about something else.
+func workspacesFromUpdate(u daemon.StateUpdate) []*workspace.WorkspaceNode {
+	if len(u.Workspaces) == 0 {
+		return nil
+	}
+	nodes := make([]*workspace.WorkspaceNode, 0, len(u.Workspaces))
+	for _, ew := range u.Workspaces {
+		if ew != nil && ew.WorkspaceNode != nil {
+			nodes = append(nodes, ew.WorkspaceNode)
+		}
+	}
+	return nodes
+}
+
+// apply replaces the current scan and highlights what changed. The first
+// scan seeds the tree without highlighting everything as new.
+func (m *Model) apply(nodes []*workspace.WorkspaceNode, at time.Time) {
+	if !m.loaded {
+		m.current = nodes
+		m.loaded = true
+		return
+	}
+	until := at.Add(m.cfg.Highlight)
+	for _, c := range Diff(m.current, nodes) {
+		switch c.Kind {
+		case Added:
+			m.added[c.Node.Path] = until
+			delete(m.removed, c.Node.Path)
+			m.totalAdded++
+		case Removed:
+			m.removed[c.Node.Path] = removedNode{node: c.Node, until: until}
+			delete(m.added, c.Node.Path)
+			m.totalRemoved++
+		}
+	}
+	m.current = nodes
+}
+
+// expire drops highlights that have run their course.
+func (m *Model) expire(now time.Time) {
+	for path, until := range m.added {
+		if !now.Before(until) {
+			delete(m.added, path)
+		}
+	}
+	for path, r := range m.removed {
+		if !now.Before(r.until) {
+			delete(m.removed, path)
+		}
+	}
+}
+
+// View implements tea.Model.
+func (m *Model) View() string {
+	t := theme.DefaultTheme
+	var b strings.Builder
+
+	source := fmt.Sprintf("discovery every %s", m.cfg.Interval)
+	if m.cfg.Daemon != nil {
+		source = "daemon stream"
+	}
+	header := t.Bold.Render("Workspace watch") +
+		t.Muted.Render(fmt.Sprintf(" · %d workspaces · %s", len(m.current), source))
+	if !m.lastScan.IsZero() {
+		header += t.Muted.Render(" · updated " + m.lastScan.Format("15:04:05"))
+	}
+	if m.totalAdded > 0 || m.totalRemoved > 0 {
+		header += " " + t.Success.Render(fmt.Sprintf("+%d", m.totalAdded)) +
+			" " + t.Error.Render(fmt.Sprintf("-%d", m.totalRemoved))
+	}
+	b.WriteString(header + "\n")
+	if m.err != nil {
+		b.WriteString(t.Error.Render("error: "+m.err.Error()) + "\n")
+	}
+	b.WriteString("\n")
+
+	lines := m.treeLines()
+	if !m.loaded {
+		lines = []string{t.Muted.Render("Discovering workspaces…")}
+	}
+	if limit := m.height - 4; m.height > 0 && len(lines) > limit && limit > 0 {
+		more := len(lines) - limit + 1
+		lines = append(lines[:limit-1], t.Muted.Render(fmt.Sprintf("… %d more", more)))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n\n" + t.Muted.Render("ctrl+r rescan · q quit"))
+	return b.String()
+}
+
+// treeLines renders the current tree plus recently removed workspaces, in
+// tree order, with changes marked.
+func (m *Model) treeLines() []string {
+	t := theme.DefaultTheme
+	nodes := append([]*workspace.WorkspaceNode(nil), m.current...)
+	for _, r := range m.removed {
+		nodes = append(nodes, r.node)
+	}
+
+	var lines []string
+	for _, n := range workspace.BuildWorkspaceTree(nodes) {
+		name := n.TreePrefix + n.Name
+		kind := t.Muted.Render("  " + string(n.Kind))
+		if _, ok := m.added[n.Path]; ok {
+			lines = append(lines, t.Success.Render("+ "+name)+kind)
+		} else if _, ok := m.removed[n.Path]; ok {
+			lines = append(lines, t.Error.Render("- ")+t.Error.Strikethrough(true).Render(name)+kind)
+		} else {
+			lines = append(lines, "  "+name+kind)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,66 @@
+package wswatch
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/grovetools/core/pkg/workspace"
+)
+
+func node(name string) *workspace.WorkspaceNode {
+	return &workspace.WorkspaceNode{Name: name, Path: "/src/" + name, Kind: workspace.KindStandaloneProject}
+}
+
+func TestDiff(t *testing.T) {
+	prev := []*workspace.WorkspaceNode{node("a"), node("b")}
+	next := []*workspace.WorkspaceNode{node("b"), node("d"), node("c")}
+
+	got := Diff(prev, next)
+	want := []struct {
+		kind ChangeKind
+		name string
+	}{{Removed, "a"}, {Added, "c"}, {Added, "d"}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].Node.Name != w.name {
+			t.Errorf("change %d = %s %s, want %s %s", i, got[i].Kind, got[i].Node.Name, w.kind, w.name)
+		}
+	}
+}
+
+func TestModelHighlightsChanges(t *testing.T) {
+	m := New(context.Background(), Config{Highlight: 5 * time.Second})
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	m.Update(scanMsg{nodes: []*workspace.WorkspaceNode{node("a"), node("b")}, at: t0})
+	if len(m.added) != 0 {
+		t.Fatal("the first scan should not highlight anything")
+	}
+
+	m.Update(scanMsg{nodes: []*workspace.WorkspaceNode{node("b"), node("c")}, at: t0.Add(2 * time.Second)})
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "+ c") {
+		t.Errorf("added workspace should be marked:\n%s", view)
+	}
+	if !strings.Contains(view, "- a") {
+		t.Errorf("removed workspace should stay visible while highlighted:\n%s", view)
+	}
+	if !strings.Contains(view, "+1 -1") {
+		t.Errorf("header should count changes:\n%s", view)
+	}
+
+	m.Update(tickMsg(t0.Add(8 * time.Second)))
+	view = ansi.Strip(m.View())
+	if strings.Contains(view, "- a") || strings.Contains(view, "+ c") {
+		t.Errorf("highlights should expire:\n%s", view)
+	}
+	if !strings.Contains(view, "  c") {
+		t.Errorf("added workspace should remain in the tree:\n%s", view)
+	}
+}
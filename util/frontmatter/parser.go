@@ -5,6 +5,7 @@ package frontmatter
 import (
 	"bufio"
 	"io"
+	"slices"
 	"strings"
 	"time"
 )
@@ -25,6 +26,9 @@ type DocMetadata struct {
 	Priority  string    `json:"priority,omitempty"` // p0 (most critical) .. p3, empty = none
 	Created   time.Time `json:"created,omitempty"`
 	Modified  time.Time `json:"modified,omitempty"`
+	// Keys lists the top-level keys set in the frontmatter, in file order.
+	// It is empty when the file has no frontmatter block.
+	Keys []string `json:"-"`
 }
 
 // Parse extracts metadata from YAML frontmatter in a markdown reader.
@@ -89,6 +93,9 @@ func Parse(r io.Reader) (DocMetadata, error) {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 		value = strings.Trim(value, `"'`)
+		if line == trimmed && (value != "" || key == "tags" || key == "channels") && !slices.Contains(meta.Keys, key) {
+			meta.Keys = append(meta.Keys, key)
+		}
 
 		switch key {
 		case "id":
@@ -146,6 +153,31 @@ func ParseString(content string) (DocMetadata, error) {
 	return Parse(strings.NewReader(content))
 }
 
+// Split separates a leading "---" delimited frontmatter block from the rest
+// of content. block runs from the opening delimiter line through the closing
+// one, including its line break; it is empty, and body is content, when
+// content does not start with a complete block.
+func Split(content string) (block, body string) {
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		if rest, ok = strings.CutPrefix(content, "---\r\n"); !ok {
+			return "", content
+		}
+	}
+	for rest != "" {
+		line, next, found := strings.Cut(rest, "\n")
+		if strings.TrimSuffix(line, "\r") == "---" {
+			end := len(content) - len(next)
+			return content[:end], content[end:]
+		}
+		if !found {
+			break
+		}
+		rest = next
+	}
+	return "", content
+}
+
 // parseFlowArray parses a YAML flow array like "[a, b, c]" into a string slice.
 func parseFlowArray(s string) []string {
 	s = strings.TrimPrefix(s, "[")
@@ -55,3 +55,25 @@ plan_ref: plans/my-feature
 		t.Errorf("PlanJob = %q, want empty", meta.PlanJob)
 	}
 }
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name, content, block, body string
+	}{
+		{"no frontmatter", "# Title\n", "", "# Title\n"},
+		{"block", "---\ntitle: x\n---\n# Body\n", "---\ntitle: x\n---\n", "# Body\n"},
+		{"block at end", "---\ntitle: x\n---", "---\ntitle: x\n---", ""},
+		{"crlf", "---\r\ntitle: x\r\n---\r\nbody", "---\r\ntitle: x\r\n---\r\n", "body"},
+		{"empty block", "---\n---\nbody", "---\n---\n", "body"},
+		{"unterminated", "---\ntitle: x\nbody\n", "", "---\ntitle: x\nbody\n"},
+		{"longer rule is not a delimiter", "---\ntitle: x\n----\nbody", "", "---\ntitle: x\n----\nbody"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, body := Split(tt.content)
+			if block != tt.block || body != tt.body {
+				t.Errorf("Split(%q) = %q, %q; want %q, %q", tt.content, block, body, tt.block, tt.body)
+			}
+		})
+	}
+}
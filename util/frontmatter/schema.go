@@ -0,0 +1,172 @@
+package frontmatter
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+//go:generate sh -c "cd ../.. && go run ./tools/frontmatter-schema-generator/"
+
+// schemaData is frontmatter.schema.json, generated from JobFrontmatter and
+// NoteFrontmatter by tools/frontmatter-schema-generator.
+//
+//go:embed frontmatter.schema.json
+var schemaData []byte
+
+// Schema returns the JSON Schema for job and note frontmatter, for editors
+// and tools outside Go. Callers must not modify the returned slice.
+func Schema() []byte {
+	return schemaData
+}
+
+// Kind is what a markdown file's frontmatter describes.
+type Kind string
+
+const (
+	// KindJob is a flow job file: its type is one of JobTypes.
+	KindJob Kind = "job"
+	// KindNote is a notebook note: a file whose frontmatter has no type.
+	KindNote Kind = "note"
+)
+
+// JobTypes are the type values of flow job files.
+var JobTypes = []string{"oneshot", "chat", "interactive_agent", "headless_agent", "agent", "shell"}
+
+// Statuses are the status values jobs and notes may carry.
+var Statuses = []string{"pending", "todo", "running", "pending_user", "needs_review", "hold", "blocked", "completed", "failed", "abandoned"}
+
+// Priorities are the priority values, most critical first.
+var Priorities = []string{"p0", "p1", "p2", "p3"}
+
+// JobFrontmatter documents the frontmatter of a flow job file. It is not
+// decoded into; Parse fills DocMetadata. It exists so the schema is
+// generated from Go like the repo's other schemas.
+type JobFrontmatter struct {
+	ID        string    `yaml:"id" jsonschema:"required,description=Stable job identifier"`
+	Title     string    `yaml:"title" jsonschema:"required,description=Job title"`
+	Type      string    `yaml:"type" jsonschema:"required,description=Job type"`
+	Status    string    `yaml:"status" jsonschema:"required,description=Job status"`
+	Worktree  string    `yaml:"worktree,omitempty" jsonschema:"description=Worktree the job runs in"`
+	StartTime time.Time `yaml:"start_time,omitempty" jsonschema:"description=When the job started (RFC 3339)"`
+	UpdatedAt time.Time `yaml:"updated_at,omitempty" jsonschema:"description=When the job was last updated (RFC 3339)"`
+	Priority  string    `yaml:"priority,omitempty" jsonschema:"description=Priority from p0 (most critical) to p3"`
+	Tags      []string  `yaml:"tags,omitempty" jsonschema:"description=Free-form tags"`
+	Channels  []string  `yaml:"channels,omitempty" jsonschema:"description=Notification channels"`
+}
+
+// NoteFrontmatter documents the frontmatter of a notebook note; see
+// JobFrontmatter.
+type NoteFrontmatter struct {
+	ID       string   `yaml:"id,omitempty" jsonschema:"description=Note identifier (defaults to the title)"`
+	Title    string   `yaml:"title" jsonschema:"required,description=Note title"`
+	Status   string   `yaml:"status,omitempty" jsonschema:"description=Note status"`
+	Priority string   `yaml:"priority,omitempty" jsonschema:"description=Priority from p0 (most critical) to p3"`
+	Tags     []string `yaml:"tags,omitempty" jsonschema:"description=Free-form tags"`
+	PlanRef  string   `yaml:"plan_ref,omitempty" jsonschema:"description=Plan the note was promoted to"`
+	PlanJob  string   `yaml:"plan_job,omitempty" jsonschema:"description=Filename of the job the note was promoted to"`
+	Created  string   `yaml:"created,omitempty" jsonschema:"description=Creation time (RFC 3339 or YYYY-MM-DD[ HH:MM:SS])"`
+	Modified string   `yaml:"modified,omitempty" jsonschema:"description=Last modification time (same formats as created)"`
+}
+
+// Kind reports whether the file is a job or a note. A type outside
+// JobTypes still reads as a job, which Validate rejects.
+func (m DocMetadata) Kind() Kind {
+	if m.Has("type") {
+		return KindJob
+	}
+	return KindNote
+}
+
+// IsJob reports whether the file is a flow job with a known type.
+func (m DocMetadata) IsJob() bool {
+	return m.Has("type") && slices.Contains(JobTypes, m.Type)
+}
+
+// Has reports whether key was set in the frontmatter.
+func (m DocMetadata) Has(key string) bool {
+	return slices.Contains(m.Keys, key)
+}
+
+// PriorityRank returns 0 for p0 through 3 for p3, and false when no
+// valid priority is set.
+func (m DocMetadata) PriorityRank() (int, bool) {
+	i := slices.Index(Priorities, m.Priority)
+	return i, i >= 0
+}
+
+// Issue is one problem Validate found in a file's frontmatter.
+type Issue struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	if i.Field == "" {
+		return i.Message
+	}
+	return i.Field + ": " + i.Message
+}
+
+// ValidationError lists every Issue found in one file's frontmatter.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		parts[i] = issue.String()
+	}
+	return "invalid frontmatter: " + strings.Join(parts, "; ")
+}
+
+// Validate checks parsed metadata against the frontmatter schema: the
+// required fields of its Kind, the allowed type, status and priority
+// values, and timestamps that failed to parse. It returns nil or a
+// *ValidationError.
+func Validate(meta DocMetadata) error {
+	if len(meta.Keys) == 0 {
+		return &ValidationError{Issues: []Issue{{Message: "no frontmatter block"}}}
+	}
+
+	var issues []Issue
+	required := []string{"title"}
+	if meta.Kind() == KindJob {
+		required = []string{"id", "title", "type", "status"}
+		if !slices.Contains(JobTypes, meta.Type) {
+			issues = append(issues, Issue{Field: "type", Message: fmt.Sprintf("unknown job type %q (expected one of %s)", meta.Type, strings.Join(JobTypes, ", "))})
+		}
+	}
+	for _, key := range required {
+		if !meta.Has(key) {
+			issues = append(issues, Issue{Field: key, Message: "required field is missing"})
+		}
+	}
+	if meta.Has("status") && !slices.Contains(Statuses, meta.Status) {
+		issues = append(issues, Issue{Field: "status", Message: fmt.Sprintf("unknown status %q (expected one of %s)", meta.Status, strings.Join(Statuses, ", "))})
+	}
+	if meta.Has("priority") && meta.Priority != "" {
+		if _, ok := meta.PriorityRank(); !ok {
+			issues = append(issues, Issue{Field: "priority", Message: fmt.Sprintf("unknown priority %q (expected one of %s)", meta.Priority, strings.Join(Priorities, ", "))})
+		}
+	}
+	for key, t := range map[string]time.Time{
+		"start_time": meta.StartedAt,
+		"updated_at": meta.UpdatedAt,
+		"created":    meta.Created,
+		"modified":   meta.Modified,
+	} {
+		if meta.Has(key) && t.IsZero() {
+			issues = append(issues, Issue{Field: key, Message: "not a valid timestamp"})
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(issues, func(a, b Issue) int { return strings.Compare(a.Field, b.Field) })
+	return &ValidationError{Issues: issues}
+}
@@ -0,0 +1,128 @@
+package frontmatter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		kind   Kind
+		fields []string // fields with issues; nil means valid
+	}{
+		{
+			name: "valid job",
+			doc:  "---\nid: job-1\ntitle: Build it\ntype: oneshot\nstatus: running\nstart_time: 2026-01-02T10:00:00Z\n---\n",
+			kind: KindJob,
+		},
+		{
+			name: "valid note",
+			doc:  "---\ntitle: Idea\nstatus: todo\npriority: p2\ncreated: 2026-01-02\ntags:\n  - a\n---\n",
+			kind: KindNote,
+		},
+		{
+			name:   "job missing fields and bad status",
+			doc:    "---\ntitle: Build it\ntype: oneshot\nstatus: done\n---\n",
+			kind:   KindJob,
+			fields: []string{"id", "status"},
+		},
+		{
+			name:   "unknown type",
+			doc:    "---\nid: x\ntitle: X\ntype: essay\nstatus: pending\n---\n",
+			kind:   KindJob,
+			fields: []string{"type"},
+		},
+		{
+			name:   "note with bad priority and timestamp",
+			doc:    "---\ntitle: Idea\npriority: urgent\nmodified: last tuesday\n---\n",
+			kind:   KindNote,
+			fields: []string{"modified", "priority"},
+		},
+		{
+			name:   "no frontmatter",
+			doc:    "# Just a heading\n",
+			kind:   KindNote,
+			fields: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := ParseString(tt.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := meta.Kind(); got != tt.kind {
+				t.Errorf("Kind() = %s, want %s", got, tt.kind)
+			}
+			err = Validate(meta)
+			if tt.fields == nil {
+				if err != nil {
+					t.Errorf("Validate: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate = %v, want a *ValidationError", err)
+			}
+			var fields []string
+			for _, issue := range verr.Issues {
+				fields = append(fields, issue.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("issues on %v, want %v (%v)", fields, tt.fields, err)
+			}
+		})
+	}
+}
+
+func TestParseRecordsTopLevelKeys(t *testing.T) {
+	meta, err := ParseString("---\ntitle: T\nworktree:\nextra:\n  nested: 1\ntags: [a]\n---\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"title", "tags"}; !reflect.DeepEqual(meta.Keys, want) {
+		t.Errorf("Keys = %v, want %v", meta.Keys, want)
+	}
+	if meta.IsJob() {
+		t.Error("a file without type must not read as a job")
+	}
+}
+
+// TestSchemaMatchesValidate pins the generated schema to the value lists
+// Validate uses; regenerate it with `go generate ./util/frontmatter/`.
+func TestSchemaMatchesValidate(t *testing.T) {
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+			Required []string `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	job, note := schema.Defs["job"], schema.Defs["note"]
+	checks := map[string][2][]string{
+		"job type":      {job.Properties["type"].Enum, JobTypes},
+		"job status":    {job.Properties["status"].Enum, Statuses},
+		"note status":   {note.Properties["status"].Enum, Statuses},
+		"note priority": {note.Properties["priority"].Enum, Priorities},
+		"job required":  {job.Required, []string{"id", "title", "type", "status"}},
+		"note required": {note.Required, []string{"title"}},
+	}
+	for name, c := range checks {
+		if !reflect.DeepEqual(c[0], c[1]) {
+			t.Errorf("%s: schema has %v, Validate uses %v", name, c[0], c[1])
+		}
+	}
+	if !strings.Contains(string(Schema()), `"$ref": "#/$defs/job"`) {
+		t.Error("schema does not dispatch to the job definition")
+	}
+}
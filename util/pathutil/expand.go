@@ -7,19 +7,19 @@ import (
 	"strings"
 
 	"github.com/grovetools/core/git"
+	"github.com/grovetools/core/pkg/paths"
 )
 
 // Expand expands home directory (~), environment variables, and git variables in a path.
 // It returns an absolute path.
 func Expand(path string) (string, error) {
 	// 1. Expand home directory character '~'.
-	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
-		if err != nil {
+	if strings.HasPrefix(path, "~") {
+		if _, err := os.UserHomeDir(); err != nil {
 			return "", fmt.Errorf("could not get user home directory: %w", err)
 		}
-		path = filepath.Join(home, path[2:])
 	}
+	path = paths.ExpandHome(path)
 
 	// 2. Expand environment variables.
 	path = os.ExpandEnv(path)
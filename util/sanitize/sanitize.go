@@ -179,6 +179,12 @@ func ForFilename(s string) string {
 	return s
 }
 
+// ForShell single-quotes a string for use as one word on a POSIX shell
+// command line
+func ForShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // UTF8 takes a byte slice and returns a string with invalid UTF-8 sequences replaced.
 // Invalid sequences are replaced with the Unicode replacement character (�).
 // This is useful when reading files that may contain mixed encodings or corrupted data.
@@ -202,3 +202,21 @@ func BenchmarkUTF8Invalid(b *testing.B) {
 		_ = UTF8(data)
 	}
 }
+
+func TestForShell(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "''"},
+		{"plain", "'plain'"},
+		{"with space", "'with space'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+	}
+	for _, tt := range tests {
+		if got := ForShell(tt.input); got != tt.expected {
+			t.Errorf("ForShell(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}